@@ -23,6 +23,7 @@ AGENT_CORECHECKS = [
     "oom_kill",
     "oracle",
     "oracle-dbm",
+    "process_match",
     "sbom",
     "systemd",
     "tcp_queue_length",
@@ -82,6 +82,7 @@ import (
 	processAgent "github.com/DataDog/datadog-agent/comp/process/agent/def"
 	publishermetadatacachefx "github.com/DataDog/datadog-agent/comp/publishermetadatacache/fx"
 	rcclient "github.com/DataDog/datadog-agent/comp/remote-config/rcclient/def"
+	sbomstore "github.com/DataDog/datadog-agent/comp/sbomstore/def"
 	snmpscanmanager "github.com/DataDog/datadog-agent/comp/snmpscanmanager/def"
 	softwareinventoryfx "github.com/DataDog/datadog-agent/comp/softwareinventory/fx"
 	"github.com/DataDog/datadog-agent/pkg/serializer"
@@ -149,6 +150,7 @@ func StartAgentWithDefaults(ctxChan <-chan context.Context) (<-chan error, error
 			hostname hostnameinterface.Component,
 			ipc ipc.Component,
 			snmpScanManager snmpscanmanager.Component,
+			sbomStore sbomstore.Component,
 			traceroute traceroute.Component,
 			healthplatformComp healthplatformdef.Component,
 			ncmComp option.Option[networkconfigmanagement.Component],
@@ -179,6 +181,7 @@ func StartAgentWithDefaults(ctxChan <-chan context.Context) (<-chan error, error
 				hostname,
 				ipc,
 				snmpScanManager,
+				sbomStore,
 				traceroute,
 				healthplatformComp,
 				ncmComp,
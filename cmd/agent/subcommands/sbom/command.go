@@ -0,0 +1,130 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+// Package sbom implements 'agent sbom'.
+package sbom
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/fx"
+
+	"github.com/DataDog/datadog-agent/cmd/agent/command"
+	"github.com/DataDog/datadog-agent/comp/core"
+	"github.com/DataDog/datadog-agent/comp/core/config"
+	ipc "github.com/DataDog/datadog-agent/comp/core/ipc/def"
+	ipcfx "github.com/DataDog/datadog-agent/comp/core/ipc/fx"
+	ipchttp "github.com/DataDog/datadog-agent/comp/core/ipc/httphelpers"
+	log "github.com/DataDog/datadog-agent/comp/core/log/def"
+	"github.com/DataDog/datadog-agent/pkg/sbom/export"
+	"github.com/DataDog/datadog-agent/pkg/util/fxutil"
+)
+
+// cliParams are the command-line arguments for this subcommand
+type cliParams struct {
+	*command.GlobalParams
+
+	args       []string
+	format     string
+	outputFile string
+}
+
+// Commands returns a slice of subcommands for the 'agent' command.
+func Commands(globalParams *command.GlobalParams) []*cobra.Command {
+	cliParams := &cliParams{
+		GlobalParams: globalParams,
+	}
+
+	sbomCommand := &cobra.Command{
+		Use:   "sbom",
+		Short: "Interact with the SBOMs collected by a running agent",
+		Long:  ``,
+	}
+
+	exportCommand := &cobra.Command{
+		Use:   "export <host|image> [image]",
+		Short: "Export the latest SBOM of the host or of a container image as a CycloneDX or SPDX document",
+		Long: `Export the latest SBOM collected by the running agent for the host or for a
+container image. The image is referenced by its ID, one of its tags or one of
+its repo digests.`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			cliParams.args = args
+			return fxutil.OneShot(exportSBOM,
+				fx.Supply(cliParams),
+				fx.Supply(core.BundleParams{
+					ConfigParams: config.NewAgentParams(globalParams.ConfFilePath, config.WithExtraConfFiles(globalParams.ExtraConfFilePath), config.WithFleetPoliciesDirPath(globalParams.FleetPoliciesDirPath)),
+					LogParams:    log.ForOneShot(command.LoggerName, "off", false)}), // the document may be written to stdout
+				core.Bundle(),
+				ipcfx.ModuleReadOnly(),
+			)
+		},
+	}
+	exportCommand.Flags().StringVarP(&cliParams.format, "format", "f", string(export.FormatCycloneDXJSON), fmt.Sprintf("format of the document, one of %v", export.Formats))
+	exportCommand.Flags().StringVarP(&cliParams.outputFile, "output", "o", "", "write the document to this file instead of stdout")
+
+	sbomCommand.AddCommand(exportCommand)
+
+	return []*cobra.Command{sbomCommand}
+}
+
+func exportSBOM(_ log.Component, params *cliParams, client ipc.HTTPClient) error {
+	values, err := exportQuery(params)
+	if err != nil {
+		return err
+	}
+
+	endpoint, err := client.NewIPCEndpoint("/agent/sbom/export")
+	if err != nil {
+		return err
+	}
+
+	doc, err := endpoint.DoGet(ipchttp.WithValues(values), ipchttp.WithCloseConnection)
+	if err != nil {
+		return fmt.Errorf("failed to export the SBOM: %w", err)
+	}
+
+	if params.outputFile == "" {
+		_, err = os.Stdout.Write(doc)
+		return err
+	}
+
+	if err := os.WriteFile(params.outputFile, doc, 0644); err != nil {
+		return fmt.Errorf("failed to write the SBOM to %s: %w", params.outputFile, err)
+	}
+	fmt.Fprintf(os.Stderr, "SBOM written to %s\n", params.outputFile)
+	return nil
+}
+
+// exportQuery returns the query parameters of the export endpoint
+func exportQuery(params *cliParams) (url.Values, error) {
+	if _, err := export.ParseFormat(params.format); err != nil {
+		return nil, err
+	}
+
+	values := url.Values{}
+	values.Set("format", params.format)
+
+	switch target := params.args[0]; target {
+	case export.TargetHost:
+		if len(params.args) > 1 {
+			return nil, errors.New("the host target doesn't take an image argument")
+		}
+	case export.TargetImage:
+		if len(params.args) < 2 {
+			return nil, errors.New("missing image argument")
+		}
+		values.Set("id", params.args[1])
+	default:
+		return nil, fmt.Errorf("unknown target %q, expected %s or %s", target, export.TargetHost, export.TargetImage)
+	}
+	values.Set("target", params.args[0])
+
+	return values, nil
+}
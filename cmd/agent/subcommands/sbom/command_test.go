@@ -0,0 +1,49 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/cmd/agent/command"
+	"github.com/DataDog/datadog-agent/comp/core"
+	"github.com/DataDog/datadog-agent/pkg/util/fxutil"
+)
+
+func TestCommand(t *testing.T) {
+	fxutil.TestOneShotSubcommand(t,
+		Commands(&command.GlobalParams{}),
+		[]string{"sbom", "export", "image", "nginx:1.25", "--format", "spdx-json", "-o", "nginx.spdx.json"},
+		exportSBOM,
+		func(cliParams *cliParams, _ core.BundleParams) {
+			require.Equal(t, []string{"image", "nginx:1.25"}, cliParams.args)
+			require.Equal(t, "spdx-json", cliParams.format)
+			require.Equal(t, "nginx.spdx.json", cliParams.outputFile)
+		})
+}
+
+func TestExportQuery(t *testing.T) {
+	values, err := exportQuery(&cliParams{args: []string{"host"}, format: "cyclonedx-json"})
+	require.NoError(t, err)
+	assert.Equal(t, "format=cyclonedx-json&target=host", values.Encode())
+
+	values, err = exportQuery(&cliParams{args: []string{"image", "sha256:1234"}, format: "spdx-json"})
+	require.NoError(t, err)
+	assert.Equal(t, "format=spdx-json&id=sha256%3A1234&target=image", values.Encode())
+
+	for _, params := range []*cliParams{
+		{args: []string{"host"}, format: "xml"},
+		{args: []string{"host", "sha256:1234"}, format: "cyclonedx-json"},
+		{args: []string{"image"}, format: "cyclonedx-json"},
+		{args: []string{"container", "1234"}, format: "cyclonedx-json"},
+	} {
+		_, err := exportQuery(params)
+		assert.Error(t, err, params.args)
+	}
+}
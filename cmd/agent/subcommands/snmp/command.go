@@ -42,6 +42,7 @@ import (
 	snmpscanfx "github.com/DataDog/datadog-agent/comp/snmpscan/fx"
 	"github.com/DataDog/datadog-agent/pkg/networkdevice/metadata"
 	"github.com/DataDog/datadog-agent/pkg/snmp/analyzer"
+	"github.com/DataDog/datadog-agent/pkg/snmp/profilegen"
 	"github.com/DataDog/datadog-agent/pkg/snmp/snmpparse"
 	"github.com/DataDog/datadog-agent/pkg/util/fxutil"
 )
@@ -65,6 +66,9 @@ type argsType []string
 // analyzeFlag is the type for the --analyze flag so it can be injected via fx.
 type analyzeFlag bool
 
+// profileOutputFlag is the type for the --output flag of generate-profile so it can be injected via fx.
+type profileOutputFlag string
+
 // configErr wraps any error caused by invalid configuration.
 // If the main script returns a configErr it will print the usage string along
 // with the error message.
@@ -166,6 +170,72 @@ With --analyze, the walk is matched against SNMP device profiles and a summary r
 
 	snmpCmd.AddCommand(snmpWalkCmd)
 
+	var profileOutput string
+	snmpGenerateProfileCmd := &cobra.Command{
+		Use:   "generate-profile <IP Address>[:Port]",
+		Short: "Generate a draft SNMP profile for a device.",
+		Long: `Walk the SNMP tree for a device and generate a draft profile from the standard MIB tables (IF-MIB, ENTITY-SENSOR-MIB, HOST-RESOURCES-MIB) and the tables of the vendor enterprise subtree found in the walk.
+Metric types are inferred from the SNMP types of the values and table indexes are turned into tags. A coverage report lists the OIDs of the walk the profile doesn't collect.
+Flags that aren't specified will be pulled from the agent SNMP config if possible.
+
+The profile is printed to stdout and the report to stderr, unless --output is set. The generated profile is a draft: review it, and rename the vendor symbols using the vendor MIBs, before copying it to the snmp.d/profiles directory.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			err := fxutil.OneShot(generateProfile,
+				fx.Supply(connParams),
+				fx.Provide(func() argsType { return args }),
+				fx.Provide(func() profileOutputFlag { return profileOutputFlag(profileOutput) }),
+				fx.Supply(core.BundleParams{
+					ConfigParams: config.NewAgentParams(globalParams.ConfFilePath, config.WithExtraConfFiles(globalParams.ExtraConfFilePath), config.WithFleetPoliciesDirPath(globalParams.FleetPoliciesDirPath)),
+					LogParams:    log.ForOneShot(command.LoggerName, "off", true)}),
+				core.Bundle(core.WithSecrets()),
+				hostnameimpl.Module(),
+				snmpscanfx.Module(),
+				orchestratorfx.Module(orchestrator.NewDisabledParams()),
+				eventplatformfx.Module(eventplatform.NewDefaultParams()),
+				nooptagger.Module(),
+				eventplatformreceiverimpl.Module(),
+				haagentfx.Module(),
+				metricscompression.Module(),
+				logscompression.Module(),
+				ipcfx.ModuleReadOnly(),
+			)
+			if err != nil {
+				var ue configErr
+				if errors.As(err, &ue) {
+					fmt.Println("Usage:", cmd.UseLine())
+				}
+				return err
+			}
+			return nil
+		},
+	}
+	snmpGenerateProfileCmd.Flags().VarP(Flag(&snmpparse.VersionOpts, &connParams.Version), "snmp-version", "v",
+		fmt.Sprintf("Specify SNMP version to use (%s)", snmpparse.VersionOpts.OptsStr()))
+
+	// snmp v1 or v2c specific
+	snmpGenerateProfileCmd.Flags().StringVarP(&connParams.CommunityString, "community-string", "C", "", "Set the community string")
+
+	// snmp v3 specific
+	snmpGenerateProfileCmd.Flags().VarP(Flag(&snmpparse.AuthOpts, &connParams.AuthProtocol), "auth-protocol", "a",
+		fmt.Sprintf("Set authentication protocol (%s)", snmpparse.AuthOpts.OptsStr()))
+	snmpGenerateProfileCmd.Flags().StringVarP(&connParams.AuthKey, "auth-key", "A", "", "Set authentication protocol pass phrase")
+	snmpGenerateProfileCmd.Flags().VarP(Flag(&snmpparse.LevelOpts, &connParams.SecurityLevel), "security-level", "l",
+		fmt.Sprintf("Set security level (%s)", snmpparse.LevelOpts.OptsStr()))
+	snmpGenerateProfileCmd.Flags().StringVarP(&connParams.Context, "context", "N", "", "Set context name")
+	snmpGenerateProfileCmd.Flags().StringVarP(&connParams.Username, "user-name", "u", "", "Set security name")
+	snmpGenerateProfileCmd.Flags().VarP(Flag(&snmpparse.PrivOpts, &connParams.PrivProtocol), "priv-protocol", "x",
+		fmt.Sprintf("Set privacy protocol (%s)", snmpparse.PrivOpts.OptsStr()))
+	snmpGenerateProfileCmd.Flags().StringVarP(&connParams.PrivKey, "priv-key", "X", "", "Set privacy protocol pass phrase")
+
+	// general communication options
+	snmpGenerateProfileCmd.Flags().IntVarP(&connParams.Retries, "retries", "r", defaultRetries, "Set the number of retries")
+	snmpGenerateProfileCmd.Flags().IntVarP(&connParams.Timeout, "timeout", "t", defaultTimeout, "Set the request timeout (in seconds)")
+	snmpGenerateProfileCmd.Flags().StringVarP(&profileOutput, "output", "o", "",
+		"Write the profile to this file and print the coverage report to stdout")
+
+	snmpCmd.AddCommand(snmpGenerateProfileCmd)
+
 	logLevelDefaultOff := command.LogLevelDefaultOff{}
 
 	// This command does nothing until the backend supports it, so it isn't visible yet.
@@ -340,20 +410,8 @@ func scanDevice(connParams *snmpparse.SNMPConfig, args argsType, scanOpts *scanF
 	return nil
 }
 
-// snmpWalk prints every SNMP value, in the style of the unix snmpwalk command.
-func snmpWalk(connParams *snmpparse.SNMPConfig, args argsType, analyze analyzeFlag, snmpScanner snmpscan.Component, conf config.Component, logger log.Component, client ipc.HTTPClient) error {
-	// Parse args
-	if len(args) == 0 {
-		return confErrf("missing argument: IP address")
-	}
-	deviceAddr := args[0]
-	oid := ""
-	if len(args) > 1 {
-		oid = args[1]
-	}
-	if len(args) > 2 {
-		return confErrf("the number of arguments must be between 1 and 2. %d arguments were given.", len(args))
-	}
+// connectToDevice connects to the device at deviceAddr, using the agent SNMP config for the flags that aren't specified.
+func connectToDevice(connParams *snmpparse.SNMPConfig, deviceAddr string, conf config.Component, logger log.Component, client ipc.HTTPClient) (*gosnmp.GoSNMP, error) {
 	// Parse port from IP address
 	connParams.IPAddress, connParams.Port, _ = maybeSplitIP(deviceAddr)
 	agentParams, _, agentErr := snmpparse.GetParamsFromAgent(connParams.IPAddress, conf, client)
@@ -368,7 +426,7 @@ func snmpWalk(connParams *snmpparse.SNMPConfig, args argsType, analyze analyzeFl
 	snmp, err := snmpparse.NewSNMP(connParams, logger)
 	if err != nil {
 		// newSNMP only returns config errors, so any problem is a usage error
-		return configErr{err}
+		return nil, configErr{err}
 	}
 
 	// Print progress to stderr so it doesn't pollute walk output when piped
@@ -385,7 +443,28 @@ func snmpWalk(connParams *snmpparse.SNMPConfig, args argsType, analyze analyzeFl
 	}
 
 	if err := snmp.Connect(); err != nil {
-		return fmt.Errorf("unable to connect to SNMP agent on %s:%d: %w", snmp.LocalAddr, snmp.Port, err)
+		return nil, fmt.Errorf("unable to connect to SNMP agent on %s:%d: %w", snmp.LocalAddr, snmp.Port, err)
+	}
+	return snmp, nil
+}
+
+// snmpWalk prints every SNMP value, in the style of the unix snmpwalk command.
+func snmpWalk(connParams *snmpparse.SNMPConfig, args argsType, analyze analyzeFlag, snmpScanner snmpscan.Component, conf config.Component, logger log.Component, client ipc.HTTPClient) error {
+	// Parse args
+	if len(args) == 0 {
+		return confErrf("missing argument: IP address")
+	}
+	deviceAddr := args[0]
+	oid := ""
+	if len(args) > 1 {
+		oid = args[1]
+	}
+	if len(args) > 2 {
+		return confErrf("the number of arguments must be between 1 and 2. %d arguments were given.", len(args))
+	}
+	snmp, err := connectToDevice(connParams, deviceAddr, conf, logger, client)
+	if err != nil {
+		return err
 	}
 	defer func() { _ = snmp.Conn.Close() }()
 
@@ -428,6 +507,50 @@ func snmpWalk(connParams *snmpparse.SNMPConfig, args argsType, analyze analyzeFl
 	return nil
 }
 
+// generateProfile walks a device and prints a draft profile with its coverage report.
+func generateProfile(connParams *snmpparse.SNMPConfig, args argsType, output profileOutputFlag, snmpScanner snmpscan.Component, conf config.Component, logger log.Component, client ipc.HTTPClient) error {
+	// Parse args
+	if len(args) == 0 {
+		return confErrf("missing argument: IP address")
+	}
+	if len(args) > 1 {
+		return confErrf("unexpected extra arguments; only one argument expected.")
+	}
+	snmp, err := connectToDevice(connParams, args[0], conf, logger, client)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = snmp.Conn.Close() }()
+
+	_, _ = fmt.Fprintf(os.Stderr, "Walking %s:%d\n", connParams.IPAddress, snmp.Port)
+	pdus, err := snmpScanner.RunSnmpWalkAll(snmp, "")
+	if err != nil {
+		return fmt.Errorf("unable to walk SNMP agent on %s:%d: %w", connParams.IPAddress, connParams.Port, err)
+	}
+	sysOID := analyzer.FindSysOID(pdus)
+	if sysOID == "" {
+		_, _ = fmt.Fprintln(os.Stderr, "Warning: sysObjectID not found in the walk, the profile won't match any device until sysobjectid is set")
+	}
+
+	result := profilegen.Generate(pdus, sysOID)
+	profile, err := profilegen.MarshalProfile(result.Profile)
+	if err != nil {
+		return fmt.Errorf("unable to marshal the generated profile: %w", err)
+	}
+	report := profilegen.FormatCoverageReport(result.Coverage)
+	if output == "" {
+		fmt.Print(string(profile))
+		_, _ = fmt.Fprint(os.Stderr, report)
+		return nil
+	}
+	if err := os.WriteFile(string(output), profile, 0o644); err != nil {
+		return fmt.Errorf("unable to write the generated profile: %w", err)
+	}
+	fmt.Print(report)
+	fmt.Printf("Profile written to %s\n", output)
+	return nil
+}
+
 // runPager writes content to a temp file and runs PAGER (or "less") so the user can scroll. Falls back to stdout on error.
 func runPager(content string) error {
 	f, err := os.CreateTemp("", "datadog-snmp-analyze-*.txt")
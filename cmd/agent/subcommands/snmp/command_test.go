@@ -31,6 +31,19 @@ func TestWalkCommand(t *testing.T) {
 		})
 }
 
+func TestGenerateProfileCommand(t *testing.T) {
+	fxutil.TestOneShotSubcommand(t,
+		Commands(&command.GlobalParams{}),
+		[]string{"snmp", "generate-profile", "1.2.3.4:1161", "-C", "public", "-o", "/tmp/profile.yaml"},
+		generateProfile,
+		func(cliParams *snmpparse.SNMPConfig, args argsType, output profileOutputFlag) {
+			require.Equal(t, argsType{"1.2.3.4:1161"}, args)
+			require.Equal(t, "public", cliParams.CommunityString)
+			require.Equal(t, defaultRetries, cliParams.Retries)
+			require.Equal(t, profileOutputFlag("/tmp/profile.yaml"), output)
+		})
+}
+
 func TestScanCommand(t *testing.T) {
 	// this command has _lots_ of options, so the test just exercises a few
 	fxutil.TestOneShotSubcommand(t,
@@ -18,6 +18,8 @@ import (
 	"strings"
 
 	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+	"github.com/DataDog/datadog-agent/pkg/sbom/export"
+	"github.com/DataDog/datadog-agent/pkg/sbom/osv"
 	"github.com/spf13/cobra"
 )
 
@@ -28,6 +30,12 @@ func main() {
 	var cpuprofile string
 	var closers []io.Closer
 
+	var osvDBPath string
+	var vulnDB *osv.Database
+
+	var formatName string
+	var format export.Format
+
 	var rootCmd = &cobra.Command{
 		Use:   "sbomgen",
 		Short: "A generator for SBOMs",
@@ -35,6 +43,8 @@ func main() {
 	rootCmd.PersistentFlags().BoolVar(&fast, "fast", false, "use fast mode")
 	rootCmd.PersistentFlags().StringSliceVar(&analyzers, "analyzers", nil, "analyzers to use")
 	rootCmd.PersistentFlags().StringVar(&cpuprofile, "cpuprofile", "", "write cpu profile to file")
+	rootCmd.PersistentFlags().StringVar(&formatName, "format", "", fmt.Sprintf("output the SBOM as a standard document, one of %v", export.Formats))
+	rootCmd.PersistentFlags().StringVar(&osvDBPath, "osv-db", "", "match the SBOM against the OSV database at this path (JSON entry, ZIP archive or directory)")
 	rootCmd.PersistentPreRunE = func(_ *cobra.Command, _ []string) error {
 		if cpuprofile != "" {
 			f, err := os.Create(cpuprofile)
@@ -46,6 +56,20 @@ func main() {
 				return fmt.Errorf("error starting CPU profile: %w", err)
 			}
 		}
+		if formatName != "" {
+			f, err := export.ParseFormat(formatName)
+			if err != nil {
+				return err
+			}
+			format = f
+		}
+		if osvDBPath != "" {
+			db, err := osv.LoadDatabase(osvDBPath)
+			if err != nil {
+				return fmt.Errorf("error loading OSV database: %w", err)
+			}
+			vulnDB = db
+		}
 		return nil
 	}
 	rootCmd.PersistentPostRunE = func(_ *cobra.Command, _ []string) error {
@@ -65,7 +89,7 @@ func main() {
 		Args: cobra.MinimumNArgs(1),
 		RunE: func(_ *cobra.Command, args []string) error {
 			path := args[0]
-			return runScanFS(path, analyzers, fast)
+			return runScanFS(path, analyzers, fast, output{format: format, vulnDB: vulnDB})
 		},
 	}
 	rootCmd.AddCommand(fsCmd)
@@ -79,7 +103,7 @@ func main() {
 			if err != nil {
 				return err
 			}
-			return runScanDocker(imageMeta, analyzers, fast)
+			return runScanDocker(imageMeta, analyzers, fast, output{format: format, vulnDB: vulnDB})
 		},
 	}
 	rootCmd.AddCommand(dockerCmd)
@@ -94,7 +118,7 @@ func main() {
 			if err != nil {
 				return err
 			}
-			return runScanContainerd(imageMeta, analyzers, fast, containerdStrategy)
+			return runScanContainerd(imageMeta, analyzers, fast, containerdStrategy, output{format: format, vulnDB: vulnDB})
 		},
 	}
 	containerdCmd.Flags().StringVar(&containerdStrategy, "strategy", "image", "strategy to use (mount, overlayfs or image)")
@@ -109,7 +133,7 @@ func main() {
 			if err != nil {
 				return err
 			}
-			return runScanCrio(imageMeta, analyzers, fast)
+			return runScanCrio(imageMeta, analyzers, fast, output{format: format, vulnDB: vulnDB})
 		},
 	}
 	rootCmd.AddCommand(crioCmd)
@@ -11,9 +11,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"time"
 
 	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
 	"github.com/DataDog/datadog-agent/pkg/sbom"
+	"github.com/DataDog/datadog-agent/pkg/sbom/export"
+	"github.com/DataDog/datadog-agent/pkg/sbom/osv"
 	containerdutil "github.com/DataDog/datadog-agent/pkg/util/containerd"
 	"github.com/DataDog/datadog-agent/pkg/util/crio"
 	"github.com/DataDog/datadog-agent/pkg/util/docker"
@@ -21,7 +25,7 @@ import (
 	containerd "github.com/containerd/containerd/v2/client"
 )
 
-func runScanFS(path string, analyzers []string, fast bool) error {
+func runScanFS(path string, analyzers []string, fast bool, out output) error {
 	collector := trivy.NewCollectorForCLI()
 
 	ctx := context.Background()
@@ -33,10 +37,10 @@ func runScanFS(path string, analyzers []string, fast bool) error {
 		return err
 	}
 
-	return outputReport(report)
+	return out.write(report, path)
 }
 
-func runScanDocker(imageMeta *workloadmeta.ContainerImageMetadata, analyzers []string, fast bool) error {
+func runScanDocker(imageMeta *workloadmeta.ContainerImageMetadata, analyzers []string, fast bool, out output) error {
 	collector := trivy.NewCollectorForCLI()
 
 	cl, err := docker.GetDockerUtil()
@@ -59,10 +63,10 @@ func runScanDocker(imageMeta *workloadmeta.ContainerImageMetadata, analyzers []s
 		return err
 	}
 
-	return outputReport(report)
+	return out.write(report, imageName(imageMeta))
 }
 
-func runScanContainerd(imageMeta *workloadmeta.ContainerImageMetadata, analyzers []string, fast bool, strategy string) error {
+func runScanContainerd(imageMeta *workloadmeta.ContainerImageMetadata, analyzers []string, fast bool, strategy string, out output) error {
 	collector := trivy.NewCollectorForCLI()
 
 	containerdClient, err := containerdutil.NewContainerdUtil()
@@ -97,10 +101,10 @@ func runScanContainerd(imageMeta *workloadmeta.ContainerImageMetadata, analyzers
 		return err
 	}
 
-	return outputReport(report)
+	return out.write(report, imageName(imageMeta))
 }
 
-func runScanCrio(imageMeta *workloadmeta.ContainerImageMetadata, analyzers []string, fast bool) error {
+func runScanCrio(imageMeta *workloadmeta.ContainerImageMetadata, analyzers []string, fast bool, out output) error {
 	collector := trivy.NewCollectorForCLI()
 
 	crioClient, err := crio.NewCRIOClient()
@@ -122,16 +126,64 @@ func runScanCrio(imageMeta *workloadmeta.ContainerImageMetadata, analyzers []str
 		return err
 	}
 
-	return outputReport(report)
+	return out.write(report, imageName(imageMeta))
 }
 
-func outputReport(report sbom.Report) error {
+// output describes how the SBOM is written
+type output struct {
+	format export.Format // standard document format, empty for the internal representation
+	vulnDB *osv.Database
+}
+
+func (o output) write(report sbom.Report, name string) error {
 	bom := report.ToCycloneDX()
+
+	var findings []osv.Finding
+	if o.vulnDB != nil {
+		findings = o.vulnDB.Match(bom)
+	}
+
+	if o.format != "" {
+		// The document is written alone on stdout, so that it can be piped
+		// to other tools
+		if err := export.Write(os.Stdout, export.Document{Name: name, Created: time.Now(), BOM: bom}, o.format); err != nil {
+			return err
+		}
+		if o.vulnDB != nil {
+			findingsJSON, err := json.MarshalIndent(findings, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "vulnerabilities: %s\n", findingsJSON)
+		}
+		return nil
+	}
+
 	bomJSON, err := json.MarshalIndent(bom, "", "  ")
 	if err != nil {
 		return err
 	}
 
 	fmt.Printf("sbom: %+v\n", string(bomJSON))
+
+	if o.vulnDB != nil {
+		findingsJSON, err := json.MarshalIndent(findings, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("vulnerabilities: %+v\n", string(findingsJSON))
+	}
 	return nil
 }
+
+// imageName returns the name of the image used in the exported documents
+func imageName(imageMeta *workloadmeta.ContainerImageMetadata) string {
+	if len(imageMeta.RepoTags) > 0 {
+		return imageMeta.RepoTags[0]
+	}
+	if imageMeta.Name != "" {
+		return imageMeta.Name
+	}
+	return imageMeta.ID
+}
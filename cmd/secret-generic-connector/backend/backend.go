@@ -19,6 +19,7 @@ import (
 	"github.com/DataDog/datadog-agent/cmd/secret-generic-connector/backend/gcp"
 	"github.com/DataDog/datadog-agent/cmd/secret-generic-connector/backend/hashicorp"
 	"github.com/DataDog/datadog-agent/cmd/secret-generic-connector/backend/kubernetes"
+	"github.com/DataDog/datadog-agent/cmd/secret-generic-connector/backend/sops"
 	"github.com/DataDog/datadog-agent/cmd/secret-generic-connector/backend/windows"
 	"github.com/DataDog/datadog-agent/cmd/secret-generic-connector/secret"
 )
@@ -50,6 +51,10 @@ func Get(backendType string, backendConfig map[string]interface{}) Backend {
 		backend, err = file.NewJSONBackend(backendConfig)
 	case "file.text":
 		backend, err = file.NewTextFileBackend(backendConfig)
+	case "sops.file":
+		backend, err = sops.NewSOPSBackend(backendConfig)
+	case "age.file":
+		backend, err = sops.NewAgeFileBackend(backendConfig)
 	case "k8s.file":
 		backend, err = kubernetes.NewK8sFileBackend(backendConfig)
 	case "k8s.secrets":
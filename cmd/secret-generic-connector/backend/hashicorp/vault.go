@@ -382,7 +382,7 @@ func (b *VaultBackend) handleVaultURIFormat(ctx context.Context, secretString st
 	}
 
 	valueStr := fmt.Sprintf("%v", value)
-	return secret.Output{Value: &valueStr, Error: nil}
+	return secret.Output{Value: &valueStr, Error: nil, TTL: leaseTTL(sec)}
 }
 
 func (b *VaultBackend) handleTypicalFormat(ctx context.Context, secretString string) secret.Output {
@@ -434,7 +434,7 @@ func (b *VaultBackend) handleTypicalFormat(ctx context.Context, secretString str
 
 	if data, ok := dataMap[secretKey]; ok {
 		if strValue, ok := data.(string); ok {
-			return secret.Output{Value: &strValue, Error: nil}
+			return secret.Output{Value: &strValue, Error: nil, TTL: leaseTTL(sec)}
 		}
 		es := "secret value is not a string"
 		return secret.Output{Value: nil, Error: &es}
@@ -444,6 +444,15 @@ func (b *VaultBackend) handleTypicalFormat(ctx context.Context, secretString str
 	return secret.Output{Value: nil, Error: &es}
 }
 
+// leaseTTL returns the lease duration of dynamic secrets, so that the Agent refreshes them
+// before the lease expires. KV secrets aren't leased: their lease duration is only a hint.
+func leaseTTL(sec *api.Secret) int64 {
+	if sec.LeaseID == "" {
+		return 0
+	}
+	return int64(sec.LeaseDuration)
+}
+
 func isKVv2Mount(client *api.Client, secretPath string) (bool, string) {
 	mounts, err := client.Sys().ListMounts()
 	if err != nil {
@@ -619,6 +619,8 @@ func TestVaultBackend_VaultURIFormat(t *testing.T) {
 				assert.NotNil(t, secretOutput.Value)
 				assert.Nil(t, secretOutput.Error)
 				assert.Equal(t, tt.expectedValue, *secretOutput.Value)
+				// KV secrets aren't leased
+				assert.Zero(t, secretOutput.TTL)
 			}
 		})
 	}
@@ -0,0 +1,178 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the BSD 3-Clause License.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2024-present Datadog, Inc.
+
+// Package sops allows to fetch secrets from SOPS or age encrypted files, decrypted with a local age key file.
+//
+// Files are decrypted by the `sops` and `age` binaries, which aren't shipped with the Agent: they must be installed
+// on the host, in the PATH or at the location set by `binary_path`.
+package sops
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	yaml "go.yaml.in/yaml/v2"
+
+	"github.com/DataDog/datadog-agent/cmd/secret-generic-connector/secret"
+)
+
+// AgeKeyFileEnvVar is the environment variable used by SOPS to locate the age key file,
+// used when the backend configuration doesn't set `age_key_file`
+const AgeKeyFileEnvVar = "SOPS_AGE_KEY_FILE"
+
+const (
+	defaultSOPSBinary = "sops"
+	defaultAgeBinary  = "age"
+	decryptTimeout    = 30 * time.Second
+)
+
+// BackendConfig is the configuration for the SOPS and age backends
+type BackendConfig struct {
+	FilePath        string `mapstructure:"file_path"`
+	AgeKeyFile      string `mapstructure:"age_key_file"`
+	BinaryPath      string `mapstructure:"binary_path"`
+	MaxFileReadSize int64  `mapstructure:"max_file_read_size"`
+}
+
+// Backend represents backend for a SOPS or age encrypted YAML or JSON file.
+// Nested values are addressed by their path, keys being joined with `/`.
+type Backend struct {
+	Config BackendConfig
+	Secret map[string]string
+}
+
+// NewSOPSBackend returns a new backend for a SOPS encrypted YAML or JSON file
+// whose data key is encrypted for an age recipient. The file is decrypted by
+// the `sops` binary, which also verifies the document MAC.
+func NewSOPSBackend(bc map[string]interface{}) (*Backend, error) {
+	return newBackend(bc, defaultSOPSBinary, func(ctx context.Context, config BackendConfig) *exec.Cmd {
+		cmd := exec.CommandContext(ctx, config.BinaryPath, "--decrypt", "--output-type", "json", config.FilePath)
+		cmd.Env = append(os.Environ(), AgeKeyFileEnvVar+"="+config.AgeKeyFile)
+		return cmd
+	})
+}
+
+// NewAgeFileBackend returns a new backend for an age encrypted YAML or JSON
+// file, decrypted by the `age` binary
+func NewAgeFileBackend(bc map[string]interface{}) (*Backend, error) {
+	return newBackend(bc, defaultAgeBinary, func(ctx context.Context, config BackendConfig) *exec.Cmd {
+		return exec.CommandContext(ctx, config.BinaryPath, "--decrypt", "--identity", config.AgeKeyFile, config.FilePath)
+	})
+}
+
+func newBackend(bc map[string]interface{}, defaultBinary string, decryptCommand func(context.Context, BackendConfig) *exec.Cmd) (*Backend, error) {
+	backendConfig := BackendConfig{}
+	err := mapstructure.Decode(bc, &backendConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map backend configuration: %s", err)
+	}
+
+	if backendConfig.FilePath == "" {
+		return nil, errors.New("file_path is required")
+	}
+	if backendConfig.AgeKeyFile == "" {
+		backendConfig.AgeKeyFile = os.Getenv(AgeKeyFileEnvVar)
+	}
+	if backendConfig.AgeKeyFile == "" {
+		return nil, fmt.Errorf("age_key_file is required when %s is not set", AgeKeyFileEnvVar)
+	}
+	if backendConfig.BinaryPath == "" {
+		backendConfig.BinaryPath = defaultBinary
+	}
+	if backendConfig.MaxFileReadSize <= 0 {
+		backendConfig.MaxFileReadSize = secret.DefaultMaxFileReadSize
+	}
+
+	if info, err := os.Stat(backendConfig.FilePath); err != nil {
+		return nil, fmt.Errorf("failed to read encrypted secret file '%s': %s", backendConfig.FilePath, err)
+	} else if info.Size() > backendConfig.MaxFileReadSize {
+		return nil, fmt.Errorf("failed to read encrypted secret file '%s': file exceeds maximum size limit of %d bytes (actual: %d bytes)",
+			backendConfig.FilePath, backendConfig.MaxFileReadSize, info.Size())
+	}
+
+	// the binaries aren't shipped with the Agent, report clearly when they aren't installed
+	if backendConfig.BinaryPath, err = exec.LookPath(backendConfig.BinaryPath); err != nil {
+		return nil, fmt.Errorf("the %s binary, which isn't shipped with the Agent, is required to decrypt secret files: install it or set binary_path: %s",
+			defaultBinary, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), decryptTimeout)
+	defer cancel()
+	plaintext, err := runDecrypt(decryptCommand(ctx, backendConfig))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt secret file '%s': %s", backendConfig.FilePath, err)
+	}
+
+	var document yaml.MapSlice
+	if err := yaml.Unmarshal(plaintext, &document); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal decrypted content: %s", err)
+	}
+	secretValue := make(map[string]string)
+	flatten(document, "", secretValue)
+
+	backend := &Backend{
+		Config: backendConfig,
+		Secret: secretValue,
+	}
+	return backend, nil
+}
+
+// runDecrypt runs a decryption command and returns its standard output
+func runDecrypt(cmd *exec.Cmd) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s: %s", err, msg)
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
+
+// GetSecretOutput returns a the value for a specific secret
+func (b *Backend) GetSecretOutput(_ context.Context, secretKey string) secret.Output {
+	if val, ok := b.Secret[secretKey]; ok {
+		return secret.Output{Value: &val, Error: nil}
+	}
+	es := secret.ErrKeyNotFound.Error()
+	return secret.Output{Value: nil, Error: &es}
+}
+
+// flatten adds the scalar values of a YAML document to secrets, keyed by
+// their path. List items are keyed by their index.
+func flatten(value interface{}, path string, secrets map[string]string) {
+	switch v := value.(type) {
+	case yaml.MapSlice:
+		for _, item := range v {
+			flatten(item.Value, joinKey(path, fmt.Sprint(item.Key)), secrets)
+		}
+	case []interface{}:
+		for i, item := range v {
+			flatten(item, joinKey(path, strconv.Itoa(i)), secrets)
+		}
+	case nil:
+		secrets[path] = ""
+	default:
+		secrets[path] = fmt.Sprint(v)
+	}
+}
+
+// joinKey returns the secret key of a child value
+func joinKey(parent, child string) string {
+	if parent == "" {
+		return child
+	}
+	return parent + "/" + child
+}
@@ -0,0 +1,144 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the BSD 3-Clause License.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2024-present Datadog, Inc.
+
+package sops
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/cmd/secret-generic-connector/secret"
+)
+
+// fakeBinaryEnvVar makes the test binary behave as a fake `sops` or `age`
+// binary, which "decrypts" a file by printing its content
+const fakeBinaryEnvVar = "SOPS_BACKEND_TEST_FAKE_BINARY"
+
+func TestMain(m *testing.M) {
+	if mode := os.Getenv(fakeBinaryEnvVar); mode != "" {
+		os.Exit(runFakeBinary(mode, os.Args[1:]))
+	}
+	os.Exit(m.Run())
+}
+
+func runFakeBinary(mode string, args []string) int {
+	var keyFile, filePath string
+	switch {
+	case mode == "sops" && len(args) == 4 && args[0] == "--decrypt" && args[1] == "--output-type" && args[2] == "json":
+		keyFile, filePath = os.Getenv(AgeKeyFileEnvVar), args[3]
+	case mode == "age" && len(args) == 4 && args[0] == "--decrypt" && args[1] == "--identity":
+		keyFile, filePath = args[2], args[3]
+	default:
+		fmt.Fprintf(os.Stderr, "unexpected arguments: %v\n", args)
+		return 2
+	}
+
+	if _, err := os.Stat(keyFile); err != nil {
+		fmt.Fprintf(os.Stderr, "cannot read identity: %s\n", err)
+		return 1
+	}
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if strings.Contains(string(content), "tampered") {
+		fmt.Fprintln(os.Stderr, "MAC mismatch")
+		return 1
+	}
+	os.Stdout.Write(content)
+	return 0
+}
+
+func writeTestFile(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+// testBackendConfig returns a backend configuration running the test binary
+// as a fake `mode` binary
+func testBackendConfig(t *testing.T, mode, content string) map[string]interface{} {
+	t.Setenv(fakeBinaryEnvVar, mode)
+	dir := t.TempDir()
+	return map[string]interface{}{
+		"file_path":    writeTestFile(t, dir, "secrets.enc", content),
+		"age_key_file": writeTestFile(t, dir, "keys.txt", "AGE-SECRET-KEY-1TEST\n"),
+		"binary_path":  os.Args[0],
+	}
+}
+
+func TestSOPSBackend(t *testing.T) {
+	config := testBackendConfig(t, "sops", `{"db":{"user":"admin","password":"s3cr3t","port":5432,"tls":true},"tokens":["a","b"],"empty":null}`)
+	backend, err := NewSOPSBackend(config)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"db/user":     "admin",
+		"db/password": "s3cr3t",
+		"db/port":     "5432",
+		"db/tls":      "true",
+		"tokens/0":    "a",
+		"tokens/1":    "b",
+		"empty":       "",
+	}, backend.Secret)
+
+	output := backend.GetSecretOutput(context.Background(), "db/password")
+	require.NotNil(t, output.Value)
+	assert.Equal(t, "s3cr3t", *output.Value)
+	assert.Nil(t, output.Error)
+
+	output = backend.GetSecretOutput(context.Background(), "db/missing")
+	assert.Nil(t, output.Value)
+	require.NotNil(t, output.Error)
+	assert.Equal(t, secret.ErrKeyNotFound.Error(), *output.Error)
+}
+
+func TestSOPSBackendDecryptionError(t *testing.T) {
+	config := testBackendConfig(t, "sops", `{"password":"tampered"}`)
+	_, err := NewSOPSBackend(config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "MAC mismatch")
+}
+
+func TestSOPSBackendConfigErrors(t *testing.T) {
+	t.Setenv(AgeKeyFileEnvVar, "")
+
+	_, err := NewSOPSBackend(map[string]interface{}{})
+	assert.ErrorContains(t, err, "file_path is required")
+
+	_, err = NewSOPSBackend(map[string]interface{}{"file_path": "/does/not/exist"})
+	assert.ErrorContains(t, err, "age_key_file is required")
+
+	_, err = NewSOPSBackend(map[string]interface{}{"file_path": "/does/not/exist", "age_key_file": "/keys.txt"})
+	assert.ErrorContains(t, err, "failed to read encrypted secret file")
+
+	config := testBackendConfig(t, "sops", `{"password":"s3cr3t"}`)
+	config["max_file_read_size"] = 4
+	_, err = NewSOPSBackend(config)
+	assert.ErrorContains(t, err, "exceeds maximum size limit")
+
+	config = testBackendConfig(t, "sops", `{"password":"s3cr3t"}`)
+	config["binary_path"] = filepath.Join(t.TempDir(), "missing-sops")
+	_, err = NewSOPSBackend(config)
+	assert.ErrorContains(t, err, "the sops binary, which isn't shipped with the Agent, is required")
+}
+
+func TestAgeFileBackend(t *testing.T) {
+	config := testBackendConfig(t, "age", "db:\n  user: admin\n  password: s3cr3t\n")
+	delete(config, "age_key_file")
+	t.Setenv(AgeKeyFileEnvVar, writeTestFile(t, t.TempDir(), "keys.txt", "AGE-SECRET-KEY-1TEST\n"))
+
+	backend, err := NewAgeFileBackend(config)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"db/user": "admin", "db/password": "s3cr3t"}, backend.Secret)
+}
@@ -22,6 +22,8 @@ type Input struct {
 type Output struct {
 	Value *string `json:"value"`
 	Error *string `json:"error"`
+	// TTL is the number of seconds the value stays valid, for leased secrets
+	TTL int64 `json:"ttl,omitempty"`
 }
 
 // ErrKeyNotFound is returned when the secret key is not found
@@ -78,6 +78,7 @@ func createNetworkTracerModule(_ *sysconfigtypes.Config, deps module.FactoryDepe
 		tracer:      t,
 		cfg:         ncfg,
 		connsSender: connsSender,
+		bandwidth:   newBandwidthSampler(t),
 		ctx:         ctx,
 		cancelFunc:  cancel,
 	}, nil
@@ -90,6 +91,7 @@ type networkTracer struct {
 	cfg          *networkconfig.Config
 	restartTimer *time.Timer
 	connsSender  sender.Sender
+	bandwidth    *bandwidthSampler
 	ctx          context.Context
 	cancelFunc   context.CancelFunc
 }
@@ -225,6 +227,8 @@ func (nt *networkTracer) Register(httpMux *module.Router) error {
 		utils.WriteAsJSON(r, w, cache, utils.CompactOutput)
 	})
 
+	httpMux.HandleFunc("/bandwidth", utils.WithConcurrencyLimit(utils.DefaultMaxConcurrentRequests, nt.handleBandwidth))
+
 	registerUSMEndpoints(nt, httpMux)
 
 	return nt.platformRegister(httpMux)
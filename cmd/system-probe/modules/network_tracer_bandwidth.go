@@ -0,0 +1,153 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+//go:build (linux && bpf) || (windows && npm) || darwin
+
+package modules
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/DataDog/datadog-agent/pkg/network"
+	"github.com/DataDog/datadog-agent/pkg/network/bandwidth"
+	"github.com/DataDog/datadog-agent/pkg/network/tracer"
+	"github.com/DataDog/datadog-agent/pkg/system-probe/utils"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+const (
+	// bandwidthClientID is the tracer client the connections are sampled with
+	bandwidthClientID = "system-probe-bandwidth"
+	// bandwidthResolution is the sampling interval of the connections
+	bandwidthResolution = 5 * time.Second
+	// bandwidthMaxWindow is the longest window that can be reported
+	bandwidthMaxWindow = 15 * time.Minute
+	// bandwidthDefaultWindow is the window reported when none is requested
+	bandwidthDefaultWindow = time.Minute
+	// bandwidthIdleTimeout is the duration after which the sampling stops
+	// when no report is requested. The tracer expires the client afterwards.
+	bandwidthIdleTimeout = 5 * time.Minute
+)
+
+// bandwidthSampler periodically fetches the connections from the tracer to
+// account their traffic. The sampling starts with the first report request,
+// so that the tracer doesn't keep the state of an extra client when no one
+// is looking at the bandwidth.
+type bandwidthSampler struct {
+	tracer     *tracer.Tracer
+	accountant *bandwidth.Accountant
+
+	mu          sync.Mutex
+	running     bool
+	lastRequest time.Time
+}
+
+func newBandwidthSampler(t *tracer.Tracer) *bandwidthSampler {
+	return &bandwidthSampler{
+		tracer:     t,
+		accountant: bandwidth.NewAccountant(bandwidthResolution, bandwidthMaxWindow),
+	}
+}
+
+// report returns the top talkers over the window, starting the sampling if
+// it isn't running
+func (s *bandwidthSampler) report(ctx context.Context, window time.Duration, groupBy bandwidth.GroupBy, limit int) bandwidth.Report {
+	now := time.Now()
+
+	s.mu.Lock()
+	s.lastRequest = now
+	if !s.running {
+		s.running = true
+		s.accountant.Reset(now)
+		go s.run(ctx)
+	}
+	s.mu.Unlock()
+
+	return s.accountant.Top(now, window, groupBy, limit)
+}
+
+func (s *bandwidthSampler) run(ctx context.Context) {
+	log.Infof("starting network bandwidth accounting")
+	if err := s.tracer.RegisterClient(bandwidthClientID); err != nil {
+		log.Errorf("unable to register bandwidth client: %s", err)
+	}
+	// the first delta of a client holds the whole traffic of the connections,
+	// it is only fetched to get the following ones
+	s.sample(time.Now(), false)
+
+	ticker := time.NewTicker(bandwidthResolution)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.mu.Lock()
+			if now.Sub(s.lastRequest) > bandwidthIdleTimeout {
+				s.running = false
+				s.mu.Unlock()
+				log.Infof("stopping network bandwidth accounting, no report was requested for %s", bandwidthIdleTimeout)
+				return
+			}
+			s.mu.Unlock()
+
+			s.sample(now, true)
+		}
+	}
+}
+
+func (s *bandwidthSampler) sample(now time.Time, account bool) {
+	cs, cleanup, err := s.tracer.GetActiveConnections(bandwidthClientID)
+	if err != nil {
+		log.Warnf("unable to retrieve connections for bandwidth accounting: %s", err)
+		return
+	}
+	defer network.Reclaim(cs)
+	defer cleanup()
+
+	if account {
+		s.accountant.Add(now, cs.Conns)
+	}
+}
+
+// handleBandwidth writes the top talkers of the host. The report is
+// configured with the `window`, `group_by` and `limit` query parameters.
+func (nt *networkTracer) handleBandwidth(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+
+	window := bandwidthDefaultWindow
+	if v := query.Get("window"); v != "" {
+		var err error
+		if window, err = time.ParseDuration(v); err != nil || window <= 0 {
+			http.Error(w, "invalid window, expected a positive duration", http.StatusBadRequest)
+			return
+		}
+	}
+
+	groupBy := bandwidth.GroupByProcess
+	if v := query.Get("group_by"); v != "" {
+		var err error
+		if groupBy, err = bandwidth.ParseGroupBy(v); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	var limit int
+	if v := query.Get("limit"); v != "" {
+		var err error
+		if limit, err = strconv.Atoi(v); err != nil || limit < 0 {
+			http.Error(w, "invalid limit, expected a non-negative integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	report := nt.bandwidth.report(nt.ctx, window, groupBy, limit)
+	utils.WriteAsJSON(req, w, report, utils.CompactOutput)
+}
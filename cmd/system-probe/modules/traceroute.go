@@ -194,6 +194,10 @@ func parseParams(req *http.Request) (tracerouteutil.Config, error) {
 	if err != nil {
 		return tracerouteutil.Config{}, fmt.Errorf("invalid e2e_queries: %s", err)
 	}
+	multipathFlows, err := parseUint(query, "multipath_flows", 32)
+	if err != nil {
+		return tracerouteutil.Config{}, fmt.Errorf("invalid multipath_flows: %s", err)
+	}
 
 	return tracerouteutil.Config{
 		DestHostname:                    host,
@@ -208,6 +212,7 @@ func parseParams(req *http.Request) (tracerouteutil.Config, error) {
 		DisableSourcePublicIPCollection: disableSourcePublicIPCollection == "true",
 		TracerouteQueries:               int(tracerouteQueries),
 		E2eQueries:                      int(e2eQueries),
+		MultipathFlows:                  int(multipathFlows),
 	}, nil
 }
 
@@ -47,6 +47,7 @@ func TestParseParams(t *testing.T) {
 				"timeout":            "1000",
 				"traceroute_queries": "3",
 				"e2e_queries":        "50",
+				"multipath_flows":    "8",
 			},
 			expectedConfig: tracerouteutil.Config{
 				DestHostname:      "1.2.3.4",
@@ -55,6 +56,7 @@ func TestParseParams(t *testing.T) {
 				Timeout:           1000,
 				TracerouteQueries: 3,
 				E2eQueries:        50,
+				MultipathFlows:    8,
 			},
 		},
 	}
@@ -13,6 +13,8 @@ import (
 	coreconfig "github.com/DataDog/datadog-agent/pkg/config/setup"
 	httpdebugging "github.com/DataDog/datadog-agent/pkg/network/protocols/http/debugging"
 	kafkadebugging "github.com/DataDog/datadog-agent/pkg/network/protocols/kafka/debugging"
+	mongodebugging "github.com/DataDog/datadog-agent/pkg/network/protocols/mongo/debugging"
+	mysqldebugging "github.com/DataDog/datadog-agent/pkg/network/protocols/mysql/debugging"
 	postgresdebugging "github.com/DataDog/datadog-agent/pkg/network/protocols/postgres/debugging"
 	redisdebugging "github.com/DataDog/datadog-agent/pkg/network/protocols/redis/debugging"
 	usmconsts "github.com/DataDog/datadog-agent/pkg/network/usm/consts"
@@ -76,6 +78,40 @@ func registerUSMEndpoints(nt *networkTracer, httpMux *module.Router) {
 		utils.WriteAsJSON(req, w, redisdebugging.Redis(cs.USMData.Redis), utils.GetPrettyPrintFromQueryParams(req))
 	})
 
+	httpMux.HandleFunc("/debug/mysql_monitoring", func(w http.ResponseWriter, req *http.Request) {
+		if !coreconfig.SystemProbe().GetBool("service_monitoring_config.mysql.enabled") {
+			writeDisabledProtocolMessage("mysql", w)
+			return
+		}
+		id := utils.GetClientID(req)
+		cs, cleanup, err := nt.tracer.GetActiveConnections(id)
+		if err != nil {
+			log.Errorf("unable to retrieve connections: %s", err)
+			w.WriteHeader(500)
+			return
+		}
+		defer cleanup()
+
+		utils.WriteAsJSON(req, w, mysqldebugging.MySQL(cs.USMData.MySQL), utils.GetPrettyPrintFromQueryParams(req))
+	})
+
+	httpMux.HandleFunc("/debug/mongo_monitoring", func(w http.ResponseWriter, req *http.Request) {
+		if !coreconfig.SystemProbe().GetBool("service_monitoring_config.mongo.enabled") {
+			writeDisabledProtocolMessage("mongo", w)
+			return
+		}
+		id := utils.GetClientID(req)
+		cs, cleanup, err := nt.tracer.GetActiveConnections(id)
+		if err != nil {
+			log.Errorf("unable to retrieve connections: %s", err)
+			w.WriteHeader(500)
+			return
+		}
+		defer cleanup()
+
+		utils.WriteAsJSON(req, w, mongodebugging.Mongo(cs.USMData.Mongo), utils.GetPrettyPrintFromQueryParams(req))
+	})
+
 	httpMux.HandleFunc("/debug/http2_monitoring", func(w http.ResponseWriter, req *http.Request) {
 		if !coreconfig.SystemProbe().GetBool("service_monitoring_config.http2.enabled") {
 			writeDisabledProtocolMessage("http2", w)
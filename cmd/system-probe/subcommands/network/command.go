@@ -0,0 +1,220 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+// Package network is the network system-probe subcommand
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+	"go.uber.org/fx"
+
+	"github.com/DataDog/datadog-agent/cmd/system-probe/command"
+	"github.com/DataDog/datadog-agent/comp/core"
+	"github.com/DataDog/datadog-agent/comp/core/config"
+	log "github.com/DataDog/datadog-agent/comp/core/log/def"
+	sysprobeconfig "github.com/DataDog/datadog-agent/comp/core/sysprobeconfig/def"
+	sysprobeconfigimpl "github.com/DataDog/datadog-agent/comp/core/sysprobeconfig/impl"
+	"github.com/DataDog/datadog-agent/pkg/network/bandwidth"
+	"github.com/DataDog/datadog-agent/pkg/system-probe/api/client"
+	sysconfig "github.com/DataDog/datadog-agent/pkg/system-probe/config"
+	"github.com/DataDog/datadog-agent/pkg/util/fxutil"
+)
+
+// clearScreen moves the cursor to the top left corner and clears the terminal
+const clearScreen = "\033[H\033[2J"
+
+// cliParams are the command-line arguments for the top subcommand
+type cliParams struct {
+	*command.GlobalParams
+
+	window   time.Duration
+	groupBy  string
+	limit    int
+	interval time.Duration
+	once     bool
+}
+
+// Commands returns a slice of subcommands for the 'system-probe' command.
+func Commands(globalParams *command.GlobalParams) []*cobra.Command {
+	cliParams := &cliParams{
+		GlobalParams: globalParams,
+	}
+
+	networkCmd := &cobra.Command{
+		Use:          "network",
+		Short:        "Network Performance Monitoring commands",
+		SilenceUsage: true,
+	}
+
+	topCmd := &cobra.Command{
+		Use:   "top",
+		Short: "Show the processes, containers or services using the most network bandwidth",
+		Long: `Show a live view of the processes, containers or services sending and
+receiving the most bytes over a sliding window. The traffic is accounted by
+system-probe from the first request, so the view fills up over the window.`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return fxutil.OneShot(runTop,
+				fx.Supply(cliParams),
+				fx.Supply(core.BundleParams{
+					ConfigParams:         config.NewAgentParams(globalParams.DatadogConfFilePath()),
+					SysprobeConfigParams: sysprobeconfigimpl.NewParams(sysprobeconfigimpl.WithSysProbeConfFilePath(globalParams.ConfFilePath), sysprobeconfigimpl.WithFleetPoliciesDirPath(globalParams.FleetPoliciesDirPath)),
+					LogParams:            log.ForOneShot(command.LoggerName, "off", false),
+				}),
+				core.Bundle(),
+			)
+		},
+	}
+	topCmd.Flags().DurationVarP(&cliParams.window, "window", "w", time.Minute, "Sliding window the traffic is accounted over")
+	topCmd.Flags().StringVarP(&cliParams.groupBy, "group-by", "g", string(bandwidth.GroupByProcess), "Aggregate the traffic by process, container or service")
+	topCmd.Flags().IntVarP(&cliParams.limit, "limit", "n", 20, "Number of talkers to show, 0 to show all of them")
+	topCmd.Flags().DurationVarP(&cliParams.interval, "interval", "i", 5*time.Second, "Refresh interval of the view")
+	topCmd.Flags().BoolVar(&cliParams.once, "once", false, "Print the top talkers once and exit")
+
+	networkCmd.AddCommand(topCmd)
+
+	return []*cobra.Command{networkCmd}
+}
+
+func runTop(sysprobeconfig sysprobeconfig.Component, cliParams *cliParams) error {
+	groupBy, err := bandwidth.ParseGroupBy(cliParams.groupBy)
+	if err != nil {
+		return err
+	}
+	if cliParams.interval <= 0 {
+		return errors.New("the refresh interval must be positive")
+	}
+
+	query := url.Values{}
+	query.Set("window", cliParams.window.String())
+	query.Set("group_by", string(groupBy))
+	query.Set("limit", strconv.Itoa(cliParams.limit))
+	endpoint := client.ModuleURL(sysconfig.NetworkTracerModule, "/bandwidth?"+query.Encode())
+	httpClient := client.Get(sysprobeconfig.SysProbeObject().SocketAddress)
+
+	if cliParams.once {
+		report, err := getReport(httpClient, endpoint)
+		if err != nil {
+			return err
+		}
+		return printReport(os.Stdout, report)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ticker := time.NewTicker(cliParams.interval)
+	defer ticker.Stop()
+	for {
+		report, err := getReport(httpClient, endpoint)
+		if err != nil {
+			return err
+		}
+
+		fmt.Print(clearScreen)
+		fmt.Printf("%s - refreshing every %s, press Ctrl-C to quit\n\n", time.Now().Format(time.TimeOnly), cliParams.interval)
+		if err := printReport(os.Stdout, report); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func getReport(httpClient *http.Client, endpoint string) (bandwidth.Report, error) {
+	var report bandwidth.Report
+
+	resp, err := httpClient.Get(endpoint)
+	if err != nil {
+		return report, fmt.Errorf("could not reach system-probe: %s\nMake sure system-probe is running with network_config.enabled before running this command", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := client.ReadAllResponseBody(resp)
+	if err != nil {
+		return report, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return report, fmt.Errorf("system-probe returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	if err := json.Unmarshal(body, &report); err != nil {
+		return report, fmt.Errorf("unable to decode the bandwidth report: %w", err)
+	}
+	return report, nil
+}
+
+func printReport(w io.Writer, report bandwidth.Report) error {
+	fmt.Fprintf(w, "Top talkers by %s over the last %s\n\n", report.GroupBy, report.Window.Round(time.Second))
+	if len(report.Talkers) == 0 {
+		fmt.Fprintln(w, "No traffic accounted yet")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	switch report.GroupBy {
+	case bandwidth.GroupByContainer:
+		fmt.Fprint(tw, "CONTAINER\t")
+	case bandwidth.GroupByService:
+		fmt.Fprint(tw, "SERVICE\t")
+	default:
+		fmt.Fprint(tw, "PID\tNAME\tCONTAINER\tSERVICE\t")
+	}
+	fmt.Fprintln(tw, "SENT/S\tRECV/S\tSENT\tRECV\tPACKETS\t")
+
+	for _, t := range report.Talkers {
+		switch report.GroupBy {
+		case bandwidth.GroupByContainer:
+			fmt.Fprintf(tw, "%s\t", orNone(shortContainerID(t.ContainerID)))
+		case bandwidth.GroupByService:
+			fmt.Fprintf(tw, "%s\t", orNone(t.Service))
+		default:
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t", t.Pid, orNone(t.Name), orNone(shortContainerID(t.ContainerID)), orNone(t.Service))
+		}
+		fmt.Fprintf(tw, "%s/s\t%s/s\t%s\t%s\t%d\t\n",
+			humanize.Bytes(uint64(t.SentBytesPerSecond)),
+			humanize.Bytes(uint64(t.RecvBytesPerSecond)),
+			humanize.Bytes(t.SentBytes),
+			humanize.Bytes(t.RecvBytes),
+			t.SentPackets+t.RecvPackets,
+		)
+	}
+	return tw.Flush()
+}
+
+// shortContainerID truncates container IDs like docker does
+func shortContainerID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
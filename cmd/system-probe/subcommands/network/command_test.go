@@ -0,0 +1,66 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package network
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/cmd/system-probe/command"
+	"github.com/DataDog/datadog-agent/pkg/network/bandwidth"
+	"github.com/DataDog/datadog-agent/pkg/util/fxutil"
+)
+
+func TestTopCommand(t *testing.T) {
+	fxutil.TestOneShotSubcommand(t,
+		Commands(&command.GlobalParams{}),
+		[]string{"network", "top", "--group-by", "container", "--once"},
+		runTop,
+		func(cliParams *cliParams) {
+			require.Equal(t, "container", cliParams.groupBy)
+			require.Equal(t, time.Minute, cliParams.window)
+			require.Equal(t, 20, cliParams.limit)
+			require.True(t, cliParams.once)
+		})
+}
+
+func TestPrintReport(t *testing.T) {
+	var b strings.Builder
+	require.NoError(t, printReport(&b, bandwidth.Report{
+		GroupBy: bandwidth.GroupByProcess,
+		Window:  time.Minute,
+		Talkers: []bandwidth.Talker{
+			{
+				Pid:                1234,
+				Name:               "nginx",
+				ContainerID:        "3b4f6a1c2d9e8f7a6b5c4d3e2f1a0b9c",
+				Service:            "frontend",
+				Counters:           bandwidth.Counters{SentBytes: 60_000_000, RecvBytes: 6_000_000, SentPackets: 40000, RecvPackets: 20000},
+				SentBytesPerSecond: 1_000_000,
+				RecvBytesPerSecond: 100_000,
+			},
+			{
+				Pid:      42,
+				Counters: bandwidth.Counters{SentBytes: 600, SentPackets: 2},
+			},
+		},
+	}))
+
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	require.Len(t, lines, 5)
+	assert.Equal(t, "Top talkers by process over the last 1m0s", lines[0])
+	assert.Equal(t, []string{"PID", "NAME", "CONTAINER", "SERVICE", "SENT/S", "RECV/S", "SENT", "RECV", "PACKETS"}, strings.Fields(lines[2]))
+	assert.Equal(t, []string{"1234", "nginx", "3b4f6a1c2d9e", "frontend", "1.0", "MB/s", "100", "kB/s", "60", "MB", "6.0", "MB", "60000"}, strings.Fields(lines[3]))
+	assert.Equal(t, []string{"42", "-", "-", "-", "0", "B/s", "0", "B/s", "600", "B", "0", "B", "2"}, strings.Fields(lines[4]))
+
+	b.Reset()
+	require.NoError(t, printReport(&b, bandwidth.Report{GroupBy: bandwidth.GroupByService, Window: 5 * time.Second}))
+	assert.Contains(t, b.String(), "No traffic accounted yet")
+}
@@ -55,6 +55,8 @@ func commonPolicyCommands(globalParams *command.GlobalParams) []*cobra.Command {
 	}
 
 	commonPolicyCmd.AddCommand(evalCommands(globalParams)...)
+	commonPolicyCmd.AddCommand(testCommands(globalParams)...)
+	commonPolicyCmd.AddCommand(importSigmaCommands(globalParams)...)
 	commonPolicyCmd.AddCommand(commonCheckPoliciesCommands(globalParams)...)
 	commonPolicyCmd.AddCommand(commonReloadPoliciesCommands(globalParams)...)
 	commonPolicyCmd.AddCommand(downloadPolicyCommands(globalParams)...)
@@ -105,6 +107,75 @@ func evalCommands(globalParams *command.GlobalParams) []*cobra.Command {
 	return []*cobra.Command{evalCmd}
 }
 
+type testCliParams struct {
+	*command.GlobalParams
+
+	dir          string
+	testsDir     string
+	windowsModel bool
+}
+
+func testCommands(globalParams *command.GlobalParams) []*cobra.Command {
+	testArgs := &testCliParams{
+		GlobalParams: globalParams,
+	}
+
+	testCmd := &cobra.Command{
+		Use:   "test",
+		Short: "Run the test suites of a policies directory and report the rule and field coverage",
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return fxutil.OneShot(testPolicies,
+				fx.Supply(testArgs),
+				fx.Supply(core.BundleParams{
+					ConfigParams: config.NewAgentParams(globalParams.DatadogConfFilePath()),
+					LogParams:    log.ForOneShot(command.LoggerName, "off", false)}),
+				core.Bundle(),
+			)
+		},
+	}
+
+	testCmd.Flags().StringVar(&testArgs.dir, "policies-dir", pkgconfigsetup.DefaultRuntimePoliciesDir, "Path to policies directory")
+	testCmd.Flags().StringVar(&testArgs.testsDir, "tests-dir", "", "Path to the directory of the *.test.yaml test suites (default: the policies directory)")
+	if runtime.GOOS == "linux" {
+		testCmd.Flags().BoolVar(&testArgs.windowsModel, "windows-model", false, "Use the Windows model")
+	}
+
+	return []*cobra.Command{testCmd}
+}
+
+type importSigmaCliParams struct {
+	*command.GlobalParams
+
+	paths      []string
+	outputPath string
+}
+
+func importSigmaCommands(globalParams *command.GlobalParams) []*cobra.Command {
+	importSigmaArgs := &importSigmaCliParams{
+		GlobalParams: globalParams,
+	}
+
+	importSigmaCmd := &cobra.Command{
+		Use:   "import-sigma <file or directory>...",
+		Short: "Convert Sigma rules to a policy and report the unsupported fields and modifiers",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			importSigmaArgs.paths = args
+			return fxutil.OneShot(importSigma,
+				fx.Supply(importSigmaArgs),
+				fx.Supply(core.BundleParams{
+					ConfigParams: config.NewAgentParams(globalParams.DatadogConfFilePath()),
+					LogParams:    log.ForOneShot(command.LoggerName, "off", false)}),
+				core.Bundle(),
+			)
+		},
+	}
+
+	importSigmaCmd.Flags().StringVar(&importSigmaArgs.outputPath, "output", "", "Path to write the policy (default: stdout)")
+
+	return []*cobra.Command{importSigmaCmd}
+}
+
 func commonCheckPoliciesCommands(globalParams *command.GlobalParams) []*cobra.Command {
 	cliParams := &checkPoliciesCliParams{
 		GlobalParams: globalParams,
@@ -457,6 +528,33 @@ func evalRule(_ log.Component, _ config.Component, _ secrets.Component, evalArgs
 	})
 }
 
+func testPolicies(_ log.Component, _ config.Component, _ secrets.Component, testArgs *testCliParams) error {
+	return clihelpers.RunPolicyTests(clihelpers.PolicyTestParams{
+		Dir:             testArgs.dir,
+		TestsDir:        testArgs.testsDir,
+		UseWindowsModel: testArgs.windowsModel,
+	}, os.Stdout)
+}
+
+func importSigma(_ log.Component, _ config.Component, _ secrets.Component, importSigmaArgs *importSigmaCliParams) error {
+	var writer io.Writer
+	if importSigmaArgs.outputPath == "" || importSigmaArgs.outputPath == "-" {
+		writer = os.Stdout
+	} else {
+		f, err := os.Create(importSigmaArgs.outputPath)
+		if err != nil {
+			return fmt.Errorf("unable to create output file: %w", err)
+		}
+		defer f.Close()
+		writer = f
+	}
+
+	// the report goes to stderr so that the policy can be redirected
+	return clihelpers.ImportSigmaRules(clihelpers.ImportSigmaParams{
+		Paths: importSigmaArgs.paths,
+	}, writer, os.Stderr)
+}
+
 //nolint:unused
 func runRuntimeSelfTest(_ log.Component, _ config.Component, _ secrets.Component) error {
 	client, err := secagent.NewRuntimeSecurityCmdClient()
@@ -189,6 +189,22 @@ func TestEvalCommand(t *testing.T) {
 		func() {})
 }
 
+func TestTestPoliciesCommand(t *testing.T) {
+	fxutil.TestOneShotSubcommand(t,
+		Commands(&command.GlobalParams{}),
+		[]string{"runtime", "policy", "test", "--tests-dir=tests"},
+		testPolicies,
+		func() {})
+}
+
+func TestImportSigmaCommand(t *testing.T) {
+	fxutil.TestOneShotSubcommand(t,
+		Commands(&command.GlobalParams{}),
+		[]string{"runtime", "policy", "import-sigma", "rules/", "--output=sigma.policy"},
+		importSigma,
+		func() {})
+}
+
 func TestCheckPoliciesCommand(t *testing.T) {
 	fxutil.TestOneShotSubcommand(t,
 		Commands(&command.GlobalParams{}),
@@ -14,6 +14,7 @@ import (
 	cmddebug "github.com/DataDog/datadog-agent/cmd/system-probe/subcommands/debug"
 	cmdebpf "github.com/DataDog/datadog-agent/cmd/system-probe/subcommands/ebpf"
 	cmdmodrestart "github.com/DataDog/datadog-agent/cmd/system-probe/subcommands/modrestart"
+	cmdnetwork "github.com/DataDog/datadog-agent/cmd/system-probe/subcommands/network"
 	cmdrun "github.com/DataDog/datadog-agent/cmd/system-probe/subcommands/run"
 	cmdruntime "github.com/DataDog/datadog-agent/cmd/system-probe/subcommands/runtime"
 	cmdusm "github.com/DataDog/datadog-agent/cmd/system-probe/subcommands/usm"
@@ -34,5 +35,6 @@ func SysprobeSubcommands() []command.SubcommandFactory {
 		cmdcoverage.Commands,
 		cmdebpf.Commands,
 		cmdusm.Commands,
+		cmdnetwork.Commands,
 	}
 }
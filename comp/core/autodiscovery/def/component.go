@@ -31,6 +31,7 @@ type Component interface {
 	RemoveScheduler(name string)
 	GetIDOfCheckWithEncryptedSecrets(checkID checkid.ID) checkid.ID
 	GetAutodiscoveryErrors() map[string]map[string]types.ErrorMsgSet
+	GetProviderStatus() map[string]map[string]string
 	AddConfigProviderFromCatalog(cp constants.ConfigurationProviders) error
 	GetTelemetryStore() *telemetry.Store
 	// TODO (component): once cluster agent uses the API component remove this function
@@ -134,7 +134,7 @@ func NewComponent(deps Requires) Provides {
 	c := newAutoConfig(deps)
 	return Provides{
 		Comp:           c,
-		StatusProvider: status.NewInformationProvider(autodiscoveryStatus.GetProvider(c, deps.FilterStore)),
+		StatusProvider: status.NewInformationProvider(autodiscoveryStatus.GetProvider(c, deps.FilterStore, deps.Config)),
 		Endpoint:       api.NewAgentEndpointProvider(c.(*AutoConfig).writeConfigCheck, "/config-check", "GET"),
 		FlareProvider:  flaretypes.NewProvider(c.(*AutoConfig).fillFlare),
 	}
@@ -330,6 +330,7 @@ func (ac *AutoConfig) buildConfigCheckResponse(scrub bool) integration.ConfigChe
 	response.Configs = configResponses
 	response.ResolveWarnings = GetResolveWarnings()
 	response.ConfigErrors = GetConfigErrors()
+	response.ProviderStatus = ac.GetProviderStatus()
 
 	if scrub {
 		unresolved := ac.getUnresolvedConfigs()
@@ -776,6 +777,19 @@ func (ac *AutoConfig) GetAutodiscoveryErrors() map[string]map[string]providerTyp
 	return errors
 }
 
+// GetProviderStatus fetches the status of each ConfigProvider reporting one,
+// such as the last synchronization of the providers pulling remote configs.
+// The resulting data structure maps provider name to status field to value.
+func (ac *AutoConfig) GetProviderStatus() map[string]map[string]string {
+	statuses := map[string]map[string]string{}
+	for _, cp := range ac.getConfigPollers() {
+		if reporter, ok := cp.provider.(providerTypes.StatusReporter); ok {
+			statuses[cp.provider.String()] = reporter.GetStatus()
+		}
+	}
+	return statuses
+}
+
 // applyChanges applies a configChanges object. This always unschedules first.
 func (ac *AutoConfig) applyChanges(changes integration.ConfigChanges) {
 	telemetryStorePresent := ac.telemetryStore != nil
@@ -33,4 +33,6 @@ type ConfigCheckResponse struct {
 	ConfigErrors    map[string]string   `json:"config_errors"`
 	Unresolved      map[string]Config   `json:"unresolved"`
 	Services        []ServiceResponse   `json:"services,omitempty"`
+	// ProviderStatus maps provider names to the status they report, if any
+	ProviderStatus map[string]map[string]string `json:"provider_status,omitempty"`
 }
@@ -15,9 +15,11 @@ const (
 	kubeEndpointsListenerName   = "kube_endpoints"
 	kubeServicesListenerName    = "kube_services"
 	kubeletListenerName         = "kubelet"
+	nomadListenerName           = "nomad"
 	processListenerName         = "process"
 	snmpListenerName            = "snmp"
 	staticConfigListenerName    = "static config"
+	systemdListenerName         = "systemd"
 	dbmAuroraListenerName       = "database-monitoring-aurora"
 	dbmRdsListenerName          = "database-monitoring-rds"
 	crdListenerName             = "kube_crd"
@@ -31,9 +33,11 @@ func RegisterListeners(serviceListenerFactories map[string]ServiceListenerFactor
 	Register(environmentListenerName, NewEnvironmentListener, serviceListenerFactories)
 	Register(kubeServicesListenerName, NewKubeServiceListener, serviceListenerFactories)
 	Register(kubeletListenerName, NewKubeletListener, serviceListenerFactories)
+	Register(nomadListenerName, NewNomadListener, serviceListenerFactories)
 	Register(processListenerName, NewProcessListener, serviceListenerFactories)
 	Register(snmpListenerName, NewSNMPListener, serviceListenerFactories)
 	Register(staticConfigListenerName, NewStaticConfigListener, serviceListenerFactories)
+	Register(systemdListenerName, NewSystemdListener, serviceListenerFactories)
 	Register(dbmAuroraListenerName, NewDBMAuroraListener, serviceListenerFactories)
 	Register(dbmRdsListenerName, NewDBMRdsListener, serviceListenerFactories)
 	Register(crdListenerName, NewCRDListerner, serviceListenerFactories)
@@ -0,0 +1,283 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package listeners
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/integration"
+	workloadfilter "github.com/DataDog/datadog-agent/comp/core/workloadfilter/def"
+	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+	pkgconfigsetup "github.com/DataDog/datadog-agent/pkg/config/setup"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+	"github.com/DataDog/datadog-agent/pkg/util/nomad"
+)
+
+// exported for testing purposes
+const (
+	NomadServiceAddress = "nomad"
+)
+
+// Abstraction for testing
+type nomadListenerBackend interface {
+	ListServiceRegistrations(ctx context.Context) ([]nomad.ServiceRegistration, uint64, error)
+	ListNodeServiceRegistrations(ctx context.Context, nodeID string) ([]nomad.ServiceRegistration, uint64, error)
+}
+
+// NomadListener defines a listener that periodically fetches the services
+// registered in the Nomad native service discovery. One service is created per
+// registration, i.e. per allocation exposing a service.
+type NomadListener struct {
+	sync.RWMutex
+	newService    chan<- Service
+	delService    chan<- Service
+	services      map[string]Service // maps entity IDs to services
+	stop          chan bool
+	refreshTicker *time.Ticker
+	client        nomadListenerBackend
+	// nodeID restricts the services to the allocations running on this
+	// Nomad client node, so that each agent only schedules checks for its
+	// own node. Empty when all the registrations should be considered.
+	nodeID string
+}
+
+// NomadService defines a service registered in Nomad
+type NomadService struct {
+	entityID     string
+	adIdentifier string
+	hosts        map[string]string
+	ports        []workloadmeta.ContainerPort
+	tags         []string
+}
+
+// Make sure NomadService implements the Service interface
+var _ Service = &NomadService{}
+
+// NewNomadListener creates a NomadListener
+func NewNomadListener(ServiceListernerDeps) (ServiceListener, error) {
+	cfg := pkgconfigsetup.Datadog()
+	client, err := nomad.NewClient(nomad.ConfigFromAgentConfig(cfg))
+	if err != nil {
+		return nil, err
+	}
+
+	var nodeID string
+	if cfg.GetBool("nomad.local_node_only") {
+		nodeID, err = localNodeID(context.TODO(), client)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pollInterval := time.Duration(cfg.GetInt("nomad.poll_interval")) * time.Second
+	if pollInterval <= 0 {
+		pollInterval = 10 * time.Second
+	}
+
+	return &NomadListener{
+		services:      map[string]Service{},
+		stop:          make(chan bool),
+		refreshTicker: time.NewTicker(pollInterval),
+		client:        client,
+		nodeID:        nodeID,
+	}, nil
+}
+
+// localNodeID returns the ID of the Nomad client node the agent connects to.
+// Nomad agents running only as servers have no node, the services of the whole
+// cluster are considered then.
+func localNodeID(ctx context.Context, client interface {
+	LocalNodeID(ctx context.Context) (string, error)
+}) (string, error) {
+	nodeID, err := client.LocalNodeID(ctx)
+	if errors.Is(err, nomad.ErrNotClientMode) {
+		log.Warnf("The nomad agent isn't running in client mode, the services of all the nodes of the cluster are listed despite nomad.local_node_only")
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("cannot get the local nomad node ID: %w", err)
+	}
+	return nodeID, nil
+}
+
+// Listen periodically refreshes services from the Nomad API
+func (l *NomadListener) Listen(newSvc chan<- Service, delSvc chan<- Service) {
+	// setup the I/O channels
+	l.newService = newSvc
+	l.delService = delSvc
+
+	go func() {
+		l.refreshServices()
+		for {
+			select {
+			case <-l.stop:
+				l.refreshTicker.Stop()
+				return
+			case <-l.refreshTicker.C:
+				l.refreshServices()
+			}
+		}
+	}()
+}
+
+func (l *NomadListener) refreshServices() {
+	log.Debug("Refreshing services via NomadListener")
+	var registrations []nomad.ServiceRegistration
+	var err error
+	if l.nodeID != "" {
+		registrations, _, err = l.client.ListNodeServiceRegistrations(context.TODO(), l.nodeID)
+	} else {
+		registrations, _, err = l.client.ListServiceRegistrations(context.TODO())
+	}
+	if err != nil {
+		log.Warnf("Cannot list nomad service registrations: %s", err)
+		return
+	}
+
+	// make sure that we can't have two simultaneous runs of this function
+	l.Lock()
+	defer l.Unlock()
+
+	notSeen := make(map[string]struct{}, len(l.services))
+	for entityID := range l.services {
+		notSeen[entityID] = struct{}{}
+	}
+
+	for _, reg := range registrations {
+		svc := newNomadService(reg)
+		if old, found := l.services[svc.entityID]; found {
+			delete(notSeen, svc.entityID)
+			if old.Equal(svc) {
+				continue
+			}
+			l.delService <- old
+		}
+		l.services[svc.entityID] = svc
+		l.newService <- svc
+	}
+
+	for entityID := range notSeen {
+		l.delService <- l.services[entityID]
+		delete(l.services, entityID)
+	}
+}
+
+func newNomadService(reg nomad.ServiceRegistration) *NomadService {
+	hosts := map[string]string{}
+	if reg.Address != "" {
+		hosts[NomadServiceAddress] = reg.Address
+	}
+
+	ports := []workloadmeta.ContainerPort{}
+	if reg.Port != 0 {
+		ports = append(ports, workloadmeta.ContainerPort{
+			Name: reg.ServiceName,
+			Port: reg.Port,
+		})
+	}
+
+	return &NomadService{
+		entityID:     reg.EntityID(),
+		adIdentifier: reg.ADIdentifier(),
+		hosts:        hosts,
+		ports:        ports,
+		tags: []string{
+			"nomad_namespace:" + reg.Namespace,
+			"nomad_job:" + reg.JobID,
+			"nomad_service:" + reg.ServiceName,
+			"nomad_datacenter:" + reg.Datacenter,
+			"nomad_alloc_id:" + reg.AllocID,
+		},
+	}
+}
+
+// Stop queues a shutdown of NomadListener
+func (l *NomadListener) Stop() {
+	l.stop <- true
+}
+
+// Equal returns whether the two NomadService are equal
+func (s *NomadService) Equal(o Service) bool {
+	s2, ok := o.(*NomadService)
+	if !ok {
+		return false
+	}
+
+	return s.entityID == s2.entityID &&
+		s.adIdentifier == s2.adIdentifier &&
+		reflect.DeepEqual(s.hosts, s2.hosts) &&
+		reflect.DeepEqual(s.ports, s2.ports) &&
+		reflect.DeepEqual(s.tags, s2.tags)
+}
+
+// GetServiceID returns the unique entity name linked to that service
+func (s *NomadService) GetServiceID() string {
+	return s.entityID
+}
+
+// GetADIdentifiers returns the AD identifier shared by every registration of the service
+func (s *NomadService) GetADIdentifiers() []string {
+	return []string{s.adIdentifier}
+}
+
+// GetHosts returns the address the service is registered with
+func (s *NomadService) GetHosts() (map[string]string, error) {
+	return s.hosts, nil
+}
+
+// GetPorts returns the port the service is registered with
+func (s *NomadService) GetPorts() ([]workloadmeta.ContainerPort, error) {
+	return s.ports, nil
+}
+
+// GetTags returns the list of service tags
+func (s *NomadService) GetTags() ([]string, error) {
+	return s.tags, nil
+}
+
+// GetTagsWithCardinality returns the tags with given cardinality. Cardinality isn't supported for Nomad
+func (s *NomadService) GetTagsWithCardinality(_ string) ([]string, error) {
+	return s.GetTags()
+}
+
+// GetPid returns nil and an error because pids are not supported for Nomad
+func (s *NomadService) GetPid() (int, error) {
+	return -1, ErrNotSupported
+}
+
+// GetHostname returns nil and an error because hostnames are not supported for Nomad
+func (s *NomadService) GetHostname() (string, error) {
+	return "", ErrNotSupported
+}
+
+// IsReady always returns true on Nomad
+func (s *NomadService) IsReady() bool {
+	return true
+}
+
+// HasFilter returns false on Nomad
+func (s *NomadService) HasFilter(_ workloadfilter.Scope) bool {
+	return false
+}
+
+// GetExtraConfig isn't supported
+func (s *NomadService) GetExtraConfig(_ string) (string, error) {
+	return "", ErrNotSupported
+}
+
+// FilterTemplates does nothing.
+func (s *NomadService) FilterTemplates(_ map[string]integration.Config) {
+}
+
+// GetImageName does nothing.
+func (s *NomadService) GetImageName() string {
+	return ""
+}
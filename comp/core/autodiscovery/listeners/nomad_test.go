@@ -0,0 +1,150 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package listeners
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+	"github.com/DataDog/datadog-agent/pkg/util/nomad"
+)
+
+type fakeNomadListenerBackend struct {
+	registrations []nomad.ServiceRegistration
+}
+
+func (f *fakeNomadListenerBackend) ListServiceRegistrations(_ context.Context) ([]nomad.ServiceRegistration, uint64, error) {
+	return f.registrations, 0, nil
+}
+
+func (f *fakeNomadListenerBackend) ListNodeServiceRegistrations(_ context.Context, nodeID string) ([]nomad.ServiceRegistration, uint64, error) {
+	var registrations []nomad.ServiceRegistration
+	for _, reg := range f.registrations {
+		if reg.NodeID == nodeID {
+			registrations = append(registrations, reg)
+		}
+	}
+	return registrations, 0, nil
+}
+
+func TestNomadListenerRefreshServices(t *testing.T) {
+	redis := nomad.ServiceRegistration{
+		ID:          "reg-1",
+		ServiceName: "redis",
+		Namespace:   "default",
+		NodeID:      "node-1",
+		Datacenter:  "dc1",
+		JobID:       "cache",
+		AllocID:     "alloc-1",
+		Address:     "10.0.0.1",
+		Port:        6379,
+	}
+	otherNode := redis
+	otherNode.ID = "reg-2"
+	otherNode.NodeID = "node-2"
+	otherNode.AllocID = "alloc-2"
+
+	backend := &fakeNomadListenerBackend{registrations: []nomad.ServiceRegistration{redis, otherNode}}
+	newSvc := make(chan Service, 10)
+	delSvc := make(chan Service, 10)
+	l := &NomadListener{
+		services:   map[string]Service{},
+		client:     backend,
+		nodeID:     "node-1",
+		newService: newSvc,
+		delService: delSvc,
+	}
+
+	l.refreshServices()
+	require.Len(t, newSvc, 1)
+	require.Len(t, delSvc, 0)
+
+	svc := (<-newSvc).(*NomadService)
+	assert.Equal(t, "nomad://default/cache/redis/alloc-1/reg-1", svc.GetServiceID())
+	assert.Equal(t, []string{"nomad://default/cache/redis"}, svc.GetADIdentifiers())
+	hosts, err := svc.GetHosts()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{NomadServiceAddress: "10.0.0.1"}, hosts)
+	ports, err := svc.GetPorts()
+	require.NoError(t, err)
+	assert.Equal(t, []workloadmeta.ContainerPort{{Name: "redis", Port: 6379}}, ports)
+	tags, err := svc.GetTags()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"nomad_namespace:default",
+		"nomad_job:cache",
+		"nomad_service:redis",
+		"nomad_datacenter:dc1",
+		"nomad_alloc_id:alloc-1",
+	}, tags)
+
+	// unchanged registrations don't trigger any event
+	l.refreshServices()
+	assert.Len(t, newSvc, 0)
+	assert.Len(t, delSvc, 0)
+
+	// a registration updated in place is replaced
+	backend.registrations[0].Port = 6380
+	l.refreshServices()
+	require.Len(t, delSvc, 1)
+	require.Len(t, newSvc, 1)
+	assert.Equal(t, svc, <-delSvc)
+	updated := (<-newSvc).(*NomadService)
+	ports, _ = updated.GetPorts()
+	assert.Equal(t, 6380, ports[0].Port)
+
+	// removed registrations are deleted
+	backend.registrations = nil
+	l.refreshServices()
+	require.Len(t, delSvc, 1)
+	assert.Equal(t, updated, <-delSvc)
+	assert.Empty(t, l.services)
+}
+
+func TestNomadListenerAllNodes(t *testing.T) {
+	backend := &fakeNomadListenerBackend{registrations: []nomad.ServiceRegistration{
+		{ID: "reg-1", ServiceName: "web", Namespace: "default", NodeID: "node-1", JobID: "web", AllocID: "alloc-1"},
+		{ID: "reg-2", ServiceName: "web", Namespace: "default", NodeID: "node-2", JobID: "web", AllocID: "alloc-2"},
+	}}
+	newSvc := make(chan Service, 10)
+	l := &NomadListener{
+		services:   map[string]Service{},
+		client:     backend,
+		newService: newSvc,
+		delService: make(chan Service, 10),
+	}
+
+	l.refreshServices()
+	assert.Len(t, newSvc, 2)
+}
+
+type fakeNomadNodeIDGetter struct {
+	nodeID string
+	err    error
+}
+
+func (f fakeNomadNodeIDGetter) LocalNodeID(_ context.Context) (string, error) {
+	return f.nodeID, f.err
+}
+
+func TestNomadListenerLocalNodeID(t *testing.T) {
+	nodeID, err := localNodeID(context.Background(), fakeNomadNodeIDGetter{nodeID: "node-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "node-1", nodeID)
+
+	// server-only agents have no node, all the services are listed
+	nodeID, err = localNodeID(context.Background(), fakeNomadNodeIDGetter{err: nomad.ErrNotClientMode})
+	require.NoError(t, err)
+	assert.Empty(t, nodeID)
+
+	_, err = localNodeID(context.Background(), fakeNomadNodeIDGetter{err: errors.New("connection refused")})
+	assert.Error(t, err)
+}
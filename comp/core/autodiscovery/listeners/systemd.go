@@ -0,0 +1,202 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build !serverless
+
+package listeners
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/integration"
+	taggercommon "github.com/DataDog/datadog-agent/comp/core/tagger/common"
+	tagger "github.com/DataDog/datadog-agent/comp/core/tagger/def"
+	"github.com/DataDog/datadog-agent/comp/core/tagger/types"
+	workloadfilter "github.com/DataDog/datadog-agent/comp/core/workloadfilter/def"
+	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+// systemdADIdentifierPrefix is the prefix of the AD identifiers of systemd
+// units, e.g. "systemd://nginx.service".
+const systemdADIdentifierPrefix = "systemd://"
+
+// SystemdListener listens to systemd unit events through a subscription to
+// the workloadmeta store and creates one service per active unit.
+type SystemdListener struct {
+	workloadmetaListener
+	tagger tagger.Component
+}
+
+// NewSystemdListener returns a new SystemdListener.
+func NewSystemdListener(options ServiceListernerDeps) (ServiceListener, error) {
+	const name = "ad-systemdlistener"
+	l := &SystemdListener{
+		tagger: options.Tagger,
+	}
+	filter := workloadmeta.NewFilterBuilder().
+		SetSource(workloadmeta.SourceAll).
+		AddKind(workloadmeta.KindSystemdUnit).Build()
+
+	wmetaInstance, ok := options.Wmeta.Get()
+	if !ok {
+		return nil, errors.New("workloadmeta store is not initialized")
+	}
+	var err error
+	l.workloadmetaListener, err = newWorkloadmetaListener(name, filter, l.createSystemdService, wmetaInstance, options.Telemetry)
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+func (l *SystemdListener) createSystemdService(entity workloadmeta.Entity) {
+	unit := entity.(*workloadmeta.SystemdUnit)
+
+	svc := &SystemdService{
+		unit:          unit,
+		adIdentifiers: systemdADIdentifiers(unit.ID),
+		tagsHash:      l.tagger.GetEntityHash(types.NewEntityID(types.SystemdUnit, unit.ID), types.ChecksConfigCardinality),
+		pid:           int(unit.MainPID),
+		// Host units are accessible at localhost
+		hosts:  map[string]string{"host": "127.0.0.1"},
+		ready:  true,
+		tagger: l.tagger,
+	}
+
+	svcID := buildSvcID(unit.GetID())
+	l.AddService(svcID, svc, "")
+}
+
+// systemdADIdentifiers returns the AD identifiers of a unit, from the most to
+// the least specific. Like systemd drop-in directories, templates can target
+// the unit itself, the template of an instantiated unit ("foo@.service") or
+// any dash-truncated prefix of its name ("foo-.service").
+func systemdADIdentifiers(unitName string) []string {
+	dot := strings.LastIndexByte(unitName, '.')
+	if dot <= 0 {
+		return []string{systemdADIdentifierPrefix + unitName}
+	}
+	prefix, suffix := unitName[:dot], unitName[dot:]
+
+	identifiers := []string{systemdADIdentifierPrefix + unitName}
+
+	if at := strings.IndexByte(prefix, '@'); at > 0 && at < len(prefix)-1 {
+		prefix = prefix[:at]
+		identifiers = append(identifiers, systemdADIdentifierPrefix+prefix+"@"+suffix)
+	}
+
+	for i := len(prefix) - 1; i > 0; i-- {
+		if prefix[i] == '-' {
+			identifiers = append(identifiers, systemdADIdentifierPrefix+prefix[:i+1]+suffix)
+		}
+	}
+
+	return identifiers
+}
+
+// SystemdService implements the Service interface for systemd unit entities.
+type SystemdService struct {
+	unit          *workloadmeta.SystemdUnit
+	adIdentifiers []string
+	tagsHash      string
+	hosts         map[string]string
+	pid           int
+	ready         bool
+	tagger        tagger.Component
+}
+
+var _ Service = &SystemdService{}
+
+// Equal returns whether the two services are equal
+func (s *SystemdService) Equal(o Service) bool {
+	s2, ok := o.(*SystemdService)
+	if !ok {
+		return false
+	}
+
+	return s.GetServiceID() == s2.GetServiceID() &&
+		reflect.DeepEqual(s.adIdentifiers, s2.adIdentifiers) &&
+		s.tagsHash == s2.tagsHash &&
+		reflect.DeepEqual(s.hosts, s2.hosts) &&
+		s.pid == s2.pid &&
+		s.ready == s2.ready
+}
+
+// GetServiceID returns the AD entity ID of the service.
+func (s *SystemdService) GetServiceID() string {
+	return buildSvcID(s.unit.GetID())
+}
+
+// GetADIdentifiers returns the service's AD identifiers.
+func (s *SystemdService) GetADIdentifiers() []string {
+	return s.adIdentifiers
+}
+
+// GetHosts returns the service's IPs for each host.
+func (s *SystemdService) GetHosts() (map[string]string, error) {
+	return s.hosts, nil
+}
+
+// GetPorts returns the ports exposed by the service. Systemd does not expose
+// the ports a unit listens on.
+func (s *SystemdService) GetPorts() ([]workloadmeta.ContainerPort, error) {
+	return []workloadmeta.ContainerPort{}, nil
+}
+
+// GetTags returns the tags associated with the service.
+func (s *SystemdService) GetTags() ([]string, error) {
+	return s.tagger.Tag(taggercommon.BuildTaggerEntityID(s.unit.GetID()), types.ChecksConfigCardinality)
+}
+
+// GetTagsWithCardinality returns the tags with given cardinality.
+func (s *SystemdService) GetTagsWithCardinality(cardinality string) ([]string, error) {
+	checkCard, err := types.StringToTagCardinality(cardinality)
+	if err == nil {
+		return s.tagger.Tag(taggercommon.BuildTaggerEntityID(s.unit.GetID()), checkCard)
+	}
+	log.Warnf("error converting cardinality %s to TagCardinality: %v", cardinality, err)
+	return s.GetTags()
+}
+
+// GetPid returns the main process ID of the unit.
+func (s *SystemdService) GetPid() (int, error) {
+	return s.pid, nil
+}
+
+// GetHostname returns the service's hostname.
+func (s *SystemdService) GetHostname() (string, error) {
+	return "", nil
+}
+
+// IsReady returns whether the service is ready.
+func (s *SystemdService) IsReady() bool {
+	return s.ready
+}
+
+// HasFilter returns whether the service should not collect certain data (logs
+// or metrics) due to filtering applied by filter.
+func (s *SystemdService) HasFilter(_ workloadfilter.Scope) bool {
+	return false
+}
+
+// FilterTemplates implements Service#FilterTemplates.
+func (s *SystemdService) FilterTemplates(_ map[string]integration.Config) {
+}
+
+// GetExtraConfig returns extra configuration associated with the service.
+func (s *SystemdService) GetExtraConfig(key string) (string, error) {
+	return "", fmt.Errorf("extra config %q is not supported for systemd services", key)
+}
+
+// GetImageName returns the image name for the monitored entity.
+// Not applicable for systemd units.
+func (s *SystemdService) GetImageName() string {
+	return ""
+}
@@ -0,0 +1,145 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build !serverless
+
+package listeners
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/assert"
+
+	taggerfxmock "github.com/DataDog/datadog-agent/comp/core/tagger/fx-mock"
+	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+)
+
+func TestCreateSystemdService(t *testing.T) {
+	unit := &workloadmeta.SystemdUnit{
+		EntityID: workloadmeta.EntityID{
+			Kind: workloadmeta.KindSystemdUnit,
+			ID:   "nginx.service",
+		},
+		EntityMeta: workloadmeta.EntityMeta{
+			Name: "nginx.service",
+		},
+		UnitType:    "service",
+		ActiveState: "active",
+		MainPID:     1234,
+		PIDs:        []int32{1234, 1235},
+	}
+
+	taggerComponent := taggerfxmock.SetupFakeTagger(t)
+	wlm := newTestWorkloadmetaListener(t)
+	listener := &SystemdListener{
+		workloadmetaListener: wlm,
+		tagger:               taggerComponent,
+	}
+
+	listener.createSystemdService(unit)
+
+	expectedSvc := wlmListenerSvc{
+		service: &SystemdService{
+			unit:          unit,
+			adIdentifiers: []string{"systemd://nginx.service"},
+			hosts:         map[string]string{"host": "127.0.0.1"},
+			pid:           1234,
+			ready:         true,
+		},
+	}
+
+	actualSvc, ok := wlm.services["systemd_unit://nginx.service"]
+	if !assert.True(t, ok, "expected service was not generated") {
+		return
+	}
+	if diff := cmp.Diff(expectedSvc, actualSvc,
+		cmp.AllowUnexported(wlmListenerSvc{}, SystemdService{}),
+		cmpopts.IgnoreFields(SystemdService{}, "tagger", "tagsHash")); diff != "" {
+		t.Errorf("service mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSystemdADIdentifiers(t *testing.T) {
+	tests := []struct {
+		unit     string
+		expected []string
+	}{
+		{
+			unit:     "nginx.service",
+			expected: []string{"systemd://nginx.service"},
+		},
+		{
+			unit: "getty@tty1.service",
+			expected: []string{
+				"systemd://getty@tty1.service",
+				"systemd://getty@.service",
+			},
+		},
+		{
+			unit: "foo-bar-baz.service",
+			expected: []string{
+				"systemd://foo-bar-baz.service",
+				"systemd://foo-bar-.service",
+				"systemd://foo-.service",
+			},
+		},
+		{
+			unit: "container-getty@1.service",
+			expected: []string{
+				"systemd://container-getty@1.service",
+				"systemd://container-getty@.service",
+				"systemd://container-.service",
+			},
+		},
+		{
+			unit:     "invalid",
+			expected: []string{"systemd://invalid"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.unit, func(t *testing.T) {
+			assert.Equal(t, tt.expected, systemdADIdentifiers(tt.unit))
+		})
+	}
+}
+
+func TestSystemdServiceInterface(t *testing.T) {
+	unit := &workloadmeta.SystemdUnit{
+		EntityID: workloadmeta.EntityID{
+			Kind: workloadmeta.KindSystemdUnit,
+			ID:   "redis.service",
+		},
+		MainPID: 42,
+	}
+
+	svc := &SystemdService{
+		unit:          unit,
+		adIdentifiers: systemdADIdentifiers(unit.ID),
+		hosts:         map[string]string{"host": "127.0.0.1"},
+		pid:           42,
+		ready:         true,
+		tagger:        taggerfxmock.SetupFakeTagger(t),
+	}
+
+	assert.Equal(t, "systemd_unit://redis.service", svc.GetServiceID())
+	assert.Equal(t, []string{"systemd://redis.service"}, svc.GetADIdentifiers())
+
+	pid, err := svc.GetPid()
+	assert.NoError(t, err)
+	assert.Equal(t, 42, pid)
+
+	ports, err := svc.GetPorts()
+	assert.NoError(t, err)
+	assert.Empty(t, ports)
+
+	_, err = svc.GetExtraConfig("namespace")
+	assert.Error(t, err)
+
+	assert.True(t, svc.Equal(svc))
+	assert.False(t, svc.Equal(&ProcessService{}))
+}
@@ -60,6 +60,10 @@ func (n *noopAutoConfig) GetAutodiscoveryErrors() map[string]map[string]types.Er
 	return map[string]map[string]types.ErrorMsgSet{}
 }
 
+func (n *noopAutoConfig) GetProviderStatus() map[string]map[string]string {
+	return map[string]map[string]string{}
+}
+
 func (n *noopAutoConfig) AddConfigProviderFromCatalog(constants.ConfigurationProviders) error {
 	return nil
 }
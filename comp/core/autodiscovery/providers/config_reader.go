@@ -413,16 +413,23 @@ const emptyFileError = "empty file"
 
 // GetIntegrationConfigFromFile returns an instance of integration.Config if `fpath` points to a valid config file
 func GetIntegrationConfigFromFile(name, fpath string) (integration.Config, ConfigFormatWrapper, error) {
-	cf := configFormat{}
-	conf := integration.Config{Name: name}
-
 	// Read file contents
 	// FIXME: ReadFile reads the entire file, possible security implications
 	yamlFile, err := os.ReadFile(fpath)
 	if err != nil {
-		return conf, ConfigFormatWrapper{}, err
+		return integration.Config{Name: name}, ConfigFormatWrapper{}, err
 	}
 
+	return getIntegrationConfigFromBytes(name, fpath, yamlFile)
+}
+
+// getIntegrationConfigFromBytes returns an instance of integration.Config if
+// `yamlFile` is a valid config file. `fpath` is the location the content was
+// read from.
+func getIntegrationConfigFromBytes(name, fpath string, yamlFile []byte) (integration.Config, ConfigFormatWrapper, error) {
+	cf := configFormat{}
+	conf := integration.Config{Name: name}
+
 	// Check for empty file and return special error if so
 	if len(yamlFile) == 0 {
 		return conf, ConfigFormatWrapper{}, errors.New(emptyFileError)
@@ -23,6 +23,8 @@ const (
 	Etcd = "etcd"
 	// File loads check configurations from YAML files in the conf.d directory.
 	File = "file"
+	// Nomad discovers check configurations from Nomad job meta for services registered in Nomad.
+	Nomad = "nomad"
 	// KubeContainer is an all-in-one provider that handles both pod and container annotations.
 	KubeContainer = "kubernetes-container-allinone"
 	// Kubernetes discovers checks from Kubernetes pod annotations.
@@ -65,6 +67,8 @@ const (
 	DataSecurity = "data-security"
 	// DOQueryActions provides check configurations for Database Observability query-level actions.
 	DOQueryActions = "do-query-actions"
+	// PulledConfigs loads check configurations pulled from an HTTP endpoint or a git checkout, verified by a signed manifest.
+	PulledConfigs = "pulled-configs"
 	// PrometheusHTTPSD discovers check configurations from a Prometheus HTTP Service Discovery endpoint.
 	PrometheusHTTPSD = "prometheus-http-sd"
 	// InstrumentationChecks pulls AD configurations derived from DatadogInstrumentation CRs via the cluster-agent.
@@ -95,9 +99,11 @@ const (
 	KubeEndpointsRegisterName         = "kube_endpoints"
 	KubeEndpointsFileRegisterName     = "kube_endpoints_file"
 	KubeCrdRegisterName               = "kube_crd"
+	NomadRegisterName                 = "nomad"
 	PrometheusPodsRegisterName        = "prometheus_pods"
 	PrometheusServicesRegisterName    = "prometheus_services"
 	PrometheusHTTPSDRegisterName      = "prometheus_http_sd"
+	PulledConfigsRegisterName         = "pulled_configs"
 	InstrumentationChecksRegisterName = "instrumentation_checks"
 	RemoteConfigRegisterName          = "remote_config"
 	ZookeeperRegisterName             = "zookeeper"
@@ -325,7 +325,7 @@ func translateEndpoint(testConfigID, testConfigName string, configTags []string,
 	if endpoint.Protocol != nil {
 		protocol := payload.Protocol(strings.ToUpper(strings.TrimSpace(*endpoint.Protocol)))
 		switch protocol {
-		case payload.ProtocolTCP, payload.ProtocolUDP, payload.ProtocolICMP:
+		case payload.ProtocolTCP, payload.ProtocolUDP, payload.ProtocolICMP, payload.ProtocolDNS:
 			instance.Protocol = string(protocol)
 		default:
 			return networkPathInstanceConfig{}, fmt.Errorf("unsupported protocol %q", *endpoint.Protocol)
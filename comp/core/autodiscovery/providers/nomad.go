@@ -0,0 +1,184 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package providers
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/common/utils"
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/integration"
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/providers/names"
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/providers/types"
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/telemetry"
+	pkgconfigsetup "github.com/DataDog/datadog-agent/pkg/config/setup"
+	"github.com/DataDog/datadog-agent/pkg/config/setup/constants"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+	"github.com/DataDog/datadog-agent/pkg/util/nomad"
+)
+
+// Abstraction for testing
+type nomadBackend interface {
+	ListServices(ctx context.Context) ([]nomad.ServiceListStub, uint64, error)
+	ListServiceRegistrations(ctx context.Context) ([]nomad.ServiceRegistration, uint64, error)
+	GetJob(ctx context.Context, namespace, jobID string) (*nomad.Job, error)
+}
+
+// nomadService identifies a service declared by a Nomad job
+type nomadService struct {
+	namespace string
+	jobID     string
+	name      string
+}
+
+// NomadConfigProvider implements the ConfigProvider interface. It collects
+// check and logs templates from the meta annotations of the Nomad jobs that
+// register services in the Nomad native service discovery.
+//
+// Templates use the same format as Kubernetes pod annotations, keyed by the
+// service name, e.g. `ad.datadoghq.com/redis.checks`. They can be set in the
+// job, group or task meta blocks; the most specific level wins. Templates
+// are matched against the services emitted by the Nomad listener, which
+// resolves template variables from the allocation address and port.
+type NomadConfigProvider struct {
+	client        nomadBackend
+	mostRecentIdx uint64
+
+	configErrors   map[string]types.ErrorMsgSet
+	configErrorsMu sync.RWMutex
+}
+
+// NewNomadConfigProvider creates a new NomadConfigProvider. The connection to
+// Nomad is configured by the `nomad` section of the agent configuration, the
+// provider's `template_url` and `token` take precedence when set.
+func NewNomadConfigProvider(providerConfig *constants.ConfigurationProviders, _ *telemetry.Store) (types.ConfigProvider, error) {
+	nomadConfig := nomad.ConfigFromAgentConfig(pkgconfigsetup.Datadog())
+	if providerConfig != nil {
+		if providerConfig.TemplateURL != "" {
+			nomadConfig.URL = providerConfig.TemplateURL
+		}
+		if providerConfig.Token != "" {
+			nomadConfig.Token = providerConfig.Token
+		}
+	}
+
+	client, err := nomad.NewClient(nomadConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NomadConfigProvider{
+		client:       client,
+		configErrors: make(map[string]types.ErrorMsgSet),
+	}, nil
+}
+
+// String returns a string representation of the NomadConfigProvider
+func (p *NomadConfigProvider) String() string {
+	return names.Nomad
+}
+
+// IsUpToDate returns whether the Nomad service registrations changed since the last call
+func (p *NomadConfigProvider) IsUpToDate(ctx context.Context) (bool, error) {
+	_, index, err := p.client.ListServices(ctx)
+	if err != nil {
+		return false, err
+	}
+	if index == 0 || index != p.mostRecentIdx {
+		log.Debugf("Nomad service index was %d and is now %d", p.mostRecentIdx, index)
+		p.mostRecentIdx = index
+		return false, nil
+	}
+	return true, nil
+}
+
+// Collect retrieves the templates declared by the jobs of the registered services
+func (p *NomadConfigProvider) Collect(ctx context.Context) ([]integration.Config, error) {
+	registrations, _, err := p.client.ListServiceRegistrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	services := make(map[nomadService]struct{})
+	for _, reg := range registrations {
+		services[nomadService{namespace: reg.Namespace, jobID: reg.JobID, name: reg.ServiceName}] = struct{}{}
+	}
+
+	jobs := make(map[nomadService]*nomad.Job)
+	configErrors := make(map[string]types.ErrorMsgSet)
+	configs := make([]integration.Config, 0)
+	for _, svc := range sortedNomadServices(services) {
+		jobKey := nomadService{namespace: svc.namespace, jobID: svc.jobID}
+		job, found := jobs[jobKey]
+		if !found {
+			job, err = p.client.GetJob(ctx, svc.namespace, svc.jobID)
+			if err != nil {
+				log.Warnf("Cannot get nomad job %s in namespace %s: %s", svc.jobID, svc.namespace, err)
+				continue
+			}
+			jobs[jobKey] = job
+		}
+
+		meta, found := job.ServiceMeta(svc.name)
+		if !found {
+			continue
+		}
+
+		adID := nomad.ADIdentifier(svc.namespace, svc.jobID, svc.name)
+		templates, errs := utils.ExtractTemplatesFromAnnotations(adID, meta, svc.name)
+		if len(errs) > 0 {
+			errMsgSet := make(types.ErrorMsgSet)
+			for _, err := range errs {
+				log.Errorf("Cannot parse templates for nomad service %s: %s", adID, err)
+				errMsgSet[err.Error()] = struct{}{}
+			}
+			configErrors[adID] = errMsgSet
+		}
+
+		for idx := range templates {
+			templates[idx].Source = "nomad:" + adID
+		}
+		configs = append(configs, templates...)
+	}
+
+	p.configErrorsMu.Lock()
+	p.configErrors = configErrors
+	p.configErrorsMu.Unlock()
+
+	return configs, nil
+}
+
+// GetConfigErrors returns the templates that couldn't be parsed, keyed by AD identifier
+func (p *NomadConfigProvider) GetConfigErrors() map[string]types.ErrorMsgSet {
+	p.configErrorsMu.RLock()
+	defer p.configErrorsMu.RUnlock()
+
+	errors := make(map[string]types.ErrorMsgSet, len(p.configErrors))
+	for adID, errs := range p.configErrors {
+		errors[adID] = errs
+	}
+	return errors
+}
+
+// sortedNomadServices returns the services in a stable order, so that
+// Collect returns the configs in the same order between calls
+func sortedNomadServices(services map[nomadService]struct{}) []nomadService {
+	sorted := make([]nomadService, 0, len(services))
+	for svc := range services {
+		sorted = append(sorted, svc)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].namespace != sorted[j].namespace {
+			return sorted[i].namespace < sorted[j].namespace
+		}
+		if sorted[i].jobID != sorted[j].jobID {
+			return sorted[i].jobID < sorted[j].jobID
+		}
+		return sorted[i].name < sorted[j].name
+	})
+	return sorted
+}
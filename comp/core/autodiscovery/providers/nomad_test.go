@@ -0,0 +1,117 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package providers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/integration"
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/providers/types"
+	"github.com/DataDog/datadog-agent/pkg/util/nomad"
+)
+
+type fakeNomadBackend struct {
+	index         uint64
+	registrations []nomad.ServiceRegistration
+	jobs          map[string]*nomad.Job
+}
+
+func (f *fakeNomadBackend) ListServices(_ context.Context) ([]nomad.ServiceListStub, uint64, error) {
+	return nil, f.index, nil
+}
+
+func (f *fakeNomadBackend) ListServiceRegistrations(_ context.Context) ([]nomad.ServiceRegistration, uint64, error) {
+	return f.registrations, f.index, nil
+}
+
+func (f *fakeNomadBackend) GetJob(_ context.Context, namespace, jobID string) (*nomad.Job, error) {
+	job, found := f.jobs[namespace+"/"+jobID]
+	if !found {
+		return nil, errors.New("job not found")
+	}
+	return job, nil
+}
+
+func TestNomadCollect(t *testing.T) {
+	backend := &fakeNomadBackend{
+		index: 10,
+		registrations: []nomad.ServiceRegistration{
+			{ID: "reg-1", ServiceName: "redis", Namespace: "default", JobID: "cache", AllocID: "alloc-1"},
+			{ID: "reg-2", ServiceName: "redis", Namespace: "default", JobID: "cache", AllocID: "alloc-2"},
+			{ID: "reg-3", ServiceName: "web", Namespace: "default", JobID: "frontend", AllocID: "alloc-3"},
+			{ID: "reg-4", ServiceName: "api", Namespace: "default", JobID: "unknown", AllocID: "alloc-4"},
+			{ID: "reg-5", ServiceName: "broken", Namespace: "default", JobID: "frontend", AllocID: "alloc-3"},
+		},
+		jobs: map[string]*nomad.Job{
+			"default/cache": {
+				ID: "cache",
+				Meta: map[string]string{
+					"ad.datadoghq.com/redis.checks": `{"redisdb": {"instances": [{"host": "%%host%%", "port": "%%port%%"}]}}`,
+				},
+				TaskGroups: []nomad.TaskGroup{{
+					Name:     "cache",
+					Services: []nomad.Service{{Name: "redis"}},
+				}},
+			},
+			"default/frontend": {
+				ID: "frontend",
+				TaskGroups: []nomad.TaskGroup{{
+					Name: "frontend",
+					Tasks: []nomad.Task{{
+						Name: "nginx",
+						Meta: map[string]string{
+							"ad.datadoghq.com/web.logs":      `[{"source": "nginx", "service": "web"}]`,
+							"ad.datadoghq.com/broken.checks": `{"invalid json`,
+						},
+						Services: []nomad.Service{{Name: "web"}, {Name: "broken"}},
+					}},
+				}},
+			},
+		},
+	}
+	provider := &NomadConfigProvider{client: backend, configErrors: make(map[string]types.ErrorMsgSet)}
+
+	configs, err := provider.Collect(context.Background())
+	require.NoError(t, err)
+	require.Len(t, configs, 2)
+
+	assert.Equal(t, "redisdb", configs[0].Name)
+	assert.Equal(t, []string{"nomad://default/cache/redis"}, configs[0].ADIdentifiers)
+	assert.Equal(t, "nomad:nomad://default/cache/redis", configs[0].Source)
+	require.Len(t, configs[0].Instances, 1)
+	assert.Equal(t, integration.Data(`{"host":"%%host%%","port":"%%port%%"}`), configs[0].Instances[0])
+
+	assert.Equal(t, []string{"nomad://default/frontend/web"}, configs[1].ADIdentifiers)
+	assert.Equal(t, integration.Data(`[{"service":"web","source":"nginx"}]`), configs[1].LogsConfig)
+
+	configErrors := provider.GetConfigErrors()
+	assert.Len(t, configErrors, 1)
+	assert.Contains(t, configErrors, "nomad://default/frontend/broken")
+}
+
+func TestNomadIsUpToDate(t *testing.T) {
+	backend := &fakeNomadBackend{index: 10}
+	provider := &NomadConfigProvider{client: backend}
+	ctx := context.Background()
+
+	upToDate, err := provider.IsUpToDate(ctx)
+	require.NoError(t, err)
+	assert.False(t, upToDate)
+
+	upToDate, err = provider.IsUpToDate(ctx)
+	require.NoError(t, err)
+	assert.True(t, upToDate)
+
+	backend.index = 11
+	upToDate, err = provider.IsUpToDate(ctx)
+	require.NoError(t, err)
+	assert.False(t, upToDate)
+}
@@ -82,6 +82,7 @@ func RegisterProviders(providerCatalog map[string]types.ConfigProviderFactory) {
 	RegisterProvider(names.EndpointsChecksRegisterName, NewEndpointsChecksConfigProvider, providerCatalog)
 	RegisterProvider(names.InstrumentationChecksRegisterName, NewInstrumentationChecksConfigProvider, providerCatalog)
 	RegisterProvider(names.EtcdRegisterName, NewEtcdConfigProvider, providerCatalog)
+	RegisterProvider(names.NomadRegisterName, NewNomadConfigProvider, providerCatalog)
 	RegisterProvider(names.KubeServicesFileRegisterName, NewKubeServiceFileConfigProvider, providerCatalog)
 	RegisterProviderWithHealthPlatform(names.KubeServicesRegisterName, NewKubeServiceConfigProvider, providerCatalog)
 	RegisterProviderWithComponents(names.PrometheusPodsRegisterName, NewPrometheusPodsConfigProvider, providerCatalog)
@@ -101,4 +102,5 @@ func RegisterProviders(providerCatalog map[string]types.ConfigProviderFactory) {
 	RegisterProviderWithHealthPlatform(names.KubeEndpointsRegisterName, endpointsProvider, providerCatalog)
 	RegisterProvider(names.KubeCrdRegisterName, NewKubeCRDConfigProvider, providerCatalog)
 	RegisterProvider(names.PrometheusHTTPSDRegisterName, NewPrometheusHTTPSDConfigProvider, providerCatalog)
+	RegisterProvider(names.PulledConfigsRegisterName, NewPulledConfigsProvider, providerCatalog)
 }
@@ -0,0 +1,551 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package providers
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/integration"
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/providers/names"
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/providers/types"
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/telemetry"
+	pkgconfigmodel "github.com/DataDog/datadog-agent/pkg/config/model"
+	pkgconfigsetup "github.com/DataDog/datadog-agent/pkg/config/setup"
+	"github.com/DataDog/datadog-agent/pkg/config/setup/constants"
+	httputils "github.com/DataDog/datadog-agent/pkg/util/http"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+// maxPulledFileSize is the maximum size of the manifest, signature and config
+// files fetched by the PulledConfigsProvider
+const maxPulledFileSize = 4 * 1024 * 1024
+
+// pulledConfigsSource abstracts the location the configs are pulled from
+type pulledConfigsSource interface {
+	// refresh updates the source before it is read
+	refresh(ctx context.Context) error
+	// read returns the content of a file, relative to the root of the source
+	read(ctx context.Context, name string) ([]byte, error)
+	// location returns a human-readable location of a file of the source,
+	// without any credentials, suitable for logs and status pages
+	location(name string) string
+}
+
+// httpConfigsSource reads files relative to a base URL
+type httpConfigsSource struct {
+	baseURL *url.URL
+	client  *http.Client
+}
+
+func (s *httpConfigsSource) refresh(_ context.Context) error {
+	return nil
+}
+
+func (s *httpConfigsSource) read(ctx context.Context, name string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL.JoinPath(name).String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d fetching %s", resp.StatusCode, s.location(name))
+	}
+
+	return readLimited(resp.Body)
+}
+
+func (s *httpConfigsSource) location(name string) string {
+	return s.baseURL.JoinPath(name).Redacted()
+}
+
+// localConfigsSource reads files from a local directory, usually a git checkout
+type localConfigsSource struct {
+	root    string
+	gitPull bool
+}
+
+func (s *localConfigsSource) refresh(ctx context.Context) error {
+	if !s.gitPull {
+		return nil
+	}
+	out, err := exec.CommandContext(ctx, "git", "-C", s.root, "pull", "--ff-only", "--quiet").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git pull in %s failed: %v: %s", s.root, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func (s *localConfigsSource) read(_ context.Context, name string) ([]byte, error) {
+	f, err := os.Open(s.location(name))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return readLimited(f)
+}
+
+func (s *localConfigsSource) location(name string) string {
+	return filepath.Join(s.root, filepath.FromSlash(name))
+}
+
+func readLimited(r io.Reader) ([]byte, error) {
+	content, err := io.ReadAll(io.LimitReader(r, maxPulledFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > maxPulledFileSize {
+		return nil, fmt.Errorf("file exceeds the maximum size of %d bytes", maxPulledFileSize)
+	}
+	return content, nil
+}
+
+// manifestEntry is a file listed in the checksum manifest
+type manifestEntry struct {
+	path     string
+	checksum string
+}
+
+// pulledFile is a config file applied by the provider
+type pulledFile struct {
+	checksum string
+	config   integration.Config
+}
+
+// pulledConfigsStatus holds the state of the last synchronizations
+type pulledConfigsStatus struct {
+	lastSync        time.Time
+	lastSuccess     time.Time
+	lastError       string
+	manifestDigest  string
+	signatureStatus string
+}
+
+// PulledConfigsProvider implements the ConfigProvider interface. It pulls
+// conf.d-style check configs from an HTTP endpoint or a local directory, such
+// as a git checkout, and applies them once verified.
+//
+// The source must contain a checksum manifest in the `sha256sum` format,
+// listing every config file with its SHA-256 digest. When a public key is
+// configured, the manifest must be signed with the matching Ed25519 private
+// key, the detached signature being stored next to the manifest. Files are
+// only applied when the manifest signature and their checksum are valid;
+// otherwise the previously applied version is kept.
+type PulledConfigsProvider struct {
+	source        pulledConfigsSource
+	manifestName  string
+	signatureName string
+	publicKey     ed25519.PublicKey
+
+	mu           sync.RWMutex
+	files        map[string]pulledFile
+	status       pulledConfigsStatus
+	configErrors map[string]types.ErrorMsgSet
+
+	// manifest is the manifest read by IsUpToDate, reused by the following
+	// Collect so that the source is refreshed once per poll
+	manifest []byte
+}
+
+// NewPulledConfigsProvider creates a new PulledConfigsProvider from the
+// `pulled_configs` section of the agent configuration.
+func NewPulledConfigsProvider(_ *constants.ConfigurationProviders, _ *telemetry.Store) (types.ConfigProvider, error) {
+	return newPulledConfigsProvider(pkgconfigsetup.Datadog())
+}
+
+func newPulledConfigsProvider(cfg pkgconfigmodel.Reader) (*PulledConfigsProvider, error) {
+	var source pulledConfigsSource
+	rawURL := cfg.GetString("pulled_configs.url")
+	localPath := cfg.GetString("pulled_configs.path")
+	switch {
+	case rawURL != "" && localPath != "":
+		return nil, errors.New("pulled_configs: url and path are mutually exclusive")
+	case rawURL != "":
+		baseURL, err := url.Parse(rawURL)
+		if err != nil {
+			return nil, fmt.Errorf("pulled_configs: invalid url: %v", err)
+		}
+		source = &httpConfigsSource{
+			baseURL: baseURL,
+			client: &http.Client{
+				Transport: httputils.CreateHTTPTransport(cfg),
+				Timeout:   time.Duration(cfg.GetInt("pulled_configs.timeout")) * time.Second,
+			},
+		}
+	case localPath != "":
+		source = &localConfigsSource{
+			root:    localPath,
+			gitPull: cfg.GetBool("pulled_configs.git_pull"),
+		}
+	default:
+		return nil, errors.New("pulled_configs: either url or path must be set")
+	}
+
+	manifestName := cfg.GetString("pulled_configs.manifest")
+	if manifestName == "" {
+		manifestName = "SHA256SUMS"
+	}
+	signatureName := cfg.GetString("pulled_configs.signature")
+	if signatureName == "" {
+		signatureName = manifestName + ".sig"
+	}
+
+	var publicKey ed25519.PublicKey
+	if keyFile := cfg.GetString("pulled_configs.public_key_file"); keyFile != "" {
+		var err error
+		if publicKey, err = loadEd25519PublicKey(keyFile); err != nil {
+			return nil, fmt.Errorf("pulled_configs: %v", err)
+		}
+	} else if cfg.GetBool("pulled_configs.require_signature") {
+		return nil, errors.New("pulled_configs: public_key_file is required unless require_signature is false")
+	}
+
+	return &PulledConfigsProvider{
+		source:        source,
+		manifestName:  manifestName,
+		signatureName: signatureName,
+		publicKey:     publicKey,
+		files:         make(map[string]pulledFile),
+		configErrors:  make(map[string]types.ErrorMsgSet),
+	}, nil
+}
+
+// String returns a string representation of the PulledConfigsProvider
+func (p *PulledConfigsProvider) String() string {
+	return names.PulledConfigs
+}
+
+// IsUpToDate refreshes the source and returns whether its manifest changed
+// since the last successful synchronization. It returns false while files of
+// the manifest couldn't be applied, so that they are retried on every poll.
+func (p *PulledConfigsProvider) IsUpToDate(ctx context.Context) (bool, error) {
+	manifest, err := p.refreshManifest(ctx)
+	if err != nil {
+		p.recordSyncError(err)
+		return false, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.manifest = manifest
+	return p.status.lastError == "" && len(p.configErrors) == 0 && p.status.manifestDigest == digest(manifest), nil
+}
+
+// refreshManifest refreshes the source and reads its manifest
+func (p *PulledConfigsProvider) refreshManifest(ctx context.Context) ([]byte, error) {
+	if err := p.source.refresh(ctx); err != nil {
+		return nil, err
+	}
+	manifest, err := p.source.read(ctx, p.manifestName)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read manifest: %v", err)
+	}
+	return manifest, nil
+}
+
+// Collect pulls and verifies the configs listed in the manifest. Only the
+// files whose checksum changed are fetched again.
+func (p *PulledConfigsProvider) Collect(ctx context.Context) ([]integration.Config, error) {
+	configs, err := p.sync(ctx)
+	if err != nil {
+		p.recordSyncError(err)
+		return nil, err
+	}
+	return configs, nil
+}
+
+func (p *PulledConfigsProvider) sync(ctx context.Context) ([]integration.Config, error) {
+	// reuse the manifest read by IsUpToDate, if any, rather than refreshing
+	// the source a second time
+	p.mu.Lock()
+	manifest := p.manifest
+	p.manifest = nil
+	p.mu.Unlock()
+
+	if manifest == nil {
+		var err error
+		if manifest, err = p.refreshManifest(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	signatureStatus := "not verified (checksums only)"
+	if p.publicKey != nil {
+		rawSignature, err := p.source.read(ctx, p.signatureName)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read manifest signature: %v", err)
+		}
+		if err := verifyManifestSignature(p.publicKey, manifest, rawSignature); err != nil {
+			return nil, err
+		}
+		signatureStatus = "verified"
+	}
+
+	entries, err := parseManifest(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.RLock()
+	previous := p.files
+	p.mu.RUnlock()
+
+	files := make(map[string]pulledFile, len(entries))
+	configErrors := make(map[string]types.ErrorMsgSet)
+	for _, entry := range entries {
+		if prev, found := previous[entry.path]; found && prev.checksum == entry.checksum {
+			files[entry.path] = prev
+			continue
+		}
+
+		config, err := p.fetchConfig(ctx, entry)
+		if err != nil {
+			log.Warnf("pulled_configs: cannot apply %s: %v", p.source.location(entry.path), err)
+			configErrors[entry.path] = types.ErrorMsgSet{err.Error(): struct{}{}}
+			// keep the last valid version of the file, if any
+			if prev, found := previous[entry.path]; found {
+				files[entry.path] = prev
+			}
+			continue
+		}
+		files[entry.path] = pulledFile{checksum: entry.checksum, config: config}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.files = files
+	p.configErrors = configErrors
+	now := time.Now()
+	p.status.lastSync = now
+	p.status.lastSuccess = now
+	p.status.lastError = ""
+	p.status.manifestDigest = digest(manifest)
+	p.status.signatureStatus = signatureStatus
+
+	return p.configsLocked(), nil
+}
+
+func (p *PulledConfigsProvider) fetchConfig(ctx context.Context, entry manifestEntry) (integration.Config, error) {
+	name, err := integrationNameFromPath(entry.path)
+	if err != nil {
+		return integration.Config{}, err
+	}
+
+	content, err := p.source.read(ctx, entry.path)
+	if err != nil {
+		return integration.Config{}, err
+	}
+	if checksum := digest(content); checksum != entry.checksum {
+		return integration.Config{}, fmt.Errorf("checksum mismatch: expected %s, got %s", entry.checksum, checksum)
+	}
+
+	location := p.source.location(entry.path)
+	config, _, err := getIntegrationConfigFromBytes(name, location, content)
+	if err != nil {
+		return integration.Config{}, err
+	}
+	config.Source = "pulled_configs:" + location
+	return config, nil
+}
+
+// configsLocked returns the applied configs, sorted by file path. It must be
+// called with p.mu held.
+func (p *PulledConfigsProvider) configsLocked() []integration.Config {
+	paths := make([]string, 0, len(p.files))
+	for filePath := range p.files {
+		paths = append(paths, filePath)
+	}
+	sort.Strings(paths)
+
+	configs := make([]integration.Config, 0, len(paths))
+	for _, filePath := range paths {
+		configs = append(configs, p.files[filePath].config)
+	}
+	return configs
+}
+
+func (p *PulledConfigsProvider) recordSyncError(err error) {
+	log.Warnf("pulled_configs: synchronization failed: %v", err)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.status.lastSync = time.Now()
+	p.status.lastError = err.Error()
+}
+
+// GetConfigErrors returns the errors of the files that couldn't be applied on
+// the last synchronization, keyed by file path
+func (p *PulledConfigsProvider) GetConfigErrors() map[string]types.ErrorMsgSet {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	errors := make(map[string]types.ErrorMsgSet, len(p.configErrors)+1)
+	for filePath, errs := range p.configErrors {
+		errors[filePath] = errs
+	}
+	if p.status.lastError != "" {
+		errors[p.manifestName] = types.ErrorMsgSet{p.status.lastError: struct{}{}}
+	}
+	return errors
+}
+
+// GetStatus returns the state of the last synchronizations
+func (p *PulledConfigsProvider) GetStatus() map[string]string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	status := map[string]string{
+		"Source":  p.source.location(""),
+		"Configs": strconv.Itoa(len(p.files)),
+	}
+	if p.status.lastSync.IsZero() {
+		status["Last Sync"] = "never"
+		return status
+	}
+
+	status["Last Sync"] = p.status.lastSync.Format(time.RFC3339)
+	if p.status.lastError != "" {
+		status["Last Sync Status"] = "error: " + p.status.lastError
+	} else {
+		status["Last Sync Status"] = "OK"
+	}
+	if !p.status.lastSuccess.IsZero() {
+		status["Last Successful Sync"] = p.status.lastSuccess.Format(time.RFC3339)
+		status["Manifest SHA256"] = p.status.manifestDigest
+		status["Manifest Signature"] = p.status.signatureStatus
+	}
+	return status
+}
+
+// parseManifest parses a checksum manifest in the `sha256sum` format: one
+// `<hex digest>  <relative path>` line per file
+func parseManifest(manifest []byte) ([]manifestEntry, error) {
+	var entries []manifestEntry
+	seen := make(map[string]struct{})
+	for i, line := range strings.Split(string(manifest), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		checksum, filePath, found := strings.Cut(line, " ")
+		if !found {
+			return nil, fmt.Errorf("invalid manifest line %d: %q", i+1, line)
+		}
+		// `sha256sum --binary` prefixes file names with `*`
+		filePath = strings.TrimPrefix(strings.TrimSpace(filePath), "*")
+		checksum = strings.ToLower(checksum)
+
+		if decoded, err := hex.DecodeString(checksum); err != nil || len(decoded) != sha256.Size {
+			return nil, fmt.Errorf("invalid checksum on manifest line %d", i+1)
+		}
+		if err := validateManifestPath(filePath); err != nil {
+			return nil, fmt.Errorf("invalid path on manifest line %d: %v", i+1, err)
+		}
+		if _, found := seen[filePath]; found {
+			return nil, fmt.Errorf("duplicate path on manifest line %d: %s", i+1, filePath)
+		}
+		seen[filePath] = struct{}{}
+
+		entries = append(entries, manifestEntry{path: filePath, checksum: checksum})
+	}
+	return entries, nil
+}
+
+func validateManifestPath(filePath string) error {
+	if filePath == "" {
+		return errors.New("empty path")
+	}
+	if path.IsAbs(filePath) || path.Clean(filePath) != filePath || strings.HasPrefix(filePath, "../") || filePath == ".." {
+		return fmt.Errorf("%q must be a clean relative path", filePath)
+	}
+	if ext := path.Ext(filePath); ext != ".yaml" && ext != ".yml" {
+		return fmt.Errorf("%q is not a YAML file", filePath)
+	}
+	return nil
+}
+
+// integrationNameFromPath returns the integration a config file applies to,
+// following the conf.d layout: `<integration>.d/<file>.yaml` or `<integration>.yaml`
+func integrationNameFromPath(filePath string) (string, error) {
+	if dir := path.Base(path.Dir(filePath)); strings.HasSuffix(dir, ".d") && dir != ".d" {
+		return strings.TrimSuffix(dir, ".d"), nil
+	}
+
+	name := strings.TrimSuffix(path.Base(filePath), path.Ext(filePath))
+	if name == "" {
+		return "", fmt.Errorf("cannot infer the integration name of %s", filePath)
+	}
+	return name, nil
+}
+
+// loadEd25519PublicKey loads a PEM-encoded PKIX Ed25519 public key
+func loadEd25519PublicKey(keyFile string) (ed25519.PublicKey, error) {
+	raw, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read public key file: %v", err)
+	}
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", keyFile)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse public key %s: %v", keyFile, err)
+	}
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("public key %s is not an Ed25519 key", keyFile)
+	}
+	return publicKey, nil
+}
+
+// verifyManifestSignature verifies a detached Ed25519 signature of the
+// manifest. The signature is either raw or base64-encoded.
+func verifyManifestSignature(publicKey ed25519.PublicKey, manifest, rawSignature []byte) error {
+	signature := rawSignature
+	if len(signature) != ed25519.SignatureSize {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(rawSignature)))
+		if err != nil {
+			return errors.New("cannot decode manifest signature")
+		}
+		signature = decoded
+	}
+	if !ed25519.Verify(publicKey, manifest, signature) {
+		return errors.New("invalid manifest signature")
+	}
+	return nil
+}
+
+func digest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
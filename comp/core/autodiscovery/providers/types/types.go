@@ -72,6 +72,14 @@ type CollectingConfigProvider interface {
 	IsUpToDate(context.Context) (bool, error)
 }
 
+// StatusReporter is an interface used together with ConfigProvider.
+// ConfigProviders that have a state worth surfacing in diagnostic tools, such
+// as `agent status` and `agent configcheck`, should implement it.
+type StatusReporter interface {
+	// GetStatus returns human-readable status fields indexed by their name.
+	GetStatus() map[string]string
+}
+
 // StreamingConfigProvider is an interface used together with ConfigProvider.
 // ConfigProviders that are able to use streaming should implement it, and the
 // config poller will use Stream instead of Collect to collect config changes.
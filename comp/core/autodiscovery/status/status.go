@@ -14,6 +14,7 @@ import (
 	"github.com/DataDog/datadog-agent/comp/core/status"
 	workloadfilter "github.com/DataDog/datadog-agent/comp/core/workloadfilter/def"
 	"github.com/DataDog/datadog-agent/pkg/config/env"
+	pkgconfigmodel "github.com/DataDog/datadog-agent/pkg/config/model"
 )
 
 // populateStatus populates the status stats
@@ -21,6 +22,7 @@ func populateStatus(ac autodiscovery.Component, wf workloadfilter.Component, sta
 	stats["adEnabledFeatures"] = env.GetDetectedFeatures()
 	stats["adConfigErrors"] = ac.GetAutodiscoveryErrors()
 	stats["filterErrors"] = getAutodiscoveryFilterErrors(wf)
+	stats["adProviderStatus"] = ac.GetProviderStatus()
 }
 
 // GetAutodiscoveryFilterErrors returns a map of all autodiscovery filter errors
@@ -44,9 +46,10 @@ type Provider struct {
 	filterStore workloadfilter.Component
 }
 
-// GetProvider if agent is running in a container environment returns status.Provider otherwise returns nil
-func GetProvider(acComp autodiscovery.Component, filterStore workloadfilter.Component) status.Provider {
-	if env.IsContainerized() {
+// GetProvider returns status.Provider if the agent is running in a container environment or pulls
+// configs from a remote source, otherwise returns nil
+func GetProvider(acComp autodiscovery.Component, filterStore workloadfilter.Component, cfg pkgconfigmodel.Reader) status.Provider {
+	if env.IsContainerized() || cfg.GetString("pulled_configs.url") != "" || cfg.GetString("pulled_configs.path") != "" {
 		return Provider{ac: acComp, filterStore: filterStore}
 	}
 
@@ -33,6 +33,7 @@ type ConfigParams struct {
 	AllowedNamespace             []string
 	ImageToHandle                map[string][]string
 	APIKeyFailureRefreshInterval int
+	RefreshOnTTL                 bool
 }
 
 // Component is the component type.
@@ -9,6 +9,9 @@ package secrets
 type SecretVal struct {
 	Value    string `json:"value,omitempty"`
 	ErrorMsg string `json:"error,omitempty"`
+	// TTL is the number of seconds the value stays valid for, as reported by the backend (for
+	// example the lease duration of a dynamic Vault secret). 0 means the value doesn't expire.
+	TTL int64 `json:"ttl,omitempty"`
 }
 
 // SecretChangeCallback is the callback type used by SubscribeToChanges to send notifications
@@ -256,7 +256,8 @@ func (r *secretResolver) fetchSecret(handles []string) (map[string]string, error
 		}
 		for i, key := range g.keys {
 			if val, ok := res[key]; ok {
-				result[g.origHandles[i]] = val
+				result[g.origHandles[i]] = val.Value
+				r.recordSecretTTL(g.origHandles[i], val.TTL)
 			} else if err, ok := perHandleErrs[key]; ok {
 				errs = append(errs, fmt.Errorf("handle %q: %w", g.origHandles[i], err))
 			}
@@ -267,10 +268,10 @@ func (r *secretResolver) fetchSecret(handles []string) (map[string]string, error
 
 // fetchSingleBackend calls the secret backend command for a single backend type/config.
 // It returns:
-//   - resolved: map of secret key → resolved value for handles that succeeded
+//   - resolved: map of secret key → resolved value, and its TTL, for handles that succeeded
 //   - handleErrors: map of secret key → error for handles that failed individually
 //   - err: a global/fatal error (command failure, JSON unmarshal) that affects all handles
-func (r *secretResolver) fetchSingleBackend(backendType string, backendConfig map[string]interface{}, backendTimeout int, secretsHandle []string) (resolved map[string]string, handleErrors map[string]error, err error) {
+func (r *secretResolver) fetchSingleBackend(backendType string, backendConfig map[string]interface{}, backendTimeout int, secretsHandle []string) (resolved map[string]secrets.SecretVal, handleErrors map[string]error, err error) {
 	payload := map[string]interface{}{
 		"version":                secrets.PayloadVersion,
 		"secrets":                secretsHandle,
@@ -298,7 +299,7 @@ func (r *secretResolver) fetchSingleBackend(backendType string, backendConfig ma
 			r.backendCommand, err, secretsManagementDocsURL)
 	}
 
-	resolved = map[string]string{}
+	resolved = map[string]secrets.SecretVal{}
 	for _, sec := range secretsHandle {
 		v, ok := secretVals[sec]
 		if !ok {
@@ -331,7 +332,7 @@ func (r *secretResolver) fetchSingleBackend(backendType string, backendConfig ma
 			handleErrors[sec] = fmt.Errorf("resolved secret for '%s' is empty. Check that the secret exists in your backend and has a non-empty value. If using secret_backend_remove_trailing_line_break, trailing newlines are stripped. Docs: %s", sec, secretsManagementDocsURL)
 			continue
 		}
-		resolved[sec] = v.Value
+		resolved[sec] = v
 	}
 	return resolved, handleErrors, nil
 }
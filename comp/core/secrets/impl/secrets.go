@@ -47,6 +47,13 @@ import (
 
 const auditFileBasename = "secret-audit-file.json"
 
+const (
+	// secrets with a TTL are refreshed once this fraction of their TTL has elapsed
+	ttlRefreshRatio = 0.75
+	// minimum delay before refreshing a secret with a TTL, also used to retry failed refreshes
+	minTTLRefreshDelay = 10 * time.Second
+)
+
 var newClock = clock.New
 
 //go:embed status_templates
@@ -141,6 +148,17 @@ type secretResolver struct {
 	lastThrottledRefresh         time.Time
 
 	refreshTrigger chan struct{}
+
+	// refresh secrets before the TTL reported by the backend expires
+	refreshOnTTL bool
+	// ttlRefreshDeadlines holds when each handle with a TTL is due for a refresh
+	ttlRefreshDeadlines map[string]time.Time
+	// ttlUpdated wakes up the refresh routine when a deadline changes
+	ttlUpdated chan struct{}
+
+	// refreshRoutineOnce ensures the refresh routine is started once, either by
+	// Configure or when the first secret with a TTL is resolved
+	refreshRoutineOnce sync.Once
 }
 
 var _ secrets.Component = (*secretResolver)(nil)
@@ -156,6 +174,8 @@ func newEnabledSecretResolver(telemetry telemetry.Component) *secretResolver {
 		clk:                     newClock(),
 		unresolvedSecrets:       make(map[string]struct{}),
 		refreshTrigger:          make(chan struct{}, 1),
+		ttlRefreshDeadlines:     make(map[string]time.Time),
+		ttlUpdated:              make(chan struct{}, 1),
 		secretBackendMethod:     "secret_backend_command",
 	}
 }
@@ -366,8 +386,10 @@ func (r *secretResolver) Configure(params secrets.ConfigParams) {
 	r.imageToHandle = params.ImageToHandle
 
 	r.apiKeyFailureRefreshInterval = time.Duration(params.APIKeyFailureRefreshInterval) * time.Minute
+	r.refreshOnTTL = params.RefreshOnTTL
 
-	// If either timed interval refresh, or invalid key refresh, are set then we need a goroutine
+	// If either timed interval refresh or invalid key refresh are set then we need a goroutine. The TTL refresh
+	// only needs it once a backend returns a TTL, so it is started by recordSecretTTL.
 	if r.refreshInterval != 0 || r.apiKeyFailureRefreshInterval != 0 {
 		log.Debug("Secrets refresh routine starting...")
 		r.startRefreshRoutine(nil)
@@ -408,8 +430,24 @@ func (r *secretResolver) setupRefreshInterval(rd *rand.Rand) *clock.Ticker {
 }
 
 func (r *secretResolver) startRefreshRoutine(rd *rand.Rand) {
+	r.refreshRoutineOnce.Do(func() {
+		r.runRefreshRoutine(rd)
+	})
+}
+
+func (r *secretResolver) runRefreshRoutine(rd *rand.Rand) {
 	refreshTicker := r.setupRefreshInterval(rd)
 
+	// the TTL timer only runs while some secrets have a TTL
+	ttlTimer := r.clk.Timer(time.Hour * 24 * 365)
+	ttlTimer.Stop()
+	resetTTLTimer := func() {
+		ttlTimer.Stop()
+		if deadline, ok := r.nextTTLRefresh(); ok {
+			ttlTimer.Reset(max(deadline.Sub(r.clk.Now()), 0))
+		}
+	}
+
 	go func() {
 		for {
 			select {
@@ -444,6 +482,16 @@ func (r *secretResolver) startRefreshRoutine(rd *rand.Rand) {
 				} else if result != "" {
 					log.Infof("Secret refresh after invalid API key completed")
 				}
+			// a TTL was recorded or updated
+			case <-r.ttlUpdated:
+				resetTTLTimer()
+			// some secrets are about to expire
+			case <-ttlTimer.C:
+				log.Debug("Secrets refresh got TTL deadline, performing now")
+				if _, err := r.refreshExpiringSecrets(); err != nil {
+					log.Infof("Error with refreshing expiring secrets: %s", err)
+				}
+				resetTTLTimer()
 			}
 		}
 	}()
@@ -841,7 +889,82 @@ func (r *secretResolver) performRefresh() (string, error) {
 	}
 
 	log.Infof("Refreshing secrets for %d handles", len(newHandles))
+	return r.refreshHandles(newHandles)
+}
+
+// recordSecretTTL records when a handle has to be refreshed, given the TTL returned by the
+// backend along with its value. It must be called with the lock held.
+func (r *secretResolver) recordSecretTTL(handle string, ttl int64) {
+	if !r.refreshOnTTL {
+		return
+	}
+	if ttl <= 0 {
+		delete(r.ttlRefreshDeadlines, handle)
+		return
+	}
+
+	delay := max(time.Duration(float64(ttl)*ttlRefreshRatio)*time.Second, minTTLRefreshDelay)
+	if r.ttlRefreshDeadlines == nil {
+		r.ttlRefreshDeadlines = make(map[string]time.Time)
+	}
+	r.ttlRefreshDeadlines[handle] = r.clk.Now().Add(delay)
+	r.startRefreshRoutine(nil)
+
+	// non-blocking send, the refresh routine only needs to know that a deadline changed
+	select {
+	case r.ttlUpdated <- struct{}{}:
+	default:
+	}
+}
 
+// nextTTLRefresh returns the earliest deadline of the handles with a TTL
+func (r *secretResolver) nextTTLRefresh() (time.Time, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var next time.Time
+	for _, deadline := range r.ttlRefreshDeadlines {
+		if next.IsZero() || deadline.Before(next) {
+			next = deadline
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// refreshExpiringSecrets refreshes the handles whose refresh deadline has passed, i.e. 75% of
+// their TTL elapsed. Handles that are not used anymore are dropped, and the others are retried
+// after minTTLRefreshDelay unless the backend returns a new TTL along with their value.
+func (r *secretResolver) refreshExpiringSecrets() (string, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.clk.Now()
+	var handles []string
+	for handle, deadline := range r.ttlRefreshDeadlines {
+		if deadline.After(now) {
+			continue
+		}
+		// the handle is not used anymore, or its new value could not be applied
+		if !r.matchesAllowlist(handle) {
+			delete(r.ttlRefreshDeadlines, handle)
+			continue
+		}
+		// retry later if the backend doesn't return a new value and TTL for the handle
+		r.ttlRefreshDeadlines[handle] = now.Add(minTTLRefreshDelay)
+		handles = append(handles, handle)
+	}
+	if len(handles) == 0 {
+		return "", nil
+	}
+	sort.Strings(handles)
+
+	log.Infof("Refreshing %d secrets whose TTL is about to expire", len(handles))
+	return r.refreshHandles(handles)
+}
+
+// refreshHandles fetches new values for the given handles, notifies subscribers about the
+// ones that changed and returns a report. It must be called with the lock held.
+func (r *secretResolver) refreshHandles(newHandles []string) (string, error) {
 	var secretResponse map[string]string
 	var refreshErr error
 	if r.fetchHookFunc != nil {
@@ -1046,6 +1169,13 @@ func (r *secretResolver) getDebugInfo(stats map[string]interface{}, includeVersi
 		stats["scatterDuration"] = fmt.Sprintf("%.2fs", r.scatterDuration.Seconds())
 	}
 
+	stats["refreshOnTTLEnabled"] = r.refreshOnTTL
+	ttlRefreshes := make(map[string]string, len(r.ttlRefreshDeadlines))
+	for handle, deadline := range r.ttlRefreshDeadlines {
+		ttlRefreshes[handle] = deadline.UTC().Format(time.RFC3339)
+	}
+	stats["ttlRefreshes"] = ttlRefreshes
+
 	stats["unresolvedSecrets"] = r.unresolvedSecrets
 
 	return stats
@@ -6,6 +6,7 @@
 package secretsimpl
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/rand"
@@ -1596,3 +1597,152 @@ func TestShouldResolvedSecretMultiBackendNamespace(t *testing.T) {
 		assert.False(t, resolver.shouldResolvedSecret("prodk8s;k8s_secret@namespace1/secret/key", "origin", "img", "namespace2"))
 	})
 }
+
+func TestRefreshOnTTL(t *testing.T) {
+	newClock = func() clock.Clock { return clock.NewMock() }
+	t.Cleanup(func() {
+		newClock = clock.New
+	})
+	tel := nooptelemetry.GetCompatComponent()
+
+	resolver := newEnabledSecretResolver(tel)
+	mockClock := resolver.clk.(*clock.Mock)
+	resolver.backendCommand = "some_command"
+	resolver.refreshOnTTL = true
+
+	var calls atomic.Int32
+	resolver.commandHookFunc = func(string) ([]byte, error) {
+		n := calls.Add(1)
+		return []byte(fmt.Sprintf(`{"pass1":{"value":"password%d","ttl":100}}`, n)), nil
+	}
+
+	changes := make(chan string, 10)
+	resolver.SubscribeToChanges(func(_, _ string, _ []string, _, newValue any) {
+		changes <- newValue.(string)
+	})
+
+	// the refresh routine is started when the first secret with a TTL is resolved
+	_, err := resolver.Resolve(testSimpleConf, "test_check", "", "", true)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Time{"pass1": mockClock.Now().Add(75 * time.Second)}, resolver.ttlRefreshDeadlines)
+
+	// the secret isn't refreshed before 75% of its TTL elapsed
+	mockClock.Add(70 * time.Second)
+	assert.Never(t, func() bool { return calls.Load() > 1 }, 100*time.Millisecond, 10*time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		mockClock.Add(time.Second)
+		return calls.Load() == 2
+	}, time.Second, 10*time.Millisecond)
+
+	select {
+	case newValue := <-changes:
+		assert.Equal(t, "password2", newValue)
+	case <-time.After(time.Second):
+		t.Fatal("subscribers weren't notified of the refreshed secret")
+	}
+}
+
+func TestRefreshExpiringSecrets(t *testing.T) {
+	newClock = func() clock.Clock { return clock.NewMock() }
+	t.Cleanup(func() {
+		newClock = clock.New
+	})
+	tel := nooptelemetry.GetCompatComponent()
+
+	resolver := newEnabledSecretResolver(tel)
+	mockClock := resolver.clk.(*clock.Mock)
+	resolver.backendCommand = "some_command"
+	resolver.refreshOnTTL = true
+	resolver.cache = map[string]string{"pass1": "password1", "pass2": "password2", "pass3": "password3"}
+	resolver.origin = handleToContext{
+		"pass1": []secretContext{{origin: "test_check", path: []string{"password"}}},
+		"pass2": []secretContext{{origin: "test_check", path: []string{"password"}}},
+		// not allowed to be refreshed
+		"pass3": []secretContext{{origin: "datadog.yaml", path: []string{"proxy", "https"}}},
+	}
+	now := mockClock.Now()
+	resolver.ttlRefreshDeadlines = map[string]time.Time{
+		"pass1": now,
+		"pass2": now.Add(time.Minute),
+		"pass3": now,
+		// not used anymore
+		"pass4": now,
+	}
+
+	var requested []string
+	resolver.commandHookFunc = func(payload string) ([]byte, error) {
+		var input struct {
+			Secrets []string `json:"secrets"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(payload), &input))
+		requested = append(requested, input.Secrets...)
+		// the backend doesn't return a TTL anymore
+		return []byte(`{"pass1":{"value":"rotated1"}}`), nil
+	}
+
+	_, err := resolver.refreshExpiringSecrets()
+	require.NoError(t, err)
+
+	// only the expiring handles are fetched
+	assert.Equal(t, []string{"pass1"}, requested)
+	assert.Equal(t, "rotated1", resolver.cache["pass1"])
+	assert.Equal(t, map[string]time.Time{"pass2": now.Add(time.Minute)}, resolver.ttlRefreshDeadlines)
+
+	// a failed refresh is retried later
+	mockClock.Add(time.Minute)
+	resolver.commandHookFunc = func(string) ([]byte, error) {
+		return nil, errors.New("backend unavailable")
+	}
+	_, err = resolver.refreshExpiringSecrets()
+	require.Error(t, err)
+	assert.Equal(t, map[string]time.Time{"pass2": mockClock.Now().Add(minTTLRefreshDelay)}, resolver.ttlRefreshDeadlines)
+}
+
+func TestRecordSecretTTL(t *testing.T) {
+	newClock = func() clock.Clock { return clock.NewMock() }
+	t.Cleanup(func() {
+		newClock = clock.New
+	})
+	tel := nooptelemetry.GetCompatComponent()
+
+	resolver := newEnabledSecretResolver(tel)
+	now := resolver.clk.Now()
+
+	// disabled
+	resolver.recordSecretTTL("pass1", 3600)
+	assert.Empty(t, resolver.ttlRefreshDeadlines)
+
+	resolver.refreshOnTTL = true
+	resolver.recordSecretTTL("pass1", 3600)
+	resolver.recordSecretTTL("pass2", 1)
+	assert.Equal(t, map[string]time.Time{
+		"pass1": now.Add(45 * time.Minute),
+		"pass2": now.Add(minTTLRefreshDelay),
+	}, resolver.ttlRefreshDeadlines)
+
+	next, ok := resolver.nextTTLRefresh()
+	require.True(t, ok)
+	assert.Equal(t, now.Add(minTTLRefreshDelay), next)
+
+	resolver.recordSecretTTL("pass2", 0)
+	assert.Equal(t, map[string]time.Time{"pass1": now.Add(45 * time.Minute)}, resolver.ttlRefreshDeadlines)
+}
+
+func TestRefreshOnTTLStartsRoutineLazily(t *testing.T) {
+	tel := nooptelemetry.GetCompatComponent()
+	resolver := newEnabledSecretResolver(tel)
+	resolver.Configure(secrets.ConfigParams{Command: "some_command", RefreshOnTTL: true})
+
+	// no interval refresh is configured: the routine isn't started until a TTL is recorded
+	started := true
+	resolver.refreshRoutineOnce.Do(func() { started = false })
+	assert.False(t, started)
+
+	resolver = newEnabledSecretResolver(tel)
+	resolver.Configure(secrets.ConfigParams{Command: "some_command", RefreshOnTTL: true})
+	resolver.recordSecretTTL("pass1", 3600)
+	started = true
+	resolver.refreshRoutineOnce.Do(func() { started = false })
+	assert.True(t, started)
+}
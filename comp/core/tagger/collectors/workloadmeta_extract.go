@@ -181,6 +181,8 @@ func (c *WorkloadMetaCollector) processEvents(evBundle workloadmeta.EventBundle)
 				tagInfos = append(tagInfos, c.handleCRD(ev)...)
 			case workloadmeta.KindKubeCapabilities:
 				tagInfos = append(tagInfos, c.handleKubeCapabilities(ev)...)
+			case workloadmeta.KindSystemdUnit:
+				tagInfos = append(tagInfos, c.handleSystemdUnit(ev)...)
 			default:
 				log.Errorf("cannot handle event for entity %q with kind %q", entityID.ID, entityID.Kind)
 			}
@@ -193,6 +195,9 @@ func (c *WorkloadMetaCollector) processEvents(evBundle workloadmeta.EventBundle)
 
 			// ... and remove entities for everything that has been
 			// left
+			if entityID.Kind == workloadmeta.KindSystemdUnit {
+				unseen = c.unclaimedSystemdUnitChildren(taggerEntityID, unseen)
+			}
 			source := buildTaggerSource(entityID)
 			tagInfos = append(tagInfos, c.handleDeleteChildren(source, unseen)...)
 
@@ -935,6 +940,51 @@ func ExtractGPUTags(gpu *workloadmeta.GPU, tagList *taglist.TagList) {
 	}
 }
 
+func (c *WorkloadMetaCollector) handleSystemdUnit(ev workloadmeta.Event) []*types.TagInfo {
+	unit := ev.Entity.(*workloadmeta.SystemdUnit)
+
+	tagList := taglist.NewTagList()
+	tagList.AddLow(tags.SystemdUnit, unit.Name)
+	tagList.AddLow(tags.SystemdSlice, unit.Slice)
+
+	low, orch, high, standard := tagList.Compute()
+
+	tagInfos := make([]*types.TagInfo, 0, len(unit.PIDs)+1)
+	tagInfos = append(tagInfos, &types.TagInfo{
+		Source:               systemdUnitSource,
+		EntityID:             common.BuildTaggerEntityID(unit.EntityID),
+		HighCardTags:         high,
+		OrchestratorCardTags: orch,
+		LowCardTags:          low,
+		StandardTags:         standard,
+		IsComplete:           ev.IsComplete,
+	})
+
+	// the processes of the unit are its children, so that they get untagged
+	// when they leave the unit or when the unit stops
+	for _, pid := range unit.PIDs {
+		processID := workloadmeta.EntityID{
+			Kind: workloadmeta.KindProcess,
+			ID:   strconv.Itoa(int(pid)),
+		}
+		c.registerChild(unit.EntityID, processID)
+
+		tagInfos = append(tagInfos, &types.TagInfo{
+			// systemdUnitSource here is not a mistake. the source is
+			// always from the parent resource.
+			Source:               systemdUnitSource,
+			EntityID:             common.BuildTaggerEntityID(processID),
+			HighCardTags:         high,
+			OrchestratorCardTags: orch,
+			LowCardTags:          low,
+			StandardTags:         standard,
+			IsComplete:           ev.IsComplete,
+		})
+	}
+
+	return tagInfos
+}
+
 func (c *WorkloadMetaCollector) handleCRD(ev workloadmeta.Event) []*types.TagInfo {
 	crd := ev.Entity.(*workloadmeta.CRD)
 
@@ -1345,6 +1395,9 @@ func (c *WorkloadMetaCollector) handleDelete(ev workloadmeta.Event) []*types.Tag
 	taggerEntityID := common.BuildTaggerEntityID(entityID)
 
 	children := c.children[taggerEntityID]
+	if entityID.Kind == workloadmeta.KindSystemdUnit {
+		children = c.unclaimedSystemdUnitChildren(taggerEntityID, children)
+	}
 
 	source := buildTaggerSource(entityID)
 	tagInfos := make([]*types.TagInfo, 0, len(children)+1)
@@ -1417,6 +1470,31 @@ func (c *WorkloadMetaCollector) containerCompletenessECS(containerID string, con
 	return taskComplete
 }
 
+// unclaimedSystemdUnitChildren returns the processes of a systemd unit that
+// aren't claimed by another unit. The processes of all the units are tagged
+// from the same source, so a process that moved to another unit must not be
+// untagged if the new unit was handled first.
+func (c *WorkloadMetaCollector) unclaimedSystemdUnitChildren(unitID types.EntityID, children map[types.EntityID]struct{}) map[types.EntityID]struct{} {
+	unclaimed := make(map[types.EntityID]struct{}, len(children))
+	for childID := range children {
+		unclaimed[childID] = struct{}{}
+	}
+
+	for parentID, parentChildren := range c.children {
+		if len(unclaimed) == 0 {
+			break
+		}
+		if parentID == unitID || parentID.GetPrefix() != types.SystemdUnit {
+			continue
+		}
+		for childID := range parentChildren {
+			delete(unclaimed, childID)
+		}
+	}
+
+	return unclaimed
+}
+
 func (c *WorkloadMetaCollector) handleDeleteChildren(source string, children map[types.EntityID]struct{}) []*types.TagInfo {
 	tagInfos := make([]*types.TagInfo, 0, len(children))
 
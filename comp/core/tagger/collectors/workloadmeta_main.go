@@ -45,6 +45,7 @@ const (
 	gpuSource                 = workloadmetaCollectorName + "-" + string(workloadmeta.KindGPU)
 	crdSource                 = workloadmetaCollectorName + "-" + string(workloadmeta.KindCRD)
 	kubeCapabilitiesSource    = workloadmetaCollectorName + "-" + string(workloadmeta.KindKubeCapabilities)
+	systemdUnitSource         = workloadmetaCollectorName + "-" + string(workloadmeta.KindSystemdUnit)
 
 	clusterTagNamePrefix = tags.KubeClusterName
 )
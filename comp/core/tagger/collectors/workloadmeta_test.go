@@ -4061,6 +4061,128 @@ func TestHandleGPU(t *testing.T) {
 	}
 }
 
+func TestHandleSystemdUnit(t *testing.T) {
+	entityID := workloadmeta.EntityID{
+		Kind: workloadmeta.KindSystemdUnit,
+		ID:   "nginx.service",
+	}
+
+	unit := workloadmeta.SystemdUnit{
+		EntityID: entityID,
+		EntityMeta: workloadmeta.EntityMeta{
+			Name: "nginx.service",
+		},
+		UnitType:    "service",
+		ActiveState: "active",
+		Slice:       "system.slice",
+		MainPID:     1234,
+		PIDs:        []int32{1234, 1235},
+	}
+
+	expectedTags := []string{
+		"systemd_unit:nginx.service",
+		"systemd_slice:system.slice",
+	}
+
+	expected := []*types.TagInfo{
+		{
+			Source:               systemdUnitSource,
+			EntityID:             types.NewEntityID(types.SystemdUnit, "nginx.service"),
+			HighCardTags:         []string{},
+			OrchestratorCardTags: []string{},
+			LowCardTags:          expectedTags,
+			StandardTags:         []string{},
+		},
+		{
+			Source:               systemdUnitSource,
+			EntityID:             types.NewEntityID(types.Process, "1234"),
+			HighCardTags:         []string{},
+			OrchestratorCardTags: []string{},
+			LowCardTags:          expectedTags,
+			StandardTags:         []string{},
+		},
+		{
+			Source:               systemdUnitSource,
+			EntityID:             types.NewEntityID(types.Process, "1235"),
+			HighCardTags:         []string{},
+			OrchestratorCardTags: []string{},
+			LowCardTags:          expectedTags,
+			StandardTags:         []string{},
+		},
+	}
+
+	cfg := configmock.New(t)
+	collector := NewWorkloadMetaCollector(context.Background(), cfg, nil, nil)
+
+	actual := collector.handleSystemdUnit(workloadmeta.Event{
+		Type:   workloadmeta.EventTypeSet,
+		Entity: &unit,
+	})
+
+	assertTagInfoListEqual(t, expected, actual)
+	assert.Len(t, collector.children[types.NewEntityID(types.SystemdUnit, "nginx.service")], 2)
+}
+
+func TestHandleSystemdUnitProcessChanges(t *testing.T) {
+	newUnit := func(name string, pids ...int32) *workloadmeta.SystemdUnit {
+		return &workloadmeta.SystemdUnit{
+			EntityID: workloadmeta.EntityID{
+				Kind: workloadmeta.KindSystemdUnit,
+				ID:   name,
+			},
+			EntityMeta: workloadmeta.EntityMeta{
+				Name: name,
+			},
+			UnitType:    "service",
+			ActiveState: "active",
+			Slice:       "system.slice",
+			PIDs:        pids,
+		}
+	}
+
+	collectorCh := make(chan []*types.TagInfo, 10)
+	collector := NewWorkloadMetaCollector(context.Background(), configmock.New(t), nil, &fakeProcessor{collectorCh})
+
+	process := func(events ...workloadmeta.Event) []*types.TagInfo {
+		collector.processEvents(workloadmeta.EventBundle{
+			Events: events,
+			Ch:     make(chan struct{}),
+		})
+		return <-collectorCh
+	}
+
+	deletedProcesses := func(tagInfos []*types.TagInfo) []string {
+		var pids []string
+		for _, tagInfo := range tagInfos {
+			if tagInfo.DeleteEntity && tagInfo.EntityID.GetPrefix() == types.Process {
+				assert.Equal(t, systemdUnitSource, tagInfo.Source)
+				pids = append(pids, tagInfo.EntityID.GetID())
+			}
+		}
+		return pids
+	}
+
+	process(workloadmeta.Event{Type: workloadmeta.EventTypeSet, Entity: newUnit("nginx.service", 1234, 1235)})
+
+	// 1235 left the unit, it must be untagged
+	tagInfos := process(workloadmeta.Event{Type: workloadmeta.EventTypeSet, Entity: newUnit("nginx.service", 1234)})
+	assert.Equal(t, []string{"1235"}, deletedProcesses(tagInfos))
+
+	// 1234 moved to another unit, which was handled first: it must keep the
+	// tags of its new unit
+	tagInfos = process(
+		workloadmeta.Event{Type: workloadmeta.EventTypeSet, Entity: newUnit("worker.service", 1234)},
+		workloadmeta.Event{Type: workloadmeta.EventTypeSet, Entity: newUnit("nginx.service")},
+	)
+	assert.Empty(t, deletedProcesses(tagInfos))
+
+	tagInfos = process(workloadmeta.Event{Type: workloadmeta.EventTypeUnset, Entity: newUnit("nginx.service")})
+	assert.Empty(t, deletedProcesses(tagInfos))
+
+	tagInfos = process(workloadmeta.Event{Type: workloadmeta.EventTypeUnset, Entity: newUnit("worker.service")})
+	assert.Equal(t, []string{"1234"}, deletedProcesses(tagInfos))
+}
+
 func TestHandleDelete(t *testing.T) {
 	const (
 		podName       = "datadog-agent-foobar"
@@ -164,6 +164,13 @@ const (
 	// GPUNVLinkCapable is the tag indicating whether the GPU has one or more NVLink links.
 	GPUNVLinkCapable = "gpu_nvlink_capable"
 
+	// Systemd related tags
+
+	// SystemdUnit is the tag for the systemd unit owning a process
+	SystemdUnit = "systemd_unit"
+	// SystemdSlice is the tag for the slice of the systemd unit owning a process
+	SystemdSlice = "systemd_slice"
+
 	// KubeArgoRollout is the tag for the Argo Rollout name
 	KubeArgoRollout = "kube_argo_rollout"
 
@@ -95,6 +95,8 @@ const (
 	Kubelet EntityIDPrefix = "kubelet"
 	// Crd is the prefix `crd`
 	Crd EntityIDPrefix = "crd"
+	// SystemdUnit is the prefix `systemd_unit`
+	SystemdUnit EntityIDPrefix = "systemd_unit"
 )
 
 // AllPrefixesSet returns a set of all possible entity id prefixes that can be used in the tagger
@@ -117,6 +119,7 @@ func AllPrefixesSet() map[EntityIDPrefix]struct{} {
 		Kubelet:                {},
 		Crd:                    {},
 		KubernetesCapabilities: {},
+		SystemdUnit:            {},
 	}
 }
 
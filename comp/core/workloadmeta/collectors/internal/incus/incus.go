@@ -0,0 +1,282 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux
+
+// Package incus implements the Incus/LXD Workloadmeta collector.
+package incus
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"go.uber.org/fx"
+
+	config "github.com/DataDog/datadog-agent/comp/core/config"
+	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+	"github.com/DataDog/datadog-agent/pkg/config/env"
+	dderrors "github.com/DataDog/datadog-agent/pkg/errors"
+	"github.com/DataDog/datadog-agent/pkg/util/containers"
+	"github.com/DataDog/datadog-agent/pkg/util/incus"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+const (
+	collectorID   = "incus"
+	componentName = "workloadmeta-incus"
+)
+
+type incusClient interface {
+	GetServer(ctx context.Context) (*incus.Server, error)
+	GetInstances(ctx context.Context) ([]incus.Instance, error)
+}
+
+type dependencies struct {
+	fx.In
+
+	Config config.Component
+}
+
+type collector struct {
+	id      string
+	cfg     config.Component
+	client  incusClient
+	runtime workloadmeta.ContainerRuntime
+	store   workloadmeta.Component
+	catalog workloadmeta.AgentType
+	seen    map[workloadmeta.EntityID]struct{}
+}
+
+// NewCollector returns a new incus collector provider and an error
+func NewCollector(deps dependencies) (workloadmeta.CollectorProvider, error) {
+	return workloadmeta.CollectorProvider{
+		Collector: &collector{
+			id:      collectorID,
+			cfg:     deps.Config,
+			seen:    make(map[workloadmeta.EntityID]struct{}),
+			catalog: workloadmeta.NodeAgent,
+		},
+	}, nil
+}
+
+// GetFxOptions returns the FX framework options for the collector
+func GetFxOptions() fx.Option {
+	return fx.Provide(NewCollector)
+}
+
+// Start the collector for the provided workloadmeta component
+func (c *collector) Start(ctx context.Context, store workloadmeta.Component) error {
+	if !env.IsFeaturePresent(env.Incus) {
+		return dderrors.NewDisabled(componentName, "Incus not detected")
+	}
+
+	socketPath, err := incus.FindSocket(c.cfg.GetString("incus_socket_path"), env.IsContainerized())
+	if err != nil {
+		return dderrors.NewDisabled(componentName, err.Error())
+	}
+
+	client := incus.NewClient(socketPath)
+	server, err := client.GetServer(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.client = client
+	c.runtime = runtimeFromServer(server)
+	c.store = store
+
+	log.Infof("Using %s %s at %q", c.runtime, server.Environment.ServerVersion, socketPath)
+
+	return nil
+}
+
+func (c *collector) Pull(ctx context.Context) error {
+	instances, err := c.client.GetInstances(ctx)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[workloadmeta.EntityID]struct{})
+	events := []workloadmeta.CollectorEvent{}
+
+	for _, instance := range instances {
+		// virtual machines don't share the host kernel, the agent can't
+		// collect anything about them from the host
+		if instance.Type != incus.InstanceTypeContainer {
+			continue
+		}
+
+		event := convertToEvent(&instance, c.runtime)
+		seen[event.Entity.GetID()] = struct{}{}
+		events = append(events, event)
+	}
+
+	for seenID := range c.seen {
+		if _, ok := seen[seenID]; ok {
+			continue
+		}
+
+		events = append(events, workloadmeta.CollectorEvent{
+			Type:   workloadmeta.EventTypeUnset,
+			Source: workloadmeta.SourceRuntime,
+			Entity: &workloadmeta.Container{
+				EntityID: seenID,
+			},
+		})
+	}
+
+	c.seen = seen
+
+	c.store.Notify(events)
+
+	return nil
+}
+
+func (c *collector) GetID() string {
+	return c.id
+}
+
+func (c *collector) GetTargetCatalog() workloadmeta.AgentType {
+	return c.catalog
+}
+
+func runtimeFromServer(server *incus.Server) workloadmeta.ContainerRuntime {
+	if strings.EqualFold(server.Environment.Server, "lxd") {
+		return workloadmeta.ContainerRuntimeLXD
+	}
+	return workloadmeta.ContainerRuntimeIncus
+}
+
+func convertToEvent(instance *incus.Instance, runtime workloadmeta.ContainerRuntime) workloadmeta.CollectorEvent {
+	containerID := instance.ContainerID()
+
+	var eventType workloadmeta.EventType
+	if instance.Status == incus.StatusRunning {
+		eventType = workloadmeta.EventTypeSet
+	} else {
+		eventType = workloadmeta.EventTypeUnset
+	}
+
+	var pid int
+	var ips map[string]string
+	if instance.State != nil {
+		pid = instance.State.PID
+		ips = networkIPs(instance.State)
+	}
+
+	return workloadmeta.CollectorEvent{
+		Type:   eventType,
+		Source: workloadmeta.SourceRuntime,
+		Entity: &workloadmeta.Container{
+			EntityID: workloadmeta.EntityID{
+				Kind: workloadmeta.KindContainer,
+				ID:   containerID,
+			},
+			EntityMeta: workloadmeta.EntityMeta{
+				Name:      instance.Name,
+				Namespace: instance.Project,
+				Labels:    instance.UserConfig(),
+			},
+			EnvVars:    envVars(instance),
+			Hostname:   instance.Name,
+			Image:      image(instance),
+			NetworkIPs: ips,
+			PID:        pid,
+			Runtime:    runtime,
+			// the cgroup of the container is named after its ID, right under the root
+			CgroupPath: "/" + containerID,
+			State: workloadmeta.ContainerState{
+				Running:   instance.Status == incus.StatusRunning,
+				Status:    status(instance.Status),
+				CreatedAt: instance.CreatedAt,
+				StartedAt: instance.LastUsedAt,
+			},
+		},
+	}
+}
+
+func image(instance *incus.Instance) workloadmeta.ContainerImage {
+	instanceConfig := instance.ExpandedConfig
+	if len(instanceConfig) == 0 {
+		instanceConfig = instance.Config
+	}
+
+	imageID := instanceConfig["volatile.base_image"]
+	if imageID != "" {
+		imageID = "sha256:" + imageID
+	}
+
+	var imageName string
+	if distribution := strings.ToLower(instanceConfig["image.os"]); distribution != "" {
+		imageName = distribution
+		if release := instanceConfig["image.release"]; release != "" {
+			imageName += ":" + release
+		}
+	}
+
+	if imageID == "" && imageName == "" {
+		return workloadmeta.ContainerImage{}
+	}
+
+	img, err := workloadmeta.NewContainerImage(imageID, imageName)
+	if err != nil {
+		log.Debugf("Could not parse image %q of container %s: %v", imageName, instance.Name, err)
+	}
+	return img
+}
+
+// networkIPs returns the first global IPv4 address of every interface
+func networkIPs(state *incus.InstanceState) map[string]string {
+	res := make(map[string]string)
+
+	interfaces := make([]string, 0, len(state.Network))
+	for name := range state.Network {
+		interfaces = append(interfaces, name)
+	}
+	sort.Strings(interfaces)
+
+	for _, name := range interfaces {
+		network := state.Network[name]
+		if network.Type == "loopback" || name == "lo" {
+			continue
+		}
+		for _, address := range network.Addresses {
+			if address.Family == "inet" && address.Scope == "global" {
+				res[name] = address.Address
+				break
+			}
+		}
+	}
+
+	return res
+}
+
+func envVars(instance *incus.Instance) map[string]string {
+	res := make(map[string]string)
+
+	for name, value := range instance.Environment() {
+		if containers.EnvVarFilterFromConfig().IsIncluded(name) {
+			res[name] = value
+		}
+	}
+
+	return res
+}
+
+func status(status string) workloadmeta.ContainerStatus {
+	switch status {
+	case incus.StatusRunning:
+		return workloadmeta.ContainerStatusRunning
+	case incus.StatusFrozen, incus.StatusFreezing:
+		return workloadmeta.ContainerStatusPaused
+	case incus.StatusStopped, incus.StatusStopping:
+		return workloadmeta.ContainerStatusStopped
+	case incus.StatusStarting:
+		return workloadmeta.ContainerStatusCreated
+	}
+
+	return workloadmeta.ContainerStatusUnknown
+}
@@ -0,0 +1,179 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux
+
+package incus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+	"github.com/DataDog/datadog-agent/pkg/util/incus"
+)
+
+type fakeWorkloadmetaStore struct {
+	workloadmeta.Component
+	notifiedEvents []workloadmeta.CollectorEvent
+}
+
+func (store *fakeWorkloadmetaStore) Notify(events []workloadmeta.CollectorEvent) {
+	store.notifiedEvents = append(store.notifiedEvents, events...)
+}
+
+type fakeIncusClient struct {
+	instances []incus.Instance
+}
+
+func (client *fakeIncusClient) GetServer(_ context.Context) (*incus.Server, error) {
+	return &incus.Server{}, nil
+}
+
+func (client *fakeIncusClient) GetInstances(_ context.Context) ([]incus.Instance, error) {
+	return client.instances, nil
+}
+
+func TestPull(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	startedAt := time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC)
+
+	client := &fakeIncusClient{
+		instances: []incus.Instance{
+			{
+				Name:       "web1",
+				Project:    incus.DefaultProject,
+				Type:       incus.InstanceTypeContainer,
+				Status:     incus.StatusRunning,
+				CreatedAt:  createdAt,
+				LastUsedAt: startedAt,
+				ExpandedConfig: map[string]string{
+					"image.os":                    "Ubuntu",
+					"image.release":               "noble",
+					"volatile.base_image":         "5a2bc6e1",
+					"user.com.datadoghq.tags.env": "prod",
+					"environment.DD_SERVICE":      "web",
+				},
+				State: &incus.InstanceState{
+					Status: incus.StatusRunning,
+					PID:    4242,
+					Network: map[string]incus.InstanceStateNetwork{
+						"lo": {
+							Type:      "loopback",
+							Addresses: []incus.InstanceStateNetworkAddress{{Family: "inet", Address: "127.0.0.1", Scope: "local"}},
+						},
+						"eth0": {
+							Type: "broadcast",
+							Addresses: []incus.InstanceStateNetworkAddress{
+								{Family: "inet6", Address: "fe80::1", Scope: "link"},
+								{Family: "inet", Address: "10.0.3.15", Scope: "global"},
+							},
+						},
+					},
+				},
+			},
+			{
+				Name:    "db",
+				Project: "edge",
+				Type:    incus.InstanceTypeContainer,
+				Status:  incus.StatusStopped,
+			},
+			{
+				Name:    "vm",
+				Project: incus.DefaultProject,
+				Type:    incus.InstanceTypeVirtualMachine,
+				Status:  incus.StatusRunning,
+			},
+		},
+	}
+
+	store := &fakeWorkloadmetaStore{}
+	c := collector{
+		client:  client,
+		runtime: workloadmeta.ContainerRuntimeIncus,
+		store:   store,
+		seen:    make(map[workloadmeta.EntityID]struct{}),
+	}
+
+	require.NoError(t, c.Pull(context.Background()))
+
+	expectedImage, err := workloadmeta.NewContainerImage("sha256:5a2bc6e1", "ubuntu:noble")
+	require.NoError(t, err)
+
+	expectedEvents := []workloadmeta.CollectorEvent{
+		{
+			Type:   workloadmeta.EventTypeSet,
+			Source: workloadmeta.SourceRuntime,
+			Entity: &workloadmeta.Container{
+				EntityID: workloadmeta.EntityID{
+					Kind: workloadmeta.KindContainer,
+					ID:   "lxc.payload.web1",
+				},
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      "web1",
+					Namespace: incus.DefaultProject,
+					Labels:    map[string]string{"com.datadoghq.tags.env": "prod"},
+				},
+				EnvVars:    map[string]string{"DD_SERVICE": "web"},
+				Hostname:   "web1",
+				Image:      expectedImage,
+				NetworkIPs: map[string]string{"eth0": "10.0.3.15"},
+				PID:        4242,
+				Runtime:    workloadmeta.ContainerRuntimeIncus,
+				CgroupPath: "/lxc.payload.web1",
+				State: workloadmeta.ContainerState{
+					Running:   true,
+					Status:    workloadmeta.ContainerStatusRunning,
+					CreatedAt: createdAt,
+					StartedAt: startedAt,
+				},
+			},
+		},
+		{
+			Type:   workloadmeta.EventTypeUnset,
+			Source: workloadmeta.SourceRuntime,
+			Entity: &workloadmeta.Container{
+				EntityID: workloadmeta.EntityID{
+					Kind: workloadmeta.KindContainer,
+					ID:   "lxc.payload.edge_db",
+				},
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      "db",
+					Namespace: "edge",
+					Labels:    map[string]string{},
+				},
+				EnvVars:    map[string]string{},
+				Hostname:   "db",
+				Runtime:    workloadmeta.ContainerRuntimeIncus,
+				CgroupPath: "/lxc.payload.edge_db",
+				State: workloadmeta.ContainerState{
+					Status: workloadmeta.ContainerStatusStopped,
+				},
+			},
+		},
+	}
+	assert.Equal(t, expectedEvents, store.notifiedEvents)
+
+	// web1 is deleted, it must be unset
+	client.instances = client.instances[1:]
+	store.notifiedEvents = nil
+	require.NoError(t, c.Pull(context.Background()))
+
+	require.Len(t, store.notifiedEvents, 2)
+	assert.Equal(t, workloadmeta.EventTypeUnset, store.notifiedEvents[1].Type)
+	assert.Equal(t, "lxc.payload.web1", store.notifiedEvents[1].Entity.GetID().ID)
+}
+
+func TestRuntimeFromServer(t *testing.T) {
+	lxd := &incus.Server{Environment: incus.ServerEnvironment{Server: "lxd"}}
+	assert.Equal(t, workloadmeta.ContainerRuntimeLXD, runtimeFromServer(lxd))
+
+	incusServer := &incus.Server{Environment: incus.ServerEnvironment{Server: "incus"}}
+	assert.Equal(t, workloadmeta.ContainerRuntimeIncus, runtimeFromServer(incusServer))
+}
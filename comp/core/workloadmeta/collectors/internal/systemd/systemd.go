@@ -0,0 +1,278 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build systemd
+
+// Package systemd implements the systemd Workloadmeta collector, publishing
+// the units of the host as entities.
+package systemd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/coreos/go-systemd/v22/dbus"
+	"go.uber.org/fx"
+
+	config "github.com/DataDog/datadog-agent/comp/core/config"
+	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+	dderrors "github.com/DataDog/datadog-agent/pkg/errors"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+	systemdutil "github.com/DataDog/datadog-agent/pkg/util/systemd"
+)
+
+const (
+	collectorID   = "systemd"
+	componentName = "workloadmeta-systemd"
+)
+
+// activeStates are the states of the units published as entities
+var activeStates = []string{"active", "reloading"}
+
+type systemdClient interface {
+	ListUnitsContext(ctx context.Context) ([]dbus.UnitStatus, error)
+	GetUnitPropertiesContext(ctx context.Context, unit string) (map[string]interface{}, error)
+	GetUnitTypePropertiesContext(ctx context.Context, unit string, unitType string) (map[string]interface{}, error)
+	Close()
+}
+
+type dependencies struct {
+	fx.In
+
+	Config config.Component
+}
+
+type collector struct {
+	id           string
+	cfg          config.Component
+	client       systemdClient
+	connect      func() (systemdClient, error)
+	unitTypes    []string
+	cgroupRoot   string
+	pullInterval time.Duration
+	store        workloadmeta.Component
+	catalog      workloadmeta.AgentType
+	seen         map[workloadmeta.EntityID]struct{}
+}
+
+// NewCollector returns a new systemd collector provider and an error
+func NewCollector(deps dependencies) (workloadmeta.CollectorProvider, error) {
+	return workloadmeta.CollectorProvider{
+		Collector: &collector{
+			id:      collectorID,
+			cfg:     deps.Config,
+			seen:    make(map[workloadmeta.EntityID]struct{}),
+			catalog: workloadmeta.NodeAgent,
+		},
+	}, nil
+}
+
+// GetFxOptions returns the FX framework options for the collector
+func GetFxOptions() fx.Option {
+	return fx.Provide(NewCollector)
+}
+
+// Start the collector for the provided workloadmeta component
+func (c *collector) Start(_ context.Context, store workloadmeta.Component) error {
+	if !c.cfg.GetBool("systemd_units.enabled") {
+		return dderrors.NewDisabled(componentName, "systemd unit collection is disabled")
+	}
+
+	// the unit types are matched against the suffix of the unit names and
+	// turned into the name of their D-Bus interface, they can't be empty
+	unitTypes := c.cfg.GetStringSlice("systemd_units.unit_types")
+	if slices.Contains(unitTypes, "") {
+		return errors.New("systemd_units.unit_types cannot contain an empty unit type")
+	}
+
+	privateSocket := c.cfg.GetString("systemd_units.private_socket")
+	c.connect = func() (systemdClient, error) {
+		conn, err := systemdutil.Connect(privateSocket)
+		if err != nil {
+			return nil, err
+		}
+		return conn, nil
+	}
+
+	client, err := c.connect()
+	if err != nil {
+		return dderrors.NewDisabled(componentName, "cannot connect to systemd: "+err.Error())
+	}
+
+	c.client = client
+	c.store = store
+	c.unitTypes = unitTypes
+	c.cgroupRoot = c.cfg.GetString("container_cgroup_root")
+	c.pullInterval = time.Duration(c.cfg.GetInt("systemd_units.pull_interval")) * time.Second
+
+	return nil
+}
+
+func (c *collector) Pull(ctx context.Context) error {
+	if c.client == nil {
+		client, err := c.connect()
+		if err != nil {
+			return err
+		}
+		c.client = client
+	}
+
+	units, err := c.client.ListUnitsContext(ctx)
+	if err != nil {
+		// the connection is likely broken, e.g. after a restart of systemd
+		c.client.Close()
+		c.client = nil
+		return err
+	}
+
+	seen := make(map[workloadmeta.EntityID]struct{})
+	events := []workloadmeta.CollectorEvent{}
+
+	for _, unit := range units {
+		if !slices.Contains(activeStates, unit.ActiveState) || !slices.Contains(c.unitTypes, unitType(unit.Name)) {
+			continue
+		}
+
+		entity, err := c.buildEntity(ctx, unit)
+		if err != nil {
+			log.Debugf("Cannot get the properties of systemd unit %s: %v", unit.Name, err)
+			continue
+		}
+
+		seen[entity.EntityID] = struct{}{}
+		events = append(events, workloadmeta.CollectorEvent{
+			Type:   workloadmeta.EventTypeSet,
+			Source: workloadmeta.SourceSystemd,
+			Entity: entity,
+		})
+	}
+
+	for seenID := range c.seen {
+		if _, ok := seen[seenID]; ok {
+			continue
+		}
+
+		events = append(events, workloadmeta.CollectorEvent{
+			Type:   workloadmeta.EventTypeUnset,
+			Source: workloadmeta.SourceSystemd,
+			Entity: &workloadmeta.SystemdUnit{
+				EntityID: seenID,
+			},
+		})
+	}
+
+	c.seen = seen
+
+	c.store.Notify(events)
+
+	return nil
+}
+
+func (c *collector) GetID() string {
+	return c.id
+}
+
+func (c *collector) GetTargetCatalog() workloadmeta.AgentType {
+	return c.catalog
+}
+
+// GetPullInterval returns the interval between two listings of the units,
+// which can be expensive on hosts running many of them
+func (c *collector) GetPullInterval() time.Duration {
+	return c.pullInterval
+}
+
+func (c *collector) buildEntity(ctx context.Context, unit dbus.UnitStatus) (*workloadmeta.SystemdUnit, error) {
+	unitProperties, err := c.client.GetUnitPropertiesContext(ctx, unit.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	typ := unitType(unit.Name)
+	// the properties of the unit type are exposed on the D-Bus interface
+	// named after it, e.g. org.freedesktop.systemd1.Service
+	typeProperties, err := c.client.GetUnitTypePropertiesContext(ctx, unit.Name, strings.ToUpper(typ[:1])+typ[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	cgroupPath := stringProperty(typeProperties, "ControlGroup")
+
+	var mainPID int32
+	if pid, ok := typeProperties["MainPID"].(uint32); ok {
+		mainPID = int32(pid)
+	}
+
+	return &workloadmeta.SystemdUnit{
+		EntityID: workloadmeta.EntityID{
+			Kind: workloadmeta.KindSystemdUnit,
+			ID:   unit.Name,
+		},
+		EntityMeta: workloadmeta.EntityMeta{
+			Name: unit.Name,
+		},
+		UnitType:     typ,
+		Description:  unit.Description,
+		LoadState:    unit.LoadState,
+		ActiveState:  unit.ActiveState,
+		SubState:     unit.SubState,
+		FragmentPath: stringProperty(unitProperties, "FragmentPath"),
+		Slice:        stringProperty(typeProperties, "Slice"),
+		CgroupPath:   cgroupPath,
+		MainPID:      mainPID,
+		PIDs:         readCgroupPIDs(c.cgroupRoot, cgroupPath),
+	}, nil
+}
+
+// unitType returns the type of a unit from its name, e.g. service for nginx.service
+func unitType(name string) string {
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		return name[i+1:]
+	}
+	return ""
+}
+
+func stringProperty(properties map[string]interface{}, name string) string {
+	value, _ := properties[name].(string)
+	return value
+}
+
+// readCgroupPIDs returns the processes of the control group of a unit, looking
+// first in the unified hierarchy then in the systemd one of cgroup v1. The
+// processes of the child control groups aren't listed.
+func readCgroupPIDs(cgroupRoot string, cgroupPath string) []int32 {
+	if cgroupPath == "" {
+		return nil
+	}
+
+	for _, dir := range []string{
+		filepath.Join(cgroupRoot, cgroupPath),
+		filepath.Join(cgroupRoot, "systemd", cgroupPath),
+	} {
+		content, err := os.ReadFile(filepath.Join(dir, "cgroup.procs"))
+		if err != nil {
+			continue
+		}
+
+		var pids []int32
+		for _, line := range strings.Fields(string(content)) {
+			pid, err := strconv.ParseInt(line, 10, 32)
+			if err != nil {
+				continue
+			}
+			pids = append(pids, int32(pid))
+		}
+		slices.Sort(pids)
+		return pids
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build systemd
+
+package systemd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/coreos/go-systemd/v22/dbus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/comp/core/config"
+	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+)
+
+type fakeWorkloadmetaStore struct {
+	workloadmeta.Component
+	notifiedEvents []workloadmeta.CollectorEvent
+}
+
+func (store *fakeWorkloadmetaStore) Notify(events []workloadmeta.CollectorEvent) {
+	store.notifiedEvents = append(store.notifiedEvents, events...)
+}
+
+type fakeSystemdClient struct {
+	units          []dbus.UnitStatus
+	listErr        error
+	typeProperties map[string]map[string]interface{}
+	closed         bool
+}
+
+func (client *fakeSystemdClient) ListUnitsContext(_ context.Context) ([]dbus.UnitStatus, error) {
+	return client.units, client.listErr
+}
+
+func (client *fakeSystemdClient) GetUnitPropertiesContext(_ context.Context, unit string) (map[string]interface{}, error) {
+	return map[string]interface{}{"FragmentPath": "/lib/systemd/system/" + unit}, nil
+}
+
+func (client *fakeSystemdClient) GetUnitTypePropertiesContext(_ context.Context, unit string, unitType string) (map[string]interface{}, error) {
+	if unitType != "Service" {
+		return nil, errors.New("unexpected unit type " + unitType)
+	}
+	return client.typeProperties[unit], nil
+}
+
+func (client *fakeSystemdClient) Close() {
+	client.closed = true
+}
+
+func TestPull(t *testing.T) {
+	cgroupRoot := t.TempDir()
+	cgroupDir := filepath.Join(cgroupRoot, "system.slice", "nginx.service")
+	require.NoError(t, os.MkdirAll(cgroupDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(cgroupDir, "cgroup.procs"), []byte("1235\n1234\n"), 0o644))
+
+	client := &fakeSystemdClient{
+		units: []dbus.UnitStatus{
+			{Name: "nginx.service", Description: "A high performance web server", LoadState: "loaded", ActiveState: "active", SubState: "running"},
+			{Name: "cron.service", LoadState: "loaded", ActiveState: "inactive", SubState: "dead"},
+			{Name: "session-1.scope", LoadState: "loaded", ActiveState: "active", SubState: "running"},
+		},
+		typeProperties: map[string]map[string]interface{}{
+			"nginx.service": {
+				"MainPID":      uint32(1234),
+				"Slice":        "system.slice",
+				"ControlGroup": "/system.slice/nginx.service",
+			},
+		},
+	}
+
+	store := &fakeWorkloadmetaStore{}
+	c := collector{
+		client:     client,
+		unitTypes:  []string{"service"},
+		cgroupRoot: cgroupRoot,
+		store:      store,
+		seen:       make(map[workloadmeta.EntityID]struct{}),
+	}
+
+	require.NoError(t, c.Pull(context.Background()))
+
+	expectedEvents := []workloadmeta.CollectorEvent{
+		{
+			Type:   workloadmeta.EventTypeSet,
+			Source: workloadmeta.SourceSystemd,
+			Entity: &workloadmeta.SystemdUnit{
+				EntityID: workloadmeta.EntityID{
+					Kind: workloadmeta.KindSystemdUnit,
+					ID:   "nginx.service",
+				},
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: "nginx.service",
+				},
+				UnitType:     "service",
+				Description:  "A high performance web server",
+				LoadState:    "loaded",
+				ActiveState:  "active",
+				SubState:     "running",
+				FragmentPath: "/lib/systemd/system/nginx.service",
+				Slice:        "system.slice",
+				CgroupPath:   "/system.slice/nginx.service",
+				MainPID:      1234,
+				PIDs:         []int32{1234, 1235},
+			},
+		},
+	}
+	assert.Equal(t, expectedEvents, store.notifiedEvents)
+
+	// nginx is stopped, it must be unset
+	client.units[0].ActiveState = "inactive"
+	store.notifiedEvents = nil
+	require.NoError(t, c.Pull(context.Background()))
+
+	require.Len(t, store.notifiedEvents, 1)
+	assert.Equal(t, workloadmeta.EventTypeUnset, store.notifiedEvents[0].Type)
+	assert.Equal(t, "nginx.service", store.notifiedEvents[0].Entity.GetID().ID)
+}
+
+func TestPullReconnects(t *testing.T) {
+	broken := &fakeSystemdClient{listErr: errors.New("connection closed")}
+	healthy := &fakeSystemdClient{}
+
+	store := &fakeWorkloadmetaStore{}
+	c := collector{
+		client: broken,
+		connect: func() (systemdClient, error) {
+			return healthy, nil
+		},
+		store: store,
+		seen:  make(map[workloadmeta.EntityID]struct{}),
+	}
+
+	assert.Error(t, c.Pull(context.Background()))
+	assert.True(t, broken.closed)
+	assert.Nil(t, c.client)
+
+	require.NoError(t, c.Pull(context.Background()))
+	assert.Equal(t, healthy, c.client)
+}
+
+func TestStartRejectsEmptyUnitType(t *testing.T) {
+	cfg := config.NewMock(t)
+	cfg.SetInTest("systemd_units.enabled", true)
+	cfg.SetInTest("systemd_units.unit_types", []string{"service", ""})
+
+	c := collector{cfg: cfg}
+	err := c.Start(context.Background(), &fakeWorkloadmetaStore{})
+
+	require.EqualError(t, err, "systemd_units.unit_types cannot contain an empty unit type")
+	assert.Nil(t, c.client)
+}
+
+func TestUnitType(t *testing.T) {
+	assert.Equal(t, "service", unitType("nginx.service"))
+	assert.Equal(t, "service", unitType("getty@tty1.service"))
+	assert.Equal(t, "scope", unitType("session-1.scope"))
+	assert.Equal(t, "", unitType("invalid"))
+}
@@ -143,6 +143,14 @@ type Component interface {
 	// to all entities with kind KindGPU.
 	ListGPUs() []*GPU
 
+	// GetSystemdUnit returns metadata about a systemd unit. It fetches the
+	// entity with kind KindSystemdUnit and the given unit name.
+	GetSystemdUnit(name string) (*SystemdUnit, error)
+
+	// ListSystemdUnits returns metadata about all known systemd units,
+	// equivalent to all entities with kind KindSystemdUnit.
+	ListSystemdUnits() []*SystemdUnit
+
 	// ListProcessesWithFilter returns all the processes for which the passed
 	// filter evaluates to true.
 	ListProcessesWithFilter(filterFunc EntityFilterFunc[*Process]) []*Process
@@ -59,6 +59,7 @@ const (
 	KindGPU                           Kind = "gpu"
 	KindKubelet                       Kind = "kubelet"
 	KindCRD                           Kind = "crd"
+	KindSystemdUnit                   Kind = "systemd_unit"
 )
 
 // Source is the source name of an entity.
@@ -122,6 +123,9 @@ const (
 
 	// SourceKubeAPIServer represents metadata collected from the Kubernetes API Server
 	SourceKubeAPIServer Source = "kubeapiserver"
+
+	// SourceSystemd represents units detected by querying systemd over D-Bus.
+	SourceSystemd Source = "systemd"
 )
 
 // ContainerRuntime is the container runtime used by a container.
@@ -134,6 +138,8 @@ const (
 	ContainerRuntimePodman     ContainerRuntime = "podman"
 	ContainerRuntimeCRIO       ContainerRuntime = "cri-o"
 	ContainerRuntimeGarden     ContainerRuntime = "garden"
+	ContainerRuntimeIncus      ContainerRuntime = "incus"
+	ContainerRuntimeLXD        ContainerRuntime = "lxd"
 	// ECS Fargate can be considered as a runtime in the sense that we don't
 	// know the actual runtime but we need to identify it's Fargate
 	ContainerRuntimeECSFargate          ContainerRuntime = "ecsfargate"
@@ -2496,6 +2502,88 @@ func (crd CRD) BuildGVK() string {
 	return crd.Group + "/" + crd.Version + "/" + crd.Kind
 }
 
+// SystemdUnit is a unit managed by the systemd instance of the host.
+type SystemdUnit struct {
+	EntityID // EntityID.ID is the unit name, e.g. nginx.service
+	EntityMeta
+
+	// UnitType is the suffix of the unit name, e.g. service or scope
+	UnitType    string
+	Description string
+
+	// LoadState, ActiveState and SubState are the states reported by
+	// `systemctl list-units`
+	LoadState   string
+	ActiveState string
+	SubState    string
+
+	// FragmentPath is the path of the unit file
+	FragmentPath string
+
+	// Slice is the slice the unit belongs to, e.g. system.slice
+	Slice string
+	// CgroupPath is the control group of the unit, relative to the cgroup
+	// root, e.g. /system.slice/nginx.service
+	CgroupPath string
+
+	// MainPID is the main process of a service. It is zero for the other
+	// unit types and for services without a main process.
+	MainPID int32
+	// PIDs are the processes running in the control group of the unit
+	PIDs []int32
+}
+
+var _ Entity = &SystemdUnit{}
+
+// GetID implements Entity#GetID.
+func (u SystemdUnit) GetID() EntityID {
+	return u.EntityID
+}
+
+// Merge implements Entity#Merge.
+func (u *SystemdUnit) Merge(e Entity) error {
+	otherUnit, ok := e.(*SystemdUnit)
+	if !ok {
+		return fmt.Errorf("cannot merge SystemdUnit with different kind %T", e)
+	}
+
+	return merge(u, otherUnit)
+}
+
+// DeepCopy implements Entity#DeepCopy.
+func (u SystemdUnit) DeepCopy() Entity {
+	cp := deepcopy.Copy(u).(SystemdUnit)
+	return &cp
+}
+
+// String implements Entity#String.
+func (u SystemdUnit) String(verbose bool) string {
+	var sb strings.Builder
+
+	_, _ = fmt.Fprintln(&sb, "----------- Entity ID -----------")
+	_, _ = fmt.Fprintln(&sb, u.EntityID.String(verbose))
+
+	_, _ = fmt.Fprintln(&sb, "----------- Entity Meta -----------")
+	_, _ = fmt.Fprintln(&sb, u.EntityMeta.String(verbose))
+
+	_, _ = fmt.Fprintln(&sb, "----------- Unit Info -----------")
+	_, _ = fmt.Fprintln(&sb, "Type:", u.UnitType)
+	_, _ = fmt.Fprintln(&sb, "Active State:", u.ActiveState)
+	_, _ = fmt.Fprintln(&sb, "Sub State:", u.SubState)
+	_, _ = fmt.Fprintln(&sb, "Slice:", u.Slice)
+	_, _ = fmt.Fprintln(&sb, "Main PID:", u.MainPID)
+
+	if verbose {
+		_, _ = fmt.Fprintln(&sb, "Description:", u.Description)
+		_, _ = fmt.Fprintln(&sb, "Load State:", u.LoadState)
+		_, _ = fmt.Fprintln(&sb, "Fragment Path:", u.FragmentPath)
+		_, _ = fmt.Fprintln(&sb, "Cgroup Path:", u.CgroupPath)
+		_, _ = fmt.Fprintln(&sb, "PIDs:", u.PIDs)
+	}
+
+	return sb.String()
+}
+
 // FeatureGateStage represents the maturity level of a Kubernetes feature gate
 type FeatureGateStage string
 
@@ -113,6 +113,6 @@ func expectedSourcesECS() map[wmdef.Kind][]wmdef.Source {
 }
 
 func containerRuntimeIsAccessible() bool {
-	runtimes := []env.Feature{env.Docker, env.Containerd, env.Crio, env.Podman}
+	runtimes := []env.Feature{env.Docker, env.Containerd, env.Crio, env.Podman, env.Incus}
 	return slices.ContainsFunc(runtimes, env.IsFeaturePresent)
 }
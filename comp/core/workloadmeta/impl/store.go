@@ -588,6 +588,28 @@ func (w *workloadmeta) ListGPUs() []*wmdef.GPU {
 	return gpuList
 }
 
+// GetSystemdUnit implements Store#GetSystemdUnit.
+func (w *workloadmeta) GetSystemdUnit(name string) (*wmdef.SystemdUnit, error) {
+	entity, err := w.getEntityByKind(wmdef.KindSystemdUnit, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.(*wmdef.SystemdUnit), nil
+}
+
+// ListSystemdUnits implements Store#ListSystemdUnits.
+func (w *workloadmeta) ListSystemdUnits() []*wmdef.SystemdUnit {
+	entities := w.listEntitiesByKind(wmdef.KindSystemdUnit)
+
+	units := make([]*wmdef.SystemdUnit, 0, len(entities))
+	for i := range entities {
+		units = append(units, entities[i].(*wmdef.SystemdUnit))
+	}
+
+	return units
+}
+
 // Notify implements Store#Notify
 func (w *workloadmeta) Notify(events []wmdef.CollectorEvent) {
 	if len(events) > 0 {
@@ -314,6 +314,10 @@ func toProtoRuntime(runtime workloadmeta.ContainerRuntime) (pb.Runtime, error) {
 		return pb.Runtime_CRIO, nil
 	case workloadmeta.ContainerRuntimeGarden:
 		return pb.Runtime_GARDEN, nil
+	case workloadmeta.ContainerRuntimeIncus:
+		return pb.Runtime_INCUS, nil
+	case workloadmeta.ContainerRuntimeLXD:
+		return pb.Runtime_LXD, nil
 	case workloadmeta.ContainerRuntimeECSFargate:
 		return pb.Runtime_ECS_FARGATE, nil
 	}
@@ -1036,6 +1040,10 @@ func toWorkloadmetaContainerRuntime(protoRuntime pb.Runtime) (workloadmeta.Conta
 		return workloadmeta.ContainerRuntimeCRIO, nil
 	case pb.Runtime_GARDEN:
 		return workloadmeta.ContainerRuntimeGarden, nil
+	case pb.Runtime_INCUS:
+		return workloadmeta.ContainerRuntimeIncus, nil
+	case pb.Runtime_LXD:
+		return workloadmeta.ContainerRuntimeLXD, nil
 	case pb.Runtime_ECS_FARGATE:
 		return workloadmeta.ContainerRuntimeECSFargate, nil
 	case pb.Runtime_UNKNOWN:
@@ -815,6 +815,25 @@ func TestConvertWorkloadEventToProtoWithUnpopulatedFields(t *testing.T) {
 	assert.Equal(t, expectedProtoEvent, actualProtoEvent)
 }
 
+func TestContainerRuntimeConversions(t *testing.T) {
+	for _, runtime := range []workloadmeta.ContainerRuntime{
+		workloadmeta.ContainerRuntimeDocker,
+		workloadmeta.ContainerRuntimeContainerd,
+		workloadmeta.ContainerRuntimePodman,
+		workloadmeta.ContainerRuntimeCRIO,
+		workloadmeta.ContainerRuntimeGarden,
+		workloadmeta.ContainerRuntimeIncus,
+		workloadmeta.ContainerRuntimeLXD,
+		workloadmeta.ContainerRuntimeECSFargate,
+	} {
+		protoRuntime, err := toProtoRuntime(runtime)
+		require.NoError(t, err, runtime)
+		converted, err := toWorkloadmetaContainerRuntime(protoRuntime)
+		require.NoError(t, err, runtime)
+		assert.Equal(t, runtime, converted)
+	}
+}
+
 func TestProtobufFilterFromWorkloadmetaFilter(t *testing.T) {
 	filter := workloadmeta.NewFilterBuilder().
 		SetSource(workloadmeta.SourceRuntime).
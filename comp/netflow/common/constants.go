@@ -21,6 +21,15 @@ const (
 	// DefaultAggregatorRollupTrackerRefreshInterval is the default aggregator rollup tracker refresh interval
 	DefaultAggregatorRollupTrackerRefreshInterval = 300 // 5min
 
+	// DefaultGeoIPReloadInterval is the default interval in seconds the GeoIP databases are checked for changes at
+	DefaultGeoIPReloadInterval = 60
+
+	// ReexportTypeIPFIX re-exports the aggregated flows as IPFIX over UDP
+	ReexportTypeIPFIX = "ipfix"
+
+	// ReexportTypeJSONLines re-exports the aggregated flows as JSON lines to a file or unix socket
+	ReexportTypeJSONLines = "jsonl"
+
 	// DefaultBindHost is the default bind host used for flow listeners
 	DefaultBindHost = "0.0.0.0"
 
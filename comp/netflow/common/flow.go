@@ -67,6 +67,10 @@ type Flow struct {
 	SrcReverseDNSHostname string
 	DstReverseDNSHostname string
 
+	// GeoIP enrichment added during Flow aggregation processing
+	SrcGeo GeoInfo
+	DstGeo GeoInfo
+
 	// Ethernet information
 	Tos uint32 // FLOW KEY
 
@@ -76,6 +80,14 @@ type Flow struct {
 	AdditionalFields AdditionalFields
 }
 
+// GeoInfo holds the location and autonomous system of an IP address
+type GeoInfo struct {
+	CountryISOCode string
+	City           string
+	ASNumber       uint32
+	ASOrganization string
+}
+
 // AdditionalFields holds additional fields collected
 type AdditionalFields = map[string]any
 
@@ -6,7 +6,9 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"net"
 
 	coreconfig "github.com/DataDog/datadog-agent/comp/core/config"
 	log "github.com/DataDog/datadog-agent/comp/core/log/def"
@@ -26,6 +28,7 @@ type NetflowConfig struct {
 	AggregatorPortRollupThreshold int              `mapstructure:"aggregator_port_rollup_threshold"`
 	AggregatorPortRollupDisabled  bool             `mapstructure:"aggregator_port_rollup_disabled"`
 	AggregatorMaxFlowsPerPeriod   int              `mapstructure:"aggregator_max_flows_per_flush_interval"`
+	AggregatorMaxFlowsGroupBy     string           `mapstructure:"aggregator_max_flows_group_by"`
 
 	// AggregatorRollupTrackerRefreshInterval is useful to speed up testing to avoid wait for 1h default
 	AggregatorRollupTrackerRefreshInterval uint `mapstructure:"aggregator_rollup_tracker_refresh_interval"`
@@ -34,6 +37,25 @@ type NetflowConfig struct {
 	PrometheusListenerEnabled bool   `mapstructure:"prometheus_listener_enabled"`
 
 	ReverseDNSEnrichmentEnabled bool `mapstructure:"reverse_dns_enrichment_enabled"`
+
+	GeoIP GeoIPConfig `mapstructure:"geoip"`
+
+	Reexport []ReexportConfig `mapstructure:"reexport"`
+}
+
+// GeoIPConfig contains configuration for the enrichment of flows from local MaxMind databases
+type GeoIPConfig struct {
+	Enabled          bool   `mapstructure:"enabled"`
+	CityDatabasePath string `mapstructure:"city_database_path"`
+	ASNDatabasePath  string `mapstructure:"asn_database_path"`
+	ReloadInterval   int    `mapstructure:"reload_interval"` // in seconds
+}
+
+// ReexportConfig contains configuration for a destination the aggregated flows are re-exported to
+type ReexportConfig struct {
+	Type                string `mapstructure:"type"`        // ipfix or jsonl
+	Destination         string `mapstructure:"destination"` // host:port for ipfix, file path or unix:///path for jsonl
+	ObservationDomainID uint32 `mapstructure:"observation_domain_id"`
 }
 
 // ListenerConfig contains configuration for a single flow listener
@@ -133,6 +155,31 @@ func (mainConfig *NetflowConfig) SetDefaults(namespace string, logger log.Compon
 		mainConfig.AggregatorRollupTrackerRefreshInterval = common.DefaultAggregatorRollupTrackerRefreshInterval
 	}
 
+	if mainConfig.GeoIP.Enabled {
+		if mainConfig.GeoIP.CityDatabasePath == "" && mainConfig.GeoIP.ASNDatabasePath == "" {
+			return errors.New("GeoIP enrichment is enabled, but neither `city_database_path` nor `asn_database_path` is set")
+		}
+		if mainConfig.GeoIP.ReloadInterval <= 0 {
+			mainConfig.GeoIP.ReloadInterval = common.DefaultGeoIPReloadInterval
+		}
+	}
+
+	for i := range mainConfig.Reexport {
+		reexportConfig := &mainConfig.Reexport[i]
+		if reexportConfig.Destination == "" {
+			return fmt.Errorf("reexport entry %d must set a `destination`", i)
+		}
+		switch reexportConfig.Type {
+		case common.ReexportTypeIPFIX:
+			if _, _, err := net.SplitHostPort(reexportConfig.Destination); err != nil {
+				return fmt.Errorf("invalid IPFIX reexport destination `%s`, it must be host:port: %s", reexportConfig.Destination, err)
+			}
+		case common.ReexportTypeJSONLines:
+		default:
+			return fmt.Errorf("the provided reexport type `%s` is not valid (valid types: %s, %s)", reexportConfig.Type, common.ReexportTypeIPFIX, common.ReexportTypeJSONLines)
+		}
+	}
+
 	if mainConfig.PrometheusListenerAddress == "" {
 		mainConfig.PrometheusListenerAddress = common.DefaultPrometheusListenerAddress
 	}
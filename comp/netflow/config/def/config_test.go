@@ -149,6 +149,147 @@ network_devices:
 				ReverseDNSEnrichmentEnabled: false,
 			},
 		},
+		{
+			name: "geoip enrichment",
+			configYaml: `
+network_devices:
+  netflow:
+    enabled: true
+    aggregator_max_flows_group_by: destination.as_number
+    geoip:
+      enabled: true
+      city_database_path: /opt/geoip/GeoLite2-City.mmdb
+      asn_database_path: /opt/geoip/GeoLite2-ASN.mmdb
+    listeners:
+      - flow_type: netflow9
+`,
+			expectedConfig: NetflowConfig{
+				Enabled:                                true,
+				StopTimeout:                            5,
+				AggregatorBufferSize:                   10000,
+				AggregatorFlushInterval:                300,
+				AggregatorFlowContextTTL:               300,
+				AggregatorPortRollupThreshold:          10,
+				AggregatorRollupTrackerRefreshInterval: 300,
+				AggregatorMaxFlowsGroupBy:              "destination.as_number",
+				PrometheusListenerAddress:              "localhost:9090",
+				Listeners: []ListenerConfig{
+					{
+						FlowType:  common.TypeNetFlow9,
+						BindHost:  "0.0.0.0",
+						Port:      uint16(2055),
+						Workers:   1,
+						Namespace: "default",
+					},
+				},
+				GeoIP: GeoIPConfig{
+					Enabled:          true,
+					CityDatabasePath: "/opt/geoip/GeoLite2-City.mmdb",
+					ASNDatabasePath:  "/opt/geoip/GeoLite2-ASN.mmdb",
+					ReloadInterval:   60,
+				},
+			},
+		},
+		{
+			name: "geoip enrichment without database",
+			configYaml: `
+network_devices:
+  netflow:
+    enabled: true
+    geoip:
+      enabled: true
+    listeners:
+      - flow_type: netflow9
+`,
+			expectedError: "GeoIP enrichment is enabled, but neither `city_database_path` nor `asn_database_path` is set",
+		},
+		{
+			name: "reexport",
+			configYaml: `
+network_devices:
+  netflow:
+    enabled: true
+    reexport:
+      - type: ipfix
+        destination: collector.example.com:4739
+        observation_domain_id: 42
+      - type: jsonl
+        destination: /var/log/datadog/netflow.jsonl
+    listeners:
+      - flow_type: netflow9
+`,
+			expectedConfig: NetflowConfig{
+				Enabled:                                true,
+				StopTimeout:                            5,
+				AggregatorBufferSize:                   10000,
+				AggregatorFlushInterval:                300,
+				AggregatorFlowContextTTL:               300,
+				AggregatorPortRollupThreshold:          10,
+				AggregatorRollupTrackerRefreshInterval: 300,
+				PrometheusListenerAddress:              "localhost:9090",
+				Listeners: []ListenerConfig{
+					{
+						FlowType:  common.TypeNetFlow9,
+						BindHost:  "0.0.0.0",
+						Port:      uint16(2055),
+						Workers:   1,
+						Namespace: "default",
+					},
+				},
+				Reexport: []ReexportConfig{
+					{
+						Type:                "ipfix",
+						Destination:         "collector.example.com:4739",
+						ObservationDomainID: 42,
+					},
+					{
+						Type:        "jsonl",
+						Destination: "/var/log/datadog/netflow.jsonl",
+					},
+				},
+			},
+		},
+		{
+			name: "reexport with invalid type",
+			configYaml: `
+network_devices:
+  netflow:
+    enabled: true
+    reexport:
+      - type: netflow9
+        destination: collector.example.com:2055
+    listeners:
+      - flow_type: netflow9
+`,
+			expectedError: "the provided reexport type `netflow9` is not valid (valid types: ipfix, jsonl)",
+		},
+		{
+			name: "reexport IPFIX without port",
+			configYaml: `
+network_devices:
+  netflow:
+    enabled: true
+    reexport:
+      - type: ipfix
+        destination: collector.example.com
+    listeners:
+      - flow_type: netflow9
+`,
+			expectedError: "invalid IPFIX reexport destination `collector.example.com`, it must be host:port",
+		},
+		{
+			name: "reexport without destination",
+			configYaml: `
+network_devices:
+  netflow:
+    enabled: true
+    reexport:
+      - type: jsonl
+    listeners:
+      - flow_type: netflow9
+`,
+			expectedError: "reexport entry 0 must set a `destination`",
+		},
 		{
 			name: "invalid flow type",
 			configYaml: `
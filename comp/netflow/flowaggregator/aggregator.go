@@ -35,7 +35,9 @@ import (
 
 	"github.com/DataDog/datadog-agent/comp/netflow/common"
 	"github.com/DataDog/datadog-agent/comp/netflow/config/def"
+	"github.com/DataDog/datadog-agent/comp/netflow/geoip"
 	"github.com/DataDog/datadog-agent/comp/netflow/goflowlib"
+	"github.com/DataDog/datadog-agent/comp/netflow/reexport"
 )
 
 const flushFlowsToSendInterval = 10 * time.Second
@@ -66,6 +68,14 @@ type FlowAggregator struct {
 
 	flowFilter FlowFlushFilter
 	logger     log.Component
+
+	reexporters []reexporter
+}
+
+// reexporter is a destination the flushed flows are re-exported to, along with the tags of its metrics
+type reexporter struct {
+	sink reexport.Sink
+	tags []string
 }
 
 type sequenceDeltaKey struct {
@@ -105,15 +115,40 @@ func NewFlowAggregator(sender sender.Sender, epForwarder eventplatform.Forwarder
 		flushConfig: flushConfig,
 	}
 	if config.AggregatorMaxFlowsPerPeriod > 0 {
-		topNFilter = topn.NewPerFlushFilter(int64(config.AggregatorMaxFlowsPerPeriod), flushConfig, sender, logger)
+		groupBy, err := topn.ParseGroupBy(config.AggregatorMaxFlowsGroupBy)
+		if err != nil {
+			logger.Errorf("Error parsing `aggregator_max_flows_group_by`, the top flows won't be grouped: %s", err)
+		}
+		topNFilter = topn.NewPerFlushFilter(int64(config.AggregatorMaxFlowsPerPeriod), groupBy, flushConfig, sender, logger)
 		flowScheduler = JitterFlowScheduler{flushConfig: flushConfig}
 	}
 
 	flowContextTTL := time.Duration(config.AggregatorFlowContextTTL) * time.Second
 	rollupTrackerRefreshInterval := time.Duration(config.AggregatorRollupTrackerRefreshInterval) * time.Second
+	flowAcc := newFlowAccumulator(flushConfig, flowScheduler, flowContextTTL, config.AggregatorPortRollupThreshold, config.AggregatorPortRollupDisabled, logger, rdnsQuerier)
+	if config.GeoIP.Enabled {
+		resolver, err := geoip.NewResolver(config.GeoIP, logger)
+		if err != nil {
+			logger.Errorf("Error loading GeoIP databases, flows won't be enriched with GeoIP data: %s", err)
+		} else {
+			flowAcc.geoIP = resolver
+		}
+	}
+	var reexporters []reexporter
+	for _, reexportConfig := range config.Reexport {
+		sink, err := reexport.NewSink(reexportConfig)
+		if err != nil {
+			logger.Errorf("Error creating the `%s` reexport to `%s`, flows won't be re-exported to it: %s", reexportConfig.Type, reexportConfig.Destination, err)
+			continue
+		}
+		reexporters = append(reexporters, reexporter{
+			sink: sink,
+			tags: []string{"reexport_type:" + reexportConfig.Type, "reexport_destination:" + reexportConfig.Destination},
+		})
+	}
 	return &FlowAggregator{
 		flowIn:                       make(chan *common.Flow, config.AggregatorBufferSize),
-		flowAcc:                      newFlowAccumulator(flushConfig, flowScheduler, flowContextTTL, config.AggregatorPortRollupThreshold, config.AggregatorPortRollupDisabled, logger, rdnsQuerier),
+		flowAcc:                      flowAcc,
 		FlushConfig:                  flushConfig,
 		rollupTrackerRefreshInterval: rollupTrackerRefreshInterval,
 		sender:                       sender,
@@ -132,12 +167,16 @@ func NewFlowAggregator(sender sender.Sender, epForwarder eventplatform.Forwarder
 		lastSequencePerExporter:      make(map[sequenceDeltaKey]uint32),
 		logger:                       logger,
 		flowFilter:                   topNFilter,
+		reexporters:                  reexporters,
 	}
 }
 
 // Start will start the FlowAggregator worker
 func (agg *FlowAggregator) Start() {
 	agg.logger.Info("Flow Aggregator started")
+	if agg.flowAcc.geoIP != nil {
+		agg.flowAcc.geoIP.Start()
+	}
 	go agg.run()
 	agg.flushLoop() // blocking call
 }
@@ -147,6 +186,14 @@ func (agg *FlowAggregator) Stop() {
 	close(agg.stopChan)
 	<-agg.flushLoopDone
 	<-agg.runDone
+	if agg.flowAcc.geoIP != nil {
+		agg.flowAcc.geoIP.Stop()
+	}
+	for _, r := range agg.reexporters {
+		if err := r.sink.Close(); err != nil {
+			agg.logger.Warnf("Error closing flows reexport: %s", err)
+		}
+	}
 }
 
 // GetFlowInChan returns flow input chan
@@ -214,6 +261,10 @@ func (agg *FlowAggregator) scheduleNetworkPathForFlow(flow *common.Flow) {
 }
 
 func (agg *FlowAggregator) sendFlows(flows []*common.Flow, flushTime time.Time) {
+	var records []reexport.Record
+	if len(agg.reexporters) > 0 {
+		records = make([]reexport.Record, 0, len(flows))
+	}
 	for _, flow := range flows {
 		flowPayload := buildPayload(flow, agg.hostname, flushTime)
 
@@ -224,6 +275,9 @@ func (agg *FlowAggregator) sendFlows(flows []*common.Flow, flushTime time.Time)
 			continue
 		}
 		agg.logger.Tracef("flushed flow: %s", string(payloadBytes))
+		if records != nil {
+			records = append(records, reexport.Record{Flow: flow, Payload: payloadBytes})
+		}
 
 		m := message.NewMessage(payloadBytes, nil, "", 0)
 		err = agg.epForwarder.SendEventPlatformEventBlocking(m, eventplatform.EventTypeNetworkDevicesNetFlow)
@@ -233,6 +287,22 @@ func (agg *FlowAggregator) sendFlows(flows []*common.Flow, flushTime time.Time)
 			continue
 		}
 	}
+	agg.reexportFlows(records, flushTime)
+}
+
+// reexportFlows sends the flushed flows to the configured reexport destinations
+func (agg *FlowAggregator) reexportFlows(records []reexport.Record, flushTime time.Time) {
+	if len(records) == 0 {
+		return
+	}
+	for _, r := range agg.reexporters {
+		if err := r.sink.Send(records, flushTime); err != nil {
+			agg.logger.Warnf("Error re-exporting flows: %s", err)
+			agg.sender.Count("datadog.netflow.aggregator.reexport.errors", 1, "", r.tags)
+			continue
+		}
+		agg.sender.Count("datadog.netflow.aggregator.reexport.flows", float64(len(records)), "", r.tags)
+	}
 }
 
 func (agg *FlowAggregator) sendExporterMetadata(flows []*common.Flow, flushTime time.Time) {
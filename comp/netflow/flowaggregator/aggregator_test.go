@@ -15,6 +15,8 @@ import (
 	"fmt"
 	"iter"
 	"net/netip"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -276,6 +278,68 @@ func TestFlowAggregator_flushSchedulesNetworkPathForSentFlow(t *testing.T) {
 	assert.Equal(t, model.ConnectionDirection_outgoing, collector.netflowConns[0].Direction)
 }
 
+func TestFlowAggregator_flushReexportsFlows(t *testing.T) {
+	flushTime, _ := time.Parse(time.RFC3339, "2019-02-18T16:00:00Z")
+	sender := mocksender.NewMockSender(t, "")
+	sender.On("Gauge", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	sender.On("Count", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	sender.On("MonotonicCount", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	sender.On("Commit").Return()
+
+	ctrl := gomock.NewController(t)
+	epForwarder := eventplatformimpl.NewMockEventPlatformForwarder(ctrl)
+	epForwarder.EXPECT().SendEventPlatformEventBlocking(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	reexportPath := filepath.Join(t.TempDir(), "flows.jsonl")
+	logger := logmock.New(t)
+	rdnsQuerier := fxutil.Test[rdnsquerier.Component](t, rdnsquerierfxmock.MockModule())
+	conf := &config.NetflowConfig{
+		AggregatorFlushInterval:                1,
+		AggregatorPortRollupThreshold:          10,
+		AggregatorRollupTrackerRefreshInterval: 3600,
+		Reexport: []config.ReexportConfig{
+			{Type: common.ReexportTypeJSONLines, Destination: reexportPath},
+		},
+	}
+	aggregator := NewFlowAggregator(sender, epForwarder, conf, "test-host", logger, rdnsQuerier, false, nil)
+
+	setMockTimeNow(flushTime)
+	aggregator.flowAcc.add(&common.Flow{
+		Namespace:      "netflow-ns",
+		FlowType:       common.TypeNetFlow9,
+		ExporterAddr:   []byte{127, 0, 0, 1},
+		StartTimestamp: 1234568,
+		EndTimestamp:   1234569,
+		Bytes:          20,
+		Packets:        4,
+		SrcAddr:        []byte{10, 0, 0, 1},
+		DstAddr:        []byte{10, 0, 0, 20},
+		IPProtocol:     uint32(6),
+		SrcPort:        12345,
+		DstPort:        443,
+		EtherType:      uint32(0x0800),
+	})
+
+	flushedCount := aggregator.flush(common.FlushContext{
+		FlushTime:     flushTime,
+		LastFlushedAt: time.Time{},
+		NumFlushes:    1,
+	})
+	assert.Equal(t, 1, flushedCount)
+
+	content, err := os.ReadFile(reexportPath)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	require.Len(t, lines, 1)
+	var flow map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &flow))
+	assert.Equal(t, "test-host", flow["host"])
+	assert.Equal(t, float64(20), flow["bytes"])
+	assert.Equal(t, map[string]any{"ip": "10.0.0.20", "port": "443", "mac": "00:00:00:00:00:00", "mask": "0.0.0.0/0"}, flow["destination"])
+
+	sender.AssertMetric(t, "Count", "datadog.netflow.aggregator.reexport.flows", 1, "", []string{"reexport_type:jsonl", "reexport_destination:" + reexportPath})
+}
+
 func TestAggregator(t *testing.T) {
 	stoppedMu := sync.RWMutex{} // Mutex needed to avoid race condition in test
 	flushTime, _ := time.Parse(time.RFC3339, "2019-02-18T16:00:06Z")
@@ -40,6 +40,7 @@ func buildPayload(aggFlow *common.Flow, hostname string, flushTime time.Time) pa
 			Mac:                format.MacAddress(aggFlow.SrcMac),
 			Mask:               format.CIDR(aggFlow.SrcAddr, aggFlow.SrcMask),
 			ReverseDNSHostname: aggFlow.SrcReverseDNSHostname,
+			Geo:                buildGeo(aggFlow.SrcGeo),
 		},
 		Destination: payload.Endpoint{
 			IP:                 format.IPAddr(aggFlow.DstAddr),
@@ -47,6 +48,7 @@ func buildPayload(aggFlow *common.Flow, hostname string, flushTime time.Time) pa
 			Mac:                format.MacAddress(aggFlow.DstMac),
 			Mask:               format.CIDR(aggFlow.DstAddr, aggFlow.DstMask),
 			ReverseDNSHostname: aggFlow.DstReverseDNSHostname,
+			Geo:                buildGeo(aggFlow.DstGeo),
 		},
 		Ingress: payload.ObservationPoint{
 			Interface: payload.Interface{
@@ -66,3 +68,16 @@ func buildPayload(aggFlow *common.Flow, hostname string, flushTime time.Time) pa
 		AdditionalFields: aggFlow.AdditionalFields,
 	}
 }
+
+// buildGeo returns the payload GeoIP enrichment, or nil when the endpoint wasn't found in the GeoIP databases
+func buildGeo(info common.GeoInfo) *payload.Geo {
+	if info == (common.GeoInfo{}) {
+		return nil
+	}
+	return &payload.Geo{
+		CountryISOCode: info.CountryISOCode,
+		City:           info.City,
+		ASNumber:       info.ASNumber,
+		ASOrganization: info.ASOrganization,
+	}
+}
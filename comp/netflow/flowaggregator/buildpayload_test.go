@@ -366,3 +366,30 @@ func Test_buildPayload(t *testing.T) {
 		})
 	}
 }
+
+func Test_buildPayload_geo(t *testing.T) {
+	flow := common.Flow{
+		FlowType: common.TypeNetFlow9,
+		SrcAddr:  []byte{10, 10, 10, 10},
+		DstAddr:  []byte{1, 128, 0, 1},
+		DstGeo: common.GeoInfo{
+			CountryISOCode: "AU",
+			City:           "Sydney",
+			ASNumber:       1221,
+			ASOrganization: "Telstra Pty Ltd",
+		},
+	}
+
+	flowPayload := buildPayload(&flow, "my-hostname", time.Now())
+	assert.Nil(t, flowPayload.Source.Geo)
+	assert.Equal(t, &payload.Geo{
+		CountryISOCode: "AU",
+		City:           "Sydney",
+		ASNumber:       1221,
+		ASOrganization: "Telstra Pty Ltd",
+	}, flowPayload.Destination.Geo)
+
+	endpointJSON, err := json.Marshal(flowPayload.Destination)
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"ip\":\"1.128.0.1\",\"port\":\"0\",\"mac\":\"00:00:00:00:00:00\",\"mask\":\"0.0.0.0/0\",\"geo\":{\"country_iso_code\":\"AU\",\"city\":\"Sydney\",\"as_number\":1221,\"as_organization\":\"Telstra Pty Ltd\"}}", string(endpointJSON))
+}
@@ -11,6 +11,7 @@ import (
 
 	log "github.com/DataDog/datadog-agent/comp/core/log/def"
 	"github.com/DataDog/datadog-agent/comp/netflow/common"
+	"github.com/DataDog/datadog-agent/comp/netflow/geoip"
 	"github.com/DataDog/datadog-agent/comp/netflow/portrollup"
 	rdnsquerier "github.com/DataDog/datadog-agent/comp/rdnsquerier/def"
 	"go.uber.org/atomic"
@@ -44,6 +45,9 @@ type flowAccumulator struct {
 
 	logger      log.Component
 	rdnsQuerier rdnsquerier.Component
+
+	// geoIP is nil when GeoIP enrichment is disabled
+	geoIP *geoip.Resolver
 }
 
 func newFlowAccumulator(flushConfig common.FlushConfig, flowScheduler FlowScheduler, aggregatorFlowContextTTL time.Duration, portRollupThreshold int, portRollupDisabled bool, logger log.Component, rdnsQuerier rdnsquerier.Component) *flowAccumulator {
@@ -147,12 +151,14 @@ func (f *flowAccumulator) add(flowToAdd *common.Flow) {
 			nextFlush: nextFlush,
 		}
 		f.addRDNSEnrichment(aggHash, flowToAdd.SrcAddr, flowToAdd.DstAddr)
+		f.addGeoIPEnrichment(flowToAdd)
 		return
 	}
 	if aggFlow.flow == nil {
 		// flowToAdd is for the same hash as an aggregated flow that has been flushed
 		aggFlow.flow = flowToAdd
 		f.addRDNSEnrichment(aggHash, flowToAdd.SrcAddr, flowToAdd.DstAddr)
+		f.addGeoIPEnrichment(flowToAdd)
 	} else {
 		// use go routine for hash collision detection to avoid blocking critical path
 		go f.detectHashCollision(aggHash, *aggFlow.flow, *flowToAdd)
@@ -253,6 +259,16 @@ func (f *flowAccumulator) addRDNSEnrichment(aggHash uint64, srcAddr []byte, dstA
 	}
 }
 
+// addGeoIPEnrichment looks up the location and autonomous system of the flow endpoints. Unlike reverse DNS, the
+// databases are local so the lookups are done synchronously.
+func (f *flowAccumulator) addGeoIPEnrichment(flow *common.Flow) {
+	if f.geoIP == nil {
+		return
+	}
+	flow.SrcGeo = f.geoIP.Lookup(flow.SrcAddr)
+	flow.DstGeo = f.geoIP.Lookup(flow.DstAddr)
+}
+
 func (f *flowAccumulator) getFlowContextCount() int {
 	f.flowsMutex.Lock()
 	defer f.flowsMutex.Unlock()
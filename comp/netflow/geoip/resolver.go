@@ -0,0 +1,214 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+// Package geoip enriches flows with the location and autonomous system of
+// their endpoints, read from local MaxMind databases. The databases are
+// reloaded when their files change, so that they can be updated without
+// restarting the agent.
+package geoip
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/oschwald/maxminddb-golang"
+
+	log "github.com/DataDog/datadog-agent/comp/core/log/def"
+	"github.com/DataDog/datadog-agent/comp/netflow/common"
+	config "github.com/DataDog/datadog-agent/comp/netflow/config/def"
+)
+
+// cityRecord holds the fields read from City and Country databases
+type cityRecord struct {
+	Country struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+	City struct {
+		Names map[string]string `maxminddb:"names"`
+	} `maxminddb:"city"`
+}
+
+// asnRecord holds the fields read from ASN databases
+type asnRecord struct {
+	AutonomousSystemNumber       uint32 `maxminddb:"autonomous_system_number"`
+	AutonomousSystemOrganization string `maxminddb:"autonomous_system_organization"`
+}
+
+// database is a MaxMind database opened from a file
+type database struct {
+	path    string
+	reader  *maxminddb.Reader
+	modTime time.Time
+	size    int64
+}
+
+// Resolver looks up the location and autonomous system of IP addresses
+type Resolver struct {
+	reloadInterval time.Duration
+	logger         log.Component
+
+	// mutex protects the databases, which are swapped when reloaded
+	mutex sync.RWMutex
+	city  *database
+	asn   *database
+
+	stopChan chan struct{}
+	stopped  chan struct{}
+}
+
+// NewResolver opens the configured databases
+func NewResolver(conf config.GeoIPConfig, logger log.Component) (*Resolver, error) {
+	r := &Resolver{
+		reloadInterval: time.Duration(conf.ReloadInterval) * time.Second,
+		logger:         logger,
+		stopChan:       make(chan struct{}),
+		stopped:        make(chan struct{}),
+	}
+
+	var err error
+	if conf.CityDatabasePath != "" {
+		if r.city, err = openDatabase(conf.CityDatabasePath); err != nil {
+			return nil, err
+		}
+	}
+	if conf.ASNDatabasePath != "" {
+		if r.asn, err = openDatabase(conf.ASNDatabasePath); err != nil {
+			r.close()
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func openDatabase(path string) (*database, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open GeoIP database: %w", err)
+	}
+	reader, err := maxminddb.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open GeoIP database %s: %w", path, err)
+	}
+	return &database{
+		path:    path,
+		reader:  reader,
+		modTime: info.ModTime(),
+		size:    info.Size(),
+	}, nil
+}
+
+// Start starts watching the databases for changes
+func (r *Resolver) Start() {
+	go r.watch()
+}
+
+// Stop stops watching the databases and closes them
+func (r *Resolver) Stop() {
+	close(r.stopChan)
+	<-r.stopped
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.close()
+}
+
+func (r *Resolver) close() {
+	for _, db := range []*database{r.city, r.asn} {
+		if db != nil {
+			db.reader.Close()
+		}
+	}
+}
+
+func (r *Resolver) watch() {
+	defer close(r.stopped)
+
+	ticker := time.NewTicker(r.reloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stopChan:
+			return
+		case <-ticker.C:
+			r.reload()
+		}
+	}
+}
+
+// reload reopens the databases whose file changed. A database that can't be
+// opened, for instance because it is being written, is kept until the next
+// reload.
+func (r *Resolver) reload() {
+	for _, current := range []**database{&r.city, &r.asn} {
+		r.mutex.RLock()
+		db := *current
+		r.mutex.RUnlock()
+		if db == nil {
+			continue
+		}
+
+		info, err := os.Stat(db.path)
+		if err != nil {
+			r.logger.Warnf("Unable to check GeoIP database %s for changes: %s", db.path, err)
+			continue
+		}
+		if info.ModTime().Equal(db.modTime) && info.Size() == db.size {
+			continue
+		}
+
+		updated, err := openDatabase(db.path)
+		if err != nil {
+			r.logger.Warnf("Unable to reload GeoIP database, keeping the previous one: %s", err)
+			continue
+		}
+
+		r.mutex.Lock()
+		*current = updated
+		r.mutex.Unlock()
+		db.reader.Close()
+
+		r.logger.Infof("Reloaded GeoIP database %s (%s, built %s)", db.path, updated.reader.Metadata.DatabaseType, time.Unix(int64(updated.reader.Metadata.BuildEpoch), 0).UTC().Format(time.DateOnly))
+	}
+}
+
+// Lookup returns the location and autonomous system of an IP address. The
+// fields that aren't found are left empty.
+func (r *Resolver) Lookup(addr []byte) common.GeoInfo {
+	var info common.GeoInfo
+
+	ip := net.IP(addr)
+	if len(ip) != net.IPv4len && len(ip) != net.IPv6len {
+		return info
+	}
+	if ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return info
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if r.city != nil {
+		var record cityRecord
+		if err := r.city.reader.Lookup(ip, &record); err != nil {
+			r.logger.Debugf("Error looking up the location of %s: %s", ip, err)
+		} else {
+			info.CountryISOCode = record.Country.ISOCode
+			info.City = record.City.Names["en"]
+		}
+	}
+	if r.asn != nil {
+		var record asnRecord
+		if err := r.asn.reader.Lookup(ip, &record); err != nil {
+			r.logger.Debugf("Error looking up the autonomous system of %s: %s", ip, err)
+		} else {
+			info.ASNumber = record.AutonomousSystemNumber
+			info.ASOrganization = record.AutonomousSystemOrganization
+		}
+	}
+	return info
+}
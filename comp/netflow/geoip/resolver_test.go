@@ -0,0 +1,274 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package geoip
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	logmock "github.com/DataDog/datadog-agent/comp/core/log/mock"
+	"github.com/DataDog/datadog-agent/comp/netflow/common"
+	config "github.com/DataDog/datadog-agent/comp/netflow/config/def"
+)
+
+// mmdbWriter writes minimal IPv4 MaxMind databases, with 24 bits records
+type mmdbWriter struct {
+	// nodes are the left and right records of the search tree, a record is
+	// either a node index, -1 when empty, or a data offset encoded as -2-offset
+	nodes [][2]int
+	data  bytes.Buffer
+}
+
+func (w *mmdbWriter) insert(t *testing.T, cidr string, value map[string]any) {
+	_, network, err := net.ParseCIDR(cidr)
+	require.NoError(t, err)
+	ones, _ := network.Mask.Size()
+
+	if len(w.nodes) == 0 {
+		w.nodes = append(w.nodes, [2]int{-1, -1})
+	}
+	offset := w.data.Len()
+	encode(&w.data, value)
+
+	node := 0
+	ip := network.IP.To4()
+	for i := 0; i < ones; i++ {
+		bit := (ip[i/8] >> (7 - i%8)) & 1
+		if i == ones-1 {
+			w.nodes[node][bit] = -2 - offset
+			break
+		}
+		if w.nodes[node][bit] < 0 {
+			w.nodes = append(w.nodes, [2]int{-1, -1})
+			w.nodes[node][bit] = len(w.nodes) - 1
+		}
+		node = w.nodes[node][bit]
+	}
+}
+
+func (w *mmdbWriter) write(t *testing.T, path string, databaseType string) {
+	var out bytes.Buffer
+	count := len(w.nodes)
+	for _, node := range w.nodes {
+		for _, record := range node {
+			value := record
+			switch {
+			case record == -1:
+				value = count
+			case record < -1:
+				value = count + 16 + (-2 - record)
+			}
+			out.Write([]byte{byte(value >> 16), byte(value >> 8), byte(value)})
+		}
+	}
+	out.Write(make([]byte, 16))
+	out.Write(w.data.Bytes())
+
+	out.WriteString("\xab\xcd\xefMaxMind.com")
+	encode(&out, map[string]any{
+		"binary_format_major_version": uint16(2),
+		"binary_format_minor_version": uint16(0),
+		"build_epoch":                 uint64(time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC).Unix()),
+		"database_type":               databaseType,
+		"description":                 map[string]any{"en": "Test database"},
+		"ip_version":                  uint16(4),
+		"languages":                   []any{"en"},
+		"node_count":                  uint32(count),
+		"record_size":                 uint16(24),
+	})
+
+	require.NoError(t, os.WriteFile(path, out.Bytes(), 0o644))
+}
+
+// encode encodes a value in the MaxMind DB data section format
+func encode(buf *bytes.Buffer, value any) {
+	header := func(typ int, size int) {
+		// sizes from 29 to 284 are stored in an extra byte, larger values
+		// aren't needed by the tests
+		control, extra := byte(size), []byte(nil)
+		if size >= 29 {
+			control, extra = 29, []byte{byte(size - 29)}
+		}
+		if typ <= 7 {
+			buf.WriteByte(byte(typ<<5) | control)
+		} else {
+			buf.WriteByte(control)
+			buf.WriteByte(byte(typ - 7))
+		}
+		buf.Write(extra)
+	}
+	unsigned := func(typ int, v uint64, size int) {
+		b := binary.BigEndian.AppendUint64(nil, v)[8-size:]
+		for len(b) > 0 && b[0] == 0 {
+			b = b[1:]
+		}
+		header(typ, len(b))
+		buf.Write(b)
+	}
+
+	switch v := value.(type) {
+	case string:
+		header(2, len(v))
+		buf.WriteString(v)
+	case uint16:
+		unsigned(5, uint64(v), 2)
+	case uint32:
+		unsigned(6, uint64(v), 4)
+	case uint64:
+		unsigned(9, v, 8)
+	case []any:
+		header(11, len(v))
+		for _, item := range v {
+			encode(buf, item)
+		}
+	case map[string]any:
+		header(7, len(v))
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			encode(buf, k)
+			encode(buf, v[k])
+		}
+	}
+}
+
+func writeCityDatabase(t *testing.T, path string, city string) {
+	var w mmdbWriter
+	w.insert(t, "81.2.69.0/24", map[string]any{
+		"city":    map[string]any{"names": map[string]any{"en": city, "fr": city}},
+		"country": map[string]any{"iso_code": "GB"},
+	})
+	w.insert(t, "89.160.20.0/24", map[string]any{
+		"country": map[string]any{"iso_code": "SE"},
+	})
+	w.write(t, path, "GeoLite2-City")
+}
+
+func writeASNDatabase(t *testing.T, path string) {
+	var w mmdbWriter
+	w.insert(t, "1.128.0.0/11", map[string]any{
+		"autonomous_system_number":       uint32(1221),
+		"autonomous_system_organization": "Telstra Pty Ltd",
+	})
+	w.write(t, path, "GeoLite2-ASN")
+}
+
+func TestResolverLookup(t *testing.T) {
+	dir := t.TempDir()
+	cityPath := filepath.Join(dir, "city.mmdb")
+	asnPath := filepath.Join(dir, "asn.mmdb")
+	writeCityDatabase(t, cityPath, "London")
+	writeASNDatabase(t, asnPath)
+
+	r, err := NewResolver(config.GeoIPConfig{
+		Enabled:          true,
+		CityDatabasePath: cityPath,
+		ASNDatabasePath:  asnPath,
+		ReloadInterval:   60,
+	}, logmock.New(t))
+	require.NoError(t, err)
+	defer r.close()
+
+	tests := []struct {
+		name     string
+		ip       net.IP
+		expected common.GeoInfo
+	}{
+		{
+			name:     "city",
+			ip:       net.ParseIP("81.2.69.142").To4(),
+			expected: common.GeoInfo{CountryISOCode: "GB", City: "London"},
+		},
+		{
+			name:     "country only",
+			ip:       net.ParseIP("89.160.20.112").To4(),
+			expected: common.GeoInfo{CountryISOCode: "SE"},
+		},
+		{
+			name:     "autonomous system",
+			ip:       net.ParseIP("1.128.0.1").To4(),
+			expected: common.GeoInfo{ASNumber: 1221, ASOrganization: "Telstra Pty Ltd"},
+		},
+		{
+			name: "not found",
+			ip:   net.ParseIP("8.8.8.8").To4(),
+		},
+		{
+			name: "private",
+			ip:   net.ParseIP("10.0.0.1").To4(),
+		},
+		{
+			name: "IPv6 in IPv4 databases",
+			ip:   net.ParseIP("2001:db8::1"),
+		},
+		{
+			name: "invalid",
+			ip:   []byte{1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, r.Lookup(tt.ip))
+		})
+	}
+}
+
+func TestResolverReload(t *testing.T) {
+	dir := t.TempDir()
+	cityPath := filepath.Join(dir, "city.mmdb")
+	writeCityDatabase(t, cityPath, "London")
+
+	r, err := NewResolver(config.GeoIPConfig{
+		Enabled:          true,
+		CityDatabasePath: cityPath,
+		ReloadInterval:   60,
+	}, logmock.New(t))
+	require.NoError(t, err)
+	defer r.close()
+
+	ip := net.ParseIP("81.2.69.142").To4()
+	assert.Equal(t, "London", r.Lookup(ip).City)
+
+	// an unchanged database isn't reloaded
+	previous := r.city
+	r.reload()
+	assert.Same(t, previous, r.city)
+
+	// the databases are replaced by renaming the updated files over them, like
+	// geoipupdate does, a corrupted database is ignored
+	updatedPath := filepath.Join(dir, "updated.mmdb")
+	require.NoError(t, os.WriteFile(updatedPath, []byte("corrupted"), 0o644))
+	require.NoError(t, os.Rename(updatedPath, cityPath))
+	r.reload()
+	assert.Same(t, previous, r.city)
+	assert.Equal(t, "London", r.Lookup(ip).City)
+
+	writeCityDatabase(t, updatedPath, "Londres")
+	require.NoError(t, os.Rename(updatedPath, cityPath))
+	r.reload()
+	assert.NotSame(t, previous, r.city)
+	assert.Equal(t, "Londres", r.Lookup(ip).City)
+}
+
+func TestNewResolverMissingDatabase(t *testing.T) {
+	_, err := NewResolver(config.GeoIPConfig{
+		Enabled:         true,
+		ASNDatabasePath: filepath.Join(t.TempDir(), "missing.mmdb"),
+	}, logmock.New(t))
+	assert.ErrorContains(t, err, "unable to open GeoIP database")
+}
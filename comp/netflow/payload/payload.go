@@ -25,6 +25,15 @@ type Endpoint struct {
 	Mac                string `json:"mac"`
 	Mask               string `json:"mask"`
 	ReverseDNSHostname string `json:"reverse_dns_hostname,omitempty"`
+	Geo                *Geo   `json:"geo,omitempty"`
+}
+
+// Geo contains the location and autonomous system of an endpoint
+type Geo struct {
+	CountryISOCode string `json:"country_iso_code,omitempty"`
+	City           string `json:"city,omitempty"`
+	ASNumber       uint32 `json:"as_number,omitempty"`
+	ASOrganization string `json:"as_organization,omitempty"`
 }
 
 // NextHop contains next hop details
@@ -0,0 +1,271 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package reexport
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/DataDog/datadog-agent/comp/netflow/common"
+)
+
+const (
+	ipfixVersion       = 10
+	ipfixHeaderLen     = 16
+	ipfixSetHeaderLen  = 4
+	ipfixTemplateSetID = 2
+
+	// maxMessageSize keeps the messages within the usual MTU to avoid IP fragmentation
+	maxMessageSize = 1400
+
+	// templateIDBase is the ID of the first template, lower IDs are reserved for sets (RFC 7011)
+	templateIDBase = 256
+)
+
+// ipfixField is an information element of the templates, its value is read
+// from the flow either as a number or as an IP address
+type ipfixField struct {
+	id     uint16
+	length uint16
+	number func(flow *common.Flow) uint64
+	addr   func(flow *common.Flow) []byte
+}
+
+// flowFields are the information elements (RFC 5102) sent for every flow
+var flowFields = []ipfixField{
+	{id: 150, length: 4, number: func(f *common.Flow) uint64 { return f.StartTimestamp }},         // flowStartSeconds
+	{id: 151, length: 4, number: func(f *common.Flow) uint64 { return f.EndTimestamp }},           // flowEndSeconds
+	{id: 1, length: 8, number: func(f *common.Flow) uint64 { return f.Bytes }},                    // octetDeltaCount
+	{id: 2, length: 8, number: func(f *common.Flow) uint64 { return f.Packets }},                  // packetDeltaCount
+	{id: 34, length: 4, number: func(f *common.Flow) uint64 { return f.SamplingRate }},            // samplingInterval
+	{id: 61, length: 1, number: func(f *common.Flow) uint64 { return uint64(f.Direction) }},       // flowDirection
+	{id: 256, length: 2, number: func(f *common.Flow) uint64 { return uint64(f.EtherType) }},      // ethernetType
+	{id: 4, length: 1, number: func(f *common.Flow) uint64 { return uint64(f.IPProtocol) }},       // protocolIdentifier
+	{id: 5, length: 1, number: func(f *common.Flow) uint64 { return uint64(f.Tos) }},              // ipClassOfService
+	{id: 6, length: 2, number: func(f *common.Flow) uint64 { return uint64(f.TCPFlags) }},         // tcpControlBits
+	{id: 7, length: 2, number: func(f *common.Flow) uint64 { return port(f.SrcPort) }},            // sourceTransportPort
+	{id: 11, length: 2, number: func(f *common.Flow) uint64 { return port(f.DstPort) }},           // destinationTransportPort
+	{id: 10, length: 4, number: func(f *common.Flow) uint64 { return uint64(f.InputInterface) }},  // ingressInterface
+	{id: 14, length: 4, number: func(f *common.Flow) uint64 { return uint64(f.OutputInterface) }}, // egressInterface
+	{id: 56, length: 6, number: func(f *common.Flow) uint64 { return f.SrcMac }},                  // sourceMacAddress
+	{id: 80, length: 6, number: func(f *common.Flow) uint64 { return f.DstMac }},                  // destinationMacAddress
+	{id: 16, length: 4, number: func(f *common.Flow) uint64 { return uint64(f.SrcGeo.ASNumber) }}, // bgpSourceAsNumber
+	{id: 17, length: 4, number: func(f *common.Flow) uint64 { return uint64(f.DstGeo.ASNumber) }}, // bgpDestinationAsNumber
+}
+
+var ipv4Fields = []ipfixField{
+	{id: 8, length: 4, addr: func(f *common.Flow) []byte { return f.SrcAddr }},            // sourceIPv4Address
+	{id: 12, length: 4, addr: func(f *common.Flow) []byte { return f.DstAddr }},           // destinationIPv4Address
+	{id: 9, length: 1, number: func(f *common.Flow) uint64 { return uint64(f.SrcMask) }},  // sourceIPv4PrefixLength
+	{id: 13, length: 1, number: func(f *common.Flow) uint64 { return uint64(f.DstMask) }}, // destinationIPv4PrefixLength
+	{id: 15, length: 4, addr: func(f *common.Flow) []byte { return f.NextHop }},           // ipNextHopIPv4Address
+}
+
+var ipv6Fields = []ipfixField{
+	{id: 27, length: 16, addr: func(f *common.Flow) []byte { return f.SrcAddr }},          // sourceIPv6Address
+	{id: 28, length: 16, addr: func(f *common.Flow) []byte { return f.DstAddr }},          // destinationIPv6Address
+	{id: 29, length: 1, number: func(f *common.Flow) uint64 { return uint64(f.SrcMask) }}, // sourceIPv6PrefixLength
+	{id: 30, length: 1, number: func(f *common.Flow) uint64 { return uint64(f.DstMask) }}, // destinationIPv6PrefixLength
+	{id: 62, length: 16, addr: func(f *common.Flow) []byte { return f.NextHop }},          // ipNextHopIPv6Address
+}
+
+var (
+	exporterIPv4Field = ipfixField{id: 130, length: 4, addr: func(f *common.Flow) []byte { return f.ExporterAddr }}  // exporterIPv4Address
+	exporterIPv6Field = ipfixField{id: 131, length: 16, addr: func(f *common.Flow) []byte { return f.ExporterAddr }} // exporterIPv6Address
+)
+
+// templates holds a template per combination of flow and exporter address
+// families, indexed by their ID minus templateIDBase (see templateID)
+var templates = [][]ipfixField{
+	buildTemplate(ipv4Fields, exporterIPv4Field),
+	buildTemplate(ipv4Fields, exporterIPv6Field),
+	buildTemplate(ipv6Fields, exporterIPv4Field),
+	buildTemplate(ipv6Fields, exporterIPv6Field),
+}
+
+func buildTemplate(addrFields []ipfixField, exporterField ipfixField) []ipfixField {
+	fields := append([]ipfixField{}, flowFields...)
+	fields = append(fields, addrFields...)
+	return append(fields, exporterField)
+}
+
+// templateID returns the ID of the template matching the address families of a flow
+func templateID(flow *common.Flow) uint16 {
+	id := uint16(templateIDBase)
+	if len(flow.SrcAddr) == net.IPv6len || len(flow.DstAddr) == net.IPv6len {
+		id += 2
+	}
+	if len(flow.ExporterAddr) == net.IPv6len {
+		id++
+	}
+	return id
+}
+
+// port returns the transport port of a flow, rolled up ephemeral ports being exported as 0
+func port(p int32) uint64 {
+	return uint64(max(p, 0))
+}
+
+func recordLen(fields []ipfixField) int {
+	size := 0
+	for _, field := range fields {
+		size += int(field.length)
+	}
+	return size
+}
+
+func appendRecord(b []byte, fields []ipfixField, flow *common.Flow) []byte {
+	for _, field := range fields {
+		start := len(b)
+		b = append(b, make([]byte, field.length)...)
+		value := b[start:]
+		if field.number != nil {
+			n := field.number(flow)
+			for i := len(value) - 1; i >= 0; i-- {
+				value[i] = byte(n)
+				n >>= 8
+			}
+		} else if addr := field.addr(flow); len(addr) == len(value) {
+			// addresses of another family are left unset
+			copy(value, addr)
+		}
+	}
+	return b
+}
+
+// ipfixEncoder encodes flows into IPFIX messages (RFC 7011)
+type ipfixEncoder struct {
+	observationDomainID uint32
+	// sequence is the number of data records sent so far
+	sequence uint32
+}
+
+// encode returns the messages holding the flows. The templates are sent in
+// the first message of every flush, flushes being far more frequent than the
+// template timeouts of collectors.
+func (e *ipfixEncoder) encode(flows []*common.Flow, exportTime time.Time) [][]byte {
+	var messages [][]byte
+
+	msg := e.newMessage()
+	msg = appendTemplateSet(msg)
+	records := 0
+	setStart, setID := 0, uint16(0)
+	for _, flow := range flows {
+		id := templateID(flow)
+		fields := templates[id-templateIDBase]
+
+		size := recordLen(fields)
+		if setID != id {
+			size += ipfixSetHeaderLen
+		}
+		if records > 0 && len(msg)+size > maxMessageSize {
+			endSet(msg, setStart)
+			messages = append(messages, e.endMessage(msg, exportTime, records))
+			msg = e.newMessage()
+			records, setID = 0, 0
+		}
+		if setID != id {
+			if setID != 0 {
+				endSet(msg, setStart)
+			}
+			setStart, setID = len(msg), id
+			msg = binary.BigEndian.AppendUint16(msg, id)
+			msg = append(msg, 0, 0) // length, set by endSet
+		}
+		msg = appendRecord(msg, fields, flow)
+		records++
+	}
+	if setID != 0 {
+		endSet(msg, setStart)
+	}
+	return append(messages, e.endMessage(msg, exportTime, records))
+}
+
+func (e *ipfixEncoder) newMessage() []byte {
+	return make([]byte, ipfixHeaderLen, maxMessageSize)
+}
+
+// endMessage fills the header of a message holding the given number of data records
+func (e *ipfixEncoder) endMessage(msg []byte, exportTime time.Time, records int) []byte {
+	binary.BigEndian.PutUint16(msg[0:], ipfixVersion)
+	binary.BigEndian.PutUint16(msg[2:], uint16(len(msg)))
+	binary.BigEndian.PutUint32(msg[4:], uint32(exportTime.Unix()))
+	binary.BigEndian.PutUint32(msg[8:], e.sequence)
+	binary.BigEndian.PutUint32(msg[12:], e.observationDomainID)
+	e.sequence += uint32(records)
+	return msg
+}
+
+func appendTemplateSet(msg []byte) []byte {
+	setStart := len(msg)
+	msg = binary.BigEndian.AppendUint16(msg, ipfixTemplateSetID)
+	msg = append(msg, 0, 0)
+	for i, fields := range templates {
+		msg = binary.BigEndian.AppendUint16(msg, uint16(templateIDBase+i))
+		msg = binary.BigEndian.AppendUint16(msg, uint16(len(fields)))
+		for _, field := range fields {
+			msg = binary.BigEndian.AppendUint16(msg, field.id)
+			msg = binary.BigEndian.AppendUint16(msg, field.length)
+		}
+	}
+	endSet(msg, setStart)
+	return msg
+}
+
+// endSet fills the length of the set starting at setStart
+func endSet(msg []byte, setStart int) {
+	binary.BigEndian.PutUint16(msg[setStart+2:], uint16(len(msg)-setStart))
+}
+
+// ipfixSink re-exports the flows to an IPFIX collector over UDP
+type ipfixSink struct {
+	destination string
+	encoder     ipfixEncoder
+	conn        net.Conn
+}
+
+func newIPFIXSink(destination string, observationDomainID uint32) *ipfixSink {
+	return &ipfixSink{
+		destination: destination,
+		encoder:     ipfixEncoder{observationDomainID: observationDomainID},
+	}
+}
+
+// Send implements Sink
+func (s *ipfixSink) Send(records []Record, flushTime time.Time) error {
+	if s.conn == nil {
+		conn, err := net.Dial("udp", s.destination)
+		if err != nil {
+			return fmt.Errorf("unable to connect to IPFIX collector %s: %w", s.destination, err)
+		}
+		s.conn = conn
+	}
+
+	flows := make([]*common.Flow, 0, len(records))
+	for _, record := range records {
+		flows = append(flows, record.Flow)
+	}
+	for _, msg := range s.encoder.encode(flows, flushTime) {
+		if _, err := s.conn.Write(msg); err != nil {
+			// reconnect on the next flush, in case the collector address changed
+			s.Close()
+			return fmt.Errorf("error sending IPFIX message to %s: %w", s.destination, err)
+		}
+	}
+	return nil
+}
+
+// Close implements Sink
+func (s *ipfixSink) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
@@ -0,0 +1,216 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package reexport
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/comp/netflow/common"
+	config "github.com/DataDog/datadog-agent/comp/netflow/config/def"
+	"github.com/DataDog/datadog-agent/comp/netflow/portrollup"
+)
+
+// ipfixSet is a set decoded from an IPFIX message
+type ipfixSet struct {
+	id   uint16
+	data []byte
+}
+
+// decodeMessage decodes the header and sets of an IPFIX message
+func decodeMessage(t *testing.T, msg []byte) (sequence uint32, domainID uint32, sets []ipfixSet) {
+	t.Helper()
+	require.GreaterOrEqual(t, len(msg), ipfixHeaderLen)
+	require.Equal(t, uint16(ipfixVersion), binary.BigEndian.Uint16(msg[0:]))
+	require.Equal(t, len(msg), int(binary.BigEndian.Uint16(msg[2:])))
+	sequence = binary.BigEndian.Uint32(msg[8:])
+	domainID = binary.BigEndian.Uint32(msg[12:])
+
+	rest := msg[ipfixHeaderLen:]
+	for len(rest) > 0 {
+		require.GreaterOrEqual(t, len(rest), ipfixSetHeaderLen)
+		length := int(binary.BigEndian.Uint16(rest[2:]))
+		require.LessOrEqual(t, length, len(rest))
+		sets = append(sets, ipfixSet{id: binary.BigEndian.Uint16(rest), data: rest[ipfixSetHeaderLen:length]})
+		rest = rest[length:]
+	}
+	return sequence, domainID, sets
+}
+
+// decodeRecord returns the values of a data record by information element ID
+func decodeRecord(fields []ipfixField, data []byte) map[uint16][]byte {
+	values := make(map[uint16][]byte)
+	for _, field := range fields {
+		values[field.id] = data[:field.length]
+		data = data[field.length:]
+	}
+	return values
+}
+
+func TestIPFIXEncoder_encode(t *testing.T) {
+	exportTime := time.Unix(1700000000, 0)
+	flows := []*common.Flow{
+		{
+			ExporterAddr:    []byte{127, 0, 0, 1},
+			StartTimestamp:  1699999990,
+			EndTimestamp:    1699999999,
+			Bytes:           1500,
+			Packets:         3,
+			SamplingRate:    10,
+			EtherType:       0x0800,
+			IPProtocol:      6,
+			TCPFlags:        0x12,
+			SrcAddr:         []byte{10, 0, 0, 1},
+			DstAddr:         []byte{8, 8, 8, 8},
+			SrcPort:         portrollup.EphemeralPort,
+			DstPort:         443,
+			SrcMask:         24,
+			DstMask:         16,
+			InputInterface:  1,
+			OutputInterface: 2,
+			SrcMac:          0x0a0b0c0d0e0f,
+			NextHop:         []byte{10, 0, 0, 254},
+			DstGeo:          common.GeoInfo{ASNumber: 15169},
+		},
+		{
+			ExporterAddr: []byte{127, 0, 0, 1},
+			Bytes:        100,
+			SrcAddr:      net.ParseIP("2001:db8::1"),
+			DstAddr:      net.ParseIP("2001:db8::2"),
+			DstPort:      53,
+		},
+	}
+
+	encoder := ipfixEncoder{observationDomainID: 42}
+	messages := encoder.encode(flows, exportTime)
+	require.Len(t, messages, 1)
+
+	sequence, domainID, sets := decodeMessage(t, messages[0])
+	assert.Equal(t, uint32(0), sequence)
+	assert.Equal(t, uint32(42), domainID)
+	assert.Equal(t, uint32(exportTime.Unix()), binary.BigEndian.Uint32(messages[0][4:]))
+	require.Len(t, sets, 3)
+
+	// template set
+	assert.Equal(t, uint16(ipfixTemplateSetID), sets[0].id)
+	tmpl := sets[0].data
+	for i, fields := range templates {
+		assert.Equal(t, uint16(templateIDBase+i), binary.BigEndian.Uint16(tmpl))
+		require.Equal(t, len(fields), int(binary.BigEndian.Uint16(tmpl[2:])))
+		tmpl = tmpl[4:]
+		for _, field := range fields {
+			assert.Equal(t, field.id, binary.BigEndian.Uint16(tmpl))
+			assert.Equal(t, field.length, binary.BigEndian.Uint16(tmpl[2:]))
+			tmpl = tmpl[4:]
+		}
+	}
+	assert.Empty(t, tmpl)
+
+	// IPv4 flow
+	assert.Equal(t, uint16(256), sets[1].id)
+	require.Len(t, sets[1].data, recordLen(templates[0]))
+	v4 := decodeRecord(templates[0], sets[1].data)
+	assert.Equal(t, uint64(1500), binary.BigEndian.Uint64(v4[1]))
+	assert.Equal(t, uint64(3), binary.BigEndian.Uint64(v4[2]))
+	assert.Equal(t, []byte{6}, v4[4])
+	assert.Equal(t, []byte{0, 0x12}, v4[6])
+	assert.Equal(t, []byte{0, 0}, v4[7])
+	assert.Equal(t, uint16(443), binary.BigEndian.Uint16(v4[11]))
+	assert.Equal(t, []byte{10, 0, 0, 1}, v4[8])
+	assert.Equal(t, []byte{8, 8, 8, 8}, v4[12])
+	assert.Equal(t, []byte{24}, v4[9])
+	assert.Equal(t, []byte{10, 0, 0, 254}, v4[15])
+	assert.Equal(t, []byte{0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f}, v4[56])
+	assert.Equal(t, uint32(0), binary.BigEndian.Uint32(v4[16]))
+	assert.Equal(t, uint32(15169), binary.BigEndian.Uint32(v4[17]))
+	assert.Equal(t, uint32(1699999990), binary.BigEndian.Uint32(v4[150]))
+	assert.Equal(t, []byte{127, 0, 0, 1}, v4[130])
+
+	// IPv6 flow
+	assert.Equal(t, uint16(258), sets[2].id)
+	require.Len(t, sets[2].data, recordLen(templates[2]))
+	v6 := decodeRecord(templates[2], sets[2].data)
+	assert.Equal(t, []byte(net.ParseIP("2001:db8::1")), v6[27])
+	assert.Equal(t, []byte(net.ParseIP("2001:db8::2")), v6[28])
+	assert.Equal(t, make([]byte, 16), v6[62])
+	assert.Equal(t, uint16(53), binary.BigEndian.Uint16(v6[11]))
+	assert.Equal(t, []byte{127, 0, 0, 1}, v6[130])
+
+	// the sequence number counts the data records already sent
+	messages = encoder.encode(flows[:1], exportTime)
+	require.Len(t, messages, 1)
+	sequence, _, _ = decodeMessage(t, messages[0])
+	assert.Equal(t, uint32(2), sequence)
+}
+
+func TestIPFIXEncoder_encodeSplitsMessages(t *testing.T) {
+	var flows []*common.Flow
+	for i := 0; i < 40; i++ {
+		flows = append(flows, &common.Flow{
+			ExporterAddr: []byte{127, 0, 0, 1},
+			SrcAddr:      []byte{10, 0, 0, byte(i)},
+			DstAddr:      []byte{10, 0, 1, byte(i)},
+		})
+	}
+
+	encoder := ipfixEncoder{}
+	messages := encoder.encode(flows, time.Now())
+	require.Greater(t, len(messages), 1)
+
+	records := 0
+	for i, msg := range messages {
+		assert.LessOrEqual(t, len(msg), maxMessageSize)
+		sequence, _, sets := decodeMessage(t, msg)
+		assert.Equal(t, uint32(records), sequence)
+		if i == 0 {
+			assert.Equal(t, uint16(ipfixTemplateSetID), sets[0].id)
+			sets = sets[1:]
+		}
+		require.Len(t, sets, 1)
+		assert.Equal(t, uint16(256), sets[0].id)
+		records += len(sets[0].data) / recordLen(templates[0])
+	}
+	assert.Equal(t, len(flows), records)
+	assert.Equal(t, uint32(len(flows)), encoder.sequence)
+}
+
+func TestIPFIXSink_Send(t *testing.T) {
+	collector, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer collector.Close()
+
+	sink, err := NewSink(config.ReexportConfig{
+		Type:                common.ReexportTypeIPFIX,
+		Destination:         collector.LocalAddr().String(),
+		ObservationDomainID: 7,
+	})
+	require.NoError(t, err)
+	defer sink.Close()
+
+	flow := &common.Flow{
+		ExporterAddr: []byte{127, 0, 0, 1},
+		SrcAddr:      []byte{10, 0, 0, 1},
+		DstAddr:      []byte{10, 0, 0, 2},
+		Bytes:        64,
+	}
+	require.NoError(t, sink.Send([]Record{{Flow: flow}}, time.Now()))
+
+	buf := make([]byte, 65535)
+	require.NoError(t, collector.SetReadDeadline(time.Now().Add(5*time.Second)))
+	n, _, err := collector.ReadFrom(buf)
+	require.NoError(t, err)
+
+	_, domainID, sets := decodeMessage(t, buf[:n])
+	assert.Equal(t, uint32(7), domainID)
+	require.Len(t, sets, 2)
+	values := decodeRecord(templates[0], sets[1].data)
+	assert.Equal(t, uint64(64), binary.BigEndian.Uint64(values[1]))
+}
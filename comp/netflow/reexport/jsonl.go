@@ -0,0 +1,97 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package reexport
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// unixSocketPrefix marks JSON lines destinations that are unix stream sockets rather than files
+	unixSocketPrefix = "unix://"
+
+	// socketTimeout bounds the time the flush waits for a slow socket reader
+	socketTimeout = 5 * time.Second
+)
+
+// jsonLinesSink re-exports the flow payloads as JSON lines, appended to a
+// file or written to a unix socket
+type jsonLinesSink struct {
+	destination string
+	// socketPath is set when the destination is a unix socket
+	socketPath string
+	conn       net.Conn
+}
+
+func newJSONLinesSink(destination string) *jsonLinesSink {
+	sink := &jsonLinesSink{destination: destination}
+	if path, ok := strings.CutPrefix(destination, unixSocketPrefix); ok {
+		sink.socketPath = path
+	}
+	return sink
+}
+
+// Send implements Sink
+func (s *jsonLinesSink) Send(records []Record, _ time.Time) error {
+	var buf bytes.Buffer
+	for _, record := range records {
+		buf.Write(record.Payload)
+		buf.WriteByte('\n')
+	}
+	if s.socketPath != "" {
+		return s.writeSocket(buf.Bytes())
+	}
+	return s.writeFile(buf.Bytes())
+}
+
+// writeFile appends the lines to the file, which is reopened on every flush
+// so that it can be rotated
+func (s *jsonLinesSink) writeFile(lines []byte) error {
+	f, err := os.OpenFile(s.destination, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0640)
+	if err != nil {
+		return fmt.Errorf("unable to open %s: %w", s.destination, err)
+	}
+	if _, err = f.Write(lines); err != nil {
+		f.Close()
+		return fmt.Errorf("error writing flows to %s: %w", s.destination, err)
+	}
+	return f.Close()
+}
+
+func (s *jsonLinesSink) writeSocket(lines []byte) error {
+	if s.conn == nil {
+		conn, err := net.DialTimeout("unix", s.socketPath, socketTimeout)
+		if err != nil {
+			return fmt.Errorf("unable to connect to %s: %w", s.socketPath, err)
+		}
+		s.conn = conn
+	}
+	if err := s.conn.SetWriteDeadline(time.Now().Add(socketTimeout)); err != nil {
+		s.Close()
+		return fmt.Errorf("error writing flows to %s: %w", s.socketPath, err)
+	}
+	if _, err := s.conn.Write(lines); err != nil {
+		// reconnect on the next flush
+		s.Close()
+		return fmt.Errorf("error writing flows to %s: %w", s.socketPath, err)
+	}
+	return nil
+}
+
+// Close implements Sink
+func (s *jsonLinesSink) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
@@ -0,0 +1,98 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package reexport
+
+import (
+	"bufio"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/comp/netflow/common"
+	config "github.com/DataDog/datadog-agent/comp/netflow/config/def"
+)
+
+func TestJSONLinesSink_file(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flows.jsonl")
+	sink, err := NewSink(config.ReexportConfig{Type: common.ReexportTypeJSONLines, Destination: path})
+	require.NoError(t, err)
+	defer sink.Close()
+
+	require.NoError(t, sink.Send([]Record{{Payload: []byte(`{"bytes":1}`)}, {Payload: []byte(`{"bytes":2}`)}}, time.Now()))
+	require.NoError(t, sink.Send([]Record{{Payload: []byte(`{"bytes":3}`)}}, time.Now()))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "{\"bytes\":1}\n{\"bytes\":2}\n{\"bytes\":3}\n", string(content))
+
+	// the file is reopened on every flush, so that it can be rotated
+	require.NoError(t, os.Rename(path, path+".1"))
+	require.NoError(t, sink.Send([]Record{{Payload: []byte(`{"bytes":4}`)}}, time.Now()))
+	content, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "{\"bytes\":4}\n", string(content))
+}
+
+func TestJSONLinesSink_fileError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "flows.jsonl")
+	sink, err := NewSink(config.ReexportConfig{Type: common.ReexportTypeJSONLines, Destination: path})
+	require.NoError(t, err)
+
+	err = sink.Send([]Record{{Payload: []byte(`{}`)}}, time.Now())
+	assert.ErrorContains(t, err, "unable to open "+path)
+}
+
+func TestJSONLinesSink_unixSocket(t *testing.T) {
+	// unix socket paths are limited in length, t.TempDir() may be too long
+	dir, err := os.MkdirTemp("", "reexport")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	socketPath := filepath.Join(dir, "flows.sock")
+
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	lines := make(chan string, 10)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+
+	sink, err := NewSink(config.ReexportConfig{Type: common.ReexportTypeJSONLines, Destination: "unix://" + socketPath})
+	require.NoError(t, err)
+	defer sink.Close()
+
+	require.NoError(t, sink.Send([]Record{{Payload: []byte(`{"bytes":1}`)}, {Payload: []byte(`{"bytes":2}`)}}, time.Now()))
+	for _, expected := range []string{`{"bytes":1}`, `{"bytes":2}`} {
+		select {
+		case line := <-lines:
+			assert.Equal(t, expected, line)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "timed out waiting for line", expected)
+		}
+	}
+}
+
+func TestJSONLinesSink_unixSocketError(t *testing.T) {
+	sink, err := NewSink(config.ReexportConfig{Type: common.ReexportTypeJSONLines, Destination: "unix:///nonexistent/flows.sock"})
+	require.NoError(t, err)
+
+	err = sink.Send([]Record{{Payload: []byte(`{}`)}}, time.Now())
+	assert.ErrorContains(t, err, "unable to connect to /nonexistent/flows.sock")
+}
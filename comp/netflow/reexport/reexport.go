@@ -0,0 +1,43 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+// Package reexport re-exports the enriched flows flushed by the aggregator to
+// third-party destinations: IPFIX collectors, or files and unix sockets as
+// JSON lines.
+package reexport
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/DataDog/datadog-agent/comp/netflow/common"
+	config "github.com/DataDog/datadog-agent/comp/netflow/config/def"
+)
+
+// Record is an aggregated flow along with its JSON payload, as sent to the event platform
+type Record struct {
+	Flow    *common.Flow
+	Payload []byte
+}
+
+// Sink is a destination the aggregated flows are re-exported to
+type Sink interface {
+	// Send re-exports the records flushed at flushTime
+	Send(records []Record, flushTime time.Time) error
+	// Close releases the resources held by the sink
+	Close() error
+}
+
+// NewSink returns the sink for a re-export destination
+func NewSink(conf config.ReexportConfig) (Sink, error) {
+	switch conf.Type {
+	case common.ReexportTypeIPFIX:
+		return newIPFIXSink(conf.Destination, conf.ObservationDomainID), nil
+	case common.ReexportTypeJSONLines:
+		return newJSONLinesSink(conf.Destination), nil
+	default:
+		return nil, fmt.Errorf("unknown reexport type `%s`", conf.Type)
+	}
+}
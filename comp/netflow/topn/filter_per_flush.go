@@ -7,6 +7,7 @@
 package topn
 
 import (
+	"cmp"
 	"slices"
 	"time"
 
@@ -16,9 +17,11 @@ import (
 )
 
 // NewPerFlushFilter will create a per flush filter for the given config. This filter will reduce "n" into "k" rows per flush period.
-func NewPerFlushFilter(n int64, flushConfig common.FlushConfig, sender sender.Sender, logger log.Component) *PerFlushFilter {
+// When groupBy is set, the flows of the groups with the most bytes are kept rather than the flows with the most bytes.
+func NewPerFlushFilter(n int64, groupBy GroupBy, flushConfig common.FlushConfig, sender sender.Sender, logger log.Component) *PerFlushFilter {
 	return &PerFlushFilter{
 		n:           n,
+		groupBy:     groupBy,
 		flushConfig: flushConfig,
 		throttler:   newThrottler(n, flushConfig, logger),
 		metrics:     sender,
@@ -30,6 +33,7 @@ func NewPerFlushFilter(n int64, flushConfig common.FlushConfig, sender sender.Se
 //	k * NumFlushes / CollectionPeriod = N
 type PerFlushFilter struct {
 	n           int64
+	groupBy     GroupBy
 	flushConfig common.FlushConfig
 	throttler   interface {
 		GetNumRowsToFlushFor(ctx common.FlushContext) int
@@ -77,7 +81,11 @@ func (p *PerFlushFilter) applyFilters(ctx common.FlushContext, flows []*common.F
 		}
 	}
 
-	slices.SortFunc(flows, reversed(compareByBytesAscending))
+	if p.groupBy != nil {
+		sortByGroupBytes(flows, p.groupBy)
+	} else {
+		slices.SortFunc(flows, reversed(compareByBytesAscending))
+	}
 
 	return filterResult{
 		toPublish: flows[:numFlowsToPublish],
@@ -89,3 +97,23 @@ type filterResult struct {
 	toPublish []*common.Flow
 	toDrop    []*common.Flow
 }
+
+// sortByGroupBytes sorts the flows by the bytes of their group, then by their own bytes, in descending order. Flows of
+// groups with the same bytes are sorted by group key so that groups aren't interleaved.
+func sortByGroupBytes(flows []*common.Flow, groupBy GroupBy) {
+	keys := make(map[*common.Flow]string, len(flows))
+	groupBytes := make(map[string]uint64)
+	for _, flow := range flows {
+		key := groupBy(flow)
+		keys[flow] = key
+		groupBytes[key] += flow.Bytes
+	}
+
+	slices.SortFunc(flows, func(a, b *common.Flow) int {
+		return cmp.Or(
+			cmp.Compare(groupBytes[keys[b]], groupBytes[keys[a]]),
+			cmp.Compare(keys[a], keys[b]),
+			cmp.Compare(b.Bytes, a.Bytes),
+		)
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/DataDog/datadog-agent/pkg/aggregator/mocksender"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPerFlushFilter(t *testing.T) {
@@ -90,7 +91,7 @@ func TestPerFlushFilter(t *testing.T) {
 			metrics.On("Count", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
 			metrics.On("Histogram", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
 
-			filter := NewPerFlushFilter(120, common.FlushConfig{
+			filter := NewPerFlushFilter(120, nil, common.FlushConfig{
 				// 60 buckets, make tests easy to set up + run. 2 per tick
 				FlowCollectionDuration: 1 * time.Hour,
 				FlushTickFrequency:     1 * time.Minute,
@@ -103,6 +104,52 @@ func TestPerFlushFilter(t *testing.T) {
 	}
 }
 
+func TestPerFlushFilterGroupBy(t *testing.T) {
+	metrics := mocksender.NewMockSender(t, "")
+	metrics.On("Gauge", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	metrics.On("Count", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	metrics.On("Histogram", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+	groupBy, err := ParseGroupBy("destination.as_number")
+	require.NoError(t, err)
+
+	filter := NewPerFlushFilter(120, groupBy, common.FlushConfig{
+		FlowCollectionDuration: 1 * time.Hour,
+		FlushTickFrequency:     1 * time.Minute,
+	}, metrics, logmock.New(t))
+
+	flowToAS := func(asNumber uint32, bytes uint64) *common.Flow {
+		return &common.Flow{Bytes: bytes, DstGeo: common.GeoInfo{ASNumber: asNumber}}
+	}
+	flushTime := time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC)
+	outputs := filter.Filter(common.FlushContext{
+		FlushTime:     flushTime,
+		LastFlushedAt: flushTime.Add(-time.Minute),
+		NumFlushes:    1,
+	}, []*common.Flow{
+		flowToAS(100, 6),
+		flowToAS(200, 3),
+		flowToAS(300, 1),
+		flowToAS(200, 4),
+	})
+
+	// the largest flow is dropped, its autonomous system has less traffic
+	assert.Equal(t, []*common.Flow{flowToAS(200, 4), flowToAS(200, 3)}, outputs)
+}
+
+func TestParseGroupBy(t *testing.T) {
+	groupBy, err := ParseGroupBy("")
+	assert.NoError(t, err)
+	assert.Nil(t, groupBy)
+
+	groupBy, err = ParseGroupBy("source.country")
+	require.NoError(t, err)
+	assert.Equal(t, "FR", groupBy(&common.Flow{SrcGeo: common.GeoInfo{CountryISOCode: "FR"}}))
+
+	_, err = ParseGroupBy("source.city")
+	assert.ErrorContains(t, err, `invalid group by key "source.city"`)
+}
+
 func sampleFlows(numBytes ...uint64) []*common.Flow {
 	flows := make([]*common.Flow, len(numBytes))
 	for idx, bytes := range numBytes {
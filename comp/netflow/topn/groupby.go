@@ -0,0 +1,42 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+// Package topn defines business logic for filtering NetFlow records to the Top "N" occurrences.
+package topn
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/DataDog/datadog-agent/comp/netflow/common"
+)
+
+// GroupBy returns the key of the group a flow belongs to. When flows are grouped, the top flows are the flows of
+// the groups with the most bytes, for instance the flows to the top destination autonomous systems.
+type GroupBy func(flow *common.Flow) string
+
+var groupByKeys = map[string]GroupBy{
+	"exporter.ip":           func(flow *common.Flow) string { return string(flow.ExporterAddr) },
+	"source.ip":             func(flow *common.Flow) string { return string(flow.SrcAddr) },
+	"source.country":        func(flow *common.Flow) string { return flow.SrcGeo.CountryISOCode },
+	"source.as_number":      func(flow *common.Flow) string { return strconv.FormatUint(uint64(flow.SrcGeo.ASNumber), 10) },
+	"destination.ip":        func(flow *common.Flow) string { return string(flow.DstAddr) },
+	"destination.country":   func(flow *common.Flow) string { return flow.DstGeo.CountryISOCode },
+	"destination.as_number": func(flow *common.Flow) string { return strconv.FormatUint(uint64(flow.DstGeo.ASNumber), 10) },
+}
+
+// ParseGroupBy returns the GroupBy for the given key, or nil when the key is empty and flows aren't grouped
+func ParseGroupBy(key string) (GroupBy, error) {
+	if key == "" {
+		return nil, nil
+	}
+	groupBy, ok := groupByKeys[key]
+	if !ok {
+		return nil, fmt.Errorf("invalid group by key %q, expected one of %v", key, slices.Sorted(maps.Keys(groupByKeys)))
+	}
+	return groupBy, nil
+}
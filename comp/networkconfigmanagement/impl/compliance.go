@@ -0,0 +1,86 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package networkconfigmanagementimpl
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/compliance"
+	ncmreport "github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/report"
+	ncmsender "github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/sender"
+	"github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/types"
+)
+
+// deviceComplianceStatus is the result of the last compliance evaluation of a
+// device, displayed in the agent status.
+type deviceComplianceStatus struct {
+	EvaluatedAt time.Time
+	Results     []compliance.RuleResult
+	// Drift is nil if drift detection is disabled, or if the running and
+	// startup configs weren't both fetched.
+	Drift *compliance.Drift
+}
+
+// evaluateCompliance evaluates the compliance rules of the device on the
+// configs fetched by the check, compares the running and startup configs, and
+// reports the results: metrics on every check, and events when a violation
+// appears or is resolved. The configs are redacted, so rules can't match
+// secrets and changes of secrets are not reported as drift.
+func (n *networkDeviceConfigImpl) evaluateCompliance(ctx context.Context, dc *DeviceContext, sender *ncmsender.NCMSender, configs []ncmreport.NetworkDeviceConfig) {
+	log := LoggerFromContext(ctx)
+	policy, err := dc.CompliancePolicy()
+	if err != nil {
+		log.Warnf("invalid compliance rules, they will not be evaluated: %v", err)
+	}
+
+	var results []compliance.RuleResult
+	contents := make(map[types.ConfigType]string, len(configs))
+	for _, conf := range configs {
+		contents[conf.ConfigType] = conf.Content
+		results = append(results, policy.Evaluate(conf.Content, conf.ConfigType)...)
+	}
+	var drift *compliance.Drift
+	running, hasRunning := contents[types.RUNNING]
+	startup, hasStartup := contents[types.STARTUP]
+	if dc.device.Compliance.DriftDetectionEnabled() && hasRunning && hasStartup {
+		d := compliance.CompareConfigs(running, startup)
+		drift = &d
+	}
+	if len(results) == 0 && drift == nil {
+		return
+	}
+
+	sender.SendComplianceMetrics(results)
+	if drift != nil {
+		sender.SendDriftMetrics(*drift)
+	}
+	for _, transition := range dc.compliance.Update(results, drift) {
+		log.Debugf("compliance change: rule=%q config_type=%s violated=%t", transition.Rule, transition.ConfigType, transition.Violated)
+		sender.SendComplianceEvent(dc.device.DeviceID(), transition)
+	}
+	n.setComplianceStatus(dc.device.DeviceID(), deviceComplianceStatus{
+		EvaluatedAt: n.clock.Now(),
+		Results:     results,
+		Drift:       drift,
+	})
+}
+
+func (n *networkDeviceConfigImpl) setComplianceStatus(deviceID string, status deviceComplianceStatus) {
+	n.complianceLock.Lock()
+	defer n.complianceLock.Unlock()
+	if n.complianceStatus == nil {
+		n.complianceStatus = make(map[string]deviceComplianceStatus)
+	}
+	n.complianceStatus[deviceID] = status
+}
+
+func (n *networkDeviceConfigImpl) getComplianceStatus() map[string]deviceComplianceStatus {
+	n.complianceLock.Lock()
+	defer n.complianceLock.Unlock()
+	return maps.Clone(n.complianceStatus)
+}
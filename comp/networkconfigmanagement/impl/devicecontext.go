@@ -11,8 +11,10 @@ import (
 	"time"
 
 	log "github.com/DataDog/datadog-agent/comp/core/log/def"
+	"github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/compliance"
 	ncmconfig "github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/config"
 	ncmprofile "github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/profile"
+	ncmtypes "github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/types"
 )
 
 // DeviceContext is a wrapper around some information about a device. It also
@@ -32,6 +34,13 @@ type DeviceContext struct {
 	// every known profile and found no matches. This way we don't try again on
 	// every check - we just report the error again.
 	noMatchingProfile bool
+	// policy holds the compiled compliance rules of the device; it is compiled
+	// on the first check after the device is registered or updated.
+	policy         *compliance.Policy
+	policyCompiled bool
+	// compliance tracks the compliance of the device between checks, so that
+	// events are only sent when a violation appears or is resolved.
+	compliance compliance.Tracker
 }
 
 func NewDeviceContext(device *ncmconfig.DeviceInstance, profile *ncmprofile.NCMProfile) *DeviceContext {
@@ -50,6 +59,23 @@ func (dc *DeviceContext) SetDevice(device *ncmconfig.DeviceInstance, profile *nc
 	// clear noMatchingProfile - if profile is nil, then the next time this
 	// device is checked we'll test every available profile.
 	dc.noMatchingProfile = false
+	// the compliance rules may have changed, so compile them again on the next
+	// check.
+	dc.policy = nil
+	dc.policyCompiled = false
+}
+
+// CompliancePolicy returns the compiled compliance rules of the device.
+func (dc *DeviceContext) CompliancePolicy() (*compliance.Policy, error) {
+	if !dc.policyCompiled {
+		policy, err := dc.device.Compliance.Policy()
+		if err != nil {
+			return nil, err
+		}
+		dc.policy = policy
+		dc.policyCompiled = true
+	}
+	return dc.policy, nil
 }
 
 // Lock blocks until this device is available and then locks it.
@@ -89,13 +115,18 @@ func (dc *DeviceContext) UnlockOrLog(log log.Component) {
 
 // GetTags returns standard tags for this device. The profile tag is omitted
 // if no profile has been resolved yet (e.g. a connection failure happened
-// before profile matching could occur).
+// before profile matching could occur); the config source is then cli, as
+// only CLI profiles are matched automatically.
 func (dc *DeviceContext) GetTags() []string {
+	configSource := ncmtypes.CLI
+	if dc.profile != nil {
+		configSource = dc.profile.ConfigSource()
+	}
 	tags := []string{
 		"device_namespace:" + dc.device.Namespace,
 		"device_ip:" + dc.device.IPAddress,
 		"device_id:" + dc.device.DeviceID(),
-		"config_source:cli",
+		"config_source:" + string(configSource),
 	}
 	if dc.profile != nil {
 		tags = append(tags, "profile:"+string(dc.profile.Name))
@@ -49,6 +49,7 @@ func retrieveAndStoreConfig(ctx context.Context, dc *DeviceContext, conn ncmremo
 		}
 	}
 	conf := ncmreport.ToNetworkDeviceConfig(deviceID, dc.device.IPAddress, confType, string(dc.profile.Name), result.Metadata, dc.GetTags(), result.Redacted, configID, configHash)
+	conf.ConfigSource = dc.profile.ConfigSource()
 	return &conf, stored, nil
 }
 
@@ -60,6 +60,9 @@ type networkDeviceConfigImpl struct {
 	profiles              ncmprofile.Map
 
 	connect func(*ncmconfig.DeviceInstance) (ncmremote.Connection, error)
+
+	complianceStatus map[string]deviceComplianceStatus
+	complianceLock   sync.Mutex
 }
 
 // RegisterDevice tells the component how to connect to a device.
@@ -141,6 +144,7 @@ func (n *networkDeviceConfigImpl) reportConfig(ctx context.Context, dc *DeviceCo
 
 	configs, localStoreChanged, confErrs := retrieveAndStoreBothConfigs(ctx, dc, conn, n.store)
 	nonBlockingErrors = append(nonBlockingErrors, confErrs...)
+	n.evaluateCompliance(ctx, dc, sender, configs)
 
 	var inventoryEntries []ncmreport.InventoryEntry
 	timeSinceInventory := startTime.Sub(n.getLastInventoryTime())
@@ -234,7 +238,8 @@ func (n *networkDeviceConfigImpl) findMatchingProfile(ctx context.Context, conn
 	logger := LoggerFromContext(ctx)
 	logger.Debugf("Testing %d profiles", len(n.profiles))
 	for profName, prof := range n.profiles {
-		if prof.Commands.Verify == nil {
+		// only CLI profiles can be verified over the SSH connection
+		if prof.GetTransport() != ncmprofile.TransportCLI || prof.Commands.Verify == nil {
 			continue
 		}
 		logger.Debugf("testing profile %s", profName)
@@ -27,6 +27,8 @@ import (
 	logmock "github.com/DataDog/datadog-agent/comp/core/log/mock"
 	eventplatform "github.com/DataDog/datadog-agent/comp/forwarder/eventplatform/def"
 	"github.com/DataDog/datadog-agent/pkg/aggregator/mocksender"
+	"github.com/DataDog/datadog-agent/pkg/metrics/event"
+	"github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/compliance"
 	ncmconfig "github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/config"
 	"github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/profile"
 	ncmremote "github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/remote"
@@ -325,9 +327,118 @@ func TestCheck_Run_Success(t *testing.T) {
 	mockSender.AssertEventPlatformEvent(t, expectedDeviceMetadata, eventplatform.EventTypeNetworkDevicesMetadata)
 	mockSender.AssertMetricTaggedWith(t, "Gauge", "datadog.ncm.check_duration", expectedTags)
 	mockSender.AssertMetric(t, "Count", "datadog.ncm.inventory.entries_sent", 2, "test-agent-host", []string{"agent_host:test-agent-host"})
+	// drift detection is disabled by default
+	mockSender.AssertNotCalled(t, "Gauge", "ncm.config.drift", mock.Anything, mock.Anything, mock.Anything)
+	mockSender.AssertNotCalled(t, "Event", mock.Anything)
 	mockSender.AssertExpectations(t)
 }
 
+func TestCheck_Run_Drift(t *testing.T) {
+	comp, reqs := createTestComponent(t)
+	device := createTestDevice()
+	detectDrift := true
+	device.Compliance = &ncmconfig.ComplianceConfig{DetectDrift: &detectDrift}
+	err := comp.RegisterDevice(device)
+	assert.NoError(t, err)
+
+	// the header of the running config isn't a drift, but the shutdown line moving to another interface is
+	reqs.connFactory.conn.OutputMap["show running-config"] = ok(`Building configuration...
+! Last configuration change at 10:20:00 UTC Fri Aug 1 2025
+interface GigabitEthernet0/1
+ ip address 192.168.1.1 255.255.255.0
+interface GigabitEthernet0/2
+ shutdown`)
+	reqs.connFactory.conn.OutputMap["show startup-config"] = ok(`interface GigabitEthernet0/1
+ ip address 192.168.1.1 255.255.255.0
+ shutdown
+interface GigabitEthernet0/2`)
+
+	mockSender := reqs.sender
+	mockSender.On("EventPlatformEvent", mock.Anything, mock.Anything).Return()
+	mockSender.On("Gauge", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	mockSender.On("Count", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	mockSender.On("Event", mock.Anything).Return()
+	mockSender.On("Commit").Return()
+
+	err = comp.ReportConfig(t.Context(), device.DeviceID(), reqs.sender)
+	assert.NoError(t, err)
+
+	expectedTags := []string{
+		"device_namespace:default",
+		"device_ip:10.0.0.1",
+		"device_id:default:10.0.0.1",
+		"config_source:cli",
+		"profile:p2",
+	}
+	mockSender.AssertMetric(t, "Gauge", "ncm.config.drift", 1, "test-agent-host", expectedTags)
+	mockSender.AssertMetric(t, "Gauge", "ncm.config.drift.lines", 1, "test-agent-host", append(expectedTags, "change:added"))
+	mockSender.AssertMetric(t, "Gauge", "ncm.config.drift.lines", 1, "test-agent-host", append(expectedTags, "change:removed"))
+	mockSender.AssertNumberOfCalls(t, "Event", 1)
+
+	deviceStatus := comp.getComplianceStatus()[device.DeviceID()]
+	assert.Equal(t, &compliance.Drift{
+		Added:   []string{"interface GigabitEthernet0/2: shutdown"},
+		Removed: []string{"interface GigabitEthernet0/1: shutdown"},
+	}, deviceStatus.Drift)
+}
+
+func TestCheck_Run_Compliance(t *testing.T) {
+	comp, reqs := createTestComponent(t)
+	device := createTestDevice()
+	detectDrift := false
+	device.Compliance = &ncmconfig.ComplianceConfig{
+		DetectDrift: &detectDrift,
+		Rules: []compliance.Rule{
+			{
+				Name:          "interface-shutdown",
+				Severity:      compliance.SeverityCritical,
+				Section:       "^interface ",
+				RequiredLines: []string{"shutdown"},
+			},
+			{
+				Name:              "no-telnet",
+				ConfigTypes:       []types.ConfigType{types.RUNNING, types.STARTUP},
+				ForbiddenPatterns: []string{"telnet"},
+			},
+		},
+	}
+	err := comp.RegisterDevice(device)
+	assert.NoError(t, err)
+
+	mockSender := reqs.sender
+	mockSender.On("EventPlatformEvent", mock.Anything, mock.Anything).Return()
+	mockSender.On("Gauge", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	mockSender.On("Count", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	mockSender.On("Event", mock.Anything).Return()
+	mockSender.On("Commit").Return()
+
+	// the event is only sent when the violation appears
+	for range 2 {
+		err = comp.ReportConfig(t.Context(), device.DeviceID(), reqs.sender)
+		assert.NoError(t, err)
+	}
+
+	mockSender.AssertMetric(t, "Gauge", "ncm.compliance.violations", 1, "test-agent-host", []string{"device_id:default:10.0.0.1", "rule:interface-shutdown", "severity:critical", "config_type:running"})
+	mockSender.AssertMetric(t, "Gauge", "ncm.compliance.violations", 0, "test-agent-host", []string{"device_id:default:10.0.0.1", "rule:no-telnet", "severity:warning", "config_type:startup"})
+	mockSender.AssertNotCalled(t, "Gauge", "ncm.config.drift", mock.Anything, mock.Anything, mock.Anything)
+	mockSender.AssertNumberOfCalls(t, "Event", 1)
+	mockSender.AssertEventWithCompareFunc(t, event.Event{
+		Priority:       event.PriorityNormal,
+		Host:           "test-agent-host",
+		Tags:           []string{"device_id:default:10.0.0.1", "rule:interface-shutdown"},
+		AggregationKey: "default:10.0.0.1",
+		SourceTypeName: "network_config_management",
+		EventType:      "ncm_compliance",
+	}, time.Hour, func(_, actual event.Event) bool {
+		return actual.AlertType == event.AlertTypeError &&
+			actual.Title == "Compliance rule interface-shutdown violated by the running config of default:10.0.0.1"
+	})
+
+	deviceStatus := comp.getComplianceStatus()[device.DeviceID()]
+	assert.Len(t, deviceStatus.Results, 3)
+	assert.Nil(t, deviceStatus.Drift)
+}
+
 // hashConfigForTest mirrors the SHA-256 hashing done by the config store, so tests
 // can predict the ConfigHash field of stored configs without depending on the store package.
 func hashConfigForTest(raw string) string {
@@ -482,6 +593,7 @@ func TestCheck_Run_ProfileMatch(t *testing.T) {
 	mockSender.On("EventPlatformEvent", mock.Anything, mock.Anything).Return().Times(2)
 	mockSender.On("Gauge", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
 	mockSender.On("Count", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	mockSender.On("Event", mock.Anything).Return()
 	mockSender.On("Commit").Return()
 
 	err = comp.ReportConfig(t.Context(), device.DeviceID(), reqs.sender)
@@ -18,6 +18,7 @@ import (
 	"github.com/DataDog/datadog-agent/comp/core/config"
 	"github.com/DataDog/datadog-agent/comp/core/hostname"
 	log "github.com/DataDog/datadog-agent/comp/core/log/def"
+	"github.com/DataDog/datadog-agent/comp/core/status"
 	compdef "github.com/DataDog/datadog-agent/comp/def"
 	networkconfigmanagement "github.com/DataDog/datadog-agent/comp/networkconfigmanagement/def"
 	"github.com/DataDog/datadog-agent/comp/networkconfigmanagement/stub"
@@ -39,14 +40,16 @@ type Requires struct {
 // NewComponent creates a new networkconfigmanagement component
 func NewComponent(reqs Requires) (Provides, error) {
 	var comp networkconfigmanagement.Component
+	var statusProvider status.Provider
 	compImpl, err := newComponent(reqs)
 	if err != nil {
 		reqs.Logger.Errorf("NCM service could not be initialized: %s", err)
 		comp = stub.NewStub("network config management could not be initialized")
 	} else {
 		comp = compImpl
+		statusProvider = newStatusProvider(compImpl)
 	}
-	return NewProvides(comp), nil
+	return NewProvides(comp, statusProvider), nil
 
 }
 
@@ -85,7 +88,7 @@ func newComponent(reqs Requires) (*networkDeviceConfigImpl, error) {
 		sender,
 		hostname,
 		profiles,
-		ncmremote.ConnectOverSSH,
+		ncmremote.ConnectToDevice,
 		clock.New(),
 	)
 	return impl, nil
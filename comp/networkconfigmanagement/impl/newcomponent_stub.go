@@ -23,5 +23,5 @@ type Requires struct {
 // NewComponent creates a new networkconfigmanagement component
 func NewComponent(reqs Requires) (Provides, error) {
 	reqs.Logger.Debugf("NCM is disabled in this build")
-	return NewProvides(stub.NewStub("network config management is disabled")), nil
+	return NewProvides(stub.NewStub("network config management is disabled"), nil), nil
 }
@@ -0,0 +1,124 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package networkconfigmanagementimpl
+
+import (
+	"embed"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/DataDog/datadog-agent/comp/core/status"
+)
+
+//go:embed status_templates
+var templatesFS embed.FS
+
+// complianceDeviceStatus is the compliance status of a device, as displayed in
+// the agent status.
+type complianceDeviceStatus struct {
+	DeviceID       string
+	EvaluatedAt    string
+	Rules          int
+	ViolatedRules  int
+	Violations     []complianceViolationStatus
+	DriftDetected  bool
+	DriftAdded     int
+	DriftRemoved   int
+	DriftEvaluated bool
+}
+
+type complianceViolationStatus struct {
+	Rule       string
+	Severity   string
+	ConfigType string
+	Findings   []string
+}
+
+// statusProvider provides the compliance status of the devices to the agent
+// status.
+type statusProvider struct {
+	ncm *networkDeviceConfigImpl
+}
+
+func newStatusProvider(ncm *networkDeviceConfigImpl) statusProvider {
+	return statusProvider{ncm: ncm}
+}
+
+// Name returns the name
+func (statusProvider) Name() string {
+	return "Network Config Management"
+}
+
+// Section returns the section
+func (statusProvider) Section() string {
+	return "Network Config Management"
+}
+
+// JSON populates the status map
+func (p statusProvider) JSON(_ bool, stats map[string]interface{}) error {
+	p.getStatus(stats)
+	return nil
+}
+
+// Text renders the text output
+func (p statusProvider) Text(_ bool, buffer io.Writer) error {
+	return status.RenderText(templatesFS, "networkconfigmanagement.tmpl", buffer, p.populateStatus())
+}
+
+// HTML renders the html output
+func (p statusProvider) HTML(_ bool, buffer io.Writer) error {
+	return status.RenderHTML(templatesFS, "networkconfigmanagementHTML.tmpl", buffer, p.populateStatus())
+}
+
+func (p statusProvider) getStatus(stats map[string]interface{}) {
+	devices := []complianceDeviceStatus{}
+	for deviceID, deviceStatus := range p.ncm.getComplianceStatus() {
+		device := complianceDeviceStatus{
+			DeviceID:    deviceID,
+			EvaluatedAt: deviceStatus.EvaluatedAt.UTC().Format("2006-01-02 15:04:05 UTC"),
+			Rules:       len(deviceStatus.Results),
+		}
+		for _, result := range deviceStatus.Results {
+			if result.Compliant() {
+				continue
+			}
+			device.ViolatedRules++
+			violation := complianceViolationStatus{
+				Rule:       result.Rule,
+				Severity:   string(result.Severity),
+				ConfigType: string(result.ConfigType),
+			}
+			for _, finding := range result.Findings {
+				if finding.Section != "" {
+					violation.Findings = append(violation.Findings, finding.Section+": "+finding.Message)
+				} else {
+					violation.Findings = append(violation.Findings, finding.Message)
+				}
+			}
+			device.Violations = append(device.Violations, violation)
+		}
+		if drift := deviceStatus.Drift; drift != nil {
+			device.DriftEvaluated = true
+			device.DriftDetected = drift.HasDrift()
+			device.DriftAdded = len(drift.Added)
+			device.DriftRemoved = len(drift.Removed)
+		}
+		devices = append(devices, device)
+	}
+	slices.SortFunc(devices, func(a, b complianceDeviceStatus) int {
+		return strings.Compare(a.DeviceID, b.DeviceID)
+	})
+	stats["networkConfigManagementStats"] = map[string]interface{}{
+		"Devices": devices,
+	}
+}
+
+func (p statusProvider) populateStatus() map[string]interface{} {
+	stats := make(map[string]interface{})
+	p.getStatus(stats)
+	return stats
+}
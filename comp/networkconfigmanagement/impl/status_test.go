@@ -0,0 +1,79 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package networkconfigmanagementimpl
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/compliance"
+	"github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/types"
+)
+
+func TestStatusProvider(t *testing.T) {
+	ncm := &networkDeviceConfigImpl{}
+	provider := newStatusProvider(ncm)
+
+	stats := map[string]interface{}{}
+	require.NoError(t, provider.JSON(false, stats))
+	assert.Equal(t, map[string]interface{}{"Devices": []complianceDeviceStatus{}}, stats["networkConfigManagementStats"])
+
+	evaluatedAt := time.Date(2025, 8, 1, 10, 20, 0, 0, time.UTC)
+	ncm.setComplianceStatus("default:10.0.0.2", deviceComplianceStatus{
+		EvaluatedAt: evaluatedAt,
+		Results: []compliance.RuleResult{
+			{Rule: "password-encryption", Severity: compliance.SeverityWarning, ConfigType: types.RUNNING},
+		},
+	})
+	ncm.setComplianceStatus("default:10.0.0.1", deviceComplianceStatus{
+		EvaluatedAt: evaluatedAt,
+		Results: []compliance.RuleResult{
+			{Rule: "password-encryption", Severity: compliance.SeverityWarning, ConfigType: types.RUNNING},
+			{Rule: "no-telnet", Severity: compliance.SeverityCritical, ConfigType: types.RUNNING, Findings: []compliance.Finding{
+				{Section: "line vty 0 4", Message: `line "transport input telnet" matches forbidden pattern "telnet"`},
+			}},
+		},
+		Drift: &compliance.Drift{Added: []string{"logging host 10.0.0.5"}},
+	})
+
+	stats = map[string]interface{}{}
+	require.NoError(t, provider.JSON(false, stats))
+	assert.Equal(t, map[string]interface{}{"Devices": []complianceDeviceStatus{
+		{
+			DeviceID:      "default:10.0.0.1",
+			EvaluatedAt:   "2025-08-01 10:20:00 UTC",
+			Rules:         2,
+			ViolatedRules: 1,
+			Violations: []complianceViolationStatus{
+				{Rule: "no-telnet", Severity: "critical", ConfigType: "running", Findings: []string{
+					`line vty 0 4: line "transport input telnet" matches forbidden pattern "telnet"`,
+				}},
+			},
+			DriftEvaluated: true,
+			DriftDetected:  true,
+			DriftAdded:     1,
+		},
+		{
+			DeviceID:    "default:10.0.0.2",
+			EvaluatedAt: "2025-08-01 10:20:00 UTC",
+			Rules:       1,
+		},
+	}}, stats["networkConfigManagementStats"])
+
+	var text bytes.Buffer
+	require.NoError(t, provider.Text(false, &text))
+	assert.Contains(t, text.String(), "Compliance rules: 2 evaluated, 1 violated")
+	assert.Contains(t, text.String(), "- no-telnet (critical, running config)")
+	assert.Contains(t, text.String(), "Startup config drift: 1 lines added, 0 lines removed")
+
+	var html bytes.Buffer
+	require.NoError(t, provider.HTML(false, &html))
+	assert.Contains(t, html.String(), "default:10.0.0.2")
+}
@@ -8,6 +8,7 @@ package networkconfigmanagementimpl
 
 import (
 	api "github.com/DataDog/datadog-agent/comp/api/api/def"
+	"github.com/DataDog/datadog-agent/comp/core/status"
 	compdef "github.com/DataDog/datadog-agent/comp/def"
 	networkconfigmanagement "github.com/DataDog/datadog-agent/comp/networkconfigmanagement/def"
 	"github.com/DataDog/datadog-agent/pkg/util/option"
@@ -23,11 +24,14 @@ type Provides struct {
 	Comp              option.Option[networkconfigmanagement.Component]
 	GetConfigEndpoint api.EndpointProvider `group:"agent_endpoint"`
 	RollbackEndpoint  api.EndpointProvider `group:"agent_endpoint"`
+	Status            status.InformationProvider
 }
 
-// NewProvides populates a Provides from a component
-func NewProvides(comp networkconfigmanagement.Component) Provides {
+// NewProvides populates a Provides from a component; statusProvider can be nil
+// when the component is not available.
+func NewProvides(comp networkconfigmanagement.Component, statusProvider status.Provider) Provides {
 	return Provides{
+		Status:            status.NewInformationProvider(statusProvider),
 		Comp:              option.New(comp),
 		GetConfigEndpoint: api.NewAgentEndpointProvider(comp.GetConfigEndpointHandler(), "/ncm/config", "GET").Provider,
 		RollbackEndpoint:  api.NewAgentEndpointProvider(comp.RollbackEndpointHandler(), "/ncm/rollback", "POST").Provider,
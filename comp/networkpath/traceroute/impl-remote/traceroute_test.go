@@ -327,6 +327,7 @@ func TestGetTracerouteURL(t *testing.T) {
 		timeout                         time.Duration
 		tracerouteQueries               int
 		e2eQueries                      int
+		multipathFlows                  int
 		expectedParams                  map[string]string
 	}{
 		{
@@ -344,6 +345,7 @@ func TestGetTracerouteURL(t *testing.T) {
 			timeout:                         5 * time.Second,
 			tracerouteQueries:               3,
 			e2eQueries:                      50,
+			multipathFlows:                  4,
 			expectedParams: map[string]string{
 				"client_id":                           "test-client",
 				"port":                                "80",
@@ -356,6 +358,7 @@ func TestGetTracerouteURL(t *testing.T) {
 				"disable_source_public_ip_collection": "false",
 				"traceroute_queries":                  "3",
 				"e2e_queries":                         "50",
+				"multipath_flows":                     "4",
 				"disable_windows_driver":              "false",
 			},
 		},
@@ -388,6 +391,7 @@ func TestGetTracerouteURL(t *testing.T) {
 				tt.timeout,
 				tt.tracerouteQueries,
 				tt.e2eQueries,
+				tt.multipathFlows,
 			)
 
 			require.NoError(t, err)
@@ -0,0 +1,27 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+// Package sbomstore provides the component holding the SBOMs and the vulnerabilities produced by the sbom check, which
+// are served by the agent API.
+package sbomstore
+
+import (
+	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+	"github.com/DataDog/datadog-agent/pkg/sbom/osv"
+)
+
+// team: container-integrations
+
+// Component is the component type.
+type Component interface {
+	// SetHostSBOM stores the latest SBOM of the host, exported by the `/sbom/export` endpoint. The SBOMs of the
+	// container images are read from workloadmeta.
+	SetHostSBOM(hostname string, sbom *workloadmeta.SBOM)
+	// SetVulnerabilityReport stores the vulnerabilities found in the latest SBOM of a target, served by the
+	// `/sbom/vulnerabilities` endpoint
+	SetVulnerabilityReport(report osv.Report)
+	// DeleteVulnerabilityReport removes the vulnerabilities of a target
+	DeleteVulnerabilityReport(targetType, targetID string)
+}
@@ -0,0 +1,58 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+// Package sbomstoreimpl implements the sbomstore component
+package sbomstoreimpl
+
+import (
+	api "github.com/DataDog/datadog-agent/comp/api/api/def"
+	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+	sbomstore "github.com/DataDog/datadog-agent/comp/sbomstore/def"
+	"github.com/DataDog/datadog-agent/pkg/sbom/export"
+	"github.com/DataDog/datadog-agent/pkg/sbom/osv"
+)
+
+// Requires defines the dependencies of the sbomstore component
+type Requires struct {
+	WMeta workloadmeta.Component
+}
+
+// Provides defines the output of the sbomstore component
+type Provides struct {
+	Comp                    sbomstore.Component
+	ExportEndpoint          api.AgentEndpointProvider
+	VulnerabilitiesEndpoint api.AgentEndpointProvider
+}
+
+type sbomStore struct {
+	export          *export.Store
+	vulnerabilities *osv.Store
+}
+
+// NewComponent creates a new sbomstore component
+func NewComponent(reqs Requires) Provides {
+	s := &sbomStore{
+		export:          export.NewStore(reqs.WMeta),
+		vulnerabilities: osv.NewStore(),
+	}
+
+	return Provides{
+		Comp:                    s,
+		ExportEndpoint:          api.NewAgentEndpointProvider(s.export.HandleExport, "/sbom/export", "GET"),
+		VulnerabilitiesEndpoint: api.NewAgentEndpointProvider(s.vulnerabilities.HandleGetFindings, "/sbom/vulnerabilities", "GET"),
+	}
+}
+
+func (s *sbomStore) SetHostSBOM(hostname string, sbom *workloadmeta.SBOM) {
+	s.export.SetHostSBOM(hostname, sbom)
+}
+
+func (s *sbomStore) SetVulnerabilityReport(report osv.Report) {
+	s.vulnerabilities.Set(report)
+}
+
+func (s *sbomStore) DeleteVulnerabilityReport(targetType, targetID string) {
+	s.vulnerabilities.Delete(targetType, targetID)
+}
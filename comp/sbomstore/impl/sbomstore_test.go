@@ -0,0 +1,52 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package sbomstoreimpl
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/DataDog/datadog-agent/comp/api/api/def"
+	"github.com/DataDog/datadog-agent/pkg/sbom/osv"
+)
+
+func get(t *testing.T, endpoint api.AgentEndpointProvider, target string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	endpoint.Provider.HandlerFunc()(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	return rec
+}
+
+func TestVulnerabilitiesEndpoint(t *testing.T) {
+	provides := NewComponent(Requires{})
+	assert.Equal(t, "/sbom/vulnerabilities", provides.VulnerabilitiesEndpoint.Provider.Route())
+
+	provides.Comp.SetVulnerabilityReport(osv.Report{TargetType: osv.TargetTypeHost, TargetID: "my-host"})
+	provides.Comp.SetVulnerabilityReport(osv.Report{TargetType: osv.TargetTypeContainerImage, TargetID: "sha256:1234"})
+	provides.Comp.DeleteVulnerabilityReport(osv.TargetTypeContainerImage, "sha256:1234")
+
+	rec := get(t, provides.VulnerabilitiesEndpoint, "/sbom/vulnerabilities")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var reports []osv.Report
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &reports))
+	require.Len(t, reports, 1)
+	assert.Equal(t, "my-host", reports[0].TargetID)
+}
+
+func TestExportEndpoint(t *testing.T) {
+	provides := NewComponent(Requires{})
+	assert.Equal(t, "/sbom/export", provides.ExportEndpoint.Provider.Route())
+
+	assert.Equal(t, http.StatusNotFound, get(t, provides.ExportEndpoint, "/sbom/export?target=host").Code)
+	assert.Equal(t, http.StatusNotFound, get(t, provides.ExportEndpoint, "/sbom/export?target=image&id=sha256:1234").Code)
+}
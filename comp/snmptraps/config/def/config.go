@@ -26,6 +26,8 @@ const (
 	defaultPort        = uint16(9162) // Standard UDP port for traps.
 	defaultStopTimeout = 5
 	packetsChanSize    = 100
+
+	defaultRateLimitInterval = 60
 )
 
 // UserV3 contains the definition of one SNMPv3 user with its username and its auth
@@ -42,15 +44,32 @@ type UserV3 struct {
 // TrapsConfig contains configuration for SNMP trap listeners.
 // YAML field tags provided for test marshalling purposes.
 type TrapsConfig struct {
-	Enabled               bool     `mapstructure:"enabled" yaml:"enabled"`
-	Port                  uint16   `mapstructure:"port" yaml:"port"`
-	Users                 []UserV3 `mapstructure:"users" yaml:"users"`
-	CommunityStrings      []string `mapstructure:"community_strings" yaml:"community_strings"`
-	BindHost              string   `mapstructure:"bind_host" yaml:"bind_host"`
-	StopTimeout           int      `mapstructure:"stop_timeout" yaml:"stop_timeout"`
-	Namespace             string   `mapstructure:"namespace" yaml:"namespace"`
-	Tags                  []string `mapstructure:"tags" yaml:"tags"`
-	authoritativeEngineID string   `mapstructure:"-" yaml:"-"`
+	Enabled               bool              `mapstructure:"enabled" yaml:"enabled"`
+	Port                  uint16            `mapstructure:"port" yaml:"port"`
+	Users                 []UserV3          `mapstructure:"users" yaml:"users"`
+	CommunityStrings      []string          `mapstructure:"community_strings" yaml:"community_strings"`
+	BindHost              string            `mapstructure:"bind_host" yaml:"bind_host"`
+	StopTimeout           int               `mapstructure:"stop_timeout" yaml:"stop_timeout"`
+	Namespace             string            `mapstructure:"namespace" yaml:"namespace"`
+	Tags                  []string          `mapstructure:"tags" yaml:"tags"`
+	Correlation           CorrelationConfig `mapstructure:"correlation" yaml:"correlation"`
+	authoritativeEngineID string            `mapstructure:"-" yaml:"-"`
+}
+
+// CorrelationConfig contains the configuration of the deduplication,
+// correlation and rate limiting of traps before they are forwarded. Each
+// feature is disabled when its window or limit is zero.
+type CorrelationConfig struct {
+	// DedupWindow is the number of seconds during which traps with the same
+	// OID and variables as a forwarded trap are dropped and counted.
+	DedupWindow int `mapstructure:"dedup_window" yaml:"dedup_window"`
+	// FlapWindow is the number of seconds during which linkDown and linkUp
+	// traps of an interface are paired and reported as a single flap.
+	FlapWindow int `mapstructure:"flap_window" yaml:"flap_window"`
+	// RateLimit is the maximum number of traps forwarded per device during
+	// RateLimitInterval seconds.
+	RateLimit         int `mapstructure:"rate_limit" yaml:"rate_limit"`
+	RateLimitInterval int `mapstructure:"rate_limit_interval" yaml:"rate_limit_interval"`
 }
 
 // ReadConfig builds the traps configuration from the Agent configuration.
@@ -111,6 +130,29 @@ func (c *TrapsConfig) SetDefaults(host string, namespace string) error {
 	}
 	c.Tags = cleaned
 
+	if err := c.Correlation.setDefaults(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	return nil
+}
+
+func (c *CorrelationConfig) setDefaults() error {
+	if c.DedupWindow < 0 {
+		return fmt.Errorf("correlation.dedup_window must not be negative, got %d", c.DedupWindow)
+	}
+	if c.FlapWindow < 0 {
+		return fmt.Errorf("correlation.flap_window must not be negative, got %d", c.FlapWindow)
+	}
+	if c.RateLimit < 0 {
+		return fmt.Errorf("correlation.rate_limit must not be negative, got %d", c.RateLimit)
+	}
+	if c.RateLimitInterval < 0 {
+		return fmt.Errorf("correlation.rate_limit_interval must not be negative, got %d", c.RateLimitInterval)
+	}
+	if c.RateLimitInterval == 0 {
+		c.RateLimitInterval = defaultRateLimitInterval
+	}
 	return nil
 }
 
@@ -264,3 +264,32 @@ func TestTagsNormalization(t *testing.T) {
 	}, "")
 	assert.Equal(t, []string{"application:foo", "team:netops"}, config.Tags)
 }
+
+func TestCorrelationDefaults(t *testing.T) {
+	config := buildTrapsConfig(t, nil, "")
+	assert.Equal(t, CorrelationConfig{RateLimitInterval: 60}, config.Correlation)
+}
+
+func TestCorrelationConfig(t *testing.T) {
+	config := buildTrapsConfig(t, &TrapsConfig{
+		Correlation: CorrelationConfig{
+			DedupWindow:       30,
+			FlapWindow:        120,
+			RateLimit:         100,
+			RateLimitInterval: 10,
+		},
+	}, "")
+	assert.Equal(t, CorrelationConfig{
+		DedupWindow:       30,
+		FlapWindow:        120,
+		RateLimit:         100,
+		RateLimitInterval: 10,
+	}, config.Correlation)
+}
+
+func TestInvalidCorrelationConfig(t *testing.T) {
+	config := &TrapsConfig{Correlation: CorrelationConfig{FlapWindow: -1}}
+	err := config.SetDefaults("", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "correlation.flap_window must not be negative")
+}
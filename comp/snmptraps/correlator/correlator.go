@@ -0,0 +1,294 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+// Package correlator reduces the volume of forwarded traps: it drops the
+// duplicates of recently forwarded traps, pairs the linkDown and linkUp traps
+// of flapping interfaces into a single flap, and limits the number of traps
+// forwarded per device. The dropped traps are reported in summaries.
+package correlator
+
+import (
+	"time"
+
+	config "github.com/DataDog/datadog-agent/comp/snmptraps/config/def"
+	"github.com/DataDog/datadog-agent/comp/snmptraps/packet"
+)
+
+// Verdict is the decision taken for a trap
+type Verdict int
+
+const (
+	// Forward means that the trap must be forwarded
+	Forward Verdict = iota
+	// Deduplicated means that the trap is a duplicate of a recently forwarded trap
+	Deduplicated
+	// Correlated means that the trap is held or paired with the other link traps of its interface
+	Correlated
+	// RateLimited means that the device sent more traps than its rate limit
+	RateLimited
+)
+
+// SummaryType is the type of a summary
+type SummaryType string
+
+const (
+	// SummaryDeduplicated reports the duplicates of a trap dropped during the dedup window
+	SummaryDeduplicated SummaryType = "deduplicated"
+	// SummaryLinkFlap reports the linkDown and linkUp traps of a flapping interface
+	SummaryLinkFlap SummaryType = "link_flap"
+	// SummaryRateLimited reports the traps of a device dropped by the rate limit
+	SummaryRateLimited SummaryType = "rate_limited"
+)
+
+// Summary reports traps that were not forwarded
+type Summary struct {
+	Type SummaryType
+	// Packet is the first trap of the summary, it identifies the device
+	Packet *packet.SnmpPacket
+	// TrapOID is the OID of the summarized traps, empty for rate limits
+	TrapOID string
+	// IfIndex is the index of the flapping interface
+	IfIndex string
+	// Count is the number of dropped traps, or the number of flaps for link flaps
+	Count     int
+	LinkDowns int
+	LinkUps   int
+	Start     time.Time
+	End       time.Time
+}
+
+type dedupEntry struct {
+	first      *packet.SnmpPacket
+	trapOID    string
+	start      time.Time
+	expires    time.Time
+	duplicates int
+}
+
+type flapEntry struct {
+	first   *packet.SnmpPacket
+	ifIndex string
+	// lastDown is the last linkDown trap if the interface is down, it is
+	// forwarded when the flap ends so that the final state isn't lost.
+	lastDown *packet.SnmpPacket
+	downs    int
+	ups      int
+	start    time.Time
+	last     time.Time
+	expires  time.Time
+}
+
+type rateEntry struct {
+	first     *packet.SnmpPacket
+	start     time.Time
+	expires   time.Time
+	forwarded int
+	dropped   int
+}
+
+// Correlator decides which traps are forwarded. It isn't safe for concurrent
+// use.
+type Correlator struct {
+	dedupWindow       time.Duration
+	flapWindow        time.Duration
+	rateLimit         int
+	rateLimitInterval time.Duration
+
+	dedup map[string]*dedupEntry
+	flaps map[string]*flapEntry
+	rates map[string]*rateEntry
+
+	// the traps and summaries of the windows that ended while processing
+	// traps, returned by the next flush
+	released  []*packet.SnmpPacket
+	summaries []Summary
+}
+
+// New returns a correlator, or nil if deduplication, flap detection and rate
+// limiting are all disabled.
+func New(conf config.CorrelationConfig) *Correlator {
+	if conf.DedupWindow == 0 && conf.FlapWindow == 0 && conf.RateLimit == 0 {
+		return nil
+	}
+	return &Correlator{
+		dedupWindow:       time.Duration(conf.DedupWindow) * time.Second,
+		flapWindow:        time.Duration(conf.FlapWindow) * time.Second,
+		rateLimit:         conf.RateLimit,
+		rateLimitInterval: time.Duration(conf.RateLimitInterval) * time.Second,
+		dedup:             make(map[string]*dedupEntry),
+		flaps:             make(map[string]*flapEntry),
+		rates:             make(map[string]*rateEntry),
+	}
+}
+
+// Process decides whether a trap received at now must be forwarded. Link
+// traps are correlated before being deduplicated, so that repeated flaps of an
+// interface aren't hidden by the deduplication.
+func (c *Correlator) Process(p *packet.SnmpPacket, now time.Time) Verdict {
+	oid, variables, ok := trapOID(p)
+	if !ok {
+		return c.limit(p, now)
+	}
+	if c.flapWindow > 0 && (oid == LinkDownOID || oid == LinkUpOID) {
+		if c.correlateLink(p, oid, ifIndex(variables), now) {
+			return Correlated
+		}
+	}
+	if c.dedupWindow > 0 {
+		key := deviceKey(p) + "|" + oid + variablesKey(variables)
+		if entry, ok := c.dedup[key]; ok {
+			if now.Before(entry.expires) {
+				entry.duplicates++
+				return Deduplicated
+			}
+			c.endDedup(entry)
+		}
+		c.dedup[key] = &dedupEntry{
+			first:   p,
+			trapOID: oid,
+			start:   now,
+			expires: now.Add(c.dedupWindow),
+		}
+	}
+	return c.limit(p, now)
+}
+
+// correlateLink pairs the linkDown and linkUp traps of an interface. A
+// linkDown trap is held during the flap window; if the interface goes up
+// before the window ends, the traps are reported as a flap. The window starts
+// with the first linkDown trap and isn't extended by the following traps, so
+// an interface that keeps flapping is still reported once per window. It
+// returns false if the trap isn't part of a flap and must be processed
+// normally.
+func (c *Correlator) correlateLink(p *packet.SnmpPacket, oid string, ifIndex string, now time.Time) bool {
+	key := deviceKey(p) + "|" + ifIndex
+	entry, ok := c.flaps[key]
+	if ok && !now.Before(entry.expires) {
+		c.endFlap(entry)
+		delete(c.flaps, key)
+		ok = false
+	}
+	if oid == LinkUpOID {
+		if !ok {
+			return false
+		}
+		entry.ups++
+		entry.lastDown = nil
+	} else {
+		if !ok {
+			entry = &flapEntry{first: p, ifIndex: ifIndex, start: now, expires: now.Add(c.flapWindow)}
+			c.flaps[key] = entry
+		}
+		entry.downs++
+		entry.lastDown = p
+	}
+	entry.last = now
+	return true
+}
+
+// limit applies the rate limit of the device to a trap
+func (c *Correlator) limit(p *packet.SnmpPacket, now time.Time) Verdict {
+	if c.rateLimit == 0 {
+		return Forward
+	}
+	key := deviceKey(p)
+	entry, ok := c.rates[key]
+	if ok && !now.Before(entry.expires) {
+		c.endRate(entry)
+		ok = false
+	}
+	if !ok {
+		entry = &rateEntry{first: p, start: now, expires: now.Add(c.rateLimitInterval)}
+		c.rates[key] = entry
+	}
+	if entry.forwarded >= c.rateLimit {
+		entry.dropped++
+		return RateLimited
+	}
+	entry.forwarded++
+	return Forward
+}
+
+func (c *Correlator) endDedup(entry *dedupEntry) {
+	if entry.duplicates == 0 {
+		return
+	}
+	c.summaries = append(c.summaries, Summary{
+		Type:    SummaryDeduplicated,
+		Packet:  entry.first,
+		TrapOID: entry.trapOID,
+		Count:   entry.duplicates,
+		Start:   entry.start,
+		End:     entry.expires,
+	})
+}
+
+func (c *Correlator) endFlap(entry *flapEntry) {
+	if entry.ups > 0 {
+		c.summaries = append(c.summaries, Summary{
+			Type:      SummaryLinkFlap,
+			Packet:    entry.first,
+			TrapOID:   LinkDownOID,
+			IfIndex:   entry.ifIndex,
+			Count:     entry.ups,
+			LinkDowns: entry.downs,
+			LinkUps:   entry.ups,
+			Start:     entry.start,
+			End:       entry.last,
+		})
+	}
+	if entry.lastDown != nil {
+		c.released = append(c.released, entry.lastDown)
+	}
+}
+
+func (c *Correlator) endRate(entry *rateEntry) {
+	if entry.dropped == 0 {
+		return
+	}
+	c.summaries = append(c.summaries, Summary{
+		Type:   SummaryRateLimited,
+		Packet: entry.first,
+		Count:  entry.dropped,
+		Start:  entry.start,
+		End:    entry.expires,
+	})
+}
+
+// Flush returns the summaries of the windows ended at now, and the linkDown
+// traps that must be forwarded because their interface didn't come back up.
+func (c *Correlator) Flush(now time.Time) ([]*packet.SnmpPacket, []Summary) {
+	return c.flush(func(expires time.Time) bool { return !now.Before(expires) })
+}
+
+// FlushAll ends all windows and returns their summaries and the held linkDown
+// traps; it is used when the forwarder stops.
+func (c *Correlator) FlushAll() ([]*packet.SnmpPacket, []Summary) {
+	return c.flush(func(time.Time) bool { return true })
+}
+
+func (c *Correlator) flush(ended func(expires time.Time) bool) ([]*packet.SnmpPacket, []Summary) {
+	for key, entry := range c.flaps {
+		if ended(entry.expires) {
+			c.endFlap(entry)
+			delete(c.flaps, key)
+		}
+	}
+	for key, entry := range c.dedup {
+		if ended(entry.expires) {
+			c.endDedup(entry)
+			delete(c.dedup, key)
+		}
+	}
+	for key, entry := range c.rates {
+		if ended(entry.expires) {
+			c.endRate(entry)
+			delete(c.rates, key)
+		}
+	}
+	released, summaries := c.released, c.summaries
+	c.released, c.summaries = nil, nil
+	return released, summaries
+}
@@ -0,0 +1,253 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package correlator
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	config "github.com/DataDog/datadog-agent/comp/snmptraps/config/def"
+	"github.com/DataDog/datadog-agent/comp/snmptraps/packet"
+)
+
+var start = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func makeTrap(ip string, oid string, variables ...gosnmp.SnmpPDU) *packet.SnmpPacket {
+	return &packet.SnmpPacket{
+		Content: &gosnmp.SnmpPacket{
+			Version: gosnmp.Version2c,
+			Variables: append([]gosnmp.SnmpPDU{
+				{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(1000)},
+				{Name: ".1.3.6.1.6.3.1.1.4.1.0", Type: gosnmp.ObjectIdentifier, Value: oid},
+			}, variables...),
+		},
+		Addr:      &net.UDPAddr{IP: net.ParseIP(ip), Port: 161},
+		Namespace: "default",
+	}
+}
+
+func makeLinkTrap(ip string, oid string, ifIndex int) *packet.SnmpPacket {
+	return makeTrap(ip, oid,
+		gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.2.2.1.1", Type: gosnmp.Integer, Value: ifIndex},
+		gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.2.2.1.7", Type: gosnmp.Integer, Value: 1},
+	)
+}
+
+func TestNewDisabled(t *testing.T) {
+	assert.Nil(t, New(config.CorrelationConfig{RateLimitInterval: 60}))
+}
+
+func TestTrapOID(t *testing.T) {
+	oid, variables, ok := trapOID(makeLinkTrap("10.0.0.1", ".1.3.6.1.6.3.1.1.5.3", 2))
+	require.True(t, ok)
+	assert.Equal(t, LinkDownOID, oid)
+	assert.Len(t, variables, 2)
+	assert.Equal(t, "2", ifIndex(variables))
+
+	v1 := &packet.SnmpPacket{Content: &gosnmp.SnmpPacket{
+		Version:  gosnmp.Version1,
+		SnmpTrap: gosnmp.SnmpTrap{GenericTrap: 3},
+	}}
+	oid, _, ok = trapOID(v1)
+	require.True(t, ok)
+	assert.Equal(t, LinkUpOID, oid)
+
+	v1.Content.SnmpTrap = gosnmp.SnmpTrap{Enterprise: ".1.3.6.1.2.1.118", GenericTrap: 6, SpecificTrap: 2}
+	oid, _, ok = trapOID(v1)
+	require.True(t, ok)
+	assert.Equal(t, "1.3.6.1.2.1.118.0.2", oid)
+
+	_, _, ok = trapOID(&packet.SnmpPacket{Content: &gosnmp.SnmpPacket{Version: gosnmp.Version2c}})
+	assert.False(t, ok)
+}
+
+func TestDeduplication(t *testing.T) {
+	c := New(config.CorrelationConfig{DedupWindow: 30})
+	value := gosnmp.SnmpPDU{Name: "1.3.6.1.4.1.8072.2.3.2.1", Type: gosnmp.Integer, Value: 1024}
+	first := makeTrap("10.0.0.1", "1.3.6.1.4.1.8072.2.3.0.1", value)
+
+	assert.Equal(t, Forward, c.Process(first, start))
+	assert.Equal(t, Deduplicated, c.Process(makeTrap("10.0.0.1", "1.3.6.1.4.1.8072.2.3.0.1", value), start.Add(time.Second)))
+	assert.Equal(t, Deduplicated, c.Process(makeTrap("10.0.0.1", "1.3.6.1.4.1.8072.2.3.0.1", value), start.Add(2*time.Second)))
+	// a different value, OID or device isn't a duplicate
+	other := gosnmp.SnmpPDU{Name: "1.3.6.1.4.1.8072.2.3.2.1", Type: gosnmp.Integer, Value: 1025}
+	assert.Equal(t, Forward, c.Process(makeTrap("10.0.0.1", "1.3.6.1.4.1.8072.2.3.0.1", other), start.Add(3*time.Second)))
+	assert.Equal(t, Forward, c.Process(makeTrap("10.0.0.1", "1.3.6.1.4.1.8072.2.3.0.2", value), start.Add(3*time.Second)))
+	assert.Equal(t, Forward, c.Process(makeTrap("10.0.0.2", "1.3.6.1.4.1.8072.2.3.0.1", value), start.Add(3*time.Second)))
+
+	released, summaries := c.Flush(start.Add(29 * time.Second))
+	assert.Empty(t, released)
+	assert.Empty(t, summaries)
+
+	released, summaries = c.Flush(start.Add(33 * time.Second))
+	assert.Empty(t, released)
+	assert.Equal(t, []Summary{{
+		Type:    SummaryDeduplicated,
+		Packet:  first,
+		TrapOID: "1.3.6.1.4.1.8072.2.3.0.1",
+		Count:   2,
+		Start:   start,
+		End:     start.Add(30 * time.Second),
+	}}, summaries)
+
+	// the window is over, the trap is forwarded again
+	assert.Equal(t, Forward, c.Process(makeTrap("10.0.0.1", "1.3.6.1.4.1.8072.2.3.0.1", value), start.Add(34*time.Second)))
+}
+
+func TestDeduplicationWindowEndedBeforeFlush(t *testing.T) {
+	c := New(config.CorrelationConfig{DedupWindow: 10})
+	first := makeTrap("10.0.0.1", "1.3.6.1.4.1.8072.2.3.0.1")
+
+	assert.Equal(t, Forward, c.Process(first, start))
+	assert.Equal(t, Deduplicated, c.Process(makeTrap("10.0.0.1", "1.3.6.1.4.1.8072.2.3.0.1"), start.Add(time.Second)))
+	assert.Equal(t, Forward, c.Process(makeTrap("10.0.0.1", "1.3.6.1.4.1.8072.2.3.0.1"), start.Add(11*time.Second)))
+
+	_, summaries := c.Flush(start.Add(12 * time.Second))
+	require.Len(t, summaries, 1)
+	assert.Equal(t, first, summaries[0].Packet)
+	assert.Equal(t, 1, summaries[0].Count)
+}
+
+func TestLinkFlap(t *testing.T) {
+	c := New(config.CorrelationConfig{DedupWindow: 60, FlapWindow: 10})
+	firstDown := makeLinkTrap("10.0.0.1", LinkDownOID, 2)
+
+	assert.Equal(t, Correlated, c.Process(firstDown, start))
+	assert.Equal(t, Correlated, c.Process(makeLinkTrap("10.0.0.1", LinkUpOID, 2), start.Add(1*time.Second)))
+	assert.Equal(t, Correlated, c.Process(makeLinkTrap("10.0.0.1", LinkDownOID, 2), start.Add(2*time.Second)))
+	assert.Equal(t, Correlated, c.Process(makeLinkTrap("10.0.0.1", LinkUpOID, 2), start.Add(3*time.Second)))
+	// other interfaces aren't part of the flap
+	assert.Equal(t, Forward, c.Process(makeLinkTrap("10.0.0.1", LinkUpOID, 3), start.Add(3*time.Second)))
+
+	released, summaries := c.Flush(start.Add(9 * time.Second))
+	assert.Empty(t, released)
+	assert.Empty(t, summaries)
+
+	released, summaries = c.Flush(start.Add(10 * time.Second))
+	assert.Empty(t, released)
+	assert.Equal(t, []Summary{{
+		Type:      SummaryLinkFlap,
+		Packet:    firstDown,
+		TrapOID:   LinkDownOID,
+		IfIndex:   "2",
+		Count:     2,
+		LinkDowns: 2,
+		LinkUps:   2,
+		Start:     start,
+		End:       start.Add(3 * time.Second),
+	}}, summaries)
+}
+
+func TestLinkFlapEndsDown(t *testing.T) {
+	c := New(config.CorrelationConfig{FlapWindow: 10})
+	lastDown := makeLinkTrap("10.0.0.1", LinkDownOID, 2)
+
+	assert.Equal(t, Correlated, c.Process(makeLinkTrap("10.0.0.1", LinkDownOID, 2), start))
+	assert.Equal(t, Correlated, c.Process(makeLinkTrap("10.0.0.1", LinkUpOID, 2), start.Add(time.Second)))
+	assert.Equal(t, Correlated, c.Process(lastDown, start.Add(2*time.Second)))
+
+	// the interface is still down when the flap ends, the last linkDown is forwarded
+	released, summaries := c.Flush(start.Add(12 * time.Second))
+	assert.Equal(t, []*packet.SnmpPacket{lastDown}, released)
+	require.Len(t, summaries, 1)
+	assert.Equal(t, 1, summaries[0].Count)
+	assert.Equal(t, 2, summaries[0].LinkDowns)
+	assert.Equal(t, 1, summaries[0].LinkUps)
+}
+
+func TestLinkFlapWindowIsNotExtended(t *testing.T) {
+	c := New(config.CorrelationConfig{FlapWindow: 10})
+
+	// the interface flaps every second, it is reported once per window
+	var summaries []Summary
+	for i := range 25 {
+		oid := LinkDownOID
+		if i%2 == 1 {
+			oid = LinkUpOID
+		}
+		assert.Equal(t, Correlated, c.Process(makeLinkTrap("10.0.0.1", oid, 2), start.Add(time.Duration(i)*time.Second)))
+		_, flushed := c.Flush(start.Add(time.Duration(i) * time.Second))
+		summaries = append(summaries, flushed...)
+	}
+	require.Len(t, summaries, 2)
+	assert.Equal(t, start, summaries[0].Start)
+	assert.Equal(t, start.Add(9*time.Second), summaries[0].End)
+	assert.Equal(t, 5, summaries[0].LinkDowns)
+	assert.Equal(t, 5, summaries[0].LinkUps)
+	assert.Equal(t, start.Add(10*time.Second), summaries[1].Start)
+	assert.Equal(t, start.Add(19*time.Second), summaries[1].End)
+}
+
+func TestLinkDownWithoutLinkUp(t *testing.T) {
+	c := New(config.CorrelationConfig{FlapWindow: 10})
+	down := makeLinkTrap("10.0.0.1", LinkDownOID, 2)
+
+	assert.Equal(t, Correlated, c.Process(down, start))
+	released, summaries := c.Flush(start.Add(5 * time.Second))
+	assert.Empty(t, released)
+	assert.Empty(t, summaries)
+
+	released, summaries = c.Flush(start.Add(10 * time.Second))
+	assert.Equal(t, []*packet.SnmpPacket{down}, released)
+	assert.Empty(t, summaries)
+
+	// a linkUp without a preceding linkDown is forwarded
+	assert.Equal(t, Forward, c.Process(makeLinkTrap("10.0.0.1", LinkUpOID, 2), start.Add(11*time.Second)))
+}
+
+func TestRateLimit(t *testing.T) {
+	c := New(config.CorrelationConfig{RateLimit: 2, RateLimitInterval: 60})
+	first := makeTrap("10.0.0.1", "1.3.6.1.4.1.8072.2.3.0.1")
+
+	assert.Equal(t, Forward, c.Process(first, start))
+	assert.Equal(t, Forward, c.Process(makeTrap("10.0.0.1", "1.3.6.1.4.1.8072.2.3.0.2"), start.Add(time.Second)))
+	assert.Equal(t, RateLimited, c.Process(makeTrap("10.0.0.1", "1.3.6.1.4.1.8072.2.3.0.3"), start.Add(2*time.Second)))
+	assert.Equal(t, RateLimited, c.Process(makeTrap("10.0.0.1", "1.3.6.1.4.1.8072.2.3.0.4"), start.Add(3*time.Second)))
+	// the limit applies per device
+	assert.Equal(t, Forward, c.Process(makeTrap("10.0.0.2", "1.3.6.1.4.1.8072.2.3.0.1"), start.Add(3*time.Second)))
+	// packets that aren't valid traps are rate limited too
+	assert.Equal(t, RateLimited, c.Process(&packet.SnmpPacket{
+		Content:   &gosnmp.SnmpPacket{Version: gosnmp.Version2c},
+		Addr:      &net.UDPAddr{IP: net.ParseIP("10.0.0.1"), Port: 161},
+		Namespace: "default",
+	}, start.Add(4*time.Second)))
+
+	// a new interval starts, the summary of the previous one is returned by the next flush
+	assert.Equal(t, Forward, c.Process(makeTrap("10.0.0.1", "1.3.6.1.4.1.8072.2.3.0.5"), start.Add(61*time.Second)))
+	released, summaries := c.Flush(start.Add(62 * time.Second))
+	assert.Empty(t, released)
+	assert.Equal(t, []Summary{{
+		Type:   SummaryRateLimited,
+		Packet: first,
+		Count:  3,
+		Start:  start,
+		End:    start.Add(60 * time.Second),
+	}}, summaries)
+}
+
+func TestFlushAll(t *testing.T) {
+	c := New(config.CorrelationConfig{DedupWindow: 30, FlapWindow: 10, RateLimit: 1, RateLimitInterval: 60})
+	down := makeLinkTrap("10.0.0.1", LinkDownOID, 2)
+
+	assert.Equal(t, Correlated, c.Process(down, start))
+	assert.Equal(t, Forward, c.Process(makeTrap("10.0.0.1", "1.3.6.1.4.1.8072.2.3.0.1"), start))
+	assert.Equal(t, Deduplicated, c.Process(makeTrap("10.0.0.1", "1.3.6.1.4.1.8072.2.3.0.1"), start))
+	assert.Equal(t, RateLimited, c.Process(makeTrap("10.0.0.1", "1.3.6.1.4.1.8072.2.3.0.2"), start))
+
+	released, summaries := c.FlushAll()
+	assert.Equal(t, []*packet.SnmpPacket{down}, released)
+	require.Len(t, summaries, 2)
+	assert.ElementsMatch(t, []SummaryType{SummaryDeduplicated, SummaryRateLimited}, []SummaryType{summaries[0].Type, summaries[1].Type})
+
+	released, summaries = c.FlushAll()
+	assert.Empty(t, released)
+	assert.Empty(t, summaries)
+}
@@ -0,0 +1,79 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package correlator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gosnmp/gosnmp"
+
+	oidresolver "github.com/DataDog/datadog-agent/comp/snmptraps/oidresolver/def"
+	"github.com/DataDog/datadog-agent/comp/snmptraps/packet"
+)
+
+const (
+	genericTrapOID = "1.3.6.1.6.3.1.1.5"
+	snmpTrapOID    = "1.3.6.1.6.3.1.1.4.1.0"
+	// LinkDownOID is the OID of the IF-MIB linkDown trap
+	LinkDownOID = "1.3.6.1.6.3.1.1.5.3"
+	// LinkUpOID is the OID of the IF-MIB linkUp trap
+	LinkUpOID  = "1.3.6.1.6.3.1.1.5.4"
+	ifIndexOID = "1.3.6.1.2.1.2.2.1.1"
+)
+
+// trapOID returns the OID of a trap and its variables, without the
+// sysUpTime.0 and snmpTrapOID.0 variables of v2 and v3 traps. It returns
+// false if the packet is not a valid trap; such packets are left to the
+// formatter, which reports them.
+func trapOID(p *packet.SnmpPacket) (string, []gosnmp.SnmpPDU, bool) {
+	content := p.Content
+	if content.Version == gosnmp.Version1 {
+		if content.GenericTrap == 6 {
+			return fmt.Sprintf("%s.0.%d", oidresolver.NormalizeOID(content.Enterprise), content.SpecificTrap), content.Variables, true
+		}
+		return fmt.Sprintf("%s.%d", genericTrapOID, content.GenericTrap+1), content.Variables, true
+	}
+	if len(content.Variables) < 2 || oidresolver.NormalizeOID(content.Variables[1].Name) != snmpTrapOID {
+		return "", nil, false
+	}
+	var oid string
+	switch value := content.Variables[1].Value.(type) {
+	case string:
+		oid = value
+	case []byte:
+		oid = string(value)
+	default:
+		return "", nil, false
+	}
+	return oidresolver.NormalizeOID(oid), content.Variables[2:], true
+}
+
+// deviceKey identifies the device that sent a trap
+func deviceKey(p *packet.SnmpPacket) string {
+	return p.Namespace + ":" + p.Addr.IP.String()
+}
+
+// variablesKey returns a string identifying the values of the variables
+func variablesKey(variables []gosnmp.SnmpPDU) string {
+	var b strings.Builder
+	for _, variable := range variables {
+		fmt.Fprintf(&b, "|%s=%d:%v", oidresolver.NormalizeOID(variable.Name), variable.Type, variable.Value)
+	}
+	return b.String()
+}
+
+// ifIndex returns the index of the interface of a linkDown or linkUp trap,
+// from its ifIndex variable.
+func ifIndex(variables []gosnmp.SnmpPDU) string {
+	for _, variable := range variables {
+		name := oidresolver.NormalizeOID(variable.Name)
+		if name == ifIndexOID || strings.HasPrefix(name, ifIndexOID+".") {
+			return fmt.Sprint(variable.Value)
+		}
+	}
+	return ""
+}
@@ -15,10 +15,12 @@ import (
 	compdef "github.com/DataDog/datadog-agent/comp/def"
 	eventplatform "github.com/DataDog/datadog-agent/comp/forwarder/eventplatform/def"
 	config "github.com/DataDog/datadog-agent/comp/snmptraps/config/def"
+	"github.com/DataDog/datadog-agent/comp/snmptraps/correlator"
 	formatter "github.com/DataDog/datadog-agent/comp/snmptraps/formatter/def"
 	forwarder "github.com/DataDog/datadog-agent/comp/snmptraps/forwarder/def"
 	listener "github.com/DataDog/datadog-agent/comp/snmptraps/listener/def"
 	"github.com/DataDog/datadog-agent/comp/snmptraps/packet"
+	status "github.com/DataDog/datadog-agent/comp/snmptraps/status/def"
 	"github.com/DataDog/datadog-agent/pkg/aggregator/sender"
 )
 
@@ -26,12 +28,16 @@ import (
 // The trapForwarder is an intermediate step between the listener and the epforwarder in order to limit the processing of the listener
 // to the minimum. The forwarder process payloads received by the listener via the trapsIn channel, formats them and finally
 // give them to the epforwarder for sending it to Datadog.
+// When correlation is configured, traps go through the correlator first, which drops duplicates, pairs link flaps and
+// applies rate limits; the dropped traps are reported in summary events.
 type trapForwarder struct {
-	trapsIn   packet.PacketsChannel
-	formatter formatter.Component
-	sender    sender.Sender
-	stopChan  chan struct{}
-	logger    log.Component
+	trapsIn    packet.PacketsChannel
+	formatter  formatter.Component
+	correlator *correlator.Correlator
+	sender     sender.Sender
+	stopChan   chan struct{}
+	logger     log.Component
+	status     status.Component
 }
 
 // Requires defines the dependencies for the forwarder component.
@@ -43,6 +49,7 @@ type Requires struct {
 	Demux     demultiplexer.Component
 	Listener  listener.Component
 	Logger    log.Component
+	Status    status.Component
 }
 
 // Provides defines the output of the forwarder component.
@@ -57,14 +64,16 @@ func NewComponent(dep Requires) (Provides, error) {
 	if err != nil {
 		return Provides{}, err
 	}
+	conf := dep.Config.Get()
 	tf := &trapForwarder{
-		trapsIn:   dep.Listener.Packets(),
-		formatter: dep.Formatter,
-		sender:    sender,
-		stopChan:  make(chan struct{}, 1),
-		logger:    dep.Logger,
+		trapsIn:    dep.Listener.Packets(),
+		formatter:  dep.Formatter,
+		correlator: correlator.New(conf.Correlation),
+		sender:     sender,
+		stopChan:   make(chan struct{}, 1),
+		logger:     dep.Logger,
+		status:     dep.Status,
 	}
-	conf := dep.Config.Get()
 	if conf.Enabled {
 		dep.Lc.Append(compdef.Hook{
 			OnStart: func(_ context.Context) error {
@@ -99,19 +108,62 @@ func (tf *trapForwarder) Stop() {
 func (tf *trapForwarder) run() {
 	flushTicker := time.NewTicker(10 * time.Second)
 	defer flushTicker.Stop()
+	// the correlation windows are checked every second, a nil channel blocks
+	// forever when correlation is disabled
+	var correlationTick <-chan time.Time
+	if tf.correlator != nil {
+		correlationTicker := time.NewTicker(time.Second)
+		defer correlationTicker.Stop()
+		correlationTick = correlationTicker.C
+	}
 	for {
 		select {
 		case <-tf.stopChan:
+			if tf.correlator != nil {
+				tf.sendCorrelated(tf.correlator.FlushAll())
+				tf.sender.Commit()
+			}
 			tf.logger.Info("Stopped TrapForwarder")
 			return
 		case packet := <-tf.trapsIn:
-			tf.sendTrap(packet)
+			tf.processTrap(packet)
+		case now := <-correlationTick:
+			tf.sendCorrelated(tf.correlator.Flush(now))
 		case <-flushTicker.C:
 			tf.sender.Commit() // Commit metrics
 		}
 	}
 }
 
+func (tf *trapForwarder) processTrap(packet *packet.SnmpPacket) {
+	if tf.correlator == nil {
+		tf.sendTrap(packet)
+		return
+	}
+	switch tf.correlator.Process(packet, time.Now()) {
+	case correlator.Forward:
+		tf.sendTrap(packet)
+	case correlator.Deduplicated:
+		tf.sender.Count("datadog.snmp_traps.deduplicated", 1, "", packet.GetTags())
+		tf.status.AddTrapsPacketsDeduplicated(1)
+	case correlator.RateLimited:
+		tf.sender.Count("datadog.snmp_traps.rate_limited", 1, "", packet.GetTags())
+		tf.status.AddTrapsPacketsRateLimited(1)
+	case correlator.Correlated:
+		// held until the flap ends
+	}
+}
+
+// sendCorrelated forwards the traps released by the correlator and its summaries
+func (tf *trapForwarder) sendCorrelated(released []*packet.SnmpPacket, summaries []correlator.Summary) {
+	for _, packet := range released {
+		tf.sendTrap(packet)
+	}
+	for _, summary := range summaries {
+		tf.sendSummary(summary)
+	}
+}
+
 func (tf *trapForwarder) sendTrap(packet *packet.SnmpPacket) {
 	data, err := tf.formatter.FormatPacket(packet)
 	if err != nil {
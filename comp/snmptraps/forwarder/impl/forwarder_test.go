@@ -6,16 +6,20 @@
 package forwarderimpl
 
 import (
+	"encoding/json"
 	"net"
 	"testing"
 	"time"
 
 	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/fx"
 
 	eventplatform "github.com/DataDog/datadog-agent/comp/forwarder/eventplatform/def"
+	config "github.com/DataDog/datadog-agent/comp/snmptraps/config/def"
 	configfx "github.com/DataDog/datadog-agent/comp/snmptraps/config/fx"
+	"github.com/DataDog/datadog-agent/comp/snmptraps/correlator"
 	formatter "github.com/DataDog/datadog-agent/comp/snmptraps/formatter/def"
 	formatterfx "github.com/DataDog/datadog-agent/comp/snmptraps/formatter/fx"
 	forwarder "github.com/DataDog/datadog-agent/comp/snmptraps/forwarder/def"
@@ -23,6 +27,8 @@ import (
 	listenerfx "github.com/DataDog/datadog-agent/comp/snmptraps/listener/fx"
 	"github.com/DataDog/datadog-agent/comp/snmptraps/packet"
 	"github.com/DataDog/datadog-agent/comp/snmptraps/senderhelper"
+	status "github.com/DataDog/datadog-agent/comp/snmptraps/status/def"
+	statusfx "github.com/DataDog/datadog-agent/comp/snmptraps/status/fx"
 	"github.com/DataDog/datadog-agent/pkg/aggregator/mocksender"
 	"github.com/DataDog/datadog-agent/pkg/util/fxutil"
 )
@@ -35,16 +41,19 @@ type services struct {
 	Formatter formatter.Component
 	Listener  listener.MockComponent
 	Forwarder forwarder.Component
+	Status    status.Component
 }
 
-func setUp(t *testing.T) *services {
+func setUp(t *testing.T, opts ...fx.Option) *services {
 	t.Helper()
 	s := fxutil.Test[services](t,
 		configfx.MockModule(),
 		senderhelper.Opts,
 		formatterfx.MockModule(),
 		listenerfx.MockModule(),
+		statusfx.MockModule(),
 		fxutil.ProvideComponentConstructor(NewComponent),
+		fx.Options(opts...),
 	)
 	return &s
 }
@@ -113,3 +122,80 @@ func TestForwarderCustomTagsOnForwardedMetric(t *testing.T) {
 		"team:netops",
 	})
 }
+
+func makeLinkTrap(oid string) *packet.SnmpPacket {
+	return makeSnmpPacket(gosnmp.SnmpTrap{
+		Variables: []gosnmp.SnmpPDU{
+			{Name: "1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(1000)},
+			{Name: "1.3.6.1.6.3.1.1.4.1.0", Type: gosnmp.ObjectIdentifier, Value: oid},
+			{Name: "1.3.6.1.2.1.2.2.1.1", Type: gosnmp.Integer, Value: 2},
+		},
+	})
+}
+
+func TestDuplicateTrapsAreDropped(t *testing.T) {
+	s := setUp(t, fx.Replace(&config.TrapsConfig{
+		Enabled:     true,
+		Correlation: config.CorrelationConfig{DedupWindow: 30},
+	}))
+	for i := 0; i < 3; i++ {
+		s.Listener.Send(makeSnmpPacket(packet.NetSNMPExampleHeartbeatNotification))
+	}
+	time.Sleep(100 * time.Millisecond)
+	s.Sender.AssertNumberOfCalls(t, "EventPlatformEvent", 1)
+	s.Sender.AssertMetric(t, "Count", "datadog.snmp_traps.deduplicated", 1, "", []string{"snmp_device:1.1.1.1", "device_namespace:totoro", "snmp_version:2"})
+	require.Equal(t, int64(2), s.Status.GetTrapsPacketsDeduplicated())
+}
+
+func TestTrapsAreRateLimited(t *testing.T) {
+	s := setUp(t, fx.Replace(&config.TrapsConfig{
+		Enabled:     true,
+		Correlation: config.CorrelationConfig{RateLimit: 2},
+	}))
+	for i := 0; i < 5; i++ {
+		s.Listener.Send(makeSnmpPacket(packet.NetSNMPExampleHeartbeatNotification))
+	}
+	time.Sleep(100 * time.Millisecond)
+	s.Sender.AssertNumberOfCalls(t, "EventPlatformEvent", 2)
+	require.Equal(t, int64(3), s.Status.GetTrapsPacketsRateLimited())
+}
+
+func TestLinkFlapSummaryIsForwarded(t *testing.T) {
+	s := setUp(t, fx.Replace(&config.TrapsConfig{
+		Enabled:     true,
+		Correlation: config.CorrelationConfig{FlapWindow: 1},
+	}))
+	s.Listener.Send(makeLinkTrap(correlator.LinkDownOID))
+	s.Listener.Send(makeLinkTrap(correlator.LinkUpOID))
+	s.Listener.Send(makeLinkTrap(correlator.LinkDownOID))
+	s.Listener.Send(makeLinkTrap(correlator.LinkUpOID))
+	time.Sleep(100 * time.Millisecond)
+	s.Sender.AssertNumberOfCalls(t, "EventPlatformEvent", 0)
+
+	require.Eventually(t, func() bool {
+		return s.Status.GetTrapsLinkFlaps() == 2
+	}, 5*time.Second, 100*time.Millisecond)
+	s.Sender.AssertNumberOfCalls(t, "EventPlatformEvent", 1)
+	var payload struct {
+		Trap struct {
+			SnmpTrapOID string `json:"snmpTrapOID"`
+			Correlation struct {
+				Type     string `json:"type"`
+				Count    int    `json:"count"`
+				IfIndex  string `json:"ifIndex"`
+				LinkDown int    `json:"linkDown"`
+				LinkUp   int    `json:"linkUp"`
+			} `json:"correlation"`
+		} `json:"trap"`
+	}
+	s.Sender.AssertCalled(t, "EventPlatformEvent", mock.MatchedBy(func(event []byte) bool {
+		return json.Unmarshal(event, &payload) == nil
+	}), eventplatform.EventTypeSnmpTraps)
+	require.Equal(t, correlator.LinkDownOID, payload.Trap.SnmpTrapOID)
+	require.Equal(t, "link_flap", payload.Trap.Correlation.Type)
+	require.Equal(t, 2, payload.Trap.Correlation.Count)
+	require.Equal(t, "2", payload.Trap.Correlation.IfIndex)
+	require.Equal(t, 2, payload.Trap.Correlation.LinkDown)
+	require.Equal(t, 2, payload.Trap.Correlation.LinkUp)
+	s.Sender.AssertMetric(t, "Count", "datadog.snmp_traps.link_flaps", 2, "", []string{"snmp_device:1.1.1.1", "device_namespace:totoro", "snmp_version:2"})
+}
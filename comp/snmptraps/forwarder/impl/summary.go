@@ -0,0 +1,70 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package forwarderimpl
+
+import (
+	"encoding/json"
+	"strings"
+
+	eventplatform "github.com/DataDog/datadog-agent/comp/forwarder/eventplatform/def"
+	"github.com/DataDog/datadog-agent/comp/snmptraps/correlator"
+)
+
+const ddsource = "snmp-traps"
+
+// formatSummary formats a correlation summary like a trap, so that it is
+// searchable with the traps it summarizes:
+//
+//	{
+//	  "trap": {
+//	    "ddsource": "snmp-traps",
+//	    "ddtags": "namespace:default,snmp_device:10.0.0.2,...",
+//	    "timestamp": 123456789,
+//	    "snmpTrapOID": "1.3.6.1.6.3.1.1.5.3",
+//	    "correlation": {
+//	      "type": "link_flap",
+//	      "count": 12,
+//	      ...
+//	    }
+//	  }
+//	}
+func formatSummary(summary correlator.Summary) ([]byte, error) {
+	correlation := map[string]interface{}{
+		"type":  summary.Type,
+		"count": summary.Count,
+		"start": summary.Start.UnixMilli(),
+		"end":   summary.End.UnixMilli(),
+	}
+	if summary.Type == correlator.SummaryLinkFlap {
+		correlation["ifIndex"] = summary.IfIndex
+		correlation["linkDown"] = summary.LinkDowns
+		correlation["linkUp"] = summary.LinkUps
+	}
+	trap := map[string]interface{}{
+		"ddsource":    ddsource,
+		"ddtags":      strings.Join(summary.Packet.GetTags(), ","),
+		"timestamp":   summary.End.UnixMilli(),
+		"correlation": correlation,
+	}
+	if summary.TrapOID != "" {
+		trap["snmpTrapOID"] = summary.TrapOID
+	}
+	return json.Marshal(map[string]interface{}{"trap": trap})
+}
+
+func (tf *trapForwarder) sendSummary(summary correlator.Summary) {
+	if summary.Type == correlator.SummaryLinkFlap {
+		tf.sender.Count("datadog.snmp_traps.link_flaps", float64(summary.Count), "", summary.Packet.GetTags())
+		tf.status.AddTrapsLinkFlaps(int64(summary.Count))
+	}
+	data, err := formatSummary(summary)
+	if err != nil {
+		tf.logger.Errorf("failed to format %s summary: %s", summary.Type, err)
+		return
+	}
+	tf.logger.Tracef("send trap summary payload: %s", string(data))
+	tf.sender.EventPlatformEvent(data, eventplatform.EventTypeSnmpTraps)
+}
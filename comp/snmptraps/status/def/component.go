@@ -15,6 +15,12 @@ type Component interface {
 	GetTrapsPackets() int64
 	AddTrapsPacketsUnknownCommunityString(int64)
 	GetTrapsPacketsUnknownCommunityString() int64
+	AddTrapsPacketsDeduplicated(int64)
+	GetTrapsPacketsDeduplicated() int64
+	AddTrapsPacketsRateLimited(int64)
+	GetTrapsPacketsRateLimited() int64
+	AddTrapsLinkFlaps(int64)
+	GetTrapsLinkFlaps() int64
 	SetStartError(error)
 	GetStartError() error
 }
@@ -18,9 +18,11 @@ func NewMock() status.Component {
 
 // mockManager mocks a manager using plain values (not expvars)
 type mockManager struct {
-	trapsPackets, trapsPacketsUnknownCommunityString int64
-	lock                                             sync.Mutex
-	err                                              error
+	trapsPackets, trapsPacketsUnknownCommunityString  int64
+	trapsPacketsDeduplicated, trapsPacketsRateLimited int64
+	trapsLinkFlaps                                    int64
+	lock                                              sync.Mutex
+	err                                               error
 }
 
 func (s *mockManager) AddTrapsPackets(i int64) {
@@ -47,6 +49,42 @@ func (s *mockManager) GetTrapsPacketsUnknownCommunityString() int64 {
 	return s.trapsPacketsUnknownCommunityString
 }
 
+func (s *mockManager) AddTrapsPacketsDeduplicated(i int64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.trapsPacketsDeduplicated += i
+}
+
+func (s *mockManager) GetTrapsPacketsDeduplicated() int64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.trapsPacketsDeduplicated
+}
+
+func (s *mockManager) AddTrapsPacketsRateLimited(i int64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.trapsPacketsRateLimited += i
+}
+
+func (s *mockManager) GetTrapsPacketsRateLimited() int64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.trapsPacketsRateLimited
+}
+
+func (s *mockManager) AddTrapsLinkFlaps(i int64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.trapsLinkFlaps += i
+}
+
+func (s *mockManager) GetTrapsLinkFlaps() int64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.trapsLinkFlaps
+}
+
 func (s *mockManager) SetStartError(err error) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -21,6 +21,9 @@ var (
 	trapsExpvars                       = expvar.NewMap("snmp_traps")
 	trapsPackets                       = expvar.Int{}
 	trapsPacketsUnknownCommunityString = expvar.Int{}
+	trapsPacketsDeduplicated           = expvar.Int{}
+	trapsPacketsRateLimited            = expvar.Int{}
+	trapsLinkFlaps                     = expvar.Int{}
 	// startError stores the error we report to GetStatus()
 	startError error
 )
@@ -28,6 +31,9 @@ var (
 func init() {
 	trapsExpvars.Set("Packets", &trapsPackets)
 	trapsExpvars.Set("PacketsUnknownCommunityString", &trapsPacketsUnknownCommunityString)
+	trapsExpvars.Set("PacketsDeduplicated", &trapsPacketsDeduplicated)
+	trapsExpvars.Set("PacketsRateLimited", &trapsPacketsRateLimited)
+	trapsExpvars.Set("LinkFlaps", &trapsLinkFlaps)
 }
 
 // Requires defines the dependencies for the status component.
@@ -67,6 +73,30 @@ func (s *manager) GetTrapsPacketsUnknownCommunityString() int64 {
 	return trapsPacketsUnknownCommunityString.Value()
 }
 
+func (s *manager) AddTrapsPacketsDeduplicated(i int64) {
+	trapsPacketsDeduplicated.Add(i)
+}
+
+func (s *manager) GetTrapsPacketsDeduplicated() int64 {
+	return trapsPacketsDeduplicated.Value()
+}
+
+func (s *manager) AddTrapsPacketsRateLimited(i int64) {
+	trapsPacketsRateLimited.Add(i)
+}
+
+func (s *manager) GetTrapsPacketsRateLimited() int64 {
+	return trapsPacketsRateLimited.Value()
+}
+
+func (s *manager) AddTrapsLinkFlaps(i int64) {
+	trapsLinkFlaps.Add(i)
+}
+
+func (s *manager) GetTrapsLinkFlaps() int64 {
+	return trapsLinkFlaps.Value()
+}
+
 func (s *manager) GetStartError() error {
 	return startError
 }
@@ -50,6 +50,10 @@ func TestStatusProvider(t *testing.T) {
 			_ = metrics["PacketsDropped"].(float64)
 			// assert PacketsUnknownCommunityString is float64
 			_ = metrics["PacketsUnknownCommunityString"].(float64)
+			// assert the correlation counters are float64
+			_ = metrics["PacketsDeduplicated"].(float64)
+			_ = metrics["PacketsRateLimited"].(float64)
+			_ = metrics["LinkFlaps"].(float64)
 		}},
 		{"Text", func(t *testing.T) {
 			b := new(bytes.Buffer)
@@ -58,8 +62,11 @@ func TestStatusProvider(t *testing.T) {
 			assert.NoError(t, err)
 
 			expectedOutput := `
+  Link Flaps: 0
   Packets: 0
+  Packets Deduplicated: 0
   Packets Dropped: 42
+  Packets Rate Limited: 0
   Packets Unknown Community String: 0
 `
 
@@ -79,8 +86,11 @@ func TestStatusProvider(t *testing.T) {
   <div class="stat">
     <span class="stat_title">SNMP Traps</span>
     <span class="stat_data">
+          Link Flaps: 0<br>
           Packets: 0<br>
+          Packets Deduplicated: 0<br>
           Packets Dropped: 42<br>
+          Packets Rate Limited: 0<br>
           Packets Unknown Community String: 0<br>
     </span>
   </div>
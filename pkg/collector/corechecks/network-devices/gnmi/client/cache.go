@@ -0,0 +1,191 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package client
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// Value is the latest value received for a leaf
+type Value struct {
+	Path Path
+	// String holds the value of string and enumeration leaves, and the textual representation of the others
+	String string
+	// Number holds the value of numeric and boolean leaves, when IsNumber is set
+	Number    float64
+	IsNumber  bool
+	Timestamp time.Time
+}
+
+// Float returns the numeric value of the leaf, parsing string values since JSON encodings represent 64 bits integers
+// as strings
+func (v Value) Float() (float64, bool) {
+	if v.IsNumber {
+		return v.Number, true
+	}
+	f, err := strconv.ParseFloat(v.String, 64)
+	return f, err == nil
+}
+
+func numberValue(path Path, number float64, timestamp time.Time) Value {
+	return Value{
+		Path:      path,
+		String:    strconv.FormatFloat(number, 'f', -1, 64),
+		Number:    number,
+		IsNumber:  true,
+		Timestamp: timestamp,
+	}
+}
+
+func stringValue(path Path, s string, timestamp time.Time) Value {
+	return Value{
+		Path:      path,
+		String:    s,
+		Timestamp: timestamp,
+	}
+}
+
+// decodeValues returns the leaves of an update. JSON values of containers are flattened into a leaf per scalar
+// member, lists and leaf-lists are ignored since their entries can't be identified without the schema.
+func decodeValues(path Path, typedValue *gnmipb.TypedValue, timestamp time.Time) []Value {
+	switch v := typedValue.GetValue().(type) {
+	case *gnmipb.TypedValue_StringVal:
+		return []Value{stringValue(path, v.StringVal, timestamp)}
+	case *gnmipb.TypedValue_AsciiVal:
+		return []Value{stringValue(path, v.AsciiVal, timestamp)}
+	case *gnmipb.TypedValue_IntVal:
+		return []Value{numberValue(path, float64(v.IntVal), timestamp)}
+	case *gnmipb.TypedValue_UintVal:
+		return []Value{numberValue(path, float64(v.UintVal), timestamp)}
+	case *gnmipb.TypedValue_DoubleVal:
+		return []Value{numberValue(path, v.DoubleVal, timestamp)}
+	case *gnmipb.TypedValue_FloatVal: //nolint:staticcheck // still sent by targets implementing older gNMI versions
+		return []Value{numberValue(path, float64(v.FloatVal), timestamp)} //nolint:staticcheck
+	case *gnmipb.TypedValue_BoolVal:
+		value := numberValue(path, 0, timestamp)
+		if v.BoolVal {
+			value.Number = 1
+		}
+		value.String = strconv.FormatBool(v.BoolVal)
+		return []Value{value}
+	case *gnmipb.TypedValue_JsonVal:
+		return decodeJSON(path, v.JsonVal, timestamp)
+	case *gnmipb.TypedValue_JsonIetfVal:
+		return decodeJSON(path, v.JsonIetfVal, timestamp)
+	default:
+		return nil
+	}
+}
+
+func decodeJSON(path Path, data []byte, timestamp time.Time) []Value {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil
+	}
+	var values []Value
+	flattenJSON(path, value, timestamp, &values)
+	return values
+}
+
+func flattenJSON(path Path, value any, timestamp time.Time, values *[]Value) {
+	switch v := value.(type) {
+	case map[string]any:
+		for name, member := range v {
+			// JSON IETF qualifies the members defined in another module than their parent with the module name
+			if _, unqualified, ok := strings.Cut(name, ":"); ok {
+				name = unqualified
+			}
+			flattenJSON(path.Append(Path{{Name: name}}), member, timestamp, values)
+		}
+	case string:
+		*values = append(*values, stringValue(path, v, timestamp))
+	case float64:
+		*values = append(*values, numberValue(path, v, timestamp))
+	case bool:
+		value := numberValue(path, 0, timestamp)
+		if v {
+			value.Number = 1
+		}
+		value.String = strconv.FormatBool(v)
+		*values = append(*values, value)
+	}
+}
+
+// Cache holds the latest value of the leaves received from a target
+type Cache struct {
+	mu     sync.RWMutex
+	values map[string]Value
+}
+
+// NewCache returns an empty cache
+func NewCache() *Cache {
+	return &Cache{values: make(map[string]Value)}
+}
+
+// Update sets the values of leaves
+func (c *Cache) Update(values ...Value) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, value := range values {
+		c.values[value.Path.String()] = value
+	}
+}
+
+// delete removes the leaves under the path
+func (c *Cache) delete(path Path) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, value := range c.values {
+		if value.Path.HasPrefix(path) {
+			delete(c.values, key)
+		}
+	}
+}
+
+func (c *Cache) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.values)
+}
+
+// Get returns the value of a leaf
+func (c *Cache) Get(path Path) (Value, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	value, ok := c.values[path.String()]
+	return value, ok
+}
+
+// Match returns the values of the leaves matching the pattern, sorted by path
+func (c *Cache) Match(pattern Path) []Value {
+	c.mu.RLock()
+	var values []Value
+	for _, value := range c.values {
+		if value.Path.Match(pattern) {
+			values = append(values, value)
+		}
+	}
+	c.mu.RUnlock()
+
+	slices.SortFunc(values, func(a, b Value) int {
+		return strings.Compare(a.Path.String(), b.Path.String())
+	})
+	return values
+}
+
+// Len returns the number of leaves in the cache
+func (c *Cache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.values)
+}
@@ -0,0 +1,305 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+// Package client implements a gNMI client streaming the telemetry of a target into a cache
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+const (
+	minReconnectDelay = 1 * time.Second
+	maxReconnectDelay = 1 * time.Minute
+)
+
+// SubscriptionMode is the mode a path is streamed with
+type SubscriptionMode string
+
+const (
+	// SubscriptionModeSample streams the values at a fixed interval
+	SubscriptionModeSample SubscriptionMode = "sample"
+	// SubscriptionModeOnChange streams the values when they change
+	SubscriptionModeOnChange SubscriptionMode = "on_change"
+	// SubscriptionModeTargetDefined lets the target choose the mode of each leaf
+	SubscriptionModeTargetDefined SubscriptionMode = "target_defined"
+)
+
+var subscriptionModes = map[SubscriptionMode]gnmipb.SubscriptionMode{
+	SubscriptionModeSample:        gnmipb.SubscriptionMode_SAMPLE,
+	SubscriptionModeOnChange:      gnmipb.SubscriptionMode_ON_CHANGE,
+	SubscriptionModeTargetDefined: gnmipb.SubscriptionMode_TARGET_DEFINED,
+}
+
+// Subscription is a path streamed from the target
+type Subscription struct {
+	Path           Path
+	Mode           SubscriptionMode
+	SampleInterval time.Duration
+}
+
+// TLSConfig holds the TLS settings of the connection to a target
+type TLSConfig struct {
+	// Plaintext disables TLS, it should only be used in labs
+	Plaintext          bool
+	InsecureSkipVerify bool
+	CAFile             string
+	CertFile           string
+	KeyFile            string
+	ServerName         string
+}
+
+// Config holds the connection and subscription settings of a target
+type Config struct {
+	// Target is the address of the target, as host:port
+	Target   string
+	Username string
+	Password string
+	TLS      TLSConfig
+	// Encoding is the encoding requested for the values: proto, json, json_ietf or ascii
+	Encoding      string
+	Subscriptions []Subscription
+}
+
+// ParseEncoding returns the gNMI encoding for its name
+func ParseEncoding(name string) (gnmipb.Encoding, error) {
+	encoding, ok := map[string]gnmipb.Encoding{
+		"proto":     gnmipb.Encoding_PROTO,
+		"json":      gnmipb.Encoding_JSON,
+		"json_ietf": gnmipb.Encoding_JSON_IETF,
+		"ascii":     gnmipb.Encoding_ASCII,
+	}[name]
+	if !ok {
+		return 0, fmt.Errorf("invalid encoding %q, expected proto, json, json_ietf or ascii", name)
+	}
+	return encoding, nil
+}
+
+// Client streams the telemetry of a target into a cache. It reconnects when the subscription fails.
+type Client struct {
+	config               Config
+	encoding             gnmipb.Encoding
+	transportCredentials credentials.TransportCredentials
+	cache                *Cache
+
+	connected *atomic.Bool
+	lastErrMu sync.Mutex
+	lastErr   error
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewClient returns a client for the target, Start must be called to start streaming
+func NewClient(config Config) (*Client, error) {
+	encoding, err := ParseEncoding(config.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	if len(config.Subscriptions) == 0 {
+		return nil, errors.New("no path to subscribe to")
+	}
+	for _, subscription := range config.Subscriptions {
+		if _, ok := subscriptionModes[subscription.Mode]; !ok {
+			return nil, fmt.Errorf("invalid subscription mode %q for %s", subscription.Mode, subscription.Path)
+		}
+	}
+	transportCredentials, err := buildTransportCredentials(config.TLS)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		config:               config,
+		encoding:             encoding,
+		transportCredentials: transportCredentials,
+		cache:                NewCache(),
+		connected:            atomic.NewBool(false),
+	}, nil
+}
+
+// Cache returns the cache the telemetry is streamed into
+func (c *Client) Cache() *Cache {
+	return c.cache
+}
+
+// Connected returns true while the subscription is established
+func (c *Client) Connected() bool {
+	return c.connected.Load()
+}
+
+// LastError returns the error the last subscription failed with
+func (c *Client) LastError() error {
+	c.lastErrMu.Lock()
+	defer c.lastErrMu.Unlock()
+	return c.lastErr
+}
+
+// Start starts streaming in the background
+func (c *Client) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.done = make(chan struct{})
+	go c.run(ctx)
+}
+
+// Stop stops streaming
+func (c *Client) Stop() {
+	if c.cancel == nil {
+		return
+	}
+	c.cancel()
+	<-c.done
+}
+
+func (c *Client) run(ctx context.Context) {
+	defer close(c.done)
+
+	delay := minReconnectDelay
+	for {
+		synced, err := c.subscribe(ctx)
+		c.connected.Store(false)
+		if ctx.Err() != nil {
+			return
+		}
+
+		c.lastErrMu.Lock()
+		c.lastErr = err
+		c.lastErrMu.Unlock()
+		if synced {
+			delay = minReconnectDelay
+		}
+		log.Warnf("gNMI subscription to %s failed, reconnecting in %s: %s", c.config.Target, delay, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(2*delay, maxReconnectDelay)
+	}
+}
+
+// subscribe streams the telemetry until the subscription fails. It returns true if the target sent all the
+// current values before failing.
+func (c *Client) subscribe(ctx context.Context) (bool, error) {
+	conn, err := grpc.NewClient(c.config.Target, grpc.WithTransportCredentials(c.transportCredentials))
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if c.config.Username != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "username", c.config.Username, "password", c.config.Password)
+	}
+
+	stream, err := gnmipb.NewGNMIClient(conn).Subscribe(ctx)
+	if err != nil {
+		return false, fmt.Errorf("unable to subscribe: %w", err)
+	}
+	if err := stream.Send(c.buildSubscribeRequest()); err != nil {
+		return false, fmt.Errorf("unable to send the subscription: %w", err)
+	}
+
+	// the values of the previous subscription may have been deleted in between
+	c.cache.reset()
+	synced := false
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return synced, err
+		}
+		if !c.connected.Load() {
+			log.Infof("gNMI subscription to %s established", c.config.Target)
+			c.connected.Store(true)
+		}
+
+		switch r := resp.GetResponse().(type) {
+		case *gnmipb.SubscribeResponse_Update:
+			c.handleNotification(r.Update)
+		case *gnmipb.SubscribeResponse_SyncResponse:
+			synced = true
+			log.Debugf("gNMI target %s sent the current values, %d leaves cached", c.config.Target, c.cache.Len())
+		}
+	}
+}
+
+func (c *Client) buildSubscribeRequest() *gnmipb.SubscribeRequest {
+	subscriptions := make([]*gnmipb.Subscription, 0, len(c.config.Subscriptions))
+	for _, subscription := range c.config.Subscriptions {
+		subscriptions = append(subscriptions, &gnmipb.Subscription{
+			Path:           subscription.Path.toProto(),
+			Mode:           subscriptionModes[subscription.Mode],
+			SampleInterval: uint64(subscription.SampleInterval.Nanoseconds()),
+		})
+	}
+	return &gnmipb.SubscribeRequest{
+		Request: &gnmipb.SubscribeRequest_Subscribe{
+			Subscribe: &gnmipb.SubscriptionList{
+				Subscription: subscriptions,
+				Mode:         gnmipb.SubscriptionList_STREAM,
+				Encoding:     c.encoding,
+			},
+		},
+	}
+}
+
+func (c *Client) handleNotification(notification *gnmipb.Notification) {
+	timestamp := time.Unix(0, notification.GetTimestamp())
+	for _, deleted := range notification.GetDelete() {
+		c.cache.delete(pathFromProto(notification.GetPrefix(), deleted))
+	}
+	for _, update := range notification.GetUpdate() {
+		path := pathFromProto(notification.GetPrefix(), update.GetPath())
+		c.cache.Update(decodeValues(path, update.GetVal(), timestamp)...)
+	}
+}
+
+func buildTransportCredentials(config TLSConfig) (credentials.TransportCredentials, error) {
+	if config.Plaintext {
+		return insecure.NewCredentials(), nil
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: config.InsecureSkipVerify,
+		ServerName:         config.ServerName,
+		MinVersion:         tls.VersionTLS12,
+	}
+	if config.CAFile != "" {
+		caCert, err := os.ReadFile(config.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the CA file: %w", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no certificate found in the CA file %s", config.CAFile)
+		}
+	}
+	if config.CertFile != "" || config.KeyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load the client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
@@ -0,0 +1,215 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package client
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// fakeTarget is a gNMI target sending a fixed set of notifications to the subscribers
+type fakeTarget struct {
+	gnmipb.UnimplementedGNMIServer
+	notifications []*gnmipb.Notification
+	requests      chan *gnmipb.SubscribeRequest
+	credentials   chan []string
+}
+
+func (f *fakeTarget) Subscribe(stream gnmipb.GNMI_SubscribeServer) error {
+	request, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	f.credentials <- append(md.Get("username"), md.Get("password")...)
+	f.requests <- request
+
+	for _, notification := range f.notifications {
+		if err := stream.Send(&gnmipb.SubscribeResponse{Response: &gnmipb.SubscribeResponse_Update{Update: notification}}); err != nil {
+			return err
+		}
+	}
+	if err := stream.Send(&gnmipb.SubscribeResponse{Response: &gnmipb.SubscribeResponse_SyncResponse{SyncResponse: true}}); err != nil {
+		return err
+	}
+	<-stream.Context().Done()
+	return nil
+}
+
+func startFakeTarget(t *testing.T, target *fakeTarget) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	gnmipb.RegisterGNMIServer(server, target)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+func protoPath(t *testing.T, s string) *gnmipb.Path {
+	path, err := ParsePath(s)
+	require.NoError(t, err)
+	return path.toProto()
+}
+
+func TestClientSubscribe(t *testing.T) {
+	target := &fakeTarget{
+		requests:    make(chan *gnmipb.SubscribeRequest, 1),
+		credentials: make(chan []string, 1),
+		notifications: []*gnmipb.Notification{
+			{
+				Timestamp: time.Unix(1700000000, 0).UnixNano(),
+				Prefix:    protoPath(t, "/interfaces/interface[name=Ethernet1]/state"),
+				Update: []*gnmipb.Update{
+					{
+						Path: protoPath(t, "/counters/in-octets"),
+						Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: 1234}},
+					},
+					{
+						Path: protoPath(t, "/oper-status"),
+						Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "UP"}},
+					},
+				},
+			},
+			{
+				Prefix: protoPath(t, "/system"),
+				Update: []*gnmipb.Update{
+					{
+						Path: protoPath(t, "/state"),
+						Val:  &gnmipb.TypedValue{Value: &gnmipb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{"openconfig-system:hostname":"router1","boot-time":"1700000000","up":true}`)}},
+					},
+				},
+			},
+			{
+				Delete: []*gnmipb.Path{protoPath(t, "/interfaces/interface[name=Ethernet1]/state/oper-status")},
+			},
+		},
+	}
+	address := startFakeTarget(t, target)
+
+	subscriptionPath, err := ParsePath("/interfaces/interface/state/counters")
+	require.NoError(t, err)
+	c, err := NewClient(Config{
+		Target:   address,
+		Username: "admin",
+		Password: "secret",
+		TLS:      TLSConfig{Plaintext: true},
+		Encoding: "json_ietf",
+		Subscriptions: []Subscription{
+			{Path: subscriptionPath, Mode: SubscriptionModeSample, SampleInterval: 10 * time.Second},
+		},
+	})
+	require.NoError(t, err)
+	assert.False(t, c.Connected())
+	c.Start()
+	defer c.Stop()
+
+	select {
+	case request := <-target.requests:
+		subscriptionList := request.GetSubscribe()
+		require.NotNil(t, subscriptionList)
+		assert.Equal(t, gnmipb.SubscriptionList_STREAM, subscriptionList.GetMode())
+		assert.Equal(t, gnmipb.Encoding_JSON_IETF, subscriptionList.GetEncoding())
+		require.Len(t, subscriptionList.GetSubscription(), 1)
+		subscription := subscriptionList.GetSubscription()[0]
+		assert.Equal(t, gnmipb.SubscriptionMode_SAMPLE, subscription.GetMode())
+		assert.Equal(t, uint64(10*time.Second), subscription.GetSampleInterval())
+		assert.Equal(t, "/interfaces/interface/state/counters", pathFromProto(nil, subscription.GetPath()).String())
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no subscription received")
+	}
+	assert.Equal(t, []string{"admin", "secret"}, <-target.credentials)
+
+	require.Eventually(t, func() bool { return c.Connected() && c.Cache().Len() == 4 }, 5*time.Second, 10*time.Millisecond)
+
+	inOctets, err := ParsePath("/interfaces/interface[name=Ethernet1]/state/counters/in-octets")
+	require.NoError(t, err)
+	value, ok := c.Cache().Get(inOctets)
+	require.True(t, ok)
+	assert.True(t, value.IsNumber)
+	assert.Equal(t, float64(1234), value.Number)
+	assert.Equal(t, time.Unix(1700000000, 0), value.Timestamp)
+
+	pattern, err := ParsePath("/system/state/*")
+	require.NoError(t, err)
+	values := c.Cache().Match(pattern)
+	require.Len(t, values, 3)
+	assert.Equal(t, "/system/state/boot-time", values[0].Path.String())
+	bootTime, ok := values[0].Float()
+	assert.True(t, ok)
+	assert.Equal(t, float64(1700000000), bootTime)
+	assert.Equal(t, "/system/state/hostname", values[1].Path.String())
+	assert.Equal(t, "router1", values[1].String)
+	assert.Equal(t, "/system/state/up", values[2].Path.String())
+	assert.Equal(t, float64(1), values[2].Number)
+	assert.Equal(t, "true", values[2].String)
+
+	c.Stop()
+	assert.False(t, c.Connected())
+}
+
+func TestClientUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	listener.Close()
+
+	c, err := NewClient(Config{
+		Target:        address,
+		TLS:           TLSConfig{Plaintext: true},
+		Encoding:      "proto",
+		Subscriptions: []Subscription{{Path: Path{{Name: "system"}}, Mode: SubscriptionModeTargetDefined}},
+	})
+	require.NoError(t, err)
+	c.Start()
+	defer c.Stop()
+
+	require.Eventually(t, func() bool { return c.LastError() != nil }, 5*time.Second, 10*time.Millisecond)
+	assert.False(t, c.Connected())
+}
+
+func TestNewClientErrors(t *testing.T) {
+	subscriptions := []Subscription{{Path: Path{{Name: "system"}}, Mode: SubscriptionModeOnChange}}
+	tests := []struct {
+		name          string
+		config        Config
+		expectedError string
+	}{
+		{
+			name:          "invalid encoding",
+			config:        Config{Encoding: "xml", Subscriptions: subscriptions},
+			expectedError: `invalid encoding "xml"`,
+		},
+		{
+			name:          "no subscription",
+			config:        Config{Encoding: "proto"},
+			expectedError: "no path to subscribe to",
+		},
+		{
+			name:          "invalid mode",
+			config:        Config{Encoding: "proto", Subscriptions: []Subscription{{Path: Path{{Name: "system"}}, Mode: "poll"}}},
+			expectedError: `invalid subscription mode "poll" for /system`,
+		},
+		{
+			name:          "missing CA file",
+			config:        Config{Encoding: "proto", Subscriptions: subscriptions, TLS: TLSConfig{CAFile: "/does/not/exist"}},
+			expectedError: "unable to read the CA file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClient(tt.config)
+			assert.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,196 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// Wildcard matches any element name or key value
+const Wildcard = "*"
+
+// PathElem is an element of a gNMI path, with the keys of the list entry it identifies
+type PathElem struct {
+	Name string
+	Keys map[string]string
+}
+
+// Path is a gNMI path, like /interfaces/interface[name=Ethernet1/1]/state/counters
+type Path []PathElem
+
+// ParsePath parses a path in the gNMI path conventions string format. Key values can contain `/`, while `]` and `\`
+// must be escaped with a `\`.
+func ParsePath(s string) (Path, error) {
+	s = strings.TrimPrefix(s, "/")
+	if s == "" {
+		return nil, nil
+	}
+
+	segments, err := splitPath(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path %q: %w", s, err)
+	}
+	path := make(Path, 0, len(segments))
+	for _, segment := range segments {
+		elem, err := parseElem(segment)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path %q: %w", s, err)
+		}
+		path = append(path, elem)
+	}
+	return path, nil
+}
+
+func splitPath(s string) ([]string, error) {
+	var segments []string
+	start, inKey := 0, false
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '[':
+			inKey = true
+		case ']':
+			inKey = false
+		case '/':
+			if !inKey {
+				segments = append(segments, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if inKey {
+		return nil, errors.New("unterminated key")
+	}
+	return append(segments, s[start:]), nil
+}
+
+func parseElem(segment string) (PathElem, error) {
+	name, keys, _ := strings.Cut(segment, "[")
+	if name == "" {
+		return PathElem{}, errors.New("empty element name")
+	}
+	elem := PathElem{Name: name}
+	if keys == "" {
+		return elem, nil
+	}
+
+	elem.Keys = make(map[string]string)
+	rest := "[" + keys
+	for rest != "" {
+		if rest[0] != '[' {
+			return PathElem{}, fmt.Errorf("unexpected %q after the keys of %s", rest, name)
+		}
+		var value strings.Builder
+		end := -1
+		for i := 1; i < len(rest) && end < 0; i++ {
+			switch rest[i] {
+			case '\\':
+				if i+1 < len(rest) {
+					i++
+					value.WriteByte(rest[i])
+				}
+			case ']':
+				end = i
+			default:
+				value.WriteByte(rest[i])
+			}
+		}
+		if end < 0 {
+			return PathElem{}, fmt.Errorf("unterminated key in %s", name)
+		}
+		key, keyValue, ok := strings.Cut(value.String(), "=")
+		if !ok || key == "" {
+			return PathElem{}, fmt.Errorf("invalid key %q in %s, expected [key=value]", value.String(), name)
+		}
+		elem.Keys[key] = keyValue
+		rest = rest[end+1:]
+	}
+	return elem, nil
+}
+
+// String returns the path in the gNMI path conventions string format, with sorted keys
+func (p Path) String() string {
+	if len(p) == 0 {
+		return "/"
+	}
+	var b strings.Builder
+	for _, elem := range p {
+		b.WriteByte('/')
+		b.WriteString(elem.Name)
+		for _, key := range slices.Sorted(maps.Keys(elem.Keys)) {
+			b.WriteByte('[')
+			b.WriteString(key)
+			b.WriteByte('=')
+			b.WriteString(escapeKeyValue(elem.Keys[key]))
+			b.WriteByte(']')
+		}
+	}
+	return b.String()
+}
+
+var keyValueEscaper = strings.NewReplacer(`\`, `\\`, `]`, `\]`)
+
+func escapeKeyValue(value string) string {
+	return keyValueEscaper.Replace(value)
+}
+
+// Append returns a new path made of the path followed by the elements of other
+func (p Path) Append(other Path) Path {
+	path := make(Path, 0, len(p)+len(other))
+	return append(append(path, p...), other...)
+}
+
+// Match returns true if the path is an instance of the pattern. The pattern must have the same number of elements;
+// pattern elements can use the wildcard as name, and only constrain the keys they have, a wildcard key value
+// matching any value.
+func (p Path) Match(pattern Path) bool {
+	if len(p) != len(pattern) {
+		return false
+	}
+	for i, elem := range pattern {
+		if elem.Name != Wildcard && elem.Name != p[i].Name {
+			return false
+		}
+		for key, value := range elem.Keys {
+			actual, ok := p[i].Keys[key]
+			if !ok || (value != Wildcard && value != actual) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// HasPrefix returns true if the path starts with the elements of prefix
+func (p Path) HasPrefix(prefix Path) bool {
+	return len(p) >= len(prefix) && p[:len(prefix)].Match(prefix)
+}
+
+// pathFromProto returns the path of an update, made of the notification prefix followed by the update path
+func pathFromProto(prefix *gnmipb.Path, path *gnmipb.Path) Path {
+	elems := append(slices.Clone(prefix.GetElem()), path.GetElem()...)
+	p := make(Path, 0, len(elems))
+	for _, elem := range elems {
+		p = append(p, PathElem{Name: elem.GetName(), Keys: elem.GetKey()})
+	}
+	return p
+}
+
+// toProto returns the gNMI protobuf representation of the path
+func (p Path) toProto() *gnmipb.Path {
+	elems := make([]*gnmipb.PathElem, 0, len(p))
+	for _, elem := range p {
+		elems = append(elems, &gnmipb.PathElem{Name: elem.Name, Key: elem.Keys})
+	}
+	return &gnmipb.Path{Elem: elems}
+}
@@ -0,0 +1,137 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		name          string
+		path          string
+		expectedPath  Path
+		expectedError string
+	}{
+		{
+			name:         "root",
+			path:         "/",
+			expectedPath: nil,
+		},
+		{
+			name: "elements",
+			path: "/system/state/hostname",
+			expectedPath: Path{
+				{Name: "system"},
+				{Name: "state"},
+				{Name: "hostname"},
+			},
+		},
+		{
+			name: "without leading slash",
+			path: "system/state",
+			expectedPath: Path{
+				{Name: "system"},
+				{Name: "state"},
+			},
+		},
+		{
+			name: "keys",
+			path: "/network-instances/network-instance[name=default]/protocols/protocol[identifier=BGP][name=bgp]",
+			expectedPath: Path{
+				{Name: "network-instances"},
+				{Name: "network-instance", Keys: map[string]string{"name": "default"}},
+				{Name: "protocols"},
+				{Name: "protocol", Keys: map[string]string{"identifier": "BGP", "name": "bgp"}},
+			},
+		},
+		{
+			name: "key with slash and escapes",
+			path: `/interfaces/interface[name=Ethernet1/1\]\\]/state`,
+			expectedPath: Path{
+				{Name: "interfaces"},
+				{Name: "interface", Keys: map[string]string{"name": `Ethernet1/1]\`}},
+				{Name: "state"},
+			},
+		},
+		{
+			name:          "unterminated key",
+			path:          "/interfaces/interface[name=Ethernet1",
+			expectedError: "unterminated key",
+		},
+		{
+			name:          "empty element",
+			path:          "/interfaces//state",
+			expectedError: "empty element name",
+		},
+		{
+			name:          "key without value",
+			path:          "/interfaces/interface[name]",
+			expectedError: "invalid key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := ParsePath(tt.path)
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedPath, path)
+		})
+	}
+}
+
+func TestPathString(t *testing.T) {
+	for _, s := range []string{
+		"/",
+		"/system/state/hostname",
+		"/protocols/protocol[identifier=BGP][name=bgp]/state",
+		`/interfaces/interface[name=Ethernet1/1\]\\]/state`,
+	} {
+		path, err := ParsePath(s)
+		require.NoError(t, err)
+		assert.Equal(t, s, path.String())
+	}
+
+	path := Path{{Name: "protocol", Keys: map[string]string{"name": "bgp", "identifier": "BGP"}}}
+	assert.Equal(t, "/protocol[identifier=BGP][name=bgp]", path.String())
+}
+
+func TestPathMatch(t *testing.T) {
+	mustParse := func(s string) Path {
+		path, err := ParsePath(s)
+		require.NoError(t, err)
+		return path
+	}
+	path := mustParse("/interfaces/interface[name=Ethernet1]/state/counters/in-octets")
+
+	tests := []struct {
+		pattern string
+		match   bool
+	}{
+		{"/interfaces/interface[name=Ethernet1]/state/counters/in-octets", true},
+		{"/interfaces/interface/state/counters/in-octets", true},
+		{"/interfaces/interface[name=*]/state/counters/in-octets", true},
+		{"/interfaces/interface/state/counters/*", true},
+		{"/interfaces/interface[name=Ethernet2]/state/counters/in-octets", false},
+		{"/interfaces/interface[index=*]/state/counters/in-octets", false},
+		{"/interfaces/interface/state/counters", false},
+		{"/interfaces/interface/state/counters/out-octets", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.match, path.Match(mustParse(tt.pattern)), tt.pattern)
+	}
+
+	assert.True(t, path.HasPrefix(mustParse("/interfaces/interface")))
+	assert.True(t, path.HasPrefix(mustParse("/interfaces/interface[name=Ethernet1]/state")))
+	assert.False(t, path.HasPrefix(mustParse("/interfaces/interface[name=Ethernet2]")))
+	assert.False(t, path.HasPrefix(mustParse("/system")))
+}
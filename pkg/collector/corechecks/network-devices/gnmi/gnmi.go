@@ -0,0 +1,210 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+// Package gnmi implements NDM gNMI streaming telemetry corecheck
+package gnmi
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	yaml "go.yaml.in/yaml/v2"
+
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/integration"
+	"github.com/DataDog/datadog-agent/pkg/aggregator/sender"
+	"github.com/DataDog/datadog-agent/pkg/collector/check"
+	core "github.com/DataDog/datadog-agent/pkg/collector/corechecks"
+	"github.com/DataDog/datadog-agent/pkg/collector/corechecks/network-devices/gnmi/client"
+	"github.com/DataDog/datadog-agent/pkg/collector/corechecks/network-devices/gnmi/profile"
+	"github.com/DataDog/datadog-agent/pkg/collector/corechecks/network-devices/gnmi/report"
+	pkgconfigsetup "github.com/DataDog/datadog-agent/pkg/config/setup"
+	"github.com/DataDog/datadog-agent/pkg/snmp/utils"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+	"github.com/DataDog/datadog-agent/pkg/util/option"
+)
+
+const (
+	// CheckName is the name of the check
+	CheckName             = "gnmi"
+	defaultCheckInterval  = 15 * time.Second
+	defaultPort           = 9339
+	defaultEncoding       = "proto"
+	defaultSampleInterval = 10
+	userProfilesFolder    = "profiles"
+)
+
+// Configuration for the gNMI check
+type checkCfg struct {
+	IPAddress             string   `yaml:"ip_address"`
+	Port                  int      `yaml:"port"`
+	Username              string   `yaml:"username"`
+	Password              string   `yaml:"password"`
+	Plaintext             bool     `yaml:"plaintext"`
+	Insecure              bool     `yaml:"insecure"`
+	CAFile                string   `yaml:"ca_file"`
+	CertFile              string   `yaml:"cert_file"`
+	KeyFile               string   `yaml:"key_file"`
+	ServerName            string   `yaml:"server_name"`
+	Encoding              string   `yaml:"encoding"`
+	SampleInterval        int      `yaml:"sample_interval"`
+	Profile               string   `yaml:"profile"`
+	Namespace             string   `yaml:"namespace"`
+	Tags                  []string `yaml:"tags"`
+	SendMetadata          *bool    `yaml:"send_metadata"`
+	MinCollectionInterval int      `yaml:"min_collection_interval"`
+}
+
+// GNMICheck streams the telemetry of a gNMI target and reports it at each run
+type GNMICheck struct {
+	core.CheckBase
+	interval time.Duration
+	config   checkCfg
+	client   *client.Client
+	started  bool
+	sender   *report.Sender
+}
+
+// Run reports the telemetry streamed since the previous run
+func (c *GNMICheck) Run() error {
+	// the subscription outlives the runs, it is started once the check is scheduled
+	if !c.started {
+		c.client.Start()
+		c.started = true
+	}
+
+	cache := c.client.Cache()
+	connected := c.client.Connected()
+	c.sender.ReportMetrics(cache, connected)
+	if *c.config.SendMetadata {
+		c.sender.ReportMetadata(cache, connected, time.Now())
+	}
+	c.sender.Commit()
+
+	if !connected {
+		if err := c.client.LastError(); err != nil {
+			return fmt.Errorf("gNMI target %s is unreachable: %w", c.config.IPAddress, err)
+		}
+	}
+	return nil
+}
+
+// Cancel stops the subscription
+func (c *GNMICheck) Cancel() {
+	if c.client != nil {
+		c.client.Stop()
+	}
+	c.CheckBase.Cancel()
+}
+
+// Configure the gNMI check
+func (c *GNMICheck) Configure(senderManager sender.SenderManager, integrationConfigDigest uint64, rawInstance integration.Data, rawInitConfig integration.Data, source string, provider string) error {
+	// Must be called before c.CommonConfigure
+	c.BuildID(integrationConfigDigest, rawInstance, rawInitConfig)
+
+	err := c.CommonConfigure(senderManager, rawInitConfig, rawInstance, source, provider)
+	if err != nil {
+		return err
+	}
+
+	sender, err := c.GetSender()
+	if err != nil {
+		return err
+	}
+
+	instanceConfig := checkCfg{
+		Port:           defaultPort,
+		Encoding:       defaultEncoding,
+		SampleInterval: defaultSampleInterval,
+		Profile:        profile.DefaultProfile,
+		SendMetadata:   boolPointer(true),
+	}
+	err = yaml.Unmarshal(rawInstance, &instanceConfig)
+	if err != nil {
+		return err
+	}
+	c.config = instanceConfig
+
+	if c.config.IPAddress == "" {
+		return errors.New("ip_address is required")
+	}
+
+	if c.config.Namespace == "" {
+		c.config.Namespace = "default"
+	} else {
+		namespace, err := utils.NormalizeNamespace(c.config.Namespace)
+		if err != nil {
+			return err
+		}
+		c.config.Namespace = namespace
+	}
+
+	if c.config.MinCollectionInterval != 0 {
+		c.interval = time.Second * time.Duration(c.config.MinCollectionInterval)
+	}
+
+	userProfilesDir := filepath.Join(pkgconfigsetup.Datadog().GetString("confd_path"), CheckName+".d", userProfilesFolder)
+	definition, err := profile.Load(c.config.Profile, userProfilesDir)
+	if err != nil {
+		return err
+	}
+
+	c.client, err = client.NewClient(client.Config{
+		Target:   net.JoinHostPort(c.config.IPAddress, strconv.Itoa(c.config.Port)),
+		Username: c.config.Username,
+		Password: c.config.Password,
+		TLS: client.TLSConfig{
+			Plaintext:          c.config.Plaintext,
+			InsecureSkipVerify: c.config.Insecure,
+			CAFile:             c.config.CAFile,
+			CertFile:           c.config.CertFile,
+			KeyFile:            c.config.KeyFile,
+			ServerName:         c.config.ServerName,
+		},
+		Encoding:      c.config.Encoding,
+		Subscriptions: definition.Subscriptions(time.Duration(c.config.SampleInterval) * time.Second),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating gNMI client: %w", err)
+	}
+	log.Debugf("gNMI check configured for %s with profile %s", c.config.IPAddress, definition.Name)
+
+	c.sender = report.NewSender(sender, report.Device{
+		Namespace: c.config.Namespace,
+		IPAddress: c.config.IPAddress,
+		Tags:      c.config.Tags,
+	}, definition)
+
+	return nil
+}
+
+// Interval returns the scheduling time for the check
+func (c *GNMICheck) Interval() time.Duration {
+	return c.interval
+}
+
+// IsHASupported returns true if the check supports HA
+func (c *GNMICheck) IsHASupported() bool {
+	return true
+}
+
+func boolPointer(b bool) *bool {
+	return &b
+}
+
+// Factory creates a new check factory
+func Factory() option.Option[func() check.Check] {
+	return option.New(newCheck)
+}
+
+func newCheck() check.Check {
+	return &GNMICheck{
+		CheckBase: core.NewCheckBase(CheckName),
+		interval:  defaultCheckInterval,
+	}
+}
@@ -0,0 +1,254 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+// Package profile defines how the paths streamed from gNMI targets are mapped to metrics, tags and metadata.
+// Profiles are analogous to the SNMP profile definitions, with gNMI paths in place of OIDs.
+package profile
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	yaml "go.yaml.in/yaml/v2"
+
+	"github.com/DataDog/datadog-agent/pkg/collector/corechecks/network-devices/gnmi/client"
+	"github.com/DataDog/datadog-agent/pkg/networkdevice/profile/profiledefinition"
+)
+
+//go:embed default_profiles/*.yaml
+var defaultProfiles embed.FS
+
+// DefaultProfile is the profile used when none is configured
+const DefaultProfile = "openconfig"
+
+// DeviceFields are the device metadata fields that can be set by profiles
+var DeviceFields = []string{"name", "description", "vendor", "model", "serial_number", "version", "os_name", "os_version", "os_hostname", "location", "product_name"}
+
+// InterfaceFields are the interface metadata fields that can be set by profiles
+var InterfaceFields = []string{"description", "alias", "admin_status", "oper_status", "index", "mac_address"}
+
+// Definition defines the paths subscribed from a target and how their values are reported
+type Definition struct {
+	Name     string          `yaml:"-"`
+	Metadata MetadataConfig  `yaml:"metadata,omitempty"`
+	Metrics  []MetricsConfig `yaml:"metrics,omitempty"`
+}
+
+// MetricsConfig defines the metrics reported from the leaves under a path
+type MetricsConfig struct {
+	// Path is the path of the entity the metrics are about, like /interfaces/interface
+	Path       string            `yaml:"path"`
+	Symbols    []SymbolConfig    `yaml:"symbols"`
+	MetricTags []MetricTagConfig `yaml:"metric_tags,omitempty"`
+	StaticTags []string          `yaml:"static_tags,omitempty"`
+
+	ParsedPath client.Path `yaml:"-"`
+}
+
+// SymbolConfig defines a metric reported from a leaf
+type SymbolConfig struct {
+	// Path is the path of the leaf, relative to the metrics path
+	Path string `yaml:"path"`
+	Name string `yaml:"name"`
+	// MetricType is the type of the metric: gauge (the default), rate, monotonic_count or monotonic_count_and_rate
+	MetricType  profiledefinition.ProfileMetricType `yaml:"metric_type,omitempty"`
+	ScaleFactor float64                             `yaml:"scale_factor,omitempty"`
+	// Mapping maps the values of enumeration leaves to metric values
+	Mapping map[string]float64 `yaml:"mapping,omitempty"`
+
+	// FullPath is the path of the leaf, with the metrics path
+	FullPath client.Path `yaml:"-"`
+}
+
+// MetricTagConfig defines a tag of the metrics, from a key of the metrics path or from a leaf
+type MetricTagConfig struct {
+	Tag string `yaml:"tag"`
+	// Key is the name of the key of the metrics path the tag value is read from, from the deepest element having
+	// this key, or from Element if set
+	Key     string `yaml:"key,omitempty"`
+	Element string `yaml:"element,omitempty"`
+	// Path is the path of the leaf the tag value is read from, relative to the metrics path
+	Path    string            `yaml:"path,omitempty"`
+	Mapping map[string]string `yaml:"mapping,omitempty"`
+
+	ParsedPath client.Path `yaml:"-"`
+}
+
+// MetadataConfig defines the device and interface metadata
+type MetadataConfig struct {
+	Device    DeviceMetadataConfig    `yaml:"device,omitempty"`
+	Interface InterfaceMetadataConfig `yaml:"interface,omitempty"`
+}
+
+// DeviceMetadataConfig defines the device metadata fields
+type DeviceMetadataConfig struct {
+	Fields map[string]FieldConfig `yaml:"fields,omitempty"`
+}
+
+// InterfaceMetadataConfig defines the interface metadata, from the entries of a list
+type InterfaceMetadataConfig struct {
+	// Path is the path of the interfaces list, like /interfaces/interface
+	Path string `yaml:"path,omitempty"`
+	// Key is the key of the list holding the interface name
+	Key    string                 `yaml:"key,omitempty"`
+	Fields map[string]FieldConfig `yaml:"fields,omitempty"`
+
+	ParsedPath client.Path `yaml:"-"`
+}
+
+// FieldConfig defines a metadata field, from a leaf or a constant value
+type FieldConfig struct {
+	// Path is the path of the leaf, absolute for devices and relative to the list entry for interfaces
+	Path  string `yaml:"path,omitempty"`
+	Value string `yaml:"value,omitempty"`
+
+	ParsedPath client.Path `yaml:"-"`
+}
+
+// Load loads a profile from the user profiles directory, falling back to the profiles shipped with the agent
+func Load(name string, userProfilesDir string) (*Definition, error) {
+	var data []byte
+	err := os.ErrNotExist
+	if userProfilesDir != "" {
+		data, err = os.ReadFile(filepath.Join(userProfilesDir, name+".yaml"))
+	}
+	if errors.Is(err, os.ErrNotExist) {
+		data, err = defaultProfiles.ReadFile("default_profiles/" + name + ".yaml")
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("unknown profile %q", name)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read profile %q: %w", name, err)
+	}
+
+	var definition Definition
+	if err := yaml.UnmarshalStrict(data, &definition); err != nil {
+		return nil, fmt.Errorf("unable to parse profile %q: %w", name, err)
+	}
+	definition.Name = name
+	if err := definition.parse(); err != nil {
+		return nil, fmt.Errorf("invalid profile %q: %w", name, err)
+	}
+	return &definition, nil
+}
+
+// parse validates the definition and parses its paths
+func (d *Definition) parse() error {
+	var errs []error
+	parsePath := func(path string, required bool, what string) client.Path {
+		if path == "" {
+			if required {
+				errs = append(errs, fmt.Errorf("%s: missing path", what))
+			}
+			return nil
+		}
+		parsed, err := client.ParsePath(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", what, err))
+		}
+		return parsed
+	}
+
+	for i := range d.Metrics {
+		metric := &d.Metrics[i]
+		metric.ParsedPath = parsePath(metric.Path, true, "metrics")
+		if len(metric.Symbols) == 0 {
+			errs = append(errs, fmt.Errorf("metrics %s: no symbol", metric.Path))
+		}
+		for j := range metric.Symbols {
+			symbol := &metric.Symbols[j]
+			symbol.FullPath = metric.ParsedPath.Append(parsePath(symbol.Path, true, "symbol "+symbol.Name))
+			if symbol.Name == "" {
+				errs = append(errs, fmt.Errorf("metrics %s: symbol %s has no name", metric.Path, symbol.Path))
+			}
+			switch symbol.MetricType {
+			case "", profiledefinition.ProfileMetricTypeGauge, profiledefinition.ProfileMetricTypeRate,
+				profiledefinition.ProfileMetricTypeMonotonicCount, profiledefinition.ProfileMetricTypeMonotonicCountAndRate:
+			default:
+				errs = append(errs, fmt.Errorf("symbol %s: unsupported metric type %q", symbol.Name, symbol.MetricType))
+			}
+		}
+		for j := range metric.MetricTags {
+			tag := &metric.MetricTags[j]
+			if tag.Tag == "" {
+				errs = append(errs, fmt.Errorf("metrics %s: metric tag without name", metric.Path))
+			}
+			if (tag.Key == "") == (tag.Path == "") {
+				errs = append(errs, fmt.Errorf("metric tag %s: exactly one of key and path must be set", tag.Tag))
+			}
+			tag.ParsedPath = parsePath(tag.Path, false, "metric tag "+tag.Tag)
+		}
+	}
+
+	for name, field := range d.Metadata.Device.Fields {
+		if !slices.Contains(DeviceFields, name) {
+			errs = append(errs, fmt.Errorf("unknown device metadata field %q", name))
+		}
+		field.ParsedPath = parsePath(field.Path, field.Value == "", "device metadata field "+name)
+		d.Metadata.Device.Fields[name] = field
+	}
+
+	if iface := &d.Metadata.Interface; iface.Path != "" || len(iface.Fields) > 0 {
+		iface.ParsedPath = parsePath(iface.Path, true, "interface metadata")
+		if iface.Key == "" {
+			errs = append(errs, errors.New("interface metadata: missing key"))
+		}
+		for name, field := range iface.Fields {
+			if !slices.Contains(InterfaceFields, name) {
+				errs = append(errs, fmt.Errorf("unknown interface metadata field %q", name))
+			}
+			field.ParsedPath = parsePath(field.Path, true, "interface metadata field "+name)
+			iface.Fields[name] = field
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Subscriptions returns the paths to subscribe to. The metric leaves are sampled, while the leaves of tags and
+// metadata are streamed as the target sees fit since they rarely change.
+func (d *Definition) Subscriptions(sampleInterval time.Duration) []client.Subscription {
+	var subscriptions []client.Subscription
+	seen := make(map[string]bool)
+	add := func(path client.Path, mode client.SubscriptionMode) {
+		if len(path) == 0 || seen[path.String()] {
+			return
+		}
+		seen[path.String()] = true
+		subscription := client.Subscription{Path: path, Mode: mode}
+		if mode == client.SubscriptionModeSample {
+			subscription.SampleInterval = sampleInterval
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	for _, metric := range d.Metrics {
+		for _, symbol := range metric.Symbols {
+			add(symbol.FullPath, client.SubscriptionModeSample)
+		}
+	}
+	for _, metric := range d.Metrics {
+		for _, tag := range metric.MetricTags {
+			if len(tag.ParsedPath) > 0 {
+				add(metric.ParsedPath.Append(tag.ParsedPath), client.SubscriptionModeTargetDefined)
+			}
+		}
+	}
+	for _, name := range DeviceFields {
+		add(d.Metadata.Device.Fields[name].ParsedPath, client.SubscriptionModeTargetDefined)
+	}
+	for _, name := range InterfaceFields {
+		if field, ok := d.Metadata.Interface.Fields[name]; ok {
+			add(d.Metadata.Interface.ParsedPath.Append(field.ParsedPath), client.SubscriptionModeTargetDefined)
+		}
+	}
+	return subscriptions
+}
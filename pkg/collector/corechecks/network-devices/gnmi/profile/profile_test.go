@@ -0,0 +1,149 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/collector/corechecks/network-devices/gnmi/client"
+	"github.com/DataDog/datadog-agent/pkg/networkdevice/profile/profiledefinition"
+)
+
+func TestLoadDefaultProfile(t *testing.T) {
+	definition, err := Load(DefaultProfile, "")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultProfile, definition.Name)
+	require.NotEmpty(t, definition.Metrics)
+	assert.Equal(t, "/interfaces/interface", definition.Metadata.Interface.ParsedPath.String())
+
+	subscriptions := definition.Subscriptions(10 * time.Second)
+	modes := make(map[string]client.Subscription, len(subscriptions))
+	for _, subscription := range subscriptions {
+		modes[subscription.Path.String()] = subscription
+	}
+	assert.Len(t, modes, len(subscriptions), "duplicate subscriptions")
+
+	inOctets := modes["/interfaces/interface/state/counters/in-octets"]
+	assert.Equal(t, client.SubscriptionModeSample, inOctets.Mode)
+	assert.Equal(t, 10*time.Second, inOctets.SampleInterval)
+
+	hostname := modes["/system/state/hostname"]
+	assert.Equal(t, client.SubscriptionModeTargetDefined, hostname.Mode)
+	assert.Zero(t, hostname.SampleInterval)
+}
+
+func TestLoadUserProfile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "custom.yaml"), []byte(`
+metadata:
+  device:
+    fields:
+      vendor:
+        value: acme
+      version:
+        path: /system/state/software-version
+metrics:
+  - path: /qos/interfaces/interface/output/queues/queue
+    symbols:
+      - path: state/dropped-pkts
+        name: qos.queue.dropped_pkts
+        metric_type: monotonic_count
+    metric_tags:
+      - tag: interface
+        key: interface-id
+      - tag: queue
+        key: name
+        element: queue
+    static_tags:
+      - source:qos
+`), 0o644))
+
+	definition, err := Load("custom", dir)
+	require.NoError(t, err)
+	assert.Equal(t, "custom", definition.Name)
+	require.Len(t, definition.Metrics, 1)
+	symbol := definition.Metrics[0].Symbols[0]
+	assert.Equal(t, profiledefinition.ProfileMetricTypeMonotonicCount, symbol.MetricType)
+	assert.Equal(t, "/qos/interfaces/interface/output/queues/queue/state/dropped-pkts", symbol.FullPath.String())
+	assert.Equal(t, "acme", definition.Metadata.Device.Fields["vendor"].Value)
+
+	// the profiles shipped with the agent are still available
+	_, err = Load(DefaultProfile, dir)
+	assert.NoError(t, err)
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		profile        string
+		expectedErrors []string
+	}{
+		{
+			name:           "unknown field",
+			profile:        "metric:\n  - path: /system\n",
+			expectedErrors: []string{`unable to parse profile "invalid"`},
+		},
+		{
+			name: "invalid metrics",
+			profile: `
+metrics:
+  - path: /interfaces/interface[name
+    symbols:
+      - path: state/counters/in-octets
+        metric_type: histogram
+    metric_tags:
+      - tag: interface
+  - path: /system
+`,
+			expectedErrors: []string{
+				"unterminated key",
+				"symbol state/counters/in-octets has no name",
+				`unsupported metric type "histogram"`,
+				"metric tag interface: exactly one of key and path must be set",
+				"metrics /system: no symbol",
+			},
+		},
+		{
+			name: "invalid metadata",
+			profile: `
+metadata:
+  device:
+    fields:
+      color:
+        value: blue
+  interface:
+    path: /interfaces/interface
+    fields:
+      speed:
+        path: state/speed
+`,
+			expectedErrors: []string{
+				`unknown device metadata field "color"`,
+				"interface metadata: missing key",
+				`unknown interface metadata field "speed"`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "invalid.yaml"), []byte(tt.profile), 0o644))
+			_, err := Load("invalid", dir)
+			for _, expectedError := range tt.expectedErrors {
+				assert.ErrorContains(t, err, expectedError)
+			}
+		})
+	}
+
+	_, err := Load("does-not-exist", t.TempDir())
+	assert.EqualError(t, err, `unknown profile "does-not-exist"`)
+}
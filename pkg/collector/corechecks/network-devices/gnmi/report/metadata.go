@@ -0,0 +1,166 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package report
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	eventplatform "github.com/DataDog/datadog-agent/comp/forwarder/eventplatform/def"
+	"github.com/DataDog/datadog-agent/pkg/collector/corechecks/network-devices/gnmi/client"
+	"github.com/DataDog/datadog-agent/pkg/networkdevice/integrations"
+	devicemetadata "github.com/DataDog/datadog-agent/pkg/networkdevice/metadata"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+// OpenConfig interface statuses
+var (
+	adminStatuses = map[string]devicemetadata.IfAdminStatus{
+		"UP":      devicemetadata.AdminStatusUp,
+		"DOWN":    devicemetadata.AdminStatusDown,
+		"TESTING": devicemetadata.AdminStatusTesting,
+	}
+	operStatuses = map[string]devicemetadata.IfOperStatus{
+		"UP":               devicemetadata.OperStatusUp,
+		"DOWN":             devicemetadata.OperStatusDown,
+		"TESTING":          devicemetadata.OperStatusTesting,
+		"UNKNOWN":          devicemetadata.OperStatusUnknown,
+		"DORMANT":          devicemetadata.OperStatusDormant,
+		"NOT_PRESENT":      devicemetadata.OperStatusNotPresent,
+		"LOWER_LAYER_DOWN": devicemetadata.OperStatusLowerLayerDown,
+	}
+)
+
+// ReportMetadata sends the device and interface metadata
+func (s *Sender) ReportMetadata(cache *client.Cache, connected bool, collectTime time.Time) {
+	device := s.buildDeviceMetadata(cache, connected)
+	interfaces := s.buildInterfaceMetadata(cache)
+
+	metadataPayloads := devicemetadata.BatchPayloads(integrations.GNMI, s.device.Namespace, "", collectTime, devicemetadata.PayloadMetadataBatchSize, []devicemetadata.DeviceMetadata{device}, interfaces, nil, nil, nil, nil, nil)
+	for _, payload := range metadataPayloads {
+		payloadBytes, err := json.Marshal(payload)
+		if err != nil {
+			log.Errorf("Error marshalling gNMI metadata: %s", err)
+			continue
+		}
+		s.sender.EventPlatformEvent(payloadBytes, eventplatform.EventTypeNetworkDevicesMetadata)
+	}
+}
+
+func (s *Sender) buildDeviceMetadata(cache *client.Cache, connected bool) devicemetadata.DeviceMetadata {
+	status := devicemetadata.DeviceStatusUnreachable
+	if connected {
+		status = devicemetadata.DeviceStatusReachable
+	}
+
+	device := devicemetadata.DeviceMetadata{
+		ID:          s.device.ID(),
+		IDTags:      s.device.IDTags(),
+		Tags:        s.deviceTags(cache),
+		IPAddress:   s.device.IPAddress,
+		Status:      status,
+		Profile:     s.profile.Name,
+		Integration: string(integrations.GNMI),
+	}
+	slices.Sort(device.Tags)
+
+	fields := map[string]*string{
+		"name":          &device.Name,
+		"description":   &device.Description,
+		"vendor":        &device.Vendor,
+		"model":         &device.Model,
+		"serial_number": &device.SerialNumber,
+		"version":       &device.Version,
+		"os_name":       &device.OsName,
+		"os_version":    &device.OsVersion,
+		"os_hostname":   &device.OsHostname,
+		"location":      &device.Location,
+		"product_name":  &device.ProductName,
+	}
+	for name, field := range fields {
+		*field = s.deviceField(cache, name)
+	}
+	return device
+}
+
+// deviceField returns the value of a device metadata field. When the path of the field matches several leaves, the
+// first one is used.
+func (s *Sender) deviceField(cache *client.Cache, name string) string {
+	field, ok := s.profile.Metadata.Device.Fields[name]
+	if !ok {
+		return ""
+	}
+	if field.Value != "" {
+		return field.Value
+	}
+	if values := cache.Match(field.ParsedPath); len(values) > 0 {
+		return values[0].String
+	}
+	return ""
+}
+
+func (s *Sender) buildInterfaceMetadata(cache *client.Cache) []devicemetadata.InterfaceMetadata {
+	config := s.profile.Metadata.Interface
+	if len(config.ParsedPath) == 0 {
+		return nil
+	}
+
+	// the interfaces are the entries of the list having at least one of the fields
+	entries := make(map[string]client.Path)
+	for _, field := range config.Fields {
+		for _, value := range cache.Match(config.ParsedPath.Append(field.ParsedPath)) {
+			entry := value.Path[:len(config.ParsedPath)]
+			entries[entry.String()] = entry
+		}
+	}
+
+	interfaces := make([]devicemetadata.InterfaceMetadata, 0, len(entries))
+	for _, key := range slices.Sorted(maps.Keys(entries)) {
+		entry := entries[key]
+		name := entry[len(entry)-1].Keys[config.Key]
+		if name == "" {
+			continue
+		}
+		fieldValue := func(field string) (client.Value, bool) {
+			fieldConfig, ok := config.Fields[field]
+			if !ok {
+				return client.Value{}, false
+			}
+			return cache.Get(entry.Append(fieldConfig.ParsedPath))
+		}
+
+		iface := devicemetadata.InterfaceMetadata{
+			DeviceID: s.device.ID(),
+			IDTags:   []string{"interface:" + name},
+			Name:     name,
+		}
+		if value, ok := fieldValue("description"); ok {
+			iface.Description = value.String
+		}
+		if value, ok := fieldValue("alias"); ok {
+			iface.Alias = value.String
+		}
+		if value, ok := fieldValue("mac_address"); ok {
+			iface.MacAddress = strings.ToLower(value.String)
+		}
+		if value, ok := fieldValue("index"); ok {
+			if index, ok := value.Float(); ok {
+				iface.Index = int32(index)
+			}
+		}
+		if value, ok := fieldValue("admin_status"); ok {
+			iface.AdminStatus = adminStatuses[value.String]
+		}
+		if value, ok := fieldValue("oper_status"); ok {
+			iface.OperStatus = operStatuses[value.String]
+		}
+		interfaces = append(interfaces, iface)
+	}
+	return interfaces
+}
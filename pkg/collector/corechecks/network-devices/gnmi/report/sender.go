@@ -0,0 +1,168 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+// Package report implements gNMI metrics and metadata reporting
+package report
+
+import (
+	"slices"
+
+	"github.com/DataDog/datadog-agent/pkg/aggregator/sender"
+	"github.com/DataDog/datadog-agent/pkg/collector/corechecks/network-devices/gnmi/client"
+	"github.com/DataDog/datadog-agent/pkg/collector/corechecks/network-devices/gnmi/profile"
+	"github.com/DataDog/datadog-agent/pkg/networkdevice/profile/profiledefinition"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+const metricPrefix = "gnmi."
+
+// Device identifies the target the telemetry is reported for
+type Device struct {
+	Namespace string
+	IPAddress string
+	// Tags are the tags configured on the check instance
+	Tags []string
+}
+
+// ID returns the NDM device ID
+func (d Device) ID() string {
+	return d.Namespace + ":" + d.IPAddress
+}
+
+// IDTags returns the tags used to correlate the device metadata with the metrics
+func (d Device) IDTags() []string {
+	return []string{"device_namespace:" + d.Namespace, "device_ip:" + d.IPAddress}
+}
+
+// Sender reports the telemetry cached from a target as metrics and metadata
+type Sender struct {
+	sender  sender.Sender
+	device  Device
+	profile *profile.Definition
+}
+
+// NewSender returns a new Sender
+func NewSender(sender sender.Sender, device Device, profile *profile.Definition) *Sender {
+	return &Sender{
+		sender:  sender,
+		device:  device,
+		profile: profile,
+	}
+}
+
+// deviceTags returns the tags of all the metrics of the device
+func (s *Sender) deviceTags(cache *client.Cache) []string {
+	tags := append(s.device.IDTags(), "gnmi_profile:"+s.profile.Name)
+	if vendor := s.deviceField(cache, "vendor"); vendor != "" {
+		tags = append(tags, "device_vendor:"+vendor)
+	}
+	return append(tags, s.device.Tags...)
+}
+
+// ReportMetrics reports the device reachability and the metrics defined by the profile
+func (s *Sender) ReportMetrics(cache *client.Cache, connected bool) {
+	tags := s.deviceTags(cache)
+	if connected {
+		s.sender.Gauge(metricPrefix+"device.reachable", 1, "", tags)
+		s.sender.Gauge(metricPrefix+"device.unreachable", 0, "", tags)
+	} else {
+		s.sender.Gauge(metricPrefix+"device.reachable", 0, "", tags)
+		s.sender.Gauge(metricPrefix+"device.unreachable", 1, "", tags)
+	}
+
+	for _, metric := range s.profile.Metrics {
+		// the tags of the entities the metrics are about, like an interface
+		entityTags := make(map[string][]string)
+		for _, symbol := range metric.Symbols {
+			for _, value := range cache.Match(symbol.FullPath) {
+				floatValue, ok := symbolValue(symbol, value)
+				if !ok {
+					log.Debugf("gNMI metric `%s`: unable to convert %s value %q", symbol.Name, value.Path, value.String)
+					continue
+				}
+
+				entity := value.Path[:len(metric.ParsedPath)]
+				metricTags, ok := entityTags[entity.String()]
+				if !ok {
+					metricTags = slices.Concat(tags, metric.StaticTags, resolveMetricTags(cache, metric, entity))
+					entityTags[entity.String()] = metricTags
+				}
+				s.sendMetric(metricPrefix+symbol.Name, floatValue, symbol.MetricType, metricTags)
+			}
+		}
+	}
+}
+
+func symbolValue(symbol profile.SymbolConfig, value client.Value) (float64, bool) {
+	var floatValue float64
+	if len(symbol.Mapping) > 0 {
+		mapped, ok := symbol.Mapping[value.String]
+		if !ok {
+			return 0, false
+		}
+		floatValue = mapped
+	} else {
+		var ok bool
+		if floatValue, ok = value.Float(); !ok {
+			return 0, false
+		}
+	}
+	if symbol.ScaleFactor != 0 {
+		floatValue *= symbol.ScaleFactor
+	}
+	return floatValue, true
+}
+
+// resolveMetricTags returns the tags of an entity, read from the keys of its path or from its leaves
+func resolveMetricTags(cache *client.Cache, metric profile.MetricsConfig, entity client.Path) []string {
+	var tags []string
+	for _, tag := range metric.MetricTags {
+		var value string
+		if tag.Key != "" {
+			value = keyValue(entity, tag.Element, tag.Key)
+		} else if leaf, ok := cache.Get(entity.Append(tag.ParsedPath)); ok {
+			value = leaf.String
+		}
+		if mapped, ok := tag.Mapping[value]; ok {
+			value = mapped
+		}
+		if value != "" {
+			tags = append(tags, tag.Tag+":"+value)
+		}
+	}
+	return tags
+}
+
+// keyValue returns the value of a key of the path, from the deepest element having it
+func keyValue(path client.Path, element string, key string) string {
+	for i := len(path) - 1; i >= 0; i-- {
+		if element != "" && path[i].Name != element {
+			continue
+		}
+		if value, ok := path[i].Keys[key]; ok {
+			return value
+		}
+	}
+	return ""
+}
+
+func (s *Sender) sendMetric(name string, value float64, metricType profiledefinition.ProfileMetricType, tags []string) {
+	switch metricType {
+	case profiledefinition.ProfileMetricTypeRate:
+		s.sender.Rate(name, value, "", tags)
+	case profiledefinition.ProfileMetricTypeMonotonicCount:
+		s.sender.MonotonicCount(name, value, "", tags)
+	case profiledefinition.ProfileMetricTypeMonotonicCountAndRate:
+		s.sender.MonotonicCount(name, value, "", tags)
+		s.sender.Rate(name+".rate", value, "", tags)
+	default:
+		s.sender.Gauge(name, value, "", tags)
+	}
+}
+
+// Commit commits the metrics
+func (s *Sender) Commit() {
+	s.sender.Commit()
+}
@@ -0,0 +1,162 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+//go:build test
+
+package report
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	eventplatform "github.com/DataDog/datadog-agent/comp/forwarder/eventplatform/def"
+	"github.com/DataDog/datadog-agent/pkg/aggregator/mocksender"
+	"github.com/DataDog/datadog-agent/pkg/collector/corechecks/network-devices/gnmi/client"
+	"github.com/DataDog/datadog-agent/pkg/collector/corechecks/network-devices/gnmi/profile"
+	devicemetadata "github.com/DataDog/datadog-agent/pkg/networkdevice/metadata"
+)
+
+func newTestCache(t *testing.T, leaves map[string]any) *client.Cache {
+	cache := client.NewCache()
+	for path, leaf := range leaves {
+		parsed, err := client.ParsePath(path)
+		require.NoError(t, err)
+		value := client.Value{Path: parsed}
+		switch v := leaf.(type) {
+		case string:
+			value.String = v
+		case float64:
+			value.String = strconv.FormatFloat(v, 'f', -1, 64)
+			value.Number = v
+			value.IsNumber = true
+		}
+		cache.Update(value)
+	}
+	return cache
+}
+
+func newTestSender(t *testing.T) (*Sender, *mocksender.MockSender) {
+	definition, err := profile.Load(profile.DefaultProfile, "")
+	require.NoError(t, err)
+	definition.Metadata.Device.Fields["vendor"] = profile.FieldConfig{Value: "acme"}
+
+	mockSender := mocksender.NewMockSender("gnmi")
+	mockSender.SetupAcceptAll()
+	device := Device{Namespace: "default", IPAddress: "10.0.0.1", Tags: []string{"site:paris"}}
+	return NewSender(mockSender, device, definition), mockSender
+}
+
+func TestReportMetrics(t *testing.T) {
+	cache := newTestCache(t, map[string]any{
+		"/interfaces/interface[name=Ethernet1]/state/counters/in-octets": float64(1000),
+		// JSON encodings send 64 bits counters as strings
+		"/interfaces/interface[name=Ethernet1]/state/counters/out-octets": "2000",
+		"/interfaces/interface[name=Ethernet1]/state/oper-status":         "DOWN",
+		"/interfaces/interface[name=Ethernet1]/state/description":         "uplink",
+		"/interfaces/interface[name=Ethernet2]/state/counters/in-octets":  float64(3000),
+		"/interfaces/interface[name=Ethernet2]/state/oper-status":         "BOGUS",
+		"/system/cpus/cpu[index=0]/state/total/instant":                   float64(42),
+		"/system/memory/state/physical":                                   float64(8e9),
+	})
+	sender, mockSender := newTestSender(t)
+
+	sender.ReportMetrics(cache, true)
+
+	deviceTags := []string{"device_namespace:default", "device_ip:10.0.0.1", "gnmi_profile:openconfig", "device_vendor:acme", "site:paris"}
+	mockSender.AssertMetric(t, "Gauge", "gnmi.device.reachable", 1, "", deviceTags)
+	mockSender.AssertMetric(t, "Gauge", "gnmi.device.unreachable", 0, "", deviceTags)
+
+	ethernet1Tags := append(deviceTags, "interface:Ethernet1", "interface_alias:uplink")
+	mockSender.AssertMetric(t, "MonotonicCount", "gnmi.interface.in_octets", 1000, "", ethernet1Tags)
+	mockSender.AssertMetric(t, "Rate", "gnmi.interface.in_octets.rate", 1000, "", ethernet1Tags)
+	mockSender.AssertMetric(t, "MonotonicCount", "gnmi.interface.out_octets", 2000, "", ethernet1Tags)
+	mockSender.AssertMetric(t, "Gauge", "gnmi.interface.oper_status", 2, "", ethernet1Tags)
+
+	ethernet2Tags := append(deviceTags, "interface:Ethernet2")
+	mockSender.AssertMetric(t, "MonotonicCount", "gnmi.interface.in_octets", 3000, "", ethernet2Tags)
+	mockSender.AssertNotCalled(t, "Gauge", "gnmi.interface.oper_status", mock.Anything, "", mocksender.MatchTagsContains(ethernet2Tags))
+
+	mockSender.AssertMetric(t, "Gauge", "gnmi.cpu.usage", 42, "", append(deviceTags, "cpu:0"))
+	mockSender.AssertMetric(t, "Gauge", "gnmi.memory.total", 8e9, "", deviceTags)
+	mockSender.AssertMetricMissing(t, "Gauge", "gnmi.memory.used")
+}
+
+func TestReportMetricsUnreachable(t *testing.T) {
+	sender, mockSender := newTestSender(t)
+
+	sender.ReportMetrics(client.NewCache(), false)
+
+	deviceTags := []string{"device_namespace:default", "device_ip:10.0.0.1", "gnmi_profile:openconfig", "device_vendor:acme", "site:paris"}
+	mockSender.AssertMetric(t, "Gauge", "gnmi.device.reachable", 0, "", deviceTags)
+	mockSender.AssertMetric(t, "Gauge", "gnmi.device.unreachable", 1, "", deviceTags)
+	mockSender.AssertMetricMissing(t, "MonotonicCount", "gnmi.interface.in_octets")
+}
+
+func TestReportMetadata(t *testing.T) {
+	cache := newTestCache(t, map[string]any{
+		"/system/state/hostname":                                           "router1",
+		"/system/state/software-version":                                   "4.30.1F",
+		"/interfaces/interface[name=Ethernet1]/state/description":          "uplink",
+		"/interfaces/interface[name=Ethernet1]/state/admin-status":         "UP",
+		"/interfaces/interface[name=Ethernet1]/state/oper-status":          "LOWER_LAYER_DOWN",
+		"/interfaces/interface[name=Ethernet1]/state/ifindex":              float64(1),
+		"/interfaces/interface[name=Ethernet1]/ethernet/state/mac-address": "00:1C:73:AA:BB:CC",
+		"/interfaces/interface[name=Ethernet2]/state/oper-status":          "UP",
+		"/interfaces/interface[name=Ethernet2]/state/counters/in-octets":   float64(3000),
+	})
+	sender, mockSender := newTestSender(t)
+
+	collectTime := time.Unix(1700000000, 0)
+	sender.ReportMetadata(cache, true, collectTime)
+
+	mockSender.AssertNumberOfCalls(t, "EventPlatformEvent", 1)
+	call := mockSender.Calls[len(mockSender.Calls)-1]
+	assert.Equal(t, eventplatform.EventTypeNetworkDevicesMetadata, call.Arguments.Get(1))
+	var payload devicemetadata.NetworkDevicesMetadata
+	require.NoError(t, json.Unmarshal(call.Arguments.Get(0).([]byte), &payload))
+
+	assert.Equal(t, "gnmi", string(payload.Integration))
+	assert.Equal(t, "default", payload.Namespace)
+	assert.Equal(t, int64(1700000000), payload.CollectTimestamp)
+	require.Len(t, payload.Devices, 1)
+	assert.Equal(t, devicemetadata.DeviceMetadata{
+		ID:          "default:10.0.0.1",
+		IDTags:      []string{"device_namespace:default", "device_ip:10.0.0.1"},
+		Tags:        []string{"device_ip:10.0.0.1", "device_namespace:default", "device_vendor:acme", "gnmi_profile:openconfig", "site:paris"},
+		IPAddress:   "10.0.0.1",
+		Status:      devicemetadata.DeviceStatusReachable,
+		Name:        "router1",
+		OsHostname:  "router1",
+		Vendor:      "acme",
+		Version:     "4.30.1F",
+		Profile:     "openconfig",
+		Integration: "gnmi",
+	}, payload.Devices[0])
+
+	assert.Equal(t, []devicemetadata.InterfaceMetadata{
+		{
+			DeviceID:    "default:10.0.0.1",
+			IDTags:      []string{"interface:Ethernet1"},
+			Index:       1,
+			Name:        "Ethernet1",
+			Description: "uplink",
+			MacAddress:  "00:1c:73:aa:bb:cc",
+			AdminStatus: devicemetadata.AdminStatusUp,
+			OperStatus:  devicemetadata.OperStatusLowerLayerDown,
+		},
+		{
+			DeviceID:   "default:10.0.0.1",
+			IDTags:     []string{"interface:Ethernet2"},
+			Name:       "Ethernet2",
+			OperStatus: devicemetadata.OperStatusUp,
+		},
+	}, payload.Interfaces)
+}
@@ -21,6 +21,8 @@ import (
 
 const (
 	defaultCheckInterval time.Duration = 1 * time.Minute
+	// maxMultipathFlows bounds the number of traceroutes run per check
+	maxMultipathFlows = 16
 )
 
 // Number is a type that is used to make a generic version
@@ -36,6 +38,7 @@ type InitConfig struct {
 	MaxTTL                          uint8 `yaml:"max_ttl"`
 	TracerouteQueries               int   `yaml:"traceroute_queries"`
 	E2eQueries                      int   `yaml:"e2e_queries"`
+	MultipathFlows                  int   `yaml:"multipath_flows"`
 	DisableSourcePublicIPCollection bool  `yaml:"disable_source_public_ip_collection"`
 }
 
@@ -70,6 +73,9 @@ type InstanceConfig struct {
 
 	TracerouteQueries int `yaml:"traceroute_queries"`
 	E2eQueries        int `yaml:"e2e_queries"`
+	// MultipathFlows is the number of flows traced to discover the ECMP paths
+	// to the destination
+	MultipathFlows int `yaml:"multipath_flows"`
 
 	Tags []string `yaml:"tags"`
 }
@@ -96,6 +102,7 @@ type CheckConfig struct {
 	MinCollectionInterval           time.Duration
 	TracerouteQueries               int
 	E2eQueries                      int
+	MultipathFlows                  int
 	Tags                            []string
 	Namespace                       string
 }
@@ -170,6 +177,14 @@ func NewCheckConfig(rawInstance integration.Data, rawInitConfig integration.Data
 		constants.DefaultNetworkPathStaticPathE2eQueries,
 	)
 
+	c.MultipathFlows = firstNonZero(
+		instance.MultipathFlows,
+		initConfig.MultipathFlows,
+	)
+	if c.MultipathFlows < 0 || c.MultipathFlows > maxMultipathFlows {
+		return nil, fmt.Errorf("multipath_flows must be between 0 and %d", maxMultipathFlows)
+	}
+
 	c.Tags = instance.Tags
 	c.Namespace = setup.Datadog().GetString("network_devices.namespace")
 
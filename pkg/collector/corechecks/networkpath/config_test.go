@@ -579,6 +579,55 @@ disable_source_public_ip_collection: true
 				DisableSourcePublicIPCollection: true,
 			},
 		},
+		{
+			name: "DNS multipath test",
+			rawInstance: []byte(`
+hostname: 1.2.3.4
+protocol: dns
+multipath_flows: 8
+`),
+			rawInitConfig: []byte(`
+multipath_flows: 4
+`),
+			expectedConfig: &CheckConfig{
+				DestHostname:          "1.2.3.4",
+				MinCollectionInterval: time.Duration(60) * time.Second,
+				Namespace:             "my-namespace",
+				Protocol:              payload.ProtocolDNS,
+				Timeout:               constants.DefaultNetworkPathTimeout * time.Millisecond,
+				MaxTTL:                constants.DefaultNetworkPathMaxTTL,
+				TracerouteQueries:     constants.DefaultNetworkPathStaticPathTracerouteQueries,
+				E2eQueries:            constants.DefaultNetworkPathStaticPathE2eQueries,
+				MultipathFlows:        8,
+			},
+		},
+		{
+			name: "multipath flows from init config",
+			rawInstance: []byte(`
+hostname: 1.2.3.4
+`),
+			rawInitConfig: []byte(`
+multipath_flows: 4
+`),
+			expectedConfig: &CheckConfig{
+				DestHostname:          "1.2.3.4",
+				MinCollectionInterval: time.Duration(60) * time.Second,
+				Namespace:             "my-namespace",
+				Timeout:               constants.DefaultNetworkPathTimeout * time.Millisecond,
+				MaxTTL:                constants.DefaultNetworkPathMaxTTL,
+				TracerouteQueries:     constants.DefaultNetworkPathStaticPathTracerouteQueries,
+				E2eQueries:            constants.DefaultNetworkPathStaticPathE2eQueries,
+				MultipathFlows:        4,
+			},
+		},
+		{
+			name: "too many multipath flows",
+			rawInstance: []byte(`
+hostname: 1.2.3.4
+multipath_flows: 100
+`),
+			expectedError: "multipath_flows must be between 0 and 16",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
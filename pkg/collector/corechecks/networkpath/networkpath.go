@@ -67,6 +67,7 @@ func (c *Check) Run() error {
 		ReverseDNS:                      true,
 		TracerouteQueries:               c.config.TracerouteQueries,
 		E2eQueries:                      c.config.E2eQueries,
+		MultipathFlows:                  c.config.MultipathFlows,
 	}
 
 	path, err := c.traceroute.Run(context.TODO(), cfg)
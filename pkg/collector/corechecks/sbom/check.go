@@ -19,6 +19,7 @@ import (
 	tagger "github.com/DataDog/datadog-agent/comp/core/tagger/def"
 	workloadfilter "github.com/DataDog/datadog-agent/comp/core/workloadfilter/def"
 	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+	sbomstore "github.com/DataDog/datadog-agent/comp/sbomstore/def"
 	"github.com/DataDog/datadog-agent/pkg/aggregator/sender"
 	"github.com/DataDog/datadog-agent/pkg/collector/check"
 	core "github.com/DataDog/datadog-agent/pkg/collector/corechecks"
@@ -111,6 +112,7 @@ type Check struct {
 	core.CheckBase
 	workloadmetaStore workloadmeta.Component
 	filterStore       workloadfilter.Component
+	sbomStore         sbomstore.Component
 	tagger            tagger.Component
 	instance          *Config
 	processor         *processor
@@ -120,12 +122,13 @@ type Check struct {
 }
 
 // Factory returns a new check factory
-func Factory(store workloadmeta.Component, filterStore workloadfilter.Component, cfg config.Component, tagger tagger.Component) option.Option[func() check.Check] {
+func Factory(store workloadmeta.Component, filterStore workloadfilter.Component, sbomStore sbomstore.Component, cfg config.Component, tagger tagger.Component) option.Option[func() check.Check] {
 	return option.New(func() check.Check {
 		return core.NewLongRunningCheckWrapper(&Check{
 			CheckBase:         core.NewCheckBase(CheckName),
 			workloadmetaStore: store,
 			filterStore:       filterStore,
+			sbomStore:         sbomStore,
 			tagger:            tagger,
 			instance:          &Config{},
 			stopCh:            make(chan struct{}),
@@ -158,6 +161,7 @@ func (c *Check) Configure(senderManager sender.SenderManager, _ uint64, config,
 	if c.processor, err = newProcessor(
 		c.workloadmetaStore,
 		c.filterStore,
+		c.sbomStore,
 		sender,
 		c.tagger,
 		c.cfg,
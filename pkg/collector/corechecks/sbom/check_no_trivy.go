@@ -12,6 +12,7 @@ import (
 	tagger "github.com/DataDog/datadog-agent/comp/core/tagger/def"
 	workloadfilter "github.com/DataDog/datadog-agent/comp/core/workloadfilter/def"
 	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+	sbomstore "github.com/DataDog/datadog-agent/comp/sbomstore/def"
 	"github.com/DataDog/datadog-agent/pkg/collector/check"
 	"github.com/DataDog/datadog-agent/pkg/util/option"
 )
@@ -22,6 +23,6 @@ const (
 )
 
 // Factory returns a new check factory
-func Factory(workloadmeta.Component, workloadfilter.Component, config.Component, tagger.Component) option.Option[func() check.Check] {
+func Factory(workloadmeta.Component, workloadfilter.Component, sbomstore.Component, config.Component, tagger.Component) option.Option[func() check.Check] {
 	return option.None[func() check.Check]()
 }
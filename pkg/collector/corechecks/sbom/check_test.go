@@ -21,6 +21,7 @@ import (
 	workloadmetafxmock "github.com/DataDog/datadog-agent/comp/core/workloadmeta/fx-mock"
 	workloadmetainit "github.com/DataDog/datadog-agent/comp/core/workloadmeta/init"
 	workloadmetamock "github.com/DataDog/datadog-agent/comp/core/workloadmeta/mock"
+	sbomstoreimpl "github.com/DataDog/datadog-agent/comp/sbomstore/impl"
 	"github.com/DataDog/datadog-agent/pkg/aggregator/mocksender"
 	"github.com/DataDog/datadog-agent/pkg/aggregator/sender"
 	checkid "github.com/DataDog/datadog-agent/pkg/collector/check/id"
@@ -119,7 +120,7 @@ func TestFactory(t *testing.T) {
 		core.MockBundle(),
 		workloadmetafxmock.MockModule(workloadmeta.NewParams()),
 	))
-	checkFactory := Factory(mockStore, mockFilterStore, cfg, fakeTagger)
+	checkFactory := Factory(mockStore, mockFilterStore, sbomstoreimpl.NewComponent(sbomstoreimpl.Requires{WMeta: mockStore}).Comp, cfg, fakeTagger)
 	assert.NotNil(t, checkFactory)
 
 	check, ok := checkFactory.Get()
@@ -179,7 +180,7 @@ func TestConfigure(t *testing.T) {
 	cfg := app.Cfg
 	mockStore := app.Store
 
-	checkFactory := Factory(mockStore, mockFilterStore, cfg, fakeTagger)
+	checkFactory := Factory(mockStore, mockFilterStore, sbomstoreimpl.NewComponent(sbomstoreimpl.Requires{WMeta: mockStore}).Comp, cfg, fakeTagger)
 	assert.NotNil(t, checkFactory)
 
 	check, ok := checkFactory.Get()
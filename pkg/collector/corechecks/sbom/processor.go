@@ -21,6 +21,7 @@ import (
 	"github.com/DataDog/datadog-agent/comp/core/workloadmeta/collectors/sbomutil"
 	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
 	eventplatform "github.com/DataDog/datadog-agent/comp/forwarder/eventplatform/def"
+	sbomstore "github.com/DataDog/datadog-agent/comp/sbomstore/def"
 	"github.com/DataDog/datadog-agent/pkg/aggregator/sender"
 	pkgconfigsetup "github.com/DataDog/datadog-agent/pkg/config/setup"
 
@@ -28,6 +29,7 @@ import (
 	"github.com/DataDog/datadog-agent/pkg/sbom/bomconvert"
 	"github.com/DataDog/datadog-agent/pkg/sbom/collectors/host"
 	"github.com/DataDog/datadog-agent/pkg/sbom/collectors/procfs"
+	"github.com/DataDog/datadog-agent/pkg/sbom/osv"
 	sbomscanner "github.com/DataDog/datadog-agent/pkg/sbom/scanner"
 	queue "github.com/DataDog/datadog-agent/pkg/util/aggregatingqueue"
 	pkgimage "github.com/DataDog/datadog-agent/pkg/util/containers/image"
@@ -35,6 +37,7 @@ import (
 	"github.com/DataDog/datadog-agent/pkg/util/hostname"
 	"github.com/DataDog/datadog-agent/pkg/util/log"
 
+	"github.com/DataDog/agent-payload/v5/cyclonedx_v1_4"
 	model "github.com/DataDog/agent-payload/v5/sbom"
 
 	gopsutil "github.com/shirou/gopsutil/v4/host"
@@ -62,9 +65,11 @@ type processor struct {
 	hostCache             string
 	hostLastFullSBOM      time.Time
 	hostHeartbeatValidity time.Duration
+	vulnDatabase          *osv.Database // Offline vulnerability database, nil when disabled
+	sbomStore             sbomstore.Component
 }
 
-func newProcessor(workloadmetaStore workloadmeta.Component, filterStore workloadfilter.Component, sender sender.Sender, tagger tagger.Component, cfg config.Component, maxNbItem int, maxRetentionTime time.Duration, hostHeartbeatValidity time.Duration) (*processor, error) {
+func newProcessor(workloadmetaStore workloadmeta.Component, filterStore workloadfilter.Component, sbomStore sbomstore.Component, sender sender.Sender, tagger tagger.Component, cfg config.Component, maxNbItem int, maxRetentionTime time.Duration, hostHeartbeatValidity time.Duration) (*processor, error) {
 	sbomScanner := sbomscanner.GetGlobalScanner()
 	if sbomScanner == nil {
 		return nil, errors.New("failed to get global SBOM scanner")
@@ -79,6 +84,7 @@ func newProcessor(workloadmetaStore workloadmeta.Component, filterStore workload
 	contImageSBOM := cfg.GetBool("sbom.container_image.enabled")
 	hostSBOM := cfg.GetBool("sbom.host.enabled")
 	procfsSBOM := isProcfsSBOMEnabled(cfg)
+	vulnDatabase := loadVulnerabilityDatabase(cfg)
 
 	return &processor{
 		cfg: cfg,
@@ -109,9 +115,47 @@ func newProcessor(workloadmetaStore workloadmeta.Component, filterStore workload
 		procfsSBOM:            procfsSBOM,
 		hostname:              hname,
 		hostHeartbeatValidity: hostHeartbeatValidity,
+		vulnDatabase:          vulnDatabase,
+		sbomStore:             sbomStore,
 	}, nil
 }
 
+// loadVulnerabilityDatabase loads the OSV database used to match the SBOMs
+// locally, if enabled
+func loadVulnerabilityDatabase(cfg config.Component) *osv.Database {
+	if !cfg.GetBool("sbom.offline_vulnerabilities.enabled") {
+		return nil
+	}
+
+	path := cfg.GetString("sbom.offline_vulnerabilities.database_path")
+	db, err := osv.LoadDatabase(path)
+	if err != nil {
+		log.Errorf("Failed to load the OSV database from %s, vulnerabilities won't be matched locally: %v", path, err)
+		return nil
+	}
+
+	log.Infof("Loaded %d vulnerabilities from the OSV database %s", db.Len(), path)
+	return db
+}
+
+// matchVulnerabilities matches the SBOM against the offline vulnerability
+// database and stores the findings, to be served by the agent API
+func (p *processor) matchVulnerabilities(targetType, targetID string, bom *cyclonedx_v1_4.Bom, generatedAt time.Time) {
+	if p.vulnDatabase == nil || bom == nil {
+		return
+	}
+
+	findings := p.vulnDatabase.Match(bom)
+	log.Debugf("Found %d vulnerabilities in the SBOM of %s %s", len(findings), targetType, targetID)
+
+	p.sbomStore.SetVulnerabilityReport(osv.Report{
+		TargetType:  targetType,
+		TargetID:    targetID,
+		GeneratedAt: generatedAt,
+		Findings:    findings,
+	})
+}
+
 func isProcfsSBOMEnabled(cfg config.Component) bool {
 	// Allowed only in sidecar mode for now
 	return cfg.GetBool("sbom.container.enabled") && fargate.IsSidecar()
@@ -218,6 +262,10 @@ func (p *processor) unregisterImage(img *workloadmeta.ContainerImageMetadata) {
 			delete(p.imageRepoDigests, repoDigest)
 		}
 	}
+
+	if p.vulnDatabase != nil {
+		p.sbomStore.DeleteVulnerabilityReport(osv.TargetTypeContainerImage, img.ID)
+	}
 }
 
 // runningImages returns the identifiers of the images that have at least one
@@ -317,6 +365,8 @@ func (p *processor) processHostScanResult(result sbom.ScanResult) {
 			sbom.Sbom = &model.SBOMEntity_Cyclonedx{
 				Cyclonedx: report,
 			}
+			p.matchVulnerabilities(osv.TargetTypeHost, p.hostname, report, result.CreatedAt)
+			p.sbomStore.SetHostSBOM(p.hostname, result.ConvertScanResultToSBOM())
 
 			sbom.Hash = result.Report.ID()
 			p.hostCache = result.Report.ID()
@@ -447,6 +497,10 @@ func (p *processor) processImageSBOM(img *workloadmeta.ContainerImageMetadata, r
 		return
 	}
 
+	if cyclosbom.Status == workloadmeta.Success {
+		p.matchVulnerabilities(osv.TargetTypeContainerImage, img.ID, cyclosbom.CycloneDXBOM, cyclosbom.GenerationTime)
+	}
+
 	for repo := range repos {
 		repoSplitted := strings.Split(repo, "/")
 		shortName := repoSplitted[len(repoSplitted)-1]
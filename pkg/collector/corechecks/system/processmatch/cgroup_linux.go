@@ -0,0 +1,39 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+//go:build linux
+
+package processmatch
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/DataDog/datadog-agent/pkg/util/kernel"
+)
+
+const cgroupsSupported = true
+
+// readCgroups returns the cgroup paths of a process, read from /proc/<pid>/cgroup
+func readCgroups(pid int32) ([]string, error) {
+	content, err := os.ReadFile(kernel.HostProc(strconv.Itoa(int(pid)), "cgroup"))
+	if err != nil {
+		return nil, err
+	}
+	return parseCgroups(string(content)), nil
+}
+
+// parseCgroups returns the paths of the hierarchy-ID:controller-list:cgroup-path lines
+func parseCgroups(content string) []string {
+	var paths []string
+	for _, line := range strings.Split(content, "\n") {
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) == 3 {
+			paths = append(paths, parts[2])
+		}
+	}
+	return paths
+}
@@ -0,0 +1,22 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+//go:build linux
+
+package processmatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCgroups(t *testing.T) {
+	content := `12:memory:/system.slice/nginx.service
+11:cpu,cpuacct:/system.slice/nginx.service
+0::/system.slice/nginx.service
+`
+	assert.Equal(t, []string{"/system.slice/nginx.service", "/system.slice/nginx.service", "/system.slice/nginx.service"}, parseCgroups(content))
+}
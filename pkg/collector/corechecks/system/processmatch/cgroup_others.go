@@ -0,0 +1,16 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+//go:build !linux
+
+package processmatch
+
+import "errors"
+
+const cgroupsSupported = false
+
+func readCgroups(_ int32) ([]string, error) {
+	return nil, errors.New("cgroups are only supported on Linux")
+}
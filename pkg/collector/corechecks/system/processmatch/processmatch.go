@@ -0,0 +1,316 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+// Package processmatch implements a core check that monitors the processes
+// matching a name, command line, user or cgroup, and reports their count and
+// aggregated resource usage.
+package processmatch
+
+import (
+	"errors"
+	"fmt"
+	"os/user"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v2"
+
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/integration"
+	"github.com/DataDog/datadog-agent/pkg/aggregator/sender"
+	"github.com/DataDog/datadog-agent/pkg/collector/check"
+	core "github.com/DataDog/datadog-agent/pkg/collector/corechecks"
+	"github.com/DataDog/datadog-agent/pkg/metrics/servicecheck"
+	"github.com/DataDog/datadog-agent/pkg/process/procutil"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+	"github.com/DataDog/datadog-agent/pkg/util/option"
+)
+
+const (
+	// CheckName is the name of the check
+	CheckName = "process_match"
+
+	metricPrefix   = "process_match."
+	upServiceCheck = "process_match.up"
+)
+
+type instanceConfig struct {
+	Name         string   `yaml:"name"`
+	ProcessNames []string `yaml:"process_names"`
+	CmdlineRegex string   `yaml:"cmdline_regex"`
+	Users        []string `yaml:"users"`
+	CgroupRegex  string   `yaml:"cgroup_regex"`
+	MinCount     *int     `yaml:"min_count"`
+	MaxCount     *int     `yaml:"max_count"`
+}
+
+// cpuSample is the CPU time a process used so far, in seconds
+type cpuSample struct {
+	createTime int64
+	total      float64
+}
+
+// groupStats holds the resource usage summed over the processes of the match group
+type groupStats struct {
+	count     int
+	cpuPct    float64
+	rss       uint64
+	vms       uint64
+	threads   int64
+	fds       int64
+	fdsExists bool
+}
+
+// Check reports the count and resource usage of the processes matching its instance
+type Check struct {
+	core.CheckBase
+	config         instanceConfig
+	minCount       int
+	maxCount       int // negative when there is no upper bound
+	cmdlinePattern *regexp.Regexp
+	cgroupPattern  *regexp.Regexp
+	tags           []string
+
+	probe       procutil.Probe
+	readCgroups func(pid int32) ([]string, error)
+	lookupUser  func(uid string) (string, error)
+	now         func() time.Time
+
+	// usernames caches the names of the uids, looking them up can be slow
+	usernames map[int32]string
+	lastRun   time.Time
+	lastCPU   map[int32]cpuSample
+}
+
+// Run executes the check
+func (c *Check) Run() error {
+	sender, err := c.GetSender()
+	if err != nil {
+		return err
+	}
+
+	now := c.now()
+	procs, err := c.probe.ProcessesByPID(now, true)
+	if err != nil {
+		return fmt.Errorf("unable to list processes: %w", err)
+	}
+
+	stats := c.collect(procs, now)
+
+	sender.Gauge(metricPrefix+"count", float64(stats.count), "", c.tags)
+	sender.Gauge(metricPrefix+"mem.rss", float64(stats.rss), "", c.tags)
+	sender.Gauge(metricPrefix+"mem.vms", float64(stats.vms), "", c.tags)
+	sender.Gauge(metricPrefix+"threads", float64(stats.threads), "", c.tags)
+	if stats.fdsExists {
+		sender.Gauge(metricPrefix+"open_file_descriptors", float64(stats.fds), "", c.tags)
+	}
+	// the CPU usage is computed from the CPU time used since the previous run
+	if !c.lastRun.IsZero() {
+		sender.Gauge(metricPrefix+"cpu.pct", stats.cpuPct, "", c.tags)
+	}
+	c.lastRun = now
+
+	status, message := c.countStatus(stats.count)
+	sender.ServiceCheck(upServiceCheck, status, "", c.tags, message)
+	sender.Commit()
+	return nil
+}
+
+// collect sums the resource usage of the matching processes
+func (c *Check) collect(procs map[int32]*procutil.Process, now time.Time) groupStats {
+	var stats groupStats
+	elapsed := now.Sub(c.lastRun).Seconds()
+	cpu := make(map[int32]cpuSample)
+	for pid, proc := range procs {
+		if !c.matches(proc) {
+			continue
+		}
+		stats.count++
+		if proc.Stats == nil {
+			continue
+		}
+		if proc.Stats.MemInfo != nil {
+			stats.rss += proc.Stats.MemInfo.RSS
+			stats.vms += proc.Stats.MemInfo.VMS
+		}
+		stats.threads += int64(proc.Stats.NumThreads)
+		// the count is negative when the agent isn't allowed to read it
+		if proc.Stats.OpenFdCount >= 0 {
+			stats.fds += int64(proc.Stats.OpenFdCount)
+			stats.fdsExists = true
+		}
+		if proc.Stats.CPUTime != nil {
+			sample := cpuSample{
+				createTime: proc.Stats.CreateTime,
+				total:      proc.Stats.CPUTime.User + proc.Stats.CPUTime.System,
+			}
+			cpu[pid] = sample
+			// a different create time means the pid was reused by another process
+			if previous, ok := c.lastCPU[pid]; ok && previous.createTime == sample.createTime && elapsed > 0 {
+				stats.cpuPct += max(sample.total-previous.total, 0) / elapsed * 100
+			}
+		}
+	}
+	c.lastCPU = cpu
+	return stats
+}
+
+// matches returns whether a process matches all the criteria of the instance
+func (c *Check) matches(proc *procutil.Process) bool {
+	if len(c.config.ProcessNames) > 0 && !slices.ContainsFunc(processNames(proc), func(name string) bool {
+		return slices.Contains(c.config.ProcessNames, name)
+	}) {
+		return false
+	}
+	if c.cmdlinePattern != nil && !c.cmdlinePattern.MatchString(strings.Join(proc.Cmdline, " ")) {
+		return false
+	}
+	if len(c.config.Users) > 0 && !slices.Contains(c.config.Users, c.username(proc)) {
+		return false
+	}
+	if c.cgroupPattern != nil {
+		// checked last as it requires reading the cgroups of the process
+		cgroups, err := c.readCgroups(proc.Pid)
+		if err != nil {
+			log.Debugf("Unable to read the cgroups of process %d: %s", proc.Pid, err)
+			return false
+		}
+		return slices.ContainsFunc(cgroups, c.cgroupPattern.MatchString)
+	}
+	return true
+}
+
+// processNames returns the names a process can be matched by. The command
+// name is truncated by the kernel, so the executable name is matched as well.
+func processNames(proc *procutil.Process) []string {
+	names := []string{proc.Name, proc.Comm}
+	if proc.Exe != "" {
+		names = append(names, filepath.Base(proc.Exe))
+	}
+	if len(proc.Cmdline) > 0 && proc.Cmdline[0] != "" {
+		names = append(names, filepath.Base(proc.Cmdline[0]))
+	}
+	return names
+}
+
+// username returns the name of the user running a process
+func (c *Check) username(proc *procutil.Process) string {
+	// the probe only resolves the username on Windows
+	if proc.Username != "" || len(proc.Uids) == 0 {
+		return proc.Username
+	}
+	uid := proc.Uids[0]
+	if name, ok := c.usernames[uid]; ok {
+		return name
+	}
+	name, err := c.lookupUser(strconv.Itoa(int(uid)))
+	if err != nil {
+		log.Debugf("Unable to look up the name of user %d: %s", uid, err)
+		name = strconv.Itoa(int(uid))
+	}
+	c.usernames[uid] = name
+	return name
+}
+
+// countStatus returns the service check status for the number of matching processes
+func (c *Check) countStatus(count int) (servicecheck.ServiceCheckStatus, string) {
+	if count < c.minCount {
+		return servicecheck.ServiceCheckCritical, fmt.Sprintf("%d processes found, expected at least %d", count, c.minCount)
+	}
+	if c.maxCount >= 0 && count > c.maxCount {
+		return servicecheck.ServiceCheckCritical, fmt.Sprintf("%d processes found, expected at most %d", count, c.maxCount)
+	}
+	return servicecheck.ServiceCheckOK, ""
+}
+
+// Configure configures the process_match check
+func (c *Check) Configure(senderManager sender.SenderManager, integrationConfigDigest uint64, rawInstance integration.Data, rawInitConfig integration.Data, source string, provider string) error {
+	// Make sure check id is different for each different config
+	// Must be called before CommonConfigure that uses checkID
+	c.BuildID(integrationConfigDigest, rawInstance, rawInitConfig)
+
+	err := c.CommonConfigure(senderManager, rawInitConfig, rawInstance, source, provider)
+	if err != nil {
+		return err
+	}
+	err = yaml.Unmarshal(rawInstance, &c.config)
+	if err != nil {
+		return err
+	}
+
+	if c.config.Name == "" {
+		return errors.New("`name` is required to identify the matching processes")
+	}
+	if len(c.config.ProcessNames) == 0 && c.config.CmdlineRegex == "" && len(c.config.Users) == 0 && c.config.CgroupRegex == "" {
+		return errors.New("please set at least one of `process_names`, `cmdline_regex`, `users` or `cgroup_regex` in the instance config")
+	}
+	if c.config.CmdlineRegex != "" {
+		if c.cmdlinePattern, err = regexp.Compile(c.config.CmdlineRegex); err != nil {
+			return fmt.Errorf("cannot compile `cmdline_regex` %q: %w", c.config.CmdlineRegex, err)
+		}
+	}
+	if c.config.CgroupRegex != "" {
+		if !cgroupsSupported {
+			return errors.New("`cgroup_regex` is only supported on Linux")
+		}
+		if c.cgroupPattern, err = regexp.Compile(c.config.CgroupRegex); err != nil {
+			return fmt.Errorf("cannot compile `cgroup_regex` %q: %w", c.config.CgroupRegex, err)
+		}
+	}
+
+	c.minCount, c.maxCount = 1, -1
+	if c.config.MinCount != nil {
+		if c.minCount = *c.config.MinCount; c.minCount < 0 {
+			return errors.New("`min_count` must not be negative")
+		}
+	}
+	if c.config.MaxCount != nil {
+		if c.maxCount = *c.config.MaxCount; c.maxCount < 0 {
+			return errors.New("`max_count` must not be negative")
+		}
+	}
+	if c.maxCount >= 0 && c.maxCount < c.minCount {
+		return fmt.Errorf("`max_count` (%d) must be greater than or equal to `min_count` (%d)", c.maxCount, c.minCount)
+	}
+
+	c.tags = []string{"process_name:" + c.config.Name}
+	if c.probe == nil {
+		c.probe = procutil.NewProcessProbe(procutil.WithPermission(true), procutil.WithIgnoreZombieProcesses(true))
+	}
+	return nil
+}
+
+// Cancel closes the process probe when the check is unscheduled
+func (c *Check) Cancel() {
+	if c.probe != nil {
+		c.probe.Close()
+	}
+}
+
+// Factory creates a new check factory
+func Factory() option.Option[func() check.Check] {
+	return option.New(newCheck)
+}
+
+func newCheck() check.Check {
+	return &Check{
+		CheckBase:   core.NewCheckBase(CheckName),
+		readCgroups: readCgroups,
+		lookupUser:  lookupUser,
+		now:         time.Now,
+		usernames:   make(map[int32]string),
+	}
+}
+
+func lookupUser(uid string) (string, error) {
+	u, err := user.LookupId(uid)
+	if err != nil {
+		return "", err
+	}
+	return u.Username, nil
+}
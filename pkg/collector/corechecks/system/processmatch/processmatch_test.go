@@ -0,0 +1,212 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+//go:build test
+
+package processmatch
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/integration"
+	"github.com/DataDog/datadog-agent/pkg/aggregator/mocksender"
+	"github.com/DataDog/datadog-agent/pkg/metrics/servicecheck"
+	"github.com/DataDog/datadog-agent/pkg/process/procutil"
+	"github.com/DataDog/datadog-agent/pkg/process/procutil/mocks"
+)
+
+func testProcess(pid int32, name string, cmdline []string, uid int32, createTime int64, cpuTime float64) *procutil.Process {
+	return &procutil.Process{
+		Pid:     pid,
+		Name:    name,
+		Comm:    name,
+		Cmdline: cmdline,
+		Uids:    []int32{uid},
+		Stats: &procutil.Stats{
+			CreateTime:  createTime,
+			NumThreads:  4,
+			OpenFdCount: 10,
+			MemInfo:     &procutil.MemoryInfoStat{RSS: 1000, VMS: 5000},
+			CPUTime:     &procutil.CPUTimesStat{User: cpuTime, System: cpuTime},
+		},
+	}
+}
+
+func newTestCheck(t *testing.T, probe procutil.Probe, instance string) (*Check, *mocksender.MockSender, error) {
+	check := newCheck().(*Check)
+	check.probe = probe
+	check.lookupUser = func(uid string) (string, error) {
+		switch uid {
+		case "0":
+			return "root", nil
+		case "33":
+			return "www-data", nil
+		}
+		return "", errors.New("unknown user")
+	}
+	check.readCgroups = func(pid int32) ([]string, error) {
+		if pid == 1 {
+			return []string{"/system.slice/nginx.service"}, nil
+		}
+		return []string{"/user.slice"}, nil
+	}
+
+	mockSender := mocksender.NewMockSender(t, "")
+	mockSender.SetupAcceptAll()
+	err := check.Configure(mockSender.GetSenderManager(), integration.FakeConfigHash, []byte(instance), nil, "test", "provider")
+	mocksender.SetSender(mockSender, check.ID())
+	return check, mockSender, err
+}
+
+func TestConfigure(t *testing.T) {
+	tests := []struct {
+		name          string
+		instance      string
+		expectedError string
+	}{
+		{
+			name:     "valid",
+			instance: "name: nginx\nprocess_names: [nginx]\nmin_count: 2\nmax_count: 4",
+		},
+		{
+			name:          "missing name",
+			instance:      "process_names: [nginx]",
+			expectedError: "`name` is required",
+		},
+		{
+			name:          "missing criteria",
+			instance:      "name: nginx",
+			expectedError: "please set at least one of `process_names`, `cmdline_regex`, `users` or `cgroup_regex`",
+		},
+		{
+			name:          "invalid regex",
+			instance:      "name: nginx\ncmdline_regex: 'nginx: (worker'",
+			expectedError: "cannot compile `cmdline_regex`",
+		},
+		{
+			name:          "negative count",
+			instance:      "name: nginx\nprocess_names: [nginx]\nmin_count: -1",
+			expectedError: "`min_count` must not be negative",
+		},
+		{
+			name:          "inverted range",
+			instance:      "name: nginx\nprocess_names: [nginx]\nmin_count: 3\nmax_count: 2",
+			expectedError: "`max_count` (2) must be greater than or equal to `min_count` (3)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := newTestCheck(t, mocks.NewProbe(t), tt.instance)
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	now := time.Now()
+	probe := mocks.NewProbe(t)
+	probe.On("ProcessesByPID", mock.Anything, true).Return(map[int32]*procutil.Process{
+		1: testProcess(1, "nginx", []string{"nginx: master process /usr/sbin/nginx"}, 0, 100, 1),
+		2: testProcess(2, "nginx", []string{"nginx: worker process"}, 33, 200, 2),
+		3: testProcess(3, "nginx", []string{"nginx: worker process"}, 33, 300, 3),
+		4: testProcess(4, "bash", []string{"/bin/bash"}, 33, 400, 4),
+	}, nil).Once()
+	probe.On("ProcessesByPID", mock.Anything, true).Return(map[int32]*procutil.Process{
+		1: testProcess(1, "nginx", []string{"nginx: master process /usr/sbin/nginx"}, 0, 100, 1),
+		// process 2 used 1 second of CPU time since the previous run
+		2: testProcess(2, "nginx", []string{"nginx: worker process"}, 33, 200, 2.5),
+		// pid 3 was reused by another process
+		3: testProcess(3, "nginx", []string{"nginx: worker process"}, 33, 301, 10),
+	}, nil).Once()
+
+	check, sender, err := newTestCheck(t, probe, `
+name: nginx
+process_names: [nginx]
+cmdline_regex: worker
+users: [www-data]
+`)
+	require.NoError(t, err)
+	check.now = func() time.Time { return now }
+
+	tags := []string{"process_name:nginx"}
+	require.NoError(t, check.Run())
+	sender.AssertMetric(t, "Gauge", "process_match.count", 2, "", tags)
+	sender.AssertMetric(t, "Gauge", "process_match.mem.rss", 2000, "", tags)
+	sender.AssertMetric(t, "Gauge", "process_match.mem.vms", 10000, "", tags)
+	sender.AssertMetric(t, "Gauge", "process_match.threads", 8, "", tags)
+	sender.AssertMetric(t, "Gauge", "process_match.open_file_descriptors", 20, "", tags)
+	sender.AssertMetricMissing(t, "Gauge", "process_match.cpu.pct")
+	sender.AssertServiceCheck(t, "process_match.up", servicecheck.ServiceCheckOK, "", tags, "")
+
+	sender.ResetCalls()
+	check.now = func() time.Time { return now.Add(10 * time.Second) }
+	require.NoError(t, check.Run())
+	sender.AssertMetric(t, "Gauge", "process_match.count", 2, "", tags)
+	sender.AssertMetric(t, "Gauge", "process_match.cpu.pct", 10, "", tags)
+}
+
+func TestRunCountOutOfRange(t *testing.T) {
+	probe := mocks.NewProbe(t)
+	probe.On("ProcessesByPID", mock.Anything, true).Return(map[int32]*procutil.Process{
+		1: testProcess(1, "nginx", []string{"nginx: master process"}, 0, 100, 1),
+		2: testProcess(2, "nginx", []string{"nginx: worker process"}, 33, 200, 1),
+	}, nil)
+
+	check, sender, err := newTestCheck(t, probe, "name: nginx\nprocess_names: [nginx]\nmax_count: 1")
+	require.NoError(t, err)
+	require.NoError(t, check.Run())
+	sender.AssertServiceCheck(t, "process_match.up", servicecheck.ServiceCheckCritical, "", []string{"process_name:nginx"}, "2 processes found, expected at most 1")
+
+	check, sender, err = newTestCheck(t, probe, "name: redis\nprocess_names: [redis-server]")
+	require.NoError(t, err)
+	require.NoError(t, check.Run())
+	sender.AssertMetric(t, "Gauge", "process_match.count", 0, "", []string{"process_name:redis"})
+	sender.AssertServiceCheck(t, "process_match.up", servicecheck.ServiceCheckCritical, "", []string{"process_name:redis"}, "0 processes found, expected at least 1")
+}
+
+func TestRunCgroup(t *testing.T) {
+	if !cgroupsSupported {
+		t.Skip("cgroups are only supported on Linux")
+	}
+	probe := mocks.NewProbe(t)
+	probe.On("ProcessesByPID", mock.Anything, true).Return(map[int32]*procutil.Process{
+		1: testProcess(1, "nginx", []string{"nginx: master process"}, 0, 100, 1),
+		2: testProcess(2, "nginx", []string{"nginx: master process"}, 0, 200, 1),
+	}, nil)
+
+	check, sender, err := newTestCheck(t, probe, "name: nginx\ncgroup_regex: nginx\\.service$")
+	require.NoError(t, err)
+	require.NoError(t, check.Run())
+	sender.AssertMetric(t, "Gauge", "process_match.count", 1, "", []string{"process_name:nginx"})
+}
+
+func TestRunError(t *testing.T) {
+	probe := mocks.NewProbe(t)
+	probe.On("ProcessesByPID", mock.Anything, true).Return(nil, errors.New("permission denied"))
+
+	check, _, err := newTestCheck(t, probe, "name: nginx\nprocess_names: [nginx]")
+	require.NoError(t, err)
+	assert.EqualError(t, check.Run(), "unable to list processes: permission denied")
+}
+
+func TestProcessNames(t *testing.T) {
+	proc := &procutil.Process{
+		Name:    "php-fpm: pool w",
+		Comm:    "php-fpm: pool w",
+		Exe:     "/usr/sbin/php-fpm8.2",
+		Cmdline: []string{"php-fpm: pool www"},
+	}
+	assert.Equal(t, []string{"php-fpm: pool w", "php-fpm: pool w", "php-fpm8.2", "php-fpm: pool www"}, processNames(proc))
+}
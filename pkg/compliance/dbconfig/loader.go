@@ -42,6 +42,10 @@ func GetProcResourceType(proc *process.Process) (types.ResourceType, bool) {
 		return types.ResourceTypeDbPostgresql, true
 	case "mongod":
 		return types.ResourceTypeDbMongodb, true
+	case "mysqld", "mariadbd":
+		return types.ResourceTypeDbMysql, true
+	case "redis-server":
+		return types.ResourceTypeDbRedis, true
 	case "java":
 		cmdline, _ := proc.CmdlineSlice()
 		if len(cmdline) > 0 && cmdline[len(cmdline)-1] == "org.apache.cassandra.service.CassandraDaemon" {
@@ -66,6 +70,10 @@ func LoadConfiguration(ctx context.Context, rootPath string, proc *process.Proce
 		conf, ok = LoadMongoDBConfig(ctx, rootPath, proc)
 	case types.ResourceTypeDbCassandra:
 		conf, ok = LoadCassandraConfig(ctx, rootPath, proc)
+	case types.ResourceTypeDbMysql:
+		conf, ok = LoadMySQLConfig(ctx, rootPath, proc)
+	case types.ResourceTypeDbRedis:
+		conf, ok = LoadRedisConfig(ctx, rootPath, proc)
 	default:
 		ok = false
 	}
@@ -105,6 +113,10 @@ func LoadDBResourceFromPID(ctx context.Context, pid int32) (*DBResource, bool) {
 		conf, ok = LoadMongoDBConfig(ctx, hostroot, proc)
 	case types.ResourceTypeDbCassandra:
 		conf, ok = LoadCassandraConfig(ctx, hostroot, proc)
+	case types.ResourceTypeDbMysql:
+		conf, ok = LoadMySQLConfig(ctx, hostroot, proc)
+	case types.ResourceTypeDbRedis:
+		conf, ok = LoadRedisConfig(ctx, hostroot, proc)
 	default:
 		ok = false
 	}
@@ -0,0 +1,234 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package dbconfig
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/DataDog/datadog-agent/pkg/compliance/utils"
+
+	"github.com/shirou/gopsutil/v4/process"
+)
+
+// mysqlDefaultConfigPaths are the option files read by default by MySQL and
+// MariaDB servers, in order. Later files override the earlier ones.
+var mysqlDefaultConfigPaths = []string{
+	"/etc/my.cnf",
+	"/etc/mysql/my.cnf",
+	"/usr/etc/my.cnf",
+	"/usr/local/etc/my.cnf",
+}
+
+// LoadMySQLConfig loads and extracts the MySQL or MariaDB configuration data
+// found on the system. The option files are either the one given by the
+// --defaults-file flag, or the default ones followed by the one given by the
+// --defaults-extra-file flag. The server flags override the options files.
+func LoadMySQLConfig(ctx context.Context, hostroot string, proc *process.Process) (*DBConfig, bool) {
+	result := newDBConfig(ctx, proc)
+
+	var defaultsFile, defaultsExtraFile string
+	cmdline, _ := proc.CmdlineSlice()
+	foreachFlags(cmdline, func(k, v string) {
+		switch k {
+		case "--defaults-file":
+			defaultsFile = v
+		case "--defaults-extra-file":
+			defaultsExtraFile = v
+		}
+	})
+
+	root, err := os.OpenRoot(hostroot)
+	if err != nil {
+		return nil, false
+	}
+	defer root.Close()
+
+	var configPaths []string
+	if defaultsFile != "" {
+		configPaths = []string{resolveProcPath(proc, defaultsFile)}
+	} else {
+		configPaths = append(configPaths, mysqlDefaultConfigPaths...)
+		if defaultsExtraFile != "" {
+			configPaths = append(configPaths, resolveProcPath(proc, defaultsExtraFile))
+		}
+	}
+
+	configData := make(map[string]string)
+	var (
+		configPath string
+		configFi   os.FileInfo
+	)
+	for _, path := range configPaths {
+		fi, ok := parseMySQLConfig(proc, root, path, configData, 0)
+		if ok && configFi == nil {
+			configPath, configFi = path, fi
+		}
+	}
+	if defaultsFile != "" && configFi == nil {
+		return nil, false
+	}
+
+	foreachFlags(cmdline, func(k, v string) {
+		if name, ok := strings.CutPrefix(k, "--"); ok {
+			setMySQLOption(configData, name, v, true)
+		}
+	})
+
+	if configFi == nil {
+		// mysqld can be run without any option file.
+		result.ConfigData = configData
+		return &result, true
+	}
+	setConfigFileMetadata(&result, configFi, configPath, configData)
+	return &result, true
+}
+
+// resolveProcPath returns the absolute path of a path given on the command
+// line of a process.
+func resolveProcPath(proc *process.Process, path string) string {
+	if !filepath.IsAbs(path) {
+		cwd, _ := proc.Cwd()
+		path = filepath.Join(cwd, path)
+	}
+	return filepath.Clean(path)
+}
+
+// parseMySQLConfig loads and parses the given option file, following its
+// !include and !includedir directives. Only the options of the groups read by
+// the server, and known to be relevant, are kept.
+//
+// references:
+//   - https://dev.mysql.com/doc/refman/8.0/en/option-files.html
+//   - https://mariadb.com/kb/en/configuring-mariadb-with-option-files/
+func parseMySQLConfig(proc *process.Process, root *os.Root, configPath string, config map[string]string, includeDepth int) (os.FileInfo, bool) {
+	// protect ourselves from circular includes
+	if includeDepth > 10 {
+		return nil, false
+	}
+
+	b, fi, err := utils.ReadProcessFileLimit(proc, root, configPath, maxFileSize)
+	if err != nil {
+		return nil, false
+	}
+
+	var serverGroup bool
+	s := bufio.NewScanner(bytes.NewReader(b))
+	s.Split(bufio.ScanLines)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+
+		if includedPath, ok := strings.CutPrefix(line, "!includedir"); ok {
+			includedPath = strings.TrimSpace(includedPath)
+			if !filepath.IsAbs(includedPath) {
+				includedPath = filepath.Join(filepath.Dir(configPath), includedPath)
+			}
+			for _, includedPath := range utils.RootedGlob(root.Name(), filepath.Join(includedPath, "*.cnf")) {
+				_, _ = parseMySQLConfig(proc, root, includedPath, config, includeDepth+1)
+			}
+			continue
+		}
+		if includedPath, ok := strings.CutPrefix(line, "!include"); ok {
+			includedPath = strings.TrimSpace(includedPath)
+			if !filepath.IsAbs(includedPath) {
+				includedPath = filepath.Join(filepath.Dir(configPath), includedPath)
+			}
+			_, _ = parseMySQLConfig(proc, root, includedPath, config, includeDepth+1)
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			group, _, _ := strings.Cut(strings.TrimPrefix(line, "["), "]")
+			serverGroup = isMySQLServerGroup(strings.ToLower(strings.TrimSpace(group)))
+			continue
+		}
+
+		if !serverGroup {
+			continue
+		}
+
+		key, value, hasValue := strings.Cut(line, "=")
+		setMySQLOption(config, strings.TrimSpace(key), parseMySQLValue(value), hasValue)
+	}
+
+	return fi, true
+}
+
+// isMySQLServerGroup returns whether the options of the given group are read
+// by the MySQL or MariaDB server.
+func isMySQLServerGroup(group string) bool {
+	switch group {
+	case "mysqld", "server", "mysqld_safe", "mariadb", "mariadbd", "client-server", "galera":
+		return true
+	}
+	return strings.HasPrefix(group, "mysqld-") || strings.HasPrefix(group, "mariadb-")
+}
+
+// setMySQLOption stores a known option. Dashes and underscores are
+// interchangeable in option names, the `loose` prefix is ignored and boolean
+// values are normalized to the ON / OFF nomenclature. Like mysqld, an option
+// given without a value is enabled and the `skip`, `disable` and `enable`
+// prefixes turn the option they prefix OFF or ON.
+func setMySQLOption(config map[string]string, key, value string, hasValue bool) {
+	key = strings.ReplaceAll(strings.ToLower(key), "-", "_")
+	key = strings.TrimPrefix(key, "loose_")
+	if _, ok := mysqlKnownConfigKeys[key]; !ok {
+		key, value, hasValue = cutMySQLOptionPrefix(key)
+		if _, ok := mysqlKnownConfigKeys[key]; !ok {
+			return
+		}
+	}
+
+	if !hasValue {
+		config[key] = "ON"
+		return
+	}
+
+	switch strings.ToLower(value) {
+	case "on", "true", "yes":
+		config[key] = "ON"
+	case "off", "false", "no":
+		config[key] = "OFF"
+	default:
+		config[key] = value
+	}
+}
+
+// cutMySQLOptionPrefix returns the option toggled by an option prefixed with
+// `skip`, `disable` or `enable`, and the value it is set to.
+func cutMySQLOptionPrefix(key string) (string, string, bool) {
+	for _, prefix := range []string{"skip_", "disable_"} {
+		if name, ok := strings.CutPrefix(key, prefix); ok {
+			return name, "OFF", true
+		}
+	}
+	if name, ok := strings.CutPrefix(key, "enable_"); ok {
+		return name, "ON", true
+	}
+	return key, "", false
+}
+
+// parseMySQLValue trims the value of an option, removing its quotes or its
+// trailing comment.
+func parseMySQLValue(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') {
+		if end := strings.IndexByte(value[1:], value[0]); end >= 0 {
+			return value[1 : end+1]
+		}
+	}
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = value[:i]
+	}
+	return strings.TrimSpace(value)
+}
@@ -0,0 +1,252 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package dbconfig
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"strings"
+
+	"github.com/DataDog/datadog-agent/pkg/compliance/utils"
+
+	"github.com/shirou/gopsutil/v4/process"
+)
+
+// redisDefaultConfigPaths are the usual locations of the Redis configuration,
+// used when the configuration file can't be found on the command line. Redis
+// rewrites its process title by default, hiding its arguments.
+var redisDefaultConfigPaths = []string{
+	"/etc/redis/redis.conf",
+	"/etc/redis.conf",
+	"/usr/local/etc/redis/redis.conf",
+	"/usr/local/etc/redis.conf",
+}
+
+// LoadRedisConfig loads and extracts the Redis configuration data found on
+// the system. The configuration data maps each directive to its values, with
+// one value per occurrence for the directives that can be repeated (save,
+// rename-command, ...). The options given on the command line override the
+// configuration file.
+func LoadRedisConfig(ctx context.Context, hostroot string, proc *process.Process) (*DBConfig, bool) {
+	result := newDBConfig(ctx, proc)
+
+	cmdline, _ := proc.CmdlineSlice()
+	configPaths := redisDefaultConfigPaths
+	var overrides []string
+	if len(cmdline) > 1 {
+		args := cmdline[1:]
+		if !strings.HasPrefix(args[0], "--") {
+			if args[0] != "-" {
+				configPaths = append([]string{resolveProcPath(proc, args[0])}, configPaths...)
+			}
+			args = args[1:]
+		}
+		overrides = args
+	}
+
+	root, err := os.OpenRoot(hostroot)
+	if err != nil {
+		return nil, false
+	}
+	defer root.Close()
+
+	configData := make(map[string][]string)
+	var (
+		configPath string
+		configFi   os.FileInfo
+	)
+	for _, path := range configPaths {
+		if fi, ok := parseRedisConfig(proc, root, path, configData, 0); ok {
+			configPath, configFi = path, fi
+			break
+		}
+	}
+
+	// command line options use the configuration file syntax, prefixed by --
+	var directive []string
+	for _, arg := range overrides {
+		if name, ok := strings.CutPrefix(arg, "--"); ok {
+			setRedisDirective(configData, directive)
+			directive = nil
+			if name != "" {
+				directive = []string{name}
+			}
+		} else if len(directive) > 0 {
+			directive = append(directive, arg)
+		}
+	}
+	setRedisDirective(configData, directive)
+
+	if configFi == nil {
+		// redis-server can be run without configuration file.
+		result.ConfigData = configData
+		return &result, true
+	}
+	setConfigFileMetadata(&result, configFi, configPath, configData)
+	return &result, true
+}
+
+// parseRedisConfig loads and parses the given configuration file, following
+// its include directives.
+//
+// reference: https://redis.io/docs/latest/operate/oss_and_stack/management/config-file/
+func parseRedisConfig(proc *process.Process, root *os.Root, configPath string, config map[string][]string, includeDepth int) (os.FileInfo, bool) {
+	// protect ourselves from circular includes
+	if includeDepth > 10 {
+		return nil, false
+	}
+
+	b, fi, err := utils.ReadProcessFileLimit(proc, root, configPath, maxFileSize)
+	if err != nil {
+		return nil, false
+	}
+
+	s := bufio.NewScanner(bytes.NewReader(b))
+	s.Split(bufio.ScanLines)
+	for s.Scan() {
+		args, ok := splitRedisArgs(s.Text())
+		if !ok || len(args) == 0 {
+			continue
+		}
+
+		if strings.ToLower(args[0]) == "include" && len(args) == 2 {
+			// relative includes are resolved from the working directory of
+			// the server
+			includedPath := resolveProcPath(proc, args[1])
+			if strings.ContainsAny(includedPath, "*?[") {
+				for _, includedPath := range utils.RootedGlob(root.Name(), includedPath) {
+					_, _ = parseRedisConfig(proc, root, includedPath, config, includeDepth+1)
+				}
+			} else {
+				_, _ = parseRedisConfig(proc, root, includedPath, config, includeDepth+1)
+			}
+			continue
+		}
+
+		setRedisDirective(config, args)
+	}
+
+	return fi, true
+}
+
+// setRedisDirective stores the arguments of a directive as a single value,
+// redacting secrets.
+func setRedisDirective(config map[string][]string, args []string) {
+	if len(args) == 0 {
+		return
+	}
+
+	name := strings.ToLower(args[0])
+	values := args[1:]
+	if _, ok := redisRedactedDirectives[name]; ok {
+		values = []string{"<redacted>"}
+	} else if name == "user" {
+		// ACL rules can contain passwords (>password, <password) and
+		// password hashes (#hash, !hash)
+		values = make([]string, 0, len(args)-1)
+		for _, rule := range args[1:] {
+			if rule != "" && strings.ContainsRune("<>#!", rune(rule[0])) {
+				rule = rule[:1] + "<redacted>"
+			}
+			values = append(values, rule)
+		}
+	}
+
+	value := strings.Join(quoteRedisArgs(values), " ")
+	if _, ok := redisRepeatableDirectives[name]; ok {
+		config[name] = append(config[name], value)
+	} else {
+		config[name] = []string{value}
+	}
+}
+
+// quoteRedisArgs quotes the empty arguments and the ones containing spaces so
+// that they remain distinguishable once joined.
+func quoteRedisArgs(args []string) []string {
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t") {
+			arg = `"` + strings.ReplaceAll(arg, `"`, `\"`) + `"`
+		}
+		quoted = append(quoted, arg)
+	}
+	return quoted
+}
+
+// splitRedisArgs splits a configuration line into its arguments, handling
+// double quoted strings with escapes and single quoted strings, like the
+// sdssplitargs function of Redis.
+func splitRedisArgs(line string) ([]string, bool) {
+	var args []string
+	for i := 0; i < len(line); {
+		c := line[i]
+		if c == ' ' || c == '\t' || c == '\r' {
+			i++
+			continue
+		}
+		if c == '#' && len(args) == 0 {
+			break
+		}
+
+		var arg strings.Builder
+		switch c {
+		case '"':
+			i++
+			for {
+				if i >= len(line) {
+					return nil, false // unterminated quotes
+				}
+				if line[i] == '\\' && i+1 < len(line) {
+					switch line[i+1] {
+					case 'n':
+						arg.WriteByte('\n')
+					case 'r':
+						arg.WriteByte('\r')
+					case 't':
+						arg.WriteByte('\t')
+					default:
+						arg.WriteByte(line[i+1])
+					}
+					i += 2
+					continue
+				}
+				if line[i] == '"' {
+					i++
+					break
+				}
+				arg.WriteByte(line[i])
+				i++
+			}
+		case '\'':
+			i++
+			for {
+				if i >= len(line) {
+					return nil, false // unterminated quotes
+				}
+				if line[i] == '\\' && i+1 < len(line) && line[i+1] == '\'' {
+					arg.WriteByte('\'')
+					i += 2
+					continue
+				}
+				if line[i] == '\'' {
+					i++
+					break
+				}
+				arg.WriteByte(line[i])
+				i++
+			}
+		default:
+			for i < len(line) && line[i] != ' ' && line[i] != '\t' && line[i] != '\r' {
+				arg.WriteByte(line[i])
+				i++
+			}
+		}
+		args = append(args, arg.String())
+	}
+	return args, true
+}
@@ -2094,3 +2094,217 @@ net:
 
 #auditLog:
 `
+
+func TestMySQLConfParsing(t *testing.T) {
+	hostroot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(hostroot, "/etc/mysql/conf.d"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(hostroot, "/etc/mysql/my.cnf"), []byte(mysqlConfigSample), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(hostroot, "/etc/mysql/conf.d/security.cnf"), []byte(mysqlIncludedConfigSample), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(hostroot, "/etc/mysql/conf.d/ignored.conf"), []byte("[mysqld]\nport = 1234\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	proc, stop := launchFakeProcess(context.Background(), t, "mysqld", "--port=3307", "--skip-name-resolve")
+	defer stop()
+
+	resourceType, ok := GetProcResourceType(proc)
+	assert.True(t, ok)
+	assert.Equal(t, types.ResourceTypeDbMysql, resourceType)
+
+	c, ok := LoadMySQLConfig(context.Background(), hostroot, proc)
+	require.True(t, ok)
+	assert.Equal(t, "/etc/mysql/my.cnf", c.ConfigFilePath)
+	assert.Equal(t, uint32(0644), c.ConfigFileMode)
+	assert.NotEmpty(t, c.ConfigFileUser)
+	assert.Equal(t, map[string]string{
+		"bind_address":             "127.0.0.1",
+		"datadir":                  "/var/lib/mysql",
+		"local_infile":             "0",
+		"log_bin":                  "OFF",
+		"log_error":                "/var/log/mysql/error.log",
+		"port":                     "3307",
+		"require_secure_transport": "ON",
+		"secure_file_priv":         "",
+		"skip_name_resolve":        "ON",
+		"skip_symbolic_links":      "ON",
+		"sql_mode":                 "STRICT_ALL_TABLES",
+		"user":                     "mysql",
+	}, c.ConfigData)
+}
+
+func TestMySQLConfParsingDefaultsFile(t *testing.T) {
+	hostroot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(hostroot, "/etc/mysql"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(hostroot, "/opt/mysql"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(hostroot, "/etc/mysql/my.cnf"), []byte(mysqlConfigSample), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(hostroot, "/opt/mysql/my.cnf"), []byte("[mysqld]\nlocal_infile = 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	proc, stop := launchFakeProcess(context.Background(), t, "mysqld", "--defaults-file=/opt/mysql/my.cnf")
+	defer stop()
+
+	c, ok := LoadMySQLConfig(context.Background(), hostroot, proc)
+	require.True(t, ok)
+	assert.Equal(t, "/opt/mysql/my.cnf", c.ConfigFilePath)
+	assert.Equal(t, uint32(0644), c.ConfigFileMode)
+	assert.Equal(t, map[string]string{"local_infile": "1"}, c.ConfigData)
+
+	proc, stop = launchFakeProcess(context.Background(), t, "mariadbd", "--defaults-file=/opt/mysql/missing.cnf")
+	defer stop()
+
+	_, ok = LoadMySQLConfig(context.Background(), hostroot, proc)
+	assert.False(t, ok)
+}
+
+func TestSetMySQLOption(t *testing.T) {
+	for _, tc := range []struct {
+		key      string
+		value    string
+		hasValue bool
+		expected map[string]string
+	}{
+		{key: "local-infile", expected: map[string]string{"local_infile": "ON"}},
+		{key: "local_infile", value: "false", hasValue: true, expected: map[string]string{"local_infile": "OFF"}},
+		{key: "secure_file_priv", value: "", hasValue: true, expected: map[string]string{"secure_file_priv": ""}},
+		{key: "loose-general-log", value: "1", hasValue: true, expected: map[string]string{"general_log": "1"}},
+		{key: "skip-name-resolve", expected: map[string]string{"skip_name_resolve": "ON"}},
+		{key: "skip-local-infile", expected: map[string]string{"local_infile": "OFF"}},
+		{key: "disable-log-bin", expected: map[string]string{"log_bin": "OFF"}},
+		{key: "loose-enable-general-log", expected: map[string]string{"general_log": "ON"}},
+		{key: "skip-unknown-option", expected: map[string]string{}},
+		{key: "max_allowed_packet", value: "64M", hasValue: true, expected: map[string]string{}},
+	} {
+		config := make(map[string]string)
+		setMySQLOption(config, tc.key, tc.value, tc.hasValue)
+		assert.Equal(t, tc.expected, config, tc.key)
+	}
+}
+
+func TestRedisConfParsing(t *testing.T) {
+	hostroot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(hostroot, "/etc/redis/conf.d"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(hostroot, "/etc/redis/redis.conf"), []byte(redisConfigSample), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(hostroot, "/etc/redis/conf.d/acl.conf"), []byte(redisIncludedConfigSample), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	proc, stop := launchFakeProcess(context.Background(), t, "redis-server", "--port", "6380", "--save", "60", "1000", "--protected-mode", "no")
+	defer stop()
+
+	resourceType, ok := GetProcResourceType(proc)
+	assert.True(t, ok)
+	assert.Equal(t, types.ResourceTypeDbRedis, resourceType)
+
+	c, ok := LoadRedisConfig(context.Background(), hostroot, proc)
+	require.True(t, ok)
+	assert.Equal(t, "/etc/redis/redis.conf", c.ConfigFilePath)
+	assert.Equal(t, uint32(0644), c.ConfigFileMode)
+	assert.NotEmpty(t, c.ConfigFileUser)
+	assert.Equal(t, map[string][]string{
+		"bind":           {"127.0.0.1 -::1"},
+		"port":           {"6380"},
+		"protected-mode": {"no"},
+		"requirepass":    {"<redacted>"},
+		"masterauth":     {"<redacted>"},
+		"rename-command": {"CONFIG \"\"", "FLUSHALL \"\""},
+		"save":           {"3600 1", "300 100", "60 1000"},
+		"logfile":        {"\"/var/log/redis/redis server.log\""},
+		"user": {
+			"default off",
+			"app on ><redacted> ~app:* +@read",
+			"admin on #<redacted> ~* +@all",
+		},
+	}, c.ConfigData)
+}
+
+func TestRedisSplitArgs(t *testing.T) {
+	for _, tc := range []struct {
+		line     string
+		expected []string
+		ok       bool
+	}{
+		{line: "", ok: true},
+		{line: "   # comment", ok: true},
+		{line: "port 6379", expected: []string{"port", "6379"}, ok: true},
+		{line: "\tsave  900 1 ", expected: []string{"save", "900", "1"}, ok: true},
+		{line: `requirepass "pass word"`, expected: []string{"requirepass", "pass word"}, ok: true},
+		{line: `logfile ""`, expected: []string{"logfile", ""}, ok: true},
+		{line: `notify "a\"b\n"`, expected: []string{"notify", "a\"b\n"}, ok: true},
+		{line: `dir 'it\'s'`, expected: []string{"dir", "it's"}, ok: true},
+		{line: `dir "unterminated`, ok: false},
+	} {
+		args, ok := splitRedisArgs(tc.line)
+		assert.Equal(t, tc.ok, ok, tc.line)
+		assert.Equal(t, tc.expected, args, tc.line)
+	}
+}
+
+const mysqlConfigSample = `
+# The MySQL database server configuration file.
+[client]
+port = 3306
+password = clientsecret
+
+[mysqld]
+user = mysql
+port = 3306
+bind-address = 127.0.0.1
+datadir = "/var/lib/mysql"
+log_error = /var/log/mysql/error.log # error log
+max_allowed_packet = 64M
+local-infile
+
+[mysqldump]
+quick
+
+!includedir /etc/mysql/conf.d/
+`
+
+const mysqlIncludedConfigSample = `
+[mysqld]
+local_infile = 0
+loose-skip-symbolic-links
+skip-log-bin
+secure_file_priv = ""
+require_secure_transport = true
+sql_mode = 'STRICT_ALL_TABLES'
+`
+
+const redisConfigSample = `
+# Redis configuration file example.
+bind 127.0.0.1 -::1
+port 6379
+protected-mode yes
+requirepass "s3cr3t pass"
+masterauth s3cr3t
+save 3600 1
+save 300 100
+rename-command CONFIG ""
+logfile "/var/log/redis/redis server.log"
+
+include /etc/redis/conf.d/*.conf
+`
+
+const redisIncludedConfigSample = `
+rename-command FLUSHALL ""
+user default off
+user app on >apppassword ~app:* +@read
+user admin on #e5e9fa1ba31ecd1ae84f75caaa474f3a663f05f4 ~* +@all
+`
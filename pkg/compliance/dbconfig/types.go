@@ -369,3 +369,110 @@ var postgresKnownConfigKeys = map[string]struct{}{
 	"wal_log_hints":                       {},
 	"work_mem":                            {},
 }
+
+var mysqlKnownConfigKeys = map[string]struct{}{
+	"admin_address":                  {},
+	"admin_port":                     {},
+	"allow_suspicious_udfs":          {},
+	"audit_log":                      {},
+	"audit_log_file":                 {},
+	"audit_log_format":               {},
+	"audit_log_policy":               {},
+	"authentication_policy":          {},
+	"automatic_sp_privileges":        {},
+	"basedir":                        {},
+	"bind_address":                   {},
+	"binlog_encryption":              {},
+	"binlog_expire_logs_seconds":     {},
+	"binlog_format":                  {},
+	"character_set_server":           {},
+	"connect_timeout":                {},
+	"datadir":                        {},
+	"default_authentication_plugin":  {},
+	"default_password_lifetime":      {},
+	"disconnect_on_expired_password": {},
+	"early_plugin_load":              {},
+	"enforce_gtid_consistency":       {},
+	"expire_logs_days":               {},
+	"general_log":                    {},
+	"general_log_file":               {},
+	"gtid_mode":                      {},
+	"innodb_encrypt_log":             {},
+	"innodb_encrypt_tables":          {},
+	"innodb_redo_log_encrypt":        {},
+	"innodb_undo_log_encrypt":        {},
+	"interactive_timeout":            {},
+	"local_infile":                   {},
+	"log_bin":                        {},
+	"log_error":                      {},
+	"log_error_verbosity":            {},
+	"log_output":                     {},
+	"log_raw":                        {},
+	"log_warnings":                   {},
+	"master_info_repository":         {},
+	"max_connect_errors":             {},
+	"max_connections":                {},
+	"max_user_connections":           {},
+	"mysqlx":                         {},
+	"mysqlx_bind_address":            {},
+	"mysqlx_port":                    {},
+	"old_passwords":                  {},
+	"password_history":               {},
+	"password_reuse_interval":        {},
+	"pid_file":                       {},
+	"plugin_dir":                     {},
+	"plugin_load":                    {},
+	"plugin_load_add":                {},
+	"port":                           {},
+	"relay_log_info_repository":      {},
+	"require_secure_transport":       {},
+	"secure_auth":                    {},
+	"secure_file_priv":               {},
+	"server_audit_events":            {},
+	"server_audit_file_path":         {},
+	"server_audit_logging":           {},
+	"skip_grant_tables":              {},
+	"skip_name_resolve":              {},
+	"skip_networking":                {},
+	"skip_show_database":             {},
+	"skip_symbolic_links":            {},
+	"slow_query_log":                 {},
+	"slow_query_log_file":            {},
+	"socket":                         {},
+	"sql_mode":                       {},
+	"ssl":                            {},
+	"ssl_ca":                         {},
+	"ssl_cert":                       {},
+	"ssl_cipher":                     {},
+	"ssl_key":                        {},
+	"symbolic_links":                 {},
+	"sync_binlog":                    {},
+	"tls_ciphersuites":               {},
+	"tls_version":                    {},
+	"tmpdir":                         {},
+	"user":                           {},
+	"validate_password.length":       {},
+	"validate_password.policy":       {},
+	"validate_password_length":       {},
+	"validate_password_policy":       {},
+	"wait_timeout":                   {},
+}
+
+// redisRepeatableDirectives are the directives that can be set multiple
+// times, each occurrence adding a value instead of overriding the previous
+// ones.
+var redisRepeatableDirectives = map[string]struct{}{
+	"client-output-buffer-limit": {},
+	"loadmodule":                 {},
+	"rename-command":             {},
+	"save":                       {},
+	"user":                       {},
+}
+
+// redisRedactedDirectives are the directives holding secrets.
+var redisRedactedDirectives = map[string]struct{}{
+	"masterauth":               {},
+	"requirepass":              {},
+	"tls-client-key-file-pass": {},
+	"tls-key-file-pass":        {},
+}
@@ -18,6 +18,10 @@ const (
 	ResourceTypeDbMongodb ResourceType = "db_mongodb"
 	// ResourceTypeDbPostgresql is used to represent a PostgreSQL database.
 	ResourceTypeDbPostgresql ResourceType = "db_postgresql"
+	// ResourceTypeDbMysql is used to represent a MySQL or MariaDB database.
+	ResourceTypeDbMysql ResourceType = "db_mysql"
+	// ResourceTypeDbRedis is used to represent a Redis database.
+	ResourceTypeDbRedis ResourceType = "db_redis"
 	// ResourceTypeAwsEksWorkerNode is used to represent an EKS worker node.
 	ResourceTypeAwsEksWorkerNode ResourceType = "aws_eks_worker_node"
 	// ResourceTypeAzureAksWorkerNode is used to represent an AKS worker node.
@@ -63,6 +63,22 @@ func DiscoverComponentsFromConfig(cfg config.Component) ([]constants.Configurati
 		log.Info("Database monitoring rds discovery is enabled: Adding the rds listener")
 	}
 
+	// Add the systemd listener when systemd units are collected by workloadmeta
+	if cfg.GetBool("systemd_units.enabled") && flavor.GetFlavor() == flavor.DefaultAgent {
+		detectedListeners = append(detectedListeners, pkgconfigsetup.Listeners{Name: "systemd"})
+		log.Info("Systemd units collection is enabled: Adding the systemd listener")
+	}
+
+	// Auto-add the pulled configs provider when a source is configured
+	if cfg.GetString("pulled_configs.url") != "" || cfg.GetString("pulled_configs.path") != "" {
+		log.Info("Pulled configs source is configured: Adding the pulled_configs config provider")
+		detectedProviders = append(detectedProviders, constants.ConfigurationProviders{
+			Name:         names.PulledConfigsRegisterName,
+			Polling:      true,
+			PollInterval: cfg.GetString("pulled_configs.poll_interval"),
+		})
+	}
+
 	// 1) Auto-add Prometheus HTTP SD config provider when a URL is configured
 	// 2) Auto-add file-based kube service and endpoints config providers based on check config files.
 	if flavor.GetFlavor() == flavor.ClusterAgent {
@@ -143,13 +159,14 @@ func DiscoverComponentsFromEnv(cfg config.Component) ([]constants.ConfigurationP
 		return detectedProviders, detectedListeners
 	}
 
-	// Container environments include Docker, Containerd, Podman, and ECS sidecar mode
+	// Container environments include Docker, Containerd, Podman, Incus/LXD, and ECS sidecar mode
 	// Note: ECS in daemon mode (EC2 or Managed Instances) relies on Docker/Containerd detection
 	// Note: env.IsECSSidecarMode should only be called after env.DetectFeatures() has run,
 	// which is guaranteed by the defer in LoadDatadog*() functions during normal agent startup.
 	isContainerEnv := env.IsFeaturePresent(env.Docker) ||
 		env.IsFeaturePresent(env.Containerd) ||
 		env.IsFeaturePresent(env.Podman) ||
+		env.IsFeaturePresent(env.Incus) ||
 		env.IsECSSidecarMode(cfg)
 	isKubeEnv := env.IsFeaturePresent(env.Kubernetes)
 
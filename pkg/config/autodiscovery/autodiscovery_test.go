@@ -107,6 +107,22 @@ func TestDiscoverComponentsFromEnvForProcess(t *testing.T) {
 	})
 }
 
+func TestDiscoverComponentsFromConfigForSystemd(t *testing.T) {
+	configmock.SetDefaultConfigType(t, "yaml")
+	flavor.SetTestFlavor(t, flavor.DefaultAgent)
+
+	cfg := configmock.NewFromYAML(t, `
+systemd_units:
+  enabled: true
+`)
+	_, listeners := DiscoverComponentsFromConfig(cfg)
+	assert.True(t, containsListener(listeners, "systemd"))
+
+	cfg = configmock.NewFromYAML(t, ``)
+	_, listeners = DiscoverComponentsFromConfig(cfg)
+	assert.False(t, containsListener(listeners, "systemd"))
+}
+
 func TestDiscoverComponentsFromConfigForDDI(t *testing.T) {
 	configmock.SetDefaultConfigType(t, "yaml")
 
@@ -35,6 +35,8 @@ const (
 	CloudFoundry Feature = "cloudfoundry"
 	// Podman containers storage path accessible
 	Podman Feature = "podman"
+	// Incus or LXD socket present
+	Incus Feature = "incus"
 	// PodResources socket present
 	PodResources Feature = "podresources"
 	// NVML library present for GPU detection
@@ -28,6 +28,9 @@ const (
 	defaultLinuxCrioSocket             = "/var/run/crio/crio.sock"
 	defaultHostMountPrefix             = "/host"
 	defaultPodmanContainersStoragePath = "/var/lib/containers/storage"
+	defaultIncusSocket                 = "/var/lib/incus/unix.socket"
+	defaultLXDSnapSocket               = "/var/snap/lxd/common/lxd/unix.socket"
+	defaultLXDSocket                   = "/var/lib/lxd/unix.socket"
 	unixSocketPrefix                   = "unix://"
 	winNamedPipePrefix                 = "npipe://"
 	defaultNVMLLibraryName             = "libnvidia-ml.so.1"
@@ -50,6 +53,7 @@ func init() {
 	registerFeature(ECSOrchestratorExplorer)
 	registerFeature(CloudFoundry)
 	registerFeature(Podman)
+	registerFeature(Incus)
 	registerFeature(PodResources)
 	registerFeature(KubernetesDevicePlugins)
 	registerFeature(NVML)
@@ -70,6 +74,7 @@ func IsAnyContainerFeaturePresent() bool {
 		IsFeaturePresent(EKSFargate) ||
 		IsFeaturePresent(CloudFoundry) ||
 		IsFeaturePresent(Podman) ||
+		IsFeaturePresent(Incus) ||
 		IsFeaturePresent(NonstandardCRIRuntime)
 }
 
@@ -80,6 +85,7 @@ func detectContainerFeatures(features FeatureMap, cfg model.ReaderWriter) {
 	detectAWSEnvironments(features, cfg)
 	detectCloudFoundry(features, cfg)
 	detectPodman(features, cfg)
+	detectIncus(features, cfg)
 	detectPodResources(features, cfg)
 	detectDevicePlugins(features, cfg)
 	detectNVML(features, cfg)
@@ -278,6 +284,28 @@ func detectPodmanInHomeDir(homeBase string, features FeatureMap) {
 	}
 }
 
+func detectIncus(features FeatureMap, cfg model.Reader) {
+	if runtime.GOOS != "linux" {
+		return
+	}
+
+	paths := getDefaultIncusPaths()
+	if socketPath := cfg.GetString("incus_socket_path"); socketPath != "" {
+		paths = []string{socketPath}
+	}
+
+	for _, socketPath := range paths {
+		exists, reachable := socket.IsAvailable(socketPath, socketTimeout)
+		if exists && reachable {
+			log.Infof("Agent found Incus socket at: %s", socketPath)
+			features[Incus] = struct{}{}
+			return
+		} else if exists && !reachable {
+			log.Infof("Agent found Incus socket at: %s but socket not reachable (permissions?)", socketPath)
+		}
+	}
+}
+
 func detectPodResources(features FeatureMap, cfg model.Reader) {
 	// We only check the path from config. Default socket path is defined in the config,
 	// without the unix:/// prefix, as socket.IsAvailable receives a filesystem path.
@@ -397,6 +425,22 @@ func getDefaultPodmanPaths() []string {
 	return paths
 }
 
+// getDefaultIncusPaths returns the sockets of Incus and of LXD, installed
+// either from the snap or from the distribution packages.
+// NOTE: the same paths are used in pkg/util/incus (DefaultSocketPaths).
+func getDefaultIncusPaths() []string {
+	paths := []string{}
+	for _, prefix := range getHostMountPrefixes() {
+		paths = append(
+			paths,
+			path.Join(prefix, defaultIncusSocket),
+			path.Join(prefix, defaultLXDSnapSocket),
+			path.Join(prefix, defaultLXDSocket),
+		)
+	}
+	return paths
+}
+
 // getDefaultNvmlPaths returns the common paths where the NVML library may be installed.
 // NOTE: This logic is intentionally duplicated in pkg/gpu/safenvml/lib.go
 // (generateDefaultNvmlPaths). We keep it inline here to avoid adding a dependency on
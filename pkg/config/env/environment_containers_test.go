@@ -116,3 +116,16 @@ func TestDetectPodmanInHomeDir(t *testing.T) {
 		assert.False(t, found, "Podman feature should not be detected")
 	})
 }
+
+func TestGetDefaultIncusPaths(t *testing.T) {
+	t.Setenv("DOCKER_DD_AGENT", "true")
+
+	assert.Equal(t, []string{
+		"/var/lib/incus/unix.socket",
+		"/var/snap/lxd/common/lxd/unix.socket",
+		"/var/lib/lxd/unix.socket",
+		"/host/var/lib/incus/unix.socket",
+		"/host/var/snap/lxd/common/lxd/unix.socket",
+		"/host/var/lib/lxd/unix.socket",
+	}, getDefaultIncusPaths())
+}
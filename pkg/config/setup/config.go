@@ -730,6 +730,7 @@ func resolveSecrets(config pkgconfigmodel.Config, secretResolver secrets.Compone
 		AllowedNamespace:             config.GetStringSlice("secret_allowed_k8s_namespace"),
 		ImageToHandle:                config.GetStringMapStringSlice("secret_image_to_handle"),
 		APIKeyFailureRefreshInterval: config.GetInt("secret_refresh_on_api_key_failure_interval"),
+		RefreshOnTTL:                 config.GetBool("secret_refresh_on_ttl"),
 	})
 
 	if config.GetString("secret_backend_command") != "" || config.GetString("secret_backend_type") != "" || len(multiBackends) > 0 {
@@ -30,6 +30,11 @@ func PrintConfigCheck(w io.Writer, cr integration.ConfigCheckResponse, withDebug
 		}
 	}
 
+	if len(cr.ProviderStatus) > 0 {
+		fmt.Fprintf(w, "=== Configuration provider %s ===\n", color.BlueString("status"))
+		printProviderStatus(w, cr.ProviderStatus)
+	}
+
 	for _, configResponse := range cr.Configs {
 		PrintConfigWithInstanceIDs(w, configResponse.Config, configResponse.InstanceIDs, "")
 	}
@@ -218,3 +223,25 @@ func printContainerExclusionRulesInfo(w io.Writer, c *integration.Config) {
 		fmt.Fprintln(w, color.BlueString(msg))
 	}
 }
+
+// printProviderStatus prints the status reported by config providers, sorted by provider and field name
+func printProviderStatus(w io.Writer, providerStatus map[string]map[string]string) {
+	providerNames := make([]string, 0, len(providerStatus))
+	for name := range providerStatus {
+		providerNames = append(providerNames, name)
+	}
+	slices.Sort(providerNames)
+
+	for _, name := range providerNames {
+		fmt.Fprintf(w, "\n%s\n", color.CyanString(name))
+		fields := make([]string, 0, len(providerStatus[name]))
+		for field := range providerStatus[name] {
+			fields = append(fields, field)
+		}
+		slices.Sort(fields)
+		for _, field := range fields {
+			fmt.Fprintf(w, "%s: %s\n", color.BlueString(field), providerStatus[name][field])
+		}
+	}
+	fmt.Fprintln(w)
+}
@@ -26,6 +26,10 @@ import (
 	"github.com/DataDog/datadog-agent/pkg/util/log"
 )
 
+// systemdUnitServiceType is the type of the services of systemd units, i.e.
+// the workloadmeta kind used as prefix of their service IDs.
+const systemdUnitServiceType = "systemd_unit"
+
 // Scheduler creates and deletes new sources and services to start or stop
 // log collection based on information from autodiscovery.
 //
@@ -270,6 +274,8 @@ func CreateSources(config integration.Config) ([]*sourcesPkg.LogSource, error) {
 				// cfg.Type is not overwritten as tailing a file from a Docker, Kubernetes, or DDI AD configuration
 				// is explicitly supported (other combinations may be supported later)
 				cfg.Identifier = service.Identifier
+			} else if service.Type == systemdUnitServiceType {
+				setSystemdUnitJournal(cfg, service.Identifier)
 			} else {
 				cfg.Type = service.Type
 				cfg.Identifier = service.Identifier // used for matching a source with a service
@@ -295,6 +301,20 @@ func CreateSources(config integration.Config) ([]*sourcesPkg.LogSource, error) {
 	return sources, nil
 }
 
+// setSystemdUnitJournal collects the logs of a systemd unit from the journal,
+// unless the config already selects the units to tail.
+func setSystemdUnitJournal(cfg *logsConfig.LogsConfig, unit string) {
+	cfg.Type = logsConfig.JournaldType
+	cfg.Identifier = unit
+	if len(cfg.IncludeSystemUnits) == 0 {
+		cfg.IncludeSystemUnits = []string{unit}
+	}
+	// the journald launcher tails a single source per config ID
+	if cfg.ConfigID == "" {
+		cfg.ConfigID = systemdUnitServiceType + ":" + unit
+	}
+}
+
 func preservesExplicitLogType(provider, logType string) bool {
 	switch logType {
 	case logsConfig.FileType, logsConfig.TCPType, logsConfig.UDPType, logsConfig.IntegrationType:
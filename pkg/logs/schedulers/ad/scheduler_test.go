@@ -173,6 +173,26 @@ func TestScheduleInstrumentationChecksUsesServiceTypeByDefault(t *testing.T) {
 	assert.Equal(t, "a1887023ed72a2b0d083ef465e8edfe4932a25731d4bda2f39f288f70af3405b", logSource.Config.Identifier)
 }
 
+func TestScheduleSystemdUnitConfigUsesJournald(t *testing.T) {
+	scheduler, spy := setup()
+	configSource := integration.Config{
+		LogsConfig:    []byte(`[{"service":"foo","source":"nginx"}]`),
+		ADIdentifiers: []string{"systemd://nginx.service"},
+		Provider:      names.File,
+		ServiceID:     "systemd_unit://nginx.service",
+	}
+
+	scheduler.Schedule([]integration.Config{configSource})
+
+	require.Equal(t, 1, len(spy.Events))
+	require.True(t, spy.Events[0].Add)
+	logSource := spy.Events[0].Source
+	assert.Equal(t, config.JournaldType, logSource.Config.Type)
+	assert.Equal(t, []string{"nginx.service"}, []string(logSource.Config.IncludeSystemUnits))
+	assert.Equal(t, "systemd_unit:nginx.service", logSource.Config.ConfigID)
+	assert.Equal(t, "nginx.service", logSource.Config.Identifier)
+}
+
 func TestScheduleConfigCreatesNewSourceServiceFallback(t *testing.T) {
 	scheduler, spy := setup()
 	configSource := integration.Config{
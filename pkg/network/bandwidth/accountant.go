@@ -0,0 +1,269 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+// Package bandwidth accounts the network traffic of the processes, containers
+// and services of the host over sliding windows, to find its top talkers
+package bandwidth
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DataDog/datadog-agent/pkg/network"
+)
+
+// GroupBy selects how the traffic is aggregated
+type GroupBy string
+
+// Supported aggregations
+const (
+	GroupByProcess   GroupBy = "process"
+	GroupByContainer GroupBy = "container"
+	GroupByService   GroupBy = "service"
+)
+
+// ParseGroupBy parses an aggregation
+func ParseGroupBy(s string) (GroupBy, error) {
+	switch g := GroupBy(s); g {
+	case GroupByProcess, GroupByContainer, GroupByService:
+		return g, nil
+	default:
+		return "", fmt.Errorf("unknown aggregation %q, expected %s, %s or %s", s, GroupByProcess, GroupByContainer, GroupByService)
+	}
+}
+
+const serviceTagPrefix = "service:"
+
+// Counters holds the traffic of a talker
+type Counters struct {
+	SentBytes   uint64 `json:"sent_bytes"`
+	RecvBytes   uint64 `json:"recv_bytes"`
+	SentPackets uint64 `json:"sent_packets"`
+	RecvPackets uint64 `json:"recv_packets"`
+}
+
+func (c *Counters) add(o Counters) {
+	c.SentBytes += o.SentBytes
+	c.RecvBytes += o.RecvBytes
+	c.SentPackets += o.SentPackets
+	c.RecvPackets += o.RecvPackets
+}
+
+// TotalBytes returns the bytes sent and received
+func (c Counters) TotalBytes() uint64 {
+	return c.SentBytes + c.RecvBytes
+}
+
+// Talker is the traffic of a process, a container or a service over a window
+type Talker struct {
+	Pid         uint32 `json:"pid,omitempty"`
+	Name        string `json:"name,omitempty"`
+	ContainerID string `json:"container_id,omitempty"`
+	Service     string `json:"service,omitempty"`
+	Counters
+	SentBytesPerSecond float64 `json:"sent_bytes_per_second"`
+	RecvBytesPerSecond float64 `json:"recv_bytes_per_second"`
+}
+
+// Report lists the top talkers over a window
+type Report struct {
+	GroupBy GroupBy `json:"group_by"`
+	// Window is the duration the traffic was accounted over, which is shorter
+	// than the requested window when the accounting started recently
+	Window  time.Duration `json:"window"`
+	Talkers []Talker      `json:"talkers"`
+}
+
+// key identifies the process a connection belongs to
+type key struct {
+	pid         uint32
+	containerID string
+	service     string
+}
+
+type bucket struct {
+	start   time.Time
+	traffic map[key]Counters
+}
+
+// Accountant aggregates the traffic of the connections per process in
+// buckets of a fixed resolution, covering the longest window that can be
+// reported
+type Accountant struct {
+	mu         sync.Mutex
+	resolution time.Duration
+	buckets    []bucket
+	// since is the time the accounting started at
+	since time.Time
+
+	// processName resolves the name of the processes of the report
+	processName func(pid uint32) string
+}
+
+// NewAccountant returns an accountant keeping the traffic of the last
+// maxWindow, with the given resolution
+func NewAccountant(resolution, maxWindow time.Duration) *Accountant {
+	count := int((maxWindow + resolution - 1) / resolution)
+	return &Accountant{
+		resolution:  resolution,
+		buckets:     make([]bucket, count),
+		processName: processName,
+	}
+}
+
+// MaxWindow returns the longest window that can be reported
+func (a *Accountant) MaxWindow() time.Duration {
+	return time.Duration(len(a.buckets)) * a.resolution
+}
+
+// Reset drops the accounted traffic and restarts the accounting at now
+func (a *Accountant) Reset(now time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i := range a.buckets {
+		a.buckets[i] = bucket{}
+	}
+	a.since = now
+}
+
+// Add accounts the traffic of the connections since the previous call,
+// which is given by their Last counters
+func (a *Accountant) Add(now time.Time, conns []network.ConnectionStats) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	b := a.bucket(now)
+	for i := range conns {
+		c := &conns[i]
+		traffic := Counters{
+			SentBytes:   c.Last.SentBytes,
+			RecvBytes:   c.Last.RecvBytes,
+			SentPackets: c.Last.SentPackets,
+			RecvPackets: c.Last.RecvPackets,
+		}
+		if traffic == (Counters{}) {
+			continue
+		}
+
+		k := keyOf(c)
+		counters := b.traffic[k]
+		counters.add(traffic)
+		b.traffic[k] = counters
+	}
+}
+
+// bucket returns the bucket holding the traffic of now, clearing it if it
+// holds the traffic of an older period
+func (a *Accountant) bucket(now time.Time) *bucket {
+	start := now.Truncate(a.resolution)
+	b := &a.buckets[(start.UnixNano()/int64(a.resolution))%int64(len(a.buckets))]
+	if !b.start.Equal(start) {
+		b.start = start
+		b.traffic = make(map[key]Counters, len(b.traffic))
+	}
+	return b
+}
+
+// Top returns the n talkers with the most traffic over the window, all of
+// them if n is zero
+func (a *Accountant) Top(now time.Time, window time.Duration, groupBy GroupBy, n int) Report {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	window = min(max(window, a.resolution), a.MaxWindow())
+	from := now.Add(-window)
+	if from.Before(a.since) {
+		from = a.since
+	}
+
+	talkers := make(map[key]*Talker)
+	for i := range a.buckets {
+		b := &a.buckets[i]
+		// the traffic of a bucket was sampled after its start, it is included
+		// when it starts within the window
+		if b.start.IsZero() || b.start.After(now) || !b.start.After(from) {
+			continue
+		}
+		for k, counters := range b.traffic {
+			k = groupKey(k, groupBy)
+			t, ok := talkers[k]
+			if !ok {
+				t = &Talker{Pid: k.pid, ContainerID: k.containerID, Service: k.service}
+				talkers[k] = t
+			}
+			t.Counters.add(counters)
+		}
+	}
+
+	report := Report{
+		GroupBy: groupBy,
+		Window:  max(now.Sub(from), 0),
+		Talkers: make([]Talker, 0, len(talkers)),
+	}
+	for _, t := range talkers {
+		report.Talkers = append(report.Talkers, *t)
+	}
+	slices.SortFunc(report.Talkers, func(a, b Talker) int {
+		if c := cmp.Compare(b.TotalBytes(), a.TotalBytes()); c != 0 {
+			return c
+		}
+		return cmp.Or(
+			cmp.Compare(a.Pid, b.Pid),
+			strings.Compare(a.ContainerID, b.ContainerID),
+			strings.Compare(a.Service, b.Service),
+		)
+	})
+	if n > 0 && len(report.Talkers) > n {
+		report.Talkers = report.Talkers[:n]
+	}
+
+	seconds := report.Window.Seconds()
+	for i := range report.Talkers {
+		t := &report.Talkers[i]
+		if seconds > 0 {
+			t.SentBytesPerSecond = float64(t.SentBytes) / seconds
+			t.RecvBytesPerSecond = float64(t.RecvBytes) / seconds
+		}
+		if groupBy == GroupByProcess && t.Pid != 0 {
+			t.Name = a.processName(t.Pid)
+		}
+	}
+	return report
+}
+
+// keyOf returns the key of the process of a connection
+func keyOf(c *network.ConnectionStats) key {
+	k := key{pid: c.Pid}
+	if c.ContainerID.Source != nil {
+		k.containerID = c.ContainerID.Source.Get().(string)
+	}
+	for _, tag := range c.Tags {
+		if tag == nil {
+			continue
+		}
+		if service, ok := strings.CutPrefix(tag.Get().(string), serviceTagPrefix); ok {
+			k.service = service
+			break
+		}
+	}
+	return k
+}
+
+// groupKey returns the key a process is aggregated under
+func groupKey(k key, groupBy GroupBy) key {
+	switch groupBy {
+	case GroupByContainer:
+		return key{containerID: k.containerID}
+	case GroupByService:
+		return key{service: k.service}
+	default:
+		return k
+	}
+}
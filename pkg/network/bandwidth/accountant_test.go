@@ -0,0 +1,144 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package bandwidth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go4.org/intern"
+
+	"github.com/DataDog/datadog-agent/pkg/network"
+)
+
+func newConn(pid uint32, containerID, service string, sent, recv uint64) network.ConnectionStats {
+	c := network.ConnectionStats{
+		ConnectionTuple: network.ConnectionTuple{Pid: pid},
+		Last:            network.StatCounters{SentBytes: sent, RecvBytes: recv},
+	}
+	if sent > 0 {
+		c.Last.SentPackets = 1
+	}
+	if recv > 0 {
+		c.Last.RecvPackets = 1
+	}
+	if containerID != "" {
+		c.ContainerID.Source = intern.GetByString(containerID)
+	}
+	if service != "" {
+		c.Tags = []*intern.Value{intern.GetByString("env:prod"), intern.GetByString("service:" + service)}
+	}
+	return c
+}
+
+func newTestAccountant(start time.Time) *Accountant {
+	a := NewAccountant(5*time.Second, time.Minute)
+	a.processName = func(pid uint32) string {
+		return map[uint32]string{100: "nginx", 200: "curl", 300: "redis-server"}[pid]
+	}
+	a.Reset(start)
+	return a
+}
+
+func TestAccountantTop(t *testing.T) {
+	start := time.Date(2025, 7, 2, 12, 0, 0, 0, time.UTC)
+	a := newTestAccountant(start)
+
+	a.Add(start.Add(5*time.Second), []network.ConnectionStats{
+		newConn(100, "web", "frontend", 1000, 9000),
+		newConn(100, "web", "frontend", 1000, 1000),
+		newConn(200, "web", "frontend", 500, 500),
+		newConn(300, "cache", "redis", 4000, 0),
+		// connections without traffic are ignored
+		newConn(400, "", "", 0, 0),
+	})
+	a.Add(start.Add(10*time.Second), []network.ConnectionStats{
+		newConn(300, "cache", "redis", 4000, 4000),
+	})
+	now := start.Add(10 * time.Second)
+
+	t.Run("Process", func(t *testing.T) {
+		report := a.Top(now, time.Minute, GroupByProcess, 0)
+		assert.Equal(t, GroupByProcess, report.GroupBy)
+		assert.Equal(t, 10*time.Second, report.Window)
+		assert.Equal(t, []Talker{
+			{
+				Pid: 100, Name: "nginx", ContainerID: "web", Service: "frontend",
+				Counters:           Counters{SentBytes: 2000, RecvBytes: 10000, SentPackets: 2, RecvPackets: 2},
+				SentBytesPerSecond: 200, RecvBytesPerSecond: 1000,
+			},
+			{
+				Pid: 300, Name: "redis-server", ContainerID: "cache", Service: "redis",
+				Counters:           Counters{SentBytes: 8000, RecvBytes: 4000, SentPackets: 2, RecvPackets: 1},
+				SentBytesPerSecond: 800, RecvBytesPerSecond: 400,
+			},
+			{
+				Pid: 200, Name: "curl", ContainerID: "web", Service: "frontend",
+				Counters:           Counters{SentBytes: 500, RecvBytes: 500, SentPackets: 1, RecvPackets: 1},
+				SentBytesPerSecond: 50, RecvBytesPerSecond: 50,
+			},
+		}, report.Talkers)
+	})
+
+	t.Run("Container", func(t *testing.T) {
+		report := a.Top(now, time.Minute, GroupByContainer, 1)
+		require.Len(t, report.Talkers, 1)
+		assert.Equal(t, "web", report.Talkers[0].ContainerID)
+		assert.Zero(t, report.Talkers[0].Pid)
+		assert.Empty(t, report.Talkers[0].Name)
+		assert.Equal(t, uint64(13000), report.Talkers[0].TotalBytes())
+	})
+
+	t.Run("Service", func(t *testing.T) {
+		report := a.Top(now, time.Minute, GroupByService, 0)
+		require.Len(t, report.Talkers, 2)
+		assert.Equal(t, "frontend", report.Talkers[0].Service)
+		assert.Equal(t, "redis", report.Talkers[1].Service)
+		assert.Equal(t, uint64(12000), report.Talkers[1].TotalBytes())
+	})
+
+	t.Run("Window", func(t *testing.T) {
+		// only the last bucket starts within the window
+		report := a.Top(now, 5*time.Second, GroupByProcess, 0)
+		assert.Equal(t, 5*time.Second, report.Window)
+		require.Len(t, report.Talkers, 1)
+		assert.Equal(t, uint32(300), report.Talkers[0].Pid)
+		assert.Equal(t, uint64(8000), report.Talkers[0].TotalBytes())
+	})
+}
+
+func TestAccountantSlidingWindow(t *testing.T) {
+	start := time.Date(2025, 7, 2, 12, 0, 0, 0, time.UTC)
+	a := newTestAccountant(start)
+	assert.Equal(t, time.Minute, a.MaxWindow())
+
+	for i := 1; i <= 30; i++ {
+		a.Add(start.Add(time.Duration(i)*5*time.Second), []network.ConnectionStats{
+			newConn(100, "", "", 100, 0),
+		})
+	}
+	now := start.Add(150 * time.Second)
+
+	// the buckets older than the longest window are reused
+	report := a.Top(now, time.Hour, GroupByProcess, 0)
+	assert.Equal(t, time.Minute, report.Window)
+	require.Len(t, report.Talkers, 1)
+	assert.Equal(t, uint64(1200), report.Talkers[0].SentBytes)
+
+	a.Reset(now)
+	assert.Empty(t, a.Top(now, time.Minute, GroupByProcess, 0).Talkers)
+}
+
+func TestParseGroupBy(t *testing.T) {
+	groupBy, err := ParseGroupBy("container")
+	require.NoError(t, err)
+	assert.Equal(t, GroupByContainer, groupBy)
+
+	_, err = ParseGroupBy("host")
+	assert.Error(t, err)
+}
@@ -138,6 +138,26 @@ type USMConfig struct {
 	// MaxPostgresTelemetryBuffer represents the maximum size of the telemetry buffer size for Postgres
 	MaxPostgresTelemetryBuffer int
 
+	// ========================================
+	// MySQL Protocol Configuration
+	// ========================================
+
+	// EnableMySQLMonitoring specifies whether the tracer should monitor MySQL traffic
+	EnableMySQLMonitoring bool
+
+	// MaxMySQLStatsBuffered represents the maximum number of MySQL stats we'll buffer in memory
+	MaxMySQLStatsBuffered int
+
+	// ========================================
+	// MongoDB Protocol Configuration
+	// ========================================
+
+	// EnableMongoMonitoring specifies whether the tracer should monitor MongoDB traffic
+	EnableMongoMonitoring bool
+
+	// MaxMongoStatsBuffered represents the maximum number of MongoDB stats we'll buffer in memory
+	MaxMongoStatsBuffered int
+
 	// ========================================
 	// Redis Protocol Configuration
 	// ========================================
@@ -228,6 +248,14 @@ func NewUSMConfig(cfg model.Config) *USMConfig {
 		MaxPostgresStatsBuffered:   cfg.GetInt(sysconfig.FullKeyPath(smNS, "postgres", "max_stats_buffered")),
 		MaxPostgresTelemetryBuffer: cfg.GetInt(sysconfig.FullKeyPath(smNS, "postgres", "max_telemetry_buffer")),
 
+		// MySQL Protocol Configuration
+		EnableMySQLMonitoring: cfg.GetBool(sysconfig.FullKeyPath(smNS, "mysql", "enabled")),
+		MaxMySQLStatsBuffered: cfg.GetInt(sysconfig.FullKeyPath(smNS, "mysql", "max_stats_buffered")),
+
+		// MongoDB Protocol Configuration
+		EnableMongoMonitoring: cfg.GetBool(sysconfig.FullKeyPath(smNS, "mongo", "enabled")),
+		MaxMongoStatsBuffered: cfg.GetInt(sysconfig.FullKeyPath(smNS, "mongo", "max_stats_buffered")),
+
 		// Redis Protocol Configuration
 		EnableRedisMonitoring: cfg.GetBool(sysconfig.FullKeyPath(smNS, "redis", "enabled")),
 		RedisTrackResources:   cfg.GetBool(sysconfig.FullKeyPath(smNS, "redis", "track_resources")),
@@ -13,6 +13,8 @@
 #include "protocols/http2/decoding.h"
 #include "protocols/http2/decoding-tls.h"
 #include "protocols/kafka/kafka-parsing.h"
+#include "protocols/mongo/decoding.h"
+#include "protocols/mysql/decoding.h"
 #include "protocols/postgres/decoding.h"
 #include "protocols/redis/decoding.h"
 #include "protocols/sockfd-probes.h"
@@ -162,6 +162,9 @@ typedef enum {
     PROG_POSTGRES_TERMINATION,
     PROG_REDIS,
     PROG_REDIS_TERMINATION,
+    PROG_MYSQL,
+    PROG_MYSQL_TERMINATION,
+    PROG_MONGO,
     // Add before this value.
     PROG_MAX,
 } protocol_prog_t;
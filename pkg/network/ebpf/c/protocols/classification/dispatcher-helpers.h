@@ -15,6 +15,10 @@
 #include "protocols/http2/usm-events.h"
 #include "protocols/kafka/kafka-classification.h"
 #include "protocols/kafka/usm-events.h"
+#include "protocols/mongo/helpers.h"
+#include "protocols/mongo/usm-events.h"
+#include "protocols/mysql/helpers.h"
+#include "protocols/mysql/usm-events.h"
 #include "protocols/postgres/helpers.h"
 #include "protocols/postgres/usm-events.h"
 #include "protocols/redis/helpers.h"
@@ -32,6 +36,10 @@ __maybe_unused static __always_inline protocol_prog_t protocol_to_program(protoc
         return PROG_POSTGRES;
     case PROTOCOL_REDIS:
         return PROG_REDIS;
+    case PROTOCOL_MYSQL:
+        return PROG_MYSQL;
+    case PROTOCOL_MONGO:
+        return PROG_MONGO;
     default:
         if (proto != PROTOCOL_UNKNOWN) {
             log_debug("protocol doesn't have a matching program: %d", proto);
@@ -85,6 +93,10 @@ static __always_inline bool is_protocol_supported_for_dispatcher(protocol_t prot
         return is_postgres_monitoring_enabled();
     case PROTOCOL_REDIS:
         return is_redis_enabled();
+    case PROTOCOL_MYSQL:
+        return is_mysql_monitoring_enabled();
+    case PROTOCOL_MONGO:
+        return is_mongo_monitoring_enabled();
     case PROTOCOL_KAFKA:
         return is_kafka_monitoring_enabled();
     default:
@@ -107,6 +119,10 @@ static __always_inline void classify_protocol_for_dispatcher(protocol_t *protoco
         *protocol = PROTOCOL_POSTGRES;
     } else if (is_redis_enabled() && is_redis(buf, size)) {
         *protocol = PROTOCOL_REDIS;
+    } else if (is_mysql_monitoring_enabled() && is_mysql(tup, buf, size)) {
+        *protocol = PROTOCOL_MYSQL;
+    } else if (is_mongo_monitoring_enabled() && is_mongo(tup, buf, size)) {
+        *protocol = PROTOCOL_MONGO;
     } else {
         *protocol = PROTOCOL_UNKNOWN;
     }
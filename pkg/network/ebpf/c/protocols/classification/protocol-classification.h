@@ -237,11 +237,30 @@ __maybe_unused static __always_inline void protocol_classifier_entrypoint_tls_ha
     if (!tls_info) {
         return;
     }
+    const __u32 zero = 0;
+    tls_server_name_t *server_name = bpf_map_lookup_elem(&tls_server_name_heap, &zero);
+    if (!server_name) {
+        return;
+    }
+    server_name->len = 0;
+
     __u32 offset = classification_ctx->skb_info.data_off + sizeof(tls_record_header_t);
     __u32 data_end = classification_ctx->skb_info.data_end;
-    if (!parse_client_hello(skb, offset, data_end, tls_info)) {
+    if (!parse_client_hello(skb, offset, data_end, tls_info, server_name)) {
+        return;
+    }
+
+    // The server name is stored apart from the connection, which only references it by its ID
+    if (server_name->len == 0 || tls_info->server_name_id != 0) {
         return;
     }
+    __u32 server_name_id = tls_server_name_id(server_name);
+    if (server_name_id == 0) {
+        return;
+    }
+    server_name->timestamp = bpf_ktime_get_ns();
+    bpf_map_update_with_telemetry(tls_server_names, &server_name_id, server_name, BPF_ANY);
+    tls_info->server_name_id = server_name_id;
 }
 
 __maybe_unused static __always_inline void protocol_classifier_entrypoint_tls_handshake_server(struct __sk_buff *skb) {
@@ -258,6 +277,35 @@ __maybe_unused static __always_inline void protocol_classifier_entrypoint_tls_ha
     if (!parse_server_hello(skb, offset, data_end, tls_info)) {
         return;
     }
+
+    // In TLS 1.3 the server certificate is encrypted, it can only be collected by the TLS library uprobes
+    if (tls_info->chosen_version == TLS_VERSION13 || tls_info->cert_id != 0) {
+        return;
+    }
+
+    // The Certificate message is in the record following the ServerHello record
+    tls_record_header_t tls_hdr = {0};
+    if (!read_tls_record_header(skb, classification_ctx->skb_info.data_off, data_end, &tls_hdr)) {
+        return;
+    }
+    __u32 cert_offset = classification_ctx->skb_info.data_off + sizeof(tls_record_header_t) + tls_hdr.length;
+
+    const __u32 zero = 0;
+    tls_handshake_cert_t *cert = bpf_map_lookup_elem(&tls_handshake_cert_heap, &zero);
+    if (!cert) {
+        return;
+    }
+    if (!parse_server_certificate(skb, cert_offset, data_end, cert)) {
+        return;
+    }
+
+    __u32 cert_id = tls_handshake_cert_id(cert);
+    if (cert_id == 0) {
+        return;
+    }
+    cert->timestamp = bpf_ktime_get_ns();
+    bpf_map_update_with_telemetry(tls_handshake_certs, &cert_id, cert, BPF_ANY);
+    tls_info->cert_id = cert_id;
 }
 
 __maybe_unused static __always_inline void protocol_classifier_entrypoint_queues(struct __sk_buff *skb) {
@@ -6,6 +6,8 @@
 #include "protocols/http/http.h"
 #include "protocols/http2/decoding.h"
 #include "protocols/kafka/kafka-parsing.h"
+#include "protocols/mongo/decoding.h"
+#include "protocols/mysql/decoding.h"
 #include "protocols/postgres/decoding.h"
 #include "protocols/redis/decoding.h"
 
@@ -88,4 +90,28 @@ int netif_receive_skb_core_redis_4_14(void *ctx) {
     return 0;
 }
 
+SEC("tracepoint/net/netif_receive_skb")
+int tracepoint__net__netif_receive_skb_mysql(void *ctx) {
+    mysql_batch_flush_with_telemetry(ctx);
+    return 0;
+}
+
+SEC("kprobe/__netif_receive_skb_core")
+int netif_receive_skb_core_mysql_4_14(void *ctx) {
+    mysql_batch_flush_with_telemetry(ctx);
+    return 0;
+}
+
+SEC("tracepoint/net/netif_receive_skb")
+int tracepoint__net__netif_receive_skb_mongo(void *ctx) {
+    mongo_batch_flush_with_telemetry(ctx);
+    return 0;
+}
+
+SEC("kprobe/__netif_receive_skb_core")
+int netif_receive_skb_core_mongo_4_14(void *ctx) {
+    mongo_batch_flush_with_telemetry(ctx);
+    return 0;
+}
+
 #endif // __USM_FLUSH_H
@@ -0,0 +1,17 @@
+#ifndef __MONGO_MAPS_H
+#define __MONGO_MAPS_H
+
+#include "bpf_helpers.h"
+#include "map-defs.h"
+
+#include "protocols/classification/structs.h"
+#include "protocols/mongo/types.h"
+
+// Keeps track of in-flight MongoDB transactions. Requests are keyed by their request id, as the driver may have
+// several requests in flight on the same connection.
+BPF_HASH_MAP(mongo_in_flight, mongo_key, mongo_transaction_t, 0)
+
+// Acts as a scratch buffer for MongoDB events, for preparing events before they are sent to userspace.
+BPF_PERCPU_ARRAY_MAP(mongo_scratch_buffer, mongo_event_t, 1)
+
+#endif
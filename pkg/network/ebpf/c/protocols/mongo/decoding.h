@@ -0,0 +1,153 @@
+#ifndef __MONGO_DECODING_H
+#define __MONGO_DECODING_H
+
+#include "bpf_builtins.h"
+#include "bpf_telemetry.h"
+
+#include "protocols/sockfd.h"
+
+#include "protocols/classification/structs.h"
+#include "protocols/helpers/pktbuf.h"
+#include "protocols/mongo/decoding-maps.h"
+#include "protocols/mongo/defs.h"
+#include "protocols/mongo/types.h"
+#include "protocols/mongo/usm-events.h"
+#include "protocols/read_into_buffer.h"
+
+PKTBUF_READ_INTO_BUFFER(mongo_request, MONGO_REQUEST_BUFFER_SIZE, BLK_SIZE)
+PKTBUF_READ_INTO_BUFFER(mongo_response, MONGO_RESPONSE_BUFFER_SIZE, BLK_SIZE)
+
+// Enqueues a batch of events to the user-space. To spare stack size, we take a scratch buffer from the map, copy
+// the connection tuple and the transaction to it, and then enqueue the event.
+static __always_inline void mongo_batch_enqueue_wrapper(conn_tuple_t *tuple, mongo_transaction_t *tx) {
+    u32 zero = 0;
+    mongo_event_t *event = bpf_map_lookup_elem(&mongo_scratch_buffer, &zero);
+    if (!event) {
+        return;
+    }
+
+    bpf_memcpy(&event->tuple, tuple, sizeof(conn_tuple_t));
+    bpf_memcpy(&event->tx, tx, sizeof(mongo_transaction_t));
+    mongo_batch_enqueue(event);
+}
+
+// Reads the OP_MSG header and flag bits from the given context. Returns true if the packet starts with a valid OP_MSG
+// header, false otherwise. Other opcodes (including OP_COMPRESSED) are not decoded.
+// OP_MSG format - https://www.mongodb.com/docs/manual/reference/mongodb-wire-protocol/#op_msg
+static __always_inline bool mongo_read_header(pktbuf_t pkt, mongo_msg_header *header, __u32 *flags) {
+    u32 data_off = pktbuf_data_offset(pkt);
+    u32 data_end = pktbuf_data_end(pkt);
+    // Ensuring that the header and the flag bits are in the buffer.
+    if (data_off + sizeof(mongo_msg_header) + sizeof(__u32) > data_end) {
+        return false;
+    }
+    pktbuf_load_bytes(pkt, data_off, header, sizeof(mongo_msg_header));
+    if (header->op_code != MONGO_OP_MSG) {
+        return false;
+    }
+    // The message must contain at least the header, the flag bits and a section kind.
+    if (header->message_length <= (__s32)(sizeof(mongo_msg_header) + sizeof(__u32))) {
+        return false;
+    }
+    pktbuf_load_bytes(pkt, data_off + sizeof(mongo_msg_header), flags, sizeof(__u32));
+    return true;
+}
+
+// Handles a new request by creating a new transaction keyed by the request id and storing it in the map. The
+// sections of the message are stored as is, and decoded in userspace.
+static __always_inline void mongo_handle_request(pktbuf_t pkt, conn_tuple_t *conn_tuple, mongo_msg_header *header, __u8 tags) {
+    mongo_key key = {};
+    key.tup = *conn_tuple;
+    key.req_id = header->request_id;
+
+    mongo_transaction_t new_transaction = {};
+    new_transaction.request_started = bpf_ktime_get_ns();
+    new_transaction.tags = tags;
+    new_transaction.original_request_size = header->message_length - sizeof(mongo_msg_header) - sizeof(__u32);
+    u32 data_off = pktbuf_data_offset(pkt) + sizeof(mongo_msg_header) + sizeof(__u32);
+    pktbuf_read_into_buffer_mongo_request((char *)new_transaction.request_fragment, pkt, data_off);
+    bpf_map_update_elem(&mongo_in_flight, &key, &new_transaction, BPF_ANY);
+}
+
+// Handles a response by pairing it with its request, enqueuing the transaction and deleting it from the in-flight map.
+static __always_inline void mongo_handle_response(pktbuf_t pkt, conn_tuple_t *conn_tuple, mongo_msg_header *header) {
+    mongo_key key = {};
+    key.tup = *conn_tuple;
+    key.req_id = header->response_to;
+
+    mongo_transaction_t *transaction = bpf_map_lookup_elem(&mongo_in_flight, &key);
+    if (transaction == NULL) {
+        return;
+    }
+
+    transaction->response_last_seen = bpf_ktime_get_ns();
+    u32 data_off = pktbuf_data_offset(pkt) + sizeof(mongo_msg_header) + sizeof(__u32);
+    pktbuf_read_into_buffer_mongo_response((char *)transaction->response_fragment, pkt, data_off);
+    mongo_batch_enqueue_wrapper(conn_tuple, transaction);
+    bpf_map_delete_elem(&mongo_in_flight, &key);
+}
+
+// Reads the message header and decides what to do based on it. Requests have a response_to of 0, while responses
+// carry the request id of the request they answer.
+static __always_inline void mongo_handle_message(pktbuf_t pkt, conn_tuple_t *conn_tuple, __u8 tags) {
+    mongo_msg_header header = {};
+    __u32 flags = 0;
+    if (!mongo_read_header(pkt, &header, &flags)) {
+        return;
+    }
+
+    if (header.response_to != 0) {
+        mongo_handle_response(pkt, conn_tuple, &header);
+        return;
+    }
+
+    // Requests sent with moreToCome are not answered, so there is no latency to measure.
+    if (flags & MONGO_OP_MSG_FLAG_MORE_TO_COME) {
+        return;
+    }
+    mongo_handle_request(pkt, conn_tuple, &header, tags);
+}
+
+// Entrypoint to process plaintext MongoDB traffic. Pulls the connection tuple and the packet buffer from the map and
+// calls the main processing function. As transactions are keyed by request id, the in-flight entries of a terminated
+// connection cannot be looked up, and are removed by the userspace map cleaner instead.
+SEC("socket/mongo_process")
+int socket__mongo_process(struct __sk_buff* skb) {
+    skb_info_t skb_info = {};
+    conn_tuple_t conn_tuple = {};
+
+    if (!fetch_dispatching_arguments(&conn_tuple, &skb_info)) {
+        return 0;
+    }
+
+    if (is_tcp_termination(&skb_info)) {
+        return 0;
+    }
+
+    normalize_tuple(&conn_tuple);
+
+    pktbuf_t pkt = pktbuf_from_skb(skb, &skb_info);
+    mongo_handle_message(pkt, &conn_tuple, NO_TAGS);
+    return 0;
+}
+
+// Entrypoint to process TLS MongoDB traffic. Pulls the connection tuple and the packet buffer from the map and calls
+// the main processing function.
+SEC("uprobe/mongo_tls_process")
+int uprobe__mongo_tls_process(struct pt_regs *ctx) {
+    const __u32 zero = 0;
+
+    tls_dispatcher_arguments_t *args = bpf_map_lookup_elem(&tls_dispatcher_arguments, &zero);
+    if (args == NULL) {
+        return 0;
+    }
+
+    // Copying the tuple to the stack to handle verifier issues on kernel 4.14.
+    conn_tuple_t tup = args->tup;
+
+    pktbuf_t pkt = pktbuf_from_tls(ctx, args);
+    mongo_handle_message(pkt, &tup, (__u8)args->tags);
+    return 0;
+}
+
+#endif
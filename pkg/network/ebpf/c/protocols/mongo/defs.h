@@ -18,4 +18,8 @@
 
 #define MONGO_HEADER_LENGTH 16
 
+// OP_MSG flag bits, taken from https://www.mongodb.com/docs/manual/reference/mongodb-wire-protocol/#flag-bits.
+// A message with the moreToCome bit set by the client is not answered by the server.
+#define MONGO_OP_MSG_FLAG_MORE_TO_COME (1 << 1)
+
 #endif
@@ -0,0 +1,32 @@
+#ifndef __MONGO_TYPES_H
+#define __MONGO_TYPES_H
+
+#include "conn_tuple.h"
+
+// Maximum number of bytes of the request sections we send to userspace. The body section starts with the command
+// document, whose first element is the command name and (for most commands) the collection.
+#define MONGO_REQUEST_BUFFER_SIZE 160
+// Maximum number of bytes of the response sections we send to userspace. Error replies start with the "ok" element,
+// so the beginning of the response is enough to tell whether the command failed.
+#define MONGO_RESPONSE_BUFFER_SIZE 32
+
+// MongoDB transaction information we store in the kernel.
+typedef struct {
+    // The sections of the OP_MSG request, following the flag bits. Stored up to MONGO_REQUEST_BUFFER_SIZE bytes.
+    char request_fragment[MONGO_REQUEST_BUFFER_SIZE];
+    // The sections of the OP_MSG response, following the flag bits. Stored up to MONGO_RESPONSE_BUFFER_SIZE bytes.
+    char response_fragment[MONGO_RESPONSE_BUFFER_SIZE];
+    __u64 request_started;
+    __u64 response_last_seen;
+    // The actual size of the sections of the request.
+    __u32 original_request_size;
+    __u8 tags;
+} mongo_transaction_t;
+
+// The struct we send to userspace, containing the connection tuple and the transaction information.
+typedef struct {
+    conn_tuple_t tuple;
+    mongo_transaction_t tx;
+} mongo_event_t;
+
+#endif
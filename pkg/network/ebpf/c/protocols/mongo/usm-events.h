@@ -0,0 +1,12 @@
+#ifndef __MONGO_USM_EVENTS_H
+#define __MONGO_USM_EVENTS_H
+
+#include "protocols/events.h"
+#include "protocols/mongo/types.h"
+
+// Controls the number of MongoDB transactions read from userspace at a time.
+#define MONGO_BATCH_SIZE (MAX_BATCH_SIZE(mongo_event_t))
+
+USM_EVENTS_INIT(mongo, mongo_event_t, MONGO_BATCH_SIZE);
+
+#endif
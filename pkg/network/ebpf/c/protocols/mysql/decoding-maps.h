@@ -0,0 +1,19 @@
+#ifndef __MYSQL_MAPS_H
+#define __MYSQL_MAPS_H
+
+#include "bpf_helpers.h"
+#include "map-defs.h"
+
+#include "protocols/mysql/types.h"
+
+// Keeps track of in-flight MySQL transactions
+BPF_HASH_MAP(mysql_in_flight, conn_tuple_t, mysql_transaction_t, 0)
+
+// Keeps track of the queries of the prepared statements, so their executions can be attributed to a query.
+// Statements are removed on COM_STMT_CLOSE, and the LRU evicts the statements of closed connections.
+BPF_LRU_MAP(mysql_prepared_statements, mysql_statement_key_t, mysql_statement_t, 0)
+
+// Acts as a scratch buffer for MySQL events, for preparing events before they are sent to userspace.
+BPF_PERCPU_ARRAY_MAP(mysql_scratch_buffer, mysql_event_t, 1)
+
+#endif
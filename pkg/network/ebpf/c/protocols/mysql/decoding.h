@@ -0,0 +1,254 @@
+#ifndef __MYSQL_DECODING_H
+#define __MYSQL_DECODING_H
+
+#include "bpf_builtins.h"
+#include "bpf_telemetry.h"
+
+#include "protocols/sockfd.h"
+
+#include "protocols/helpers/pktbuf.h"
+#include "protocols/mysql/decoding-maps.h"
+#include "protocols/mysql/defs.h"
+#include "protocols/mysql/types.h"
+#include "protocols/mysql/usm-events.h"
+#include "protocols/read_into_buffer.h"
+
+PKTBUF_READ_INTO_BUFFER(mysql_query, MYSQL_BUFFER_SIZE, BLK_SIZE)
+
+// Enqueues a batch of events to the user-space. To spare stack size, we take a scratch buffer from the map, copy
+// the connection tuple and the transaction to it, and then enqueue the event.
+static __always_inline void mysql_batch_enqueue_wrapper(conn_tuple_t *tuple, mysql_transaction_t *tx) {
+    u32 zero = 0;
+    mysql_event_t *event = bpf_map_lookup_elem(&mysql_scratch_buffer, &zero);
+    if (!event) {
+        return;
+    }
+
+    bpf_memcpy(&event->tuple, tuple, sizeof(conn_tuple_t));
+    bpf_memcpy(&event->tx, tx, sizeof(mysql_transaction_t));
+    mysql_batch_enqueue(event);
+}
+
+// Reads a packet header from the given context. Returns true if the header was read successfully, false otherwise.
+// Server packets (such as OK or ERR) share the same layout, with the status byte in place of the command byte.
+static __always_inline bool mysql_read_header(pktbuf_t pkt, mysql_hdr *header) {
+    u32 data_off = pktbuf_data_offset(pkt);
+    u32 data_end = pktbuf_data_end(pkt);
+    // Ensuring that the header is in the buffer.
+    if (data_off + sizeof(mysql_hdr) > data_end) {
+        return false;
+    }
+    pktbuf_load_bytes(pkt, data_off, header, sizeof(mysql_hdr));
+    return header->payload_length > 0;
+}
+
+// Reads a little endian 32 bits integer located right after the header. Used to read the statement id of
+// COM_STMT_EXECUTE, COM_STMT_CLOSE and COM_STMT_PREPARE_OK packets.
+static __always_inline bool mysql_read_statement_id(pktbuf_t pkt, __u32 *statement_id) {
+    u32 data_off = pktbuf_data_offset(pkt) + sizeof(mysql_hdr);
+    if (data_off + sizeof(__u32) > pktbuf_data_end(pkt)) {
+        return false;
+    }
+    // Like mysql_hdr, this assumes a little endian host, matching the wire format.
+    pktbuf_load_bytes(pkt, data_off, statement_id, sizeof(__u32));
+    return true;
+}
+
+// Handles a new query (COM_QUERY or COM_STMT_PREPARE) by creating a new transaction and storing it in the map.
+// If a transaction already exists for the given connection, it is overridden.
+// The payload of both commands is the command byte followed by the query.
+static __always_inline void mysql_handle_query(pktbuf_t pkt, conn_tuple_t *conn_tuple, mysql_hdr *header, __u8 tags) {
+    mysql_transaction_t new_transaction = {};
+    new_transaction.request_started = bpf_ktime_get_ns();
+    new_transaction.command = header->command_type;
+    new_transaction.tags = tags;
+    // payload_length includes the command byte.
+    new_transaction.original_query_size = header->payload_length - 1;
+    u32 data_off = pktbuf_data_offset(pkt) + sizeof(mysql_hdr);
+    pktbuf_read_into_buffer_mysql_query((char *)new_transaction.request_fragment, pkt, data_off);
+    bpf_map_update_elem(&mysql_in_flight, conn_tuple, &new_transaction, BPF_ANY);
+}
+
+// Handles COM_STMT_EXECUTE by creating a new transaction holding the query of the executed statement. Executions of
+// statements we have not seen being prepared are ignored, as we cannot attribute them to a query.
+static __always_inline void mysql_handle_statement_execute(pktbuf_t pkt, conn_tuple_t *conn_tuple, mysql_hdr *header, __u8 tags) {
+    mysql_statement_key_t key = {};
+    if (!mysql_read_statement_id(pkt, &key.statement_id)) {
+        return;
+    }
+    key.tuple = *conn_tuple;
+
+    mysql_statement_t *statement = bpf_map_lookup_elem(&mysql_prepared_statements, &key);
+    if (statement == NULL) {
+        return;
+    }
+
+    mysql_transaction_t new_transaction = {};
+    new_transaction.request_started = bpf_ktime_get_ns();
+    new_transaction.command = header->command_type;
+    new_transaction.tags = tags;
+    new_transaction.original_query_size = statement->original_query_size;
+    bpf_memcpy(new_transaction.request_fragment, statement->query_fragment, sizeof(new_transaction.request_fragment));
+    bpf_map_update_elem(&mysql_in_flight, conn_tuple, &new_transaction, BPF_ANY);
+}
+
+// Handles COM_STMT_CLOSE by forgetting the statement. The command has no response.
+static __always_inline void mysql_handle_statement_close(pktbuf_t pkt, conn_tuple_t *conn_tuple) {
+    mysql_statement_key_t key = {};
+    if (!mysql_read_statement_id(pkt, &key.statement_id)) {
+        return;
+    }
+    key.tuple = *conn_tuple;
+    bpf_map_delete_elem(&mysql_prepared_statements, &key);
+}
+
+// Stores the query of a successfully prepared statement, keyed by the statement id assigned by the server.
+// COM_STMT_PREPARE_OK format - https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_com_stmt_prepare.html#sect_protocol_com_stmt_prepare_response_ok
+static __always_inline void mysql_handle_prepare_ok(pktbuf_t pkt, conn_tuple_t *conn_tuple, mysql_transaction_t *transaction) {
+    mysql_statement_key_t key = {};
+    if (!mysql_read_statement_id(pkt, &key.statement_id)) {
+        return;
+    }
+    key.tuple = *conn_tuple;
+
+    mysql_statement_t statement = {};
+    statement.original_query_size = transaction->original_query_size;
+    bpf_memcpy(statement.query_fragment, transaction->request_fragment, sizeof(statement.query_fragment));
+    bpf_map_update_elem(&mysql_prepared_statements, &key, &statement, BPF_ANY);
+}
+
+// Handles the first packet of a response. The transaction is completed by the first packet the server sends, thus the
+// reported latency is the time to the first byte of the response rather than the time to the last row of a result set.
+// ERR packet format - https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_basic_err_packet.html
+static __always_inline void mysql_handle_response(pktbuf_t pkt, conn_tuple_t *conn_tuple, mysql_hdr *header) {
+    mysql_transaction_t *transaction = bpf_map_lookup_elem(&mysql_in_flight, conn_tuple);
+    if (transaction == NULL) {
+        return;
+    }
+
+    // The preparation of a statement is not reported, only its executions.
+    if (transaction->command == MYSQL_PREPARE_QUERY) {
+        if (header->command_type == MYSQL_RESPONSE_OK) {
+            mysql_handle_prepare_ok(pkt, conn_tuple, transaction);
+        }
+        bpf_map_delete_elem(&mysql_in_flight, conn_tuple);
+        return;
+    }
+
+    if (header->command_type == MYSQL_RESPONSE_ERR) {
+        transaction->is_error = true;
+        // The error code is a little endian 16 bits integer following the 0xff status byte.
+        u32 data_off = pktbuf_data_offset(pkt) + sizeof(mysql_hdr);
+        if (data_off + sizeof(transaction->error_code) <= pktbuf_data_end(pkt)) {
+            pktbuf_load_bytes(pkt, data_off, &transaction->error_code, sizeof(transaction->error_code));
+        }
+    }
+
+    transaction->response_last_seen = bpf_ktime_get_ns();
+    mysql_batch_enqueue_wrapper(conn_tuple, transaction);
+    bpf_map_delete_elem(&mysql_in_flight, conn_tuple);
+}
+
+// Handles a TCP termination event by deleting the connection tuple from the in-flight map. Prepared statements of the
+// connection are left for the LRU map to evict.
+static void __always_inline mysql_tcp_termination(conn_tuple_t *tup) {
+    bpf_map_delete_elem(&mysql_in_flight, tup);
+    flip_tuple(tup);
+    bpf_map_delete_elem(&mysql_in_flight, tup);
+}
+
+// Reads the packet header and decides what to do based on the sequence id and the command byte.
+// Commands always have a sequence id of 0, and the first packet of the response has a sequence id of 1.
+static __always_inline void mysql_handle_packet(pktbuf_t pkt, conn_tuple_t *conn_tuple, __u8 tags) {
+    mysql_hdr header = {};
+    if (!mysql_read_header(pkt, &header)) {
+        return;
+    }
+
+    if (header.seq_id == MYSQL_RESPONSE_SEQ_ID) {
+        mysql_handle_response(pkt, conn_tuple, &header);
+        return;
+    }
+
+    if (header.seq_id != MYSQL_REQUEST_SEQ_ID) {
+        return;
+    }
+
+    switch (header.command_type) {
+    case MYSQL_COMMAND_QUERY:
+    case MYSQL_PREPARE_QUERY:
+        mysql_handle_query(pkt, conn_tuple, &header, tags);
+        break;
+    case MYSQL_COMMAND_STMT_EXECUTE:
+        mysql_handle_statement_execute(pkt, conn_tuple, &header, tags);
+        break;
+    case MYSQL_COMMAND_STMT_CLOSE:
+        mysql_handle_statement_close(pkt, conn_tuple);
+        break;
+    default:
+        // Any other command (e.g. COM_PING) would be answered by a response we do not want to attribute to a
+        // previous query.
+        bpf_map_delete_elem(&mysql_in_flight, conn_tuple);
+        break;
+    }
+}
+
+// Entrypoint to process plaintext MySQL traffic. Pulls the connection tuple and the packet buffer from the map and
+// calls the main processing function. If the packet is a TCP termination, it calls the termination function.
+SEC("socket/mysql_process")
+int socket__mysql_process(struct __sk_buff* skb) {
+    skb_info_t skb_info = {};
+    conn_tuple_t conn_tuple = {};
+
+    if (!fetch_dispatching_arguments(&conn_tuple, &skb_info)) {
+        return 0;
+    }
+
+    if (is_tcp_termination(&skb_info)) {
+        mysql_tcp_termination(&conn_tuple);
+        return 0;
+    }
+
+    normalize_tuple(&conn_tuple);
+
+    pktbuf_t pkt = pktbuf_from_skb(skb, &skb_info);
+    mysql_handle_packet(pkt, &conn_tuple, NO_TAGS);
+    return 0;
+}
+
+// Entrypoint to process TLS MySQL traffic. Pulls the connection tuple and the packet buffer from the map and calls
+// the main processing function.
+SEC("uprobe/mysql_tls_process")
+int uprobe__mysql_tls_process(struct pt_regs *ctx) {
+    const __u32 zero = 0;
+
+    tls_dispatcher_arguments_t *args = bpf_map_lookup_elem(&tls_dispatcher_arguments, &zero);
+    if (args == NULL) {
+        return 0;
+    }
+
+    // Copying the tuple to the stack to handle verifier issues on kernel 4.14.
+    conn_tuple_t tup = args->tup;
+
+    pktbuf_t pkt = pktbuf_from_tls(ctx, args);
+    mysql_handle_packet(pkt, &tup, (__u8)args->tags);
+    return 0;
+}
+
+// Handles connection termination for a TLS MySQL connection.
+SEC("uprobe/mysql_tls_termination")
+int uprobe__mysql_tls_termination(struct pt_regs *ctx) {
+    const __u32 zero = 0;
+
+    tls_dispatcher_arguments_t *args = bpf_map_lookup_elem(&tls_dispatcher_arguments, &zero);
+    if (args == NULL) {
+        return 0;
+    }
+
+    // Copying the tuple to the stack to handle verifier issues on kernel 4.14.
+    conn_tuple_t tup = args->tup;
+    mysql_tcp_termination(&tup);
+    return 0;
+}
+
+#endif
@@ -8,6 +8,19 @@
 #define MYSQL_COMMAND_QUERY 0x3
 // Taken from https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_com_stmt_prepare.html
 #define MYSQL_PREPARE_QUERY 0x16
+// Taken from https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_com_stmt_execute.html
+#define MYSQL_COMMAND_STMT_EXECUTE 0x17
+// Taken from https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_com_stmt_close.html
+#define MYSQL_COMMAND_STMT_CLOSE 0x19
+// Taken from https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_basic_ok_packet.html, also used as
+// the status of COM_STMT_PREPARE_OK.
+#define MYSQL_RESPONSE_OK 0x0
+// Taken from https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_basic_err_packet.html
+#define MYSQL_RESPONSE_ERR 0xff
+// The sequence id of a command packet, sent by the client.
+#define MYSQL_REQUEST_SEQ_ID 0
+// The sequence id of the first packet of the response to a command, sent by the server.
+#define MYSQL_RESPONSE_SEQ_ID 1
 // Taken from https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_connection_phase_packets_protocol_handshake_v10.html.
 #define MYSQL_SERVER_GREETING_V10 0xa
 // Taken from https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_connection_phase_packets_protocol_handshake_v9.html.
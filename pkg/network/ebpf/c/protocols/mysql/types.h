@@ -0,0 +1,44 @@
+#ifndef __MYSQL_TYPES_H
+#define __MYSQL_TYPES_H
+
+#include "conn_tuple.h"
+
+// Maximum length of MySQL query to send to userspace.
+#define MYSQL_BUFFER_SIZE 160
+
+// MySQL transaction information we store in the kernel.
+typedef struct {
+    // The MySQL query we are currently processing. Stored up to MYSQL_BUFFER_SIZE bytes.
+    // For COM_STMT_EXECUTE, this is the query of the statement, as seen when it was prepared.
+    char request_fragment[MYSQL_BUFFER_SIZE];
+    __u64 request_started;
+    __u64 response_last_seen;
+    // The actual size of the query stored in request_fragment.
+    __u32 original_query_size;
+    // The error code of the ERR packet, if the command failed.
+    __u16 error_code;
+    // The command byte of the request (COM_QUERY, COM_STMT_PREPARE or COM_STMT_EXECUTE).
+    __u8 command;
+    __u8 tags;
+    bool is_error;
+} mysql_transaction_t;
+
+// The struct we send to userspace, containing the connection tuple and the transaction information.
+typedef struct {
+    conn_tuple_t tuple;
+    mysql_transaction_t tx;
+} mysql_event_t;
+
+// Identifies a prepared statement of a connection.
+typedef struct {
+    conn_tuple_t tuple;
+    __u32 statement_id;
+} mysql_statement_key_t;
+
+// The query of a prepared statement, attached to the transactions executing it.
+typedef struct {
+    char query_fragment[MYSQL_BUFFER_SIZE];
+    __u32 original_query_size;
+} mysql_statement_t;
+
+#endif
@@ -0,0 +1,12 @@
+#ifndef __MYSQL_USM_EVENTS_H
+#define __MYSQL_USM_EVENTS_H
+
+#include "protocols/events.h"
+#include "protocols/mysql/types.h"
+
+// Controls the number of MySQL transactions read from userspace at a time.
+#define MYSQL_BATCH_SIZE (MAX_BATCH_SIZE(mysql_event_t))
+
+USM_EVENTS_INIT(mysql, mysql_event_t, MYSQL_BATCH_SIZE);
+
+#endif
@@ -82,6 +82,8 @@ update_stack:
  * - Kafka: PROG_KAFKA
  * - PostgreSQL: PROG_POSTGRES
  * - Redis: PROG_REDIS
+ * - MySQL: PROG_MYSQL
+ * - MongoDB: PROG_MONGO
  *
  * The function takes the BPF program context, connection metadata (tuple), a pointer to
  * the decrypted payload and its length, and connection metadata tags as input.
@@ -161,6 +163,14 @@ static __always_inline void tls_process(struct pt_regs *ctx, conn_tuple_t *t, vo
         prog = PROG_REDIS;
         final_tuple = normalized_tuple;
         break;
+    case PROTOCOL_MYSQL:
+        prog = PROG_MYSQL;
+        final_tuple = normalized_tuple;
+        break;
+    case PROTOCOL_MONGO:
+        prog = PROG_MONGO;
+        final_tuple = normalized_tuple;
+        break;
     default:
         return;
     }
@@ -253,6 +263,10 @@ static __always_inline void tls_finish(struct pt_regs *ctx, conn_tuple_t *t, boo
         prog = PROG_REDIS_TERMINATION;
         final_tuple = normalized_tuple;
         break;
+    case PROTOCOL_MYSQL:
+        prog = PROG_MYSQL_TERMINATION;
+        final_tuple = normalized_tuple;
+        break;
     default:
         return;
     }
@@ -10,5 +10,8 @@ BPF_HASH_MAP(ssl_certs_i2d_X509_args, __u64, i2d_X509_args_t, 1)
 BPF_HASH_MAP(ssl_handshake_state, void *, ssl_handshake_state_t, 1)
 BPF_HASH_MAP(ssl_cert_info, cert_id_t, cert_item_t, 1)
 
+// Scratch buffer used to build a ssl_handshake_state_t, which doesn't fit on the stack along with a cert_t
+BPF_PERCPU_ARRAY_MAP(ssl_handshake_state_heap, ssl_handshake_state_t, 1)
+
 
 #endif //__TLS_CERTS_MAPS_H
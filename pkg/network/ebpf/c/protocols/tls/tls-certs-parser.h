@@ -137,6 +137,7 @@ static __always_inline __s32 read_elem_size(data_t *data) {
 #define OBJECT_ID_TYPE 0x06
 #define UTC_DATE_TYPE 0x17
 #define SEQ_TYPE 0x30
+#define SET_TYPE 0x31
 #define CONTEXT_SPECIFIC_TYPE 0xa0
 
 static __always_inline data_t expect_der_elem(data_t *data, __u8 expected_type) {
@@ -242,6 +243,84 @@ static __always_inline bool parse_cert_date(data_t *data, __u8 (*dst)[UTC_ZONELE
     return false;
 }
 
+#define COMMON_NAME_ID "\x55\x04\x03"
+// the number of relative distinguished names to look into for a common name
+#define MAX_NAME_RDNS 8
+
+// parse_cert_name extracts the common name (CN) of a Name, which is a sequence of
+// relative distinguished names, each being a set of attribute type and value.
+// The name is truncated to DOMAIN_LEN, and left empty if there isn't any CN.
+static __always_inline bool parse_cert_name(data_t *data, cert_domain_t *common_name) {
+    data_t name_seq = expect_der_elem(data, SEQ_TYPE);
+    if (!name_seq.buf) {
+        log_bail();
+        return true;
+    }
+
+    for (int i = 0; i < MAX_NAME_RDNS; i++) {
+        if (is_data_consumed(name_seq)) {
+            break;
+        }
+
+        data_t rdn_set = expect_der_elem(&name_seq, SET_TYPE);
+        if (!rdn_set.buf) {
+            log_bail();
+            return true;
+        }
+
+        data_t attribute_seq = expect_der_elem(&rdn_set, SEQ_TYPE);
+        if (!attribute_seq.buf) {
+            log_bail();
+            return true;
+        }
+
+        data_t obj_id = expect_der_elem(&attribute_seq, OBJECT_ID_TYPE);
+        if (!obj_id.buf) {
+            log_bail();
+            return true;
+        }
+        if (data_size(obj_id) != 3) {
+            continue;
+        }
+
+        char obj_id_buf[3] = {0};
+        if (data_read(&obj_id_buf, &obj_id, 3)) {
+            log_bail();
+            return true;
+        }
+        if (bpf_memcmp(COMMON_NAME_ID, obj_id_buf, 3)) {
+            continue;
+        }
+
+        // the CN may be any of the directory string types, so don't check it
+        __u8 value_type = 0;
+        if (data_peek(&value_type, &attribute_seq, 1)) {
+            log_bail();
+            return true;
+        }
+        data_t value = expect_der_elem(&attribute_seq, value_type);
+        if (!value.buf) {
+            log_bail();
+            return true;
+        }
+
+        __u8 name_len = DOMAIN_LEN;
+        __u32 size = data_size(value);
+        if (size < DOMAIN_LEN) {
+            name_len = size;
+        }
+
+        common_name->len = name_len;
+        if (data_read(&common_name->data, &value, name_len)) {
+            log_bail();
+            return true;
+        }
+        break;
+    }
+
+    return false;
+}
+
 static __always_inline bool parse_cert_validity(data_t *data, cert_t *cert) {
     data_t validity_seq = expect_der_elem(data, SEQ_TYPE);
     if (!validity_seq.buf) {
@@ -485,8 +564,7 @@ static __always_inline bool parse_tbs_cert(data_t *data, cert_t *cert) {
         log_bail();
         return true;
     }
-    // issuer -- also irrelevant
-    if (!expect_der_elem(&tbs_cert_seq, SEQ_TYPE).buf) {
+    if (parse_cert_name(&tbs_cert_seq, &cert->issuer)) {
         log_bail();
         return true;
     }
@@ -496,8 +574,7 @@ static __always_inline bool parse_tbs_cert(data_t *data, cert_t *cert) {
         return true;
     }
 
-    // subject -- also irrelevant
-    if (!expect_der_elem(&tbs_cert_seq, SEQ_TYPE).buf) {
+    if (parse_cert_name(&tbs_cert_seq, &cert->subject)) {
         log_bail();
         return true;
     }
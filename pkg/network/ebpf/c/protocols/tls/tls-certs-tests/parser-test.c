@@ -106,6 +106,22 @@ bool check_memcmp_len(char *test_name, cert_t expected, cert_t actual) {
         printf("  actual: '%.*s'\n", actual.domain.len, actual.domain.data);
     }
 
+    if (!memcmp_len(expected.subject.data, expected.subject.len, actual.subject.data, actual.subject.len)) {
+        passed = false;
+
+        printf("[%s] mismatched subject.\n", test_name);
+        printf("expected: '%.*s'\n", expected.subject.len, expected.subject.data);
+        printf("  actual: '%.*s'\n", actual.subject.len, actual.subject.data);
+    }
+
+    if (!memcmp_len(expected.issuer.data, expected.issuer.len, actual.issuer.data, actual.issuer.len)) {
+        passed = false;
+
+        printf("[%s] mismatched issuer.\n", test_name);
+        printf("expected: '%.*s'\n", expected.issuer.len, expected.issuer.data);
+        printf("  actual: '%.*s'\n", actual.issuer.len, actual.issuer.data);
+    }
+
     if (!matches_utc(test_name, "not_before", expected.validity.not_before, actual.validity.not_before)) {
         passed = false;
     }
@@ -144,6 +160,13 @@ bool test_datadoghq() {
     dd_cert.domain.len = strlen(domain);
     strcpy(dd_cert.domain.data, domain);
 
+    dd_cert.subject.len = strlen(domain);
+    strcpy(dd_cert.subject.data, domain);
+
+    const char *issuer = "DigiCert Global G2 TLS RSA SHA256 2020 CA1";
+    dd_cert.issuer.len = strlen(issuer);
+    strcpy(dd_cert.issuer.data, issuer);
+
 
     return check_memcmp_len("datadoghq", dd_cert, actual);
 }
@@ -170,6 +193,14 @@ bool test_digicert_ca() {
     strncpy(dd_cert.validity.not_before, "210330000000", UTC_ZONELESS_LEN);
     strncpy(dd_cert.validity.not_after, "310329235959", UTC_ZONELESS_LEN);
 
+    const char *subject = "DigiCert Global G2 TLS RSA SHA256 2020 CA1";
+    dd_cert.subject.len = strlen(subject);
+    strcpy(dd_cert.subject.data, subject);
+
+    const char *issuer = "DigiCert Global Root G2";
+    dd_cert.issuer.len = strlen(issuer);
+    strcpy(dd_cert.issuer.data, issuer);
+
     return check_memcmp_len("digicert_ca", dd_cert, actual);
 }
 
@@ -55,6 +55,9 @@ typedef struct {
     cert_serial_t serial;
     cert_domain_t domain;
     cert_validity_t validity;
+    // common names of the subject and issuer
+    cert_domain_t subject;
+    cert_domain_t issuer;
     bool is_ca;
 } cert_t;
 
@@ -64,6 +67,8 @@ typedef struct {
     cert_serial_t serial;
     cert_domain_t domain;
     cert_validity_t validity;
+    cert_domain_t subject;
+    cert_domain_t issuer;
 } cert_item_t;
 
 typedef struct {
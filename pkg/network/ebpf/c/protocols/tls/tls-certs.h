@@ -54,19 +54,25 @@ static __always_inline void SSL_add_cert(void *ssl_ctx, data_t data) {
 
 
     if (!cert.is_ca) {
-        ssl_handshake_state_t state = {0};
+        const __u32 zero = 0;
+        ssl_handshake_state_t *state = bpf_map_lookup_elem(&ssl_handshake_state_heap, &zero);
+        if (!state) {
+            return;
+        }
 
         __u64 timestamp = bpf_ktime_get_ns();
 
-        state.cert_id = cert.cert_id;
+        state->cert_id = cert.cert_id;
 
-        state.cert_item.timestamp = timestamp;
-        state.cert_item.serial = cert.serial;
-        state.cert_item.domain = cert.domain;
-        state.cert_item.validity = cert.validity;
+        state->cert_item.timestamp = timestamp;
+        state->cert_item.serial = cert.serial;
+        state->cert_item.domain = cert.domain;
+        state->cert_item.validity = cert.validity;
+        state->cert_item.subject = cert.subject;
+        state->cert_item.issuer = cert.issuer;
 
-        bpf_map_update_with_telemetry(ssl_cert_info, &cert.cert_id, &state.cert_item, BPF_ANY);
-        bpf_map_update_with_telemetry(ssl_handshake_state, &ssl_ctx, &state, BPF_ANY);
+        bpf_map_update_with_telemetry(ssl_cert_info, &cert.cert_id, &state->cert_item, BPF_ANY);
+        bpf_map_update_with_telemetry(ssl_handshake_state, &ssl_ctx, state, BPF_ANY);
     }
 }
 
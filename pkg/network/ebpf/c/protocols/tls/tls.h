@@ -2,6 +2,7 @@
 #define __TLS_H
 
 #include "tracer/tracer.h"
+#include "protocols/read_into_buffer.h"
 
 // TLS version constants (SSL versions are deprecated, included for completeness)
 #define SSL_VERSION20 0x0200
@@ -20,6 +21,7 @@
 // TLS Handshake Types
 #define TLS_HANDSHAKE_CLIENT_HELLO 0x01
 #define TLS_HANDSHAKE_SERVER_HELLO 0x02
+#define TLS_HANDSHAKE_CERTIFICATE  0x0b
 
 // Bitmask constants for offered versions
 #define TLS_VERSION10_BIT 1 << 0
@@ -31,6 +33,10 @@
 #define MAX_EXTENSIONS 16
 // The supported_versions extension for TLS 1.3 is described in RFC 8446 Section 4.2.1
 #define SUPPORTED_VERSIONS_EXTENSION 0x002B
+// The server_name extension (SNI) is described in RFC 6066 Section 3
+#define SERVER_NAME_EXTENSION 0x0000
+// The only server name type defined by RFC 6066
+#define SERVER_NAME_TYPE_HOST_NAME 0x00
 
 // Maximum TLS record payload size (16 KB)
 #define TLS_MAX_PAYLOAD_LENGTH (1 << 14)
@@ -54,6 +60,10 @@
 #define COMPRESSION_METHODS_LENGTH    1  // Compression Methods length field is 1 byte (RFC 5246 Section 7.4.1.2)
 #define EXTENSION_TYPE_LENGTH         2  // Extension Type field is 2 bytes (RFC 5246 Section 7.4.1.4)
 #define EXTENSION_LENGTH_FIELD        2  // Extension Length field is 2 bytes (RFC 5246 Section 7.4.1.4)
+#define SERVER_NAME_LIST_LENGTH       2  // Server Name List length field is 2 bytes (RFC 6066 Section 3)
+#define SERVER_NAME_LENGTH_FIELD      2  // Host Name length field is 2 bytes (RFC 6066 Section 3)
+#define CERTIFICATE_LIST_LENGTH       3  // Certificate List length field is 3 bytes (RFC 5246 Section 7.4.2)
+#define CERTIFICATE_LENGTH            3  // Certificate length field is 3 bytes (RFC 5246 Section 7.4.2)
 
 // For single-byte fields (list lengths, etc.)
 #define SINGLE_BYTE_LENGTH           1
@@ -64,6 +74,17 @@
 // Maximum number of supported versions we unroll for (all TLS versions)
 #define MAX_SUPPORTED_VERSIONS 4
 
+// FNV-1a parameters used to derive the ID of a server name
+#define TLS_SERVER_NAME_FNV_OFFSET_BASIS 2166136261U
+#define TLS_SERVER_NAME_FNV_PRIME 16777619U
+
+// Number of 4-byte chunks of the captured certificate used to derive its ID. The first 64 bytes
+// of a certificate include its serial number, which makes the ID unique enough.
+#define TLS_HANDSHAKE_CERT_ID_CHUNKS 16
+
+READ_INTO_BUFFER(tls_server_name, TLS_MAX_SERVER_NAME_LEN, BLK_SIZE)
+READ_INTO_BUFFER(tls_handshake_cert, TLS_HANDSHAKE_CERT_LEN, BLK_SIZE)
+
 // TLS record layer header structure (RFC 5246)
 typedef struct {
     __u8 content_type;
@@ -364,20 +385,72 @@ static __always_inline bool parse_supported_versions_extension(struct __sk_buff
     return true;
 }
 
+// parse_server_name_extension reads the host name from the server_name extension of the ClientHello
+// Reference: RFC 6066 Section 3 (Server Name Indication): https://tools.ietf.org/html/rfc6066#section-3
+// Although the list may hold several names, clients send a single host_name entry.
+//   +-------------------+--------------+----------------+-------------------+
+//   | list_length(2)    | name_type(1) | name_length(2) | name(name_length) |
+//   +-------------------+--------------+----------------+-------------------+
+// Names longer than TLS_MAX_SERVER_NAME_LEN are truncated.
+static __always_inline bool parse_server_name_extension(struct __sk_buff *skb, __u32 offset, __u32 data_end, __u32 extension_end, tls_server_name_t *server_name) {
+    // Skip the Server Name List length (2 bytes)
+    offset += SERVER_NAME_LIST_LENGTH;
+
+    if (offset + SINGLE_BYTE_LENGTH + SERVER_NAME_LENGTH_FIELD > extension_end) {
+        return false;
+    }
+
+    // Read Name Type (1 byte)
+    __u8 name_type;
+    if (bpf_skb_load_bytes(skb, offset, &name_type, SINGLE_BYTE_LENGTH) < 0) {
+        return false;
+    }
+    offset += SINGLE_BYTE_LENGTH;
+
+    if (name_type != SERVER_NAME_TYPE_HOST_NAME) {
+        return true;
+    }
+
+    // Read Host Name length (2 bytes)
+    __u16 name_length;
+    if (bpf_skb_load_bytes(skb, offset, &name_length, SERVER_NAME_LENGTH_FIELD) < 0) {
+        return false;
+    }
+    name_length = bpf_ntohs(name_length);
+    offset += SERVER_NAME_LENGTH_FIELD;
+
+    if (name_length == 0 || offset + name_length > extension_end || offset + name_length > data_end) {
+        return false;
+    }
+
+    if (name_length > TLS_MAX_SERVER_NAME_LEN) {
+        name_length = TLS_MAX_SERVER_NAME_LEN;
+    }
+
+    read_into_buffer_tls_server_name((char *)server_name->name, skb, offset);
+    server_name->len = name_length;
+
+    return true;
+}
+
 // parse_tls_extensions parses TLS extensions in both ClientHello and ServerHello
 // References:
 // - RFC 5246 Section 7.4.1.4 (Hello Extensions): https://tools.ietf.org/html/rfc5246#section-7.4.1.4
 // - For TLS 1.3 supported_versions extension: RFC 8446 Section 4.2.1: https://tools.ietf.org/html/rfc8446#section-4.2.1
 // This function iterates over extensions, reading the extension_type and extension_length, and if it encounters 
 // the supported_versions extension, it calls parse_supported_versions_extension to handle it.
+// The server_name extension of the ClientHello is only located in the loop, and read into `server_name` once
+// the loop is done to keep the unrolled loop small.
 // ASCII snippet for a single extension:
 //   +---------+---------+--------------------------------+
 //   | ext_type(2) | ext_length(2) | ext_data(ext_length) |
 //   +---------+---------+--------------------------------+
 // For multiple extensions, they are just concatenated one after another.
-static __always_inline bool parse_tls_extensions(struct __sk_buff *skb, __u32 *offset, __u32 data_end, __u32 extensions_end, tls_info_t *tags, bool is_client_hello) {
+static __always_inline bool parse_tls_extensions(struct __sk_buff *skb, __u32 *offset, __u32 data_end, __u32 extensions_end, tls_info_t *tags, tls_server_name_t *server_name, bool is_client_hello) {
     __u16 extension_type;
     __u16 extension_length;
+    __u32 server_name_offset = 0;
+    __u32 server_name_end = 0;
 
     #pragma unroll(MAX_EXTENSIONS)
     for (int i = 0; i < MAX_EXTENSIONS; i++) {
@@ -407,6 +480,10 @@ static __always_inline bool parse_tls_extensions(struct __sk_buff *skb, __u32 *o
             if (!parse_supported_versions_extension(skb, offset, data_end, extensions_end, tags, is_client_hello)) {
                 return false;
             }
+        } else if (is_client_hello && server_name && extension_type == SERVER_NAME_EXTENSION) {
+            server_name_offset = *offset;
+            server_name_end = *offset + extension_length;
+            *offset += extension_length;
         } else {
             // Skip other extensions
             *offset += extension_length;
@@ -417,10 +494,14 @@ static __always_inline bool parse_tls_extensions(struct __sk_buff *skb, __u32 *o
         }
     }
 
+    if (server_name && server_name_offset != 0) {
+        return parse_server_name_extension(skb, server_name_offset, data_end, server_name_end, server_name);
+    }
+
     return true;
 }
 
-// parse_client_hello parses the ClientHello message and populates tags
+// parse_client_hello parses the ClientHello message and populates tags, the server name (SNI) is read into server_name
 // Reference: RFC 5246 Section 7.4.1.2 (Client Hello), https://tools.ietf.org/html/rfc5246
 // Structure (simplified):
 // handshake_type (1 byte), length (3 bytes), version (2 bytes), random(32 bytes), session_id_length(1 byte), session_id(variable), cipher_suites_length(2 bytes), cipher_suites(variable), compression_methods_length(1 byte), compression_methods(variable), extensions_length(2 bytes), extensions(variable)
@@ -442,7 +523,7 @@ static __always_inline bool parse_tls_extensions(struct __sk_buff *skb, __u32 *o
 // | extensions_length (2)      |
 // | extensions(...)            |
 // +----------------------------+
-static __always_inline bool parse_client_hello(struct __sk_buff *skb, __u32 offset, __u32 data_end, tls_info_t *tags) {
+static __always_inline bool parse_client_hello(struct __sk_buff *skb, __u32 offset, __u32 data_end, tls_info_t *tags, tls_server_name_t *server_name) {
     __u32 handshake_length;
     __u16 client_version;
 
@@ -508,7 +589,7 @@ static __always_inline bool parse_client_hello(struct __sk_buff *skb, __u32 offs
 
     __u32 extensions_end = offset + extensions_length;
 
-    return parse_tls_extensions(skb, &offset, data_end, extensions_end, tags, true);
+    return parse_tls_extensions(skb, &offset, data_end, extensions_end, tags, server_name, true);
 }
 
 // parse_server_hello parses the ServerHello message and populates tags
@@ -589,7 +670,7 @@ static __always_inline bool parse_server_hello(struct __sk_buff *skb, __u32 offs
 
     __u32 extensions_end = offset + extensions_length;
 
-    return parse_tls_extensions(skb, &offset, data_end, extensions_end, tags, false);
+    return parse_tls_extensions(skb, &offset, data_end, extensions_end, tags, NULL, false);
 }
 
 // is_tls_handshake_type checks if the handshake type at the given offset matches the expected type (e.g., ClientHello or ServerHello)
@@ -619,4 +700,103 @@ static __always_inline bool is_tls_handshake_server_hello(struct __sk_buff *skb,
     return is_tls_handshake_type(skb, offset, data_end, TLS_HANDSHAKE_SERVER_HELLO);
 }
 
+// parse_server_certificate captures the beginning of the server certificate from the Certificate message, which
+// follows the ServerHello in TLS 1.2 and earlier versions. In TLS 1.3 the Certificate message is encrypted.
+// Reference: RFC 5246 Section 7.4.2 (Server Certificate), https://tools.ietf.org/html/rfc5246#section-7.4.2
+// `offset` points to the record following the ServerHello record. As the certificate chain usually spans several
+// packets, only the bytes of the first (leaf) certificate present in this packet are captured, and the record isn't
+// required to be complete.
+// +--------------------------------------+
+// | record header (5)                    |
+// +--------------------------------------+
+// | handshake_type (1) | length (3)      |
+// +--------------------------------------+
+// | certificate_list_length (3)          |
+// +--------------------------------------+
+// | certificate_length (3)               |
+// | certificate (DER encoded X.509) ...  |
+// +--------------------------------------+
+static __always_inline bool parse_server_certificate(struct __sk_buff *skb, __u32 offset, __u32 data_end, tls_handshake_cert_t *cert) {
+    if (offset + sizeof(tls_record_header_t) > data_end) {
+        return false;
+    }
+    tls_record_header_t tls_hdr;
+    if (bpf_skb_load_bytes(skb, offset, &tls_hdr, sizeof(tls_record_header_t)) < 0) {
+        return false;
+    }
+    if (tls_hdr.content_type != TLS_HANDSHAKE) {
+        return false;
+    }
+    offset += sizeof(tls_record_header_t);
+
+    if (!is_tls_handshake_type(skb, offset, data_end, TLS_HANDSHAKE_CERTIFICATE)) {
+        return false;
+    }
+    // Skip the handshake header and the certificate list length
+    offset += TLS_HELLO_MESSAGE_HEADER_SIZE + CERTIFICATE_LIST_LENGTH;
+
+    // Read the length of the first certificate (3 bytes)
+    if (offset + CERTIFICATE_LENGTH > data_end) {
+        return false;
+    }
+    __u8 cert_length_bytes[CERTIFICATE_LENGTH];
+    if (bpf_skb_load_bytes(skb, offset, cert_length_bytes, CERTIFICATE_LENGTH) < 0) {
+        return false;
+    }
+    __u32 cert_length = (cert_length_bytes[0] << 16) |
+                        (cert_length_bytes[1] << 8) |
+                        cert_length_bytes[2];
+    offset += CERTIFICATE_LENGTH;
+
+    if (offset >= data_end) {
+        return false;
+    }
+    if (cert_length > data_end - offset) {
+        cert_length = data_end - offset;
+    }
+    if (cert_length > TLS_HANDSHAKE_CERT_LEN) {
+        cert_length = TLS_HANDSHAKE_CERT_LEN;
+    }
+    // The ID is derived from the beginning of the certificate, which must have been captured
+    if (cert_length < TLS_HANDSHAKE_CERT_ID_CHUNKS * sizeof(__u32)) {
+        return false;
+    }
+
+    read_into_buffer_tls_handshake_cert((char *)cert->data, skb, offset);
+    cert->len = cert_length;
+
+    return true;
+}
+
+// tls_server_name_id derives an ID for a server name read by parse_server_name_extension by hashing it with FNV-1a.
+// The bytes following the name are cleared first, as they hold whatever followed it in the packet.
+static __always_inline __u32 tls_server_name_id(tls_server_name_t *server_name) {
+    __u32 hash = TLS_SERVER_NAME_FNV_OFFSET_BASIS;
+
+    #pragma unroll(TLS_MAX_SERVER_NAME_LEN)
+    for (int i = 0; i < TLS_MAX_SERVER_NAME_LEN; i++) {
+        if (i >= server_name->len) {
+            server_name->name[i] = 0;
+        }
+        hash ^= server_name->name[i];
+        hash *= TLS_SERVER_NAME_FNV_PRIME;
+    }
+
+    return hash;
+}
+
+// tls_handshake_cert_id derives an ID for a certificate captured by parse_server_certificate
+// by XOR'ing the beginning of the certificate together
+static __always_inline __u32 tls_handshake_cert_id(tls_handshake_cert_t *cert) {
+    __u32 cert_id = 0;
+    __u32 *chunks = (__u32 *)cert->data;
+
+    #pragma unroll(TLS_HANDSHAKE_CERT_ID_CHUNKS)
+    for (int i = 0; i < TLS_HANDSHAKE_CERT_ID_CHUNKS; i++) {
+        cert_id ^= chunks[i];
+    }
+
+    return cert_id;
+}
+
 #endif // __TLS_H
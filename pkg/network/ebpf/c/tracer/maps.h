@@ -129,6 +129,18 @@ BPF_HASH_MAP(tcp_close_args, __u64, conn_tuple_t, 1024)
 // Map to store extra information about TLS connections like version, cipher, etc.
 BPF_HASH_MAP(tls_enhanced_tags, conn_tuple_t, tls_info_wrapper_t, 0)
 
+// Map to store the server names sent in TLS ClientHello messages [server_name_id -> server name]
+BPF_HASH_MAP(tls_server_names, __u32, tls_server_name_t, 1)
+
+// Scratch buffer used to build a tls_server_name_t
+BPF_PERCPU_ARRAY_MAP(tls_server_name_heap, tls_server_name_t, 1)
+
+// Map to store the server certificates captured from TLS 1.2 handshakes [cert_id -> DER prefix]
+BPF_HASH_MAP(tls_handshake_certs, __u32, tls_handshake_cert_t, 1)
+
+// Scratch buffer used to build a tls_handshake_cert_t, which doesn't fit on the stack
+BPF_PERCPU_ARRAY_MAP(tls_handshake_cert_heap, tls_handshake_cert_t, 1)
+
 // Map to store telemetry for TCP failures [code -> count]
 BPF_HASH_MAP(tcp_failure_telemetry, int, __u64, 1024)
 
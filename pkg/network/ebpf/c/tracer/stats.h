@@ -71,6 +71,16 @@ static __always_inline void merge_tls_info(tls_info_t *this, tls_info_t *that) {
 
     // Merge offered_versions bitmask
     this->offered_versions |= that->offered_versions;
+
+    // Merge server_name_id if not already set
+    if (this->server_name_id == 0 && that->server_name_id != 0) {
+        this->server_name_id = that->server_name_id;
+    }
+
+    // Merge cert_id if not already set
+    if (this->cert_id == 0 && that->cert_id != 0) {
+        this->cert_id = that->cert_id;
+    }
 }
 
 static __always_inline conn_stats_ts_t *get_conn_stats(conn_tuple_t *t, struct sock *sk) {
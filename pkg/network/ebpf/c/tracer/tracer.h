@@ -33,12 +33,34 @@ typedef enum {
 
 #define CONN_DIRECTION_MASK 0b11
 
+// Maximum length of the server name captured from the ClientHello SNI extension
+#define TLS_MAX_SERVER_NAME_LEN 64
+// Maximum number of bytes of the server certificate captured from a TLS 1.2 handshake
+#define TLS_HANDSHAKE_CERT_LEN 1024
+
 typedef struct {
     __u16 chosen_version;
     __u16 cipher_suite;
     __u8  offered_versions;
+    // ID of the server name sent in the ClientHello, in tls_server_names
+    __u32 server_name_id;
+    // ID of the server certificate captured from the handshake, in tls_handshake_certs
+    __u32 cert_id;
 } tls_info_t;
 
+typedef struct {
+    __u64 timestamp;
+    __u8 len;
+    __u8 name[TLS_MAX_SERVER_NAME_LEN];
+} tls_server_name_t;
+
+typedef struct {
+    __u64 timestamp;
+    // the captured length, which may be shorter than the certificate itself
+    __u32 len;
+    __u8 data[TLS_HANDSHAKE_CERT_LEN];
+} tls_handshake_cert_t;
+
 typedef struct {
     __u64 updated;
     tls_info_t info;
@@ -44,9 +44,9 @@ type ConnStats struct {
 	Protocol_stack ProtocolStack
 	Flags          uint8
 	Direction      uint8
+	Pad_cgo_0      [2]byte
 	Tls_tags       TLSTags
 	Cert_id        uint32
-	Pad_cgo_0      [4]byte
 }
 type Conn struct {
 	Tup        ConnTuple
@@ -107,19 +107,33 @@ type TLSTags struct {
 	Chosen_version   uint16
 	Cipher_suite     uint16
 	Offered_versions uint8
-	Pad_cgo_0        [1]byte
+	Pad_cgo_0        [3]byte
+	Server_name_id   uint32
+	Cert_id          uint32
 }
 type TLSTagsWrapper struct {
-	Updated   uint64
-	Info      TLSTags
-	Pad_cgo_0 [2]byte
+	Updated uint64
+	Info    TLSTags
+}
+type TLSServerName struct {
+	Timestamp uint64
+	Len       uint8
+	Name      [64]uint8
+	Pad_cgo_0 [7]byte
+}
+type TLSHandshakeCert struct {
+	Timestamp uint64
+	Len       uint32
+	Data      [1024]uint8
+	Pad_cgo_0 [4]byte
 }
 type CertItem struct {
 	Timestamp uint64
 	Serial    CertSerial
 	Domain    CertDomain
 	Validity  CertValidity
-	Pad_cgo_0 [2]byte
+	Subject   CertDomain
+	Issuer    CertDomain
 }
 type CertSerial struct {
 	Len  uint8
@@ -158,7 +172,7 @@ const (
 	Assured ConnFlags = 0x4
 )
 
-const SizeofConn = 0xa0
+const SizeofConn = 0xa8
 
 type ClassificationProgram = uint32
 type ClassificationTLSProgram = uint32
@@ -72,6 +72,14 @@ func TestCgoAlignment_TLSTagsWrapper(t *testing.T) {
 	ebpftest.TestCgoAlignment[TLSTagsWrapper](t)
 }
 
+func TestCgoAlignment_TLSServerName(t *testing.T) {
+	ebpftest.TestCgoAlignment[TLSServerName](t)
+}
+
+func TestCgoAlignment_TLSHandshakeCert(t *testing.T) {
+	ebpftest.TestCgoAlignment[TLSHandshakeCert](t)
+}
+
 func TestCgoAlignment_CertItem(t *testing.T) {
 	ebpftest.TestCgoAlignment[CertItem](t)
 }
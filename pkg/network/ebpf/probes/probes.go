@@ -254,6 +254,10 @@ const (
 	ConnectionTupleToSocketSKBConnMap BPFMapName = "conn_tuple_to_socket_skb_conn_tuple"
 	// EnhancedTLSTagsMap is the map storing additional tags for TLS connections (version, cipher, etc.)
 	EnhancedTLSTagsMap BPFMapName = "tls_enhanced_tags"
+	// TLSHandshakeCertsMap is the map storing the server certificates captured from TLS 1.2 handshakes
+	TLSHandshakeCertsMap BPFMapName = "tls_handshake_certs"
+	// TLSServerNamesMap is the map storing the server names sent in TLS ClientHello messages
+	TLSServerNamesMap BPFMapName = "tls_server_names"
 	// ClassificationProgsMap is the map storing the programs to run on classification events
 	ClassificationProgsMap BPFMapName = "classification_progs"
 )
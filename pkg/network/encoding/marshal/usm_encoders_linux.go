@@ -30,6 +30,9 @@ func InitializeUSMEncoders(conns *network.Connections) []USMEncoder {
 	if encoder := newPostgresEncoder(conns.USMData.Postgres); encoder != nil {
 		encoders = append(encoders, encoder)
 	}
+	// MySQL and MongoDB stats aren't encoded until the connections payload
+	// of agent-payload has messages for them, they are only exposed by the
+	// debug endpoints of system-probe meanwhile.
 
 	return encoders
 }
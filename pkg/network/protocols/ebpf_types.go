@@ -79,6 +79,12 @@ const (
 	ProgramRedis ProgramType = C.PROG_REDIS
 	// ProgramRedisTermination is the Golang representation of the C.PROG_REDIS_TERMINATION enum
 	ProgramRedisTermination ProgramType = C.PROG_REDIS_TERMINATION
+	// ProgramMySQL is the Golang representation of the C.PROG_MYSQL enum
+	ProgramMySQL ProgramType = C.PROG_MYSQL
+	// ProgramMySQLTermination is the Golang representation of the C.PROG_MYSQL_TERMINATION enum
+	ProgramMySQLTermination ProgramType = C.PROG_MYSQL_TERMINATION
+	// ProgramMongo is the Golang representation of the C.PROG_MONGO enum
+	ProgramMongo ProgramType = C.PROG_MONGO
 )
 
 type ebpfProtocolType C.protocol_t
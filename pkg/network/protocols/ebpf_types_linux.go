@@ -65,6 +65,12 @@ const (
 	ProgramRedis ProgramType = 0x16
 
 	ProgramRedisTermination ProgramType = 0x17
+
+	ProgramMySQL ProgramType = 0x18
+
+	ProgramMySQLTermination ProgramType = 0x19
+
+	ProgramMongo ProgramType = 0x1a
 )
 
 type ebpfProtocolType uint16
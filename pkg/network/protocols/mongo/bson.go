@@ -0,0 +1,185 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package mongo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+)
+
+// The kernel sends the beginning of the sections of OP_MSG messages, which may be truncated. The helpers below decode
+// as much of the body section as is available, and never fail on a truncated document.
+// References:
+// - https://www.mongodb.com/docs/manual/reference/mongodb-wire-protocol/#sections
+// - https://bsonspec.org/spec.html
+
+const (
+	sectionKindBody             = 0
+	sectionKindDocumentSequence = 1
+
+	bsonDouble     = 0x01
+	bsonString     = 0x02
+	bsonDocument   = 0x03
+	bsonArray      = 0x04
+	bsonBinary     = 0x05
+	bsonObjectID   = 0x07
+	bsonBool       = 0x08
+	bsonDateTime   = 0x09
+	bsonNull       = 0x0a
+	bsonInt32      = 0x10
+	bsonTimestamp  = 0x11
+	bsonInt64      = 0x12
+	bsonDecimal128 = 0x13
+	bsonMinKey     = 0xff
+	bsonMaxKey     = 0x7f
+
+	databaseField = "$db"
+	okField       = "ok"
+)
+
+// bsonElement is an element of a BSON document. The value is nil if it is truncated.
+type bsonElement struct {
+	kind  byte
+	name  string
+	value []byte
+}
+
+// bodyDocument returns the elements of the body document, starting after its length. A document sequence section
+// preceding the body is skipped.
+func bodyDocument(sections []byte) []byte {
+	for len(sections) > 0 {
+		switch sections[0] {
+		case sectionKindBody:
+			// Skipping the kind and the length of the document.
+			if len(sections) < 5 {
+				return nil
+			}
+			return sections[5:]
+		case sectionKindDocumentSequence:
+			// The size of a document sequence includes itself, but not the kind byte.
+			if len(sections) < 5 {
+				return nil
+			}
+			size := binary.LittleEndian.Uint32(sections[1:5])
+			if size < 4 || uint64(size)+1 > uint64(len(sections)) {
+				return nil
+			}
+			sections = sections[size+1:]
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// nextElement decodes the first element of the given document bytes, and returns the remaining bytes.
+// ok is false if the element is truncated or of an unsupported type, in which case decoding must stop.
+func nextElement(doc []byte) (elem bsonElement, rest []byte, ok bool) {
+	if len(doc) == 0 || doc[0] == 0 {
+		// End of the document.
+		return elem, nil, false
+	}
+	elem.kind = doc[0]
+	nameEnd := bytes.IndexByte(doc[1:], 0)
+	if nameEnd == -1 {
+		return elem, nil, false
+	}
+	elem.name = string(doc[1 : 1+nameEnd])
+	doc = doc[2+nameEnd:]
+
+	size := -1
+	switch elem.kind {
+	case bsonDouble, bsonDateTime, bsonTimestamp, bsonInt64:
+		size = 8
+	case bsonInt32:
+		size = 4
+	case bsonBool:
+		size = 1
+	case bsonObjectID:
+		size = 12
+	case bsonDecimal128:
+		size = 16
+	case bsonNull, bsonMinKey, bsonMaxKey:
+		size = 0
+	case bsonString:
+		if len(doc) >= 4 {
+			size = 4 + int(binary.LittleEndian.Uint32(doc))
+		}
+	case bsonDocument, bsonArray:
+		if len(doc) >= 4 {
+			size = int(binary.LittleEndian.Uint32(doc))
+		}
+	case bsonBinary:
+		if len(doc) >= 4 {
+			size = 5 + int(binary.LittleEndian.Uint32(doc))
+		}
+	default:
+		return elem, nil, false
+	}
+	if size < 0 || size > len(doc) {
+		// The element is truncated, but its name is still usable.
+		return elem, nil, true
+	}
+	elem.value = doc[:size]
+	return elem, doc[size:], true
+}
+
+// stringValue returns the value of a string element, or false if the element is not a complete string.
+func (e bsonElement) stringValue() (string, bool) {
+	if e.kind != bsonString || len(e.value) < 5 {
+		return "", false
+	}
+	// Trimming the length and the null terminator.
+	return string(e.value[4 : len(e.value)-1]), true
+}
+
+// isZero returns true if the element is a complete numeric or boolean element with a zero value.
+func (e bsonElement) isZero() bool {
+	switch e.kind {
+	case bsonDouble:
+		return len(e.value) == 8 && math.Float64frombits(binary.LittleEndian.Uint64(e.value)) == 0
+	case bsonInt32:
+		return len(e.value) == 4 && binary.LittleEndian.Uint32(e.value) == 0
+	case bsonInt64:
+		return len(e.value) == 8 && binary.LittleEndian.Uint64(e.value) == 0
+	case bsonBool:
+		return len(e.value) == 1 && e.value[0] == 0
+	default:
+		return false
+	}
+}
+
+// parseRequest extracts the command name, collection and database from the sections of a request.
+// The first element of the command document is the command, and its value is the collection for commands operating
+// on one. The database is the "$db" element, which is usually last, and might not be part of the fragment.
+func parseRequest(sections []byte) (command string, collection string, database string) {
+	doc := bodyDocument(sections)
+	elem, doc, ok := nextElement(doc)
+	if !ok {
+		return "", "", ""
+	}
+	command = elem.name
+	collection, _ = elem.stringValue()
+
+	for {
+		elem, doc, ok = nextElement(doc)
+		if !ok {
+			return
+		}
+		if elem.name == databaseField {
+			database, _ = elem.stringValue()
+			return
+		}
+	}
+}
+
+// isErrorResponse returns true if the response is an error reply. Error replies start with a zero "ok" element,
+// while successful ones usually start with the result. Write errors of successful commands are not reported.
+func isErrorResponse(sections []byte) bool {
+	elem, _, ok := nextElement(bodyDocument(sections))
+	return ok && elem.name == okField && elem.isZero()
+}
@@ -0,0 +1,115 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package mongo
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// bsonDoc builds a BSON document out of pre-encoded elements.
+func bsonDoc(elements ...[]byte) []byte {
+	var body []byte
+	for _, e := range elements {
+		body = append(body, e...)
+	}
+	doc := binary.LittleEndian.AppendUint32(nil, uint32(len(body)+5))
+	doc = append(doc, body...)
+	return append(doc, 0)
+}
+
+func bsonStringElem(name, value string) []byte {
+	elem := append([]byte{bsonString}, name...)
+	elem = append(elem, 0)
+	elem = binary.LittleEndian.AppendUint32(elem, uint32(len(value)+1))
+	elem = append(elem, value...)
+	return append(elem, 0)
+}
+
+func bsonDoubleElem(name string, value float64) []byte {
+	elem := append([]byte{bsonDouble}, name...)
+	elem = append(elem, 0)
+	return binary.LittleEndian.AppendUint64(elem, math.Float64bits(value))
+}
+
+func bsonDocElem(name string, doc []byte) []byte {
+	elem := append([]byte{bsonDocument}, name...)
+	elem = append(elem, 0)
+	return append(elem, doc...)
+}
+
+func bodySection(doc []byte) []byte {
+	return append([]byte{sectionKindBody}, doc...)
+}
+
+func TestParseRequest(t *testing.T) {
+	find := bodySection(bsonDoc(
+		bsonStringElem("find", "users"),
+		bsonDocElem("filter", bsonDoc(bsonStringElem("name", "john"))),
+		bsonStringElem("$db", "app"),
+	))
+
+	t.Run("find", func(t *testing.T) {
+		command, collection, database := parseRequest(find)
+		assert.Equal(t, "find", command)
+		assert.Equal(t, "users", collection)
+		assert.Equal(t, "app", database)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		command, collection, database := parseRequest(find[:30])
+		assert.Equal(t, "find", command)
+		assert.Equal(t, "users", collection)
+		assert.Empty(t, database)
+	})
+
+	t.Run("document sequence first", func(t *testing.T) {
+		docs := bsonDoc(bsonStringElem("name", "john"))
+		sequence := []byte{sectionKindDocumentSequence}
+		sequence = binary.LittleEndian.AppendUint32(sequence, uint32(4+len("documents")+1+len(docs)))
+		sequence = append(sequence, "documents"...)
+		sequence = append(sequence, 0)
+		sequence = append(sequence, docs...)
+		insert := append(sequence, bodySection(bsonDoc(
+			bsonStringElem("insert", "users"),
+			bsonStringElem("$db", "app"),
+		))...)
+
+		command, collection, database := parseRequest(insert)
+		assert.Equal(t, "insert", command)
+		assert.Equal(t, "users", collection)
+		assert.Equal(t, "app", database)
+	})
+
+	t.Run("not a collection command", func(t *testing.T) {
+		command, collection, _ := parseRequest(bodySection(bsonDoc(bsonDoubleElem("ping", 1))))
+		assert.Equal(t, "ping", command)
+		assert.Empty(t, collection)
+	})
+
+	t.Run("garbage", func(t *testing.T) {
+		command, collection, database := parseRequest([]byte{0x42, 1, 2, 3})
+		assert.Empty(t, command)
+		assert.Empty(t, collection)
+		assert.Empty(t, database)
+	})
+}
+
+func TestIsErrorResponse(t *testing.T) {
+	assert.True(t, isErrorResponse(bodySection(bsonDoc(
+		bsonDoubleElem("ok", 0),
+		bsonStringElem("errmsg", "ns not found"),
+	))))
+	assert.False(t, isErrorResponse(bodySection(bsonDoc(
+		bsonDocElem("cursor", bsonDoc()),
+		bsonDoubleElem("ok", 0),
+	))))
+	assert.False(t, isErrorResponse(bodySection(bsonDoc(bsonDoubleElem("ok", 1)))))
+	assert.False(t, isErrorResponse(nil))
+}
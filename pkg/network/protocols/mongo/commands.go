@@ -0,0 +1,87 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package mongo
+
+// Command represents a MongoDB database command supported by our decoder.
+type Command uint8
+
+const (
+	// UnknownCommand represents a command we do not track individually.
+	UnknownCommand Command = iota
+	// FindCommand represents the find command.
+	FindCommand
+	// InsertCommand represents the insert command.
+	InsertCommand
+	// UpdateCommand represents the update command.
+	UpdateCommand
+	// DeleteCommand represents the delete command.
+	DeleteCommand
+	// AggregateCommand represents the aggregate command.
+	AggregateCommand
+	// CountCommand represents the count command.
+	CountCommand
+	// DistinctCommand represents the distinct command.
+	DistinctCommand
+	// FindAndModifyCommand represents the findAndModify command.
+	FindAndModifyCommand
+	// GetMoreCommand represents the getMore command, fetching the next batch of a cursor.
+	GetMoreCommand
+	// maxCommand is used to validate the command value, and must be kept last.
+	maxCommand
+)
+
+// String returns the command name, as sent on the wire.
+func (c Command) String() string {
+	switch c {
+	case FindCommand:
+		return "find"
+	case InsertCommand:
+		return "insert"
+	case UpdateCommand:
+		return "update"
+	case DeleteCommand:
+		return "delete"
+	case AggregateCommand:
+		return "aggregate"
+	case CountCommand:
+		return "count"
+	case DistinctCommand:
+		return "distinct"
+	case FindAndModifyCommand:
+		return "findAndModify"
+	case GetMoreCommand:
+		return "getMore"
+	default:
+		return "unknown"
+	}
+}
+
+// commandFromName returns the Command matching the name of the first element of a command document.
+// Command names are case sensitive.
+func commandFromName(name string) Command {
+	switch name {
+	case "find":
+		return FindCommand
+	case "insert":
+		return InsertCommand
+	case "update":
+		return UpdateCommand
+	case "delete":
+		return DeleteCommand
+	case "aggregate":
+		return AggregateCommand
+	case "count":
+		return CountCommand
+	case "distinct":
+		return DistinctCommand
+	case "findAndModify", "findandmodify":
+		return FindAndModifyCommand
+	case "getMore":
+		return GetMoreCommand
+	default:
+		return UnknownCommand
+	}
+}
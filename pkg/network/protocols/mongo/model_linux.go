@@ -0,0 +1,112 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux && bpf
+
+package mongo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DataDog/datadog-agent/pkg/network/protocols"
+	"github.com/DataDog/datadog-agent/pkg/network/types"
+)
+
+// EventWrapper wraps an ebpf event and provides additional methods to extract information from it.
+// We use this wrapper to avoid decoding the request fragment multiple times.
+type EventWrapper struct {
+	*EbpfEvent
+
+	requestParsed bool
+	command       Command
+	commandName   string
+	collection    string
+	database      string
+}
+
+// NewEventWrapper creates a new EventWrapper from an ebpf event.
+func NewEventWrapper(e *EbpfEvent) *EventWrapper {
+	return &EventWrapper{EbpfEvent: e}
+}
+
+// ConnTuple returns the connection tuple for the transaction
+func (e *EventWrapper) ConnTuple() types.ConnectionKey {
+	return types.ConnectionKey{
+		SrcIPHigh: e.Tuple.Saddr_h,
+		SrcIPLow:  e.Tuple.Saddr_l,
+		DstIPHigh: e.Tuple.Daddr_h,
+		DstIPLow:  e.Tuple.Daddr_l,
+		SrcPort:   e.Tuple.Sport,
+		DstPort:   e.Tuple.Dport,
+	}
+}
+
+// getRequestFragment returns the actual request sections from the event.
+func getRequestFragment(e *EbpfTx) []byte {
+	if e.Original_request_size > uint32(len(e.Request_fragment)) {
+		return e.Request_fragment[:]
+	}
+	return e.Request_fragment[:e.Original_request_size]
+}
+
+func (e *EventWrapper) parseRequest() {
+	if e.requestParsed {
+		return
+	}
+	e.commandName, e.collection, e.database = parseRequest(getRequestFragment(&e.Tx))
+	e.command = commandFromName(e.commandName)
+	e.requestParsed = true
+}
+
+// Command returns the command of the request (find, insert, etc.)
+func (e *EventWrapper) Command() Command {
+	e.parseRequest()
+	return e.command
+}
+
+// Collection returns the collection the command operates on, or an empty string if it could not be extracted.
+func (e *EventWrapper) Collection() string {
+	e.parseRequest()
+	return e.collection
+}
+
+// Database returns the database of the command, or an empty string if it could not be extracted.
+func (e *EventWrapper) Database() string {
+	e.parseRequest()
+	return e.database
+}
+
+// IsError returns true if the server answered the command with an error reply.
+func (e *EventWrapper) IsError() bool {
+	return isErrorResponse(e.Tx.Response_fragment[:])
+}
+
+// RequestLatency returns the latency of the request in nanoseconds, measured up to the first packet of the response.
+func (e *EventWrapper) RequestLatency() float64 {
+	if e.Tx.Request_started == 0 || e.Tx.Response_last_seen == 0 {
+		return 0
+	}
+	if e.Tx.Response_last_seen < e.Tx.Request_started {
+		return 0
+	}
+	return protocols.NSTimestampToFloat(e.Tx.Response_last_seen - e.Tx.Request_started)
+}
+
+const template = `
+ebpfTx{
+	Command: %q,
+	Database: %q,
+	Collection: %q,
+	Error: %t,
+	Latency: %f
+}`
+
+// String returns a string representation of the underlying event
+func (e *EventWrapper) String() string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf(template, e.Command(), e.Database(), e.Collection(), e.IsError(), e.RequestLatency()))
+	return output.String()
+}
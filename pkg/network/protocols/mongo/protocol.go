@@ -0,0 +1,240 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux && bpf
+
+package mongo
+
+import (
+	"io"
+	"unsafe"
+
+	"github.com/cilium/ebpf"
+	"github.com/davecgh/go-spew/spew"
+
+	manager "github.com/DataDog/ebpf-manager"
+
+	ddebpf "github.com/DataDog/datadog-agent/pkg/ebpf"
+	"github.com/DataDog/datadog-agent/pkg/network/config"
+	"github.com/DataDog/datadog-agent/pkg/network/protocols"
+	"github.com/DataDog/datadog-agent/pkg/network/protocols/events"
+	"github.com/DataDog/datadog-agent/pkg/network/usm/buildmode"
+	usmconfig "github.com/DataDog/datadog-agent/pkg/network/usm/config"
+	"github.com/DataDog/datadog-agent/pkg/network/usm/utils"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+const (
+	// InFlightMap is the name of the in-flight map.
+	InFlightMap        = "mongo_in_flight"
+	scratchBufferMap   = "mongo_scratch_buffer"
+	processTailCall    = "socket__mongo_process"
+	tlsProcessTailCall = "uprobe__mongo_tls_process"
+	eventStream        = "mongo"
+	netifProbe         = "tracepoint__net__netif_receive_skb_mongo"
+	netifProbe414      = "netif_receive_skb_core_mongo_4_14"
+)
+
+// protocol holds the state of the mongo protocol monitoring.
+type protocol struct {
+	cfg            *config.Config
+	eventsConsumer *events.BatchConsumer[EbpfEvent]
+	mapCleaner     *ddebpf.MapCleaner[EbpfKey, EbpfTx]
+	statskeeper    *StatKeeper
+	mgr            *manager.Manager
+}
+
+// Spec is the protocol spec for the mongo protocol.
+var Spec = &protocols.ProtocolSpec{
+	Factory: newMongoProtocol,
+	Maps: []*manager.Map{
+		{
+			Name: InFlightMap,
+		},
+		{
+			Name: scratchBufferMap,
+		},
+		{
+			Name: "mongo_batch_events",
+		},
+		{
+			Name: "mongo_batch_state",
+		},
+		{
+			Name: "mongo_batches",
+		},
+	},
+	Probes: []*manager.Probe{
+		{
+			KprobeAttachMethod: manager.AttachKprobeWithPerfEventOpen,
+			ProbeIdentificationPair: manager.ProbeIdentificationPair{
+				EBPFFuncName: netifProbe414,
+				UID:          eventStream,
+			},
+		},
+		{
+			ProbeIdentificationPair: manager.ProbeIdentificationPair{
+				EBPFFuncName: netifProbe,
+				UID:          eventStream,
+			},
+		},
+	},
+	TailCalls: []manager.TailCallRoute{
+		{
+			ProgArrayName: protocols.ProtocolDispatcherProgramsMap,
+			Key:           uint32(protocols.ProgramMongo),
+			ProbeIdentificationPair: manager.ProbeIdentificationPair{
+				EBPFFuncName: processTailCall,
+			},
+		},
+		{
+			ProgArrayName: protocols.TLSDispatcherProgramsMap,
+			Key:           uint32(protocols.ProgramMongo),
+			ProbeIdentificationPair: manager.ProbeIdentificationPair{
+				EBPFFuncName: tlsProcessTailCall,
+			},
+		},
+	},
+}
+
+// newMongoProtocol is the factory for the MongoDB protocol object
+func newMongoProtocol(mgr *manager.Manager, cfg *config.Config) (protocols.Protocol, error) {
+	if !cfg.EnableMongoMonitoring {
+		return nil, nil
+	}
+
+	return &protocol{
+		cfg:         cfg,
+		statskeeper: NewStatkeeper(cfg),
+		mgr:         mgr,
+	}, nil
+}
+
+// Name returns the name of the protocol.
+func (p *protocol) Name() string {
+	return eventStream
+}
+
+// ConfigureOptions add the necessary options for the mongo monitoring to work, to be used by the manager.
+func (p *protocol) ConfigureOptions(opts *manager.Options) {
+	opts.MapSpecEditors[InFlightMap] = manager.MapSpecEditor{
+		MaxEntries: p.cfg.MaxUSMConcurrentRequests,
+		EditorFlag: manager.EditMaxEntries,
+	}
+	netifProbeID := manager.ProbeIdentificationPair{
+		EBPFFuncName: netifProbe,
+		UID:          eventStream,
+	}
+	if usmconfig.ShouldUseNetifReceiveSKBCoreKprobe() {
+		netifProbeID.EBPFFuncName = netifProbe414
+	}
+	opts.ActivatedProbes = append(opts.ActivatedProbes, &manager.ProbeSelector{ProbeIdentificationPair: netifProbeID})
+	utils.EnableOption(opts, "mongo_monitoring_enabled")
+	// Configure event stream
+	events.Configure(p.cfg, eventStream, p.mgr, opts)
+}
+
+// PreStart runs setup required before starting the protocol.
+func (p *protocol) PreStart() (err error) {
+	p.eventsConsumer, err = events.NewBatchConsumer(
+		eventStream,
+		p.mgr,
+		p.processMongo,
+	)
+	if err != nil {
+		return
+	}
+
+	p.eventsConsumer.Start()
+
+	return
+}
+
+// PostStart starts the map cleaner.
+func (p *protocol) PostStart() error {
+	// Setup map cleaner after manager start.
+	p.setupMapCleaner()
+	return nil
+}
+
+// Stop stops all resources associated with the protocol.
+func (p *protocol) Stop() {
+	// mapCleaner handles nil pointer receivers
+	p.mapCleaner.Stop()
+
+	if p.eventsConsumer != nil {
+		p.eventsConsumer.Stop()
+	}
+}
+
+// DumpMaps dumps map contents for debugging.
+func (p *protocol) DumpMaps(w io.Writer, mapName string, currentMap *ebpf.Map) {
+	switch mapName {
+	case InFlightMap:
+		// maps/mongo_in_flight (BPF_MAP_TYPE_HASH), key EbpfKey, value EbpfTx
+		var key EbpfKey
+		var value EbpfTx
+		protocols.WriteMapDumpHeader(w, currentMap, mapName, key, value)
+		iter := currentMap.Iterate()
+		for iter.Next(unsafe.Pointer(&key), unsafe.Pointer(&value)) {
+			spew.Fdump(w, key, value)
+		}
+	}
+}
+
+// GetStats returns a map of MongoDB stats and a callback to clean resources.
+func (p *protocol) GetStats() (*protocols.ProtocolStats, func()) {
+	p.eventsConsumer.Sync()
+
+	stats := p.statskeeper.GetAndResetAllStats()
+	return &protocols.ProtocolStats{
+			Type:  protocols.Mongo,
+			Stats: stats,
+		}, func() {
+			for _, stat := range stats {
+				stat.Close()
+				requestStatPool.Put(stat)
+			}
+		}
+}
+
+// IsBuildModeSupported returns always true, as mongo module is supported by all modes.
+func (*protocol) IsBuildModeSupported(buildmode.Type) bool {
+	return true
+}
+
+func (p *protocol) processMongo(events []EbpfEvent) {
+	for i := range events {
+		tx := &events[i]
+		p.statskeeper.Process(NewEventWrapper(tx))
+	}
+}
+
+func (p *protocol) setupMapCleaner() {
+	mongoInFlight, _, err := p.mgr.GetMap(InFlightMap)
+	if err != nil {
+		log.Errorf("error getting %s map: %s", InFlightMap, err)
+		return
+	}
+	mapCleaner, err := ddebpf.NewMapCleaner[EbpfKey, EbpfTx](mongoInFlight, protocols.DefaultMapCleanerBatchSize, InFlightMap, "usm_monitor")
+	if err != nil {
+		log.Errorf("error creating map cleaner: %s", err)
+		return
+	}
+
+	// Clean up commands that never got a response, including those of closed connections, as the kernel side does not
+	// track connection termination. We currently use the same TTL as HTTP, but we plan to rename this variable to be more generic.
+	ttl := p.cfg.HTTPIdleConnectionTTL.Nanoseconds()
+	mapCleaner.Start(p.cfg.HTTPMapCleanerInterval, nil, nil, func(now int64, _ EbpfKey, val EbpfTx) bool {
+		if updated := int64(val.Response_last_seen); updated > 0 {
+			return (now - updated) > ttl
+		}
+
+		started := int64(val.Request_started)
+		return started > 0 && (now-started) > ttl
+	})
+
+	p.mapCleaner = mapCleaner
+}
@@ -0,0 +1,106 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux && bpf
+
+package mongo
+
+import (
+	"sync"
+
+	"github.com/DataDog/datadog-agent/pkg/network/config"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+// StatKeeper is a struct to hold the records for the mongo protocol
+type StatKeeper struct {
+	stats      map[Key]*RequestStat
+	statsMutex sync.RWMutex
+	maxEntries int
+	telemetry  *telemetry
+}
+
+// NewStatkeeper creates a new StatKeeper
+func NewStatkeeper(c *config.Config) *StatKeeper {
+	newStatKeeper := &StatKeeper{
+		maxEntries: c.MaxMongoStatsBuffered,
+		telemetry:  newTelemetry(),
+	}
+	newStatKeeper.resetNoLock()
+	return newStatKeeper
+}
+
+// Process processes the mongo command
+func (s *StatKeeper) Process(tx *EventWrapper) {
+	latency := tx.RequestLatency()
+	if latency <= 0 {
+		s.telemetry.invalidLatency.Add(1)
+		return
+	}
+
+	key := Key{
+		Command:       tx.Command(),
+		Database:      tx.Database(),
+		Collection:    tx.Collection(),
+		ConnectionKey: tx.ConnTuple(),
+	}
+	if key.Command == UnknownCommand {
+		s.telemetry.unknownCommand.Add(1)
+	}
+	if key.Database == "" {
+		s.telemetry.failedDatabaseExtraction.Add(1)
+	}
+
+	s.statsMutex.Lock()
+	defer s.statsMutex.Unlock()
+
+	requestStats, ok := s.stats[key]
+	if !ok {
+		if len(s.stats) >= s.maxEntries {
+			s.telemetry.dropped.Add(1)
+			return
+		}
+		requestStats = requestStatPool.Get()
+		s.stats[key] = requestStats
+	}
+	s.telemetry.hits.Add(1)
+	requestStats.StaticTags |= uint64(tx.Tx.Tags)
+	if tx.IsError() {
+		s.telemetry.errors.Add(1)
+		requestStats.ErrorCount++
+	}
+	requestStats.Count++
+	if requestStats.Count == 1 {
+		requestStats.FirstLatencySample = latency
+		return
+	}
+	if requestStats.Latencies == nil {
+		if err := requestStats.initSketch(); err != nil {
+			log.Warnf("could not add request latency to ddsketch: %v", err)
+			return
+		}
+		if err := requestStats.Latencies.Add(requestStats.FirstLatencySample); err != nil {
+			return
+		}
+	}
+	if err := requestStats.Latencies.Add(latency); err != nil {
+		log.Debugf("could not add request latency to ddsketch: %v", err)
+	}
+}
+
+// GetAndResetAllStats returns all the records and resets the statskeeper
+func (s *StatKeeper) GetAndResetAllStats() map[Key]*RequestStat {
+	s.statsMutex.Lock()
+	defer s.statsMutex.Unlock()
+
+	s.telemetry.Log()
+	ret := s.stats // No deep copy needed since `s.stats` gets reset
+	s.resetNoLock()
+	return ret
+}
+
+func (s *StatKeeper) resetNoLock() {
+	s.stats = make(map[Key]*RequestStat)
+}
@@ -0,0 +1,92 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux && bpf
+
+package mongo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/network/config"
+)
+
+func newEvent(command, collection, database string, isError bool) *EventWrapper {
+	request := bodySection(bsonDoc(
+		bsonStringElem(command, collection),
+		bsonStringElem("$db", database),
+	))
+	okValue := 1.0
+	if isError {
+		okValue = 0
+	}
+	response := bodySection(bsonDoc(bsonDoubleElem("ok", okValue)))
+
+	event := &EbpfEvent{
+		Tx: EbpfTx{
+			Request_started:       1,
+			Response_last_seen:    10,
+			Original_request_size: uint32(len(request)),
+		},
+	}
+	copy(event.Tx.Request_fragment[:], request)
+	copy(event.Tx.Response_fragment[:], response)
+	return NewEventWrapper(event)
+}
+
+func TestStatKeeperProcess(t *testing.T) {
+	cfg := config.New()
+	cfg.MaxMongoStatsBuffered = 100
+	s := NewStatkeeper(cfg)
+	for i := 0; i < 20; i++ {
+		s.Process(newEvent("find", "users", "app", i%4 == 0))
+	}
+
+	require.Len(t, s.stats, 1)
+	for k, stat := range s.stats {
+		require.Equal(t, FindCommand, k.Command)
+		require.Equal(t, "app", k.Database)
+		require.Equal(t, "users", k.Collection)
+		require.Equal(t, 20, stat.Count)
+		require.Equal(t, 5, stat.ErrorCount)
+		require.Equal(t, float64(20), stat.Latencies.GetCount())
+	}
+}
+
+func TestStatKeeperMaxEntries(t *testing.T) {
+	cfg := config.New()
+	cfg.MaxMongoStatsBuffered = 1
+	s := NewStatkeeper(cfg)
+	s.Process(newEvent("find", "users", "app", false))
+	s.Process(newEvent("insert", "orders", "app", false))
+
+	stats := s.GetAndResetAllStats()
+	require.Len(t, stats, 1)
+	require.Empty(t, s.stats)
+}
+
+func TestEventWrapper(t *testing.T) {
+	tests := []struct {
+		command    string
+		expected   Command
+		collection string
+	}{
+		{command: "find", expected: FindCommand, collection: "users"},
+		{command: "findAndModify", expected: FindAndModifyCommand, collection: "orders"},
+		{command: "aggregate", expected: AggregateCommand, collection: "sessions"},
+		{command: "createIndexes", expected: UnknownCommand, collection: "users"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			e := newEvent(tt.command, tt.collection, "app", false)
+			require.Equal(t, tt.expected, e.Command())
+			require.Equal(t, tt.collection, e.Collection())
+			require.Equal(t, "app", e.Database())
+			require.False(t, e.IsError())
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux && bpf
+
+package mongo
+
+import (
+	libtelemetry "github.com/DataDog/datadog-agent/pkg/network/protocols/telemetry"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+// telemetry is a struct to hold the telemetry for the mongo protocol
+type telemetry struct {
+	metricGroup *libtelemetry.MetricGroup
+
+	hits                     *libtelemetry.Counter
+	errors                   *libtelemetry.Counter
+	dropped                  *libtelemetry.Counter
+	invalidLatency           *libtelemetry.Counter
+	unknownCommand           *libtelemetry.Counter
+	failedDatabaseExtraction *libtelemetry.Counter
+}
+
+// newTelemetry creates a new telemetry instance for the mongo protocol
+func newTelemetry() *telemetry {
+	metricGroup := libtelemetry.NewMetricGroup("usm.mongo")
+
+	return &telemetry{
+		metricGroup:              metricGroup,
+		hits:                     metricGroup.NewCounter("total_hits", libtelemetry.OptPrometheus),
+		errors:                   metricGroup.NewCounter("errors", libtelemetry.OptPrometheus),
+		dropped:                  metricGroup.NewCounter("dropped", libtelemetry.OptPrometheus),
+		invalidLatency:           metricGroup.NewCounter("malformed", "type:invalid-latency", libtelemetry.OptPrometheus),
+		unknownCommand:           metricGroup.NewCounter("unknown_command", libtelemetry.OptPrometheus),
+		failedDatabaseExtraction: metricGroup.NewCounter("failed_database_extraction", libtelemetry.OptPrometheus),
+	}
+}
+
+// Log logs the mongo stats summary
+func (t *telemetry) Log() {
+	if log.ShouldLog(log.DebugLvl) {
+		log.Debugf("mongo stats summary: %s", t.metricGroup.Summary())
+	}
+}
@@ -0,0 +1,25 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build ignore
+
+package mongo
+
+/*
+#include "../../ebpf/c/protocols/mongo/types.h"
+#include "../../ebpf/c/protocols/classification/structs.h"
+*/
+import "C"
+
+type ConnTuple = C.conn_tuple_t
+
+type EbpfEvent C.mongo_event_t
+type EbpfTx C.mongo_transaction_t
+type EbpfKey C.mongo_key
+
+const (
+	RequestBufferSize  = C.MONGO_REQUEST_BUFFER_SIZE
+	ResponseBufferSize = C.MONGO_RESPONSE_BUFFER_SIZE
+)
@@ -0,0 +1,138 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux && bpf
+
+package mysql
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/DataDog/go-sqllexer"
+
+	"github.com/DataDog/datadog-agent/pkg/network/protocols"
+	"github.com/DataDog/datadog-agent/pkg/network/types"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+const (
+	// UnknownTable represents the case where the table name of the query could not be extracted.
+	UnknownTable = "UNKNOWN"
+)
+
+var (
+	mysqlDBMS = sqllexer.WithDBMS(sqllexer.DBMSMySQL)
+)
+
+// EventWrapper wraps an ebpf event and provides additional methods to extract information from it.
+// We use this wrapper to avoid recomputing the same values (operation and table name) multiple times.
+type EventWrapper struct {
+	*EbpfEvent
+
+	operationSet bool
+	operation    Operation
+	tableNameSet bool
+	tableName    string
+	normalizer   *sqllexer.Normalizer
+}
+
+// NewEventWrapper creates a new EventWrapper from an ebpf event.
+func NewEventWrapper(e *EbpfEvent) *EventWrapper {
+	return &EventWrapper{
+		EbpfEvent:  e,
+		normalizer: sqllexer.NewNormalizer(sqllexer.WithCollectTables(true)),
+	}
+}
+
+// ConnTuple returns the connection tuple for the transaction
+func (e *EventWrapper) ConnTuple() types.ConnectionKey {
+	return types.ConnectionKey{
+		SrcIPHigh: e.Tuple.Saddr_h,
+		SrcIPLow:  e.Tuple.Saddr_l,
+		DstIPHigh: e.Tuple.Daddr_h,
+		DstIPLow:  e.Tuple.Daddr_l,
+		SrcPort:   e.Tuple.Sport,
+		DstPort:   e.Tuple.Dport,
+	}
+}
+
+// getFragment returns the actual query fragment from the event.
+func getFragment(e *EbpfTx) []byte {
+	if e.Original_query_size == 0 {
+		return nil
+	}
+	if e.Original_query_size > uint32(len(e.Request_fragment)) {
+		return e.Request_fragment[:len(e.Request_fragment)]
+	}
+	return e.Request_fragment[:e.Original_query_size]
+}
+
+// Operation returns the statement type of the query (SELECT, INSERT, UPDATE, etc.)
+func (e *EventWrapper) Operation() Operation {
+	if !e.operationSet {
+		op, _, _ := bytes.Cut(bytes.TrimSpace(getFragment(&e.Tx)), []byte(" "))
+		e.operation = FromString(string(op))
+		e.operationSet = true
+	}
+	return e.operation
+}
+
+// TableName returns the name of the first table referenced by the query.
+func (e *EventWrapper) TableName() string {
+	if !e.tableNameSet {
+		e.tableName = e.extractTableName()
+		e.tableNameSet = true
+	}
+	return e.tableName
+}
+
+// extractTableName extracts the table name from the query.
+func (e *EventWrapper) extractTableName() string {
+	// Normalize the query without obfuscating it.
+	_, statementMetadata, err := e.normalizer.Normalize(string(getFragment(&e.Tx)), mysqlDBMS)
+	if err != nil {
+		log.Debugf("unable to normalize due to: %s", err)
+		return UnknownTable
+	}
+	if statementMetadata.Size == 0 || len(statementMetadata.Tables) == 0 {
+		return UnknownTable
+	}
+
+	// Currently, we do not support complex queries with multiple tables. Therefore, we will return only a single table.
+	return statementMetadata.Tables[0]
+}
+
+// IsError returns true if the server answered the query with an ERR packet.
+func (e *EventWrapper) IsError() bool {
+	return e.Tx.Is_error
+}
+
+// RequestLatency returns the latency of the request in nanoseconds, measured up to the first packet of the response.
+func (e *EventWrapper) RequestLatency() float64 {
+	if e.Tx.Request_started == 0 || e.Tx.Response_last_seen == 0 {
+		return 0
+	}
+	if e.Tx.Response_last_seen < e.Tx.Request_started {
+		return 0
+	}
+	return protocols.NSTimestampToFloat(e.Tx.Response_last_seen - e.Tx.Request_started)
+}
+
+const template = `
+ebpfTx{
+	Operation: %q,
+	Table Name: %q,
+	Error Code: %d,
+	Latency: %f
+}`
+
+// String returns a string representation of the underlying event
+func (e *EventWrapper) String() string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf(template, e.Operation(), e.TableName(), e.Tx.Error_code, e.RequestLatency()))
+	return output.String()
+}
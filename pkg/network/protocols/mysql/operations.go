@@ -0,0 +1,104 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package mysql
+
+import "strings"
+
+// Operation represents a MySQL statement type supported by our decoder.
+type Operation uint8
+
+const (
+	// UnknownOP represents an unknown operation.
+	UnknownOP Operation = iota
+	// SelectOP represents a SELECT statement.
+	SelectOP
+	// InsertOP represents an INSERT statement.
+	InsertOP
+	// UpdateOP represents an UPDATE statement.
+	UpdateOP
+	// DeleteOP represents a DELETE statement.
+	DeleteOP
+	// ReplaceOP represents a REPLACE statement.
+	ReplaceOP
+	// CreateOP represents a CREATE statement.
+	CreateOP
+	// DropOP represents a DROP statement.
+	DropOP
+	// AlterOP represents an ALTER statement.
+	AlterOP
+	// TruncateOP represents a TRUNCATE statement.
+	TruncateOP
+	// CallOP represents a CALL statement, invoking a stored procedure.
+	CallOP
+	// ShowOP represents a SHOW statement.
+	ShowOP
+	// SetOP represents a SET statement.
+	SetOP
+)
+
+// String returns the string representation of the operation.
+func (op Operation) String() string {
+	switch op {
+	case SelectOP:
+		return "SELECT"
+	case InsertOP:
+		return "INSERT"
+	case UpdateOP:
+		return "UPDATE"
+	case DeleteOP:
+		return "DELETE"
+	case ReplaceOP:
+		return "REPLACE"
+	case CreateOP:
+		return "CREATE"
+	case DropOP:
+		return "DROP"
+	case AlterOP:
+		return "ALTER"
+	case TruncateOP:
+		return "TRUNCATE"
+	case CallOP:
+		return "CALL"
+	case ShowOP:
+		return "SHOW"
+	case SetOP:
+		return "SET"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// FromString returns the Operation from a string.
+func FromString(op string) Operation {
+	switch strings.ToUpper(op) {
+	case "SELECT":
+		return SelectOP
+	case "INSERT":
+		return InsertOP
+	case "UPDATE":
+		return UpdateOP
+	case "DELETE":
+		return DeleteOP
+	case "REPLACE":
+		return ReplaceOP
+	case "CREATE":
+		return CreateOP
+	case "DROP":
+		return DropOP
+	case "ALTER":
+		return AlterOP
+	case "TRUNCATE":
+		return TruncateOP
+	case "CALL":
+		return CallOP
+	case "SHOW":
+		return ShowOP
+	case "SET":
+		return SetOP
+	default:
+		return UnknownOP
+	}
+}
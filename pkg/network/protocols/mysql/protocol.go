@@ -0,0 +1,266 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux && bpf
+
+package mysql
+
+import (
+	"io"
+	"unsafe"
+
+	"github.com/cilium/ebpf"
+	"github.com/davecgh/go-spew/spew"
+
+	manager "github.com/DataDog/ebpf-manager"
+
+	ddebpf "github.com/DataDog/datadog-agent/pkg/ebpf"
+	"github.com/DataDog/datadog-agent/pkg/network/config"
+	netebpf "github.com/DataDog/datadog-agent/pkg/network/ebpf"
+	"github.com/DataDog/datadog-agent/pkg/network/protocols"
+	"github.com/DataDog/datadog-agent/pkg/network/protocols/events"
+	"github.com/DataDog/datadog-agent/pkg/network/usm/buildmode"
+	usmconfig "github.com/DataDog/datadog-agent/pkg/network/usm/config"
+	"github.com/DataDog/datadog-agent/pkg/network/usm/utils"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+const (
+	// InFlightMap is the name of the in-flight map.
+	InFlightMap = "mysql_in_flight"
+	// PreparedStatementsMap is the name of the map holding the queries of the prepared statements.
+	PreparedStatementsMap  = "mysql_prepared_statements"
+	scratchBufferMap       = "mysql_scratch_buffer"
+	processTailCall        = "socket__mysql_process"
+	tlsProcessTailCall     = "uprobe__mysql_tls_process"
+	tlsTerminationTailCall = "uprobe__mysql_tls_termination"
+	eventStream            = "mysql"
+	netifProbe             = "tracepoint__net__netif_receive_skb_mysql"
+	netifProbe414          = "netif_receive_skb_core_mysql_4_14"
+)
+
+// protocol holds the state of the mysql protocol monitoring.
+type protocol struct {
+	cfg            *config.Config
+	eventsConsumer *events.BatchConsumer[EbpfEvent]
+	mapCleaner     *ddebpf.MapCleaner[netebpf.ConnTuple, EbpfTx]
+	statskeeper    *StatKeeper
+	mgr            *manager.Manager
+}
+
+// Spec is the protocol spec for the mysql protocol.
+var Spec = &protocols.ProtocolSpec{
+	Factory: newMySQLProtocol,
+	Maps: []*manager.Map{
+		{
+			Name: InFlightMap,
+		},
+		{
+			Name: PreparedStatementsMap,
+		},
+		{
+			Name: scratchBufferMap,
+		},
+		{
+			Name: "mysql_batch_events",
+		},
+		{
+			Name: "mysql_batch_state",
+		},
+		{
+			Name: "mysql_batches",
+		},
+	},
+	Probes: []*manager.Probe{
+		{
+			KprobeAttachMethod: manager.AttachKprobeWithPerfEventOpen,
+			ProbeIdentificationPair: manager.ProbeIdentificationPair{
+				EBPFFuncName: netifProbe414,
+				UID:          eventStream,
+			},
+		},
+		{
+			ProbeIdentificationPair: manager.ProbeIdentificationPair{
+				EBPFFuncName: netifProbe,
+				UID:          eventStream,
+			},
+		},
+	},
+	TailCalls: []manager.TailCallRoute{
+		{
+			ProgArrayName: protocols.ProtocolDispatcherProgramsMap,
+			Key:           uint32(protocols.ProgramMySQL),
+			ProbeIdentificationPair: manager.ProbeIdentificationPair{
+				EBPFFuncName: processTailCall,
+			},
+		},
+		{
+			ProgArrayName: protocols.TLSDispatcherProgramsMap,
+			Key:           uint32(protocols.ProgramMySQL),
+			ProbeIdentificationPair: manager.ProbeIdentificationPair{
+				EBPFFuncName: tlsProcessTailCall,
+			},
+		},
+		{
+			ProgArrayName: protocols.TLSDispatcherProgramsMap,
+			Key:           uint32(protocols.ProgramMySQLTermination),
+			ProbeIdentificationPair: manager.ProbeIdentificationPair{
+				EBPFFuncName: tlsTerminationTailCall,
+			},
+		},
+	},
+}
+
+// newMySQLProtocol is the factory for the MySQL protocol object
+func newMySQLProtocol(mgr *manager.Manager, cfg *config.Config) (protocols.Protocol, error) {
+	if !cfg.EnableMySQLMonitoring {
+		return nil, nil
+	}
+
+	return &protocol{
+		cfg:         cfg,
+		statskeeper: NewStatkeeper(cfg),
+		mgr:         mgr,
+	}, nil
+}
+
+// Name returns the name of the protocol.
+func (p *protocol) Name() string {
+	return eventStream
+}
+
+// ConfigureOptions add the necessary options for the mysql monitoring to work, to be used by the manager.
+func (p *protocol) ConfigureOptions(opts *manager.Options) {
+	opts.MapSpecEditors[InFlightMap] = manager.MapSpecEditor{
+		MaxEntries: p.cfg.MaxUSMConcurrentRequests,
+		EditorFlag: manager.EditMaxEntries,
+	}
+	opts.MapSpecEditors[PreparedStatementsMap] = manager.MapSpecEditor{
+		MaxEntries: p.cfg.MaxUSMConcurrentRequests,
+		EditorFlag: manager.EditMaxEntries,
+	}
+	netifProbeID := manager.ProbeIdentificationPair{
+		EBPFFuncName: netifProbe,
+		UID:          eventStream,
+	}
+	if usmconfig.ShouldUseNetifReceiveSKBCoreKprobe() {
+		netifProbeID.EBPFFuncName = netifProbe414
+	}
+	opts.ActivatedProbes = append(opts.ActivatedProbes, &manager.ProbeSelector{ProbeIdentificationPair: netifProbeID})
+	utils.EnableOption(opts, "mysql_monitoring_enabled")
+	// Configure event stream
+	events.Configure(p.cfg, eventStream, p.mgr, opts)
+}
+
+// PreStart runs setup required before starting the protocol.
+func (p *protocol) PreStart() (err error) {
+	p.eventsConsumer, err = events.NewBatchConsumer(
+		eventStream,
+		p.mgr,
+		p.processMySQL,
+	)
+	if err != nil {
+		return
+	}
+
+	p.eventsConsumer.Start()
+
+	return
+}
+
+// PostStart starts the map cleaner.
+func (p *protocol) PostStart() error {
+	// Setup map cleaner after manager start.
+	p.setupMapCleaner()
+	return nil
+}
+
+// Stop stops all resources associated with the protocol.
+func (p *protocol) Stop() {
+	// mapCleaner handles nil pointer receivers
+	p.mapCleaner.Stop()
+
+	if p.eventsConsumer != nil {
+		p.eventsConsumer.Stop()
+	}
+}
+
+// DumpMaps dumps map contents for debugging.
+func (p *protocol) DumpMaps(w io.Writer, mapName string, currentMap *ebpf.Map) {
+	switch mapName {
+	case InFlightMap:
+		// maps/mysql_in_flight (BPF_MAP_TYPE_HASH), key ConnTuple, value EbpfTx
+		var key netebpf.ConnTuple
+		var value EbpfTx
+		protocols.WriteMapDumpHeader(w, currentMap, mapName, key, value)
+		iter := currentMap.Iterate()
+		for iter.Next(unsafe.Pointer(&key), unsafe.Pointer(&value)) {
+			spew.Fdump(w, key, value)
+		}
+	case PreparedStatementsMap:
+		// maps/mysql_prepared_statements (BPF_MAP_TYPE_LRU_HASH), key EbpfStatementKey, value EbpfStatement
+		var key EbpfStatementKey
+		var value EbpfStatement
+		protocols.WriteMapDumpHeader(w, currentMap, mapName, key, value)
+		iter := currentMap.Iterate()
+		for iter.Next(unsafe.Pointer(&key), unsafe.Pointer(&value)) {
+			spew.Fdump(w, key, value)
+		}
+	}
+}
+
+// GetStats returns a map of MySQL stats and a callback to clean resources.
+func (p *protocol) GetStats() (*protocols.ProtocolStats, func()) {
+	p.eventsConsumer.Sync()
+
+	stats := p.statskeeper.GetAndResetAllStats()
+	return &protocols.ProtocolStats{
+			Type:  protocols.MySQL,
+			Stats: stats,
+		}, func() {
+			for _, stat := range stats {
+				stat.Close()
+				requestStatPool.Put(stat)
+			}
+		}
+}
+
+// IsBuildModeSupported returns always true, as mysql module is supported by all modes.
+func (*protocol) IsBuildModeSupported(buildmode.Type) bool {
+	return true
+}
+
+func (p *protocol) processMySQL(events []EbpfEvent) {
+	for i := range events {
+		tx := &events[i]
+		p.statskeeper.Process(NewEventWrapper(tx))
+	}
+}
+
+func (p *protocol) setupMapCleaner() {
+	mysqlInFlight, _, err := p.mgr.GetMap(InFlightMap)
+	if err != nil {
+		log.Errorf("error getting %s map: %s", InFlightMap, err)
+		return
+	}
+	mapCleaner, err := ddebpf.NewMapCleaner[netebpf.ConnTuple, EbpfTx](mysqlInFlight, protocols.DefaultMapCleanerBatchSize, InFlightMap, "usm_monitor")
+	if err != nil {
+		log.Errorf("error creating map cleaner: %s", err)
+		return
+	}
+
+	// Clean up idle connections. We currently use the same TTL as HTTP, but we plan to rename this variable to be more generic.
+	ttl := p.cfg.HTTPIdleConnectionTTL.Nanoseconds()
+	mapCleaner.Start(p.cfg.HTTPMapCleanerInterval, nil, nil, func(now int64, _ netebpf.ConnTuple, val EbpfTx) bool {
+		if updated := int64(val.Response_last_seen); updated > 0 {
+			return (now - updated) > ttl
+		}
+
+		started := int64(val.Request_started)
+		return started > 0 && (now-started) > ttl
+	})
+
+	p.mapCleaner = mapCleaner
+}
@@ -0,0 +1,79 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux && bpf
+
+package mysql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/network/config"
+)
+
+func newEvent(query string, isError bool) *EventWrapper {
+	event := &EbpfEvent{
+		Tx: EbpfTx{
+			Request_started:     1,
+			Response_last_seen:  10,
+			Original_query_size: uint32(len(query)),
+			Is_error:            isError,
+		},
+	}
+	copy(event.Tx.Request_fragment[:], query)
+	return NewEventWrapper(event)
+}
+
+func TestStatKeeperProcess(t *testing.T) {
+	cfg := config.New()
+	cfg.MaxMySQLStatsBuffered = 100
+	s := NewStatkeeper(cfg)
+	for i := 0; i < 20; i++ {
+		s.Process(newEvent("SELECT * FROM users WHERE id = ?", i%4 == 0))
+	}
+
+	require.Len(t, s.stats, 1)
+	for k, stat := range s.stats {
+		require.Equal(t, "users", k.TableName)
+		require.Equal(t, SelectOP, k.Operation)
+		require.Equal(t, 20, stat.Count)
+		require.Equal(t, 5, stat.ErrorCount)
+		require.Equal(t, float64(20), stat.Latencies.GetCount())
+	}
+}
+
+func TestStatKeeperMaxEntries(t *testing.T) {
+	cfg := config.New()
+	cfg.MaxMySQLStatsBuffered = 1
+	s := NewStatkeeper(cfg)
+	s.Process(newEvent("SELECT * FROM users", false))
+	s.Process(newEvent("INSERT INTO orders VALUES (?)", false))
+
+	stats := s.GetAndResetAllStats()
+	require.Len(t, stats, 1)
+	require.Empty(t, s.stats)
+}
+
+func TestEventWrapper(t *testing.T) {
+	tests := []struct {
+		query     string
+		operation Operation
+		table     string
+	}{
+		{query: "SELECT id FROM users", operation: SelectOP, table: "users"},
+		{query: "  UPDATE orders SET total = ?", operation: UpdateOP, table: "orders"},
+		{query: "REPLACE INTO sessions VALUES (?, ?)", operation: ReplaceOP, table: "sessions"},
+		{query: "PING", operation: UnknownOP, table: UnknownTable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			e := newEvent(tt.query, false)
+			require.Equal(t, tt.operation, e.Operation())
+			require.Equal(t, tt.table, e.TableName())
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux && bpf
+
+package mysql
+
+import (
+	libtelemetry "github.com/DataDog/datadog-agent/pkg/network/protocols/telemetry"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+// telemetry is a struct to hold the telemetry for the mysql protocol
+type telemetry struct {
+	metricGroup *libtelemetry.MetricGroup
+
+	hits                      *libtelemetry.Counter
+	errors                    *libtelemetry.Counter
+	dropped                   *libtelemetry.Counter
+	invalidLatency            *libtelemetry.Counter
+	failedOperationExtraction *libtelemetry.Counter
+	failedTableNameExtraction *libtelemetry.Counter
+}
+
+// newTelemetry creates a new telemetry instance for the mysql protocol
+func newTelemetry() *telemetry {
+	metricGroup := libtelemetry.NewMetricGroup("usm.mysql")
+
+	return &telemetry{
+		metricGroup:               metricGroup,
+		hits:                      metricGroup.NewCounter("total_hits", libtelemetry.OptPrometheus),
+		errors:                    metricGroup.NewCounter("errors", libtelemetry.OptPrometheus),
+		dropped:                   metricGroup.NewCounter("dropped", libtelemetry.OptPrometheus),
+		invalidLatency:            metricGroup.NewCounter("malformed", "type:invalid-latency", libtelemetry.OptPrometheus),
+		failedOperationExtraction: metricGroup.NewCounter("failed_operation_extraction", libtelemetry.OptPrometheus),
+		failedTableNameExtraction: metricGroup.NewCounter("failed_table_name_extraction", libtelemetry.OptPrometheus),
+	}
+}
+
+// Log logs the mysql stats summary
+func (t *telemetry) Log() {
+	if log.ShouldLog(log.DebugLvl) {
+		log.Debugf("mysql stats summary: %s", t.metricGroup.Summary())
+	}
+}
@@ -0,0 +1,24 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build ignore
+
+package mysql
+
+/*
+#include "../../ebpf/c/protocols/mysql/types.h"
+#include "../../ebpf/c/protocols/mysql/defs.h"
+#include "../../ebpf/c/protocols/classification/defs.h"
+*/
+import "C"
+
+type ConnTuple = C.conn_tuple_t
+
+type EbpfEvent C.mysql_event_t
+type EbpfTx C.mysql_transaction_t
+type EbpfStatementKey C.mysql_statement_key_t
+type EbpfStatement C.mysql_statement_t
+
+const BufferSize = C.MYSQL_BUFFER_SIZE
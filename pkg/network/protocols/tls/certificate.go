@@ -0,0 +1,259 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package tls
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"net"
+	"time"
+)
+
+// DER tags of the certificate elements
+const (
+	derTagInteger    = 0x02
+	derTagSequence   = 0x30
+	derTagVersion    = 0xa0 // [0] EXPLICIT
+	derTagExtensions = 0xa3 // [3] EXPLICIT
+	derTagDNSName    = 0x82 // [2] IMPLICIT IA5String in a GeneralName
+	derTagIPAddress  = 0x87 // [7] IMPLICIT OCTET STRING in a GeneralName
+)
+
+var oidSubjectAltName = asn1.ObjectIdentifier{2, 5, 29, 17}
+
+var errTruncated = errors.New("truncated certificate")
+
+// Certificate holds the fields of a server certificate that are captured from a TLS handshake
+type Certificate struct {
+	SerialNumber []byte
+	// Subject and Issuer are the common names of the subject and issuer
+	Subject     string
+	Issuer      string
+	DNSNames    []string
+	IPAddresses []net.IP
+	NotBefore   time.Time
+	NotAfter    time.Time
+}
+
+// ParsePartialCertificate parses a DER encoded X.509 certificate that may be
+// truncated, as only the beginning of the certificate is captured from the
+// handshake. The fields are parsed in order until the end of the data, so
+// the fields located after the truncation are left empty. The subject
+// alternative names, which come last, are the most likely to be missing.
+func ParsePartialCertificate(der []byte) (*Certificate, error) {
+	cert, _, err := readPartialElement(der, derTagSequence)
+	if err != nil {
+		return nil, err
+	}
+	tbs, _, err := readPartialElement(cert, derTagSequence)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Certificate{}
+	if err := c.parseTBSCertificate(tbs); err != nil && !errors.Is(err, errTruncated) {
+		return nil, err
+	}
+	if len(c.SerialNumber) == 0 {
+		return nil, errTruncated
+	}
+	return c, nil
+}
+
+// parseTBSCertificate parses the fields of the TBSCertificate, as described
+// in RFC 5280 Section 4.1, until the data is exhausted
+func (c *Certificate) parseTBSCertificate(tbs []byte) error {
+	if len(tbs) > 0 && tbs[0] == derTagVersion {
+		_, rest, err := readElement(tbs, derTagVersion)
+		if err != nil {
+			return err
+		}
+		tbs = rest
+	}
+
+	serial, tbs, err := readElement(tbs, derTagInteger)
+	if err != nil {
+		return err
+	}
+	c.SerialNumber = serial
+
+	// signature algorithm
+	if _, tbs, err = readElement(tbs, derTagSequence); err != nil {
+		return err
+	}
+
+	issuer, tbs, err := readRawElement(tbs, derTagSequence)
+	if err != nil {
+		return err
+	}
+	if c.Issuer, err = commonName(issuer); err != nil {
+		return err
+	}
+
+	validity, tbs, err := readRawElement(tbs, derTagSequence)
+	if err != nil {
+		return err
+	}
+	var v struct {
+		NotBefore, NotAfter time.Time
+	}
+	if _, err := asn1.Unmarshal(validity, &v); err != nil {
+		return err
+	}
+	c.NotBefore, c.NotAfter = v.NotBefore, v.NotAfter
+
+	subject, tbs, err := readRawElement(tbs, derTagSequence)
+	if err != nil {
+		return err
+	}
+	if c.Subject, err = commonName(subject); err != nil {
+		return err
+	}
+
+	// subject public key info
+	if _, tbs, err = readElement(tbs, derTagSequence); err != nil {
+		return err
+	}
+
+	// skip the issuer and subject unique IDs, which are long deprecated
+	for len(tbs) > 0 && tbs[0] != derTagExtensions {
+		if _, tbs, err = readElement(tbs, tbs[0]); err != nil {
+			return err
+		}
+	}
+	if len(tbs) == 0 {
+		return errTruncated
+	}
+
+	extensions, _, err := readPartialElement(tbs, derTagExtensions)
+	if err != nil {
+		return err
+	}
+	extensions, _, err = readPartialElement(extensions, derTagSequence)
+	if err != nil {
+		return err
+	}
+	return c.parseExtensions(extensions)
+}
+
+// parseExtensions looks for the subject alternative names in the extensions
+func (c *Certificate) parseExtensions(extensions []byte) error {
+	for len(extensions) > 0 {
+		var raw []byte
+		var err error
+		if raw, extensions, err = readRawElement(extensions, derTagSequence); err != nil {
+			return err
+		}
+
+		var ext pkix.Extension
+		if _, err := asn1.Unmarshal(raw, &ext); err != nil {
+			return err
+		}
+		if !ext.Id.Equal(oidSubjectAltName) {
+			continue
+		}
+
+		names, _, err := readElement(ext.Value, derTagSequence)
+		if err != nil {
+			return err
+		}
+		for len(names) > 0 {
+			tag := names[0]
+			var name []byte
+			if name, names, err = readElement(names, tag); err != nil {
+				return err
+			}
+			switch {
+			case tag == derTagDNSName:
+				c.DNSNames = append(c.DNSNames, string(name))
+			case tag == derTagIPAddress && (len(name) == net.IPv4len || len(name) == net.IPv6len):
+				c.IPAddresses = append(c.IPAddresses, net.IP(bytes.Clone(name)))
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+// commonName returns the common name of a DER encoded Name
+func commonName(raw []byte) (string, error) {
+	var rdns pkix.RDNSequence
+	if _, err := asn1.Unmarshal(raw, &rdns); err != nil {
+		return "", err
+	}
+	var name pkix.Name
+	name.FillFromRDNSequence(&rdns)
+	return name.CommonName, nil
+}
+
+// readElement reads a complete DER element with the expected tag, returning
+// its content and the data following it
+func readElement(data []byte, tag byte) (content []byte, rest []byte, err error) {
+	header, length, err := readHeader(data, tag)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(data)-header < length {
+		return nil, nil, errTruncated
+	}
+	return data[header : header+length], data[header+length:], nil
+}
+
+// readRawElement is like readElement, but returns the whole element,
+// including its header
+func readRawElement(data []byte, tag byte) (raw []byte, rest []byte, err error) {
+	header, length, err := readHeader(data, tag)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(data)-header < length {
+		return nil, nil, errTruncated
+	}
+	return data[:header+length], data[header+length:], nil
+}
+
+// readPartialElement is like readElement, but accepts an element whose
+// content is truncated
+func readPartialElement(data []byte, tag byte) (content []byte, rest []byte, err error) {
+	header, length, err := readHeader(data, tag)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(data)-header < length {
+		return data[header:], nil, nil
+	}
+	return data[header : header+length], data[header+length:], nil
+}
+
+// readHeader reads the header of a DER element, returning its size and the
+// length of the element content
+func readHeader(data []byte, tag byte) (header int, length int, err error) {
+	if len(data) < 2 {
+		return 0, 0, errTruncated
+	}
+	if data[0] != tag {
+		return 0, 0, errors.New("unexpected DER tag")
+	}
+
+	if data[1] < 0x80 {
+		return 2, int(data[1]), nil
+	}
+
+	// long form, the lengths of certificates fit in 3 bytes
+	size := int(data[1] & 0x7f)
+	if size == 0 || size > 3 {
+		return 0, 0, errors.New("invalid DER length")
+	}
+	if len(data) < 2+size {
+		return 0, 0, errTruncated
+	}
+	for _, b := range data[2 : 2+size] {
+		length = length<<8 | int(b)
+	}
+	return 2 + size, length, nil
+}
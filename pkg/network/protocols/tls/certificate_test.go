@@ -0,0 +1,138 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package tls
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func createCertificate(t *testing.T) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notBefore := time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC)
+	ca := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{Organization: []string{"Datadog, Inc."}, CommonName: "Test Root CA"},
+		NotBefore:             notBefore,
+		NotAfter:              notBefore.AddDate(10, 0, 0),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	leaf := &x509.Certificate{
+		SerialNumber: big.NewInt(0x077c68dfba211528),
+		Subject:      pkix.Name{Country: []string{"US"}, CommonName: "*.datadoghq.com"},
+		NotBefore:    notBefore,
+		NotAfter:     notBefore.AddDate(1, 0, 0),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		DNSNames:     []string{"*.datadoghq.com", "datadoghq.com"},
+		IPAddresses:  []net.IP{net.ParseIP("192.0.2.1"), net.ParseIP("2001:db8::1")},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, leaf, ca, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+func TestParsePartialCertificate(t *testing.T) {
+	der := createCertificate(t)
+	notBefore := time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC)
+
+	full := &Certificate{
+		SerialNumber: []byte{0x07, 0x7c, 0x68, 0xdf, 0xba, 0x21, 0x15, 0x28},
+		Subject:      "*.datadoghq.com",
+		Issuer:       "Test Root CA",
+		DNSNames:     []string{"*.datadoghq.com", "datadoghq.com"},
+		IPAddresses:  []net.IP{net.ParseIP("192.0.2.1").To4(), net.ParseIP("2001:db8::1")},
+		NotBefore:    notBefore,
+		NotAfter:     notBefore.AddDate(1, 0, 0),
+	}
+
+	// the SANs extension is the last element of this certificate, cutting
+	// right before it leaves the other fields untouched
+	sanIndex := bytes.Index(der, []byte{0x06, 0x03, 0x55, 0x1d, 0x11})
+	if sanIndex < 0 {
+		t.Fatal("subject alternative names not found in the certificate")
+	}
+	withoutSANs := *full
+	withoutSANs.DNSNames = nil
+	withoutSANs.IPAddresses = nil
+
+	subjectIndex := bytes.Index(der, []byte("*.datadoghq.com"))
+	serialOnly := &Certificate{
+		SerialNumber: full.SerialNumber,
+		Issuer:       full.Issuer,
+		NotBefore:    full.NotBefore,
+		NotAfter:     full.NotAfter,
+	}
+
+	tests := []struct {
+		name     string
+		der      []byte
+		expected *Certificate
+	}{
+		{
+			name:     "Full_Certificate",
+			der:      der,
+			expected: full,
+		},
+		{
+			name:     "Truncated_In_Extensions",
+			der:      der[:sanIndex],
+			expected: &withoutSANs,
+		},
+		{
+			name:     "Truncated_In_Subject",
+			der:      der[:subjectIndex],
+			expected: serialOnly,
+		},
+		{
+			name:     "Truncated_In_Serial",
+			der:      der[:10],
+			expected: nil,
+		},
+		{
+			name:     "Not_A_Certificate",
+			der:      []byte("GET / HTTP/1.1\r\n"),
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := ParsePartialCertificate(test.der)
+			if test.expected == nil {
+				if err == nil {
+					t.Errorf("ParsePartialCertificate() = %+v; want an error", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePartialCertificate() returned an error: %v", err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("ParsePartialCertificate() = %+v; want %+v", result, test.expected)
+			}
+		})
+	}
+}
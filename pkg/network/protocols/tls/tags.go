@@ -16,6 +16,7 @@ const (
 	TagTLSVersion       = "tls.version:"
 	TagTLSCipherSuiteID = "tls.cipher_suite_id:"
 	TagTLSClientVersion = "tls.client_version:"
+	TagTLSServerName    = "tls.server_name:"
 	version10           = "tls_1.0"
 	version11           = "tls_1.1"
 	version12           = "tls_1.2"
@@ -57,12 +58,14 @@ var offeredVersionBitmask = []struct {
 	{OfferedTLSVersion13, tls.VersionTLS13},
 }
 
-// Tags holds the TLS tags. It is used to store the TLS version, cipher suite and offered versions.
+// Tags holds the TLS tags. It is used to store the TLS version, cipher suite, offered versions
+// and the server name (SNI) sent by the client.
 // We can't use the struct from eBPF as the definition is shared with windows.
 type Tags struct {
 	ChosenVersion   uint16
 	CipherSuite     uint16
 	OfferedVersions uint8
+	ServerName      string
 }
 
 // MergeWith merges the tags from another Tags struct into this one
@@ -76,6 +79,9 @@ func (t *Tags) MergeWith(that Tags) {
 	if t.OfferedVersions == 0 {
 		t.OfferedVersions = that.OfferedVersions
 	}
+	if t.ServerName == "" {
+		t.ServerName = that.ServerName
+	}
 
 }
 
@@ -84,12 +90,12 @@ func (t *Tags) IsEmpty() bool {
 	if t == nil {
 		return true
 	}
-	return t.ChosenVersion == 0 && t.CipherSuite == 0 && t.OfferedVersions == 0
+	return t.ChosenVersion == 0 && t.CipherSuite == 0 && t.OfferedVersions == 0 && t.ServerName == ""
 }
 
 // String returns a string representation of the Tags struct
 func (t *Tags) String() string {
-	return fmt.Sprintf("ChosenVersion: %d, CipherSuite: %d, OfferedVersions: %d", t.ChosenVersion, t.CipherSuite, t.OfferedVersions)
+	return fmt.Sprintf("ChosenVersion: %d, CipherSuite: %d, OfferedVersions: %d, ServerName: %s", t.ChosenVersion, t.CipherSuite, t.OfferedVersions, t.ServerName)
 }
 
 // parseOfferedVersions parses the Offered_versions bitmask into a slice of version strings
@@ -131,5 +137,10 @@ func (t *Tags) GetDynamicTags() map[string]struct{} {
 		tags[hexCipherSuiteTag(t.CipherSuite)] = struct{}{}
 	}
 
+	// Server name requested by the client
+	if t.ServerName != "" {
+		tags[TagTLSServerName+t.ServerName] = struct{}{}
+	}
+
 	return tags
 }
@@ -99,6 +99,19 @@ func TestGetTLSDynamicTags(t *testing.T) {
 				"tls.client_version:tls_1.0": {},
 			},
 		},
+		{
+			name: "Server_Name",
+			tlsTags: &Tags{
+				ChosenVersion: tls.VersionTLS13,
+				CipherSuite:   0x1301,
+				ServerName:    "api.datadoghq.com",
+			},
+			expected: map[string]struct{}{
+				"tls.version:tls_1.3":               {},
+				"tls.cipher_suite_id:0x1301":        {},
+				"tls.server_name:api.datadoghq.com": {},
+			},
+		},
 		{
 			name: "All_Bits_Set_In_Offered_Versions",
 			tlsTags: &Tags{
@@ -6,9 +6,13 @@
 package network
 
 import (
+	"strings"
 	"time"
 )
 
+// subjectAltNamesSeparator separates the subject alternative names of a CertInfo
+const subjectAltNamesSeparator = ","
+
 // CertValidity describes the time range that a certificate is valid for
 type CertValidity struct {
 	NotBefore time.Time
@@ -18,7 +22,14 @@ type CertValidity struct {
 // CertInfo describes metadata about a TLS certificate
 type CertInfo struct {
 	SerialNumber string
-	Domain       string
+	// Domain is the first DNS name of the subject alternative names
+	Domain string
+	// SubjectAltNames are the DNS names and IP addresses of the subject alternative names, joined with commas so
+	// that CertInfo remains comparable and can be interned
+	SubjectAltNames string
+	// Subject and Issuer are the common names of the subject and issuer
+	Subject string
+	Issuer  string
 
 	Validity CertValidity
 }
@@ -33,6 +44,17 @@ func (ci *CertInfo) GetDynamicTags() map[string]struct{} {
 	if ci.Domain != "" {
 		tags["tls_cert_domain:"+ci.Domain] = struct{}{}
 	}
+	if ci.SubjectAltNames != "" {
+		for _, name := range strings.Split(ci.SubjectAltNames, subjectAltNamesSeparator) {
+			tags["tls_cert_san:"+name] = struct{}{}
+		}
+	}
+	if ci.Subject != "" {
+		tags["tls_cert_subject:"+ci.Subject] = struct{}{}
+	}
+	if ci.Issuer != "" {
+		tags["tls_cert_issuer:"+ci.Issuer] = struct{}{}
+	}
 
 	if !ci.Validity.NotBefore.IsZero() {
 		timestamp := ci.Validity.NotBefore.UTC().Format(time.RFC3339)
@@ -9,19 +9,50 @@ package network
 
 import (
 	"encoding/hex"
+	"strings"
 	"time"
 
 	netebpf "github.com/DataDog/datadog-agent/pkg/network/ebpf"
+	"github.com/DataDog/datadog-agent/pkg/network/protocols/tls"
 )
 
 // FromCertItem converts the ebpf CertItem struct into go CertInfo
 func (ci *CertInfo) FromCertItem(certItem *netebpf.CertItem) {
 	ci.SerialNumber = hex.EncodeToString(certItem.Serial.Data[:certItem.Serial.Len])
 	ci.Domain = string(certItem.Domain.Data[:certItem.Domain.Len])
+	ci.Subject = string(certItem.Subject.Data[:certItem.Subject.Len])
+	ci.Issuer = string(certItem.Issuer.Data[:certItem.Issuer.Len])
 
 	ci.Validity.FromCertValidity(&certItem.Validity)
 }
 
+// FromHandshakeCert converts the beginning of a certificate captured from a
+// TLS handshake into go CertInfo
+func (ci *CertInfo) FromHandshakeCert(cert *netebpf.TLSHandshakeCert) error {
+	parsed, err := tls.ParsePartialCertificate(cert.Data[:min(int(cert.Len), len(cert.Data))])
+	if err != nil {
+		return err
+	}
+
+	ci.SerialNumber = hex.EncodeToString(parsed.SerialNumber)
+	if len(parsed.DNSNames) > 0 {
+		ci.Domain = parsed.DNSNames[0]
+	}
+	names := make([]string, 0, len(parsed.DNSNames)+len(parsed.IPAddresses))
+	names = append(names, parsed.DNSNames...)
+	for _, ip := range parsed.IPAddresses {
+		names = append(names, ip.String())
+	}
+	ci.SubjectAltNames = strings.Join(names, subjectAltNamesSeparator)
+	ci.Subject = parsed.Subject
+	ci.Issuer = parsed.Issuer
+	ci.Validity = CertValidity{
+		NotBefore: parsed.NotBefore,
+		NotAfter:  parsed.NotAfter,
+	}
+	return nil
+}
+
 const derDateFormat = "060102150405"
 
 // FromCertValidity converts the ebpf CertValidity struct into go CertValidity
@@ -39,6 +39,8 @@ var stateTelemetry = struct {
 	kafkaStatsDropped      *telemetryComponent.StatCounterWrapper
 	postgresStatsDropped   *telemetryComponent.StatCounterWrapper
 	redisStatsDropped      *telemetryComponent.StatCounterWrapper
+	mysqlStatsDropped      *telemetryComponent.StatCounterWrapper
+	mongoStatsDropped      *telemetryComponent.StatCounterWrapper
 	dnsPidCollisions       *telemetryComponent.StatCounterWrapper
 	windowsLingeringFlows  *telemetryComponent.StatCounterWrapper
 	incomingDirectionFixes telemetryComponent.Counter
@@ -55,6 +57,8 @@ var stateTelemetry = struct {
 	telemetryComponent.NewStatCounterWrapper(telemetryimpl.GetCompatComponent(), stateModuleName, "kafka_stats_dropped", []string{}, "Counter measuring the number of kafka stats dropped"),
 	telemetryComponent.NewStatCounterWrapper(telemetryimpl.GetCompatComponent(), stateModuleName, "postgres_stats_dropped", []string{}, "Counter measuring the number of postgres stats dropped"),
 	telemetryComponent.NewStatCounterWrapper(telemetryimpl.GetCompatComponent(), stateModuleName, "redis_stats_dropped", []string{}, "Counter measuring the number of redis stats dropped"),
+	telemetryComponent.NewStatCounterWrapper(telemetryimpl.GetCompatComponent(), stateModuleName, "mysql_stats_dropped", []string{}, "Counter measuring the number of mysql stats dropped"),
+	telemetryComponent.NewStatCounterWrapper(telemetryimpl.GetCompatComponent(), stateModuleName, "mongo_stats_dropped", []string{}, "Counter measuring the number of mongo stats dropped"),
 	telemetryComponent.NewStatCounterWrapper(telemetryimpl.GetCompatComponent(), stateModuleName, "dns_pid_collisions", []string{}, "Counter measuring the number of DNS PID collisions"),
 	telemetryComponent.NewStatCounterWrapper(telemetryimpl.GetCompatComponent(), stateModuleName, "windows_lingering_flows", []string{}, "Counter measuring flows that were already reported closed but are still being re-reported with failures by the Windows NPM driver (lingering openFlows bug)"),
 	telemetryimpl.GetCompatComponent().NewCounter(stateModuleName, "incoming_direction_fixes", []string{}, "Counter measuring the number of udp direction fixes for incoming connections"),
@@ -135,6 +139,8 @@ type lastStateTelemetry struct {
 	kafkaStatsDropped     int64
 	postgresStatsDropped  int64
 	redisStatsDropped     int64
+	mysqlStatsDropped     int64
+	mongoStatsDropped     int64
 	dnsPidCollisions      int64
 	windowsLingeringFlows int64
 }
@@ -268,6 +274,8 @@ type networkState struct {
 	maxKafkaStats               int
 	maxPostgresStats            int
 	maxRedisStats               int
+	maxMySQLStats               int
+	maxMongoStats               int
 	enableConnectionRollup      bool
 	processEventConsumerEnabled bool
 	dnsMonitoringPorts          []int
@@ -276,7 +284,7 @@ type networkState struct {
 }
 
 // NewState creates a new network state
-func NewState(_ telemetryComponent.Component, clientExpiry time.Duration, maxClosedConns uint32, maxClientStats, maxDNSStats, maxHTTPStats, maxKafkaStats, maxPostgresStats, maxRedisStats int, enableConnectionRollup bool, processEventConsumerEnabled bool, dnsMonitoringPorts []int) State {
+func NewState(_ telemetryComponent.Component, clientExpiry time.Duration, maxClosedConns uint32, maxClientStats, maxDNSStats, maxHTTPStats, maxKafkaStats, maxPostgresStats, maxRedisStats, maxMySQLStats, maxMongoStats int, enableConnectionRollup bool, processEventConsumerEnabled bool, dnsMonitoringPorts []int) State {
 	ns := &networkState{
 		clients:                     map[string]*client{},
 		clientExpiry:                clientExpiry,
@@ -287,6 +295,8 @@ func NewState(_ telemetryComponent.Component, clientExpiry time.Duration, maxClo
 		maxKafkaStats:               maxKafkaStats,
 		maxPostgresStats:            maxPostgresStats,
 		maxRedisStats:               maxRedisStats,
+		maxMySQLStats:               maxMySQLStats,
+		maxMongoStats:               maxMongoStats,
 		enableConnectionRollup:      enableConnectionRollup,
 		localResolver:               NewLocalResolver(processEventConsumerEnabled),
 		processEventConsumerEnabled: processEventConsumerEnabled,
@@ -448,12 +458,15 @@ func (ns *networkState) logTelemetry() {
 	kafkaStatsDroppedDelta := stateTelemetry.kafkaStatsDropped.Load() - ns.lastTelemetry.kafkaStatsDropped
 	postgresStatsDroppedDelta := stateTelemetry.postgresStatsDropped.Load() - ns.lastTelemetry.postgresStatsDropped
 	redisStatsDroppedDelta := stateTelemetry.redisStatsDropped.Load() - ns.lastTelemetry.redisStatsDropped
+	mysqlStatsDroppedDelta := stateTelemetry.mysqlStatsDropped.Load() - ns.lastTelemetry.mysqlStatsDropped
+	mongoStatsDroppedDelta := stateTelemetry.mongoStatsDropped.Load() - ns.lastTelemetry.mongoStatsDropped
 	dnsPidCollisionsDelta := stateTelemetry.dnsPidCollisions.Load() - ns.lastTelemetry.dnsPidCollisions
 	windowsLingeringFlowsDelta := stateTelemetry.windowsLingeringFlows.Load() - ns.lastTelemetry.windowsLingeringFlows
 
 	// Flush log line if any metric is non-zero
 	if connDroppedDelta > 0 || closedConnDroppedDelta > 0 || dnsStatsDroppedDelta > 0 || httpStatsDroppedDelta > 0 ||
-		http2StatsDroppedDelta > 0 || kafkaStatsDroppedDelta > 0 || postgresStatsDroppedDelta > 0 || redisStatsDroppedDelta > 0 {
+		http2StatsDroppedDelta > 0 || kafkaStatsDroppedDelta > 0 || postgresStatsDroppedDelta > 0 || redisStatsDroppedDelta > 0 ||
+		mysqlStatsDroppedDelta > 0 || mongoStatsDroppedDelta > 0 {
 		s := "State telemetry: "
 		s += " [%d connections dropped due to stats]"
 		s += " [%d closed connections dropped]"
@@ -463,6 +476,8 @@ func (ns *networkState) logTelemetry() {
 		s += " [%d Kafka stats dropped]"
 		s += " [%d postgres stats dropped]"
 		s += " [%d redis stats dropped]"
+		s += " [%d mysql stats dropped]"
+		s += " [%d mongo stats dropped]"
 		log.Warnf(s,
 			connDroppedDelta,
 			closedConnDroppedDelta,
@@ -472,6 +487,8 @@ func (ns *networkState) logTelemetry() {
 			kafkaStatsDroppedDelta,
 			postgresStatsDroppedDelta,
 			redisStatsDroppedDelta,
+			mysqlStatsDroppedDelta,
+			mongoStatsDroppedDelta,
 		)
 	}
 
@@ -505,6 +522,8 @@ func (ns *networkState) logTelemetry() {
 	ns.lastTelemetry.kafkaStatsDropped = stateTelemetry.kafkaStatsDropped.Load()
 	ns.lastTelemetry.postgresStatsDropped = stateTelemetry.postgresStatsDropped.Load()
 	ns.lastTelemetry.redisStatsDropped = stateTelemetry.redisStatsDropped.Load()
+	ns.lastTelemetry.mysqlStatsDropped = stateTelemetry.mysqlStatsDropped.Load()
+	ns.lastTelemetry.mongoStatsDropped = stateTelemetry.mongoStatsDropped.Load()
 	ns.lastTelemetry.dnsPidCollisions = stateTelemetry.dnsPidCollisions.Load()
 	ns.lastTelemetry.windowsLingeringFlows = stateTelemetry.windowsLingeringFlows.Load()
 }
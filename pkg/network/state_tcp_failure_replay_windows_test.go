@@ -18,7 +18,7 @@ import (
 )
 
 func replayNewDefaultState() *networkState {
-	return NewState(nil, 2*time.Minute, 50000, 75000, 75000, 7500, 7500, 7500, 7500, false, false, []int{53}).(*networkState)
+	return NewState(nil, 2*time.Minute, 50000, 75000, 75000, 7500, 7500, 7500, 7500, 7500, 7500, false, false, []int{53}).(*networkState)
 }
 
 // lingeringFlowFixture returns a ConnectionStats that models what FlowToConnStat
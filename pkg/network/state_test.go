@@ -590,7 +590,7 @@ func TestNoPriorRegistrationActiveConnections(t *testing.T) {
 func TestCleanupClient(t *testing.T) {
 	clientID := "1"
 
-	state := NewState(nil, 100*time.Millisecond, 50000, 75000, 75000, 7500, 7500, 7500, 7500, false, false, []int{53})
+	state := NewState(nil, 100*time.Millisecond, 50000, 75000, 75000, 7500, 7500, 7500, 7500, 7500, 7500, false, false, []int{53})
 	clients := state.(*networkState).getClients()
 	assert.Equal(t, 0, len(clients))
 
@@ -2621,7 +2621,7 @@ func latestEpochTime() uint64 {
 
 func newDefaultState() *networkState {
 	// Using values from ebpf.NewConfig()
-	return NewState(nil, 2*time.Minute, 50000, 75000, 75000, 7500, 7500, 7500, 7500, false, false, []int{53}).(*networkState)
+	return NewState(nil, 2*time.Minute, 50000, 75000, 75000, 7500, 7500, 7500, 7500, 7500, 7500, false, false, []int{53}).(*networkState)
 }
 
 func getIPProtocol(nt ConnectionType) uint8 {
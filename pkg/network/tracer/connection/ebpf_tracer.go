@@ -22,6 +22,7 @@ import (
 	manager "github.com/DataDog/ebpf-manager"
 	"github.com/DataDog/ebpf-manager/tracefs"
 	"github.com/cilium/ebpf"
+	lru "github.com/hashicorp/golang-lru/v2"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/sys/unix"
@@ -53,6 +54,11 @@ const (
 var tcpOngoingConnectMapTTL = 30 * time.Minute.Nanoseconds()
 var tlsTagsMapTTL = 3 * time.Minute.Nanoseconds()
 
+// certificates and server names captured from TLS handshakes are kept while connections
+// reference them, the connections check renews the timestamp of the ones in use
+var tlsHandshakeCertsMapTTL = 3 * time.Minute.Nanoseconds()
+var tlsServerNamesMapTTL = 3 * time.Minute.Nanoseconds()
+
 // EbpfTracerTelemetryData holds telemetry from the EBPF tracer
 type EbpfTracerTelemetryData struct {
 	connections       telemetryComponent.Gauge
@@ -149,6 +155,8 @@ type ebpfTracer struct {
 	ebpfTelemetryMap        *maps.GenericMap[uint32, netebpf.Telemetry]
 	tcpFailuresTelemetryMap *maps.GenericMap[int32, uint64]
 	sslCertInfoMap          *maps.GenericMap[uint32, netebpf.CertItem]
+	tlsHandshakeCertsMap    *maps.GenericMap[uint32, netebpf.TLSHandshakeCert]
+	tlsServerNamesMap       *maps.GenericMap[uint32, netebpf.TLSServerName]
 	config                  *config.Config
 
 	// tcp_close events
@@ -158,6 +166,15 @@ type ebpfTracer struct {
 	ongoingConnectCleaner *ddebpf.MapCleaner[netebpf.SkpConn, netebpf.PidTs]
 	// periodically clean the enhanced TLS tags map
 	TLSTagsCleaner *ddebpf.MapCleaner[netebpf.ConnTuple, netebpf.TLSTagsWrapper]
+	// periodically clean the TLS handshake certificates map
+	TLSHandshakeCertsCleaner *ddebpf.MapCleaner[uint32, netebpf.TLSHandshakeCert]
+	// periodically clean the TLS server names map
+	TLSServerNamesCleaner *ddebpf.MapCleaner[uint32, netebpf.TLSServerName]
+
+	// certificates and server names captured from TLS handshakes are shared
+	// by many connections, so they are only read and parsed once
+	handshakeCertInfos *lru.Cache[uint32, unique.Handle[network.CertInfo]]
+	serverNames        *lru.Cache[uint32, string]
 
 	removeTuple *netebpf.ConnTuple
 
@@ -217,8 +234,11 @@ func newEbpfTracer(config *config.Config, _ telemetryComponent.Component) (Trace
 		ch:                      newCookieHasher(),
 		lastTCPFailureTelemetry: make(map[int32]uint64),
 	}
+	tlsCacheSize := max(int(config.MaxTrackedConnections/32), 1)
+	tr.handshakeCertInfos, _ = lru.New[uint32, unique.Handle[network.CertInfo]](tlsCacheSize)
+	tr.serverNames, _ = lru.New[uint32, string](tlsCacheSize)
 
-	connCloseEventHandler, err := initClosedConnEventHandler(config, tr.getSSLCertInfo, tr.closedPerfCallback, connPool)
+	connCloseEventHandler, err := initClosedConnEventHandler(config, tr.getCertInfo, tr.getServerName, tr.closedPerfCallback, connPool)
 	if err != nil {
 		return nil, err
 	}
@@ -244,6 +264,8 @@ func newEbpfTracer(config *config.Config, _ telemetryComponent.Component) (Trace
 		mgrOptions.MapSpecEditors[probes.TCPEventStatsMap] = manager.MapSpecEditor{MaxEntries: config.MaxTrackedConnections, EditorFlag: manager.EditMaxEntries}
 		mgrOptions.MapSpecEditors[probes.ConnectionProtocolMap] = manager.MapSpecEditor{MaxEntries: config.MaxTrackedConnections, EditorFlag: manager.EditMaxEntries}
 		mgrOptions.MapSpecEditors[probes.EnhancedTLSTagsMap] = manager.MapSpecEditor{MaxEntries: config.MaxTrackedConnections, EditorFlag: manager.EditMaxEntries}
+		mgrOptions.MapSpecEditors[probes.TLSHandshakeCertsMap] = manager.MapSpecEditor{MaxEntries: config.MaxTrackedConnections / 32, EditorFlag: manager.EditMaxEntries}
+		mgrOptions.MapSpecEditors[probes.TLSServerNamesMap] = manager.MapSpecEditor{MaxEntries: config.MaxTrackedConnections / 32, EditorFlag: manager.EditMaxEntries}
 		mgrOptions.MapSpecEditors[probes.ConnectionTupleToSocketSKBConnMap] = manager.MapSpecEditor{MaxEntries: config.MaxTrackedConnections, EditorFlag: manager.EditMaxEntries}
 		mgrOptions.MapSpecEditors[probes.TCPOngoingConnectPid] = manager.MapSpecEditor{MaxEntries: config.MaxTrackedConnections, EditorFlag: manager.EditMaxEntries}
 		mgrOptions.MapSpecEditors[probes.TCPRecvMsgArgsMap] = manager.MapSpecEditor{MaxEntries: config.MaxTrackedConnections / 32, EditorFlag: manager.EditMaxEntries}
@@ -364,14 +386,25 @@ func newEbpfTracer(config *config.Config, _ telemetryComponent.Component) (Trace
 		if err != nil {
 			log.Warnf("error retrieving ssl cert info map: %s", err)
 		}
+
+		tr.tlsHandshakeCertsMap, err = maps.GetMap[uint32, netebpf.TLSHandshakeCert](m.Manager, probes.TLSHandshakeCertsMap)
+		if err != nil {
+			log.Warnf("error retrieving tls handshake certs map: %s", err)
+		}
+
+		tr.tlsServerNamesMap, err = maps.GetMap[uint32, netebpf.TLSServerName](m.Manager, probes.TLSServerNamesMap)
+		if err != nil {
+			log.Warnf("error retrieving tls server names map: %s", err)
+		}
 	}
 
 	return tr, nil
 }
 
-type lookupCertCb = func(certID uint32, refreshTimestamp bool) unique.Handle[network.CertInfo]
+type lookupCertCb = func(stats *netebpf.ConnStats, refreshTimestamp bool) unique.Handle[network.CertInfo]
+type lookupServerNameCb = func(serverNameID uint32, refreshTimestamp bool) string
 
-func initClosedConnEventHandler(config *config.Config, lookupCert lookupCertCb, closedCallback func(*network.ConnectionStats), pool ddsync.Pool[network.ConnectionStats]) (*perf.EventHandler, error) {
+func initClosedConnEventHandler(config *config.Config, lookupCert lookupCertCb, lookupServerName lookupServerNameCb, closedCallback func(*network.ConnectionStats), pool ddsync.Pool[network.ConnectionStats]) (*perf.EventHandler, error) {
 	connHasher := newCookieHasher()
 
 	handler := func(buf []byte) {
@@ -390,7 +423,8 @@ func initClosedConnEventHandler(config *config.Config, lookupCert lookupCertCb,
 		ct := (*netebpf.Conn)(unsafe.Pointer(&buf[0]))
 		c.FromConn(ct)
 
-		c.CertInfo = lookupCert(ct.Conn_stats.Cert_id, false)
+		c.CertInfo = lookupCert(&ct.Conn_stats, false)
+		c.TLSTags.ServerName = lookupServerName(ct.Conn_stats.Tls_tags.Server_name_id, false)
 		connHasher.Hash(c)
 		closedCallback(c)
 	}
@@ -490,6 +524,8 @@ func (t *ebpfTracer) Stop() {
 		t.closeConsumer.Stop()
 		t.ongoingConnectCleaner.Stop()
 		t.TLSTagsCleaner.Stop()
+		t.TLSHandshakeCertsCleaner.Stop()
+		t.TLSServerNamesCleaner.Stop()
 		if t.closeTracer != nil {
 			t.closeTracer()
 		}
@@ -587,6 +623,7 @@ func (t *ebpfTracer) getConnectionsHashmap(buffer *network.ConnectionBuffer, fil
 	var tcp4, tcp6, udp4, udp6 float64
 	entries := t.conns.IterateWithBatchSize(1000)
 	refreshedCertIDs := make(map[uint32]struct{})
+	refreshedServerNameIDs := make(map[uint32]struct{})
 
 	for entries.Next(key, stats) {
 		if cookie, exists := connsByTuple[*key]; exists && cookie == stats.Cookie {
@@ -630,12 +667,23 @@ func (t *ebpfTracer) getConnectionsHashmap(buffer *network.ConnectionBuffer, fil
 			conn.FromTCPEventStats(&tcpEvents)
 		}
 
-		// use a map to only refresh cert timestamps once per connections check
-		_, refreshTimestamp := refreshedCertIDs[stats.Cert_id]
-		if refreshTimestamp {
-			refreshedCertIDs[stats.Cert_id] = struct{}{}
+		// use maps to only refresh cert and server name timestamps once per connections check
+		certID := stats.Cert_id
+		if certID == 0 {
+			certID = stats.Tls_tags.Cert_id
+		}
+		_, refreshed := refreshedCertIDs[certID]
+		if !refreshed {
+			refreshedCertIDs[certID] = struct{}{}
+		}
+		conn.CertInfo = t.getCertInfo(stats, !refreshed)
+
+		serverNameID := stats.Tls_tags.Server_name_id
+		_, refreshed = refreshedServerNameIDs[serverNameID]
+		if !refreshed {
+			refreshedServerNameIDs[serverNameID] = struct{}{}
 		}
-		conn.CertInfo = t.getSSLCertInfo(stats.Cert_id, refreshTimestamp)
+		conn.TLSTags.ServerName = t.getServerName(serverNameID, !refreshed)
 
 		*buffer.Next() = *conn
 	}
@@ -1048,6 +1096,98 @@ func (t *ebpfTracer) getSSLCertInfo(certID uint32, refreshTimestamp bool) unique
 	return unique.Make(certInfo)
 }
 
+// getHandshakeCertInfo returns the server certificate captured from a TLS 1.2 handshake
+func (t *ebpfTracer) getHandshakeCertInfo(certID uint32, refreshTimestamp bool) unique.Handle[network.CertInfo] {
+	if t.tlsHandshakeCertsMap == nil || certID == 0 {
+		return unique.Handle[network.CertInfo]{}
+	}
+
+	certInfo, cached := t.handshakeCertInfos.Get(certID)
+	if cached && !refreshTimestamp {
+		return certInfo
+	}
+
+	cert := new(netebpf.TLSHandshakeCert)
+	if err := t.tlsHandshakeCertsMap.Lookup(&certID, cert); err != nil {
+		if !errors.Is(err, ebpf.ErrKeyNotExist) {
+			log.Warnf("getHandshakeCertInfo failed to lookup certID=%d: %s", certID, err)
+		}
+		t.handshakeCertInfos.Remove(certID)
+		return unique.Handle[network.CertInfo]{}
+	}
+
+	if !cached {
+		var info network.CertInfo
+		if err := info.FromHandshakeCert(cert); err != nil {
+			log.Debugf("getHandshakeCertInfo failed to parse certID=%d: %s", certID, err)
+			return unique.Handle[network.CertInfo]{}
+		}
+		certInfo = unique.Make(info)
+		t.handshakeCertInfos.Add(certID, certInfo)
+	}
+
+	if refreshTimestamp {
+		now, err := ddebpf.NowNanoseconds()
+		if err == nil {
+			cert.Timestamp = uint64(now)
+			err = t.tlsHandshakeCertsMap.Update(&certID, cert, ebpf.UpdateExist)
+		}
+		if err != nil {
+			log.Debugf("getHandshakeCertInfo failed to refresh timestamp for certID=%d: %s", certID, err)
+		}
+	}
+
+	return certInfo
+}
+
+// getCertInfo returns the server certificate of a connection, collected either
+// by the TLS library uprobes or from the TLS handshake
+func (t *ebpfTracer) getCertInfo(stats *netebpf.ConnStats, refreshTimestamp bool) unique.Handle[network.CertInfo] {
+	if stats.Cert_id != 0 {
+		return t.getSSLCertInfo(stats.Cert_id, refreshTimestamp)
+	}
+	return t.getHandshakeCertInfo(stats.Tls_tags.Cert_id, refreshTimestamp)
+}
+
+// getServerName returns the server name sent in the ClientHello of a connection
+func (t *ebpfTracer) getServerName(serverNameID uint32, refreshTimestamp bool) string {
+	if t.tlsServerNamesMap == nil || serverNameID == 0 {
+		return ""
+	}
+
+	serverName, cached := t.serverNames.Get(serverNameID)
+	if cached && !refreshTimestamp {
+		return serverName
+	}
+
+	value := new(netebpf.TLSServerName)
+	if err := t.tlsServerNamesMap.Lookup(&serverNameID, value); err != nil {
+		if !errors.Is(err, ebpf.ErrKeyNotExist) {
+			log.Warnf("getServerName failed to lookup serverNameID=%d: %s", serverNameID, err)
+		}
+		t.serverNames.Remove(serverNameID)
+		return ""
+	}
+
+	if !cached {
+		serverName = string(value.Name[:min(int(value.Len), len(value.Name))])
+		t.serverNames.Add(serverNameID, serverName)
+	}
+
+	if refreshTimestamp {
+		now, err := ddebpf.NowNanoseconds()
+		if err == nil {
+			value.Timestamp = uint64(now)
+			err = t.tlsServerNamesMap.Update(&serverNameID, value, ebpf.UpdateExist)
+		}
+		if err != nil {
+			log.Debugf("getServerName failed to refresh timestamp for serverNameID=%d: %s", serverNameID, err)
+		}
+	}
+
+	return serverName
+}
+
 // getTCPStats reads tcp related stats for the given ConnTuple
 func (t *ebpfTracer) getTCPStats(stats *netebpf.TCPStats, tuple *netebpf.ConnTuple) bool {
 	if tuple.Type() != netebpf.TCP {
@@ -1084,6 +1224,8 @@ func (t *ebpfTracer) getTCPEventStats(tuple *netebpf.ConnTuple, stats *netebpf.T
 func (t *ebpfTracer) setupMapCleaners(m *manager.Manager) {
 	t.setupOngoingConnectMapCleaner(m)
 	t.setupTLSTagsMapCleaner(m)
+	t.setupTLSHandshakeCertsMapCleaner(m)
+	t.setupTLSServerNamesMapCleaner(m)
 }
 
 // setupOngoingConnectMapCleaner sets up a map cleaner for the tcp_ongoing_connect_pid map
@@ -1138,3 +1280,61 @@ func (t *ebpfTracer) setupTLSTagsMapCleaner(m *manager.Manager) {
 
 	t.TLSTagsCleaner = TLSTagsMapCleaner
 }
+
+// setupTLSHandshakeCertsMapCleaner sets up a map cleaner for the tls_handshake_certs map
+func (t *ebpfTracer) setupTLSHandshakeCertsMapCleaner(m *manager.Manager) {
+	certsMap, ok, err := m.GetMap(probes.TLSHandshakeCertsMap)
+	if err != nil {
+		log.Errorf("error getting %v map: %s", probes.TLSHandshakeCertsMap, err)
+		return
+	}
+	if !ok {
+		return
+	}
+
+	certsMapCleaner, err := ddebpf.NewMapCleaner[uint32, netebpf.TLSHandshakeCert](certsMap, 100, probes.TLSHandshakeCertsMap, "npm_tracer")
+	if err != nil {
+		log.Errorf("error creating map cleaner: %s", err)
+		return
+	}
+	certsMapCleaner.Start(time.Second*65, nil, nil, func(now int64, _ uint32, val netebpf.TLSHandshakeCert) bool {
+		return tlsHandshakeCertExpired(now, &val)
+	})
+
+	t.TLSHandshakeCertsCleaner = certsMapCleaner
+}
+
+// tlsHandshakeCertExpired returns whether no connection used a certificate captured from a TLS handshake for tlsHandshakeCertsMapTTL
+func tlsHandshakeCertExpired(now int64, cert *netebpf.TLSHandshakeCert) bool {
+	ts := int64(cert.Timestamp)
+	return ts > 0 && now-ts > tlsHandshakeCertsMapTTL
+}
+
+// setupTLSServerNamesMapCleaner sets up a map cleaner for the tls_server_names map
+func (t *ebpfTracer) setupTLSServerNamesMapCleaner(m *manager.Manager) {
+	serverNamesMap, ok, err := m.GetMap(probes.TLSServerNamesMap)
+	if err != nil {
+		log.Errorf("error getting %v map: %s", probes.TLSServerNamesMap, err)
+		return
+	}
+	if !ok {
+		return
+	}
+
+	serverNamesMapCleaner, err := ddebpf.NewMapCleaner[uint32, netebpf.TLSServerName](serverNamesMap, 1024, probes.TLSServerNamesMap, "npm_tracer")
+	if err != nil {
+		log.Errorf("error creating map cleaner: %s", err)
+		return
+	}
+	serverNamesMapCleaner.Start(time.Second*75, nil, nil, func(now int64, _ uint32, val netebpf.TLSServerName) bool {
+		return tlsServerNameExpired(now, &val)
+	})
+
+	t.TLSServerNamesCleaner = serverNamesMapCleaner
+}
+
+// tlsServerNameExpired returns whether no connection used a server name captured from a TLS handshake for tlsServerNamesMapTTL
+func tlsServerNameExpired(now int64, serverName *netebpf.TLSServerName) bool {
+	ts := int64(serverName.Timestamp)
+	return ts > 0 && now-ts > tlsServerNamesMapTTL
+}
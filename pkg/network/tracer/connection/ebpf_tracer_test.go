@@ -9,11 +9,19 @@
 package connection
 
 import (
+	"encoding/pem"
+	"os"
+	"slices"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	ddebpf "github.com/DataDog/datadog-agent/pkg/ebpf"
+	"github.com/DataDog/datadog-agent/pkg/network"
 	"github.com/DataDog/datadog-agent/pkg/network/config"
+	netebpf "github.com/DataDog/datadog-agent/pkg/network/ebpf"
 )
 
 func TestFailedConnectionTelemetryMapLoads(t *testing.T) {
@@ -23,3 +31,64 @@ func TestFailedConnectionTelemetryMapLoads(t *testing.T) {
 
 	require.NotNil(t, tr.(*ebpfTracer).tcpFailuresTelemetryMap, "error loading tcp failure telemetry map")
 }
+
+func TestTLSHandshakeInfoRefreshedPastTTL(t *testing.T) {
+	tr, err := newEbpfTracer(config.New(), nil)
+	require.NoError(t, err, "could not load tracer")
+	t.Cleanup(tr.Stop)
+
+	ebpfTr := tr.(*ebpfTracer)
+	if ebpfTr.conns == nil || ebpfTr.tlsHandshakeCertsMap == nil || ebpfTr.tlsServerNamesMap == nil {
+		t.Skip("TLS handshake maps are not available with this tracer")
+	}
+
+	pemCert, err := os.ReadFile("../testdata/example.com.crt")
+	require.NoError(t, err)
+	block, _ := pem.Decode(pemCert)
+	require.NotNil(t, block, "could not decode certificate")
+
+	// timestamps the map cleaners consider expired, as if the connection had
+	// been open for longer than the TTLs
+	now, err := ddebpf.NowNanoseconds()
+	require.NoError(t, err)
+	expired := uint64(now - max(tlsHandshakeCertsMapTTL, tlsServerNamesMapTTL) - time.Second.Nanoseconds())
+
+	certID := uint32(0xc0ffee)
+	cert := &netebpf.TLSHandshakeCert{Timestamp: expired}
+	cert.Len = uint32(copy(cert.Data[:], block.Bytes))
+	require.True(t, tlsHandshakeCertExpired(now, cert))
+	require.NoError(t, ebpfTr.tlsHandshakeCertsMap.Put(&certID, cert))
+	t.Cleanup(func() { _ = ebpfTr.tlsHandshakeCertsMap.Delete(&certID) })
+
+	serverNameID := uint32(0xdecaf)
+	serverName := &netebpf.TLSServerName{Timestamp: expired}
+	serverName.Len = uint8(copy(serverName.Name[:], "example.com"))
+	require.True(t, tlsServerNameExpired(now, serverName))
+	require.NoError(t, ebpfTr.tlsServerNamesMap.Put(&serverNameID, serverName))
+	t.Cleanup(func() { _ = ebpfTr.tlsServerNamesMap.Delete(&serverNameID) })
+
+	tuple := &netebpf.ConnTuple{Sport: 40123, Dport: 443, Pid: 1, Metadata: uint32(netebpf.TCP)}
+	stats := &netebpf.ConnStats{}
+	stats.Tls_tags.Cert_id = certID
+	stats.Tls_tags.Server_name_id = serverNameID
+	require.NoError(t, ebpfTr.conns.Put(tuple, stats))
+	t.Cleanup(func() { _ = ebpfTr.conns.Delete(tuple) })
+
+	buffer := network.NewConnectionBuffer(512, 256)
+	require.NoError(t, tr.GetConnections(buffer, nil))
+	conns := buffer.Connections()
+	idx := slices.IndexFunc(conns, func(c network.ConnectionStats) bool {
+		return c.SPort == tuple.Sport && c.DPort == tuple.Dport
+	})
+	require.NotEqual(t, -1, idx, "connection not found")
+	assert.True(t, conns[idx].HasCertInfo())
+	assert.Equal(t, "example.com", conns[idx].TLSTags.ServerName)
+
+	// the connections check renewed the timestamps, so the next cleanups keep them
+	now, err = ddebpf.NowNanoseconds()
+	require.NoError(t, err)
+	require.NoError(t, ebpfTr.tlsHandshakeCertsMap.Lookup(&certID, cert))
+	assert.False(t, tlsHandshakeCertExpired(now, cert))
+	require.NoError(t, ebpfTr.tlsServerNamesMap.Lookup(&serverNameID, serverName))
+	assert.False(t, tlsServerNameExpired(now, serverName))
+}
@@ -240,6 +240,8 @@ func newTracer(cfg *config.Config, telemetryComponent telemetryComponent.Compone
 		cfg.MaxKafkaStatsBuffered,
 		cfg.MaxPostgresStatsBuffered,
 		cfg.MaxRedisStatsBuffered,
+		cfg.MaxMySQLStatsBuffered,
+		cfg.MaxMongoStatsBuffered,
 		cfg.EnableNPMConnectionRollup,
 		cfg.EnableProcessEventMonitoring,
 		cfg.DNSMonitoringPortList,
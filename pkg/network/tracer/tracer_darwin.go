@@ -54,6 +54,8 @@ func NewTracer(cfg *config.Config, telemetryComp telemetry.Component, _ statsd.C
 		cfg.MaxKafkaStatsBuffered,
 		cfg.MaxPostgresStatsBuffered,
 		cfg.MaxRedisStatsBuffered,
+		cfg.MaxMySQLStatsBuffered,
+		cfg.MaxMongoStatsBuffered,
 		cfg.EnableNPMConnectionRollup,
 		cfg.EnableProcessEventMonitoring,
 		cfg.DNSMonitoringPortList,
@@ -3313,6 +3313,8 @@ func testTLSCertParsing(t *testing.T, client *http.Client, matcher func(c *netwo
 
 	assert.Equal(t, "4dcfeb0a16bcfddba47a1fae9d28b4bd0b9212e7", cert.SerialNumber)
 	assert.Equal(t, "example.com", cert.Domain)
+	assert.Equal(t, "example.com", cert.Subject)
+	assert.Equal(t, "example.com", cert.Issuer)
 	assert.Equal(t, time.Date(2025, time.October, 2, 18, 5, 2, 0, time.UTC), cert.Validity.NotBefore)
 	assert.Equal(t, time.Date(2026, time.November, 6, 18, 5, 2, 0, time.UTC), cert.Validity.NotAfter)
 
@@ -95,6 +95,8 @@ func NewTracer(config *config.Config, telemetry telemetry.Component, _ statsd.Cl
 		config.MaxKafkaStatsBuffered,
 		config.MaxPostgresStatsBuffered,
 		config.MaxRedisStatsBuffered,
+		config.MaxMySQLStatsBuffered,
+		config.MaxMongoStatsBuffered,
 		config.EnableNPMConnectionRollup,
 		config.EnableProcessEventMonitoring,
 		config.DNSMonitoringPortList,
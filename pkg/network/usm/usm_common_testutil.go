@@ -20,6 +20,8 @@ func NewUSMEmptyConfig() *config.Config {
 	cfg.EnableKafkaMonitoring = false
 	cfg.EnablePostgresMonitoring = false
 	cfg.EnableRedisMonitoring = false
+	cfg.EnableMySQLMonitoring = false
+	cfg.EnableMongoMonitoring = false
 	cfg.EnableNativeTLSMonitoring = false
 	cfg.EnableIstioMonitoring = false
 	cfg.EnableNodeJSMonitoring = false
@@ -11,6 +11,8 @@ import (
 	"github.com/DataDog/datadog-agent/pkg/network/protocols"
 	"github.com/DataDog/datadog-agent/pkg/network/protocols/http"
 	"github.com/DataDog/datadog-agent/pkg/network/protocols/kafka"
+	"github.com/DataDog/datadog-agent/pkg/network/protocols/mongo"
+	"github.com/DataDog/datadog-agent/pkg/network/protocols/mysql"
 	"github.com/DataDog/datadog-agent/pkg/network/protocols/postgres"
 	"github.com/DataDog/datadog-agent/pkg/network/protocols/redis"
 )
@@ -22,6 +24,8 @@ type USMProtocolsData struct {
 	Kafka    map[kafka.Key]*kafka.RequestStats
 	Postgres map[postgres.Key]*postgres.RequestStat
 	Redis    map[redis.Key]*redis.RequestStats
+	MySQL    map[mysql.Key]*mysql.RequestStat
+	Mongo    map[mongo.Key]*mongo.RequestStat
 }
 
 // NewUSMProtocolsData creates a new instance of USMProtocolsData with initialized maps.
@@ -32,6 +36,8 @@ func NewUSMProtocolsData() USMProtocolsData {
 		Kafka:    make(map[kafka.Key]*kafka.RequestStats),
 		Postgres: make(map[postgres.Key]*postgres.RequestStat),
 		Redis:    make(map[redis.Key]*redis.RequestStats),
+		MySQL:    make(map[mysql.Key]*mysql.RequestStat),
+		Mongo:    make(map[mongo.Key]*mongo.RequestStat),
 	}
 }
 
@@ -52,6 +58,12 @@ func (o *USMProtocolsData) Reset() {
 	if len(o.Redis) > 0 {
 		o.Redis = make(map[redis.Key]*redis.RequestStats)
 	}
+	if len(o.MySQL) > 0 {
+		o.MySQL = make(map[mysql.Key]*mysql.RequestStat)
+	}
+	if len(o.Mongo) > 0 {
+		o.Mongo = make(map[mongo.Key]*mongo.RequestStat)
+	}
 }
 
 func (ns *networkState) storeHTTP2Stats(allStats map[http.Key]*http.RequestStats) {
@@ -105,6 +117,32 @@ func (ns *networkState) storeRedisStats(allStats map[redis.Key]*redis.RequestSta
 	)
 }
 
+// storeMySQLStats stores the latest MySQL stats for all clients
+func (ns *networkState) storeMySQLStats(allStats map[mysql.Key]*mysql.RequestStat) {
+	storeUSMStats[mysql.Key, *mysql.RequestStat](
+		allStats,
+		ns.clients,
+		func(c *client) map[mysql.Key]*mysql.RequestStat { return c.usmDelta.MySQL },
+		func(c *client, m map[mysql.Key]*mysql.RequestStat) { c.usmDelta.MySQL = m },
+		func(prev, new *mysql.RequestStat) { prev.CombineWith(new) },
+		ns.maxMySQLStats,
+		stateTelemetry.mysqlStatsDropped.Inc,
+	)
+}
+
+// storeMongoStats stores the latest MongoDB stats for all clients
+func (ns *networkState) storeMongoStats(allStats map[mongo.Key]*mongo.RequestStat) {
+	storeUSMStats[mongo.Key, *mongo.RequestStat](
+		allStats,
+		ns.clients,
+		func(c *client) map[mongo.Key]*mongo.RequestStat { return c.usmDelta.Mongo },
+		func(c *client, m map[mongo.Key]*mongo.RequestStat) { c.usmDelta.Mongo = m },
+		func(prev, new *mongo.RequestStat) { prev.CombineWith(new) },
+		ns.maxMongoStats,
+		stateTelemetry.mongoStatsDropped.Inc,
+	)
+}
+
 // processUSMDelta processes the USM delta for Linux.
 func (ns *networkState) processUSMDelta(stats map[protocols.ProtocolType]interface{}) {
 	for protocolType, protocolStats := range stats {
@@ -124,6 +162,12 @@ func (ns *networkState) processUSMDelta(stats map[protocols.ProtocolType]interfa
 		case protocols.Redis:
 			stats := protocolStats.(map[redis.Key]*redis.RequestStats)
 			ns.storeRedisStats(stats)
+		case protocols.MySQL:
+			stats := protocolStats.(map[mysql.Key]*mysql.RequestStat)
+			ns.storeMySQLStats(stats)
+		case protocols.Mongo:
+			stats := protocolStats.(map[mongo.Key]*mongo.RequestStat)
+			ns.storeMongoStats(stats)
 		}
 	}
 }
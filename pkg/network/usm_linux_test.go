@@ -15,6 +15,8 @@ import (
 	"github.com/DataDog/datadog-agent/pkg/network/protocols"
 	"github.com/DataDog/datadog-agent/pkg/network/protocols/http"
 	"github.com/DataDog/datadog-agent/pkg/network/protocols/kafka"
+	"github.com/DataDog/datadog-agent/pkg/network/protocols/mongo"
+	"github.com/DataDog/datadog-agent/pkg/network/protocols/mysql"
 	"github.com/DataDog/datadog-agent/pkg/network/protocols/redis"
 	"github.com/DataDog/datadog-agent/pkg/process/util"
 )
@@ -309,3 +311,61 @@ func TestKafkaStatsWithMultipleClients(t *testing.T) {
 	delta = state.GetDelta(client3, latestEpochTime(), nil, nil, getStats("my-topic2"))
 	assert.Len(t, delta.USMData.Kafka, 2)
 }
+
+func TestMySQLStats(t *testing.T) {
+	c := ConnectionStats{ConnectionTuple: ConnectionTuple{
+		Source: util.AddressFromString("1.1.1.1"),
+		Dest:   util.AddressFromString("0.0.0.0"),
+		SPort:  1000,
+		DPort:  3306,
+	}}
+
+	key := mysql.NewKey(c.Source, c.Dest, c.SPort, c.DPort, mysql.SelectOP, "users")
+	mysqlStats := make(map[mysql.Key]*mysql.RequestStat)
+	mysqlStats[key] = &mysql.RequestStat{
+		Count:      2,
+		ErrorCount: 1,
+	}
+	usmStats := make(map[protocols.ProtocolType]interface{})
+	usmStats[protocols.MySQL] = mysqlStats
+
+	// Register client & pass in MySQL stats
+	state := newDefaultState()
+	delta := state.GetDelta("client", latestEpochTime(), []ConnectionStats{c}, nil, usmStats)
+
+	// Verify connection has MySQL data embedded in it
+	assert.Len(t, delta.USMData.MySQL, 1)
+
+	// Verify MySQL data has been flushed
+	delta = state.GetDelta("client", latestEpochTime(), []ConnectionStats{c}, nil, nil)
+	assert.Len(t, delta.USMData.MySQL, 0)
+}
+
+func TestMongoStats(t *testing.T) {
+	c := ConnectionStats{ConnectionTuple: ConnectionTuple{
+		Source: util.AddressFromString("1.1.1.1"),
+		Dest:   util.AddressFromString("0.0.0.0"),
+		SPort:  1000,
+		DPort:  27017,
+	}}
+
+	key := mongo.NewKey(c.Source, c.Dest, c.SPort, c.DPort, mongo.FindCommand, "app", "users")
+	mongoStats := make(map[mongo.Key]*mongo.RequestStat)
+	mongoStats[key] = &mongo.RequestStat{
+		Count:      2,
+		ErrorCount: 1,
+	}
+	usmStats := make(map[protocols.ProtocolType]interface{})
+	usmStats[protocols.Mongo] = mongoStats
+
+	// Register client & pass in MongoDB stats
+	state := newDefaultState()
+	delta := state.GetDelta("client", latestEpochTime(), []ConnectionStats{c}, nil, usmStats)
+
+	// Verify connection has MongoDB data embedded in it
+	assert.Len(t, delta.USMData.Mongo, 1)
+
+	// Verify MongoDB data has been flushed
+	delta = state.GetDelta("client", latestEpochTime(), []ConnectionStats{c}, nil, nil)
+	assert.Len(t, delta.USMData.Mongo, 0)
+}
@@ -0,0 +1,86 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package compliance
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Drift is the difference between the running and the startup configs of a device, i.e. the changes that would be
+// lost on reboot
+type Drift struct {
+	// Added are the lines of the running config missing from the startup config
+	Added []string
+	// Removed are the lines of the startup config missing from the running config
+	Removed []string
+}
+
+// HasDrift returns true if the running and startup configs differ
+func (d Drift) HasDrift() bool {
+	return len(d.Added)+len(d.Removed) > 0
+}
+
+// CompareConfigs returns the drift between the running and the startup configs. Lines are compared regardless of
+// their order and leading or trailing whitespaces, but within their section: a line indented under a section is
+// reported prefixed with the first lines of its sections, e.g. `interface Gi0/1: shutdown`, so a line moving from a
+// section to another is a drift. Blank lines, comments and the headers printed by the devices before their configs
+// are ignored, since devices add comments like the last change time to their configs.
+func CompareConfigs(running string, startup string) Drift {
+	runningLines, startupLines := driftLines(running), driftLines(startup)
+	return Drift{
+		Added:   subtractLines(runningLines, startupLines),
+		Removed: subtractLines(startupLines, runningLines),
+	}
+}
+
+// configHeaders matches the lines printed by the devices before their configs, like IOS `Building configuration...`
+// and `Current configuration : 1234 bytes`
+var configHeaders = regexp.MustCompile(`^(Building configuration\.\.\.|Current configuration ?: ?\d+ bytes)$`)
+
+// driftLines returns the lines of a config compared for drift, each prefixed with the first lines of its sections
+func driftLines(config string) []string {
+	type section struct {
+		depth int
+		line  string
+	}
+	var sections []section
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(config, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "!") || strings.HasPrefix(trimmed, "#") || configHeaders.MatchString(trimmed) {
+			continue
+		}
+		depth := indentation(line)
+		for len(sections) > 0 && sections[len(sections)-1].depth >= depth {
+			sections = sections[:len(sections)-1]
+		}
+		qualified := trimmed
+		if len(sections) > 0 {
+			qualified = sections[len(sections)-1].line + ": " + trimmed
+		}
+		sections = append(sections, section{depth: depth, line: qualified})
+		lines = append(lines, qualified)
+	}
+	return lines
+}
+
+// subtractLines returns the lines of a missing from b, counting duplicates
+func subtractLines(a []string, b []string) []string {
+	counts := make(map[string]int, len(b))
+	for _, line := range b {
+		counts[line]++
+	}
+	var missing []string
+	for _, line := range a {
+		if counts[line] > 0 {
+			counts[line]--
+			continue
+		}
+		missing = append(missing, line)
+	}
+	return missing
+}
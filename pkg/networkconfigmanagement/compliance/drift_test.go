@@ -0,0 +1,79 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package compliance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareConfigs(t *testing.T) {
+	tests := []struct {
+		name     string
+		running  string
+		startup  string
+		expected Drift
+	}{
+		{
+			name:    "identical configs",
+			running: "hostname router1\ninterface Gi0/1\n shutdown\n",
+			startup: "hostname router1\ninterface Gi0/1\n shutdown\n",
+		},
+		{
+			name:    "comments, blank lines and whitespaces are ignored",
+			running: "! Last configuration change at 10:00:00\nhostname router1\n\ninterface Gi0/1\n shutdown  \n",
+			startup: "! NVRAM config last updated at 09:00:00\nhostname router1\ninterface Gi0/1\n\tshutdown\n# end\n",
+		},
+		{
+			name:    "added and removed lines",
+			running: "hostname router1\nlogging host 10.0.0.5\nntp server 10.0.0.1\n",
+			startup: "hostname router1\nntp server 10.0.0.2\n",
+			expected: Drift{
+				Added:   []string{"logging host 10.0.0.5", "ntp server 10.0.0.1"},
+				Removed: []string{"ntp server 10.0.0.2"},
+			},
+		},
+		{
+			name:    "device headers are ignored",
+			running: "Building configuration...\r\n\r\nCurrent configuration : 1234 bytes\r\n!\r\nhostname router1\r\n",
+			startup: "hostname router1\n",
+		},
+		{
+			name:    "lines are compared within their section",
+			running: "interface Gi0/1\n shutdown\ninterface Gi0/2\n shutdown\n",
+			startup: "interface Gi0/1\n shutdown\ninterface Gi0/2\n",
+			expected: Drift{
+				Added: []string{"interface Gi0/2: shutdown"},
+			},
+		},
+		{
+			name:    "line moved to another section",
+			running: "interface Gi0/1\n description uplink\ninterface Gi0/2\n shutdown\n",
+			startup: "interface Gi0/1\n description uplink\n shutdown\ninterface Gi0/2\n",
+			expected: Drift{
+				Added:   []string{"interface Gi0/2: shutdown"},
+				Removed: []string{"interface Gi0/1: shutdown"},
+			},
+		},
+		{
+			name:    "nested sections",
+			running: "router bgp 65000\n address-family ipv4\n  neighbor 10.0.0.1 activate\n address-family ipv6\n",
+			startup: "router bgp 65000\n address-family ipv4\n address-family ipv6\n  neighbor 10.0.0.1 activate\n",
+			expected: Drift{
+				Added:   []string{"router bgp 65000: address-family ipv4: neighbor 10.0.0.1 activate"},
+				Removed: []string{"router bgp 65000: address-family ipv6: neighbor 10.0.0.1 activate"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			drift := CompareConfigs(tt.running, tt.startup)
+			assert.Equal(t, tt.expected, drift)
+			assert.Equal(t, len(tt.expected.Added)+len(tt.expected.Removed) > 0, drift.HasDrift())
+		})
+	}
+}
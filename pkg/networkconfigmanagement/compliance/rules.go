@@ -0,0 +1,239 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+// Package compliance evaluates declarative compliance rules on network device configurations and detects drift
+// between the running and startup configurations.
+package compliance
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/types"
+)
+
+// Severity is the severity of a rule violation
+type Severity string
+
+const (
+	// SeverityInfo is used for violations that don't need action
+	SeverityInfo Severity = "info"
+	// SeverityWarning is used for violations that need action, the default
+	SeverityWarning Severity = "warning"
+	// SeverityCritical is used for violations that need immediate action
+	SeverityCritical Severity = "critical"
+)
+
+// Rule is a declarative compliance rule evaluated on the configs fetched from a device. A config violates the
+// rule if a required line is missing, no line matches a required pattern, or a line matches a forbidden pattern.
+type Rule struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description"`
+	Severity    Severity `yaml:"severity"` // info, warning or critical; defaults to warning
+	// ConfigTypes are the types of config the rule applies to; defaults to the running config
+	ConfigTypes []types.ConfigType `yaml:"config_types"`
+	// Section is a pattern matching the first line of the sections the rule is scoped to (e.g. `^interface `).
+	// A section holds its first line and the following lines indented deeper. When set, each section is
+	// evaluated separately; otherwise the rule is evaluated on the whole config.
+	Section string `yaml:"section"`
+	// RequiredLines must be present, ignoring the leading and trailing whitespaces
+	RequiredLines []string `yaml:"required_lines"`
+	// RequiredPatterns must each match at least one line
+	RequiredPatterns []string `yaml:"required_patterns"`
+	// ForbiddenPatterns must not match any line
+	ForbiddenPatterns []string `yaml:"forbidden_patterns"`
+}
+
+// Finding is a violation of a rule
+type Finding struct {
+	// Section is the first line of the section violating the rule, empty for the rules without section
+	Section string
+	Message string
+}
+
+// RuleResult is the result of the evaluation of a rule on a config
+type RuleResult struct {
+	Rule       string
+	Severity   Severity
+	ConfigType types.ConfigType
+	Findings   []Finding
+}
+
+// Compliant returns true if the config complies with the rule
+func (r RuleResult) Compliant() bool {
+	return len(r.Findings) == 0
+}
+
+type compiledRule struct {
+	Rule
+	section           *regexp.Regexp
+	requiredPatterns  []*regexp.Regexp
+	forbiddenPatterns []*regexp.Regexp
+}
+
+// Policy is a set of compiled rules
+type Policy struct {
+	rules []compiledRule
+}
+
+// NewPolicy validates and compiles rules
+func NewPolicy(rules []Rule) (*Policy, error) {
+	policy := &Policy{}
+	names := make(map[string]bool, len(rules))
+	var errs []error
+	for _, rule := range rules {
+		compiled, err := compileRule(rule)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if names[rule.Name] {
+			errs = append(errs, fmt.Errorf("duplicate compliance rule %q", rule.Name))
+			continue
+		}
+		names[rule.Name] = true
+		policy.rules = append(policy.rules, compiled)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return policy, nil
+}
+
+func compileRule(rule Rule) (compiledRule, error) {
+	if rule.Name == "" {
+		return compiledRule{}, errors.New("compliance rule without name")
+	}
+	if len(rule.RequiredLines)+len(rule.RequiredPatterns)+len(rule.ForbiddenPatterns) == 0 {
+		return compiledRule{}, fmt.Errorf("compliance rule %q: no required line, required pattern or forbidden pattern", rule.Name)
+	}
+	switch rule.Severity {
+	case "":
+		rule.Severity = SeverityWarning
+	case SeverityInfo, SeverityWarning, SeverityCritical:
+	default:
+		return compiledRule{}, fmt.Errorf("compliance rule %q: invalid severity %q", rule.Name, rule.Severity)
+	}
+	if len(rule.ConfigTypes) == 0 {
+		rule.ConfigTypes = []types.ConfigType{types.RUNNING}
+	}
+	for _, configType := range rule.ConfigTypes {
+		if configType != types.RUNNING && configType != types.STARTUP {
+			return compiledRule{}, fmt.Errorf("compliance rule %q: invalid config type %q", rule.Name, configType)
+		}
+	}
+
+	compiled := compiledRule{Rule: rule}
+	var err error
+	if rule.Section != "" {
+		if compiled.section, err = regexp.Compile(rule.Section); err != nil {
+			return compiledRule{}, fmt.Errorf("compliance rule %q: invalid section pattern: %w", rule.Name, err)
+		}
+	}
+	if compiled.requiredPatterns, err = compilePatterns(rule.RequiredPatterns); err != nil {
+		return compiledRule{}, fmt.Errorf("compliance rule %q: invalid required pattern: %w", rule.Name, err)
+	}
+	if compiled.forbiddenPatterns, err = compilePatterns(rule.ForbiddenPatterns); err != nil {
+		return compiledRule{}, fmt.Errorf("compliance rule %q: invalid forbidden pattern: %w", rule.Name, err)
+	}
+	return compiled, nil
+}
+
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// Empty returns true if the policy has no rule
+func (p *Policy) Empty() bool {
+	return p == nil || len(p.rules) == 0
+}
+
+// Evaluate evaluates the rules applying to a config type on a config
+func (p *Policy) Evaluate(config string, configType types.ConfigType) []RuleResult {
+	if p.Empty() {
+		return nil
+	}
+	lines := strings.Split(strings.ReplaceAll(config, "\r\n", "\n"), "\n")
+	var results []RuleResult
+	for _, rule := range p.rules {
+		if !slices.Contains(rule.ConfigTypes, configType) {
+			continue
+		}
+		result := RuleResult{Rule: rule.Name, Severity: rule.Severity, ConfigType: configType}
+		if rule.section == nil {
+			result.Findings = rule.evaluateLines(lines, "")
+		} else {
+			for _, section := range findSections(lines, rule.section) {
+				result.Findings = append(result.Findings, rule.evaluateLines(section, strings.TrimSpace(section[0]))...)
+			}
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func (r *compiledRule) evaluateLines(lines []string, section string) []Finding {
+	var findings []Finding
+	for _, required := range r.RequiredLines {
+		required = strings.TrimSpace(required)
+		if !slices.ContainsFunc(lines, func(line string) bool { return strings.TrimSpace(line) == required }) {
+			findings = append(findings, Finding{Section: section, Message: fmt.Sprintf("missing required line %q", required)})
+		}
+	}
+	for _, required := range r.requiredPatterns {
+		if !slices.ContainsFunc(lines, required.MatchString) {
+			findings = append(findings, Finding{Section: section, Message: fmt.Sprintf("no line matches required pattern %q", required)})
+		}
+	}
+	for _, forbidden := range r.forbiddenPatterns {
+		for _, line := range lines {
+			if forbidden.MatchString(line) {
+				findings = append(findings, Finding{Section: section, Message: fmt.Sprintf("line %q matches forbidden pattern %q", strings.TrimSpace(line), forbidden)})
+			}
+		}
+	}
+	return findings
+}
+
+// findSections returns the sections starting with a line matching the section pattern. A section holds its first
+// line and the following lines indented deeper, blank lines excluded.
+func findSections(lines []string, section *regexp.Regexp) [][]string {
+	var sections [][]string
+	for i := 0; i < len(lines); i++ {
+		if !section.MatchString(lines[i]) {
+			continue
+		}
+		depth := indentation(lines[i])
+		current := []string{lines[i]}
+		j := i + 1
+		for ; j < len(lines); j++ {
+			if strings.TrimSpace(lines[j]) == "" {
+				continue
+			}
+			if indentation(lines[j]) <= depth {
+				break
+			}
+			current = append(current, lines[j])
+		}
+		sections = append(sections, current)
+		i = j - 1
+	}
+	return sections
+}
+
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
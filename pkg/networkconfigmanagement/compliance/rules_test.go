@@ -0,0 +1,178 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package compliance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/types"
+)
+
+const testConfig = `hostname router1
+service password-encryption
+!
+interface GigabitEthernet0/1
+ description uplink
+ ip address 10.0.0.1 255.255.255.0
+ shutdown
+!
+interface GigabitEthernet0/2
+ description downlink
+ ip access-group 100 in
+!
+snmp-server community public RO
+line vty 0 4
+ transport input telnet ssh
+`
+
+func TestNewPolicy_Validation(t *testing.T) {
+	tests := []struct {
+		name     string
+		rules    []Rule
+		errorMsg string
+	}{
+		{
+			name:  "valid rules",
+			rules: []Rule{{Name: "a", RequiredLines: []string{"x"}}, {Name: "b", ForbiddenPatterns: []string{"^y"}, Severity: SeverityCritical, ConfigTypes: []types.ConfigType{types.RUNNING, types.STARTUP}}},
+		},
+		{
+			name:     "missing name",
+			rules:    []Rule{{RequiredLines: []string{"x"}}},
+			errorMsg: "compliance rule without name",
+		},
+		{
+			name:     "no condition",
+			rules:    []Rule{{Name: "a", Section: "^interface"}},
+			errorMsg: `compliance rule "a": no required line, required pattern or forbidden pattern`,
+		},
+		{
+			name:     "duplicate name",
+			rules:    []Rule{{Name: "a", RequiredLines: []string{"x"}}, {Name: "a", RequiredLines: []string{"y"}}},
+			errorMsg: `duplicate compliance rule "a"`,
+		},
+		{
+			name:     "invalid severity",
+			rules:    []Rule{{Name: "a", RequiredLines: []string{"x"}, Severity: "high"}},
+			errorMsg: `compliance rule "a": invalid severity "high"`,
+		},
+		{
+			name:     "invalid config type",
+			rules:    []Rule{{Name: "a", RequiredLines: []string{"x"}, ConfigTypes: []types.ConfigType{"candidate"}}},
+			errorMsg: `compliance rule "a": invalid config type "candidate"`,
+		},
+		{
+			name:     "invalid pattern",
+			rules:    []Rule{{Name: "a", ForbiddenPatterns: []string{"("}}},
+			errorMsg: `compliance rule "a": invalid forbidden pattern`,
+		},
+		{
+			name:     "invalid section",
+			rules:    []Rule{{Name: "a", RequiredLines: []string{"x"}, Section: "["}},
+			errorMsg: `compliance rule "a": invalid section pattern`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := NewPolicy(tt.rules)
+			if tt.errorMsg == "" {
+				require.NoError(t, err)
+				assert.False(t, policy.Empty())
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorMsg)
+			assert.Nil(t, policy)
+		})
+	}
+}
+
+func TestPolicy_Empty(t *testing.T) {
+	var nilPolicy *Policy
+	assert.True(t, nilPolicy.Empty())
+	assert.Nil(t, nilPolicy.Evaluate(testConfig, types.RUNNING))
+
+	policy, err := NewPolicy(nil)
+	require.NoError(t, err)
+	assert.True(t, policy.Empty())
+}
+
+func TestPolicy_Evaluate(t *testing.T) {
+	policy, err := NewPolicy([]Rule{
+		{
+			Name:          "password-encryption",
+			RequiredLines: []string{"service password-encryption"},
+		},
+		{
+			Name:             "ntp",
+			Severity:         SeverityInfo,
+			RequiredPatterns: []string{`^ntp server `},
+		},
+		{
+			Name:              "no-default-community",
+			Severity:          SeverityCritical,
+			ConfigTypes:       []types.ConfigType{types.RUNNING, types.STARTUP},
+			ForbiddenPatterns: []string{`^snmp-server community (public|private)\b`},
+		},
+		{
+			Name:          "interface-description",
+			Section:       `^interface `,
+			RequiredLines: []string{"shutdown"},
+		},
+		{
+			Name:              "no-telnet",
+			Section:           `^line vty`,
+			ForbiddenPatterns: []string{`transport input .*telnet`},
+		},
+	})
+	require.NoError(t, err)
+
+	results := policy.Evaluate(testConfig, types.RUNNING)
+	assert.Equal(t, []RuleResult{
+		{Rule: "password-encryption", Severity: SeverityWarning, ConfigType: types.RUNNING},
+		{Rule: "ntp", Severity: SeverityInfo, ConfigType: types.RUNNING, Findings: []Finding{
+			{Message: `no line matches required pattern "^ntp server "`},
+		}},
+		{Rule: "no-default-community", Severity: SeverityCritical, ConfigType: types.RUNNING, Findings: []Finding{
+			{Message: `line "snmp-server community public RO" matches forbidden pattern "^snmp-server community (public|private)\\b"`},
+		}},
+		{Rule: "interface-description", Severity: SeverityWarning, ConfigType: types.RUNNING, Findings: []Finding{
+			{Section: "interface GigabitEthernet0/2", Message: `missing required line "shutdown"`},
+		}},
+		{Rule: "no-telnet", Severity: SeverityWarning, ConfigType: types.RUNNING, Findings: []Finding{
+			{Section: "line vty 0 4", Message: `line "transport input telnet ssh" matches forbidden pattern "transport input .*telnet"`},
+		}},
+	}, results)
+
+	// only the rule applying to startup configs is evaluated on them
+	results = policy.Evaluate("hostname router1\r\nsnmp-server community private RW\r\n", types.STARTUP)
+	require.Len(t, results, 1)
+	assert.Equal(t, "no-default-community", results[0].Rule)
+	assert.Equal(t, types.STARTUP, results[0].ConfigType)
+	assert.False(t, results[0].Compliant())
+}
+
+func TestFindSections(t *testing.T) {
+	lines := []string{
+		"router bgp 65000",
+		" neighbor 10.0.0.2 remote-as 65001",
+		"",
+		" address-family ipv4",
+		"  network 10.0.0.0",
+		" exit-address-family",
+		"router bgp 65001",
+		"hostname router1",
+	}
+	policy, err := NewPolicy([]Rule{{Name: "a", Section: "^router bgp", RequiredLines: []string{"x"}}})
+	require.NoError(t, err)
+	sections := findSections(lines, policy.rules[0].section)
+	assert.Equal(t, [][]string{
+		{"router bgp 65000", " neighbor 10.0.0.2 remote-as 65001", " address-family ipv4", "  network 10.0.0.0", " exit-address-family"},
+		{"router bgp 65001"},
+	}, sections)
+}
@@ -0,0 +1,75 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package compliance
+
+import (
+	"github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/types"
+)
+
+// Transition is a change of the compliance of a device with a rule, or of the drift of a device
+type Transition struct {
+	// Rule is empty for drift transitions
+	Rule       string
+	Severity   Severity
+	ConfigType types.ConfigType
+	// Violated is true when the rule starts being violated or the drift appears, and false when it's resolved
+	Violated bool
+	Findings []Finding
+	Drift    Drift
+}
+
+// IsDrift returns true for drift transitions
+func (t Transition) IsDrift() bool {
+	return t.Rule == ""
+}
+
+type ruleKey struct {
+	rule       string
+	configType types.ConfigType
+}
+
+// Tracker tracks the compliance of a device between evaluations, so that alerts are only raised when a rule
+// starts being violated or is resolved rather than on every evaluation
+type Tracker struct {
+	violated map[ruleKey]bool
+	drift    bool
+}
+
+// Update records the results of an evaluation and returns the transitions since the previous one. Violations
+// found by the first evaluation are transitions. The rules of configs that couldn't be fetched are missing from
+// the results, and drift is nil when it wasn't evaluated: their previous state is kept.
+func (t *Tracker) Update(results []RuleResult, drift *Drift) []Transition {
+	var transitions []Transition
+	if t.violated == nil {
+		t.violated = make(map[ruleKey]bool)
+	}
+	for _, result := range results {
+		key := ruleKey{rule: result.Rule, configType: result.ConfigType}
+		if result.Compliant() {
+			if t.violated[key] {
+				transitions = append(transitions, Transition{Rule: result.Rule, Severity: result.Severity, ConfigType: result.ConfigType})
+			}
+			delete(t.violated, key)
+			continue
+		}
+		if !t.violated[key] {
+			transitions = append(transitions, Transition{
+				Rule:       result.Rule,
+				Severity:   result.Severity,
+				ConfigType: result.ConfigType,
+				Violated:   true,
+				Findings:   result.Findings,
+			})
+		}
+		t.violated[key] = true
+	}
+
+	if drift != nil && drift.HasDrift() != t.drift {
+		t.drift = drift.HasDrift()
+		transitions = append(transitions, Transition{Severity: SeverityWarning, ConfigType: types.RUNNING, Violated: t.drift, Drift: *drift})
+	}
+	return transitions
+}
@@ -0,0 +1,56 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package compliance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/types"
+)
+
+func TestTracker_Update(t *testing.T) {
+	violation := []Finding{{Message: "missing required line \"service password-encryption\""}}
+	violated := RuleResult{Rule: "a", Severity: SeverityCritical, ConfigType: types.RUNNING, Findings: violation}
+	compliant := RuleResult{Rule: "a", Severity: SeverityCritical, ConfigType: types.RUNNING}
+	startupCompliant := RuleResult{Rule: "a", Severity: SeverityCritical, ConfigType: types.STARTUP}
+	drift := Drift{Added: []string{"logging host 10.0.0.5"}}
+
+	var tracker Tracker
+
+	// compliance on the first evaluation is not a transition
+	assert.Empty(t, tracker.Update([]RuleResult{compliant, startupCompliant}, &Drift{}))
+
+	// the rule starts being violated and the configs drift
+	assert.Equal(t, []Transition{
+		{Rule: "a", Severity: SeverityCritical, ConfigType: types.RUNNING, Violated: true, Findings: violation},
+		{Severity: SeverityWarning, ConfigType: types.RUNNING, Violated: true, Drift: drift},
+	}, tracker.Update([]RuleResult{violated, startupCompliant}, &drift))
+
+	// nothing changed
+	assert.Empty(t, tracker.Update([]RuleResult{violated, startupCompliant}, &drift))
+
+	// the running config couldn't be fetched: the previous state is kept
+	assert.Empty(t, tracker.Update([]RuleResult{startupCompliant}, nil))
+
+	// the violation and the drift are resolved
+	transitions := tracker.Update([]RuleResult{compliant, startupCompliant}, &Drift{})
+	assert.Equal(t, []Transition{
+		{Rule: "a", Severity: SeverityCritical, ConfigType: types.RUNNING},
+		{Severity: SeverityWarning, ConfigType: types.RUNNING},
+	}, transitions)
+	assert.False(t, transitions[0].IsDrift())
+	assert.True(t, transitions[1].IsDrift())
+}
+
+func TestTracker_FirstEvaluationViolation(t *testing.T) {
+	var tracker Tracker
+	violated := RuleResult{Rule: "a", Severity: SeverityWarning, ConfigType: types.STARTUP, Findings: []Finding{{Message: "m"}}}
+	assert.Equal(t, []Transition{
+		{Rule: "a", Severity: SeverityWarning, ConfigType: types.STARTUP, Violated: true, Findings: violated.Findings},
+	}, tracker.Update([]RuleResult{violated}, nil))
+}
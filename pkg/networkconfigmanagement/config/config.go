@@ -21,6 +21,7 @@ import (
 	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/integration"
 	ipc "github.com/DataDog/datadog-agent/comp/core/ipc/def"
 	ipchttp "github.com/DataDog/datadog-agent/comp/core/ipc/httphelpers"
+	"github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/compliance"
 	"github.com/DataDog/datadog-agent/pkg/snmp/utils"
 	"github.com/DataDog/datadog-agent/pkg/util/log"
 )
@@ -28,6 +29,7 @@ import (
 var checkName = "network_config_management"
 var defaultCheckInterval = 15 * time.Minute
 var defaultSSHTimeout = 30 * time.Second
+var defaultRestconfTimeout = 30 // seconds
 var defaultInventoryReportMaxInterval = 1 * time.Hour
 
 // AuthCredentials holds the authentication credentials to connect to a network device.
@@ -44,6 +46,10 @@ type AuthCredentials struct { // auth_credentials
 	Protocol string `yaml:"remote"`
 
 	SSH *SSHConfig `yaml:"ssh"`
+
+	// Settings of the NETCONF and RESTCONF transports, used by the profiles retrieving configs with these protocols
+	Netconf  *NetconfConfig  `yaml:"netconf"`
+	Restconf *RestconfConfig `yaml:"restconf"`
 }
 
 // DeviceInstance holds the initial config to connect to a network device, including its IP address and authentication credentials.
@@ -52,6 +58,9 @@ type DeviceInstance struct {
 	Namespace string          `yaml:"namespace"`  // namespace for the device; if empty, defaults to value from initconfig
 	Profile   string          `yaml:"profile"`    // device profile name, e.g., "cisco-ios"
 	Auth      AuthCredentials `yaml:"auth"`
+
+	// Compliance holds the device compliance settings; the device rules are added to the init config ones
+	Compliance *ComplianceConfig `yaml:"compliance"`
 }
 
 // DeviceID returns the formatted ID for this DeviceInstance.
@@ -65,6 +74,56 @@ type InitConfig struct {
 	MinCollectionInterval      time.Duration `yaml:"min_collection_interval"`       // Interval in seconds to check for config changes
 	InventoryReportMaxInterval time.Duration `yaml:"inventory_report_max_interval"` // Slowest cadence (in seconds) for sending an inventory report; a report is also sent any time a new config is captured
 	SSH                        *SSHConfig    `yaml:"ssh"`                           // SSH holds global connection configurations that can apply to all devices if pertinent
+
+	// Compliance holds the compliance settings applying to all devices
+	Compliance *ComplianceConfig `yaml:"compliance"`
+}
+
+// ComplianceConfig holds the compliance rules evaluated on every config fetched from a device, and the settings
+// of the detection of drift between the running and startup configs
+type ComplianceConfig struct {
+	Rules       []compliance.Rule `yaml:"rules"`
+	DetectDrift *bool             `yaml:"detect_drift"` // defaults to false
+}
+
+// DriftDetectionEnabled returns true if the drift between the running and startup configs should be detected, which
+// must be enabled explicitly
+func (cc *ComplianceConfig) DriftDetectionEnabled() bool {
+	return cc != nil && cc.DetectDrift != nil && *cc.DetectDrift
+}
+
+// Policy returns the compiled compliance rules
+func (cc *ComplianceConfig) Policy() (*compliance.Policy, error) {
+	if cc == nil {
+		return nil, nil
+	}
+	return compliance.NewPolicy(cc.Rules)
+}
+
+// mergeComplianceConfigs returns the compliance settings of a device: the device rules are added to the global
+// ones, replacing the global rules with the same name, and the device drift setting takes precedence
+func mergeComplianceConfigs(global *ComplianceConfig, device *ComplianceConfig) *ComplianceConfig {
+	if global == nil {
+		return device
+	}
+	if device == nil {
+		return global
+	}
+	merged := &ComplianceConfig{DetectDrift: global.DetectDrift}
+	if device.DetectDrift != nil {
+		merged.DetectDrift = device.DetectDrift
+	}
+	deviceRules := make(map[string]bool, len(device.Rules))
+	for _, rule := range device.Rules {
+		deviceRules[rule.Name] = true
+	}
+	for _, rule := range global.Rules {
+		if !deviceRules[rule.Name] {
+			merged.Rules = append(merged.Rules, rule)
+		}
+	}
+	merged.Rules = append(merged.Rules, device.Rules...)
+	return merged
 }
 
 // SSHConfig holds the configuration (either globally if in init config or for the specific device instance) to use when connecting to the configured device via SSH
@@ -84,6 +143,22 @@ type SSHConfig struct {
 	AllowLegacyAlgorithms bool `yaml:"allow_legacy_algorithms"`
 }
 
+// NetconfConfig holds the configuration to use when connecting to the configured device via NETCONF. NETCONF runs
+// over SSH, so the SSH configuration of the device also applies.
+type NetconfConfig struct {
+	Port string `yaml:"port"` // Port is the port of the NETCONF SSH subsystem, defaults to 830
+}
+
+// RestconfConfig holds the configuration to use when connecting to the configured device via RESTCONF (over HTTPS)
+type RestconfConfig struct {
+	Port    string `yaml:"port"`    // Port is the HTTPS port of the RESTCONF server, defaults to 443
+	Timeout int    `yaml:"timeout"` // Timeout is the timeout in seconds of the RESTCONF requests, defaults to 30
+
+	// For server certificate verification
+	CAFile             string `yaml:"ca_file"`              // CAFile is the path of the CA certificates used to verify the server certificate, defaults to the system ones
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"` // InsecureSkipVerify is a boolean for development/testing purposes to skip certificate verification (insecure)
+}
+
 // NcmCheckContext holds the processed config needed for an integration instance to run
 type NcmCheckContext struct {
 	Device                     *DeviceInstance
@@ -234,6 +309,16 @@ func (di *DeviceInstance) Validate() error {
 			return fmt.Errorf("invalid SSH config for device %s: %w", di.IPAddress, err)
 		}
 	}
+
+	if di.Auth.Restconf != nil {
+		if err := di.Auth.Restconf.validate(); err != nil {
+			return fmt.Errorf("invalid RESTCONF config for device %s: %w", di.IPAddress, err)
+		}
+	}
+
+	if _, err := di.Compliance.Policy(); err != nil {
+		return fmt.Errorf("invalid compliance config for device %s: %w", di.IPAddress, err)
+	}
 	return nil
 }
 
@@ -254,7 +339,9 @@ func (di *DeviceInstance) applyDefaults(initConfig *InitConfig) {
 	if di.Namespace == "" && initConfig != nil {
 		di.Namespace = initConfig.Namespace
 	}
-
+	if initConfig != nil {
+		di.Compliance = mergeComplianceConfigs(initConfig.Compliance, di.Compliance)
+	}
 }
 
 func (di *DeviceInstance) hasRequiredFields() error {
@@ -270,7 +357,8 @@ func (di *DeviceInstance) hasRequiredFields() error {
 	if di.Auth.Password == "" && di.Auth.PrivateKeyFile == "" {
 		return fmt.Errorf(authBaseString, "auth method (either password or private key)", di.IPAddress)
 	}
-	if di.Auth.SSH == nil {
+	// devices reached over RESTCONF don't need SSH
+	if di.Auth.SSH == nil && di.Auth.Restconf == nil {
 		return fmt.Errorf(authBaseString, "SSH configuration", di.IPAddress)
 	}
 
@@ -297,6 +385,17 @@ func (sc *SSHConfig) validate() error {
 	return nil
 }
 
+func (rc *RestconfConfig) validate() error {
+	if rc.Timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
+	if rc.Timeout == 0 {
+		log.Debugf("no RESTCONF timeout specified in config, applying default: %d", defaultRestconfTimeout)
+		rc.Timeout = defaultRestconfTimeout
+	}
+	return nil
+}
+
 func (sc *SSHConfig) hasRequiredFields() error {
 	// must have at least a known paths specified or skip verification (insecure, only for development/testing purposes)
 	if sc.KnownHostsPath == "" && !sc.InsecureSkipVerify {
@@ -14,6 +14,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.yaml.in/yaml/v2"
+
+	"github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/compliance"
+	"github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/types"
 )
 
 func TestDeviceInstance_Validation(t *testing.T) {
@@ -152,6 +155,35 @@ func TestDeviceInstance_Validation(t *testing.T) {
 			expectValid: false,
 			errorMsg:    "auth is required: missing SSH configuration for device 100.1.1.1",
 		},
+		{
+			name: "RESTCONF config without SSH config",
+			config: DeviceInstance{
+				IPAddress: "100.1.1.1",
+				Auth: AuthCredentials{
+					Username: "admin",
+					Password: "password",
+					Port:     "22",
+					Protocol: "tcp",
+					Restconf: &RestconfConfig{},
+				},
+			},
+			expectValid: true,
+		},
+		{
+			name: "invalid RESTCONF timeout",
+			config: DeviceInstance{
+				IPAddress: "100.1.1.1",
+				Auth: AuthCredentials{
+					Username: "admin",
+					Password: "password",
+					Port:     "22",
+					Protocol: "tcp",
+					Restconf: &RestconfConfig{Timeout: -1},
+				},
+			},
+			expectValid: false,
+			errorMsg:    "invalid RESTCONF config for device 100.1.1.1: timeout must not be negative",
+		},
 	}
 
 	for _, tt := range tests {
@@ -377,3 +409,85 @@ auth:
 		})
 	}
 }
+
+func TestNewNcmCheckContext_Transports(t *testing.T) {
+	initConfig := []byte(`
+namespace: default
+`)
+	instanceConfig := []byte(`
+ip_address: 192.168.0.1
+profile: restconf
+auth:
+  username: 'admin'
+  password: 'password'
+  netconf:
+    port: 2830
+  restconf:
+    port: 8443
+    ca_file: /etc/ssl/device-ca.pem
+`)
+	cfg, err := NewNcmCheckContext(instanceConfig, initConfig)
+	require.NoError(t, err)
+	assert.Equal(t, &NetconfConfig{Port: "2830"}, cfg.Device.Auth.Netconf)
+	assert.Equal(t, &RestconfConfig{
+		Port:    "8443",
+		Timeout: defaultRestconfTimeout,
+		CAFile:  "/etc/ssl/device-ca.pem",
+	}, cfg.Device.Auth.Restconf)
+}
+
+func TestNewNcmCheckContext_Compliance(t *testing.T) {
+	initConfig := []byte(`
+namespace: default
+ssh:
+  insecure_skip_verify: true
+compliance:
+  detect_drift: false
+  rules:
+    - name: password-encryption
+      required_lines: ["service password-encryption"]
+    - name: no-telnet
+      section: "^line vty"
+      forbidden_patterns: ["transport input .*telnet"]
+`)
+	instanceConfig := []byte(`
+ip_address: 192.168.0.1
+auth:
+  password: 'password'
+  username: 'admin'
+compliance:
+  detect_drift: true
+  rules:
+    - name: no-telnet
+      severity: critical
+      config_types: [running, startup]
+      forbidden_patterns: ["telnet"]
+`)
+	cfg, err := NewNcmCheckContext(instanceConfig, initConfig)
+	require.NoError(t, err)
+	require.NotNil(t, cfg.Device.Compliance)
+	assert.True(t, cfg.Device.Compliance.DriftDetectionEnabled())
+	assert.Equal(t, []compliance.Rule{
+		{Name: "password-encryption", RequiredLines: []string{"service password-encryption"}},
+		{Name: "no-telnet", Severity: compliance.SeverityCritical, ConfigTypes: []types.ConfigType{types.RUNNING, types.STARTUP}, ForbiddenPatterns: []string{"telnet"}},
+	}, cfg.Device.Compliance.Rules)
+
+	// drift detection is disabled by default
+	var noCompliance *ComplianceConfig
+	assert.False(t, noCompliance.DriftDetectionEnabled())
+	assert.False(t, (&ComplianceConfig{Rules: cfg.Device.Compliance.Rules}).DriftDetectionEnabled())
+
+	invalidInstanceConfig := []byte(`
+ip_address: 192.168.0.1
+auth:
+  password: 'password'
+  username: 'admin'
+compliance:
+  rules:
+    - name: invalid
+      forbidden_patterns: ["("]
+`)
+	_, err = NewNcmCheckContext(invalidInstanceConfig, initConfig)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid compliance config for device 192.168.0.1: compliance rule "invalid": invalid forbidden pattern`)
+}
@@ -83,8 +83,16 @@ const (
 	ProfileNXOS     ProfileName = "nxos"
 	ProfilePanOS    ProfileName = "pan-os"
 	ProfileTMOS     ProfileName = "tmos"
+
+	// Generic profiles retrieving configs with the standard NETCONF and
+	// RESTCONF protocols; they're never matched automatically.
+	ProfileNETCONF  ProfileName = "netconf"
+	ProfileRESTCONF ProfileName = "restconf"
 )
 
+// secretLeaves matches the names of the YANG leaves holding secrets.
+const secretLeaves = `(?:[\w.-]+:)?(?:password|secret|key|encrypted-password|authentication-key|auth-key|pre-shared-key|shared-secret|community|community-name|passphrase|private-key)`
+
 // DefaultProfiles is the built-in set of NCM device profiles, keyed by profile name.
 var DefaultProfiles = Map{
 	ProfileAOSCX: {
@@ -422,4 +430,26 @@ var DefaultProfiles = Map{
 			MkRedaction(`^([\s\t]*\S*)encrypted \S+$`, WithReplacement("${1}encrypted <secret hidden>")),
 		},
 	},
+
+	ProfileNETCONF: {
+		Name:      ProfileNETCONF,
+		Transport: TransportNETCONF,
+		Netconf:   &NetconfSettings{},
+		Redactions: []RedactionRule{
+			MkRedaction(`^(\s*<`+secretLeaves+`(?:\s[^>]*)?>)[^<]*(</)`, WithReplacement("${1}<secret hidden>${2}")),
+		},
+	},
+
+	ProfileRESTCONF: {
+		Name:      ProfileRESTCONF,
+		Transport: TransportRESTCONF,
+		Restconf: &RestconfSettings{
+			RunningPath: "/restconf/data?content=config",
+			StartupPath: "/restconf/ds/ietf-datastores:startup",
+		},
+		Redactions: []RedactionRule{
+			MkRedaction(`^(\s*"`+secretLeaves+`": )"(?:[^"\\]|\\.)*"`, WithReplacement(`${1}"<secret hidden>"`)),
+			MkRedaction(`^(\s*<`+secretLeaves+`(?:\s[^>]*)?>)[^<]*(</)`, WithReplacement("${1}<secret hidden>${2}")),
+		},
+	},
 }
@@ -12,6 +12,8 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/types"
 )
 
 func Test_DefaultProfiles_Running(t *testing.T) {
@@ -100,6 +102,18 @@ func Test_DefaultProfiles_Running(t *testing.T) {
 				Timestamp: 1491873902,
 			},
 		},
+		{
+			name:                      "NETCONF",
+			profile:                   DefaultProfile(t, ProfileNETCONF),
+			fixture:                   loadFixture(ProfileNETCONF, "running"),
+			expectedExtractedMetadata: &ExtractedMetadata{},
+		},
+		{
+			name:                      "RESTCONF",
+			profile:                   DefaultProfile(t, ProfileRESTCONF),
+			fixture:                   loadFixture(ProfileRESTCONF, "running"),
+			expectedExtractedMetadata: &ExtractedMetadata{},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -125,6 +139,15 @@ func Test_TMOSGetRunningValidator(t *testing.T) {
 	assert.Error(t, v.Validate("not a tmos config header\n"))
 }
 
+func Test_DefaultProfiles_Transport(t *testing.T) {
+	assert.Equal(t, TransportCLI, DefaultProfile(t, ProfileCiscoIOS).GetTransport())
+	assert.Equal(t, types.CLI, DefaultProfile(t, ProfileCiscoIOS).ConfigSource())
+	assert.Equal(t, TransportNETCONF, DefaultProfile(t, ProfileNETCONF).GetTransport())
+	assert.Equal(t, types.NETCONF, DefaultProfile(t, ProfileNETCONF).ConfigSource())
+	assert.Equal(t, TransportRESTCONF, DefaultProfile(t, ProfileRESTCONF).GetTransport())
+	assert.Equal(t, types.RESTCONF, DefaultProfile(t, ProfileRESTCONF).ConfigSource())
+}
+
 func Test_DefaultProfiles_Startup(t *testing.T) {
 	tests := []struct {
 		name                      string
@@ -20,6 +20,12 @@ type Map map[ProfileName]*NCMProfile
 type NCMProfile struct {
 	Name     ProfileName
 	Commands CommandSet
+	// Transport is the protocol used to retrieve configs; the Commands are run
+	// over SSH by default. The NETCONF and RESTCONF transports don't use the
+	// Commands, but the settings below.
+	Transport Transport
+	Netconf   *NetconfSettings
+	Restconf  *RestconfSettings
 	// Preprocessing is a set of "redactions" that get applied immediately. If
 	// you roll back, it will be to the version AFTER preprocessing. This is to
 	// remove things like extra trailing/leading whitespace, or text like
@@ -0,0 +1,60 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package profile
+
+import "github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/types"
+
+// Transport is the protocol used to retrieve the configs of the devices using a profile
+type Transport string
+
+const (
+	// TransportCLI runs the profile commands over SSH; it's the default
+	TransportCLI Transport = "cli"
+	// TransportNETCONF retrieves configs with NETCONF get-config RPCs over SSH
+	TransportNETCONF Transport = "netconf"
+	// TransportRESTCONF retrieves configs from RESTCONF resources over HTTPS
+	TransportRESTCONF Transport = "restconf"
+)
+
+// NetconfSettings holds the settings of the profiles using the NETCONF transport
+type NetconfSettings struct {
+	// RunningDatastore is the datastore holding the running config, defaults to "running"
+	RunningDatastore string `json:"running_datastore,omitempty"`
+	// Filter is an optional subtree filter limiting the retrieved configs
+	Filter string `json:"filter,omitempty"`
+}
+
+// RestconfSettings holds the settings of the profiles using the RESTCONF transport
+type RestconfSettings struct {
+	// RunningPath is the path of the resource holding the running config
+	RunningPath string `json:"running_path"`
+	// StartupPath is the path of the resource holding the startup config; the startup config is not retrieved
+	// when it's empty
+	StartupPath string `json:"startup_path,omitempty"`
+	// MediaType is the media type requested for the configs, either application/yang-data+json (the default) or
+	// application/yang-data+xml
+	MediaType string `json:"media_type,omitempty"`
+}
+
+// GetTransport returns the transport used by the profile
+func (p *NCMProfile) GetTransport() Transport {
+	if p.Transport == "" {
+		return TransportCLI
+	}
+	return p.Transport
+}
+
+// ConfigSource returns the source of the configs retrieved with the profile
+func (p *NCMProfile) ConfigSource() types.ConfigSource {
+	switch p.GetTransport() {
+	case TransportNETCONF:
+		return types.NETCONF
+	case TransportRESTCONF:
+		return types.RESTCONF
+	default:
+		return types.CLI
+	}
+}
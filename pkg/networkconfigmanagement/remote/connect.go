@@ -0,0 +1,39 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package remote
+
+import (
+	"fmt"
+
+	ncmconfig "github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/config"
+	"github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/profile"
+)
+
+// ConnectToDevice connects to a device with the transport of its profile.
+// Devices without a profile are connected over SSH, since only CLI profiles
+// can be matched automatically.
+func ConnectToDevice(device *ncmconfig.DeviceInstance) (Connection, error) {
+	transport := profile.TransportCLI
+	if device.Profile != "" {
+		profiles, err := profile.GetProfileMap()
+		if err != nil {
+			return nil, err
+		}
+		if prof, ok := profiles[profile.ProfileName(device.Profile)]; ok {
+			transport = prof.GetTransport()
+		}
+	}
+	switch transport {
+	case profile.TransportCLI:
+		return ConnectOverSSH(device)
+	case profile.TransportNETCONF:
+		return ConnectOverNETCONF(device)
+	case profile.TransportRESTCONF:
+		return ConnectOverRESTCONF(device)
+	default:
+		return nil, fmt.Errorf("unknown transport %q for profile %q", transport, device.Profile)
+	}
+}
@@ -100,6 +100,9 @@ type FakeSSHServer struct {
 	listener  net.Listener
 	hostKey   ssh.Signer
 	getOutput ShellFunc
+	// subsystems maps the subsystem names (e.g. "netconf") to the functions
+	// serving them
+	subsystems map[string]ShellFunc
 
 	expectedUser     string
 	expectedPassword string
@@ -122,6 +125,17 @@ func WithCredentials(user, password string) FakeServerOption {
 	}
 }
 
+// WithSubsystem serves the named subsystem with the given function; the
+// command of the ShellContext is the name of the subsystem.
+func WithSubsystem(name string, serve ShellFunc) FakeServerOption {
+	return func(s *FakeSSHServer) {
+		if s.subsystems == nil {
+			s.subsystems = make(map[string]ShellFunc)
+		}
+		s.subsystems[name] = serve
+	}
+}
+
 // StartFakeSSHServer launches an in-process SSH server on 127.0.0.1 with a
 // random port. The server is shut down via t.Cleanup, which closes the
 // listener and every accepted connection.
@@ -243,6 +257,29 @@ func (s *FakeSSHServer) handleSession(ch ssh.Channel, reqs <-chan *ssh.Request)
 			_, _ = ch.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{Status: exitStatus}))
 			return
 
+		case "subsystem":
+			// Per RFC 4254 §6.5: payload is the subsystem name as a "string".
+			var payload struct{ Name string }
+			if err := ssh.Unmarshal(req.Payload, &payload); err != nil {
+				_ = req.Reply(false, nil)
+				return
+			}
+			serve, ok := s.subsystems[payload.Name]
+			if !ok {
+				_ = req.Reply(false, nil)
+				return
+			}
+
+			s.mu.Lock()
+			s.received = append(s.received, "subsystem "+payload.Name)
+			s.mu.Unlock()
+
+			_ = req.Reply(true, nil)
+			exitStatus := serve(NewShellContext(payload.Name, ch))
+
+			_, _ = ch.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{Status: exitStatus}))
+			return
+
 		default:
 			if req.WantReply {
 				_ = req.Reply(false, nil)
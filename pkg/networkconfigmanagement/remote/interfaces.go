@@ -3,7 +3,7 @@
 // This product includes software developed at Datadog (https://www.datadoghq.com/).
 // Copyright 2025-present Datadog, Inc.
 
-// Package remote provides interfaces for remote device communications (SSH/Telnet) to retrieve configurations
+// Package remote provides interfaces for remote device communications (SSH, NETCONF, RESTCONF) to retrieve configurations
 package remote
 
 import (
@@ -0,0 +1,424 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package remote
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+
+	ncmconfig "github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/config"
+	"github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/profile"
+	"github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/types"
+)
+
+const (
+	defaultNetconfPort = "830"
+
+	netconfNamespace         = "urn:ietf:params:xml:ns:netconf:base:1.0"
+	netconfBase10            = "urn:ietf:params:netconf:base:1.0"
+	netconfBase11            = "urn:ietf:params:netconf:base:1.1"
+	netconfCapabilityStartup = "urn:ietf:params:netconf:capability:startup:1.0"
+
+	// netconfEOM ends the messages with the NETCONF 1.0 framing, and the hello
+	// messages
+	netconfEOM = "]]>]]>"
+	// maxNetconfMessageSize is the maximum size of a message received from a
+	// device, so that a broken device can't exhaust the agent memory
+	maxNetconfMessageSize = 64 << 20
+)
+
+// NETCONFConnector implements Connector using NETCONF over SSH (RFC 6242)
+type NETCONFConnector struct {
+	device *ncmconfig.DeviceInstance
+}
+
+var _ Connector = (*NETCONFConnector)(nil)
+
+// ErrStartupConfigUnsupported is returned when retrieving the startup config of
+// a device that has no startup datastore
+var ErrStartupConfigUnsupported = errors.New("startup config retrieval is not supported")
+
+// NETCONFConnection implements Connection over NETCONF; configs are retrieved
+// with get-config RPCs and normalized so that diffs are structural.
+type NETCONFConnection struct {
+	client  *ssh.Client
+	session *netconfSession
+	device  *ncmconfig.DeviceInstance
+	prof    *profile.NCMProfile
+}
+
+var _ Connection = (*NETCONFConnection)(nil)
+
+// NewNETCONFConnector creates a new NETCONF connector for the given device configuration
+func NewNETCONFConnector(device *ncmconfig.DeviceInstance) (Connector, error) {
+	if device.Auth.SSH == nil {
+		return nil, errors.New("missing ssh client config")
+	}
+	if err := ValidateSSHConfig(device.Auth.SSH); err != nil {
+		return nil, fmt.Errorf("error validating ssh client config: %w", err)
+	}
+	return &NETCONFConnector{
+		device: device,
+	}, nil
+}
+
+// ConnectOverNETCONF connects to a device with NETCONF
+func ConnectOverNETCONF(device *ncmconfig.DeviceInstance) (Connection, error) {
+	c, err := NewNETCONFConnector(device)
+	if err != nil {
+		return nil, err
+	}
+	return c.Connect()
+}
+
+// Connect establishes a NETCONF session with the device and exchanges the
+// hello messages
+func (c *NETCONFConnector) Connect() (Connection, error) {
+	auth := c.device.Auth
+	auth.Port = defaultNetconfPort
+	if auth.Netconf != nil && auth.Netconf.Port != "" {
+		auth.Port = auth.Netconf.Port
+	}
+	client, err := connectToHost(c.device.IPAddress, auth, auth.SSH)
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	if auth.SSH.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, auth.SSH.Timeout)
+		defer cancel()
+	}
+	session, err := openNetconfSession(ctx, client)
+	if err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("unable to open NETCONF session with %s: %w", c.device.IPAddress, err)
+	}
+	return &NETCONFConnection{
+		client:  client,
+		session: session,
+		device:  c.device,
+	}, nil
+}
+
+// SetProfile sets the NCM profile that tells the connection which datastores to retrieve.
+func (c *NETCONFConnection) SetProfile(profile *profile.NCMProfile) {
+	c.prof = profile
+}
+
+// Verify validates that the device supports NETCONF
+func (c *NETCONFConnection) Verify(_ context.Context) error {
+	if c.prof == nil {
+		return fmt.Errorf("no device type provided for %q", c.device.IPAddress)
+	}
+	if !c.session.hasCapability(netconfBase10) && !c.session.hasCapability(netconfBase11) {
+		return fmt.Errorf("device %q does not support the NETCONF base capability", c.device.IPAddress)
+	}
+	return nil
+}
+
+// RetrieveRunningConfig retrieves the running configuration for the device connected via NETCONF
+func (c *NETCONFConnection) RetrieveRunningConfig(ctx context.Context) (*types.CommandResult, error) {
+	settings, err := c.settings()
+	if err != nil {
+		return nil, err
+	}
+	datastore := settings.RunningDatastore
+	if datastore == "" {
+		datastore = "running"
+	}
+	return c.getConfig(ctx, datastore, settings.Filter)
+}
+
+// RetrieveStartupConfig retrieves the startup configuration for the device
+// connected via NETCONF from its startup datastore. The other datastores don't
+// hold the config the device boots with, so devices without a startup
+// datastore return ErrStartupConfigUnsupported.
+func (c *NETCONFConnection) RetrieveStartupConfig(ctx context.Context) (*types.CommandResult, error) {
+	settings, err := c.settings()
+	if err != nil {
+		return nil, err
+	}
+	if !c.session.hasCapability(netconfCapabilityStartup) {
+		return nil, fmt.Errorf("device %q has no NETCONF startup datastore: %w", c.device.IPAddress, ErrStartupConfigUnsupported)
+	}
+	return c.getConfig(ctx, "startup", settings.Filter)
+}
+
+func (c *NETCONFConnection) settings() (*profile.NetconfSettings, error) {
+	if c.prof == nil {
+		return nil, fmt.Errorf("no device type provided for %q", c.device.IPAddress)
+	}
+	if c.prof.Netconf == nil {
+		return nil, fmt.Errorf("no NETCONF settings for profile %q", c.prof.Name)
+	}
+	return c.prof.Netconf, nil
+}
+
+func (c *NETCONFConnection) getConfig(ctx context.Context, datastore string, filter string) (*types.CommandResult, error) {
+	if !slices.Contains([]string{"running", "startup", "candidate"}, datastore) {
+		return nil, fmt.Errorf("unsupported NETCONF datastore %q for profile %q", datastore, c.prof.Name)
+	}
+	operation := "<get-config><source><" + datastore + "/></source>"
+	if filter != "" {
+		operation += `<filter type="subtree">` + filter + "</filter>"
+	}
+	operation += "</get-config>"
+	result := &types.CommandResult{CommandStr: "get-config " + datastore}
+	reply, err := c.session.rpc(ctx, operation)
+	if err != nil {
+		return nil, fmt.Errorf("get-config on the %s datastore failed: %w", datastore, err)
+	}
+	data := reply.child("data")
+	if data == nil {
+		return nil, fmt.Errorf("get-config on the %s datastore failed: no data in the reply", datastore)
+	}
+	var buf bytes.Buffer
+	writeXML(&buf, data.children, 0)
+	result.Output = buf.String()
+	return result, nil
+}
+
+// PushConfig is not supported over NETCONF
+func (c *NETCONFConnection) PushConfig(_ context.Context, _ string) (*types.PushResult, types.RollbackError) {
+	return nil, types.WrapErrorf(types.ErrPushUnsupported, "pushing configs over NETCONF is not supported for %q", c.device.IPAddress)
+}
+
+// Close closes the NETCONF session and the SSH client connection
+func (c *NETCONFConnection) Close() error {
+	if c.session != nil {
+		c.session.close()
+	}
+	if c.client != nil {
+		return c.client.Close()
+	}
+	return nil
+}
+
+// netconfSession is a NETCONF session running in the netconf SSH subsystem
+type netconfSession struct {
+	session      *ssh.Session
+	stdin        io.WriteCloser
+	stdout       *bufio.Reader
+	capabilities []string
+	// chunked is true when both ends support NETCONF 1.1, which uses the
+	// chunked framing instead of the end-of-message framing
+	chunked   bool
+	messageID int
+}
+
+// openNetconfSession starts the netconf subsystem and exchanges the hello
+// messages
+func openNetconfSession(ctx context.Context, client sshClient) (*netconfSession, error) {
+	session, err := client.NewSession()
+	if err != nil {
+		return nil, err
+	}
+	s := &netconfSession{session: session}
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		s.close()
+		return nil, err
+	}
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		s.close()
+		return nil, err
+	}
+	s.stdin = stdin
+	s.stdout = bufio.NewReader(stdout)
+	if err := session.RequestSubsystem("netconf"); err != nil {
+		s.close()
+		return nil, fmt.Errorf("netconf subsystem unavailable: %w", err)
+	}
+
+	hello := `<?xml version="1.0" encoding="UTF-8"?>` +
+		`<hello xmlns="` + netconfNamespace + `"><capabilities>` +
+		`<capability>` + netconfBase10 + `</capability>` +
+		`<capability>` + netconfBase11 + `</capability>` +
+		`</capabilities></hello>`
+	reply, err := s.withContext(ctx, func() ([]byte, error) {
+		if _, err := io.WriteString(s.stdin, hello+netconfEOM); err != nil {
+			return nil, err
+		}
+		return s.readEOMMessage()
+	})
+	if err != nil {
+		s.close()
+		return nil, fmt.Errorf("hello exchange failed: %w", err)
+	}
+	root, err := parseXML(reply)
+	if err != nil {
+		s.close()
+		return nil, fmt.Errorf("invalid hello message: %w", err)
+	}
+	if root.localName() != "hello" {
+		s.close()
+		return nil, fmt.Errorf("expected a hello message, got %s", root.name)
+	}
+	if capabilities := root.child("capabilities"); capabilities != nil {
+		for _, capability := range capabilities.children {
+			s.capabilities = append(s.capabilities, strings.TrimSpace(capability.text))
+		}
+	}
+	s.chunked = s.hasCapability(netconfBase11)
+	return s, nil
+}
+
+// hasCapability returns true if the device advertised the capability,
+// regardless of its parameters
+func (s *netconfSession) hasCapability(capability string) bool {
+	return slices.ContainsFunc(s.capabilities, func(c string) bool {
+		base, _, _ := strings.Cut(c, "?")
+		return base == capability
+	})
+}
+
+// rpc sends an RPC and returns its reply, or an error if the reply holds
+// errors.
+func (s *netconfSession) rpc(ctx context.Context, operation string) (*xmlNode, error) {
+	s.messageID++
+	messageID := strconv.Itoa(s.messageID)
+	request := `<rpc message-id="` + messageID + `" xmlns="` + netconfNamespace + `">` + operation + `</rpc>`
+	data, err := s.withContext(ctx, func() ([]byte, error) {
+		if err := s.send(request); err != nil {
+			return nil, err
+		}
+		return s.receive()
+	})
+	if err != nil {
+		return nil, err
+	}
+	reply, err := parseXML(data)
+	if err != nil {
+		return nil, err
+	}
+	if reply.localName() != "rpc-reply" {
+		return nil, fmt.Errorf("expected an rpc-reply, got %s", reply.name)
+	}
+	var errs []error
+	for _, child := range reply.children {
+		if child.localName() != "rpc-error" {
+			continue
+		}
+		if severity := child.child("error-severity"); severity != nil && strings.TrimSpace(severity.text) == "warning" {
+			continue
+		}
+		message := "unknown error"
+		if msg := child.child("error-message"); msg != nil {
+			message = strings.TrimSpace(msg.text)
+		} else if tag := child.child("error-tag"); tag != nil {
+			message = strings.TrimSpace(tag.text)
+		}
+		errs = append(errs, errors.New(message))
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("rpc-error: %w", errors.Join(errs...))
+	}
+	return reply, nil
+}
+
+// withContext runs an exchange with the device, closing the session if the
+// context is done before the exchange completes.
+func (s *netconfSession) withContext(ctx context.Context, exchange func() ([]byte, error)) ([]byte, error) {
+	type result struct {
+		data []byte
+		err  error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		data, err := exchange()
+		ch <- result{data, err}
+	}()
+	select {
+	case r := <-ch:
+		return r.data, r.err
+	case <-ctx.Done():
+		// closing the session unblocks the exchange
+		s.close()
+		return nil, ctx.Err()
+	}
+}
+
+func (s *netconfSession) send(message string) error {
+	if s.chunked {
+		_, err := fmt.Fprintf(s.stdin, "\n#%d\n%s\n##\n", len(message), message)
+		return err
+	}
+	_, err := io.WriteString(s.stdin, message+netconfEOM)
+	return err
+}
+
+func (s *netconfSession) receive() ([]byte, error) {
+	if s.chunked {
+		return s.readChunkedMessage()
+	}
+	return s.readEOMMessage()
+}
+
+// readEOMMessage reads a message ending with the end-of-message marker
+func (s *netconfSession) readEOMMessage() ([]byte, error) {
+	var message []byte
+	for {
+		data, err := s.stdout.ReadBytes('>')
+		message = append(message, data...)
+		if bytes.HasSuffix(message, []byte(netconfEOM)) {
+			return message[:len(message)-len(netconfEOM)], nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(message) > maxNetconfMessageSize {
+			return nil, errors.New("message too large")
+		}
+	}
+}
+
+// readChunkedMessage reads a message with the chunked framing: each chunk is
+// "\n#<size>\n<data>", and the message ends with "\n##\n".
+func (s *netconfSession) readChunkedMessage() ([]byte, error) {
+	var message []byte
+	for {
+		if b, err := s.stdout.ReadByte(); err != nil {
+			return nil, err
+		} else if b != '\n' {
+			return nil, fmt.Errorf("invalid chunk framing: unexpected %q", b)
+		}
+		header, err := s.stdout.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		header = strings.TrimSuffix(header, "\n")
+		if header == "##" {
+			return message, nil
+		}
+		size, err := strconv.Atoi(strings.TrimPrefix(header, "#"))
+		if !strings.HasPrefix(header, "#") || err != nil || size <= 0 {
+			return nil, fmt.Errorf("invalid chunk header %q", header)
+		}
+		if len(message)+size > maxNetconfMessageSize {
+			return nil, errors.New("message too large")
+		}
+		chunk := make([]byte, size)
+		if _, err := io.ReadFull(s.stdout, chunk); err != nil {
+			return nil, err
+		}
+		message = append(message, chunk...)
+	}
+}
+
+func (s *netconfSession) close() {
+	_ = s.session.Close()
+}
@@ -0,0 +1,296 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+//go:build test
+
+package remote
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ncmconfig "github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/config"
+	"github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/profile"
+	"github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/types"
+)
+
+var (
+	netconfMessageIDRegexp = regexp.MustCompile(`message-id="(\d+)"`)
+	netconfSourceRegexp    = regexp.MustCompile(`<source><(\w+)/></source>`)
+)
+
+// fakeNetconfDevice serves the netconf subsystem: it advertises the given
+// capabilities and replies to get-config RPCs with the content of the
+// requested datastore.
+type fakeNetconfDevice struct {
+	capabilities []string
+	// replies maps the datastores to the content of the rpc-reply
+	replies map[string]string
+
+	mu       sync.Mutex
+	requests []string
+}
+
+func (d *fakeNetconfDevice) Requests() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return slices.Clone(d.requests)
+}
+
+func (d *fakeNetconfDevice) serve(shell *ShellContext) uint32 {
+	hello := `<hello xmlns="urn:ietf:params:xml:ns:netconf:base:1.0"><capabilities>`
+	for _, capability := range d.capabilities {
+		hello += "<capability>" + capability + "</capability>"
+	}
+	hello += "</capabilities><session-id>1</session-id></hello>"
+	if _, err := io.WriteString(shell.stdout, hello+netconfEOM); err != nil {
+		return 1
+	}
+	clientHello, err := readFakeEOMMessage(shell)
+	if err != nil {
+		return 1
+	}
+	chunked := strings.Contains(clientHello, netconfBase11) && slices.Contains(d.capabilities, netconfBase11)
+	for {
+		var request string
+		if chunked {
+			request, err = readFakeChunkedMessage(shell)
+		} else {
+			request, err = readFakeEOMMessage(shell)
+		}
+		if err != nil {
+			return 0
+		}
+		d.mu.Lock()
+		d.requests = append(d.requests, request)
+		d.mu.Unlock()
+
+		messageID := ""
+		if m := netconfMessageIDRegexp.FindStringSubmatch(request); m != nil {
+			messageID = m[1]
+		}
+		content := "<rpc-error><error-type>protocol</error-type><error-tag>operation-not-supported</error-tag>" +
+			"<error-severity>error</error-severity></rpc-error>"
+		if m := netconfSourceRegexp.FindStringSubmatch(request); m != nil {
+			if reply, ok := d.replies[m[1]]; ok {
+				content = reply
+			}
+		}
+		reply := `<rpc-reply message-id="` + messageID + `" xmlns="urn:ietf:params:xml:ns:netconf:base:1.0">` + content + "</rpc-reply>"
+		if chunked {
+			// split the reply in two chunks to exercise the chunked framing
+			half := len(reply) / 2
+			_, err = fmt.Fprintf(shell.stdout, "\n#%d\n%s\n#%d\n%s\n##\n", half, reply[:half], len(reply)-half, reply[half:])
+		} else {
+			_, err = io.WriteString(shell.stdout, reply+netconfEOM)
+		}
+		if err != nil {
+			return 1
+		}
+	}
+}
+
+func readFakeEOMMessage(shell *ShellContext) (string, error) {
+	var message strings.Builder
+	for !strings.HasSuffix(message.String(), netconfEOM) {
+		b, err := shell.stdin.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		message.WriteByte(b)
+	}
+	return strings.TrimSuffix(message.String(), netconfEOM), nil
+}
+
+func readFakeChunkedMessage(shell *ShellContext) (string, error) {
+	var message strings.Builder
+	for {
+		if _, err := shell.stdin.ReadByte(); err != nil {
+			return "", err
+		}
+		header, err := shell.stdin.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+		header = strings.TrimSpace(header)
+		if header == "##" {
+			return message.String(), nil
+		}
+		size, err := strconv.Atoi(strings.TrimPrefix(header, "#"))
+		if err != nil {
+			return "", err
+		}
+		chunk := make([]byte, size)
+		if _, err := io.ReadFull(shell.stdin, chunk); err != nil {
+			return "", err
+		}
+		message.Write(chunk)
+	}
+}
+
+func makeNetconfDevice(t *testing.T, device *fakeNetconfDevice) *ncmconfig.DeviceInstance {
+	t.Helper()
+	srv := StartFakeSSHServer(t, nil, WithSubsystem("netconf", device.serve))
+	dev := makeDevice(t, srv)
+	dev.Auth.Netconf = &ncmconfig.NetconfConfig{Port: srv.Port()}
+	return dev
+}
+
+const netconfRunningData = `<data>
+    <native xmlns="http://cisco.com/ns/yang/Cisco-IOS-XE-native"><hostname>Router1</hostname>
+      <!-- comment -->
+      <username><name>admin</name><secret>   hunter2 </secret></username>
+    </native>
+  </data>`
+
+const netconfRunningConfig = `<native xmlns="http://cisco.com/ns/yang/Cisco-IOS-XE-native">
+  <hostname>Router1</hostname>
+  <username>
+    <name>admin</name>
+    <secret>hunter2</secret>
+  </username>
+</native>
+`
+
+func TestNETCONFConnector(t *testing.T) {
+	fake := &fakeNetconfDevice{
+		capabilities: []string{netconfBase10, netconfBase11, netconfCapabilityStartup + "?module=startup"},
+		replies: map[string]string{
+			"running": netconfRunningData,
+			"startup": "<data><native><hostname>Router0</hostname></native></data>",
+		},
+	}
+	device := makeNetconfDevice(t, fake)
+	conn, err := ConnectOverNETCONF(device)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	t.Run("no_profile", func(t *testing.T) {
+		assert.Error(t, conn.Verify(context.Background()))
+		_, err := conn.RetrieveRunningConfig(context.Background())
+		assert.Error(t, err)
+	})
+	conn.SetProfile(&profile.NCMProfile{
+		Name:      "test-profile",
+		Transport: profile.TransportNETCONF,
+		Netconf: &profile.NetconfSettings{
+			Filter: "<native/>",
+		},
+	})
+	t.Run("verify", func(t *testing.T) {
+		assert.NoError(t, conn.Verify(context.Background()))
+	})
+	t.Run("running", func(t *testing.T) {
+		result, err := conn.RetrieveRunningConfig(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "get-config running", result.CommandStr)
+		assert.Equal(t, netconfRunningConfig, result.Output)
+	})
+	t.Run("startup", func(t *testing.T) {
+		result, err := conn.RetrieveStartupConfig(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "get-config startup", result.CommandStr)
+		assert.Equal(t, "<native>\n  <hostname>Router0</hostname>\n</native>\n", result.Output)
+	})
+	t.Run("filter", func(t *testing.T) {
+		requests := fake.Requests()
+		require.Len(t, requests, 2)
+		assert.Contains(t, requests[0], `<source><running/></source><filter type="subtree"><native/></filter>`)
+		assert.Contains(t, requests[0], `message-id="1"`)
+		assert.Contains(t, requests[1], `message-id="2"`)
+	})
+	t.Run("push", func(t *testing.T) {
+		_, rbErr := conn.PushConfig(context.Background(), "hostname Router2")
+		require.Error(t, rbErr)
+		assert.Equal(t, types.ErrPushUnsupported, rbErr.Type())
+	})
+}
+
+func TestNETCONFConnector_EOMFraming(t *testing.T) {
+	fake := &fakeNetconfDevice{
+		capabilities: []string{netconfBase10, "urn:ietf:params:netconf:capability:candidate:1.0"},
+		replies: map[string]string{
+			"running":   netconfRunningData,
+			"candidate": "<data><native><hostname>Router2</hostname></native></data>",
+		},
+	}
+	device := makeNetconfDevice(t, fake)
+	conn, err := ConnectOverNETCONF(device)
+	require.NoError(t, err)
+	defer conn.Close()
+	conn.SetProfile(&profile.NCMProfile{
+		Name:      "test-profile",
+		Transport: profile.TransportNETCONF,
+		Netconf:   &profile.NetconfSettings{},
+	})
+
+	result, err := conn.RetrieveRunningConfig(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, netconfRunningConfig, result.Output)
+	// the device has no startup datastore, and the candidate datastore isn't reported as the startup config
+	_, err = conn.RetrieveStartupConfig(context.Background())
+	assert.ErrorIs(t, err, ErrStartupConfigUnsupported)
+	assert.Len(t, fake.Requests(), 1)
+}
+
+func TestNETCONFConnector_Errors(t *testing.T) {
+	fake := &fakeNetconfDevice{
+		capabilities: []string{netconfBase10, netconfBase11},
+		replies: map[string]string{
+			"running": `<rpc-error><error-type>application</error-type><error-tag>access-denied</error-tag>` +
+				`<error-severity>error</error-severity><error-message>access denied</error-message></rpc-error>`,
+		},
+	}
+	device := makeNetconfDevice(t, fake)
+	conn, err := ConnectOverNETCONF(device)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	conn.SetProfile(&profile.NCMProfile{Name: "test-profile", Transport: profile.TransportNETCONF})
+	_, err = conn.RetrieveRunningConfig(context.Background())
+	assert.ErrorContains(t, err, `no NETCONF settings for profile "test-profile"`)
+
+	conn.SetProfile(&profile.NCMProfile{
+		Name:      "test-profile",
+		Transport: profile.TransportNETCONF,
+		Netconf:   &profile.NetconfSettings{RunningDatastore: "other"},
+	})
+	_, err = conn.RetrieveRunningConfig(context.Background())
+	assert.ErrorContains(t, err, `unsupported NETCONF datastore "other"`)
+
+	conn.SetProfile(&profile.NCMProfile{
+		Name:      "test-profile",
+		Transport: profile.TransportNETCONF,
+		Netconf:   &profile.NetconfSettings{},
+	})
+	_, err = conn.RetrieveRunningConfig(context.Background())
+	assert.ErrorContains(t, err, "rpc-error: access denied")
+	// the device has no startup datastore
+	_, err = conn.RetrieveStartupConfig(context.Background())
+	assert.ErrorIs(t, err, ErrStartupConfigUnsupported)
+}
+
+func TestNETCONFConnector_NoSubsystem(t *testing.T) {
+	srv := StartFakeSSHServer(t, nil)
+	device := makeDevice(t, srv)
+	device.Auth.Netconf = &ncmconfig.NetconfConfig{Port: srv.Port()}
+	_, err := ConnectOverNETCONF(device)
+	assert.ErrorContains(t, err, "netconf subsystem unavailable")
+}
+
+func TestNETCONFConnector_MissingSSHConfig(t *testing.T) {
+	_, err := NewNETCONFConnector(&ncmconfig.DeviceInstance{})
+	assert.ErrorContains(t, err, "missing ssh client config")
+}
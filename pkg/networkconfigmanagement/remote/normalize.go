@@ -0,0 +1,170 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package remote
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// The configs retrieved over NETCONF and RESTCONF are normalized before being
+// stored: whatever the formatting used by the device, each XML element or JSON
+// member is written on its own line with a consistent indentation, so that
+// line-based diffs between two configs only show structural changes.
+
+// xmlNode is an element of an XML document
+type xmlNode struct {
+	name     string
+	attrs    []xml.Attr
+	text     string
+	children []*xmlNode
+}
+
+// parseXML parses an XML document and returns its root element. Comments,
+// processing instructions and whitespace between elements are dropped.
+func parseXML(data []byte) (*xmlNode, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var root *xmlNode
+	var stack []*xmlNode
+	for {
+		// RawToken keeps the namespace prefixes as written by the device
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid XML: %w", err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			node := &xmlNode{name: xmlName(t.Name), attrs: slices.Clone(t.Attr)}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, node)
+			} else if root == nil {
+				root = node
+			} else {
+				return nil, errors.New("invalid XML: multiple root elements")
+			}
+			stack = append(stack, node)
+		case xml.EndElement:
+			if len(stack) == 0 || stack[len(stack)-1].name != xmlName(t.Name) {
+				return nil, fmt.Errorf("invalid XML: unexpected end element %s", xmlName(t.Name))
+			}
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text += string(t)
+			}
+		}
+	}
+	if root == nil {
+		return nil, errors.New("invalid XML: no root element")
+	}
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("invalid XML: element %s is not closed", stack[len(stack)-1].name)
+	}
+	return root, nil
+}
+
+func xmlName(name xml.Name) string {
+	if name.Space != "" {
+		return name.Space + ":" + name.Local
+	}
+	return name.Local
+}
+
+// localName returns the name of the node without its namespace prefix
+func (n *xmlNode) localName() string {
+	_, local, found := strings.Cut(n.name, ":")
+	if !found {
+		return n.name
+	}
+	return local
+}
+
+// child returns the first child with the given local name
+func (n *xmlNode) child(localName string) *xmlNode {
+	for _, child := range n.children {
+		if child.localName() == localName {
+			return child
+		}
+	}
+	return nil
+}
+
+// writeXML writes nodes with one element per line and two spaces of
+// indentation per level. Attributes are sorted and leading and trailing
+// whitespaces of texts are trimmed.
+func writeXML(buf *bytes.Buffer, nodes []*xmlNode, depth int) {
+	for _, node := range nodes {
+		indent := strings.Repeat("  ", depth)
+		buf.WriteString(indent + "<" + node.name)
+		attrs := slices.Clone(node.attrs)
+		slices.SortFunc(attrs, func(a, b xml.Attr) int {
+			return strings.Compare(xmlName(a.Name), xmlName(b.Name))
+		})
+		for _, attr := range attrs {
+			buf.WriteString(" " + xmlName(attr.Name) + `="`)
+			_ = xml.EscapeText(buf, []byte(attr.Value))
+			buf.WriteString(`"`)
+		}
+		text := strings.TrimSpace(node.text)
+		switch {
+		case len(node.children) == 0 && text == "":
+			buf.WriteString("/>\n")
+		case len(node.children) == 0:
+			buf.WriteString(">")
+			_ = xml.EscapeText(buf, []byte(text))
+			buf.WriteString("</" + node.name + ">\n")
+		default:
+			buf.WriteString(">\n")
+			if text != "" {
+				buf.WriteString(indent + "  ")
+				_ = xml.EscapeText(buf, []byte(text))
+				buf.WriteString("\n")
+			}
+			writeXML(buf, node.children, depth+1)
+			buf.WriteString(indent + "</" + node.name + ">\n")
+		}
+	}
+}
+
+// normalizeXML returns the normalized form of an XML document
+func normalizeXML(data []byte) ([]byte, error) {
+	root, err := parseXML(data)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	writeXML(&buf, []*xmlNode{root}, 0)
+	return buf.Bytes(), nil
+}
+
+// normalizeJSON returns the normalized form of a JSON document: members are
+// sorted by name and numbers are kept as written by the device.
+func normalizeJSON(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,96 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package remote
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeXML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		errMsg   string
+	}{
+		{
+			name:  "indentation",
+			input: `<?xml version="1.0"?><a:native xmlns:a="urn:a" z="1" b="2"><hostname>  R1 </hostname><empty></empty><!-- comment --><list><item>1</item><item>2</item></list></a:native>`,
+			expected: `<a:native b="2" xmlns:a="urn:a" z="1">
+  <hostname>R1</hostname>
+  <empty/>
+  <list>
+    <item>1</item>
+    <item>2</item>
+  </list>
+</a:native>
+`,
+		},
+		{
+			name:     "escaping",
+			input:    "<banner motd=\"a&amp;b\">\n  &lt;welcome&gt;\n</banner>",
+			expected: "<banner motd=\"a&amp;b\">&lt;welcome&gt;</banner>\n",
+		},
+		{
+			name: "reformatted",
+			input: `<native>
+    <hostname>R1</hostname>
+        <list>
+  <item>1</item>   <item>2</item></list>
+</native>`,
+			expected: "<native>\n  <hostname>R1</hostname>\n  <list>\n    <item>1</item>\n    <item>2</item>\n  </list>\n</native>\n",
+		},
+		{
+			name:   "unclosed",
+			input:  "<native><hostname>R1</hostname>",
+			errMsg: "invalid XML",
+		},
+		{
+			name:   "multiple_roots",
+			input:  "<a/><b/>",
+			errMsg: "multiple root elements",
+		},
+		{
+			name:   "empty",
+			input:  "",
+			errMsg: "no root element",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := normalizeXML([]byte(tt.input))
+			if tt.errMsg != "" {
+				assert.ErrorContains(t, err, tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(actual))
+		})
+	}
+}
+
+func TestNormalizeJSON(t *testing.T) {
+	actual, err := normalizeJSON([]byte(`{"b": [1, 2.50, {"d": "<x>", "c": null}], "a": 12345678901234567890}`))
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  "a": 12345678901234567890,
+  "b": [
+    1,
+    2.50,
+    {
+      "c": null,
+      "d": "<x>"
+    }
+  ]
+}
+`, string(actual))
+
+	_, err = normalizeJSON([]byte(`{"a": `))
+	assert.ErrorContains(t, err, "invalid JSON")
+}
@@ -0,0 +1,233 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package remote
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	ncmconfig "github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/config"
+	"github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/profile"
+	"github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/types"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+const (
+	defaultRestconfPort      = "443"
+	defaultRestconfTimeout   = 30 * time.Second
+	defaultRestconfMediaType = "application/yang-data+json"
+	// maxRestconfResponseSize is the maximum size of a config retrieved from a
+	// device, so that a broken device can't exhaust the agent memory
+	maxRestconfResponseSize = 64 << 20
+	// maxRestconfErrorSize is the maximum size of a RESTCONF error included in
+	// the error messages
+	maxRestconfErrorSize = 512
+)
+
+// RESTCONFConnector implements Connector using RESTCONF (RFC 8040)
+type RESTCONFConnector struct {
+	device *ncmconfig.DeviceInstance
+}
+
+var _ Connector = (*RESTCONFConnector)(nil)
+
+// RESTCONFConnection implements Connection over RESTCONF; configs are
+// retrieved from the resources set in the profile and normalized so that diffs
+// are structural.
+type RESTCONFConnection struct {
+	client  *http.Client
+	baseURL string
+	device  *ncmconfig.DeviceInstance
+	prof    *profile.NCMProfile
+}
+
+var _ Connection = (*RESTCONFConnection)(nil)
+
+// NewRESTCONFConnector creates a new RESTCONF connector for the given device configuration
+func NewRESTCONFConnector(device *ncmconfig.DeviceInstance) (Connector, error) {
+	if device.Auth.Password == "" {
+		return nil, errors.New("RESTCONF requires password authentication")
+	}
+	return &RESTCONFConnector{
+		device: device,
+	}, nil
+}
+
+// ConnectOverRESTCONF connects to a device with RESTCONF
+func ConnectOverRESTCONF(device *ncmconfig.DeviceInstance) (Connection, error) {
+	c, err := NewRESTCONFConnector(device)
+	if err != nil {
+		return nil, err
+	}
+	return c.Connect()
+}
+
+// Connect prepares the HTTPS client used to reach the device; RESTCONF is
+// stateless, so no request is sent until a config is retrieved.
+func (c *RESTCONFConnector) Connect() (Connection, error) {
+	port, timeout := defaultRestconfPort, defaultRestconfTimeout
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if rc := c.device.Auth.Restconf; rc != nil {
+		if rc.Port != "" {
+			port = rc.Port
+		}
+		if rc.Timeout > 0 {
+			timeout = time.Duration(rc.Timeout) * time.Second
+		}
+		if rc.CAFile != "" {
+			pem, err := os.ReadFile(rc.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("error reading CA file: %w", err)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificate found in CA file %s", rc.CAFile)
+			}
+			tlsConfig.RootCAs = pool
+		}
+		if rc.InsecureSkipVerify {
+			log.Warnf("RESTCONF certificate verification is disabled - connections are insecure!")
+			tlsConfig.InsecureSkipVerify = true
+		}
+	}
+	return &RESTCONFConnection{
+		client: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				TLSClientConfig: tlsConfig,
+			},
+			// the credentials must not be sent to another host
+			CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		baseURL: "https://" + net.JoinHostPort(c.device.IPAddress, port),
+		device:  c.device,
+	}, nil
+}
+
+// SetProfile sets the NCM profile that tells the connection which resources to retrieve.
+func (c *RESTCONFConnection) SetProfile(profile *profile.NCMProfile) {
+	c.prof = profile
+}
+
+// Verify validates that the device supports RESTCONF with the discovery of
+// the RESTCONF root resource
+func (c *RESTCONFConnection) Verify(ctx context.Context) error {
+	if c.prof == nil {
+		return fmt.Errorf("no device type provided for %q", c.device.IPAddress)
+	}
+	_, err := c.get(ctx, "/.well-known/host-meta", "application/xrd+xml")
+	return err
+}
+
+// RetrieveRunningConfig retrieves the running configuration for the device connected via RESTCONF
+func (c *RESTCONFConnection) RetrieveRunningConfig(ctx context.Context) (*types.CommandResult, error) {
+	settings, err := c.settings()
+	if err != nil {
+		return nil, err
+	}
+	if settings.RunningPath == "" {
+		return nil, fmt.Errorf("no running config path for profile %q", c.prof.Name)
+	}
+	return c.getConfig(ctx, settings.RunningPath, settings.MediaType)
+}
+
+// RetrieveStartupConfig retrieves the startup configuration for the device connected via RESTCONF
+func (c *RESTCONFConnection) RetrieveStartupConfig(ctx context.Context) (*types.CommandResult, error) {
+	settings, err := c.settings()
+	if err != nil {
+		return nil, err
+	}
+	if settings.StartupPath == "" {
+		return nil, fmt.Errorf("no startup config path for profile %q", c.prof.Name)
+	}
+	return c.getConfig(ctx, settings.StartupPath, settings.MediaType)
+}
+
+func (c *RESTCONFConnection) settings() (*profile.RestconfSettings, error) {
+	if c.prof == nil {
+		return nil, fmt.Errorf("no device type provided for %q", c.device.IPAddress)
+	}
+	if c.prof.Restconf == nil {
+		return nil, fmt.Errorf("no RESTCONF settings for profile %q", c.prof.Name)
+	}
+	return c.prof.Restconf, nil
+}
+
+func (c *RESTCONFConnection) getConfig(ctx context.Context, path string, mediaType string) (*types.CommandResult, error) {
+	if mediaType == "" {
+		mediaType = defaultRestconfMediaType
+	}
+	resp, err := c.get(ctx, path, mediaType)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxRestconfResponseSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("GET %s failed: %w", path, err)
+	}
+	if len(body) > maxRestconfResponseSize {
+		return nil, fmt.Errorf("GET %s failed: response too large", path)
+	}
+	output := body
+	contentType := resp.Header.Get("Content-Type")
+	switch {
+	case strings.Contains(contentType, "json"):
+		output, err = normalizeJSON(body)
+	case strings.Contains(contentType, "xml"):
+		output, err = normalizeXML(body)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("GET %s returned an invalid config: %w", path, err)
+	}
+	return &types.CommandResult{
+		CommandStr: "GET " + path,
+		Output:     string(output),
+	}, nil
+}
+
+// get sends a GET request to the device. The body of the response must be
+// closed if no error is returned.
+func (c *RESTCONFConnection) get(ctx context.Context, path string, mediaType string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(c.device.Auth.Username, c.device.Auth.Password)
+	req.Header.Set("Accept", mediaType)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("GET %s failed: %w", path, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		// RESTCONF errors hold the reason of the failure
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxRestconfErrorSize))
+		return nil, fmt.Errorf("GET %s failed: %s: %s", path, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return resp, nil
+}
+
+// PushConfig is not supported over RESTCONF
+func (c *RESTCONFConnection) PushConfig(_ context.Context, _ string) (*types.PushResult, types.RollbackError) {
+	return nil, types.WrapErrorf(types.ErrPushUnsupported, "pushing configs over RESTCONF is not supported for %q", c.device.IPAddress)
+}
+
+// Close closes the idle HTTP connections to the device
+func (c *RESTCONFConnection) Close() error {
+	c.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,217 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package remote
+
+import (
+	"context"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ncmconfig "github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/config"
+	"github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/profile"
+	"github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/types"
+)
+
+// startFakeRestconfServer starts a RESTCONF server replying to the given
+// paths, and returns a device configured to reach it.
+func startFakeRestconfServer(t *testing.T, contentType string, responses map[string]string) *ncmconfig.DeviceInstance {
+	t.Helper()
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		if !ok || user != "test" || password != "hunter2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/.well-known/host-meta" {
+			_, _ = w.Write([]byte(`<XRD xmlns="http://docs.oasis-open.org/ns/xri/xrd-1.0"><Link rel="restconf" href="/restconf"/></XRD>`))
+			return
+		}
+		response, ok := responses[r.URL.RequestURI()]
+		if !ok {
+			w.Header().Set("Content-Type", contentType)
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"ietf-restconf:errors": {"error": [{"error-tag": "invalid-value"}]}}`))
+			return
+		}
+		if r.Header.Get("Accept") != contentType {
+			w.WriteHeader(http.StatusNotAcceptable)
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(srv.Close)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caFile, caPEM, 0600))
+
+	host, port, err := net.SplitHostPort(srv.Listener.Addr().String())
+	require.NoError(t, err)
+	return &ncmconfig.DeviceInstance{
+		IPAddress: host,
+		Auth: ncmconfig.AuthCredentials{
+			Username: "test",
+			Password: "hunter2",
+			Restconf: &ncmconfig.RestconfConfig{
+				Port:    port,
+				Timeout: 5,
+				CAFile:  caFile,
+			},
+		},
+	}
+}
+
+func TestRESTCONFConnector(t *testing.T) {
+	device := startFakeRestconfServer(t, "application/yang-data+json", map[string]string{
+		"/restconf/data?content=config":        `{"Cisco-IOS-XE-native:native": {"version": "17.3", "hostname": "Router1", "ip": {"mtu": 1500}}}`,
+		"/restconf/ds/ietf-datastores:startup": `{"Cisco-IOS-XE-native:native": {"hostname": "Router0"}}`,
+	})
+	conn, err := ConnectOverRESTCONF(device)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	t.Run("no_profile", func(t *testing.T) {
+		assert.Error(t, conn.Verify(context.Background()))
+		_, err := conn.RetrieveRunningConfig(context.Background())
+		assert.Error(t, err)
+	})
+	conn.SetProfile(&profile.NCMProfile{
+		Name:      "test-profile",
+		Transport: profile.TransportRESTCONF,
+		Restconf: &profile.RestconfSettings{
+			RunningPath: "/restconf/data?content=config",
+			StartupPath: "/restconf/ds/ietf-datastores:startup",
+		},
+	})
+	t.Run("verify", func(t *testing.T) {
+		assert.NoError(t, conn.Verify(context.Background()))
+	})
+	t.Run("running", func(t *testing.T) {
+		result, err := conn.RetrieveRunningConfig(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "GET /restconf/data?content=config", result.CommandStr)
+		assert.Equal(t, `{
+  "Cisco-IOS-XE-native:native": {
+    "hostname": "Router1",
+    "ip": {
+      "mtu": 1500
+    },
+    "version": "17.3"
+  }
+}
+`, result.Output)
+	})
+	t.Run("startup", func(t *testing.T) {
+		result, err := conn.RetrieveStartupConfig(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "GET /restconf/ds/ietf-datastores:startup", result.CommandStr)
+		assert.Equal(t, "{\n  \"Cisco-IOS-XE-native:native\": {\n    \"hostname\": \"Router0\"\n  }\n}\n", result.Output)
+	})
+	t.Run("push", func(t *testing.T) {
+		_, rbErr := conn.PushConfig(context.Background(), "{}")
+		require.Error(t, rbErr)
+		assert.Equal(t, types.ErrPushUnsupported, rbErr.Type())
+	})
+}
+
+func TestRESTCONFConnector_XML(t *testing.T) {
+	device := startFakeRestconfServer(t, "application/yang-data+xml", map[string]string{
+		"/restconf/data/native": `<native xmlns="http://cisco.com/ns/yang/Cisco-IOS-XE-native"><hostname>Router1</hostname></native>`,
+	})
+	conn, err := ConnectOverRESTCONF(device)
+	require.NoError(t, err)
+	defer conn.Close()
+	conn.SetProfile(&profile.NCMProfile{
+		Name:      "test-profile",
+		Transport: profile.TransportRESTCONF,
+		Restconf: &profile.RestconfSettings{
+			RunningPath: "/restconf/data/native",
+			MediaType:   "application/yang-data+xml",
+		},
+	})
+
+	result, err := conn.RetrieveRunningConfig(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "<native xmlns=\"http://cisco.com/ns/yang/Cisco-IOS-XE-native\">\n  <hostname>Router1</hostname>\n</native>\n", result.Output)
+	_, err = conn.RetrieveStartupConfig(context.Background())
+	assert.ErrorContains(t, err, `no startup config path for profile "test-profile"`)
+}
+
+func TestRESTCONFConnector_Errors(t *testing.T) {
+	device := startFakeRestconfServer(t, "application/yang-data+json", map[string]string{
+		"/restconf/data/invalid": `{"native": `,
+	})
+	prof := &profile.NCMProfile{
+		Name:      "test-profile",
+		Transport: profile.TransportRESTCONF,
+		Restconf: &profile.RestconfSettings{
+			RunningPath: "/restconf/data/missing",
+			StartupPath: "/restconf/data/invalid",
+		},
+	}
+
+	t.Run("status", func(t *testing.T) {
+		conn, err := ConnectOverRESTCONF(device)
+		require.NoError(t, err)
+		conn.SetProfile(prof)
+		_, err = conn.RetrieveRunningConfig(context.Background())
+		assert.ErrorContains(t, err, "GET /restconf/data/missing failed: 404 Not Found: ")
+		assert.ErrorContains(t, err, "invalid-value")
+	})
+	t.Run("invalid_config", func(t *testing.T) {
+		conn, err := ConnectOverRESTCONF(device)
+		require.NoError(t, err)
+		conn.SetProfile(prof)
+		_, err = conn.RetrieveStartupConfig(context.Background())
+		assert.ErrorContains(t, err, "GET /restconf/data/invalid returned an invalid config")
+	})
+	t.Run("credentials", func(t *testing.T) {
+		wrong := *device
+		wrong.Auth.Password = "wrong"
+		conn, err := ConnectOverRESTCONF(&wrong)
+		require.NoError(t, err)
+		conn.SetProfile(prof)
+		assert.ErrorContains(t, conn.Verify(context.Background()), "401 Unauthorized")
+	})
+	t.Run("untrusted_certificate", func(t *testing.T) {
+		untrusted := *device
+		restconf := *device.Auth.Restconf
+		restconf.CAFile = ""
+		untrusted.Auth.Restconf = &restconf
+		conn, err := ConnectOverRESTCONF(&untrusted)
+		require.NoError(t, err)
+		conn.SetProfile(prof)
+		assert.ErrorContains(t, conn.Verify(context.Background()), "certificate")
+
+		restconf.InsecureSkipVerify = true
+		conn, err = ConnectOverRESTCONF(&untrusted)
+		require.NoError(t, err)
+		conn.SetProfile(prof)
+		assert.NoError(t, conn.Verify(context.Background()))
+	})
+	t.Run("missing_password", func(t *testing.T) {
+		_, err := NewRESTCONFConnector(&ncmconfig.DeviceInstance{})
+		assert.ErrorContains(t, err, "RESTCONF requires password authentication")
+	})
+	t.Run("missing_ca_file", func(t *testing.T) {
+		_, err := ConnectOverRESTCONF(&ncmconfig.DeviceInstance{
+			Auth: ncmconfig.AuthCredentials{
+				Password: "hunter2",
+				Restconf: &ncmconfig.RestconfConfig{CAFile: filepath.Join(t.TempDir(), "missing.pem")},
+			},
+		})
+		assert.ErrorContains(t, err, "error reading CA file")
+	})
+}
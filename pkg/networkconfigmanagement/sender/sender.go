@@ -9,12 +9,15 @@ package sender
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/benbjohnson/clock"
 
 	eventplatform "github.com/DataDog/datadog-agent/comp/forwarder/eventplatform/def"
 	"github.com/DataDog/datadog-agent/pkg/aggregator/sender"
+	"github.com/DataDog/datadog-agent/pkg/metrics/event"
+	"github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/compliance"
 	"github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/profile"
 	ncmreport "github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/report"
 	"github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/types"
@@ -29,6 +32,13 @@ const (
 	ncmCheckFailureMetric              = "datadog.ncm.check_failure"
 	ncmCheckInventoryEntriesSentMetric = "datadog.ncm.inventory.entries_sent"
 	ncmConfigSizeMetric                = "ncm.config_size"
+	ncmComplianceViolationsMetric      = "ncm.compliance.violations"
+	ncmConfigDriftMetric               = "ncm.config.drift"
+	ncmConfigDriftLinesMetric          = "ncm.config.drift.lines"
+
+	ncmComplianceEventType = "ncm_compliance"
+	// maxEventLines is the maximum number of findings or drifted lines listed in an event
+	maxEventLines = 10
 
 	ncmRunningConfigTypeTag = "config_type:running"
 	ncmStartupConfigTypeTag = "config_type:startup"
@@ -149,6 +159,103 @@ func (s *NCMSender) SendDeviceMetadata(deviceID string, deviceIP string) error {
 	return nil
 }
 
+// SendComplianceMetrics sends the number of violations of each compliance rule evaluated on the device configs
+func (s *NCMSender) SendComplianceMetrics(results []compliance.RuleResult) {
+	for _, result := range results {
+		tags := append(s.getDeviceTags(), "rule:"+result.Rule, "severity:"+string(result.Severity), "config_type:"+string(result.ConfigType))
+		s.Sender.Gauge(ncmComplianceViolationsMetric, float64(len(result.Findings)), s.agentHostname, tags)
+	}
+}
+
+// SendDriftMetrics sends whether the running config drifted from the startup config, and by how many lines
+func (s *NCMSender) SendDriftMetrics(drift compliance.Drift) {
+	tags := s.getDeviceTags()
+	s.Sender.Gauge(ncmConfigDriftMetric, boolToFloat64(drift.HasDrift()), s.agentHostname, tags)
+	s.Sender.Gauge(ncmConfigDriftLinesMetric, float64(len(drift.Added)), s.agentHostname, append(s.getDeviceTags(), "change:added"))
+	s.Sender.Gauge(ncmConfigDriftLinesMetric, float64(len(drift.Removed)), s.agentHostname, append(s.getDeviceTags(), "change:removed"))
+}
+
+// SendComplianceEvent sends an event when a device starts violating a compliance rule or drifting from its startup
+// config, and when it's resolved
+func (s *NCMSender) SendComplianceEvent(deviceID string, transition compliance.Transition) {
+	tags := s.getDeviceTags()
+	var title string
+	var text strings.Builder
+	alertType := event.AlertTypeSuccess
+	if transition.IsDrift() {
+		if transition.Violated {
+			title = "Running config of " + deviceID + " differs from its startup config"
+			alertType = event.AlertTypeWarning
+			writeEventLines(&text, "Lines missing from the startup config:", transition.Drift.Added)
+			writeEventLines(&text, "Lines missing from the running config:", transition.Drift.Removed)
+		} else {
+			title = "Running config of " + deviceID + " matches its startup config"
+		}
+	} else {
+		tags = append(tags, "rule:"+transition.Rule, "severity:"+string(transition.Severity), "config_type:"+string(transition.ConfigType))
+		if transition.Violated {
+			title = fmt.Sprintf("Compliance rule %s violated by the %s config of %s", transition.Rule, transition.ConfigType, deviceID)
+			alertType = severityAlertType(transition.Severity)
+			messages := make([]string, 0, len(transition.Findings))
+			for _, finding := range transition.Findings {
+				if finding.Section != "" {
+					messages = append(messages, finding.Section+": "+finding.Message)
+				} else {
+					messages = append(messages, finding.Message)
+				}
+			}
+			writeEventLines(&text, "Findings:", messages)
+		} else {
+			title = fmt.Sprintf("Compliance rule %s resolved on the %s config of %s", transition.Rule, transition.ConfigType, deviceID)
+		}
+	}
+
+	s.Sender.Event(event.Event{
+		Title:          title,
+		Text:           text.String(),
+		Ts:             s.clock.Now().Unix(),
+		Priority:       event.PriorityNormal,
+		Host:           s.agentHostname,
+		Tags:           tags,
+		AlertType:      alertType,
+		AggregationKey: deviceID,
+		SourceTypeName: "network_config_management",
+		EventType:      ncmComplianceEventType,
+	})
+}
+
+func writeEventLines(text *strings.Builder, header string, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	text.WriteString(header + "\n")
+	for i, line := range lines {
+		if i == maxEventLines {
+			fmt.Fprintf(text, "... and %d more\n", len(lines)-maxEventLines)
+			break
+		}
+		text.WriteString("- " + line + "\n")
+	}
+}
+
+func severityAlertType(severity compliance.Severity) event.AlertType {
+	switch severity {
+	case compliance.SeverityCritical:
+		return event.AlertTypeError
+	case compliance.SeverityInfo:
+		return event.AlertTypeInfo
+	default:
+		return event.AlertTypeWarning
+	}
+}
+
+func boolToFloat64(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 // Commit commits the sender (important to ensure data is flushed/sent
 func (s *NCMSender) Commit() {
 	s.Sender.Commit()
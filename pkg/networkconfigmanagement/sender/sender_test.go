@@ -10,6 +10,7 @@ package sender
 import (
 	"bytes"
 	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
@@ -24,6 +25,8 @@ import (
 
 	eventplatform "github.com/DataDog/datadog-agent/comp/forwarder/eventplatform/def"
 	"github.com/DataDog/datadog-agent/pkg/aggregator/mocksender"
+	"github.com/DataDog/datadog-agent/pkg/metrics/event"
+	"github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/compliance"
 	ncmreport "github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/report"
 	"github.com/DataDog/datadog-agent/pkg/networkconfigmanagement/types"
 )
@@ -310,3 +313,115 @@ func TestNCMSender_SendNCMInventory_Success(t *testing.T) {
 	mockSender.AssertMetric(t, "Count", ncmCheckInventoryEntriesSentMetric, 1, "test-agent-host", []string{"agent_version:" + version.AgentVersion})
 	mockSender.AssertExpectations(t)
 }
+
+func TestNCMSender_SendComplianceMetrics(t *testing.T) {
+	mockSender := mocksender.NewMockSender(t, "test")
+	mockSender.SetupAcceptAll()
+	ncmSender := NewNCMSender(mockSender, "default", clock.NewMock(), "test-agent-host")
+	ncmSender.SetDeviceTags([]string{"device_id:default:10.0.0.1"})
+
+	ncmSender.SendComplianceMetrics([]compliance.RuleResult{
+		{Rule: "password-encryption", Severity: compliance.SeverityWarning, ConfigType: types.RUNNING},
+		{Rule: "no-default-community", Severity: compliance.SeverityCritical, ConfigType: types.STARTUP, Findings: []compliance.Finding{{Message: "a"}, {Message: "b"}}},
+	})
+	ncmSender.SendDriftMetrics(compliance.Drift{Added: []string{"logging host 10.0.0.5", "ntp server 10.0.0.1"}, Removed: []string{"ntp server 10.0.0.2"}})
+
+	mockSender.AssertMetric(t, "Gauge", ncmComplianceViolationsMetric, 0, "test-agent-host", []string{"device_id:default:10.0.0.1", "rule:password-encryption", "severity:warning", "config_type:running"})
+	mockSender.AssertMetric(t, "Gauge", ncmComplianceViolationsMetric, 2, "test-agent-host", []string{"device_id:default:10.0.0.1", "rule:no-default-community", "severity:critical", "config_type:startup"})
+	mockSender.AssertMetric(t, "Gauge", ncmConfigDriftMetric, 1, "test-agent-host", []string{"device_id:default:10.0.0.1"})
+	mockSender.AssertMetric(t, "Gauge", ncmConfigDriftLinesMetric, 2, "test-agent-host", []string{"device_id:default:10.0.0.1", "change:added"})
+	mockSender.AssertMetric(t, "Gauge", ncmConfigDriftLinesMetric, 1, "test-agent-host", []string{"device_id:default:10.0.0.1", "change:removed"})
+}
+
+func TestNCMSender_SendComplianceEvent(t *testing.T) {
+	tests := []struct {
+		name       string
+		transition compliance.Transition
+		expected   event.Event
+	}{
+		{
+			name: "rule violated",
+			transition: compliance.Transition{
+				Rule:       "no-telnet",
+				Severity:   compliance.SeverityCritical,
+				ConfigType: types.RUNNING,
+				Violated:   true,
+				Findings: []compliance.Finding{
+					{Section: "line vty 0 4", Message: `line "transport input telnet" matches forbidden pattern "telnet"`},
+				},
+			},
+			expected: event.Event{
+				Title:     "Compliance rule no-telnet violated by the running config of default:10.0.0.1",
+				Text:      "Findings:\n- line vty 0 4: line \"transport input telnet\" matches forbidden pattern \"telnet\"\n",
+				AlertType: event.AlertTypeError,
+				Tags:      []string{"device_id:default:10.0.0.1", "rule:no-telnet", "severity:critical", "config_type:running"},
+			},
+		},
+		{
+			name:       "rule resolved",
+			transition: compliance.Transition{Rule: "no-telnet", Severity: compliance.SeverityInfo, ConfigType: types.STARTUP},
+			expected: event.Event{
+				Title:     "Compliance rule no-telnet resolved on the startup config of default:10.0.0.1",
+				AlertType: event.AlertTypeSuccess,
+				Tags:      []string{"device_id:default:10.0.0.1", "rule:no-telnet", "severity:info", "config_type:startup"},
+			},
+		},
+		{
+			name: "drift",
+			transition: compliance.Transition{
+				Severity:   compliance.SeverityWarning,
+				ConfigType: types.RUNNING,
+				Violated:   true,
+				Drift:      compliance.Drift{Added: []string{"logging host 10.0.0.5"}, Removed: []string{"ntp server 10.0.0.2"}},
+			},
+			expected: event.Event{
+				Title:     "Running config of default:10.0.0.1 differs from its startup config",
+				Text:      "Lines missing from the startup config:\n- logging host 10.0.0.5\nLines missing from the running config:\n- ntp server 10.0.0.2\n",
+				AlertType: event.AlertTypeWarning,
+				Tags:      []string{"device_id:default:10.0.0.1"},
+			},
+		},
+		{
+			name:       "drift resolved",
+			transition: compliance.Transition{Severity: compliance.SeverityWarning, ConfigType: types.RUNNING},
+			expected: event.Event{
+				Title:     "Running config of default:10.0.0.1 matches its startup config",
+				AlertType: event.AlertTypeSuccess,
+				Tags:      []string{"device_id:default:10.0.0.1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSender := mocksender.NewMockSender(t, "test")
+			mockSender.SetupAcceptAll()
+			mockClock := clock.NewMock()
+			mockClock.Set(time.Date(2025, 8, 1, 10, 20, 0, 0, time.UTC))
+			ncmSender := NewNCMSender(mockSender, "default", mockClock, "test-agent-host")
+			ncmSender.SetDeviceTags([]string{"device_id:default:10.0.0.1"})
+
+			ncmSender.SendComplianceEvent("default:10.0.0.1", tt.transition)
+
+			expected := tt.expected
+			expected.Ts = mockClock.Now().Unix()
+			expected.Priority = event.PriorityNormal
+			expected.Host = "test-agent-host"
+			expected.AggregationKey = "default:10.0.0.1"
+			expected.SourceTypeName = "network_config_management"
+			expected.EventType = ncmComplianceEventType
+			mockSender.AssertEventWithCompareFunc(t, expected, 0, func(expected, actual event.Event) bool {
+				return expected.Title == actual.Title && expected.Text == actual.Text && expected.AlertType == actual.AlertType
+			})
+		})
+	}
+}
+
+func TestWriteEventLines_Truncated(t *testing.T) {
+	lines := make([]string, maxEventLines+3)
+	for i := range lines {
+		lines[i] = "line"
+	}
+	var text strings.Builder
+	writeEventLines(&text, "Lines:", lines)
+	assert.Equal(t, "Lines:\n"+strings.Repeat("- line\n", maxEventLines)+"... and 3 more\n", text.String())
+}
@@ -18,12 +18,16 @@ const (
 	STARTUP ConfigType = "startup"
 )
 
-// ConfigSource represents where the config was retrieved from (in the case of the integration, it's always via CLI commands)
+// ConfigSource represents where the config was retrieved from
 type ConfigSource string
 
 const (
-	// CLI represents the source the config was retrieved
+	// CLI represents configs retrieved via CLI commands
 	CLI ConfigSource = "cli"
+	// NETCONF represents configs retrieved via NETCONF get-config RPCs
+	NETCONF ConfigSource = "netconf"
+	// RESTCONF represents configs retrieved from RESTCONF resources
+	RESTCONF ConfigSource = "restconf"
 )
 
 // ConfigMetadata holds the metadata for configs - used to help validate rollbacks and its underlying functions
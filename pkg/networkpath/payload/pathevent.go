@@ -25,6 +25,9 @@ const (
 	ProtocolUDP Protocol = "UDP"
 	// ProtocolICMP is the ICMP protocol.
 	ProtocolICMP Protocol = "ICMP"
+	// ProtocolDNS is a UDP traceroute to the DNS port, it isn't an IANA
+	// protocol but lets tests follow the path taken by DNS queries.
+	ProtocolDNS Protocol = "DNS"
 )
 
 // TCPMethod is the method used to run a TCP traceroute.
@@ -221,6 +224,18 @@ type HopCountStats struct {
 type Traceroute struct {
 	Runs     []TracerouteRun `json:"runs"`
 	HopCount HopCountStats   `json:"hop_count"`
+	// Graph merges the runs of a multipath traceroute, it is only set when
+	// several flows were traced
+	Graph *PathGraph `json:"graph,omitempty"`
+	// FailedFlows are the flows of a multipath traceroute that failed, their
+	// runs are missing from Runs and Graph
+	FailedFlows []TracerouteFlowError `json:"failed_flows,omitempty"`
+}
+
+// TracerouteFlowError is a flow of a multipath traceroute that failed
+type TracerouteFlowError struct {
+	Flow  int    `json:"flow"`
+	Error string `json:"error"`
 }
 
 // TracerouteRun contains traceroute results for a single run
@@ -229,6 +244,60 @@ type TracerouteRun struct {
 	Source      TracerouteSource      `json:"source"`
 	Destination TracerouteDestination `json:"destination"`
 	Hops        []TracerouteHop       `json:"hops"`
+	// Flow is the flow of a multipath traceroute traced by the run, each flow
+	// has as many runs as traceroute queries
+	Flow int `json:"flow,omitempty"`
+}
+
+// PathGraph merges the runs of a multipath traceroute. Each run traces a flow
+// with different flow identifiers, so that routers doing ECMP load balancing
+// send the flows on different paths.
+type PathGraph struct {
+	Flows int            `json:"flows"`
+	Nodes []PathNode     `json:"nodes"`
+	Edges []PathEdge     `json:"edges"`
+	Hops  []PathHopStats `json:"hops"`
+}
+
+// PathNode is a hop of the path graph. The hops that didn't reply are merged
+// per TTL and previous node, so that each branch keeps its own unknown hops.
+type PathNode struct {
+	ID         int      `json:"id"`
+	TTL        int      `json:"ttl"`
+	IPAddress  net.IP   `json:"ip_address,omitempty"`
+	ReverseDNS []string `json:"reverse_dns,omitempty"`
+	Reachable  bool     `json:"reachable"`
+	// Flows is the number of flows through the node
+	Flows int `json:"flows"`
+	// PacketLossPercentage is the percentage of the probes whose next hop
+	// didn't reply, averaged over the flows through the node
+	PacketLossPercentage float32     `json:"packet_loss_percentage"`
+	RTT                  *HopLatency `json:"rtt,omitempty"`
+}
+
+// PathEdge links two nodes of the path graph
+type PathEdge struct {
+	From  int `json:"from"`
+	To    int `json:"to"`
+	Flows int `json:"flows"`
+}
+
+// PathHopStats contains the replies to the probes of a TTL across all flows
+type PathHopStats struct {
+	TTL                  int         `json:"ttl"`
+	PacketsSent          int         `json:"packets_sent"`
+	PacketsReceived      int         `json:"packets_received"`
+	PacketLossPercentage float32     `json:"packet_loss_percentage"`
+	RTT                  *HopLatency `json:"rtt,omitempty"`
+}
+
+// HopLatency contains the distribution of the RTTs of a hop
+type HopLatency struct {
+	Avg float64 `json:"avg"`
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+	P50 float64 `json:"p50"`
+	P95 float64 `json:"p95"`
 }
 
 // TracerouteHop encapsulates information about a single
@@ -43,6 +43,10 @@ type Config struct {
 	TracerouteQueries int
 	// E2eQueries is the number of end-to-end queries to perform
 	E2eQueries int
+	// MultipathFlows is the number of flows traced to discover the ECMP
+	// paths to the destination, each flow uses different flow identifiers.
+	// A single flow is traced when it is 0 or 1.
+	MultipathFlows int
 	// DisableWindowsDriver disables the use of Windows driver for traceroute
 	DisableWindowsDriver bool
 	// DisableSourcePublicIPCollection disables collection of the source public IP address
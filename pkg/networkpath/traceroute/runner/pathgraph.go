@@ -0,0 +1,162 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package runner
+
+import (
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+
+	"github.com/DataDog/datadog-agent/pkg/networkpath/payload"
+)
+
+// graphNode accumulates the replies of a node of the path graph
+type graphNode struct {
+	node  payload.PathNode
+	rtts  []float64
+	flows map[int]struct{}
+	// nextHops and nextHopsLost count, per flow through the node, the runs
+	// that have a next hop, and those whose next hop didn't reply
+	nextHops     map[int]int
+	nextHopsLost map[int]int
+}
+
+type edgeKey struct {
+	from, to int
+}
+
+// buildPathGraph merges the runs of the flows of a multipath traceroute into
+// a graph of the hops, with the loss and latency of each hop across flows.
+// The flows are counted once however many traceroute queries they ran.
+func buildPathGraph(runs []payload.TracerouteRun) *payload.PathGraph {
+	var nodes []*graphNode
+	nodeIDs := make(map[string]int)
+	edges := make(map[edgeKey]map[int]struct{})
+	var edgeOrder []edgeKey
+	ttlRTTs := make(map[int][]float64)
+	ttlSent := make(map[int]int)
+	flows := make(map[int]struct{})
+
+	for _, run := range runs {
+		flows[run.Flow] = struct{}{}
+		hops := slices.Clone(run.Hops)
+		slices.SortStableFunc(hops, func(a, b payload.TracerouteHop) int { return a.TTL - b.TTL })
+
+		previous := -1
+		for _, hop := range hops {
+			ttlSent[hop.TTL]++
+			// unknown hops are identified by their previous node to keep the branches apart
+			key := strconv.Itoa(hop.TTL) + "|" + strconv.Itoa(previous) + "|*"
+			if hop.Reachable {
+				key = strconv.Itoa(hop.TTL) + "|" + hop.IPAddress.String()
+				ttlRTTs[hop.TTL] = append(ttlRTTs[hop.TTL], hop.RTT)
+			}
+			id, ok := nodeIDs[key]
+			if !ok {
+				id = len(nodes)
+				nodeIDs[key] = id
+				node := payload.PathNode{ID: id, TTL: hop.TTL, Reachable: hop.Reachable}
+				if hop.Reachable {
+					node.IPAddress = hop.IPAddress
+					node.ReverseDNS = hop.ReverseDNS
+				}
+				nodes = append(nodes, &graphNode{
+					node:         node,
+					flows:        make(map[int]struct{}),
+					nextHops:     make(map[int]int),
+					nextHopsLost: make(map[int]int),
+				})
+			}
+			current := nodes[id]
+			current.flows[run.Flow] = struct{}{}
+			if hop.Reachable {
+				current.rtts = append(current.rtts, hop.RTT)
+			}
+
+			if previous >= 0 {
+				nodes[previous].nextHops[run.Flow]++
+				if !hop.Reachable {
+					nodes[previous].nextHopsLost[run.Flow]++
+				}
+				edge := edgeKey{from: previous, to: id}
+				if _, ok := edges[edge]; !ok {
+					edgeOrder = append(edgeOrder, edge)
+					edges[edge] = make(map[int]struct{})
+				}
+				edges[edge][run.Flow] = struct{}{}
+			}
+			previous = id
+		}
+	}
+
+	graph := &payload.PathGraph{Flows: len(flows)}
+	for _, n := range nodes {
+		n.node.Flows = len(n.flows)
+		n.node.PacketLossPercentage = flowsLoss(n.nextHops, n.nextHopsLost)
+		n.node.RTT = hopLatency(n.rtts)
+		graph.Nodes = append(graph.Nodes, n.node)
+	}
+	for _, edge := range edgeOrder {
+		graph.Edges = append(graph.Edges, payload.PathEdge{From: edge.from, To: edge.to, Flows: len(edges[edge])})
+	}
+
+	ttls := make([]int, 0, len(ttlSent))
+	for ttl := range ttlSent {
+		ttls = append(ttls, ttl)
+	}
+	slices.Sort(ttls)
+	for _, ttl := range ttls {
+		sent, received := ttlSent[ttl], len(ttlRTTs[ttl])
+		graph.Hops = append(graph.Hops, payload.PathHopStats{
+			TTL:                  ttl,
+			PacketsSent:          sent,
+			PacketsReceived:      received,
+			PacketLossPercentage: float32(sent-received) / float32(sent) * 100,
+			RTT:                  hopLatency(ttlRTTs[ttl]),
+		})
+	}
+	return graph
+}
+
+// flowsLoss returns the percentage of the probes that were lost, averaged over
+// the flows so that each flow weighs the same whatever its number of probes
+func flowsLoss(sent map[int]int, lost map[int]int) float32 {
+	if len(sent) == 0 {
+		return 0
+	}
+	var loss float32
+	for _, flow := range slices.Sorted(maps.Keys(sent)) {
+		loss += float32(lost[flow]) / float32(sent[flow])
+	}
+	return loss / float32(len(sent)) * 100
+}
+
+// hopLatency returns the distribution of the RTTs, or nil if there are none
+func hopLatency(rtts []float64) *payload.HopLatency {
+	if len(rtts) == 0 {
+		return nil
+	}
+	sorted := slices.Clone(rtts)
+	slices.Sort(sorted)
+	var sum float64
+	for _, rtt := range sorted {
+		sum += rtt
+	}
+	return &payload.HopLatency{
+		Avg: sum / float64(len(sorted)),
+		Min: sorted[0],
+		Max: sorted[len(sorted)-1],
+		P50: percentile(sorted, 50),
+		P95: percentile(sorted, 95),
+	}
+}
+
+// percentile returns the nearest-rank percentile of sorted values
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
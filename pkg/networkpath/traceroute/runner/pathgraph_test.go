@@ -0,0 +1,157 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package runner
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/networkpath/payload"
+)
+
+func makeRun(hops ...string) payload.TracerouteRun {
+	run := payload.TracerouteRun{Destination: payload.TracerouteDestination{IPAddress: net.ParseIP("8.8.8.8")}}
+	for i, ip := range hops {
+		hop := payload.TracerouteHop{TTL: i + 1, IPAddress: net.IP{}}
+		if ip != "*" {
+			hop.IPAddress = net.ParseIP(ip)
+			hop.Reachable = true
+			hop.RTT = float64(i+1) * 10
+		}
+		run.Hops = append(run.Hops, hop)
+	}
+	return run
+}
+
+func TestBuildPathGraph(t *testing.T) {
+	runs := []payload.TracerouteRun{
+		makeRun("10.0.0.1", "10.1.0.1", "8.8.8.8"),
+		makeRun("10.0.0.1", "10.2.0.1", "8.8.8.8"),
+		makeRun("10.0.0.1", "*", "8.8.8.8"),
+		makeRun("10.0.0.1", "10.1.0.1", "*"),
+	}
+	for i := range runs {
+		runs[i].Flow = i
+	}
+	runs[1].Hops[1].RTT = 40
+
+	graph := buildPathGraph(runs)
+	assert.Equal(t, 4, graph.Flows)
+
+	require.Len(t, graph.Nodes, 6)
+	ips := make([]string, 0, len(graph.Nodes))
+	for _, node := range graph.Nodes {
+		if node.Reachable {
+			ips = append(ips, node.IPAddress.String())
+		} else {
+			ips = append(ips, "*")
+		}
+	}
+	assert.Equal(t, []string{"10.0.0.1", "10.1.0.1", "8.8.8.8", "10.2.0.1", "*", "*"}, ips)
+
+	first := graph.Nodes[0]
+	assert.Equal(t, 1, first.TTL)
+	assert.Equal(t, 4, first.Flows)
+	assert.Equal(t, float32(25), first.PacketLossPercentage)
+	assert.Equal(t, &payload.HopLatency{Avg: 10, Min: 10, Max: 10, P50: 10, P95: 10}, first.RTT)
+
+	branch := graph.Nodes[1]
+	assert.Equal(t, 2, branch.Flows)
+	assert.Equal(t, float32(50), branch.PacketLossPercentage)
+
+	destination := graph.Nodes[2]
+	assert.Equal(t, 3, destination.Flows)
+	assert.Equal(t, float32(0), destination.PacketLossPercentage)
+
+	// the unknown hops of different branches are different nodes
+	assert.Equal(t, 2, graph.Nodes[4].TTL)
+	assert.Equal(t, 3, graph.Nodes[5].TTL)
+	assert.Nil(t, graph.Nodes[4].RTT)
+
+	assert.Equal(t, []payload.PathEdge{
+		{From: 0, To: 1, Flows: 2},
+		{From: 1, To: 2, Flows: 1},
+		{From: 0, To: 3, Flows: 1},
+		{From: 3, To: 2, Flows: 1},
+		{From: 0, To: 4, Flows: 1},
+		{From: 4, To: 2, Flows: 1},
+		{From: 1, To: 5, Flows: 1},
+	}, graph.Edges)
+
+	assert.Equal(t, []payload.PathHopStats{
+		{
+			TTL:             1,
+			PacketsSent:     4,
+			PacketsReceived: 4,
+			RTT:             &payload.HopLatency{Avg: 10, Min: 10, Max: 10, P50: 10, P95: 10},
+		},
+		{
+			TTL:                  2,
+			PacketsSent:          4,
+			PacketsReceived:      3,
+			PacketLossPercentage: 25,
+			RTT:                  &payload.HopLatency{Avg: 80.0 / 3, Min: 20, Max: 40, P50: 20, P95: 40},
+		},
+		{
+			TTL:                  3,
+			PacketsSent:          4,
+			PacketsReceived:      3,
+			PacketLossPercentage: 25,
+			RTT:                  &payload.HopLatency{Avg: 30, Min: 30, Max: 30, P50: 30, P95: 30},
+		},
+	}, graph.Hops)
+}
+
+func TestBuildPathGraphTracerouteQueries(t *testing.T) {
+	// two flows with two traceroute queries each
+	runs := []payload.TracerouteRun{
+		makeRun("10.0.0.1", "10.1.0.1", "8.8.8.8"),
+		makeRun("10.0.0.1", "*", "8.8.8.8"),
+		makeRun("10.0.0.1", "10.2.0.1", "8.8.8.8"),
+		makeRun("10.0.0.1", "10.2.0.1", "8.8.8.8"),
+	}
+	runs[2].Flow = 1
+	runs[3].Flow = 1
+
+	graph := buildPathGraph(runs)
+	assert.Equal(t, 2, graph.Flows)
+
+	require.Len(t, graph.Nodes, 5)
+	first := graph.Nodes[0]
+	assert.Equal(t, 2, first.Flows)
+	// half of the probes of the first flow were lost, none of the second one
+	assert.Equal(t, float32(25), first.PacketLossPercentage)
+	assert.Equal(t, 1, graph.Nodes[1].Flows)
+	assert.Equal(t, 2, graph.Nodes[2].Flows)
+	assert.Equal(t, 1, graph.Nodes[4].Flows)
+	assert.Equal(t, float32(0), graph.Nodes[4].PacketLossPercentage)
+
+	assert.Equal(t, []payload.PathEdge{
+		{From: 0, To: 1, Flows: 1},
+		{From: 1, To: 2, Flows: 1},
+		{From: 0, To: 3, Flows: 1},
+		{From: 3, To: 2, Flows: 1},
+		{From: 0, To: 4, Flows: 1},
+		{From: 4, To: 2, Flows: 1},
+	}, graph.Edges)
+
+	// the hop stats count the probes
+	require.Len(t, graph.Hops, 3)
+	assert.Equal(t, 4, graph.Hops[1].PacketsSent)
+	assert.Equal(t, 3, graph.Hops[1].PacketsReceived)
+}
+
+func TestPercentile(t *testing.T) {
+	values := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	assert.Equal(t, 1.0, percentile(values, 0))
+	assert.Equal(t, 5.0, percentile(values, 50))
+	assert.Equal(t, 10.0, percentile(values, 95))
+	assert.Equal(t, 10.0, percentile(values, 100))
+	assert.Nil(t, hopLatency(nil))
+}
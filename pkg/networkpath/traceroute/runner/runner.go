@@ -45,6 +45,8 @@ const (
 	DefaultMinTTL = 1
 	// DefaultDelay defines the default delay
 	DefaultDelay = 50 //msec
+	// DNSPort is the destination port of DNS traceroutes
+	DNSPort = 53
 
 	tracerouteRunnerModuleName = "traceroute_runner__"
 )
@@ -127,10 +129,20 @@ func (r *Runner) Run(ctx context.Context, cfg config.Config) (payload.NetworkPat
 		timeout = cfg.Timeout
 	}
 
+	// DNS traceroutes are UDP traceroutes to the DNS port
+	protocol := cfg.Protocol
+	destPort := cfg.DestPort
+	if protocol == payload.ProtocolDNS {
+		protocol = payload.ProtocolUDP
+		if destPort == 0 {
+			destPort = DNSPort
+		}
+	}
+
 	params := traceroute.TracerouteParams{
 		Hostname:              cfg.DestHostname,
-		Port:                  int(cfg.DestPort),
-		Protocol:              strings.ToLower(string(cfg.Protocol)),
+		Port:                  int(destPort),
+		Protocol:              strings.ToLower(string(protocol)),
 		MinTTL:                trcommon.DefaultMinTTL,
 		MaxTTL:                int(maxTTL),
 		Delay:                 DefaultDelay,
@@ -144,13 +156,34 @@ func (r *Runner) Run(ctx context.Context, cfg config.Config) (payload.NetworkPat
 		E2eQueries:            cfg.E2eQueries,
 	}
 
-	results, err := r.traceroute.RunTraceroute(ctx, params)
-	if err != nil {
+	flows := max(cfg.MultipathFlows, 1)
+	_, flowID, _ := getPorts(destPort)
+	flowResults := make([]*result.Results, 0, flows)
+	var failedFlows []payload.TracerouteFlowError
+	var firstErr error
+	for flow := 0; flow < flows; flow++ {
+		runParams := params
+		if flows > 1 {
+			runParams = flowParams(params, protocol, destPort, flowID, flow)
+		}
+		results, err := r.traceroute.RunTraceroute(ctx, runParams)
+		if err != nil {
+			log.Debugf("traceroute flow %d of %d to %s failed: %s", flow, flows, cfg.DestHostname, err)
+			failedFlows = append(failedFlows, payload.TracerouteFlowError{Flow: flow, Error: err.Error()})
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		flowResults = append(flowResults, results)
+	}
+	// the path is reported as long as one of the flows succeeded
+	if len(flowResults) == 0 {
 		tracerouteRunnerTelemetry.failedRuns.Inc()
-		return payload.NetworkPath{}, err
+		return payload.NetworkPath{}, firstErr
 	}
 
-	pathResult, err := r.processResults(results, cfg.Protocol, cfg.DestHostname, cfg.DestPort)
+	pathResult, err := r.processFlowResults(flowResults, failedFlows, cfg.Protocol, cfg.DestHostname, destPort)
 	if err != nil {
 		tracerouteRunnerTelemetry.failedRuns.Inc()
 		return payload.NetworkPath{}, err
@@ -159,6 +192,73 @@ func (r *Runner) Run(ctx context.Context, cfg config.Config) (payload.NetworkPat
 	return pathResult, nil
 }
 
+// flowParams returns the traceroute parameters of a flow of a multipath
+// traceroute. Each flow gets its own identifier, which is part of the ECMP
+// hash of its packets: UDP traceroutes without a fixed destination port use a
+// different destination port per flow, like classic traceroute, ICMP
+// traceroutes a different echo identifier, and the others (TCP, DNS and UDP
+// to a fixed port) a different source port, starting from flowID.
+func flowParams(params traceroute.TracerouteParams, protocol payload.Protocol, destPort uint16, flowID uint16, flow int) traceroute.TracerouteParams {
+	switch {
+	case protocol == payload.ProtocolICMP:
+		params.ICMPID = int(flowID) + flow
+	case protocol == payload.ProtocolUDP && destPort == 0:
+		params.Port = DefaultDestPort + flow
+	default:
+		params.SourcePort = int(flowID) + flow
+	}
+	return params
+}
+
+// processFlowResults merges the results of the flows of a traceroute. The
+// first successful flow gives the e2e probe and hop count, the runs of the
+// other flows are appended and merged in the path graph, which is built when
+// several flows were traced, even if some of them failed. The runs are tagged
+// with their flow, the results being in the order of the flows without the
+// failed ones.
+func (r *Runner) processFlowResults(flowResults []*result.Results, failedFlows []payload.TracerouteFlowError, protocol payload.Protocol, destinationHost string, destinationPort uint16) (payload.NetworkPath, error) {
+	if len(flowResults) == 0 {
+		return payload.NetworkPath{}, nil
+	}
+	traceroutePath, err := r.processResults(flowResults[0], protocol, destinationHost, destinationPort)
+	if err != nil || len(flowResults)+len(failedFlows) == 1 {
+		return traceroutePath, err
+	}
+	flows := succeededFlows(len(flowResults), failedFlows)
+	setRunsFlow(traceroutePath.Traceroute.Runs, flows[0])
+	for i, res := range flowResults[1:] {
+		if res == nil {
+			continue
+		}
+		runs := convertRuns(res.Traceroute.Runs)
+		setRunsFlow(runs, flows[i+1])
+		traceroutePath.Traceroute.Runs = append(traceroutePath.Traceroute.Runs, runs...)
+	}
+	traceroutePath.Traceroute.FailedFlows = failedFlows
+	traceroutePath.Traceroute.Graph = buildPathGraph(traceroutePath.Traceroute.Runs)
+	return traceroutePath, nil
+}
+
+// succeededFlows returns the flows of the given number of successful results
+func succeededFlows(results int, failedFlows []payload.TracerouteFlowError) []int {
+	flows := make([]int, 0, results)
+	for flow := 0; len(flows) < results; flow++ {
+		failed := slices.ContainsFunc(failedFlows, func(failedFlow payload.TracerouteFlowError) bool {
+			return failedFlow.Flow == flow
+		})
+		if !failed {
+			flows = append(flows, flow)
+		}
+	}
+	return flows
+}
+
+func setRunsFlow(runs []payload.TracerouteRun, flow int) {
+	for i := range runs {
+		runs[i].Flow = flow
+	}
+}
+
 func (r *Runner) processResults(res *result.Results, protocol payload.Protocol, destinationHost string, destinationPort uint16) (payload.NetworkPath, error) {
 	if res == nil {
 		return payload.NetworkPath{}, nil
@@ -215,7 +315,14 @@ func (r *Runner) processResults(res *result.Results, protocol payload.Protocol,
 		traceroutePath.Source.Via = r.gatewayLookup.LookupWithIPs(src, dst, r.nsIno)
 	}
 
-	for _, run := range res.Traceroute.Runs {
+	traceroutePath.Traceroute.Runs = convertRuns(res.Traceroute.Runs)
+
+	return traceroutePath, nil
+}
+
+func convertRuns(runs []result.TracerouteRun) []payload.TracerouteRun {
+	var converted []payload.TracerouteRun
+	for _, run := range runs {
 		var hops []payload.TracerouteHop
 		for _, hop := range run.Hops {
 			hops = append(hops, payload.TracerouteHop{
@@ -226,7 +333,7 @@ func (r *Runner) processResults(res *result.Results, protocol payload.Protocol,
 				ReverseDNS: hop.ReverseDns,
 			})
 		}
-		traceroutePath.Traceroute.Runs = append(traceroutePath.Traceroute.Runs, payload.TracerouteRun{
+		converted = append(converted, payload.TracerouteRun{
 			RunID: run.RunID,
 			Hops:  hops,
 			Source: payload.TracerouteSource{
@@ -240,8 +347,7 @@ func (r *Runner) processResults(res *result.Results, protocol payload.Protocol,
 			},
 		})
 	}
-
-	return traceroutePath, nil
+	return converted
 }
 
 func getPorts(configDestPort uint16) (uint16, uint16, bool) {
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/DataDog/datadog-traceroute/result"
+	"github.com/DataDog/datadog-traceroute/traceroute"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/assert"
@@ -473,3 +474,122 @@ func TestProcessResults(t *testing.T) {
 		})
 	}
 }
+
+func TestFlowParams(t *testing.T) {
+	// UDP traceroutes without a fixed destination port vary the destination port
+	params := traceroute.TracerouteParams{Hostname: "8.8.8.8", Protocol: "udp"}
+	flow := flowParams(params, payload.ProtocolUDP, 0, 20000, 2)
+	assert.Equal(t, DefaultDestPort+2, flow.Port)
+	assert.Zero(t, flow.SourcePort)
+
+	// fixed destination ports are kept, the source port varies
+	params.Port = 53
+	flow = flowParams(params, payload.ProtocolUDP, 53, 20000, 2)
+	assert.Equal(t, 53, flow.Port)
+	assert.Equal(t, 20002, flow.SourcePort)
+	params.Protocol = "tcp"
+	params.Port = 443
+	flow = flowParams(params, payload.ProtocolTCP, 443, 20000, 0)
+	assert.Equal(t, 443, flow.Port)
+	assert.Equal(t, 20000, flow.SourcePort)
+	assert.NotEqual(t, flow.SourcePort, flowParams(params, payload.ProtocolTCP, 443, 20000, 1).SourcePort)
+
+	// ICMP traceroutes vary the echo identifier
+	params = traceroute.TracerouteParams{Hostname: "8.8.8.8", Protocol: "icmp"}
+	flow = flowParams(params, payload.ProtocolICMP, 0, 20000, 3)
+	assert.Equal(t, 20003, flow.ICMPID)
+	assert.Zero(t, flow.Port)
+	assert.Zero(t, flow.SourcePort)
+}
+
+func runFlows(runs []payload.TracerouteRun) []int {
+	flows := make([]int, 0, len(runs))
+	for _, run := range runs {
+		flows = append(flows, run.Flow)
+	}
+	return flows
+}
+
+func TestProcessFlowResults(t *testing.T) {
+	runner := &Runner{networkID: func() string { return "" }}
+	makeResults := func(runID string, hopIP string) *result.Results {
+		return &result.Results{
+			TestRunID: "test-run-id",
+			Traceroute: result.Traceroute{
+				Runs: []result.TracerouteRun{{
+					RunID:       runID,
+					Destination: result.TracerouteDestination{IPAddress: net.ParseIP("8.8.8.8"), Port: 53},
+					Hops: []*result.TracerouteHop{
+						{TTL: 1, IPAddress: net.ParseIP(hopIP), RTT: 1, Reachable: true},
+						{TTL: 2, IPAddress: net.ParseIP("8.8.8.8"), RTT: 2, Reachable: true},
+					},
+				}},
+				HopCount: result.HopCountStats{Avg: 2, Min: 2, Max: 2},
+			},
+			E2eProbe: result.E2eProbe{PacketsSent: 10, PacketsReceived: 10},
+		}
+	}
+
+	path, err := runner.processFlowResults([]*result.Results{makeResults("a", "10.0.0.1")}, nil, payload.ProtocolDNS, "dns.google", 53)
+	require.NoError(t, err)
+	assert.Equal(t, payload.ProtocolDNS, path.Protocol)
+	assert.Len(t, path.Traceroute.Runs, 1)
+	assert.Nil(t, path.Traceroute.Graph)
+
+	path, err = runner.processFlowResults([]*result.Results{
+		makeResults("a", "10.0.0.1"),
+		makeResults("b", "10.0.0.2"),
+		makeResults("c", "10.0.0.1"),
+	}, nil, payload.ProtocolDNS, "dns.google", 53)
+	require.NoError(t, err)
+	require.Len(t, path.Traceroute.Runs, 3)
+	assert.Equal(t, "b", path.Traceroute.Runs[1].RunID)
+	assert.Equal(t, []int{0, 1, 2}, runFlows(path.Traceroute.Runs))
+	assert.Equal(t, 10, path.E2eProbe.PacketsSent)
+	require.NotNil(t, path.Traceroute.Graph)
+	assert.Equal(t, 3, path.Traceroute.Graph.Flows)
+	assert.Len(t, path.Traceroute.Graph.Nodes, 3)
+	assert.Equal(t, []payload.PathEdge{
+		{From: 0, To: 1, Flows: 2},
+		{From: 2, To: 1, Flows: 1},
+	}, path.Traceroute.Graph.Edges)
+
+	assert.Empty(t, path.Traceroute.FailedFlows)
+
+	// the graph is built from the flows that succeeded
+	failedFlows := []payload.TracerouteFlowError{{Flow: 1, Error: "timeout"}}
+	path, err = runner.processFlowResults([]*result.Results{
+		makeResults("a", "10.0.0.1"),
+		makeResults("c", "10.0.0.2"),
+	}, failedFlows, payload.ProtocolDNS, "dns.google", 53)
+	require.NoError(t, err)
+	require.Len(t, path.Traceroute.Runs, 2)
+	assert.Equal(t, []int{0, 2}, runFlows(path.Traceroute.Runs))
+	assert.Equal(t, failedFlows, path.Traceroute.FailedFlows)
+	require.NotNil(t, path.Traceroute.Graph)
+	assert.Equal(t, 2, path.Traceroute.Graph.Flows)
+
+	// the flows ran several traceroute queries
+	first, second := makeResults("a", "10.0.0.1"), makeResults("c", "10.0.0.1")
+	first.Traceroute.Runs = append(first.Traceroute.Runs, first.Traceroute.Runs[0])
+	second.Traceroute.Runs = append(second.Traceroute.Runs, second.Traceroute.Runs[0])
+	path, err = runner.processFlowResults([]*result.Results{first, second}, nil, payload.ProtocolDNS, "dns.google", 53)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 0, 1, 1}, runFlows(path.Traceroute.Runs))
+	require.NotNil(t, path.Traceroute.Graph)
+	assert.Equal(t, 2, path.Traceroute.Graph.Flows)
+	assert.Equal(t, 2, path.Traceroute.Graph.Nodes[0].Flows)
+
+	// a single flow succeeded
+	path, err = runner.processFlowResults([]*result.Results{makeResults("b", "10.0.0.2")}, failedFlows, payload.ProtocolDNS, "dns.google", 53)
+	require.NoError(t, err)
+	assert.Len(t, path.Traceroute.Runs, 1)
+	assert.Equal(t, []int{0}, runFlows(path.Traceroute.Runs))
+	assert.Equal(t, failedFlows, path.Traceroute.FailedFlows)
+	require.NotNil(t, path.Traceroute.Graph)
+	assert.Equal(t, 1, path.Traceroute.Graph.Flows)
+
+	path, err = runner.processFlowResults(nil, nil, payload.ProtocolUDP, "dns.google", 53)
+	require.NoError(t, err)
+	assert.Empty(t, path)
+}
@@ -0,0 +1,230 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package export
+
+import (
+	"time"
+
+	"github.com/CycloneDX/cyclonedx-go"
+	"github.com/DataDog/agent-payload/v5/cyclonedx_v1_4"
+)
+
+// ToCycloneDX converts the SBOM held by the agent to a CycloneDX document.
+// Only the inventory is kept: components with their licenses, purls, hashes
+// and properties, which include the layer each package comes from, and the
+// dependency graph.
+func ToCycloneDX(in *cyclonedx_v1_4.Bom) *cyclonedx.BOM {
+	out := cyclonedx.NewBOM()
+	if in == nil {
+		return out
+	}
+
+	out.SerialNumber = in.GetSerialNumber()
+	if in.GetVersion() > 0 {
+		out.Version = int(in.GetVersion())
+	}
+	out.Metadata = toCycloneDXMetadata(in.GetMetadata())
+	out.Components = toCycloneDXComponents(in.GetComponents())
+
+	if len(in.GetDependencies()) > 0 {
+		dependencies := make([]cyclonedx.Dependency, 0, len(in.GetDependencies()))
+		for _, d := range in.GetDependencies() {
+			dependencies = append(dependencies, toCycloneDXDependency(d))
+		}
+		out.Dependencies = &dependencies
+	}
+
+	return out
+}
+
+func toCycloneDXMetadata(in *cyclonedx_v1_4.Metadata) *cyclonedx.Metadata {
+	if in == nil {
+		return nil
+	}
+
+	out := &cyclonedx.Metadata{
+		Properties: toCycloneDXProperties(in.GetProperties()),
+	}
+
+	if in.GetTimestamp() != nil {
+		out.Timestamp = in.GetTimestamp().AsTime().UTC().Format(time.RFC3339)
+	}
+
+	if len(in.GetTools()) > 0 {
+		tools := make([]cyclonedx.Component, 0, len(in.GetTools()))
+		for _, t := range in.GetTools() {
+			tools = append(tools, cyclonedx.Component{
+				Type:    cyclonedx.ComponentTypeApplication,
+				Group:   t.GetVendor(),
+				Name:    t.GetName(),
+				Version: t.GetVersion(),
+			})
+		}
+		out.Tools = &cyclonedx.ToolsChoice{Components: &tools}
+	}
+
+	if in.GetComponent() != nil {
+		component := toCycloneDXComponent(in.GetComponent())
+		out.Component = &component
+	}
+
+	return out
+}
+
+func toCycloneDXComponents(in []*cyclonedx_v1_4.Component) *[]cyclonedx.Component {
+	if len(in) == 0 {
+		return nil
+	}
+
+	out := make([]cyclonedx.Component, 0, len(in))
+	for _, c := range in {
+		out = append(out, toCycloneDXComponent(c))
+	}
+	return &out
+}
+
+func toCycloneDXComponent(in *cyclonedx_v1_4.Component) cyclonedx.Component {
+	out := cyclonedx.Component{
+		BOMRef:      in.GetBomRef(),
+		Type:        toCycloneDXComponentType(in.GetType()),
+		Author:      in.GetAuthor(),
+		Publisher:   in.GetPublisher(),
+		Group:       in.GetGroup(),
+		Name:        in.GetName(),
+		Version:     in.GetVersion(),
+		Description: in.GetDescription(),
+		Copyright:   in.GetCopyright(),
+		CPE:         in.GetCpe(),
+		PackageURL:  in.GetPurl(),
+		Properties:  toCycloneDXProperties(in.GetProperties()),
+		Components:  toCycloneDXComponents(in.GetComponents()),
+	}
+
+	if in.GetSupplier().GetName() != "" {
+		out.Supplier = &cyclonedx.OrganizationalEntity{Name: in.GetSupplier().GetName()}
+	}
+
+	if len(in.GetHashes()) > 0 {
+		hashes := make([]cyclonedx.Hash, 0, len(in.GetHashes()))
+		for _, h := range in.GetHashes() {
+			if alg := toCycloneDXHashAlgorithm(h.GetAlg()); alg != "" {
+				hashes = append(hashes, cyclonedx.Hash{Algorithm: alg, Value: h.GetValue()})
+			}
+		}
+		if len(hashes) > 0 {
+			out.Hashes = &hashes
+		}
+	}
+
+	if len(in.GetLicenses()) > 0 {
+		licenses := make(cyclonedx.Licenses, 0, len(in.GetLicenses()))
+		for _, l := range in.GetLicenses() {
+			if choice, ok := toCycloneDXLicense(l); ok {
+				licenses = append(licenses, choice)
+			}
+		}
+		if len(licenses) > 0 {
+			out.Licenses = &licenses
+		}
+	}
+
+	return out
+}
+
+func toCycloneDXLicense(in *cyclonedx_v1_4.LicenseChoice) (cyclonedx.LicenseChoice, bool) {
+	if expression := in.GetExpression(); expression != "" {
+		return cyclonedx.LicenseChoice{Expression: expression}, true
+	}
+
+	license := in.GetLicense()
+	if license == nil {
+		return cyclonedx.LicenseChoice{}, false
+	}
+
+	out := &cyclonedx.License{
+		ID:   license.GetId(),
+		Name: license.GetName(),
+		URL:  license.GetUrl(),
+	}
+	if out.ID == "" && out.Name == "" {
+		return cyclonedx.LicenseChoice{}, false
+	}
+	return cyclonedx.LicenseChoice{License: out}, true
+}
+
+func toCycloneDXProperties(in []*cyclonedx_v1_4.Property) *[]cyclonedx.Property {
+	if len(in) == 0 {
+		return nil
+	}
+
+	out := make([]cyclonedx.Property, 0, len(in))
+	for _, p := range in {
+		out = append(out, cyclonedx.Property{Name: p.GetName(), Value: p.GetValue()})
+	}
+	return &out
+}
+
+func toCycloneDXDependency(in *cyclonedx_v1_4.Dependency) cyclonedx.Dependency {
+	out := cyclonedx.Dependency{Ref: in.GetRef()}
+	if len(in.GetDependencies()) > 0 {
+		refs := make([]string, 0, len(in.GetDependencies()))
+		for _, d := range in.GetDependencies() {
+			refs = append(refs, d.GetRef())
+		}
+		out.Dependencies = &refs
+	}
+	return out
+}
+
+func toCycloneDXComponentType(in cyclonedx_v1_4.Classification) cyclonedx.ComponentType {
+	switch in {
+	case cyclonedx_v1_4.Classification_CLASSIFICATION_APPLICATION:
+		return cyclonedx.ComponentTypeApplication
+	case cyclonedx_v1_4.Classification_CLASSIFICATION_CONTAINER:
+		return cyclonedx.ComponentTypeContainer
+	case cyclonedx_v1_4.Classification_CLASSIFICATION_DEVICE:
+		return cyclonedx.ComponentTypeDevice
+	case cyclonedx_v1_4.Classification_CLASSIFICATION_FILE:
+		return cyclonedx.ComponentTypeFile
+	case cyclonedx_v1_4.Classification_CLASSIFICATION_FIRMWARE:
+		return cyclonedx.ComponentTypeFirmware
+	case cyclonedx_v1_4.Classification_CLASSIFICATION_FRAMEWORK:
+		return cyclonedx.ComponentTypeFramework
+	case cyclonedx_v1_4.Classification_CLASSIFICATION_OPERATING_SYSTEM:
+		return cyclonedx.ComponentTypeOS
+	default:
+		return cyclonedx.ComponentTypeLibrary
+	}
+}
+
+func toCycloneDXHashAlgorithm(in cyclonedx_v1_4.HashAlg) cyclonedx.HashAlgorithm {
+	switch in {
+	case cyclonedx_v1_4.HashAlg_HASH_ALG_MD_5:
+		return cyclonedx.HashAlgoMD5
+	case cyclonedx_v1_4.HashAlg_HASH_ALG_SHA_1:
+		return cyclonedx.HashAlgoSHA1
+	case cyclonedx_v1_4.HashAlg_HASH_ALG_SHA_256:
+		return cyclonedx.HashAlgoSHA256
+	case cyclonedx_v1_4.HashAlg_HASH_ALG_SHA_384:
+		return cyclonedx.HashAlgoSHA384
+	case cyclonedx_v1_4.HashAlg_HASH_ALG_SHA_512:
+		return cyclonedx.HashAlgoSHA512
+	case cyclonedx_v1_4.HashAlg_HASH_ALG_SHA_3_256:
+		return cyclonedx.HashAlgoSHA3_256
+	case cyclonedx_v1_4.HashAlg_HASH_ALG_SHA_3_512:
+		return cyclonedx.HashAlgoSHA3_512
+	case cyclonedx_v1_4.HashAlg_HASH_ALG_BLAKE_2_B_256:
+		return cyclonedx.HashAlgoBlake2b_256
+	case cyclonedx_v1_4.HashAlg_HASH_ALG_BLAKE_2_B_384:
+		return cyclonedx.HashAlgoBlake2b_384
+	case cyclonedx_v1_4.HashAlg_HASH_ALG_BLAKE_2_B_512:
+		return cyclonedx.HashAlgoBlake2b_512
+	case cyclonedx_v1_4.HashAlg_HASH_ALG_BLAKE_3:
+		return cyclonedx.HashAlgoBlake3
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,63 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+// Package export converts the SBOMs collected by the agent to standard
+// CycloneDX and SPDX documents
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/CycloneDX/cyclonedx-go"
+	"github.com/DataDog/agent-payload/v5/cyclonedx_v1_4"
+)
+
+// Format is the format of an exported SBOM
+type Format string
+
+// Supported export formats
+const (
+	FormatCycloneDXJSON Format = "cyclonedx-json"
+	FormatSPDXJSON      Format = "spdx-json"
+)
+
+// Formats lists the supported export formats
+var Formats = []Format{FormatCycloneDXJSON, FormatSPDXJSON}
+
+// ParseFormat parses an export format
+func ParseFormat(s string) (Format, error) {
+	for _, f := range Formats {
+		if string(f) == s {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported SBOM format %q, supported formats are %v", s, Formats)
+}
+
+// Document describes the SBOM to export
+type Document struct {
+	// Name is the name of the SBOM subject, an image reference or a hostname
+	Name string
+	// Created is the time the SBOM was generated at
+	Created time.Time
+	BOM     *cyclonedx_v1_4.Bom
+}
+
+// Write writes the document in the given format
+func Write(w io.Writer, doc Document, format Format) error {
+	switch format {
+	case FormatCycloneDXJSON:
+		return cyclonedx.NewBOMEncoder(w, cyclonedx.BOMFileFormatJSON).SetPretty(true).Encode(ToCycloneDX(doc.BOM))
+	case FormatSPDXJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(ToSPDX(doc))
+	default:
+		return fmt.Errorf("unsupported SBOM format %q", format)
+	}
+}
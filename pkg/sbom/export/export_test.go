@@ -0,0 +1,205 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/CycloneDX/cyclonedx-go"
+	"github.com/DataDog/agent-payload/v5/cyclonedx_v1_4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+)
+
+var generatedAt = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func testBOM() *cyclonedx_v1_4.Bom {
+	return &cyclonedx_v1_4.Bom{
+		SpecVersion:  "1.4",
+		Version:      ptr(int32(1)),
+		SerialNumber: ptr("urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79"),
+		Metadata: &cyclonedx_v1_4.Metadata{
+			Timestamp: timestamppb.New(generatedAt),
+			Tools:     []*cyclonedx_v1_4.Tool{{Vendor: ptr("aquasecurity"), Name: ptr("trivy"), Version: ptr("0.50.0")}},
+			Component: &cyclonedx_v1_4.Component{
+				BomRef: ptr("1"),
+				Type:   cyclonedx_v1_4.Classification_CLASSIFICATION_CONTAINER,
+				Name:   "nginx:1.25",
+			},
+		},
+		Components: []*cyclonedx_v1_4.Component{
+			{
+				BomRef:  ptr("2"),
+				Type:    cyclonedx_v1_4.Classification_CLASSIFICATION_OPERATING_SYSTEM,
+				Name:    "debian",
+				Version: "12.4",
+			},
+			{
+				BomRef:  ptr("3"),
+				Type:    cyclonedx_v1_4.Classification_CLASSIFICATION_LIBRARY,
+				Name:    "libssl3",
+				Version: "3.0.11-1~deb12u2",
+				Purl:    ptr("pkg:deb/debian/libssl3@3.0.11-1~deb12u2?distro=debian-12.4"),
+				Hashes:  []*cyclonedx_v1_4.Hash{{Alg: cyclonedx_v1_4.HashAlg_HASH_ALG_SHA_256, Value: "abcd"}},
+				Licenses: []*cyclonedx_v1_4.LicenseChoice{
+					{Choice: &cyclonedx_v1_4.LicenseChoice_License{License: &cyclonedx_v1_4.License{License: &cyclonedx_v1_4.License_Id{Id: "Apache-2.0"}}}},
+					{Choice: &cyclonedx_v1_4.LicenseChoice_License{License: &cyclonedx_v1_4.License{License: &cyclonedx_v1_4.License_Name{Name: "OpenSSL license"}}}},
+				},
+				Properties: []*cyclonedx_v1_4.Property{
+					{Name: "aquasecurity:trivy:LayerDigest", Value: ptr("sha256:aaaa")},
+					{Name: "aquasecurity:trivy:LayerDiffID", Value: ptr("sha256:bbbb")},
+				},
+			},
+			{
+				BomRef: ptr("4"),
+				Type:   cyclonedx_v1_4.Classification_CLASSIFICATION_APPLICATION,
+				Name:   "app/package-lock.json",
+				Components: []*cyclonedx_v1_4.Component{
+					{
+						BomRef:  ptr("5"),
+						Group:   ptr("@babel"),
+						Name:    "core",
+						Version: "7.23.0",
+						Purl:    ptr("pkg:npm/%40babel/core@7.23.0"),
+						Licenses: []*cyclonedx_v1_4.LicenseChoice{
+							{Choice: &cyclonedx_v1_4.LicenseChoice_Expression{Expression: "MIT OR Apache-2.0"}},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []*cyclonedx_v1_4.Dependency{
+			{Ref: "1", Dependencies: []*cyclonedx_v1_4.Dependency{{Ref: "2"}, {Ref: "4"}}},
+			{Ref: "2", Dependencies: []*cyclonedx_v1_4.Dependency{{Ref: "3"}}},
+		},
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	f, err := ParseFormat("spdx-json")
+	require.NoError(t, err)
+	assert.Equal(t, FormatSPDXJSON, f)
+
+	_, err = ParseFormat("spdx-tag-value")
+	assert.Error(t, err)
+}
+
+func TestToCycloneDX(t *testing.T) {
+	bom := ToCycloneDX(testBOM())
+
+	assert.Equal(t, "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79", bom.SerialNumber)
+	assert.Equal(t, "2024-03-01T12:00:00Z", bom.Metadata.Timestamp)
+	assert.Equal(t, []cyclonedx.Component{{Type: cyclonedx.ComponentTypeApplication, Group: "aquasecurity", Name: "trivy", Version: "0.50.0"}}, *bom.Metadata.Tools.Components)
+	assert.Equal(t, cyclonedx.ComponentTypeContainer, bom.Metadata.Component.Type)
+
+	require.Len(t, *bom.Components, 3)
+	libssl := (*bom.Components)[1]
+	assert.Equal(t, cyclonedx.ComponentTypeLibrary, libssl.Type)
+	assert.Equal(t, "pkg:deb/debian/libssl3@3.0.11-1~deb12u2?distro=debian-12.4", libssl.PackageURL)
+	assert.Equal(t, []cyclonedx.Hash{{Algorithm: cyclonedx.HashAlgoSHA256, Value: "abcd"}}, *libssl.Hashes)
+	assert.Equal(t, cyclonedx.Licenses{
+		{License: &cyclonedx.License{ID: "Apache-2.0"}},
+		{License: &cyclonedx.License{Name: "OpenSSL license"}},
+	}, *libssl.Licenses)
+	assert.Contains(t, *libssl.Properties, cyclonedx.Property{Name: "aquasecurity:trivy:LayerDigest", Value: "sha256:aaaa"})
+
+	babel := (*(*bom.Components)[2].Components)[0]
+	assert.Equal(t, "@babel", babel.Group)
+	assert.Equal(t, cyclonedx.Licenses{{Expression: "MIT OR Apache-2.0"}}, *babel.Licenses)
+
+	assert.Equal(t, []cyclonedx.Dependency{
+		{Ref: "1", Dependencies: &[]string{"2", "4"}},
+		{Ref: "2", Dependencies: &[]string{"3"}},
+	}, *bom.Dependencies)
+
+	assert.NotNil(t, ToCycloneDX(nil))
+}
+
+func TestToSPDX(t *testing.T) {
+	doc := ToSPDX(Document{Name: "nginx:1.25", Created: generatedAt, BOM: testBOM()})
+
+	assert.Equal(t, "SPDX-2.3", doc.SPDXVersion)
+	assert.Equal(t, "nginx:1.25", doc.Name)
+	assert.Equal(t, "https://datadoghq.com/spdxdocs/nginx:1.25-3e671687-395b-41f5-a30f-a58921a69b79", doc.DocumentNamespace)
+	assert.Equal(t, "2024-03-01T12:00:00Z", doc.CreationInfo.Created)
+
+	require.Len(t, doc.Packages, 5)
+	assert.Equal(t, "CONTAINER", doc.Packages[0].PrimaryPackagePurpose)
+
+	libssl := doc.Packages[2]
+	assert.Equal(t, "SPDXRef-Package-3", libssl.SPDXID)
+	assert.Equal(t, "Apache-2.0 AND LicenseRef-OpenSSL-license", libssl.LicenseDeclared)
+	assert.Equal(t, []SPDXChecksum{{Algorithm: "SHA256", Value: "abcd"}}, libssl.Checksums)
+	assert.Equal(t, []SPDXExternalRef{{Category: "PACKAGE-MANAGER", Type: "purl", Locator: "pkg:deb/debian/libssl3@3.0.11-1~deb12u2?distro=debian-12.4"}}, libssl.ExternalRefs)
+	require.Len(t, libssl.Annotations, 2)
+	assert.Equal(t, "aquasecurity:trivy:LayerDigest: sha256:aaaa", libssl.Annotations[0].Comment)
+
+	babel := doc.Packages[4]
+	assert.Equal(t, "@babel/core", babel.Name)
+	assert.Equal(t, "MIT OR Apache-2.0", babel.LicenseDeclared)
+
+	assert.Equal(t, []SPDXExtractedLicense{{LicenseID: "LicenseRef-OpenSSL-license", Name: "OpenSSL license", ExtractedText: "OpenSSL license"}}, doc.ExtractedLicenses)
+
+	assert.Equal(t, []SPDXRelationship{
+		{Element: "SPDXRef-DOCUMENT", Type: "DESCRIBES", RelatedElement: "SPDXRef-Package-1"},
+		{Element: "SPDXRef-Package-1", Type: "CONTAINS", RelatedElement: "SPDXRef-Package-2"},
+		{Element: "SPDXRef-Package-1", Type: "CONTAINS", RelatedElement: "SPDXRef-Package-3"},
+		{Element: "SPDXRef-Package-1", Type: "CONTAINS", RelatedElement: "SPDXRef-Package-4"},
+		{Element: "SPDXRef-Package-4", Type: "CONTAINS", RelatedElement: "SPDXRef-Package-5"},
+		{Element: "SPDXRef-Package-1", Type: "DEPENDS_ON", RelatedElement: "SPDXRef-Package-2"},
+		{Element: "SPDXRef-Package-1", Type: "DEPENDS_ON", RelatedElement: "SPDXRef-Package-4"},
+		{Element: "SPDXRef-Package-2", Type: "DEPENDS_ON", RelatedElement: "SPDXRef-Package-3"},
+	}, doc.Relationships)
+}
+
+func TestWrite(t *testing.T) {
+	doc := Document{Name: "my-host", Created: generatedAt, BOM: testBOM()}
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, doc, FormatSPDXJSON))
+	var spdx map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &spdx))
+	assert.Equal(t, "SPDX-2.3", spdx["spdxVersion"])
+
+	assert.Error(t, Write(&buf, doc, Format("xml")))
+}
+
+func TestHandleExport(t *testing.T) {
+	store := NewStore(nil)
+
+	get := func(query string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		store.HandleExport(rec, httptest.NewRequest(http.MethodGet, "/sbom/export?"+query, nil))
+		return rec
+	}
+
+	assert.Equal(t, http.StatusNotFound, get("target=host").Code)
+
+	store.SetHostSBOM("my-host", &workloadmeta.SBOM{CycloneDXBOM: testBOM(), GenerationTime: generatedAt, Status: workloadmeta.Success})
+
+	rec := get("target=host&format=spdx-json")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var spdx SPDXDocument
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &spdx))
+	assert.Equal(t, "my-host", spdx.Name)
+	assert.Len(t, spdx.Packages, 5)
+
+	assert.Equal(t, http.StatusBadRequest, get("target=host&format=xml").Code)
+	assert.Equal(t, http.StatusBadRequest, get("target=container").Code)
+	assert.Equal(t, http.StatusBadRequest, get("target=image").Code)
+}
@@ -0,0 +1,348 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package export
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DataDog/agent-payload/v5/cyclonedx_v1_4"
+
+	"github.com/DataDog/datadog-agent/pkg/version"
+)
+
+const (
+	spdxVersion       = "SPDX-2.3"
+	spdxDataLicense   = "CC0-1.0"
+	spdxDocumentID    = "SPDXRef-DOCUMENT"
+	spdxNoAssertion   = "NOASSERTION"
+	spdxNamespaceBase = "https://datadoghq.com/spdxdocs/"
+
+	spdxRelationshipDescribes = "DESCRIBES"
+	spdxRelationshipContains  = "CONTAINS"
+	spdxRelationshipDependsOn = "DEPENDS_ON"
+)
+
+// invalidSPDXIDChars matches the characters not allowed in SPDX identifiers
+var invalidSPDXIDChars = regexp.MustCompile(`[^a-zA-Z0-9.-]+`)
+
+// SPDXDocument is a SPDX 2.3 document, limited to the fields the SBOMs of the
+// agent can fill
+type SPDXDocument struct {
+	SPDXVersion       string                 `json:"spdxVersion"`
+	DataLicense       string                 `json:"dataLicense"`
+	SPDXID            string                 `json:"SPDXID"`
+	Name              string                 `json:"name"`
+	DocumentNamespace string                 `json:"documentNamespace"`
+	CreationInfo      SPDXCreationInfo       `json:"creationInfo"`
+	Packages          []SPDXPackage          `json:"packages"`
+	Relationships     []SPDXRelationship     `json:"relationships"`
+	ExtractedLicenses []SPDXExtractedLicense `json:"hasExtractedLicensingInfos,omitempty"`
+}
+
+// SPDXCreationInfo describes how a SPDX document was created
+type SPDXCreationInfo struct {
+	Creators []string `json:"creators"`
+	Created  string   `json:"created"`
+}
+
+// SPDXPackage is a package of a SPDX document
+type SPDXPackage struct {
+	SPDXID                string            `json:"SPDXID"`
+	Name                  string            `json:"name"`
+	VersionInfo           string            `json:"versionInfo,omitempty"`
+	Supplier              string            `json:"supplier,omitempty"`
+	DownloadLocation      string            `json:"downloadLocation"`
+	FilesAnalyzed         bool              `json:"filesAnalyzed"`
+	LicenseConcluded      string            `json:"licenseConcluded"`
+	LicenseDeclared       string            `json:"licenseDeclared"`
+	CopyrightText         string            `json:"copyrightText"`
+	PrimaryPackagePurpose string            `json:"primaryPackagePurpose,omitempty"`
+	Checksums             []SPDXChecksum    `json:"checksums,omitempty"`
+	ExternalRefs          []SPDXExternalRef `json:"externalRefs,omitempty"`
+	Annotations           []SPDXAnnotation  `json:"annotations,omitempty"`
+}
+
+// SPDXChecksum is a checksum of a SPDX package
+type SPDXChecksum struct {
+	Algorithm string `json:"algorithm"`
+	Value     string `json:"checksumValue"`
+}
+
+// SPDXExternalRef is a reference of a SPDX package to an external identifier,
+// such as a purl or a CPE
+type SPDXExternalRef struct {
+	Category string `json:"referenceCategory"`
+	Type     string `json:"referenceType"`
+	Locator  string `json:"referenceLocator"`
+}
+
+// SPDXAnnotation is an annotation of a SPDX package. The properties of the
+// components, such as the layer a package comes from, are exported as
+// annotations.
+type SPDXAnnotation struct {
+	Annotator string `json:"annotator"`
+	Date      string `json:"annotationDate"`
+	Type      string `json:"annotationType"`
+	Comment   string `json:"comment"`
+}
+
+// SPDXRelationship is a relationship between two elements of a SPDX document
+type SPDXRelationship struct {
+	Element        string `json:"spdxElementId"`
+	Type           string `json:"relationshipType"`
+	RelatedElement string `json:"relatedSpdxElement"`
+}
+
+// SPDXExtractedLicense is a license that has no SPDX identifier
+type SPDXExtractedLicense struct {
+	LicenseID     string `json:"licenseId"`
+	Name          string `json:"name"`
+	ExtractedText string `json:"extractedText"`
+}
+
+type spdxConverter struct {
+	doc      *SPDXDocument
+	tool     string
+	created  string
+	ids      map[string]string // SPDX identifiers by bom-ref
+	licenses map[string]struct{}
+}
+
+// ToSPDX converts the SBOM held by the agent to a SPDX document. The metadata
+// component, the image or the host, is the package the document describes and
+// contains the other components.
+func ToSPDX(in Document) *SPDXDocument {
+	created := in.Created
+	if created.IsZero() && in.BOM.GetMetadata().GetTimestamp() != nil {
+		created = in.BOM.GetMetadata().GetTimestamp().AsTime()
+	}
+	if created.IsZero() {
+		created = time.Now()
+	}
+
+	c := &spdxConverter{
+		doc: &SPDXDocument{
+			SPDXVersion:       spdxVersion,
+			DataLicense:       spdxDataLicense,
+			SPDXID:            spdxDocumentID,
+			Name:              in.Name,
+			DocumentNamespace: spdxNamespace(in.Name, in.BOM.GetSerialNumber(), created),
+			Packages:          []SPDXPackage{},
+			Relationships:     []SPDXRelationship{},
+		},
+		tool:     "Tool: datadog-agent-" + version.AgentVersion,
+		created:  created.UTC().Format(time.RFC3339),
+		ids:      make(map[string]string),
+		licenses: make(map[string]struct{}),
+	}
+
+	c.doc.CreationInfo = SPDXCreationInfo{
+		Creators: []string{"Organization: Datadog", c.tool},
+		Created:  c.created,
+	}
+
+	root := spdxDocumentID
+	if component := in.BOM.GetMetadata().GetComponent(); component != nil {
+		root = c.addPackage(spdxDocumentID, spdxRelationshipDescribes, component)
+	}
+
+	relationship := spdxRelationshipContains
+	if root == spdxDocumentID {
+		relationship = spdxRelationshipDescribes
+	}
+	for _, component := range in.BOM.GetComponents() {
+		c.addComponent(root, relationship, component)
+	}
+
+	for _, d := range in.BOM.GetDependencies() {
+		id, ok := c.ids[d.GetRef()]
+		if !ok {
+			continue
+		}
+		for _, dep := range d.GetDependencies() {
+			if depID, ok := c.ids[dep.GetRef()]; ok {
+				c.doc.Relationships = append(c.doc.Relationships, SPDXRelationship{
+					Element:        id,
+					Type:           spdxRelationshipDependsOn,
+					RelatedElement: depID,
+				})
+			}
+		}
+	}
+
+	return c.doc
+}
+
+// spdxNamespace returns the unique URI of a document
+func spdxNamespace(name, serialNumber string, created time.Time) string {
+	id := strings.TrimPrefix(serialNumber, "urn:uuid:")
+	if id == "" {
+		id = fmt.Sprintf("%x", sha256.Sum256([]byte(name+"@"+created.UTC().Format(time.RFC3339Nano))))
+	}
+	return spdxNamespaceBase + url.PathEscape(name) + "-" + id
+}
+
+// addComponent adds the component and its nested components
+func (c *spdxConverter) addComponent(parent, relationship string, component *cyclonedx_v1_4.Component) {
+	id := c.addPackage(parent, relationship, component)
+	for _, nested := range component.GetComponents() {
+		c.addComponent(id, spdxRelationshipContains, nested)
+	}
+}
+
+// addPackage adds the component as a package related to its parent and
+// returns its identifier
+func (c *spdxConverter) addPackage(parent, relationship string, component *cyclonedx_v1_4.Component) string {
+	id := "SPDXRef-Package-" + strconv.Itoa(len(c.doc.Packages)+1)
+	if ref := component.GetBomRef(); ref != "" {
+		c.ids[ref] = id
+	}
+
+	pkg := SPDXPackage{
+		SPDXID:                id,
+		Name:                  component.GetName(),
+		VersionInfo:           component.GetVersion(),
+		DownloadLocation:      spdxNoAssertion,
+		LicenseConcluded:      spdxNoAssertion,
+		LicenseDeclared:       c.licenseExpression(component.GetLicenses()),
+		CopyrightText:         spdxNoAssertion,
+		PrimaryPackagePurpose: spdxPurpose(component.GetType()),
+	}
+
+	if group := component.GetGroup(); group != "" {
+		pkg.Name = group + "/" + pkg.Name
+	}
+	if supplier := component.GetSupplier().GetName(); supplier != "" {
+		pkg.Supplier = "Organization: " + supplier
+	}
+	if copyright := component.GetCopyright(); copyright != "" {
+		pkg.CopyrightText = copyright
+	}
+
+	for _, h := range component.GetHashes() {
+		if alg := spdxChecksumAlgorithm(h.GetAlg()); alg != "" {
+			pkg.Checksums = append(pkg.Checksums, SPDXChecksum{Algorithm: alg, Value: h.GetValue()})
+		}
+	}
+
+	if purl := component.GetPurl(); purl != "" {
+		pkg.ExternalRefs = append(pkg.ExternalRefs, SPDXExternalRef{Category: "PACKAGE-MANAGER", Type: "purl", Locator: purl})
+	}
+	if cpe := component.GetCpe(); cpe != "" {
+		pkg.ExternalRefs = append(pkg.ExternalRefs, SPDXExternalRef{Category: "SECURITY", Type: "cpe23Type", Locator: cpe})
+	}
+
+	for _, p := range component.GetProperties() {
+		pkg.Annotations = append(pkg.Annotations, SPDXAnnotation{
+			Annotator: c.tool,
+			Date:      c.created,
+			Type:      "OTHER",
+			Comment:   p.GetName() + ": " + p.GetValue(),
+		})
+	}
+
+	c.doc.Packages = append(c.doc.Packages, pkg)
+	c.doc.Relationships = append(c.doc.Relationships, SPDXRelationship{
+		Element:        parent,
+		Type:           relationship,
+		RelatedElement: id,
+	})
+
+	return id
+}
+
+// licenseExpression returns the SPDX license expression of the licenses.
+// Licenses known by name only are declared as extracted licenses.
+func (c *spdxConverter) licenseExpression(licenses []*cyclonedx_v1_4.LicenseChoice) string {
+	var terms []string
+	for _, l := range licenses {
+		switch {
+		case l.GetExpression() != "":
+			terms = append(terms, "("+l.GetExpression()+")")
+		case l.GetLicense().GetId() != "":
+			terms = append(terms, l.GetLicense().GetId())
+		case l.GetLicense().GetName() != "":
+			name := l.GetLicense().GetName()
+			ref := "LicenseRef-" + strings.Trim(invalidSPDXIDChars.ReplaceAllString(name, "-"), "-")
+			if _, exists := c.licenses[ref]; !exists {
+				c.licenses[ref] = struct{}{}
+				c.doc.ExtractedLicenses = append(c.doc.ExtractedLicenses, SPDXExtractedLicense{
+					LicenseID:     ref,
+					Name:          name,
+					ExtractedText: name,
+				})
+			}
+			terms = append(terms, ref)
+		}
+	}
+
+	switch len(terms) {
+	case 0:
+		return spdxNoAssertion
+	case 1:
+		return strings.TrimSuffix(strings.TrimPrefix(terms[0], "("), ")")
+	default:
+		return strings.Join(terms, " AND ")
+	}
+}
+
+func spdxPurpose(in cyclonedx_v1_4.Classification) string {
+	switch in {
+	case cyclonedx_v1_4.Classification_CLASSIFICATION_APPLICATION:
+		return "APPLICATION"
+	case cyclonedx_v1_4.Classification_CLASSIFICATION_CONTAINER:
+		return "CONTAINER"
+	case cyclonedx_v1_4.Classification_CLASSIFICATION_DEVICE:
+		return "DEVICE"
+	case cyclonedx_v1_4.Classification_CLASSIFICATION_FILE:
+		return "FILE"
+	case cyclonedx_v1_4.Classification_CLASSIFICATION_FIRMWARE:
+		return "FIRMWARE"
+	case cyclonedx_v1_4.Classification_CLASSIFICATION_FRAMEWORK:
+		return "FRAMEWORK"
+	case cyclonedx_v1_4.Classification_CLASSIFICATION_LIBRARY:
+		return "LIBRARY"
+	case cyclonedx_v1_4.Classification_CLASSIFICATION_OPERATING_SYSTEM:
+		return "OPERATING-SYSTEM"
+	default:
+		return ""
+	}
+}
+
+func spdxChecksumAlgorithm(in cyclonedx_v1_4.HashAlg) string {
+	switch in {
+	case cyclonedx_v1_4.HashAlg_HASH_ALG_MD_5:
+		return "MD5"
+	case cyclonedx_v1_4.HashAlg_HASH_ALG_SHA_1:
+		return "SHA1"
+	case cyclonedx_v1_4.HashAlg_HASH_ALG_SHA_256:
+		return "SHA256"
+	case cyclonedx_v1_4.HashAlg_HASH_ALG_SHA_384:
+		return "SHA384"
+	case cyclonedx_v1_4.HashAlg_HASH_ALG_SHA_512:
+		return "SHA512"
+	case cyclonedx_v1_4.HashAlg_HASH_ALG_SHA_3_256:
+		return "SHA3-256"
+	case cyclonedx_v1_4.HashAlg_HASH_ALG_SHA_3_512:
+		return "SHA3-512"
+	case cyclonedx_v1_4.HashAlg_HASH_ALG_BLAKE_2_B_256:
+		return "BLAKE2b-256"
+	case cyclonedx_v1_4.HashAlg_HASH_ALG_BLAKE_2_B_384:
+		return "BLAKE2b-384"
+	case cyclonedx_v1_4.HashAlg_HASH_ALG_BLAKE_2_B_512:
+		return "BLAKE2b-512"
+	case cyclonedx_v1_4.HashAlg_HASH_ALG_BLAKE_3:
+		return "BLAKE3"
+	default:
+		return ""
+	}
+}
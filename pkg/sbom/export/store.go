@@ -0,0 +1,156 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package export
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/DataDog/datadog-agent/comp/core/workloadmeta/collectors/sbomutil"
+	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+	httputils "github.com/DataDog/datadog-agent/pkg/util/http"
+)
+
+// Targets of an export
+const (
+	TargetHost  = "host"
+	TargetImage = "image"
+)
+
+// ErrNotFound is returned when there is no SBOM to export for a target
+var ErrNotFound = errors.New("SBOM not found")
+
+// Store gives access to the latest SBOM of the host and of the container
+// images. Image SBOMs are read from workloadmeta, while the host SBOM, which
+// isn't held by workloadmeta, is kept by the store.
+type Store struct {
+	wmeta workloadmeta.Component
+
+	mu       sync.RWMutex
+	hostname string
+	host     *workloadmeta.SBOM
+}
+
+// NewStore returns a store reading the image SBOMs from workloadmeta, which
+// can be nil when there is no image to export
+func NewStore(wmeta workloadmeta.Component) *Store {
+	return &Store{
+		wmeta: wmeta,
+	}
+}
+
+// SetHostSBOM stores the latest SBOM of the host
+func (s *Store) SetHostSBOM(hostname string, sbom *workloadmeta.SBOM) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hostname = hostname
+	s.host = sbom
+}
+
+// HostDocument returns the latest SBOM of the host
+func (s *Store) HostDocument() (Document, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.host == nil || s.host.CycloneDXBOM == nil {
+		return Document{}, fmt.Errorf("%w for the host, is sbom.host.enabled set?", ErrNotFound)
+	}
+
+	return Document{
+		Name:    s.hostname,
+		Created: s.host.GenerationTime,
+		BOM:     s.host.CycloneDXBOM,
+	}, nil
+}
+
+// ImageDocument returns the latest SBOM of an image, referenced by its ID,
+// one of its tags or one of its repo digests
+func (s *Store) ImageDocument(ref string) (Document, error) {
+	if s.wmeta == nil {
+		return Document{}, fmt.Errorf("%w for image %s, is sbom.container_image.enabled set?", ErrNotFound, ref)
+	}
+
+	for _, img := range s.wmeta.ListImages() {
+		if img.ID != ref && !slices.Contains(img.RepoTags, ref) && !slices.Contains(img.RepoDigests, ref) {
+			continue
+		}
+
+		sbom, err := sbomutil.UncompressSBOM(img.SBOM)
+		if err != nil {
+			return Document{}, fmt.Errorf("failed to uncompress the SBOM of image %s: %w", img.ID, err)
+		}
+		if sbom == nil || sbom.Status != workloadmeta.Success || sbom.CycloneDXBOM == nil {
+			return Document{}, fmt.Errorf("%w for image %s, it may not have been scanned yet", ErrNotFound, img.ID)
+		}
+
+		name := img.ID
+		if len(img.RepoTags) > 0 {
+			name = img.RepoTags[0]
+		}
+
+		return Document{
+			Name:    name,
+			Created: sbom.GenerationTime,
+			BOM:     sbom.CycloneDXBOM,
+		}, nil
+	}
+
+	return Document{}, fmt.Errorf("%w: unknown image %s", ErrNotFound, ref)
+}
+
+// HandleExport writes the latest SBOM of a target. The target is selected
+// with the `target` (host or image) and `id` query parameters, and the
+// document format with the `format` parameter, CycloneDX by default.
+func (s *Store) HandleExport(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	format := FormatCycloneDXJSON
+	if f := query.Get("format"); f != "" {
+		var err error
+		if format, err = ParseFormat(f); err != nil {
+			httputils.SetJSONError(w, err, http.StatusBadRequest)
+			return
+		}
+	}
+
+	var doc Document
+	var err error
+	switch target := query.Get("target"); target {
+	case TargetHost:
+		doc, err = s.HostDocument()
+	case TargetImage:
+		id := query.Get("id")
+		if id == "" {
+			httputils.SetJSONError(w, errors.New("missing image id"), http.StatusBadRequest)
+			return
+		}
+		doc, err = s.ImageDocument(id)
+	default:
+		httputils.SetJSONError(w, fmt.Errorf("unknown target %q, expected %s or %s", target, TargetHost, TargetImage), http.StatusBadRequest)
+		return
+	}
+
+	if errors.Is(err, ErrNotFound) {
+		httputils.SetJSONError(w, err, http.StatusNotFound)
+		return
+	} else if err != nil {
+		httputils.SetJSONError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	var body bytes.Buffer
+	if err := Write(&body, doc, format); err != nil {
+		httputils.SetJSONError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body.Bytes())
+}
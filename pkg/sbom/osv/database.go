@@ -0,0 +1,277 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+// Package osv matches SBOM components against a local copy of an OSV
+// (https://ossf.github.io/osv-schema/) vulnerability database, so that
+// vulnerabilities can be reported without sending the inventories out.
+package osv
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxEntrySize is the maximum size of a single OSV entry
+const maxEntrySize = 16 * 1024 * 1024
+
+// Range types defined by the OSV schema
+const (
+	rangeTypeSemver    = "SEMVER"
+	rangeTypeEcosystem = "ECOSYSTEM"
+)
+
+// entry is an OSV vulnerability entry, restricted to the fields used for the
+// matching and the reporting
+type entry struct {
+	ID               string     `json:"id"`
+	Aliases          []string   `json:"aliases"`
+	Summary          string     `json:"summary"`
+	Withdrawn        string     `json:"withdrawn"`
+	Severity         []severity `json:"severity"`
+	Affected         []affected `json:"affected"`
+	DatabaseSpecific struct {
+		Severity any `json:"severity"`
+	} `json:"database_specific"`
+}
+
+type severity struct {
+	Type  string `json:"type"`
+	Score string `json:"score"`
+}
+
+type affected struct {
+	Package struct {
+		Ecosystem string `json:"ecosystem"`
+		Name      string `json:"name"`
+	} `json:"package"`
+	Ranges            []affectedRange `json:"ranges"`
+	Versions          []string        `json:"versions"`
+	EcosystemSpecific struct {
+		Severity any `json:"severity"`
+	} `json:"ecosystem_specific"`
+}
+
+type affectedRange struct {
+	Type   string       `json:"type"`
+	Events []rangeEvent `json:"events"`
+}
+
+type rangeEvent struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+	Limit        string `json:"limit,omitempty"`
+}
+
+// advisory is an affected package of an entry, as indexed in the database
+type advisory struct {
+	entry    *entry
+	affected *affected
+}
+
+// Database is an in-memory OSV vulnerability database, indexed by package
+type Database struct {
+	advisories map[packageKey][]advisory
+	entries    int
+}
+
+// packageKey identifies a package in an ecosystem. The ecosystem is the base
+// ecosystem, without its release suffix (`Debian` for `Debian:12`).
+type packageKey struct {
+	ecosystem string
+	name      string
+}
+
+// LoadDatabase loads an OSV database from the given path. The path is either
+// a JSON entry, a ZIP archive of JSON entries as published on
+// https://osv-vulnerabilities.storage.googleapis.com, or a directory
+// containing any number of both.
+func LoadDatabase(path string) (*Database, error) {
+	if path == "" {
+		return nil, errors.New("empty OSV database path")
+	}
+
+	db := &Database{
+		advisories: make(map[packageKey][]advisory),
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !fi.IsDir() {
+		if err := db.loadFile(path); err != nil {
+			return nil, err
+		}
+		return db, nil
+	}
+
+	err = filepath.WalkDir(path, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".json", ".zip":
+			return db.loadFile(path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// Len returns the number of vulnerabilities held by the database
+func (db *Database) Len() int {
+	return db.entries
+}
+
+func (db *Database) loadFile(path string) error {
+	if strings.ToLower(filepath.Ext(path)) == ".zip" {
+		return db.loadArchive(path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := db.loadEntry(f); err != nil {
+		return fmt.Errorf("failed to load OSV entry %s: %w", path, err)
+	}
+	return nil
+}
+
+func (db *Database) loadArchive(path string) error {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return fmt.Errorf("failed to open OSV archive %s: %w", path, err)
+	}
+	defer archive.Close()
+
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() || strings.ToLower(filepath.Ext(file.Name)) != ".json" {
+			continue
+		}
+
+		r, err := file.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open OSV entry %s in %s: %w", file.Name, path, err)
+		}
+		err = db.loadEntry(r)
+		r.Close()
+		if err != nil {
+			return fmt.Errorf("failed to load OSV entry %s in %s: %w", file.Name, path, err)
+		}
+	}
+	return nil
+}
+
+func (db *Database) loadEntry(r io.Reader) error {
+	var e entry
+	if err := json.NewDecoder(io.LimitReader(r, maxEntrySize)).Decode(&e); err != nil {
+		return err
+	}
+	db.addEntry(&e)
+	return nil
+}
+
+func (db *Database) addEntry(e *entry) {
+	if e.ID == "" || e.Withdrawn != "" {
+		return
+	}
+
+	indexed := false
+	for i := range e.Affected {
+		a := &e.Affected[i]
+		if a.Package.Ecosystem == "" || a.Package.Name == "" {
+			continue
+		}
+
+		key := newPackageKey(a.Package.Ecosystem, a.Package.Name)
+		db.advisories[key] = append(db.advisories[key], advisory{
+			entry:    e,
+			affected: a,
+		})
+		indexed = true
+	}
+
+	if indexed {
+		db.entries++
+	}
+}
+
+func newPackageKey(ecosystem, name string) packageKey {
+	ecosystem = baseEcosystem(ecosystem)
+	return packageKey{
+		ecosystem: ecosystem,
+		name:      normalizePackageName(ecosystem, name),
+	}
+}
+
+// baseEcosystem returns the ecosystem without its release suffix
+func baseEcosystem(ecosystem string) string {
+	base, _, _ := strings.Cut(ecosystem, ":")
+	return base
+}
+
+// normalizePackageName normalizes the package names of the ecosystems where
+// they are case-insensitive
+func normalizePackageName(ecosystem, name string) string {
+	switch ecosystem {
+	case "PyPI":
+		// https://peps.python.org/pep-0503/#normalized-names
+		name = strings.ToLower(name)
+		return strings.Map(func(r rune) rune {
+			if r == '_' || r == '.' {
+				return '-'
+			}
+			return r
+		}, name)
+	case "NuGet", "Packagist":
+		return strings.ToLower(name)
+	}
+	return name
+}
+
+// severityOf returns the severity of the vulnerability for the given affected
+// package, either as reported by the database or as a CVSS vector
+func (a *advisory) severityOf() (string, string) {
+	level := severityString(a.affected.EcosystemSpecific.Severity)
+	if level == "" {
+		level = severityString(a.entry.DatabaseSpecific.Severity)
+	}
+
+	var cvss string
+	for _, s := range a.entry.Severity {
+		if strings.HasPrefix(s.Type, "CVSS_") {
+			cvss = s.Score
+			break
+		}
+	}
+
+	return strings.ToUpper(level), cvss
+}
+
+func severityString(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return ""
+}
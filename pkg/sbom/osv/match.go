@@ -0,0 +1,199 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package osv
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/DataDog/agent-payload/v5/cyclonedx_v1_4"
+)
+
+// Finding is a vulnerability affecting a component of an SBOM
+type Finding struct {
+	ID            string   `json:"id"`
+	Aliases       []string `json:"aliases,omitempty"`
+	Summary       string   `json:"summary,omitempty"`
+	Severity      string   `json:"severity,omitempty"`
+	CVSS          string   `json:"cvss,omitempty"`
+	Ecosystem     string   `json:"ecosystem"`
+	Package       string   `json:"package"`
+	Version       string   `json:"version"`
+	Purl          string   `json:"purl,omitempty"`
+	BomRef        string   `json:"bom_ref,omitempty"`
+	FixedVersions []string `json:"fixed_versions,omitempty"`
+}
+
+// Match returns the vulnerabilities affecting the components of the SBOM,
+// sorted by vulnerability ID and package. The components are identified by
+// their purl, the ones without purl are ignored.
+func (db *Database) Match(bom *cyclonedx_v1_4.Bom) []Finding {
+	if bom == nil {
+		return nil
+	}
+
+	var findings []Finding
+	var walk func(components []*cyclonedx_v1_4.Component)
+	walk = func(components []*cyclonedx_v1_4.Component) {
+		for _, c := range components {
+			findings = append(findings, db.matchComponent(c)...)
+			walk(c.GetComponents())
+		}
+	}
+	walk(bom.GetComponents())
+
+	sort.Slice(findings, func(i, j int) bool {
+		if findings[i].ID != findings[j].ID {
+			return findings[i].ID < findings[j].ID
+		}
+		if findings[i].Package != findings[j].Package {
+			return findings[i].Package < findings[j].Package
+		}
+		return findings[i].Purl < findings[j].Purl
+	})
+	return findings
+}
+
+func (db *Database) matchComponent(c *cyclonedx_v1_4.Component) []Finding {
+	if c.GetPurl() == "" {
+		return nil
+	}
+
+	properties := make(map[string]string, len(c.GetProperties()))
+	for _, p := range c.GetProperties() {
+		properties[p.GetName()] = p.GetValue()
+	}
+
+	id, ok := identify(c.GetPurl(), properties)
+	if !ok {
+		return nil
+	}
+
+	var findings []Finding
+	// a vulnerability is reported once per component, even when it affects
+	// both the binary and the source package
+	seen := make(map[string]struct{})
+	for _, pkg := range id.packages {
+		for _, adv := range db.advisories[newPackageKey(id.ecosystem, pkg.name)] {
+			if _, found := seen[adv.entry.ID]; found {
+				continue
+			}
+			if !matchesEcosystem(adv.affected.Package.Ecosystem, id.ecosystem) {
+				continue
+			}
+			if !adv.affected.affects(pkg.version) {
+				continue
+			}
+			seen[adv.entry.ID] = struct{}{}
+
+			level, cvss := adv.severityOf()
+			findings = append(findings, Finding{
+				ID:            adv.entry.ID,
+				Aliases:       adv.entry.Aliases,
+				Summary:       adv.entry.Summary,
+				Severity:      level,
+				CVSS:          cvss,
+				Ecosystem:     adv.affected.Package.Ecosystem,
+				Package:       pkg.name,
+				Version:       pkg.version,
+				Purl:          c.GetPurl(),
+				BomRef:        c.GetBomRef(),
+				FixedVersions: adv.affected.fixedVersions(),
+			})
+		}
+	}
+	return findings
+}
+
+// affects returns whether the given version of the package is affected, either
+// being explicitly listed or belonging to one of the ranges
+func (a *affected) affects(version string) bool {
+	if slices.Contains(a.Versions, version) {
+		return true
+	}
+
+	for _, r := range a.Ranges {
+		if r.Type != rangeTypeSemver && r.Type != rangeTypeEcosystem {
+			continue
+		}
+		if r.contains(version, comparerFor(r.Type, a.Package.Ecosystem)) {
+			return true
+		}
+	}
+	return false
+}
+
+// contains evaluates the events of the range in version order: the version is
+// affected when the last event lower than or equal to it is an introduction.
+//
+// reference: https://ossf.github.io/osv-schema/#evaluation
+func (r *affectedRange) contains(version string, compare compareFunc) bool {
+	events := make([]rangeEvent, 0, len(r.Events))
+	for _, e := range r.Events {
+		if e.Limit == "" {
+			events = append(events, e)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return compareEvents(events[i], events[j], compare) < 0
+	})
+
+	affected := false
+	for _, e := range events {
+		switch {
+		case e.Introduced != "":
+			if e.Introduced == "0" || compare(version, e.Introduced) >= 0 {
+				affected = true
+			}
+		case e.Fixed != "":
+			if compare(version, e.Fixed) >= 0 {
+				affected = false
+			}
+		case e.LastAffected != "":
+			if compare(version, e.LastAffected) > 0 {
+				affected = false
+			}
+		}
+	}
+	return affected
+}
+
+func compareEvents(e1, e2 rangeEvent, compare compareFunc) int {
+	v1, v2 := e1.version(), e2.version()
+	switch {
+	case v1 == v2:
+		return 0
+	case v1 == "0":
+		return -1
+	case v2 == "0":
+		return 1
+	default:
+		return compare(v1, v2)
+	}
+}
+
+func (e rangeEvent) version() string {
+	switch {
+	case e.Introduced != "":
+		return e.Introduced
+	case e.Fixed != "":
+		return e.Fixed
+	default:
+		return e.LastAffected
+	}
+}
+
+func (a *affected) fixedVersions() []string {
+	var fixed []string
+	for _, r := range a.Ranges {
+		for _, e := range r.Events {
+			if e.Fixed != "" && !slices.Contains(fixed, e.Fixed) {
+				fixed = append(fixed, e.Fixed)
+			}
+		}
+	}
+	return fixed
+}
@@ -0,0 +1,235 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package osv
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DataDog/agent-payload/v5/cyclonedx_v1_4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const debianEntry = `{
+  "id": "DSA-5678-1",
+  "aliases": ["CVE-2024-0001"],
+  "summary": "openssl - security update",
+  "affected": [{
+    "package": {"ecosystem": "Debian:12", "name": "openssl"},
+    "ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}, {"fixed": "3.0.13-1~deb12u1"}]}]
+  }, {
+    "package": {"ecosystem": "Debian:11", "name": "openssl"},
+    "ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}, {"fixed": "1.1.1w-0+deb11u2"}]}]
+  }]
+}`
+
+const npmEntry = `{
+  "id": "GHSA-aaaa-bbbb-cccc",
+  "aliases": ["CVE-2024-0002"],
+  "summary": "Prototype pollution in lodash",
+  "severity": [{"type": "CVSS_V3", "score": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"}],
+  "affected": [{
+    "package": {"ecosystem": "npm", "name": "lodash"},
+    "ranges": [{"type": "SEMVER", "events": [{"introduced": "4.0.0"}, {"fixed": "4.17.21"}]}]
+  }],
+  "database_specific": {"severity": "HIGH"}
+}`
+
+const pypiEntry = `{
+  "id": "PYSEC-2024-1",
+  "affected": [{
+    "package": {"ecosystem": "PyPI", "name": "Py_YAML"},
+    "ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "5.1"}, {"last_affected": "5.3.1"}]}],
+    "versions": ["4.2b1"]
+  }]
+}`
+
+const withdrawnEntry = `{
+  "id": "GHSA-withdrawn",
+  "withdrawn": "2024-01-01T00:00:00Z",
+  "affected": [{
+    "package": {"ecosystem": "npm", "name": "lodash"},
+    "ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}]}]
+  }]
+}`
+
+func writeDatabase(t *testing.T) string {
+	dir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "DSA-5678-1.json"), []byte(debianEntry), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("not an entry"), 0644))
+
+	f, err := os.Create(filepath.Join(dir, "all.zip"))
+	require.NoError(t, err)
+	w := zip.NewWriter(f)
+	for name, content := range map[string]string{
+		"GHSA-aaaa-bbbb-cccc.json": npmEntry,
+		"PYSEC-2024-1.json":        pypiEntry,
+		"GHSA-withdrawn.json":      withdrawnEntry,
+	} {
+		fw, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = fw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+
+	return dir
+}
+
+func component(purl string, properties map[string]string) *cyclonedx_v1_4.Component {
+	c := &cyclonedx_v1_4.Component{
+		BomRef: &purl,
+		Purl:   &purl,
+	}
+	for name, value := range properties {
+		c.Properties = append(c.Properties, &cyclonedx_v1_4.Property{Name: name, Value: &value})
+	}
+	return c
+}
+
+func TestLoadDatabase(t *testing.T) {
+	db, err := LoadDatabase(writeDatabase(t))
+	require.NoError(t, err)
+	assert.Equal(t, 3, db.Len())
+
+	_, err = LoadDatabase(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
+
+func TestMatch(t *testing.T) {
+	db, err := LoadDatabase(writeDatabase(t))
+	require.NoError(t, err)
+
+	bom := &cyclonedx_v1_4.Bom{
+		Components: []*cyclonedx_v1_4.Component{
+			// binary package of the vulnerable source package
+			component("pkg:deb/debian/libssl3@3.0.11-1~deb12u2?arch=amd64&distro=debian-12.4", map[string]string{
+				srcNameProperty: "openssl",
+			}),
+			// fixed version
+			component("pkg:deb/debian/openssl@3.0.13-1~deb12u1?arch=amd64&distro=debian-12.4", nil),
+			// other release
+			component("pkg:deb/ubuntu/openssl@3.0.2-0ubuntu1?distro=ubuntu-22.04", nil),
+			{
+				Name:    "application",
+				Version: "1.0.0",
+				Components: []*cyclonedx_v1_4.Component{
+					component("pkg:npm/lodash@4.17.20", nil),
+					component("pkg:npm/lodash@4.17.21", nil),
+					component("pkg:pypi/py-yaml@5.3.1", nil),
+					component("pkg:pypi/py-yaml@5.4", nil),
+					component("pkg:pypi/py-yaml@4.2b1", nil),
+				},
+			},
+		},
+	}
+
+	findings := db.Match(bom)
+	assert.Equal(t, []Finding{
+		{
+			ID:            "DSA-5678-1",
+			Aliases:       []string{"CVE-2024-0001"},
+			Summary:       "openssl - security update",
+			Ecosystem:     "Debian:12",
+			Package:       "openssl",
+			Version:       "3.0.11-1~deb12u2",
+			Purl:          "pkg:deb/debian/libssl3@3.0.11-1~deb12u2?arch=amd64&distro=debian-12.4",
+			BomRef:        "pkg:deb/debian/libssl3@3.0.11-1~deb12u2?arch=amd64&distro=debian-12.4",
+			FixedVersions: []string{"3.0.13-1~deb12u1"},
+		},
+		{
+			ID:            "GHSA-aaaa-bbbb-cccc",
+			Aliases:       []string{"CVE-2024-0002"},
+			Summary:       "Prototype pollution in lodash",
+			Severity:      "HIGH",
+			CVSS:          "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+			Ecosystem:     "npm",
+			Package:       "lodash",
+			Version:       "4.17.20",
+			Purl:          "pkg:npm/lodash@4.17.20",
+			BomRef:        "pkg:npm/lodash@4.17.20",
+			FixedVersions: []string{"4.17.21"},
+		},
+		{
+			ID:        "PYSEC-2024-1",
+			Ecosystem: "PyPI",
+			Package:   "py-yaml",
+			Version:   "4.2b1",
+			Purl:      "pkg:pypi/py-yaml@4.2b1",
+			BomRef:    "pkg:pypi/py-yaml@4.2b1",
+		},
+		{
+			ID:        "PYSEC-2024-1",
+			Ecosystem: "PyPI",
+			Package:   "py-yaml",
+			Version:   "5.3.1",
+			Purl:      "pkg:pypi/py-yaml@5.3.1",
+			BomRef:    "pkg:pypi/py-yaml@5.3.1",
+		},
+	}, findings)
+
+	assert.Empty(t, db.Match(nil))
+}
+
+func TestRangeContains(t *testing.T) {
+	r := affectedRange{
+		Type: rangeTypeEcosystem,
+		Events: []rangeEvent{
+			{Introduced: "1.0"},
+			{Fixed: "1.2"},
+			{Introduced: "2.0"},
+			{LastAffected: "2.1"},
+		},
+	}
+
+	for version, expected := range map[string]bool{
+		"0.9":   false,
+		"1.0":   true,
+		"1.1.5": true,
+		"1.2":   false,
+		"1.9":   false,
+		"2.0":   true,
+		"2.1":   true,
+		"2.1.1": false,
+	} {
+		assert.Equal(t, expected, r.contains(version, compareGeneric), version)
+	}
+}
+
+func TestHandleGetFindings(t *testing.T) {
+	store := NewStore()
+	store.Set(Report{TargetType: TargetTypeHost, TargetID: "my-host", GeneratedAt: time.Unix(0, 0).UTC()})
+	store.Set(Report{TargetType: TargetTypeContainerImage, TargetID: "sha256:1234", Findings: []Finding{{ID: "CVE-2024-0001"}}})
+	store.Set(Report{TargetType: TargetTypeContainerImage, TargetID: "sha256:5678"})
+	store.Delete(TargetTypeContainerImage, "sha256:5678")
+
+	rec := httptest.NewRecorder()
+	store.HandleGetFindings(rec, httptest.NewRequest(http.MethodGet, "/sbom/vulnerabilities", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var reports []Report
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &reports))
+	require.Len(t, reports, 2)
+	assert.Equal(t, TargetTypeContainerImage, reports[0].TargetType)
+	assert.Equal(t, "CVE-2024-0001", reports[0].Findings[0].ID)
+	assert.Equal(t, TargetTypeHost, reports[1].TargetType)
+	assert.Empty(t, reports[1].Findings)
+
+	rec = httptest.NewRecorder()
+	store.HandleGetFindings(rec, httptest.NewRequest(http.MethodGet, "/sbom/vulnerabilities?target_type=host", nil))
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &reports))
+	require.Len(t, reports, 1)
+	assert.Equal(t, "my-host", reports[0].TargetID)
+}
@@ -0,0 +1,191 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package osv
+
+import (
+	"strings"
+
+	"github.com/package-url/packageurl-go"
+)
+
+// Properties set by Trivy on the OS package components
+const (
+	srcNameProperty    = "aquasecurity:trivy:SrcName"
+	srcVersionProperty = "aquasecurity:trivy:SrcVersion"
+	srcReleaseProperty = "aquasecurity:trivy:SrcRelease"
+	srcEpochProperty   = "aquasecurity:trivy:SrcEpoch"
+)
+
+// pkgIdentity identifies a package in the OSV nomenclature
+type pkgIdentity struct {
+	// ecosystem is the OSV ecosystem, including the distribution release
+	// when known (`Debian:12`)
+	ecosystem string
+	packages  []pkgVersion
+}
+
+// pkgVersion is a package name and version
+type pkgVersion struct {
+	name    string
+	version string
+}
+
+// purlTypeEcosystems maps the purl types of the language packages to their
+// OSV ecosystem
+var purlTypeEcosystems = map[string]string{
+	packageurl.TypeCargo:    "crates.io",
+	packageurl.TypeComposer: "Packagist",
+	packageurl.TypeGem:      "RubyGems",
+	packageurl.TypeGolang:   "Go",
+	packageurl.TypeHex:      "Hex",
+	packageurl.TypeMaven:    "Maven",
+	packageurl.TypeNPM:      "npm",
+	packageurl.TypeNuget:    "NuGet",
+	packageurl.TypePub:      "Pub",
+	packageurl.TypePyPi:     "PyPI",
+}
+
+// distroEcosystems maps the namespaces of the OS package purls to their OSV
+// ecosystem
+var distroEcosystems = map[string]string{
+	"alma":       "AlmaLinux",
+	"almalinux":  "AlmaLinux",
+	"alpine":     "Alpine",
+	"chainguard": "Chainguard",
+	"debian":     "Debian",
+	"opensuse":   "openSUSE",
+	"redhat":     "Red Hat",
+	"rocky":      "Rocky Linux",
+	"sles":       "SUSE",
+	"suse":       "SUSE",
+	"ubuntu":     "Ubuntu",
+	"wolfi":      "Wolfi",
+}
+
+// identify returns the OSV identity of a package from its purl. The
+// properties give the source package of the OS packages, which is the one the
+// OS advisories refer to.
+func identify(purl string, properties map[string]string) (pkgIdentity, bool) {
+	p, err := packageurl.FromString(purl)
+	if err != nil || p.Name == "" || p.Version == "" {
+		return pkgIdentity{}, false
+	}
+	qualifiers := p.Qualifiers.Map()
+
+	switch p.Type {
+	case packageurl.TypeDebian, packageurl.TypeRPM, packageurl.TypeApk:
+		ecosystem, ok := distroEcosystems[strings.ToLower(p.Namespace)]
+		if !ok {
+			return pkgIdentity{}, false
+		}
+
+		id := pkgIdentity{
+			ecosystem: distroEcosystem(ecosystem, strings.ToLower(p.Namespace), qualifiers["distro"]),
+			packages: []pkgVersion{{
+				name:    p.Name,
+				version: withEpoch(qualifiers["epoch"], p.Version),
+			}},
+		}
+		if srcName := properties[srcNameProperty]; srcName != "" && srcName != p.Name {
+			srcVersion := id.packages[0].version
+			if v := properties[srcVersionProperty]; v != "" {
+				if release := properties[srcReleaseProperty]; release != "" {
+					v += "-" + release
+				}
+				srcVersion = withEpoch(properties[srcEpochProperty], v)
+			}
+			id.packages = append(id.packages, pkgVersion{
+				name:    srcName,
+				version: srcVersion,
+			})
+		}
+		return id, true
+
+	default:
+		ecosystem, ok := purlTypeEcosystems[p.Type]
+		if !ok {
+			return pkgIdentity{}, false
+		}
+
+		name := p.Name
+		if p.Namespace != "" {
+			switch p.Type {
+			case packageurl.TypeMaven:
+				name = p.Namespace + ":" + p.Name
+			case packageurl.TypeNPM, packageurl.TypeGolang, packageurl.TypeComposer:
+				name = p.Namespace + "/" + p.Name
+			}
+		}
+
+		return pkgIdentity{
+			ecosystem: ecosystem,
+			packages: []pkgVersion{{
+				name:    name,
+				version: p.Version,
+			}},
+		}, true
+	}
+}
+
+// withEpoch prefixes the version with its epoch, if any
+func withEpoch(epoch, version string) string {
+	if epoch == "" || epoch == "0" || strings.Contains(version, ":") {
+		return version
+	}
+	return epoch + ":" + version
+}
+
+// distroEcosystem returns the ecosystem of an OS package, qualified with the
+// release of the distribution when the OSV database splits the advisories by
+// release
+func distroEcosystem(ecosystem, namespace, distro string) string {
+	// the distro qualifier is either `<namespace>-<version>` or `<version>`
+	release := strings.TrimPrefix(distro, namespace+"-")
+	if release == "" {
+		return ecosystem
+	}
+
+	switch ecosystem {
+	case "Debian", "AlmaLinux", "Rocky Linux":
+		// major release: Debian:12, AlmaLinux:9
+		major, _, _ := strings.Cut(release, ".")
+		return ecosystem + ":" + major
+	case "Ubuntu":
+		// major and minor release: Ubuntu:22.04
+		parts := strings.SplitN(release, ".", 3)
+		if len(parts) < 2 {
+			return ecosystem
+		}
+		return ecosystem + ":" + parts[0] + "." + parts[1]
+	case "Alpine":
+		// major and minor release: Alpine:v3.18
+		parts := strings.SplitN(strings.TrimPrefix(release, "v"), ".", 3)
+		if len(parts) < 2 {
+			return ecosystem
+		}
+		return ecosystem + ":v" + parts[0] + "." + parts[1]
+	default:
+		return ecosystem
+	}
+}
+
+// matchesEcosystem returns whether the ecosystem of an affected package
+// applies to the ecosystem of a component. An affected ecosystem without
+// release applies to all the releases, and a component ecosystem without
+// release matches all the releases.
+func matchesEcosystem(affectedEcosystem, componentEcosystem string) bool {
+	if affectedEcosystem == componentEcosystem {
+		return true
+	}
+	if baseEcosystem(affectedEcosystem) != baseEcosystem(componentEcosystem) {
+		return false
+	}
+	if !strings.Contains(affectedEcosystem, ":") || !strings.Contains(componentEcosystem, ":") {
+		return true
+	}
+	// Ubuntu:22.04:LTS applies to Ubuntu:22.04
+	return strings.HasPrefix(affectedEcosystem, componentEcosystem+":")
+}
@@ -0,0 +1,108 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package osv
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	httputils "github.com/DataDog/datadog-agent/pkg/util/http"
+)
+
+// Target types of the reports
+const (
+	TargetTypeHost           = "host"
+	TargetTypeContainerImage = "container_image"
+)
+
+// Report holds the vulnerabilities found in the latest SBOM of a host or a
+// container image
+type Report struct {
+	TargetType  string    `json:"target_type"`
+	TargetID    string    `json:"target_id"`
+	GeneratedAt time.Time `json:"generated_at"`
+	Findings    []Finding `json:"findings"`
+}
+
+// Store holds the latest report of each target
+type Store struct {
+	mu      sync.RWMutex
+	reports map[storeKey]Report
+}
+
+type storeKey struct {
+	targetType string
+	targetID   string
+}
+
+// NewStore returns an empty store
+func NewStore() *Store {
+	return &Store{
+		reports: make(map[storeKey]Report),
+	}
+}
+
+// Set stores the report, replacing the previous report of the same target
+func (s *Store) Set(report Report) {
+	if report.Findings == nil {
+		report.Findings = []Finding{}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reports[storeKey{report.TargetType, report.TargetID}] = report
+}
+
+// Delete removes the report of a target
+func (s *Store) Delete(targetType, targetID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.reports, storeKey{targetType, targetID})
+}
+
+// Reports returns the stored reports, sorted by target
+func (s *Store) Reports() []Report {
+	s.mu.RLock()
+	reports := make([]Report, 0, len(s.reports))
+	for _, r := range s.reports {
+		reports = append(reports, r)
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(reports, func(i, j int) bool {
+		if reports[i].TargetType != reports[j].TargetType {
+			return reports[i].TargetType < reports[j].TargetType
+		}
+		return reports[i].TargetID < reports[j].TargetID
+	})
+	return reports
+}
+
+// HandleGetFindings writes the reports of the store. The reports can be
+// filtered with the `target_type` and `target_id` query parameters.
+func (s *Store) HandleGetFindings(w http.ResponseWriter, r *http.Request) {
+	targetType := r.URL.Query().Get("target_type")
+	targetID := r.URL.Query().Get("target_id")
+
+	reports := make([]Report, 0)
+	for _, report := range s.Reports() {
+		if (targetType == "" || report.TargetType == targetType) && (targetID == "" || report.TargetID == targetID) {
+			reports = append(reports, report)
+		}
+	}
+
+	body, err := json.Marshal(reports)
+	if err != nil {
+		httputils.SetJSONError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
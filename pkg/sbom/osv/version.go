@@ -0,0 +1,156 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package osv
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Masterminds/semver/v3"
+	apkversion "github.com/knqyf263/go-apk-version"
+	debversion "github.com/knqyf263/go-deb-version"
+	rpmversion "github.com/knqyf263/go-rpm-version"
+)
+
+// compareFunc compares two versions, returning -1, 0 or 1
+type compareFunc func(v1, v2 string) int
+
+// comparerFor returns the function comparing the versions of the given range
+// type and ecosystem
+func comparerFor(rangeType, ecosystem string) compareFunc {
+	if rangeType == rangeTypeSemver {
+		return compareSemver
+	}
+
+	switch baseEcosystem(ecosystem) {
+	case "Debian", "Ubuntu":
+		return compareDeb
+	case "Red Hat", "AlmaLinux", "Rocky Linux", "openSUSE", "SUSE", "Mageia", "openEuler":
+		return compareRPM
+	case "Alpine", "Wolfi", "Chainguard":
+		return compareAPK
+	case "Go", "npm", "crates.io", "Hex", "Pub":
+		return compareSemver
+	default:
+		return compareGeneric
+	}
+}
+
+func compareSemver(v1, v2 string) int {
+	sv1, err1 := semver.NewVersion(v1)
+	sv2, err2 := semver.NewVersion(v2)
+	if err1 != nil || err2 != nil {
+		return compareGeneric(v1, v2)
+	}
+	return sv1.Compare(sv2)
+}
+
+func compareDeb(v1, v2 string) int {
+	dv1, err1 := debversion.NewVersion(v1)
+	dv2, err2 := debversion.NewVersion(v2)
+	if err1 != nil || err2 != nil {
+		return compareGeneric(v1, v2)
+	}
+	return sign(dv1.Compare(dv2))
+}
+
+func compareRPM(v1, v2 string) int {
+	return sign(rpmversion.NewVersion(v1).Compare(rpmversion.NewVersion(v2)))
+}
+
+func compareAPK(v1, v2 string) int {
+	av1, err1 := apkversion.NewVersion(v1)
+	av2, err2 := apkversion.NewVersion(v2)
+	if err1 != nil || err2 != nil {
+		return compareGeneric(v1, v2)
+	}
+	return sign(av1.Compare(av2))
+}
+
+// sign normalizes the result of a comparison to -1, 0 or 1
+func sign(c int) int {
+	switch {
+	case c < 0:
+		return -1
+	case c > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// compareGeneric compares versions made of numeric and alphabetic parts,
+// numeric parts being compared numerically. A version followed by an
+// alphabetic part is lower than the version alone (`1.0rc1` < `1.0`), which
+// approximates the pre-release conventions of most ecosystems.
+func compareGeneric(v1, v2 string) int {
+	p1, p2 := versionParts(v1), versionParts(v2)
+	for i := 0; i < len(p1) || i < len(p2); i++ {
+		switch {
+		case i >= len(p1):
+			if isNumeric(p2[i]) {
+				return -1
+			}
+			return 1
+		case i >= len(p2):
+			if isNumeric(p1[i]) {
+				return 1
+			}
+			return -1
+		}
+
+		n1, n2 := isNumeric(p1[i]), isNumeric(p2[i])
+		switch {
+		case n1 && n2:
+			i1, _ := strconv.ParseUint(strings.TrimLeft(p1[i], "0"), 10, 64)
+			i2, _ := strconv.ParseUint(strings.TrimLeft(p2[i], "0"), 10, 64)
+			if i1 != i2 {
+				if i1 < i2 {
+					return -1
+				}
+				return 1
+			}
+		case n1:
+			return 1
+		case n2:
+			return -1
+		default:
+			if c := strings.Compare(strings.ToLower(p1[i]), strings.ToLower(p2[i])); c != 0 {
+				return c
+			}
+		}
+	}
+	return 0
+}
+
+// versionParts splits a version into its numeric and alphabetic parts,
+// dropping the separators
+func versionParts(v string) []string {
+	var parts []string
+	start := -1
+	for i, r := range v {
+		if start >= 0 && (!isAlnum(r) || unicode.IsDigit(r) != unicode.IsDigit(rune(v[start]))) {
+			parts = append(parts, v[start:i])
+			start = -1
+		}
+		if start < 0 && isAlnum(r) {
+			start = i
+		}
+	}
+	if start >= 0 {
+		parts = append(parts, v[start:])
+	}
+	return parts
+}
+
+func isAlnum(r rune) bool {
+	return unicode.IsDigit(r) || unicode.IsLetter(r)
+}
+
+func isNumeric(part string) bool {
+	return part != "" && unicode.IsDigit(rune(part[0]))
+}
@@ -0,0 +1,106 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package osv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareVersions(t *testing.T) {
+	for _, tc := range []struct {
+		ecosystem string
+		v1        string
+		v2        string
+		expected  int
+	}{
+		{"Debian:12", "3.0.11-1~deb12u2", "3.0.13-1~deb12u1", -1},
+		{"Debian:12", "1:2.0-1", "3.0-1", 1},
+		{"Debian:12", "1.0~rc1-1", "1.0-1", -1},
+		{"Ubuntu:22.04", "3.0.2-0ubuntu1.10", "3.0.2-0ubuntu1.9", 1},
+		{"Red Hat", "1.1.1k-7.el8_6", "1.1.1k-12.el8_9", -1},
+		{"AlmaLinux:9", "1:3.0.7-25.el9", "1:3.0.7-25.el9", 0},
+		{"Alpine:v3.18", "3.1.4-r1", "3.1.4-r5", -1},
+		{"Alpine:v3.18", "1.36.1-r5", "1.36.1_rc1-r0", 1},
+		{"npm", "4.17.20", "4.17.21", -1},
+		{"npm", "1.0.0-beta.2", "1.0.0", -1},
+		{"Go", "v0.17.0", "0.9.1", 1},
+		{"PyPI", "5.10", "5.9", 1},
+		{"PyPI", "2.0rc1", "2.0", -1},
+		{"Maven", "2.17.1", "2.17.1", 0},
+		{"Maven", "2.15.0-rc1", "2.15.0", -1},
+		{"RubyGems", "1.13.10", "1.13.9.1", 1},
+	} {
+		compare := comparerFor(rangeTypeEcosystem, tc.ecosystem)
+		assert.Equal(t, tc.expected, compare(tc.v1, tc.v2), "%s: %s <=> %s", tc.ecosystem, tc.v1, tc.v2)
+		assert.Equal(t, -tc.expected, compare(tc.v2, tc.v1), "%s: %s <=> %s", tc.ecosystem, tc.v2, tc.v1)
+	}
+}
+
+func TestIdentify(t *testing.T) {
+	for _, tc := range []struct {
+		purl       string
+		properties map[string]string
+		expected   pkgIdentity
+	}{
+		{
+			purl: "pkg:deb/debian/libssl3@3.0.11-1~deb12u2?arch=amd64&distro=debian-12.4",
+			properties: map[string]string{
+				srcNameProperty:    "openssl",
+				srcVersionProperty: "3.0.11",
+				srcReleaseProperty: "1~deb12u2",
+			},
+			expected: pkgIdentity{ecosystem: "Debian:12", packages: []pkgVersion{
+				{name: "libssl3", version: "3.0.11-1~deb12u2"},
+				{name: "openssl", version: "3.0.11-1~deb12u2"},
+			}},
+		},
+		{
+			purl:     "pkg:rpm/alma/openssl-libs@3.0.7-25.el9?arch=x86_64&epoch=1&distro=alma-9.3",
+			expected: pkgIdentity{ecosystem: "AlmaLinux:9", packages: []pkgVersion{{name: "openssl-libs", version: "1:3.0.7-25.el9"}}},
+		},
+		{
+			purl:     "pkg:apk/alpine/busybox@1.36.1-r5?arch=x86_64&distro=3.18.4",
+			expected: pkgIdentity{ecosystem: "Alpine:v3.18", packages: []pkgVersion{{name: "busybox", version: "1.36.1-r5"}}},
+		},
+		{
+			purl:     "pkg:deb/ubuntu/bash@5.1-6ubuntu1?distro=ubuntu-22.04",
+			expected: pkgIdentity{ecosystem: "Ubuntu:22.04", packages: []pkgVersion{{name: "bash", version: "5.1-6ubuntu1"}}},
+		},
+		{
+			purl:     "pkg:npm/%40babel/core@7.23.0",
+			expected: pkgIdentity{ecosystem: "npm", packages: []pkgVersion{{name: "@babel/core", version: "7.23.0"}}},
+		},
+		{
+			purl:     "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1",
+			expected: pkgIdentity{ecosystem: "Maven", packages: []pkgVersion{{name: "org.apache.logging.log4j:log4j-core", version: "2.14.1"}}},
+		},
+		{
+			purl:     "pkg:golang/golang.org/x/net@v0.17.0",
+			expected: pkgIdentity{ecosystem: "Go", packages: []pkgVersion{{name: "golang.org/x/net", version: "v0.17.0"}}},
+		},
+	} {
+		id, ok := identify(tc.purl, tc.properties)
+		assert.True(t, ok, tc.purl)
+		assert.Equal(t, tc.expected, id, tc.purl)
+	}
+
+	for _, purl := range []string{"", "not a purl", "pkg:npm/lodash", "pkg:deb/unknown/bash@5.1", "pkg:docker/nginx@1.25"} {
+		_, ok := identify(purl, nil)
+		assert.False(t, ok, purl)
+	}
+}
+
+func TestMatchesEcosystem(t *testing.T) {
+	assert.True(t, matchesEcosystem("Debian:12", "Debian:12"))
+	assert.True(t, matchesEcosystem("Debian", "Debian:12"))
+	assert.True(t, matchesEcosystem("Debian:12", "Debian"))
+	assert.True(t, matchesEcosystem("Ubuntu:22.04:LTS", "Ubuntu:22.04"))
+	assert.False(t, matchesEcosystem("Debian:11", "Debian:12"))
+	assert.False(t, matchesEcosystem("Ubuntu:22.04:LTS", "Ubuntu:22.10"))
+	assert.False(t, matchesEcosystem("Debian:12", "Ubuntu:22.04"))
+}
@@ -54,6 +54,16 @@ func StartRuntimeSecurity(log log.Component, config config.Component, hostname s
 		return nil, err
 	}
 
+	// write the events to the local sinks too, if any
+	localSinkReporter, err := reporter.NewLocalSinkReporter(hostname, runtimeReporter, config)
+	if err != nil {
+		return nil, err
+	}
+	if localSinkReporter != nil {
+		stopper.Add(localSinkReporter)
+		runtimeReporter = localSinkReporter
+	}
+
 	secInfoEndpoints, secInfoCtx, err := common.NewLogContextSecInfo()
 	if err != nil {
 		_ = log.Error(err)
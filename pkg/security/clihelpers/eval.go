@@ -55,19 +55,7 @@ func evalRule(provider rules.PolicyProvider, decoder *json.Decoder, evalArgs Eva
 	var report EvalReport
 
 	// we need to initialize the model early on to handle legacy field when setting field values in dataFromJSON
-	var m eval.Model
-	var eventCtor func() eval.Event
-	if evalArgs.UseWindowsModel {
-		wmodel := &winmodel.Model{}
-		wmodel.SetLegacyFields(winmodel.SECLLegacyFields)
-		m = wmodel
-		eventCtor = newFakeWindowsEvent
-	} else {
-		lmodel := &model.Model{}
-		lmodel.SetLegacyFields(model.SECLLegacyFields)
-		m = lmodel
-		eventCtor = newFakeEvent
-	}
+	m, eventCtor := newEvalModel(evalArgs.UseWindowsModel)
 
 	event, variables, err := dataFromJSON(decoder)
 	if err != nil {
@@ -76,6 +64,42 @@ func evalRule(provider rules.PolicyProvider, decoder *json.Decoder, evalArgs Eva
 
 	report.Event = event
 
+	ruleSet, err := loadEvalRuleSet(provider, m, eventCtor, evalArgs.UseWindowsModel, variables, &rules.RuleIDFilter{
+		ID: evalArgs.RuleID,
+	})
+	if err != nil {
+		return report, err
+	}
+
+	if !evalArgs.UseWindowsModel {
+		approvers, _, _, err := ruleSet.GetApprovers(kfilters.GetCapababilities())
+		if err != nil {
+			report.Error = err
+		} else {
+			report.Approvers = approvers
+		}
+	}
+
+	report.Succeeded = ruleSet.Evaluate(event)
+
+	return report, nil
+}
+
+func newEvalModel(useWindowsModel bool) (eval.Model, func() eval.Event) {
+	if useWindowsModel {
+		wmodel := &winmodel.Model{}
+		wmodel.SetLegacyFields(winmodel.SECLLegacyFields)
+		return wmodel, newFakeWindowsEvent
+	}
+
+	lmodel := &model.Model{}
+	lmodel.SetLegacyFields(model.SECLLegacyFields)
+	return lmodel, newFakeEvent
+}
+
+// loadEvalRuleSet loads the rules accepted by the given filters in a new rule set, and sets the variables to the
+// values provided by the test data
+func loadEvalRuleSet(provider rules.PolicyProvider, m eval.Model, eventCtor func() eval.Event, useWindowsModel bool, variables map[string]eval.SECLVariable, ruleFilters ...rules.RuleFilter) (*rules.RuleSet, error) {
 	// store the variables values so that we can reapply them after policies are loaded
 	variablesValues := make(map[string]any)
 	for k, v := range variables {
@@ -92,30 +116,26 @@ func evalRule(provider rules.PolicyProvider, decoder *json.Decoder, evalArgs Eva
 	enabled := map[eval.EventType]bool{"*": true}
 
 	ruleOpts := rules.NewRuleOpts(enabled)
-	evalOpts := newEvalOpts(evalArgs.UseWindowsModel).WithVariables(variables)
+	evalOpts := newEvalOpts(useWindowsModel).WithVariables(variables)
 	ruleOpts.WithLogger(seclog.DefaultLogger)
 
 	agentVersionFilter, err := newAgentVersionFilter()
 	if err != nil {
-		return report, fmt.Errorf("failed to create agent version filter: %w", err)
+		return nil, fmt.Errorf("failed to create agent version filter: %w", err)
 	}
 
 	loaderOpts := rules.PolicyLoaderOpts{
 		MacroFilters: []rules.MacroFilter{
 			agentVersionFilter,
 		},
-		RuleFilters: []rules.RuleFilter{
-			&rules.RuleIDFilter{
-				ID: evalArgs.RuleID,
-			},
-		},
+		RuleFilters: ruleFilters,
 	}
 
 	loader := rules.NewPolicyLoader(provider)
 
 	ruleSet := rules.NewRuleSet(m, eventCtor, ruleOpts, evalOpts)
 	if _, err := ruleSet.LoadPolicies(loader, loaderOpts); err.ErrorOrNil() != nil {
-		return report, err
+		return nil, err
 	}
 
 	// reapply the variables values
@@ -124,24 +144,13 @@ func evalRule(provider rules.PolicyProvider, decoder *json.Decoder, evalArgs Eva
 		if _, ok := vars[k]; ok {
 			if mv, ok := vars[k].(eval.MutableVariable); ok {
 				if err := mv.Set(nil, v); err != nil {
-					return report, fmt.Errorf("failed to set variable %s: %w", k, err)
+					return nil, fmt.Errorf("failed to set variable %s: %w", k, err)
 				}
 			}
 		}
 	}
 
-	if !evalArgs.UseWindowsModel {
-		approvers, _, _, err := ruleSet.GetApprovers(kfilters.GetCapababilities())
-		if err != nil {
-			report.Error = err
-		} else {
-			report.Approvers = approvers
-		}
-	}
-
-	report.Succeeded = ruleSet.Evaluate(event)
-
-	return report, nil
+	return ruleSet, nil
 }
 
 // EvalRule evaluates a rule against an event
@@ -0,0 +1,338 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux || windows
+
+package clihelpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/DataDog/datadog-agent/pkg/security/secl/compiler/eval"
+	"github.com/DataDog/datadog-agent/pkg/security/secl/rules"
+)
+
+const policyTestSuiteExtension = ".test.yaml"
+
+// PolicyTestSuite defines a list of tests of the rules of a policy directory
+type PolicyTestSuite struct {
+	Tests []PolicyRuleTest `yaml:"tests"`
+}
+
+// PolicyRuleTest defines the test cases of a rule
+type PolicyRuleTest struct {
+	RuleID string           `yaml:"rule_id"`
+	Cases  []PolicyTestCase `yaml:"cases"`
+}
+
+// PolicyTestCase defines an event fixture and the expected outcome of its evaluation. The event uses the format of
+// the event files of `runtime policy eval`.
+type PolicyTestCase struct {
+	Name   string             `yaml:"name"`
+	Event  map[string]any     `yaml:"event"`
+	Expect PolicyTestExpected `yaml:"expect"`
+}
+
+// PolicyTestExpected defines the expected outcome of a test case. Actions are only checked when set.
+type PolicyTestExpected struct {
+	Match   bool     `yaml:"match"`
+	Actions []string `yaml:"actions"`
+}
+
+// PolicyTestReport defines the report of a policy test run
+type PolicyTestReport struct {
+	Succeeded bool
+	Failures  int
+	Results   []PolicyTestResult
+	Coverage  PolicyTestCoverage
+}
+
+// PolicyTestResult defines the result of a test case
+type PolicyTestResult struct {
+	Suite           string
+	RuleID          string
+	Name            string
+	Succeeded       bool
+	ExpectedMatch   bool
+	Matched         bool
+	ExpectedActions []string          `json:",omitempty"`
+	Actions         []string          `json:",omitempty"`
+	FieldDiffs      []PolicyFieldDiff `json:",omitempty"`
+	Error           string            `json:",omitempty"`
+}
+
+// PolicyFieldDiff reports the value of a field used by a rule, and whether this value satisfies the rule expression
+// regardless of the other fields
+type PolicyFieldDiff struct {
+	Field   eval.Field
+	Value   any
+	Matches bool
+}
+
+// PolicyTestCoverage defines the rule and field coverage of a policy test run
+type PolicyTestCoverage struct {
+	Rules  PolicyCoverageSummary
+	Fields PolicyCoverageSummary
+}
+
+// PolicyCoverageSummary defines the coverage of a set of items
+type PolicyCoverageSummary struct {
+	Total     int
+	Covered   int
+	Percent   float64
+	Uncovered []string `json:",omitempty"`
+}
+
+// PolicyTestParams are parameters to the RunPolicyTests function
+type PolicyTestParams struct {
+	Dir             string
+	TestsDir        string
+	UseWindowsModel bool
+}
+
+// RunPolicyTests runs the test suites of a policy directory and writes the report, including the rule and field coverage.
+// An error is returned if a test fails.
+func RunPolicyTests(args PolicyTestParams, writer io.Writer) error {
+	testsDir := args.TestsDir
+	if testsDir == "" {
+		testsDir = args.Dir
+	}
+
+	suites, err := loadPolicyTestSuites(testsDir)
+	if err != nil {
+		return err
+	}
+	if len(suites) == 0 {
+		return fmt.Errorf("no policy test suite (*%s) found in %s", policyTestSuiteExtension, testsDir)
+	}
+
+	provider, err := rules.NewPoliciesDirProvider(args.Dir)
+	if err != nil {
+		return err
+	}
+
+	report, err := runPolicyTests(provider, suites, args.UseWindowsModel)
+	if err != nil {
+		return err
+	}
+
+	content, _ := json.MarshalIndent(report, "", "\t")
+	if _, err := fmt.Fprintf(writer, "%s\n", string(content)); err != nil {
+		return fmt.Errorf("unable to write out report: %w", err)
+	}
+
+	if !report.Succeeded {
+		return fmt.Errorf("%d policy test(s) failed", report.Failures)
+	}
+	return nil
+}
+
+func loadPolicyTestSuites(dir string) (map[string]*PolicyTestSuite, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*"+policyTestSuiteExtension))
+	if err != nil {
+		return nil, err
+	}
+
+	suites := make(map[string]*PolicyTestSuite, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		var suite PolicyTestSuite
+		if err := yaml.Unmarshal(data, &suite); err != nil {
+			return nil, fmt.Errorf("failed to parse policy test suite %s: %w", file, err)
+		}
+		suites[filepath.Base(file)] = &suite
+	}
+
+	return suites, nil
+}
+
+func runPolicyTests(provider rules.PolicyProvider, suites map[string]*PolicyTestSuite, useWindowsModel bool) (*PolicyTestReport, error) {
+	// load all the rules once, to compute the coverage
+	m, eventCtor := newEvalModel(useWindowsModel)
+	ruleSet, err := loadEvalRuleSet(provider, m, eventCtor, useWindowsModel, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &PolicyTestReport{
+		Succeeded: true,
+	}
+	testedRules := make(map[string]bool)
+	testedFields := make(map[eval.Field]bool)
+
+	suiteNames := make([]string, 0, len(suites))
+	for name := range suites {
+		suiteNames = append(suiteNames, name)
+	}
+	sort.Strings(suiteNames)
+
+	for _, suiteName := range suiteNames {
+		for _, test := range suites[suiteName].Tests {
+			for _, testCase := range test.Cases {
+				result := runPolicyTestCase(provider, test.RuleID, testCase, useWindowsModel)
+				result.Suite = suiteName
+				if !result.Succeeded {
+					report.Succeeded = false
+					report.Failures++
+				}
+				report.Results = append(report.Results, result)
+
+				if testCase.Expect.Match && result.Succeeded {
+					testedRules[test.RuleID] = true
+				}
+				if values, ok := testCase.Event["values"].(map[string]any); ok {
+					for field := range values {
+						testedFields[field] = true
+					}
+				}
+			}
+		}
+	}
+
+	var ruleIDs, fields []string
+	for _, rule := range ruleSet.GetRules() {
+		ruleIDs = append(ruleIDs, rule.ID)
+		fields = append(fields, rule.GetEvaluator().GetFields()...)
+	}
+	slices.Sort(fields)
+	fields = slices.Compact(fields)
+
+	report.Coverage.Rules = newPolicyCoverageSummary(ruleIDs, testedRules)
+	report.Coverage.Fields = newPolicyCoverageSummary(fields, testedFields)
+
+	return report, nil
+}
+
+func runPolicyTestCase(provider rules.PolicyProvider, ruleID string, testCase PolicyTestCase, useWindowsModel bool) PolicyTestResult {
+	result := PolicyTestResult{
+		RuleID:          ruleID,
+		Name:            testCase.Name,
+		ExpectedMatch:   testCase.Expect.Match,
+		ExpectedActions: testCase.Expect.Actions,
+	}
+
+	// we need to initialize the model early on to handle legacy field when setting field values in dataFromJSON
+	m, eventCtor := newEvalModel(useWindowsModel)
+
+	// the fixtures use the format of the event files, go through JSON to reuse the same decoding
+	data, err := json.Marshal(testCase.Event)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	event, variables, err := dataFromJSON(decoder)
+	if err != nil {
+		result.Error = fmt.Sprintf("invalid event: %s", err)
+		return result
+	}
+
+	ruleSet, err := loadEvalRuleSet(provider, m, eventCtor, useWindowsModel, variables, &rules.RuleIDFilter{ID: ruleID})
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	rule := ruleSet.GetRuleByID(ruleID)
+	if rule == nil {
+		result.Error = "rule not found"
+		return result
+	}
+
+	result.Matched = ruleSet.Evaluate(event)
+
+	ctx := eval.NewContext(event)
+	if result.Matched {
+		for _, action := range rule.PolicyRule.Actions {
+			if action.FilterEvaluator != nil && !action.FilterEvaluator.Eval(ctx) {
+				continue
+			}
+			result.Actions = append(result.Actions, action.Def.Name())
+		}
+	}
+
+	result.Succeeded = result.Matched == testCase.Expect.Match
+	if result.Succeeded && result.Matched && testCase.Expect.Actions != nil {
+		result.Succeeded = slices.Equal(sortedCopy(result.Actions), sortedCopy(testCase.Expect.Actions))
+	}
+
+	if result.Matched != testCase.Expect.Match {
+		result.FieldDiffs = fieldDiffs(ctx, rule, event)
+	}
+
+	return result
+}
+
+// fieldDiffs reports the values of the fields used by the rule. The partial evaluation of each field tells which
+// values prevent the rule from matching.
+func fieldDiffs(ctx *eval.Context, rule *rules.Rule, event eval.Event) []PolicyFieldDiff {
+	getter, ok := event.(interface {
+		GetFieldValue(field eval.Field) (interface{}, error)
+	})
+	if !ok {
+		return nil
+	}
+
+	fields := slices.Clone(rule.GetEvaluator().GetFields())
+	slices.Sort(fields)
+	fields = slices.Compact(fields)
+
+	diffs := make([]PolicyFieldDiff, 0, len(fields))
+	for _, field := range fields {
+		value, err := getter.GetFieldValue(field)
+		if err != nil {
+			continue
+		}
+		matches, err := rule.PartialEval(ctx, field)
+		if err != nil {
+			continue
+		}
+		diffs = append(diffs, PolicyFieldDiff{
+			Field:   field,
+			Value:   value,
+			Matches: matches,
+		})
+	}
+	return diffs
+}
+
+func newPolicyCoverageSummary(items []string, covered map[string]bool) PolicyCoverageSummary {
+	summary := PolicyCoverageSummary{
+		Total: len(items),
+	}
+	for _, item := range items {
+		if covered[item] {
+			summary.Covered++
+		} else {
+			summary.Uncovered = append(summary.Uncovered, item)
+		}
+	}
+	if summary.Total > 0 {
+		summary.Percent = float64(summary.Covered) * 100 / float64(summary.Total)
+	}
+	sort.Strings(summary.Uncovered)
+	return summary
+}
+
+func sortedCopy(values []string) []string {
+	values = slices.Clone(values)
+	slices.Sort(values)
+	return values
+}
@@ -0,0 +1,99 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux
+
+package clihelpers
+
+import (
+	"testing"
+
+	"go.yaml.in/yaml/v3"
+)
+
+func TestRunPolicyTests(t *testing.T) {
+	var policy = `
+rules:
+  - id: bash_exec
+    expression: exec.file.name == "bash" && process.uid == 0
+    actions:
+      - kill:
+          signal: SIGKILL
+  - id: shadow_open
+    expression: open.file.path == "/etc/shadow"
+`
+
+	var suiteData = `
+tests:
+  - rule_id: bash_exec
+    cases:
+      - name: root bash
+        event:
+          type: exec
+          values:
+            exec.file.name: bash
+            process.uid: 0
+        expect:
+          match: true
+          actions: [kill]
+      - name: user bash
+        event:
+          type: exec
+          values:
+            exec.file.name: bash
+            process.uid: 1000
+        expect:
+          match: true
+`
+
+	var suite PolicyTestSuite
+	if err := yaml.Unmarshal([]byte(suiteData), &suite); err != nil {
+		t.Fatal(err)
+	}
+
+	provider := &fakeProvider{
+		data: []byte(policy),
+	}
+
+	report, err := runPolicyTests(provider, map[string]*PolicyTestSuite{"exec.test.yaml": &suite}, false)
+	if err != nil {
+		t.Fatalf("error running policy tests: %s", err)
+	}
+
+	if report.Succeeded || report.Failures != 1 {
+		t.Fatalf("expected exactly one failure, got %d", report.Failures)
+	}
+
+	if !report.Results[0].Succeeded || len(report.Results[0].Actions) != 1 || report.Results[0].Actions[0] != "kill" {
+		t.Fatalf("expected the root bash case to match with the kill action: %+v", report.Results[0])
+	}
+
+	failure := report.Results[1]
+	if failure.Succeeded || failure.Matched {
+		t.Fatalf("expected the user bash case to fail: %+v", failure)
+	}
+
+	var uidDiff *PolicyFieldDiff
+	for i, diff := range failure.FieldDiffs {
+		if diff.Field == "process.uid" {
+			uidDiff = &failure.FieldDiffs[i]
+		} else if !diff.Matches {
+			t.Fatalf("expected field %s to match", diff.Field)
+		}
+	}
+	if uidDiff == nil || uidDiff.Matches {
+		t.Fatalf("expected a mismatching process.uid field diff: %+v", failure.FieldDiffs)
+	}
+
+	if report.Coverage.Rules.Total != 2 || report.Coverage.Rules.Covered != 1 {
+		t.Fatalf("unexpected rule coverage: %+v", report.Coverage.Rules)
+	}
+	if len(report.Coverage.Rules.Uncovered) != 1 || report.Coverage.Rules.Uncovered[0] != "shadow_open" {
+		t.Fatalf("expected shadow_open to be uncovered: %+v", report.Coverage.Rules)
+	}
+	if report.Coverage.Fields.Covered != 2 {
+		t.Fatalf("unexpected field coverage: %+v", report.Coverage.Fields)
+	}
+}
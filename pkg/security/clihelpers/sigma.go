@@ -0,0 +1,58 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux || windows
+
+package clihelpers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/DataDog/datadog-agent/pkg/security/rules/sigma"
+)
+
+// ImportSigmaParams are parameters to the ImportSigmaRules function
+type ImportSigmaParams struct {
+	Paths []string
+}
+
+// ImportSigmaRules converts the Sigma rules of the given files or directories to a policy written to policyWriter.
+// The conversion report, listing the unsupported fields and modifiers of the skipped rules, is written to reportWriter.
+func ImportSigmaRules(args ImportSigmaParams, policyWriter io.Writer, reportWriter io.Writer) error {
+	sigmaRules, err := sigma.LoadRules(args.Paths...)
+	if err != nil {
+		return err
+	}
+	if len(sigmaRules) == 0 {
+		return errors.New("no Sigma rule found")
+	}
+
+	policy, report := sigma.Convert(sigmaRules)
+
+	content, _ := json.MarshalIndent(report, "", "\t")
+	if _, err := fmt.Fprintf(reportWriter, "%s\n", string(content)); err != nil {
+		return fmt.Errorf("unable to write out report: %w", err)
+	}
+
+	if len(policy.Rules) == 0 {
+		return errors.New("none of the Sigma rules could be converted")
+	}
+
+	data, err := yaml.Marshal(policy)
+	if err != nil {
+		return fmt.Errorf("unable to marshal policy: %w", err)
+	}
+
+	if _, err := policyWriter.Write(data); err != nil {
+		return fmt.Errorf("unable to write out policy: %w", err)
+	}
+
+	return nil
+}
@@ -142,6 +142,16 @@ func NewDirectEventMsgSender(stopper startstop.Stopper, compression compression.
 		return nil, fmt.Errorf("failed to create direct reporter: %w", err)
 	}
 
+	// write the events to the local sinks too, if any
+	localSinkReporter, err := reporter.NewLocalSinkReporter(hostname, runtimeReporter, pkgconfigsetup.SystemProbe())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create local sink reporter: %w", err)
+	}
+	if localSinkReporter != nil {
+		stopper.Add(localSinkReporter)
+		runtimeReporter = localSinkReporter
+	}
+
 	secInfoReporter, err := reporter.NewCWSReporter(hostname, stopper, secInfoEndpoints, secInfoDestinationsCtx, compression, secretsComp)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create direct secinfo reporter: %w", err)
@@ -0,0 +1,272 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package reporter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/DataDog/datadog-agent/pkg/config/model"
+	seccommon "github.com/DataDog/datadog-agent/pkg/security/common"
+	"github.com/DataDog/datadog-agent/pkg/security/seclog"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+const (
+	localSinkConfigPrefix = "runtime_security_config.local_sink."
+	// localSinkQueueSize is the number of events waiting to be written to the local sinks before new events are dropped
+	localSinkQueueSize = 1024
+)
+
+// LocalSinkEvent represents a serialized security event written to a local sink
+type LocalSinkEvent struct {
+	Content   []byte
+	Service   string
+	Hostname  string
+	Timestamp time.Time
+	Tags      []string
+}
+
+// ruleID returns the ID of the rule that triggered the event
+func (e *LocalSinkEvent) ruleID() string {
+	for _, tag := range e.Tags {
+		if ruleID, ok := strings.CutPrefix(tag, "rule_id:"); ok {
+			return ruleID
+		}
+	}
+	return ""
+}
+
+// LocalSink defines a local destination of security events, such as a file, a syslog server or a Unix socket
+type LocalSink interface {
+	Write(event *LocalSinkEvent) error
+	Close() error
+}
+
+// tagSelector is a local sink that only accepts the events matching one of its tags. A tag without value selects
+// the events having a tag with this key, regardless of the value. A selector without tag accepts all the events.
+type tagSelector struct {
+	LocalSink
+	name string
+	tags []string
+}
+
+func (s *tagSelector) matches(tags []string) bool {
+	if len(s.tags) == 0 {
+		return true
+	}
+
+	for _, selector := range s.tags {
+		for _, tag := range tags {
+			if tag == selector {
+				return true
+			}
+			if !strings.Contains(selector, ":") && strings.HasPrefix(tag, selector+":") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// LocalSinkReporter forwards the security events to a reporter, and writes them to the local sinks selected by their
+// tags, so that events remain available when the host is disconnected from the intake. The events are written by a
+// dedicated goroutine, so that a slow sink doesn't block the reporting; they are dropped when the queue is full.
+type LocalSinkReporter struct {
+	hostname     string
+	reporter     seccommon.RawReporter
+	sinks        []*tagSelector
+	logLimit     *log.Limit
+	dropLogLimit *log.Limit
+
+	events   chan *LocalSinkEvent
+	dropped  atomic.Uint64
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// ReportRaw reports raw (bytes) events to the reporter and queues them to be written to the local sinks
+func (r *LocalSinkReporter) ReportRaw(content []byte, service string, hostname string, timestamp time.Time, tags ...string) {
+	if r.reporter != nil {
+		r.reporter.ReportRaw(content, service, hostname, timestamp, tags...)
+	}
+
+	if hostname == "" {
+		hostname = r.hostname
+	}
+
+	event := &LocalSinkEvent{
+		Content:   content,
+		Service:   service,
+		Hostname:  hostname,
+		Timestamp: timestamp,
+		Tags:      tags,
+	}
+
+	select {
+	case <-r.stop:
+		return
+	default:
+	}
+
+	select {
+	case r.events <- event:
+	default:
+		dropped := r.dropped.Add(1)
+		if r.dropLogLimit.ShouldLog() {
+			seclog.Warnf("local sinks queue is full, %d events dropped so far", dropped)
+		}
+	}
+}
+
+// DroppedEvents returns the number of events that were dropped because the local sinks queue was full
+func (r *LocalSinkReporter) DroppedEvents() uint64 {
+	return r.dropped.Load()
+}
+
+// run writes the queued events to the local sinks until the reporter is stopped
+func (r *LocalSinkReporter) run() {
+	defer close(r.done)
+
+	for {
+		select {
+		case <-r.stop:
+			return
+		case event := <-r.events:
+			r.write(event)
+		}
+	}
+}
+
+func (r *LocalSinkReporter) write(event *LocalSinkEvent) {
+	for _, sink := range r.sinks {
+		if !sink.matches(event.Tags) {
+			continue
+		}
+
+		if err := sink.Write(event); err != nil && r.logLimit.ShouldLog() {
+			seclog.Errorf("failed to write event to the %s local sink: %v", sink.name, err)
+		}
+	}
+}
+
+// Stop writes the queued events and closes the local sinks
+func (r *LocalSinkReporter) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.stop)
+		<-r.done
+
+	drain:
+		for {
+			select {
+			case event := <-r.events:
+				r.write(event)
+			default:
+				break drain
+			}
+		}
+
+		for _, sink := range r.sinks {
+			if err := sink.Close(); err != nil {
+				seclog.Errorf("failed to close the %s local sink: %v", sink.name, err)
+			}
+		}
+	})
+}
+
+// NewLocalSinkReporter returns a new local sink reporter forwarding the events to the given reporter, or nil if no
+// local sink is enabled by the configuration. The reporter can be nil to only write to the local sinks.
+func NewLocalSinkReporter(hostname string, reporter seccommon.RawReporter, cfg model.Reader) (*LocalSinkReporter, error) {
+	sinks, err := newLocalSinks(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(sinks) == 0 {
+		return nil, nil
+	}
+
+	return newLocalSinkReporter(hostname, reporter, sinks, localSinkQueueSize), nil
+}
+
+func newLocalSinkReporter(hostname string, reporter seccommon.RawReporter, sinks []*tagSelector, queueSize int) *LocalSinkReporter {
+	r := &LocalSinkReporter{
+		hostname:     hostname,
+		reporter:     reporter,
+		sinks:        sinks,
+		logLimit:     log.NewLogLimit(10, 10*time.Minute),
+		dropLogLimit: log.NewLogLimit(10, 10*time.Minute),
+		events:       make(chan *LocalSinkEvent, queueSize),
+		stop:         make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+	go r.run()
+
+	return r
+}
+
+// newLocalSinks returns the local sinks enabled by the configuration
+func newLocalSinks(cfg model.Reader) ([]*tagSelector, error) {
+	var sinks []*tagSelector
+
+	closeAll := func() {
+		for _, sink := range sinks {
+			_ = sink.Close()
+		}
+	}
+
+	if cfg.GetBool(localSinkConfigPrefix + "file.enabled") {
+		sink, err := newFileSink(
+			cfg.GetString(localSinkConfigPrefix+"file.path"),
+			int64(cfg.GetInt(localSinkConfigPrefix+"file.max_size")),
+			cfg.GetInt(localSinkConfigPrefix+"file.max_files"),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the file local sink: %w", err)
+		}
+		sinks = append(sinks, &tagSelector{
+			LocalSink: sink,
+			name:      "file",
+			tags:      cfg.GetStringSlice(localSinkConfigPrefix + "file.tags"),
+		})
+	}
+
+	if cfg.GetBool(localSinkConfigPrefix + "syslog.enabled") {
+		sink, err := newSyslogSink(
+			cfg.GetString(localSinkConfigPrefix+"syslog.network"),
+			cfg.GetString(localSinkConfigPrefix+"syslog.address"),
+			cfg.GetString(localSinkConfigPrefix+"syslog.format"),
+		)
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("failed to create the syslog local sink: %w", err)
+		}
+		sinks = append(sinks, &tagSelector{
+			LocalSink: sink,
+			name:      "syslog",
+			tags:      cfg.GetStringSlice(localSinkConfigPrefix + "syslog.tags"),
+		})
+	}
+
+	if cfg.GetBool(localSinkConfigPrefix + "socket.enabled") {
+		path := cfg.GetString(localSinkConfigPrefix + "socket.path")
+		if path == "" {
+			closeAll()
+			return nil, errors.New("failed to create the socket local sink: empty socket path")
+		}
+		sinks = append(sinks, &tagSelector{
+			LocalSink: newSocketSink(path),
+			name:      "socket",
+			tags:      cfg.GetStringSlice(localSinkConfigPrefix + "socket.tags"),
+		})
+	}
+
+	return sinks, nil
+}
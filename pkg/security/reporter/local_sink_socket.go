@@ -0,0 +1,92 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package reporter
+
+import (
+	"fmt"
+	"net"
+	"time"
+)
+
+const (
+	connDialTimeout  = time.Second
+	connWriteTimeout = time.Second
+	// connRetryDelay is the delay during which events are dropped after a failed connection, so that an unreachable
+	// destination doesn't slow down the event reporting
+	connRetryDelay = 5 * time.Second
+)
+
+// connWriter writes to a network connection, opened lazily and re-opened after a failure
+type connWriter struct {
+	network string
+	address string
+
+	conn       net.Conn
+	retryAfter time.Time
+}
+
+func (w *connWriter) write(data []byte) error {
+	if w.conn == nil {
+		if now := time.Now(); now.Before(w.retryAfter) {
+			return fmt.Errorf("%s is unreachable, event dropped", w.address)
+		}
+
+		conn, err := net.DialTimeout(w.network, w.address, connDialTimeout)
+		if err != nil {
+			w.retryAfter = time.Now().Add(connRetryDelay)
+			return err
+		}
+		w.conn = conn
+	}
+
+	if err := w.conn.SetWriteDeadline(time.Now().Add(connWriteTimeout)); err != nil {
+		w.close()
+		return err
+	}
+
+	if _, err := w.conn.Write(data); err != nil {
+		w.close()
+		return err
+	}
+	return nil
+}
+
+func (w *connWriter) close() error {
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
+
+// socketSink writes the events as NDJSON to a Unix socket
+type socketSink struct {
+	writer connWriter
+}
+
+func newSocketSink(path string) *socketSink {
+	return &socketSink{
+		writer: connWriter{
+			network: "unix",
+			address: path,
+		},
+	}
+}
+
+// Write sends the event to the socket
+func (s *socketSink) Write(event *LocalSinkEvent) error {
+	line := make([]byte, 0, len(event.Content)+1)
+	line = append(line, event.Content...)
+	line = append(line, '\n')
+
+	return s.writer.write(line)
+}
+
+// Close the socket
+func (s *socketSink) Close() error {
+	return s.writer.close()
+}
@@ -0,0 +1,148 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package reporter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/DataDog/datadog-agent/pkg/version"
+)
+
+const (
+	syslogFormatRFC5424 = "rfc5424"
+	syslogFormatCEF     = "cef"
+
+	// security/authorization messages (authpriv), with the warning severity
+	syslogPriority = 10*8 + 4
+
+	syslogTimestampFormat = "2006-01-02T15:04:05.000000Z07:00"
+
+	cefSeverity = 5
+)
+
+// syslogSink sends the events to a syslog server, the message being either the serialized event (RFC5424) or a CEF
+// record embedding it. The messages are newline terminated on stream connections.
+type syslogSink struct {
+	format string
+	stream bool
+	writer connWriter
+}
+
+func newSyslogSink(network, address, format string) (*syslogSink, error) {
+	if address == "" {
+		return nil, errors.New("empty syslog address")
+	}
+
+	if network == "" {
+		network = "udp"
+	}
+
+	var stream bool
+	switch network {
+	case "udp", "udp4", "udp6", "unixgram":
+	case "tcp", "tcp4", "tcp6", "unix":
+		stream = true
+	default:
+		return nil, fmt.Errorf("unsupported syslog network `%s`", network)
+	}
+
+	if format == "" {
+		format = syslogFormatRFC5424
+	}
+	if format != syslogFormatRFC5424 && format != syslogFormatCEF {
+		return nil, fmt.Errorf("unsupported syslog format `%s`", format)
+	}
+
+	return &syslogSink{
+		format: format,
+		stream: stream,
+		writer: connWriter{
+			network: network,
+			address: address,
+		},
+	}, nil
+}
+
+// Write sends the event to the syslog server
+func (s *syslogSink) Write(event *LocalSinkEvent) error {
+	msg := formatRFC5424(event, s.format == syslogFormatCEF)
+	if s.stream {
+		msg = append(msg, '\n')
+	}
+	return s.writer.write(msg)
+}
+
+// Close the connection to the syslog server
+func (s *syslogSink) Close() error {
+	return s.writer.close()
+}
+
+// formatRFC5424 formats the event as a RFC5424 syslog message. The rule ID is used as message ID.
+func formatRFC5424(event *LocalSinkEvent, cef bool) []byte {
+	hostname := syslogHeaderField(event.Hostname, 255)
+	appName := syslogHeaderField(event.Service, 48)
+	msgID := syslogHeaderField(event.ruleID(), 32)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<%d>1 %s %s %s - %s - ", syslogPriority, event.Timestamp.UTC().Format(syslogTimestampFormat), hostname, appName, msgID)
+	if cef {
+		b.WriteString(formatCEF(event))
+	} else {
+		b.Write(event.Content)
+	}
+	return []byte(b.String())
+}
+
+// syslogHeaderField returns a header field made of printable ASCII characters, or the nil value `-`
+func syslogHeaderField(value string, maxLen int) string {
+	value = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return -1
+		}
+		return r
+	}, value)
+
+	if len(value) > maxLen {
+		value = value[:maxLen]
+	}
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// formatCEF formats the event as a CEF record, the serialized event being the `msg` extension
+func formatCEF(event *LocalSinkEvent) string {
+	var content struct {
+		Title string `json:"title"`
+	}
+	_ = json.Unmarshal(event.Content, &content)
+
+	ruleID := event.ruleID()
+	name := content.Title
+	if name == "" {
+		name = ruleID
+	}
+
+	return fmt.Sprintf("CEF:0|Datadog|Workload Protection|%s|%s|%s|%d|rt=%d dvchost=%s cs1Label=tags cs1=%s msg=%s",
+		cefHeaderEscaper.Replace(version.AgentVersion),
+		cefHeaderEscaper.Replace(ruleID),
+		cefHeaderEscaper.Replace(name),
+		cefSeverity,
+		event.Timestamp.UnixMilli(),
+		cefExtensionEscaper.Replace(event.Hostname),
+		cefExtensionEscaper.Replace(strings.Join(event.Tags, ",")),
+		cefExtensionEscaper.Replace(string(event.Content)),
+	)
+}
+
+var (
+	cefHeaderEscaper    = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\n", " ", "\r", " ")
+	cefExtensionEscaper = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`)
+)
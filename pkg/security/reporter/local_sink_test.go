@@ -0,0 +1,186 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package reporter
+
+import (
+	"bufio"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configmock "github.com/DataDog/datadog-agent/pkg/config/mock"
+)
+
+type captureReporter struct {
+	contents []string
+}
+
+func (r *captureReporter) ReportRaw(content []byte, _ string, _ string, _ time.Time, _ ...string) {
+	r.contents = append(r.contents, string(content))
+}
+
+func TestLocalSinkReporter(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "events.json")
+
+	cfg := configmock.New(t)
+	cfg.SetInTest("runtime_security_config.local_sink.file.enabled", true)
+	cfg.SetInTest("runtime_security_config.local_sink.file.path", path)
+	cfg.SetInTest("runtime_security_config.local_sink.file.tags", []string{"forensics", "severity:high"})
+
+	next := &captureReporter{}
+	r, err := NewLocalSinkReporter("host", next, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, r)
+
+	r.ReportRaw([]byte(`{"id":1}`), "runtime-security-agent", "", time.Now(), "rule_id:a", "forensics:true")
+	r.ReportRaw([]byte(`{"id":2}`), "runtime-security-agent", "", time.Now(), "rule_id:b", "severity:low")
+	r.ReportRaw([]byte(`{"id":3}`), "runtime-security-agent", "", time.Now(), "rule_id:c", "severity:high")
+
+	// all the events are forwarded, only the selected ones are written locally once the queue is flushed
+	assert.Equal(t, []string{`{"id":1}`, `{"id":2}`, `{"id":3}`}, next.contents)
+	r.Stop()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "{\"id\":1}\n{\"id\":3}\n", string(data))
+}
+
+// blockingSink is a local sink whose writes block until it's released
+type blockingSink struct {
+	release chan struct{}
+	written chan string
+}
+
+func (s *blockingSink) Write(event *LocalSinkEvent) error {
+	<-s.release
+	s.written <- string(event.Content)
+	return nil
+}
+
+func (s *blockingSink) Close() error {
+	return nil
+}
+
+func TestLocalSinkReporterDropsEvents(t *testing.T) {
+	sink := &blockingSink{release: make(chan struct{}), written: make(chan string, 3)}
+	next := &captureReporter{}
+	r := newLocalSinkReporter("host", next, []*tagSelector{{LocalSink: sink, name: "blocking"}}, 1)
+
+	r.ReportRaw([]byte(`{"id":1}`), "runtime-security-agent", "", time.Now())
+	// wait for the writer to block on the first event, so that the second one fills the queue
+	assert.Eventually(t, func() bool { return len(r.events) == 0 }, 5*time.Second, 10*time.Millisecond)
+	r.ReportRaw([]byte(`{"id":2}`), "runtime-security-agent", "", time.Now())
+	r.ReportRaw([]byte(`{"id":3}`), "runtime-security-agent", "", time.Now())
+
+	// the reporting doesn't block on the local sinks
+	assert.Equal(t, []string{`{"id":1}`, `{"id":2}`, `{"id":3}`}, next.contents)
+	assert.Equal(t, uint64(1), r.DroppedEvents())
+
+	close(sink.release)
+	r.Stop()
+	close(sink.written)
+
+	var written []string
+	for content := range sink.written {
+		written = append(written, content)
+	}
+	assert.Equal(t, []string{`{"id":1}`, `{"id":2}`}, written)
+}
+
+func TestLocalSinkReporterDisabled(t *testing.T) {
+	cfg := configmock.New(t)
+
+	r, err := NewLocalSinkReporter("host", &captureReporter{}, cfg)
+	require.NoError(t, err)
+	assert.Nil(t, r)
+}
+
+func TestFileSinkRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.json")
+
+	sink, err := newFileSink(path, 10, 2)
+	require.NoError(t, err)
+	defer sink.Close()
+
+	for _, content := range []string{"event-1", "event-2", "event-3", "event-4"} {
+		require.NoError(t, sink.Write(&LocalSinkEvent{Content: []byte(content)}))
+	}
+
+	for file, expected := range map[string]string{
+		path:        "event-4\n",
+		path + ".1": "event-3\n",
+		path + ".2": "event-2\n",
+	} {
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(data))
+	}
+
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestSyslogFormat(t *testing.T) {
+	event := &LocalSinkEvent{
+		Content:   []byte(`{"title":"Suspicious | exec","a=b":"c\\d"}`),
+		Service:   "runtime-security-agent",
+		Hostname:  "my host",
+		Timestamp: time.Date(2024, 3, 4, 5, 6, 7, 8000, time.UTC),
+		Tags:      []string{"rule_id:suspicious_exec", "env:prod"},
+	}
+
+	assert.Equal(t,
+		`<84>1 2024-03-04T05:06:07.000008Z myhost runtime-security-agent - suspicious_exec - {"title":"Suspicious | exec","a=b":"c\\d"}`,
+		string(formatRFC5424(event, false)))
+
+	cef := string(formatRFC5424(event, true))
+	assert.True(t, strings.HasPrefix(cef, `<84>1 2024-03-04T05:06:07.000008Z myhost runtime-security-agent - suspicious_exec - CEF:0|Datadog|Workload Protection|`), cef)
+	assert.True(t, strings.HasSuffix(cef, `|suspicious_exec|Suspicious \| exec|5|rt=1709528767000 dvchost=my host cs1Label=tags cs1=rule_id:suspicious_exec,env:prod msg={"title":"Suspicious | exec","a\=b":"c\\\\d"}`), cef)
+}
+
+func TestSocketSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.sock")
+
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	lines := make(chan string, 2)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+
+	sink := newSocketSink(path)
+	defer sink.Close()
+
+	require.NoError(t, sink.Write(&LocalSinkEvent{Content: []byte(`{"id":1}`)}))
+	require.NoError(t, sink.Write(&LocalSinkEvent{Content: []byte(`{"id":2}`)}))
+
+	for _, expected := range []string{`{"id":1}`, `{"id":2}`} {
+		select {
+		case line := <-lines:
+			assert.Equal(t, expected, line)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for the event")
+		}
+	}
+}
@@ -0,0 +1,228 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package sigma
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+// conditionNode is a node of a parsed Sigma condition. A node is either a reference to a macro, a negation or a
+// conjunction or disjunction of its children.
+type conditionNode struct {
+	op       string
+	macro    string
+	children []*conditionNode
+}
+
+func (n *conditionNode) render(parentOp string) string {
+	switch n.op {
+	case "":
+		return n.macro
+	case "!":
+		return "!" + n.children[0].render("!")
+	}
+
+	parts := make([]string, 0, len(n.children))
+	for _, child := range n.children {
+		parts = append(parts, child.render(n.op))
+	}
+
+	expression := strings.Join(parts, " "+n.op+" ")
+	if parentOp != "" && parentOp != n.op {
+		expression = "(" + expression + ")"
+	}
+	return expression
+}
+
+func newConditionNode(op string, children []*conditionNode) *conditionNode {
+	if len(children) == 1 {
+		return children[0]
+	}
+	return &conditionNode{op: op, children: children}
+}
+
+// convertConditions converts the condition of a rule, a list of conditions being a disjunction of them
+func (c *ruleConverter) convertConditions(condition any) (*conditionNode, error) {
+	var conditions []string
+	switch condition := condition.(type) {
+	case string:
+		conditions = []string{condition}
+	case []any:
+		for _, item := range condition {
+			str, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid condition `%v`", item)
+			}
+			conditions = append(conditions, str)
+		}
+	}
+	if len(conditions) == 0 {
+		return nil, errors.New("missing condition")
+	}
+
+	nodes := make([]*conditionNode, 0, len(conditions))
+	for _, condition := range conditions {
+		node, err := c.parseCondition(condition)
+		if err != nil {
+			return nil, fmt.Errorf("condition `%s`: %w", condition, err)
+		}
+		nodes = append(nodes, node)
+	}
+
+	return newConditionNode("||", nodes), nil
+}
+
+type conditionParser struct {
+	c      *ruleConverter
+	tokens []string
+	pos    int
+}
+
+func (c *ruleConverter) parseCondition(condition string) (*conditionNode, error) {
+	if strings.Contains(condition, "|") {
+		return nil, errors.New("aggregations are not supported")
+	}
+
+	condition = strings.NewReplacer("(", " ( ", ")", " ) ").Replace(condition)
+	p := &conditionParser{
+		c:      c,
+		tokens: strings.Fields(condition),
+	}
+
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if token := p.peek(); token != "" {
+		return nil, fmt.Errorf("unexpected token `%s`", token)
+	}
+	return node, nil
+}
+
+func (p *conditionParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *conditionParser) next() string {
+	token := p.peek()
+	if token != "" {
+		p.pos++
+	}
+	return token
+}
+
+func (p *conditionParser) parseOr() (*conditionNode, error) {
+	return p.parseBinary("or", "||", p.parseAnd)
+}
+
+func (p *conditionParser) parseAnd() (*conditionNode, error) {
+	return p.parseBinary("and", "&&", p.parseNot)
+}
+
+func (p *conditionParser) parseBinary(keyword string, op string, parseOperand func() (*conditionNode, error)) (*conditionNode, error) {
+	node, err := parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	children := []*conditionNode{node}
+	for strings.ToLower(p.peek()) == keyword {
+		p.next()
+
+		node, err := parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, node)
+	}
+
+	return newConditionNode(op, children), nil
+}
+
+func (p *conditionParser) parseNot() (*conditionNode, error) {
+	if strings.ToLower(p.peek()) != "not" {
+		return p.parsePrimary()
+	}
+	p.next()
+
+	node, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	return &conditionNode{op: "!", children: []*conditionNode{node}}, nil
+}
+
+func (p *conditionParser) parsePrimary() (*conditionNode, error) {
+	token := p.next()
+	switch strings.ToLower(token) {
+	case "":
+		return nil, errors.New("unexpected end of condition")
+	case "(":
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, errors.New("missing closing parenthesis")
+		}
+		return node, nil
+	case "1", "any", "all":
+		if strings.ToLower(p.peek()) != "of" {
+			break
+		}
+		p.next()
+
+		op := "||"
+		if strings.ToLower(token) == "all" {
+			op = "&&"
+		}
+		return p.parseQuantifier(op, p.next())
+	}
+
+	macro, ok := p.c.macros[token]
+	if !ok {
+		return nil, fmt.Errorf("unknown identifier `%s`", token)
+	}
+	return &conditionNode{macro: macro}, nil
+}
+
+// parseQuantifier handles the `1 of` and `all of` expressions, the target is either `them` or an identifier pattern
+func (p *conditionParser) parseQuantifier(op string, target string) (*conditionNode, error) {
+	if target == "" {
+		return nil, errors.New("unexpected end of condition")
+	}
+
+	var identifiers []string
+	for identifier := range p.c.macros {
+		var matches bool
+		if target == "them" {
+			// identifiers starting with an underscore are excluded from `them`
+			matches = !strings.HasPrefix(identifier, "_")
+		} else {
+			matches, _ = path.Match(target, identifier)
+		}
+		if matches {
+			identifiers = append(identifiers, identifier)
+		}
+	}
+	if len(identifiers) == 0 {
+		return nil, fmt.Errorf("no identifier matches `%s`", target)
+	}
+	slices.Sort(identifiers)
+
+	children := make([]*conditionNode, 0, len(identifiers))
+	for _, identifier := range identifiers {
+		children = append(children, &conditionNode{macro: p.c.macros[identifier]})
+	}
+	return newConditionNode(op, children), nil
+}
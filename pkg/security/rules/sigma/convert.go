@@ -0,0 +1,501 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package sigma
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/DataDog/datadog-agent/pkg/security/secl/rules"
+)
+
+const ruleIDPrefix = "sigma_"
+
+// supportedModifiers lists the Sigma value modifiers handled by the conversion. Values are matched case insensitively,
+// as in Sigma, unless the `cased` modifier is set.
+var supportedModifiers = []string{"contains", "startswith", "endswith", "all", "re", "cased"}
+
+var (
+	errUnsupportedField    = errors.New("unsupported field")
+	errUnsupportedModifier = errors.New("unsupported modifier")
+
+	invalidIDCharacters = regexp.MustCompile(`[^a-z0-9_]+`)
+)
+
+// Report describes the outcome of the conversion of Sigma rules
+type Report struct {
+	Rules []RuleReport `json:"rules"`
+}
+
+// RuleReport describes the outcome of the conversion of a Sigma rule. A rule using an unsupported field or modifier
+// isn't converted.
+type RuleReport struct {
+	Title                string   `json:"title"`
+	SigmaID              string   `json:"sigma_id,omitempty"`
+	File                 string   `json:"file,omitempty"`
+	RuleID               string   `json:"rule_id,omitempty"`
+	Converted            bool     `json:"converted"`
+	UnsupportedFields    []string `json:"unsupported_fields,omitempty"`
+	UnsupportedModifiers []string `json:"unsupported_modifiers,omitempty"`
+	Errors               []string `json:"errors,omitempty"`
+}
+
+// Converted returns the number of converted rules
+func (r *Report) Converted() int {
+	var converted int
+	for _, rule := range r.Rules {
+		if rule.Converted {
+			converted++
+		}
+	}
+	return converted
+}
+
+// Convert converts Sigma rules to a policy. Each detection identifier of a rule is converted to a macro, the
+// condition of the rule is converted to the rule expression.
+func Convert(sigmaRules []*Rule) (*rules.PolicyDef, *Report) {
+	policy := &rules.PolicyDef{}
+	report := &Report{}
+	ruleIDs := make(map[string]bool)
+
+	for _, sigmaRule := range sigmaRules {
+		ruleReport := RuleReport{
+			Title:   sigmaRule.Title,
+			SigmaID: sigmaRule.ID,
+			File:    sigmaRule.File,
+		}
+
+		ruleID := newRuleID(sigmaRule, ruleIDs)
+
+		c := &ruleConverter{
+			ruleID: ruleID,
+			report: &ruleReport,
+		}
+		macros, rule := c.convert(sigmaRule)
+
+		if len(ruleReport.UnsupportedFields) == 0 && len(ruleReport.UnsupportedModifiers) == 0 && len(ruleReport.Errors) == 0 {
+			ruleIDs[ruleID] = true
+			ruleReport.RuleID = ruleID
+			ruleReport.Converted = true
+
+			policy.Macros = append(policy.Macros, macros...)
+			policy.Rules = append(policy.Rules, rule)
+		}
+
+		report.Rules = append(report.Rules, ruleReport)
+	}
+
+	return policy, report
+}
+
+func newRuleID(sigmaRule *Rule, ruleIDs map[string]bool) string {
+	name := sigmaRule.Title
+	if name == "" {
+		name = sigmaRule.ID
+	}
+
+	ruleID := ruleIDPrefix + strings.Trim(invalidIDCharacters.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if ruleID == ruleIDPrefix {
+		ruleID += "rule"
+	}
+
+	if !ruleIDs[ruleID] {
+		return ruleID
+	}
+
+	for i := 2; ; i++ {
+		if id := ruleID + "_" + strconv.Itoa(i); !ruleIDs[id] {
+			return id
+		}
+	}
+}
+
+type ruleConverter struct {
+	ruleID  string
+	mapping logSourceMapping
+	report  *RuleReport
+	// macros maps the detection identifiers to their macro ID
+	macros map[string]string
+}
+
+func (c *ruleConverter) addError(err error) {
+	c.report.Errors = append(c.report.Errors, err.Error())
+}
+
+func (c *ruleConverter) convert(sigmaRule *Rule) ([]*rules.MacroDefinition, *rules.RuleDefinition) {
+	mapping, err := getLogSourceMapping(sigmaRule.LogSource)
+	if err != nil {
+		c.addError(err)
+		return nil, nil
+	}
+	c.mapping = mapping
+
+	if _, ok := sigmaRule.Detection["timeframe"]; ok {
+		c.addError(errors.New("timeframe is not supported"))
+		return nil, nil
+	}
+
+	var identifiers []string
+	for identifier := range sigmaRule.Detection {
+		if identifier != "condition" {
+			identifiers = append(identifiers, identifier)
+		}
+	}
+	slices.Sort(identifiers)
+
+	c.macros = make(map[string]string, len(identifiers))
+	macros := make([]*rules.MacroDefinition, 0, len(identifiers))
+	for _, identifier := range identifiers {
+		macroID := c.ruleID + "_" + strings.Trim(invalidIDCharacters.ReplaceAllString(strings.ToLower(identifier), "_"), "_")
+		for slices.ContainsFunc(macros, func(macro *rules.MacroDefinition) bool { return macro.ID == macroID }) {
+			macroID += "_"
+		}
+		c.macros[identifier] = macroID
+
+		expression, err := c.convertSearch(sigmaRule.Detection[identifier])
+		if err != nil {
+			// unsupported fields and modifiers are already reported
+			if !errors.Is(err, errUnsupportedField) && !errors.Is(err, errUnsupportedModifier) {
+				c.addError(fmt.Errorf("%s: %w", identifier, err))
+			}
+			continue
+		}
+
+		macros = append(macros, &rules.MacroDefinition{
+			ID:         macroID,
+			Expression: expression,
+		})
+	}
+
+	condition, err := c.convertConditions(sigmaRule.Detection["condition"])
+	if err != nil {
+		c.addError(err)
+		return nil, nil
+	}
+
+	expression := condition.render("")
+	if mapping.expression != "" {
+		expression = mapping.expression + " && " + condition.render("&&")
+	}
+
+	return macros, &rules.RuleDefinition{
+		ID:          c.ruleID,
+		Expression:  expression,
+		Description: sigmaRule.Title,
+		Tags:        newRuleTags(sigmaRule),
+	}
+}
+
+func newRuleTags(sigmaRule *Rule) map[string]string {
+	tags := map[string]string{
+		"source": "sigma",
+	}
+	if sigmaRule.ID != "" {
+		tags["sigma_id"] = sigmaRule.ID
+	}
+	if sigmaRule.Level != "" {
+		tags["sigma_level"] = sigmaRule.Level
+	}
+	if len(sigmaRule.Tags) > 0 {
+		tags["sigma_tags"] = strings.Join(sigmaRule.Tags, ",")
+	}
+	return tags
+}
+
+// convertSearch converts a search identifier: a map is a conjunction of field matches, a list of maps is a
+// disjunction of them.
+func (c *ruleConverter) convertSearch(search any) (string, error) {
+	switch search := search.(type) {
+	case map[string]any:
+		return c.convertFieldMatches(search)
+	case []any:
+		var expressions []string
+		for _, item := range search {
+			fieldMatches, ok := item.(map[string]any)
+			if !ok {
+				return "", errors.New("keyword searches are not supported")
+			}
+
+			expression, err := c.convertFieldMatches(fieldMatches)
+			if err != nil {
+				return "", err
+			}
+			expressions = append(expressions, expression)
+		}
+		return joinExpressions(expressions, "||"), nil
+	default:
+		return "", fmt.Errorf("unsupported search definition `%v`", search)
+	}
+}
+
+func (c *ruleConverter) convertFieldMatches(fieldMatches map[string]any) (string, error) {
+	if len(fieldMatches) == 0 {
+		return "", errors.New("empty search")
+	}
+
+	keys := make([]string, 0, len(fieldMatches))
+	for key := range fieldMatches {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	var (
+		expressions []string
+		unsupported bool
+	)
+	for _, key := range keys {
+		expression, err := c.convertFieldMatch(key, fieldMatches[key])
+		if err != nil {
+			// keep going to report all the unsupported fields and modifiers
+			if errors.Is(err, errUnsupportedField) || errors.Is(err, errUnsupportedModifier) {
+				unsupported = true
+				continue
+			}
+			return "", err
+		}
+		expressions = append(expressions, expression)
+	}
+
+	if unsupported {
+		return "", errUnsupportedField
+	}
+
+	return joinExpressions(expressions, "&&"), nil
+}
+
+type matchKind int
+
+const (
+	exactMatch matchKind = iota
+	containsMatch
+	startsWithMatch
+	endsWithMatch
+	regexMatch
+)
+
+func (c *ruleConverter) convertFieldMatch(key string, value any) (string, error) {
+	name, modifiers, _ := strings.Cut(key, "|")
+
+	mapping, ok := c.mapping.fields[name]
+	if !ok {
+		if !slices.Contains(c.report.UnsupportedFields, name) {
+			c.report.UnsupportedFields = append(c.report.UnsupportedFields, name)
+		}
+		return "", errUnsupportedField
+	}
+
+	kind, all, cased := exactMatch, false, false
+	if modifiers != "" {
+		for _, modifier := range strings.Split(modifiers, "|") {
+			if !slices.Contains(supportedModifiers, modifier) {
+				if !slices.Contains(c.report.UnsupportedModifiers, modifier) {
+					c.report.UnsupportedModifiers = append(c.report.UnsupportedModifiers, modifier)
+				}
+				return "", errUnsupportedModifier
+			}
+
+			switch modifier {
+			case "all":
+				all = true
+			case "cased":
+				cased = true
+			default:
+				if kind != exactMatch {
+					return "", fmt.Errorf("%s: conflicting modifiers `%s`", name, modifiers)
+				}
+				kind = map[string]matchKind{
+					"contains":   containsMatch,
+					"startswith": startsWithMatch,
+					"endswith":   endsWithMatch,
+					"re":         regexMatch,
+				}[modifier]
+			}
+		}
+	}
+
+	values, isList := value.([]any)
+	if !isList {
+		values = []any{value}
+	}
+	if len(values) == 0 {
+		return "", fmt.Errorf("%s: empty list of values", name)
+	}
+
+	// a Sigma value may be converted to several SECL literals, any of them matching
+	var literals [][]string
+	for _, value := range values {
+		valueLiterals, err := convertValue(mapping, kind, cased, value)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", name, err)
+		}
+		literals = append(literals, valueLiterals)
+	}
+
+	if all && len(literals) > 1 {
+		expressions := make([]string, 0, len(literals))
+		for _, valueLiterals := range literals {
+			expressions = append(expressions, matchExpression(mapping.field, valueLiterals))
+		}
+		return joinExpressions(expressions, "&&"), nil
+	}
+
+	return matchExpression(mapping.field, slices.Concat(literals...)), nil
+}
+
+func matchExpression(field string, literals []string) string {
+	if len(literals) == 1 {
+		return field + " == " + literals[0]
+	}
+	return field + " in [" + strings.Join(literals, ", ") + "]"
+}
+
+// convertValue converts a Sigma value to SECL literals, the value matching when any of them does. Sigma wildcards
+// are converted to patterns, or to globs on the path fields. Values are matched case insensitively unless cased is
+// set, except on the path fields that are case sensitive on Linux.
+func convertValue(mapping fieldMapping, kind matchKind, cased bool, value any) ([]string, error) {
+	if mapping.kind == intField {
+		if kind != exactMatch {
+			return nil, errors.New("only exact matches are supported on numeric fields")
+		}
+
+		switch value := value.(type) {
+		case int:
+			return []string{strconv.Itoa(value)}, nil
+		case string:
+			if _, err := strconv.Atoi(value); err == nil {
+				return []string{value}, nil
+			}
+		}
+		return nil, fmt.Errorf("invalid numeric value `%v`", value)
+	}
+
+	var str string
+	switch value := value.(type) {
+	case nil:
+		if kind != exactMatch {
+			return nil, errors.New("null values can only be matched exactly")
+		}
+	case string:
+		str = value
+	case int, float64, bool:
+		str = fmt.Sprint(value)
+	default:
+		return nil, fmt.Errorf("unsupported value `%v`", value)
+	}
+
+	// SECL string literals can't contain a double quote or end with a backslash
+	if strings.Contains(str, `"`) || strings.HasSuffix(str, `\`) {
+		return nil, fmt.Errorf("unsupported value `%s`", str)
+	}
+
+	if kind == regexMatch {
+		// regular expressions can't be used to compute discarders, SECL thus rejects them on path fields
+		if mapping.kind == pathField {
+			return nil, errors.New("regular expressions are not supported on path fields")
+		}
+		if _, err := regexp.Compile(str); err != nil {
+			return nil, fmt.Errorf("invalid regular expression `%s`: %w", str, err)
+		}
+		return []string{`r"` + str + `"`}, nil
+	}
+
+	if strings.Contains(str, "?") || strings.Contains(str, `\*`) || strings.Contains(str, `\\`) {
+		return nil, fmt.Errorf("unsupported wildcard in value `%s`", str)
+	}
+
+	switch kind {
+	case containsMatch:
+		str = "*" + str + "*"
+	case startsWithMatch:
+		str = str + "*"
+	case endsWithMatch:
+		str = "*" + str
+	}
+
+	// `**` is equivalent to `*` in Sigma
+	for strings.Contains(str, "**") {
+		str = strings.ReplaceAll(str, "**", "*")
+	}
+
+	if mapping.kind == pathField {
+		globs, err := pathGlobs(str)
+		if err != nil {
+			return nil, err
+		}
+		literals := make([]string, 0, len(globs))
+		for _, glob := range globs {
+			literals = append(literals, stringLiteral(glob))
+		}
+		return literals, nil
+	}
+
+	// values without any letter are matched the same way whatever the case
+	if !cased && strings.ToLower(str) != strings.ToUpper(str) {
+		parts := strings.Split(str, "*")
+		for i, part := range parts {
+			parts[i] = regexp.QuoteMeta(part)
+		}
+		re := "^" + strings.Join(parts, ".*") + "$"
+		re = strings.TrimPrefix(re, "^.*")
+		re = strings.TrimSuffix(re, ".*$")
+		return []string{`r"(?i)` + re + `"`}, nil
+	}
+
+	return []string{stringLiteral(str)}, nil
+}
+
+// pathGlobs converts a Sigma value to globs on a path field. A Sigma wildcard matches any string, `/` included,
+// whereas a glob `*` doesn't match a `/` and `**` matches any number of path segments, once per glob. Only a single
+// leading or trailing wildcard can thus be converted.
+func pathGlobs(str string) ([]string, error) {
+	switch wildcards := strings.Count(str, "*"); {
+	case str == "*":
+		return []string{"**"}, nil
+	case wildcards == 0:
+		if str != "" && !strings.HasPrefix(str, "/") {
+			return nil, fmt.Errorf("path `%s` isn't absolute", str)
+		}
+		return []string{str}, nil
+	case wildcards == 1 && strings.HasPrefix(str, "*"):
+		// `*/curl` matches `/usr/bin/curl`, and `*curl` any path whose last segment ends with `curl`
+		if suffix := str[1:]; strings.HasPrefix(suffix, "/") {
+			return []string{"**" + suffix}, nil
+		}
+		return []string{"**/" + str}, nil
+	case wildcards == 1 && strings.HasSuffix(str, "*") && strings.HasPrefix(str, "/"):
+		// `/etc/cron.d/*` matches any file under `/etc/cron.d`, and `/etc/cron*` both `/etc/crontab` and the files
+		// under `/etc/cron.d`
+		if prefix := strings.TrimSuffix(str, "*"); strings.HasSuffix(prefix, "/") {
+			return []string{prefix + "**"}, nil
+		}
+		return []string{str, str + "/**"}, nil
+	default:
+		return nil, fmt.Errorf("unsupported wildcards in path `%s`", str)
+	}
+}
+
+func stringLiteral(str string) string {
+	if strings.Contains(str, "*") {
+		return `~"` + str + `"`
+	}
+	return `"` + str + `"`
+}
+
+func joinExpressions(expressions []string, op string) string {
+	if len(expressions) == 1 {
+		return expressions[0]
+	}
+
+	parenthesized := make([]string, 0, len(expressions))
+	for _, expression := range expressions {
+		parenthesized = append(parenthesized, "("+expression+")")
+	}
+	return strings.Join(parenthesized, " "+op+" ")
+}
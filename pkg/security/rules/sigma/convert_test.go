@@ -0,0 +1,293 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux
+
+package sigma
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/DataDog/datadog-agent/pkg/security/secl/compiler/eval"
+	"github.com/DataDog/datadog-agent/pkg/security/secl/model"
+	"github.com/DataDog/datadog-agent/pkg/security/secl/rules"
+)
+
+const testSigmaRules = `
+title: Curl Download To Temporary Directory
+id: 5c3a2e0e-8d7b-4f0e-9a51-2b0c3f7c9e11
+level: medium
+tags:
+  - attack.command_and_control
+logsource:
+  product: linux
+  category: process_creation
+detection:
+  selection:
+    Image|endswith: /curl
+    CommandLine|contains:
+      - '-o /tmp/'
+      - '--output /tmp/'
+  filter:
+    ParentImage: /usr/bin/apt
+  condition: selection and not filter
+---
+title: Cron File Creation
+id: 9b4f4c71-0f5e-4a7f-8b3c-6d2a1e5f7a20
+level: high
+logsource:
+  product: linux
+  category: file_event
+detection:
+  selection_crond:
+    TargetFilename|startswith: /etc/cron.d/
+  selection_spool:
+    TargetFilename|startswith: /var/spool/cron/
+  condition: 1 of selection_*
+---
+title: Shell History Wiping
+logsource:
+  product: linux
+  category: process_creation
+detection:
+  selection:
+    Image|endswith: shred
+    CommandLine|contains|cased: .bash_history
+  condition: selection
+---
+title: Unsupported Path Matches
+logsource:
+  product: linux
+  category: file_event
+detection:
+  selection_re:
+    TargetFilename|re: ^/tmp/[a-z]+$
+  selection_contains:
+    TargetFilename|contains: /tmp/
+  condition: 1 of selection_*
+---
+title: Base64 Encoded Hash
+logsource:
+  product: linux
+  category: process_creation
+detection:
+  selection:
+    Hashes: 0123456789abcdef
+    CommandLine|base64offset|contains: secret
+  condition: selection
+---
+title: Windows Process
+logsource:
+  product: windows
+  category: process_creation
+detection:
+  selection:
+    Image|endswith: \cmd.exe
+  condition: selection
+`
+
+func TestConvert(t *testing.T) {
+	sigmaRules, err := ParseRules(strings.NewReader(testSigmaRules))
+	require.NoError(t, err)
+	require.Len(t, sigmaRules, 6)
+
+	policy, report := Convert(sigmaRules)
+	require.Len(t, report.Rules, 6)
+	assert.Equal(t, 3, report.Converted())
+
+	assert.Equal(t, "sigma_curl_download_to_temporary_directory", report.Rules[0].RuleID)
+	assert.True(t, report.Rules[0].Converted)
+	assert.Equal(t, "sigma_cron_file_creation", report.Rules[1].RuleID)
+	assert.True(t, report.Rules[1].Converted)
+	assert.Equal(t, "sigma_shell_history_wiping", report.Rules[2].RuleID)
+	assert.True(t, report.Rules[2].Converted)
+
+	assert.False(t, report.Rules[3].Converted)
+	assert.Equal(t, []string{
+		"selection_contains: TargetFilename: unsupported wildcards in path `*/tmp/*`",
+		"selection_re: TargetFilename: regular expressions are not supported on path fields",
+	}, report.Rules[3].Errors)
+
+	assert.False(t, report.Rules[4].Converted)
+	assert.Equal(t, []string{"Hashes"}, report.Rules[4].UnsupportedFields)
+	assert.Equal(t, []string{"base64offset"}, report.Rules[4].UnsupportedModifiers)
+
+	assert.False(t, report.Rules[5].Converted)
+	assert.Equal(t, []string{"unsupported logsource product `windows`"}, report.Rules[5].Errors)
+
+	macros := make(map[string]string)
+	for _, macro := range policy.Macros {
+		macros[macro.ID] = macro.Expression
+	}
+	assert.Equal(t, map[string]string{
+		"sigma_curl_download_to_temporary_directory_filter":    `process.parent.file.path == "/usr/bin/apt"`,
+		"sigma_curl_download_to_temporary_directory_selection": `(exec.args in [r"(?i)-o /tmp/", r"(?i)--output /tmp/"]) && (exec.file.path == ~"**/curl")`,
+		"sigma_cron_file_creation_selection_crond":             `open.file.path == ~"/etc/cron.d/**"`,
+		"sigma_cron_file_creation_selection_spool":             `open.file.path == ~"/var/spool/cron/**"`,
+		"sigma_shell_history_wiping_selection":                 `(exec.args == ~"*.bash_history*") && (exec.file.path == ~"**/*shred")`,
+	}, macros)
+
+	require.Len(t, policy.Rules, 3)
+	assert.Equal(t, "sigma_curl_download_to_temporary_directory_selection && !sigma_curl_download_to_temporary_directory_filter", policy.Rules[0].Expression)
+	assert.Equal(t, map[string]string{
+		"source":      "sigma",
+		"sigma_id":    "5c3a2e0e-8d7b-4f0e-9a51-2b0c3f7c9e11",
+		"sigma_level": "medium",
+		"sigma_tags":  "attack.command_and_control",
+	}, policy.Rules[0].Tags)
+	assert.Equal(t, "open.flags & O_CREAT > 0 && (sigma_cron_file_creation_selection_crond || sigma_cron_file_creation_selection_spool)", policy.Rules[1].Expression)
+}
+
+func TestConvertValue(t *testing.T) {
+	path := fieldMapping{field: "exec.file.path", kind: pathField}
+	str := fieldMapping{field: "exec.args", kind: stringField}
+
+	tests := []struct {
+		mapping  fieldMapping
+		kind     matchKind
+		cased    bool
+		value    any
+		expected []string
+		err      bool
+	}{
+		{mapping: path, kind: exactMatch, value: "/usr/bin/curl", expected: []string{`"/usr/bin/curl"`}},
+		{mapping: path, kind: endsWithMatch, value: "/curl", expected: []string{`~"**/curl"`}},
+		{mapping: path, kind: endsWithMatch, value: "curl", expected: []string{`~"**/*curl"`}},
+		{mapping: path, kind: startsWithMatch, value: "/etc/cron.d/", expected: []string{`~"/etc/cron.d/**"`}},
+		{mapping: path, kind: startsWithMatch, value: "/etc/cron", expected: []string{`~"/etc/cron*"`, `~"/etc/cron*/**"`}},
+		{mapping: path, kind: exactMatch, value: "*", expected: []string{`~"**"`}},
+		{mapping: path, kind: containsMatch, value: "/tmp/", err: true},
+		{mapping: path, kind: exactMatch, value: "/home/*/.ssh/*", err: true},
+		{mapping: path, kind: exactMatch, value: "curl", err: true},
+		{mapping: path, kind: regexMatch, value: "^/tmp/", err: true},
+		{mapping: str, kind: exactMatch, value: "Root", expected: []string{`r"(?i)^Root$"`}},
+		{mapping: str, kind: exactMatch, cased: true, value: "Root", expected: []string{`"Root"`}},
+		{mapping: str, kind: containsMatch, value: "-e /bin/sh", expected: []string{`r"(?i)-e /bin/sh"`}},
+		{mapping: str, kind: containsMatch, cased: true, value: "-e /bin/sh", expected: []string{`~"*-e /bin/sh*"`}},
+		{mapping: str, kind: startsWithMatch, value: "-c ", expected: []string{`r"(?i)^-c "`}},
+		{mapping: str, kind: containsMatch, value: "1.2", expected: []string{`~"*1.2*"`}},
+		{mapping: str, kind: regexMatch, value: "^-[a-z]+$", expected: []string{`r"^-[a-z]+$"`}},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s/%v", test.mapping.field, test.value), func(t *testing.T) {
+			literals, err := convertValue(test.mapping, test.kind, test.cased, test.value)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, literals)
+		})
+	}
+}
+
+func TestConvertCondition(t *testing.T) {
+	c := &ruleConverter{
+		macros: map[string]string{
+			"a":       "m_a",
+			"b":       "m_b",
+			"c":       "m_c",
+			"_hidden": "m_hidden",
+		},
+	}
+
+	tests := []struct {
+		condition string
+		expected  string
+		err       bool
+	}{
+		{condition: "a", expected: "m_a"},
+		{condition: "a and b or c", expected: "(m_a && m_b) || m_c"},
+		{condition: "a and (b or not c)", expected: "m_a && (m_b || !m_c)"},
+		{condition: "not (a or b)", expected: "!(m_a || m_b)"},
+		{condition: "all of them", expected: "m_a && m_b && m_c"},
+		{condition: "1 of them and _hidden", expected: "(m_a || m_b || m_c) && m_hidden"},
+		{condition: "unknown", err: true},
+		{condition: "a and", err: true},
+		{condition: "(a or b", err: true},
+		{condition: "a | count() > 5", err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.condition, func(t *testing.T) {
+			node, err := c.convertConditions(test.condition)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, node.render(""))
+		})
+	}
+}
+
+func TestConvertedPolicyLoad(t *testing.T) {
+	sigmaRules, err := ParseRules(strings.NewReader(testSigmaRules))
+	require.NoError(t, err)
+
+	policy, _ := Convert(sigmaRules)
+	require.NotEmpty(t, policy.Macros)
+	data, err := yaml.Marshal(policy)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sigma.policy"), data, 0644))
+
+	provider, err := rules.NewPoliciesDirProvider(dir)
+	require.NoError(t, err)
+
+	enabled := map[eval.EventType]bool{"*": true}
+	ruleOpts, evalOpts := rules.NewBothOpts(enabled)
+	rs := rules.NewRuleSet(&model.Model{}, func() eval.Event { return model.NewFakeEvent() }, ruleOpts, evalOpts)
+
+	_, errs := rs.LoadPolicies(rules.NewPolicyLoader(provider), rules.PolicyLoaderOpts{})
+	require.NoError(t, errs.ErrorOrNil())
+	require.NotNil(t, rs.GetRuleByID("sigma_curl_download_to_temporary_directory"))
+	require.NotNil(t, rs.GetRuleByID("sigma_cron_file_creation"))
+	require.NotNil(t, rs.GetRuleByID("sigma_shell_history_wiping"))
+	for _, macro := range policy.Macros {
+		assert.Contains(t, rs.ListMacroIDs(), macro.ID)
+	}
+
+	newExecEvent := func(path string, args string, parentPath string) eval.Event {
+		event := model.NewFakeEvent()
+		event.Type = uint32(model.ExecEventType)
+		require.NoError(t, event.SetFieldValue("exec.file.path", path))
+		require.NoError(t, event.SetFieldValue("exec.args", args))
+		require.NoError(t, event.SetFieldValue("process.parent.file.path", parentPath))
+		return event
+	}
+
+	assert.True(t, rs.Evaluate(newExecEvent("/usr/bin/curl", "-s -o /tmp/payload http://example.com", "/bin/bash")))
+	assert.False(t, rs.Evaluate(newExecEvent("/usr/bin/curl", "-s -o /tmp/payload http://example.com", "/usr/bin/apt")))
+	assert.False(t, rs.Evaluate(newExecEvent("/usr/bin/wget", "-O /tmp/payload http://example.com", "/bin/bash")))
+	// values are matched case insensitively unless `cased` is set
+	assert.True(t, rs.Evaluate(newExecEvent("/opt/tools/bin/curl", "-s -O /TMP/payload http://example.com", "/bin/bash")))
+	assert.True(t, rs.Evaluate(newExecEvent("/usr/bin/shred", "-u /root/.bash_history", "/bin/bash")))
+	assert.False(t, rs.Evaluate(newExecEvent("/usr/bin/shred", "-u /root/.BASH_HISTORY", "/bin/bash")))
+
+	newOpenEvent := func(path string, flags int) eval.Event {
+		event := model.NewFakeEvent()
+		event.Type = uint32(model.FileOpenEventType)
+		require.NoError(t, event.SetFieldValue("open.file.path", path))
+		require.NoError(t, event.SetFieldValue("open.flags", flags))
+		return event
+	}
+
+	assert.True(t, rs.Evaluate(newOpenEvent("/etc/cron.d/backdoor", syscall.O_CREAT|syscall.O_WRONLY)))
+	assert.False(t, rs.Evaluate(newOpenEvent("/etc/cron.d/backdoor", syscall.O_RDONLY)))
+	assert.False(t, rs.Evaluate(newOpenEvent("/etc/crontab", syscall.O_CREAT|syscall.O_WRONLY)))
+	assert.True(t, rs.Evaluate(newOpenEvent("/var/spool/cron/crontabs/root", syscall.O_CREAT|syscall.O_WRONLY)))
+}
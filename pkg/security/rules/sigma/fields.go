@@ -0,0 +1,69 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package sigma
+
+import (
+	"fmt"
+	"strings"
+)
+
+type fieldKind int
+
+const (
+	stringField fieldKind = iota
+	// pathField is a string field on which patterns are evaluated as globs, a `*` doesn't match a `/`
+	pathField
+	intField
+)
+
+type fieldMapping struct {
+	field string
+	kind  fieldKind
+}
+
+type logSourceMapping struct {
+	// expression is added to the expression of every rule of the log source
+	expression string
+	fields     map[string]fieldMapping
+}
+
+// linuxLogSources maps the categories of the Sigma linux product to CWS fields. The `CommandLine` fields are mapped
+// to the `args` fields, that don't contain the first argument (argv0).
+var linuxLogSources = map[string]logSourceMapping{
+	"process_creation": {
+		fields: map[string]fieldMapping{
+			"Image":             {field: "exec.file.path", kind: pathField},
+			"CommandLine":       {field: "exec.args", kind: stringField},
+			"ParentImage":       {field: "process.parent.file.path", kind: pathField},
+			"ParentCommandLine": {field: "process.parent.args", kind: stringField},
+			"User":              {field: "exec.user", kind: stringField},
+			"ProcessId":         {field: "exec.pid", kind: intField},
+			"ParentProcessId":   {field: "exec.ppid", kind: intField},
+		},
+	},
+	"file_event": {
+		expression: "open.flags & O_CREAT > 0",
+		fields: map[string]fieldMapping{
+			"TargetFilename": {field: "open.file.path", kind: pathField},
+			"Image":          {field: "process.file.path", kind: pathField},
+			"User":           {field: "process.user", kind: stringField},
+			"ProcessId":      {field: "process.pid", kind: intField},
+		},
+	},
+}
+
+func getLogSourceMapping(logSource LogSource) (logSourceMapping, error) {
+	if !strings.EqualFold(logSource.Product, "linux") {
+		return logSourceMapping{}, fmt.Errorf("unsupported logsource product `%s`", logSource.Product)
+	}
+
+	mapping, ok := linuxLogSources[strings.ToLower(logSource.Category)]
+	if !ok {
+		return logSourceMapping{}, fmt.Errorf("unsupported logsource category `%s`", logSource.Category)
+	}
+
+	return mapping, nil
+}
@@ -0,0 +1,116 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+// Package sigma converts Sigma rules to CWS policies
+package sigma
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Rule describes a Sigma rule. Only the attributes used by the conversion are decoded.
+type Rule struct {
+	Title       string         `yaml:"title"`
+	ID          string         `yaml:"id"`
+	Name        string         `yaml:"name"`
+	Description string         `yaml:"description"`
+	Level       string         `yaml:"level"`
+	Tags        []string       `yaml:"tags"`
+	LogSource   LogSource      `yaml:"logsource"`
+	Detection   map[string]any `yaml:"detection"`
+
+	// File is the file the rule was read from, if any
+	File string `yaml:"-"`
+}
+
+// LogSource describes the log source of a Sigma rule
+type LogSource struct {
+	Category string `yaml:"category"`
+	Product  string `yaml:"product"`
+	Service  string `yaml:"service"`
+}
+
+// ParseRules parses the Sigma rules of a YAML stream, one per document
+func ParseRules(reader io.Reader) ([]*Rule, error) {
+	var sigmaRules []*Rule
+
+	decoder := yaml.NewDecoder(reader)
+	for {
+		var rule Rule
+		if err := decoder.Decode(&rule); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+
+		// skip empty documents
+		if rule.Title == "" && rule.Detection == nil {
+			continue
+		}
+
+		sigmaRules = append(sigmaRules, &rule)
+	}
+
+	return sigmaRules, nil
+}
+
+// LoadRules loads the Sigma rules of the given files. Directories are walked for .yml and .yaml files.
+func LoadRules(paths ...string) ([]*Rule, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		err = filepath.WalkDir(path, func(file string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if ext := filepath.Ext(file); !d.IsDir() && (ext == ".yml" || ext == ".yaml") {
+				files = append(files, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(files)
+
+	var sigmaRules []*Rule
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		fileRules, err := ParseRules(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse Sigma rules of %s: %w", file, err)
+		}
+
+		for _, rule := range fileRules {
+			rule.File = file
+		}
+		sigmaRules = append(sigmaRules, fileRules...)
+	}
+
+	return sigmaRules, nil
+}
@@ -0,0 +1,567 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+// Package profilegen generates draft SNMP profiles from the walk of a device without profile. Standard MIB tables
+// are recognized from a catalog, while the tables of the vendor enterprise subtree are inferred from the layout
+// of their OIDs. Metric types are inferred from the SNMP types of the values and table indexes become tags.
+package profilegen
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gosnmp/gosnmp"
+	yaml "go.yaml.in/yaml/v2"
+
+	"github.com/DataDog/datadog-agent/pkg/networkdevice/profile/profiledefinition"
+)
+
+const (
+	// baseProfile is extended by the generated profiles for the system group metrics and tags
+	baseProfile = "_base.yaml"
+	// maxIndexLength is the maximum number of OID components of the vendor table indexes the generator detects
+	maxIndexLength = 6
+	// uncoveredSubtreeDepth is the number of OID components uncovered OIDs are grouped by
+	uncoveredSubtreeDepth = 10
+)
+
+// Usage is how a walked OID is collected by the generated profile
+type Usage string
+
+const (
+	// UsageNone is used for the OIDs the generated profile doesn't collect
+	UsageNone Usage = ""
+	// UsageMetric is used for the OIDs collected as metrics
+	UsageMetric Usage = "metric"
+	// UsageTag is used for the OIDs collected as tag values
+	UsageTag Usage = "tag"
+	// UsageBase is used for the OIDs collected by the base profile
+	UsageBase Usage = "base"
+)
+
+// TableCoverage describes a table or scalar of the generated profile
+type TableCoverage struct {
+	MIB  string
+	Name string
+	OID  string
+	Rows int
+	// Vendor is true for the tables inferred from the vendor enterprise subtree
+	Vendor  bool
+	Metrics []string
+	Tags    []string
+}
+
+// SubtreeCoverage counts the OIDs of a subtree the generated profile doesn't collect
+type SubtreeCoverage struct {
+	OID   string
+	Count int
+}
+
+// Coverage reports how much of the walk the generated profile collects
+type Coverage struct {
+	TotalOIDs  int
+	MetricOIDs int
+	TagOIDs    int
+	BaseOIDs   int
+	Tables     []TableCoverage
+	// Uncovered are the subtrees of the OIDs the profile doesn't collect, largest first
+	Uncovered []SubtreeCoverage
+}
+
+// CoveredOIDs returns the number of OIDs collected by the generated profile
+func (c Coverage) CoveredOIDs() int {
+	return c.MetricOIDs + c.TagOIDs + c.BaseOIDs
+}
+
+// Result is a generated profile with its coverage of the walk
+type Result struct {
+	Profile  profiledefinition.ProfileDefinition
+	Coverage Coverage
+}
+
+// row is a value of a table column
+type row struct {
+	index string
+	pdu   gosnmp.SnmpPDU
+}
+
+// generator holds the state of a profile generation
+type generator struct {
+	oids    []string
+	values  map[string]gosnmp.SnmpPDU
+	usage   map[string]Usage
+	columns map[string][]row
+	// tagColumns are the columns used as tag values by the generated profile
+	tagColumns map[string]bool
+	// groups maps the OIDs to the table column or scalar they belong to, for the coverage report
+	groups map[string]string
+
+	profile profiledefinition.ProfileDefinition
+	tables  []TableCoverage
+}
+
+// Generate generates a draft profile from the walk of a device
+func Generate(pdus []gosnmp.SnmpPDU, sysObjectID string) Result {
+	g := &generator{
+		values:     make(map[string]gosnmp.SnmpPDU, len(pdus)),
+		usage:      make(map[string]Usage, len(pdus)),
+		columns:    make(map[string][]row),
+		tagColumns: make(map[string]bool),
+		groups:     make(map[string]string),
+	}
+	for _, pdu := range pdus {
+		oid := normalizeOID(pdu.Name)
+		if _, ok := g.values[oid]; ok || !isValue(pdu.Type) {
+			continue
+		}
+		g.oids = append(g.oids, oid)
+		g.values[oid] = pdu
+	}
+
+	g.profile.Description = "Draft profile generated from an SNMP walk"
+	g.profile.Extends = []string{baseProfile}
+	if sysObjectID = normalizeOID(sysObjectID); sysObjectID != "" {
+		g.profile.SysObjectIDs = profiledefinition.StringArray{sysObjectID}
+	}
+
+	g.addKnownTables()
+	g.addKnownScalars()
+	g.addVendorMetrics()
+
+	return Result{Profile: g.profile, Coverage: g.coverage()}
+}
+
+// MarshalProfile returns the YAML definition of a generated profile
+func MarshalProfile(profile profiledefinition.ProfileDefinition) ([]byte, error) {
+	data, err := yaml.Marshal(profile)
+	if err != nil {
+		return nil, err
+	}
+	header := "# Draft profile generated from an SNMP walk.\n" +
+		"# Review the metrics and tags, and rename the symbols of the vendor tables using the vendor MIBs.\n"
+	return append([]byte(header), data...), nil
+}
+
+func (g *generator) addKnownTables() {
+	for _, table := range knownTables {
+		entry := table.OID + ".1."
+		columnRows := make(map[string][]row)
+		for _, oid := range g.oids {
+			rest, ok := strings.CutPrefix(oid, entry)
+			if !ok {
+				continue
+			}
+			column, index, ok := strings.Cut(rest, ".")
+			if !ok {
+				continue
+			}
+			columnOID := entry + column
+			columnRows[column] = append(columnRows[column], row{index: index, pdu: g.values[oid]})
+			g.columns[columnOID] = append(g.columns[columnOID], row{index: index, pdu: g.values[oid]})
+			g.groups[oid] = columnOID
+		}
+		if len(columnRows) == 0 {
+			continue
+		}
+
+		metric := profiledefinition.MetricsConfig{
+			MIB:   table.MIB,
+			Table: profiledefinition.SymbolConfig{OID: table.OID, Name: table.Name},
+		}
+		coverage := TableCoverage{MIB: table.MIB, Name: table.Name, OID: table.OID}
+		for _, column := range sortedColumns(columnRows) {
+			knownColumn, ok := table.Columns[column]
+			if !ok || knownColumn.Skip {
+				continue
+			}
+			rows := columnRows[column]
+			metricType, ok := inferMetricType(rows[0].pdu.Type)
+			if !ok {
+				continue
+			}
+			metric.Symbols = append(metric.Symbols, profiledefinition.SymbolConfig{
+				OID:        entry + column,
+				Name:       knownColumn.Name,
+				MetricType: metricType,
+			})
+			coverage.Metrics = append(coverage.Metrics, knownColumn.Name)
+			coverage.Rows = max(coverage.Rows, len(rows))
+			g.markColumn(entry+column, UsageMetric)
+		}
+		if len(metric.Symbols) == 0 {
+			continue
+		}
+
+		for _, tag := range table.Tags {
+			metricTag, ok := g.knownTag(tag)
+			if !ok {
+				continue
+			}
+			metric.MetricTags = append(metric.MetricTags, metricTag)
+			coverage.Tags = append(coverage.Tags, tag.Tag)
+		}
+		g.profile.Metrics = append(g.profile.Metrics, metric)
+		g.tables = append(g.tables, coverage)
+	}
+}
+
+// knownTag returns the metric tag read from the first candidate column found in the walk, or from the index
+func (g *generator) knownTag(tag knownTag) (profiledefinition.MetricTagConfig, bool) {
+	for _, candidate := range tag.Candidates {
+		if !g.hasColumn(candidate.OID) {
+			continue
+		}
+		g.tagColumns[candidate.OID] = true
+		return profiledefinition.MetricTagConfig{
+			Tag:    tag.Tag,
+			Symbol: profiledefinition.SymbolConfigCompat{OID: candidate.OID, Name: candidate.Name},
+		}, true
+	}
+	if tag.Index == 0 {
+		return profiledefinition.MetricTagConfig{}, false
+	}
+	return profiledefinition.MetricTagConfig{Tag: tag.Tag, Index: tag.Index}, true
+}
+
+func (g *generator) hasColumn(columnOID string) bool {
+	prefix := columnOID + "."
+	for _, oid := range g.oids {
+		if strings.HasPrefix(oid, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *generator) addKnownScalars() {
+	for _, scalar := range knownScalars {
+		pdu, ok := g.values[scalar.OID]
+		if !ok {
+			continue
+		}
+		g.groups[scalar.OID] = scalar.OID
+		metricType, ok := inferMetricType(pdu.Type)
+		if !ok {
+			continue
+		}
+		g.addScalar(scalar.MIB, scalar.OID, scalar.Name, metricType, false)
+	}
+}
+
+func (g *generator) addScalar(mib string, oid string, name string, metricType profiledefinition.ProfileMetricType, vendor bool) {
+	g.profile.Metrics = append(g.profile.Metrics, profiledefinition.MetricsConfig{
+		MIB:    mib,
+		Symbol: profiledefinition.SymbolConfig{OID: oid, Name: name, MetricType: metricType},
+	})
+	g.tables = append(g.tables, TableCoverage{MIB: mib, Name: name, OID: oid, Rows: 1, Vendor: vendor, Metrics: []string{name}})
+	g.usage[oid] = UsageMetric
+}
+
+// vendorColumn is a candidate column of a vendor table, for an index length
+type vendorColumn struct {
+	entry  string
+	column string
+	index  string
+}
+
+func splitVendorColumn(parts []string, indexLength int) vendorColumn {
+	column := parts[:len(parts)-indexLength]
+	return vendorColumn{
+		entry:  strings.Join(column[:len(column)-1], "."),
+		column: column[len(column)-1],
+		index:  strings.Join(parts[len(parts)-indexLength:], "."),
+	}
+}
+
+// addVendorMetrics adds the numeric scalars and the tables of the vendor enterprise subtree. Tables are detected
+// following the SMI conventions: their entry is the `1` child of the table, and the values of the rows are the
+// children of the columns, identified by the row index. Since the length of the indexes is unknown, the length
+// leading to the most columns sharing the same index is used.
+func (g *generator) addVendorMetrics() {
+	// the shortest vendor entry is enterprises.<PEN>.<table>.1
+	minEntryLength := len(strings.Split(enterprisesOID, ".")) + 3
+
+	var tableOIDs []string
+	columnsByIndex := make(map[vendorColumn]map[string]bool)
+	for _, oid := range g.oids {
+		if !strings.HasPrefix(oid, enterprisesOID+".") {
+			continue
+		}
+		if strings.HasSuffix(oid, ".0") {
+			pdu := g.values[oid]
+			g.groups[oid] = oid
+			if metricType, ok := inferMetricType(pdu.Type); ok {
+				g.addScalar("", oid, vendorSymbolName(strings.TrimSuffix(oid, ".0")), metricType, true)
+			}
+			continue
+		}
+		parts := strings.Split(oid, ".")
+		for indexLength := 1; indexLength <= maxIndexLength && len(parts)-indexLength-1 >= minEntryLength; indexLength++ {
+			candidate := splitVendorColumn(parts, indexLength)
+			if !strings.HasSuffix(candidate.entry, ".1") {
+				continue
+			}
+			key := vendorColumn{entry: candidate.entry, index: candidate.index}
+			if columnsByIndex[key] == nil {
+				columnsByIndex[key] = make(map[string]bool)
+			}
+			columnsByIndex[key][candidate.column] = true
+			tableOIDs = append(tableOIDs, oid)
+		}
+	}
+
+	// assign each OID to the candidate column sharing its index with the most columns, and each table to the
+	// index length most of its OIDs are assigned with
+	assigned := make(map[string]vendorColumn)
+	assignedLength := make(map[string]int)
+	lengthVotes := make(map[string]map[int]int)
+	for _, oid := range slices.Compact(tableOIDs) {
+		parts := strings.Split(oid, ".")
+		bestLength, bestColumns := 0, 0
+		for indexLength := 1; indexLength <= maxIndexLength && len(parts)-indexLength-1 >= minEntryLength; indexLength++ {
+			candidate := splitVendorColumn(parts, indexLength)
+			columns := len(columnsByIndex[vendorColumn{entry: candidate.entry, index: candidate.index}])
+			if columns > bestColumns {
+				bestLength, bestColumns = indexLength, columns
+			}
+		}
+		if bestLength == 0 {
+			continue
+		}
+		candidate := splitVendorColumn(parts, bestLength)
+		assigned[oid] = candidate
+		assignedLength[oid] = bestLength
+		if lengthVotes[candidate.entry] == nil {
+			lengthVotes[candidate.entry] = make(map[int]int)
+		}
+		lengthVotes[candidate.entry][bestLength]++
+	}
+
+	tables := make(map[string]map[string][]row)
+	tableIndexLength := make(map[string]int)
+	for entry, votes := range lengthVotes {
+		tableIndexLength[entry] = slices.MaxFunc(slices.Collect(maps.Keys(votes)), func(a, b int) int {
+			return cmp.Or(cmp.Compare(votes[a], votes[b]), cmp.Compare(b, a))
+		})
+	}
+	for _, oid := range g.oids {
+		candidate, ok := assigned[oid]
+		if !ok || assignedLength[oid] != tableIndexLength[candidate.entry] {
+			continue
+		}
+		if tables[candidate.entry] == nil {
+			tables[candidate.entry] = make(map[string][]row)
+		}
+		columnOID := candidate.entry + "." + candidate.column
+		tables[candidate.entry][candidate.column] = append(tables[candidate.entry][candidate.column], row{index: candidate.index, pdu: g.values[oid]})
+		g.columns[columnOID] = append(g.columns[columnOID], row{index: candidate.index, pdu: g.values[oid]})
+		g.groups[oid] = columnOID
+	}
+
+	entries := slices.SortedFunc(maps.Keys(tables), compareOIDs)
+	for _, entry := range entries {
+		g.addVendorTable(entry, tables[entry], tableIndexLength[entry])
+	}
+}
+
+func (g *generator) addVendorTable(entry string, columns map[string][]row, indexLength int) {
+	tableOID := strings.TrimSuffix(entry, ".1")
+	tableName := vendorSymbolName(tableOID)
+	metric := profiledefinition.MetricsConfig{
+		Table: profiledefinition.SymbolConfig{OID: tableOID, Name: tableName},
+	}
+	coverage := TableCoverage{Name: tableName, OID: tableOID, Vendor: true}
+
+	var tagColumn string
+	for _, column := range sortedColumns(columns) {
+		rows := columns[column]
+		columnOID := entry + "." + column
+		if tagColumn == "" && isTagColumn(rows) {
+			tagColumn = columnOID
+			continue
+		}
+		metricType, ok := inferMetricType(rows[0].pdu.Type)
+		if !ok || isIndexColumn(rows) {
+			continue
+		}
+		name := vendorSymbolName(columnOID)
+		metric.Symbols = append(metric.Symbols, profiledefinition.SymbolConfig{OID: columnOID, Name: name, MetricType: metricType})
+		coverage.Metrics = append(coverage.Metrics, name)
+		coverage.Rows = max(coverage.Rows, len(rows))
+	}
+	if len(metric.Symbols) == 0 {
+		return
+	}
+	for _, symbol := range metric.Symbols {
+		g.markColumn(symbol.OID, UsageMetric)
+	}
+
+	if tagColumn != "" {
+		tag := vendorSymbolName(tagColumn)
+		metric.MetricTags = append(metric.MetricTags, profiledefinition.MetricTagConfig{
+			Tag:    tag,
+			Symbol: profiledefinition.SymbolConfigCompat{OID: tagColumn, Name: tag},
+		})
+		coverage.Tags = append(coverage.Tags, tag)
+		g.tagColumns[tagColumn] = true
+	} else {
+		for i := 1; i <= indexLength; i++ {
+			tag := tableName + "_index"
+			if indexLength > 1 {
+				tag += strconv.Itoa(i)
+			}
+			metric.MetricTags = append(metric.MetricTags, profiledefinition.MetricTagConfig{Tag: tag, Index: uint(i)})
+			coverage.Tags = append(coverage.Tags, tag)
+		}
+	}
+	g.profile.Metrics = append(g.profile.Metrics, metric)
+	g.tables = append(g.tables, coverage)
+}
+
+func (g *generator) markColumn(columnOID string, usage Usage) {
+	for _, r := range g.columns[columnOID] {
+		g.usage[columnOID+"."+r.index] = usage
+	}
+}
+
+func (g *generator) coverage() Coverage {
+	for columnOID := range g.tagColumns {
+		prefix := columnOID + "."
+		for _, oid := range g.oids {
+			if strings.HasPrefix(oid, prefix) && g.usage[oid] == UsageNone {
+				g.usage[oid] = UsageTag
+			}
+		}
+	}
+
+	coverage := Coverage{TotalOIDs: len(g.oids), Tables: g.tables}
+	uncovered := make(map[string]int)
+	for _, oid := range g.oids {
+		usage := g.usage[oid]
+		if usage == UsageNone && strings.HasPrefix(oid, systemGroupOID+".") {
+			usage = UsageBase
+		}
+		switch usage {
+		case UsageMetric:
+			coverage.MetricOIDs++
+		case UsageTag:
+			coverage.TagOIDs++
+		case UsageBase:
+			coverage.BaseOIDs++
+		default:
+			group, ok := g.groups[oid]
+			if !ok {
+				group = truncateOID(oid, uncoveredSubtreeDepth)
+			}
+			uncovered[group]++
+		}
+	}
+	for oid, count := range uncovered {
+		coverage.Uncovered = append(coverage.Uncovered, SubtreeCoverage{OID: oid, Count: count})
+	}
+	slices.SortFunc(coverage.Uncovered, func(a, b SubtreeCoverage) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), compareOIDs(a.OID, b.OID))
+	})
+	return coverage
+}
+
+// inferMetricType returns the type of the metrics of numeric values. Counters are reported as monotonic counts,
+// while the other numeric values are reported as gauges.
+func inferMetricType(pduType gosnmp.Asn1BER) (profiledefinition.ProfileMetricType, bool) {
+	switch pduType {
+	case gosnmp.Counter32, gosnmp.Counter64:
+		return profiledefinition.ProfileMetricTypeMonotonicCount, true
+	case gosnmp.Integer, gosnmp.Gauge32, gosnmp.Uinteger32, gosnmp.TimeTicks:
+		return profiledefinition.ProfileMetricTypeGauge, true
+	default:
+		return "", false
+	}
+}
+
+// isValue returns false for the walk exceptions
+func isValue(pduType gosnmp.Asn1BER) bool {
+	switch pduType {
+	case gosnmp.NoSuchObject, gosnmp.NoSuchInstance, gosnmp.EndOfMibView, gosnmp.Null:
+		return false
+	default:
+		return true
+	}
+}
+
+// isTagColumn returns true for columns of printable strings, which likely name the rows
+func isTagColumn(rows []row) bool {
+	for _, r := range rows {
+		if r.pdu.Type != gosnmp.OctetString {
+			return false
+		}
+		value, ok := r.pdu.Value.([]byte)
+		if !ok || len(value) == 0 || !utf8.Valid(value) {
+			return false
+		}
+		for _, c := range string(value) {
+			if !unicode.IsPrint(c) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isIndexColumn returns true for the numeric columns holding the index of the rows
+func isIndexColumn(rows []row) bool {
+	for _, r := range rows {
+		if fmt.Sprint(gosnmp.ToBigInt(r.pdu.Value)) != r.index {
+			return false
+		}
+	}
+	return true
+}
+
+// vendorSymbolName returns the name of a symbol of the vendor enterprise subtree, to be replaced by the name
+// defined in the vendor MIB
+func vendorSymbolName(oid string) string {
+	return "enterprise_" + strings.ReplaceAll(strings.TrimPrefix(oid, enterprisesOID+"."), ".", "_")
+}
+
+func sortedColumns(columns map[string][]row) []string {
+	return slices.SortedFunc(maps.Keys(columns), compareOIDs)
+}
+
+// compareOIDs compares OIDs component by component
+func compareOIDs(a, b string) int {
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		aValue, aErr := strconv.Atoi(aParts[i])
+		bValue, bErr := strconv.Atoi(bParts[i])
+		if aErr != nil || bErr != nil {
+			if c := strings.Compare(aParts[i], bParts[i]); c != 0 {
+				return c
+			}
+			continue
+		}
+		if c := cmp.Compare(aValue, bValue); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(aParts), len(bParts))
+}
+
+func truncateOID(oid string, depth int) string {
+	parts := strings.Split(oid, ".")
+	if len(parts) <= depth {
+		return oid
+	}
+	return strings.Join(parts[:depth], ".")
+}
+
+func normalizeOID(oid string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(oid), "."), ".")
+}
@@ -0,0 +1,180 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package profilegen
+
+import (
+	"testing"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "go.yaml.in/yaml/v2"
+
+	"github.com/DataDog/datadog-agent/pkg/networkdevice/profile/profiledefinition"
+)
+
+func pdu(oid string, pduType gosnmp.Asn1BER, value interface{}) gosnmp.SnmpPDU {
+	return gosnmp.SnmpPDU{Name: "." + oid, Type: pduType, Value: value}
+}
+
+func testWalk() []gosnmp.SnmpPDU {
+	return []gosnmp.SnmpPDU{
+		// system group
+		pdu("1.3.6.1.2.1.1.1.0", gosnmp.OctetString, []byte("Acme router")),
+		pdu("1.3.6.1.2.1.1.2.0", gosnmp.ObjectIdentifier, ".1.3.6.1.4.1.9999.1"),
+		// ifNumber and ifTable
+		pdu("1.3.6.1.2.1.2.1.0", gosnmp.Integer, 2),
+		pdu("1.3.6.1.2.1.2.2.1.1.1", gosnmp.Integer, 1),
+		pdu("1.3.6.1.2.1.2.2.1.1.2", gosnmp.Integer, 2),
+		pdu("1.3.6.1.2.1.2.2.1.2.1", gosnmp.OctetString, []byte("GigabitEthernet0/1")),
+		pdu("1.3.6.1.2.1.2.2.1.2.2", gosnmp.OctetString, []byte("GigabitEthernet0/2")),
+		pdu("1.3.6.1.2.1.2.2.1.8.1", gosnmp.Integer, 1),
+		pdu("1.3.6.1.2.1.2.2.1.8.2", gosnmp.Integer, 2),
+		pdu("1.3.6.1.2.1.2.2.1.10.1", gosnmp.Counter32, uint(100)),
+		pdu("1.3.6.1.2.1.2.2.1.10.2", gosnmp.Counter32, uint(200)),
+		// ifXTable
+		pdu("1.3.6.1.2.1.31.1.1.1.1.1", gosnmp.OctetString, []byte("Gi0/1")),
+		pdu("1.3.6.1.2.1.31.1.1.1.1.2", gosnmp.OctetString, []byte("Gi0/2")),
+		pdu("1.3.6.1.2.1.31.1.1.1.6.1", gosnmp.Counter64, uint64(1000)),
+		pdu("1.3.6.1.2.1.31.1.1.1.6.2", gosnmp.Counter64, uint64(2000)),
+		// unknown MIB-2 scalar
+		pdu("1.3.6.1.2.1.4.1.0", gosnmp.Integer, 1),
+		// vendor scalars
+		pdu("1.3.6.1.4.1.9999.1.1.0", gosnmp.Gauge32, uint(42)),
+		pdu("1.3.6.1.4.1.9999.1.5.0", gosnmp.OctetString, []byte("v1.2.3")),
+		// vendor table indexed by an integer, with an index column and a name column
+		pdu("1.3.6.1.4.1.9999.1.2.1.1.1", gosnmp.Integer, 1),
+		pdu("1.3.6.1.4.1.9999.1.2.1.1.2", gosnmp.Integer, 2),
+		pdu("1.3.6.1.4.1.9999.1.2.1.2.1", gosnmp.OctetString, []byte("fan1")),
+		pdu("1.3.6.1.4.1.9999.1.2.1.2.2", gosnmp.OctetString, []byte("fan2")),
+		pdu("1.3.6.1.4.1.9999.1.2.1.3.1", gosnmp.Gauge32, uint(3000)),
+		pdu("1.3.6.1.4.1.9999.1.2.1.3.2", gosnmp.Gauge32, uint(3100)),
+		// vendor table indexed by two integers
+		pdu("1.3.6.1.4.1.9999.1.3.1.1.1.5", gosnmp.Counter32, uint(10)),
+		pdu("1.3.6.1.4.1.9999.1.3.1.1.2.7", gosnmp.Counter32, uint(20)),
+		pdu("1.3.6.1.4.1.9999.1.3.1.2.1.5", gosnmp.Counter32, uint(30)),
+		pdu("1.3.6.1.4.1.9999.1.3.1.2.2.7", gosnmp.Counter32, uint(40)),
+		// walk exception
+		pdu("1.3.6.1.4.1.9999.2.0", gosnmp.NoSuchObject, nil),
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	result := Generate(testWalk(), ".1.3.6.1.4.1.9999.1")
+	profile := result.Profile
+
+	assert.Equal(t, []string{"_base.yaml"}, profile.Extends)
+	assert.Equal(t, profiledefinition.StringArray{"1.3.6.1.4.1.9999.1"}, profile.SysObjectIDs)
+
+	expectedMetrics := []profiledefinition.MetricsConfig{
+		{
+			MIB:   "IF-MIB",
+			Table: profiledefinition.SymbolConfig{OID: "1.3.6.1.2.1.2.2", Name: "ifTable"},
+			Symbols: []profiledefinition.SymbolConfig{
+				{OID: "1.3.6.1.2.1.2.2.1.8", Name: "ifOperStatus", MetricType: profiledefinition.ProfileMetricTypeGauge},
+				{OID: "1.3.6.1.2.1.2.2.1.10", Name: "ifInOctets", MetricType: profiledefinition.ProfileMetricTypeMonotonicCount},
+			},
+			MetricTags: profiledefinition.MetricTagConfigList{
+				{Tag: "interface", Symbol: profiledefinition.SymbolConfigCompat{OID: "1.3.6.1.2.1.31.1.1.1.1", Name: "ifName"}},
+			},
+		},
+		{
+			MIB:   "IF-MIB",
+			Table: profiledefinition.SymbolConfig{OID: "1.3.6.1.2.1.31.1.1", Name: "ifXTable"},
+			Symbols: []profiledefinition.SymbolConfig{
+				{OID: "1.3.6.1.2.1.31.1.1.1.6", Name: "ifHCInOctets", MetricType: profiledefinition.ProfileMetricTypeMonotonicCount},
+			},
+			MetricTags: profiledefinition.MetricTagConfigList{
+				{Tag: "interface", Symbol: profiledefinition.SymbolConfigCompat{OID: "1.3.6.1.2.1.31.1.1.1.1", Name: "ifName"}},
+			},
+		},
+		{
+			MIB:    "IF-MIB",
+			Symbol: profiledefinition.SymbolConfig{OID: "1.3.6.1.2.1.2.1.0", Name: "ifNumber", MetricType: profiledefinition.ProfileMetricTypeGauge},
+		},
+		{
+			Symbol: profiledefinition.SymbolConfig{OID: "1.3.6.1.4.1.9999.1.1.0", Name: "enterprise_9999_1_1", MetricType: profiledefinition.ProfileMetricTypeGauge},
+		},
+		{
+			Table: profiledefinition.SymbolConfig{OID: "1.3.6.1.4.1.9999.1.2", Name: "enterprise_9999_1_2"},
+			Symbols: []profiledefinition.SymbolConfig{
+				{OID: "1.3.6.1.4.1.9999.1.2.1.3", Name: "enterprise_9999_1_2_1_3", MetricType: profiledefinition.ProfileMetricTypeGauge},
+			},
+			MetricTags: profiledefinition.MetricTagConfigList{
+				{Tag: "enterprise_9999_1_2_1_2", Symbol: profiledefinition.SymbolConfigCompat{OID: "1.3.6.1.4.1.9999.1.2.1.2", Name: "enterprise_9999_1_2_1_2"}},
+			},
+		},
+		{
+			Table: profiledefinition.SymbolConfig{OID: "1.3.6.1.4.1.9999.1.3", Name: "enterprise_9999_1_3"},
+			Symbols: []profiledefinition.SymbolConfig{
+				{OID: "1.3.6.1.4.1.9999.1.3.1.1", Name: "enterprise_9999_1_3_1_1", MetricType: profiledefinition.ProfileMetricTypeMonotonicCount},
+				{OID: "1.3.6.1.4.1.9999.1.3.1.2", Name: "enterprise_9999_1_3_1_2", MetricType: profiledefinition.ProfileMetricTypeMonotonicCount},
+			},
+			MetricTags: profiledefinition.MetricTagConfigList{
+				{Tag: "enterprise_9999_1_3_index1", Index: 1},
+				{Tag: "enterprise_9999_1_3_index2", Index: 2},
+			},
+		},
+	}
+	assert.Equal(t, expectedMetrics, profile.Metrics)
+
+	coverage := result.Coverage
+	assert.Equal(t, 28, coverage.TotalOIDs)
+	assert.Equal(t, 14, coverage.MetricOIDs)
+	// ifName and the vendor name column
+	assert.Equal(t, 4, coverage.TagOIDs)
+	assert.Equal(t, 2, coverage.BaseOIDs)
+	assert.Equal(t, []SubtreeCoverage{
+		{OID: "1.3.6.1.2.1.2.2.1.1", Count: 2},
+		{OID: "1.3.6.1.2.1.2.2.1.2", Count: 2},
+		{OID: "1.3.6.1.4.1.9999.1.2.1.1", Count: 2},
+		{OID: "1.3.6.1.2.1.4.1.0", Count: 1},
+		{OID: "1.3.6.1.4.1.9999.1.5.0", Count: 1},
+	}, coverage.Uncovered)
+	require.Len(t, coverage.Tables, 6)
+	assert.Equal(t, TableCoverage{
+		MIB:     "IF-MIB",
+		Name:    "ifTable",
+		OID:     "1.3.6.1.2.1.2.2",
+		Rows:    2,
+		Metrics: []string{"ifOperStatus", "ifInOctets"},
+		Tags:    []string{"interface"},
+	}, coverage.Tables[0])
+	assert.True(t, coverage.Tables[5].Vendor)
+
+	report := FormatCoverageReport(coverage)
+	assert.Contains(t, report, "Total OIDs: 28  |  Covered: 20 (71.4%)  |  Uncovered: 8")
+	assert.Contains(t, report, "1.3.6.1.4.1.9999.1.2.1.1")
+}
+
+func TestGenerateFallbackToIndexTags(t *testing.T) {
+	result := Generate([]gosnmp.SnmpPDU{
+		pdu("1.3.6.1.2.1.25.3.3.1.2.196608", gosnmp.Integer, 12),
+		pdu("1.3.6.1.2.1.25.3.3.1.2.196609", gosnmp.Integer, 34),
+		pdu("1.3.6.1.2.1.2.2.1.14.1", gosnmp.Counter32, uint(0)),
+	}, "")
+
+	assert.Empty(t, result.Profile.SysObjectIDs)
+	require.Len(t, result.Profile.Metrics, 2)
+	assert.Equal(t, profiledefinition.MetricTagConfigList{{Tag: "interface", Index: 1}}, result.Profile.Metrics[0].MetricTags)
+	assert.Equal(t, profiledefinition.MetricTagConfigList{{Tag: "processorid", Index: 1}}, result.Profile.Metrics[1].MetricTags)
+	assert.Equal(t, 3, result.Coverage.MetricOIDs)
+	assert.Empty(t, result.Coverage.Uncovered)
+}
+
+func TestMarshalProfile(t *testing.T) {
+	result := Generate(testWalk(), "1.3.6.1.4.1.9999.1")
+
+	data, err := MarshalProfile(result.Profile)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# Draft profile generated from an SNMP walk.\n")
+
+	var profile profiledefinition.ProfileDefinition
+	require.NoError(t, yaml.Unmarshal(data, &profile))
+	assert.Empty(t, profiledefinition.ValidateEnrichProfile(&profile))
+	assert.Equal(t, result.Profile.SysObjectIDs, profile.SysObjectIDs)
+	assert.Len(t, profile.Metrics, len(result.Profile.Metrics))
+}
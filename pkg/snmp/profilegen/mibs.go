@@ -0,0 +1,177 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package profilegen
+
+// systemGroupOID is the MIB-2 system group, covered by the _base.yaml profile all the generated profiles extend
+const systemGroupOID = "1.3.6.1.2.1.1"
+
+// enterprisesOID is the root of the vendor enterprise subtrees
+const enterprisesOID = "1.3.6.1.4.1"
+
+// symbol is a named OID
+type symbol struct {
+	OID  string
+	Name string
+}
+
+// knownColumn is a column of a known table
+type knownColumn struct {
+	Name string
+	// Skip excludes numeric columns that are not meaningful as metrics, like indexes, enumerations used as tags
+	// and timestamps
+	Skip bool
+}
+
+// knownTag is a tag of the rows of a known table, read from the first column found in the walk. The columns
+// can belong to other tables sharing the same index.
+type knownTag struct {
+	Tag        string
+	Candidates []symbol
+	// Index is the position of the index component used as tag value when no candidate column is found
+	Index uint
+}
+
+// knownTable is a standard MIB table the generator recognizes
+type knownTable struct {
+	MIB     string
+	Name    string
+	OID     string
+	Columns map[string]knownColumn
+	Tags    []knownTag
+}
+
+// knownScalar is a standard MIB scalar the generator recognizes
+type knownScalar struct {
+	MIB  string
+	Name string
+	OID  string
+}
+
+var (
+	ifName            = symbol{OID: "1.3.6.1.2.1.31.1.1.1.1", Name: "ifName"}
+	ifDescr           = symbol{OID: "1.3.6.1.2.1.2.2.1.2", Name: "ifDescr"}
+	ifAlias           = symbol{OID: "1.3.6.1.2.1.31.1.1.1.18", Name: "ifAlias"}
+	entPhysicalName   = symbol{OID: "1.3.6.1.2.1.47.1.1.1.1.7", Name: "entPhysicalName"}
+	entPhysicalDescr  = symbol{OID: "1.3.6.1.2.1.47.1.1.1.1.2", Name: "entPhysicalDescr"}
+	entPhySensorType  = symbol{OID: "1.3.6.1.2.1.99.1.1.1.1", Name: "entPhySensorType"}
+	hrStorageDescr    = symbol{OID: "1.3.6.1.2.1.25.2.3.1.3", Name: "hrStorageDescr"}
+	interfaceTags     = []knownTag{{Tag: "interface", Candidates: []symbol{ifName, ifDescr}, Index: 1}, {Tag: "interface_alias", Candidates: []symbol{ifAlias}}}
+	entityTagsColumns = []symbol{entPhysicalName, entPhysicalDescr}
+)
+
+// knownTables are the standard MIB tables the generator recognizes, in the order their metrics are generated
+var knownTables = []knownTable{
+	{
+		MIB:  "IF-MIB",
+		Name: "ifTable",
+		OID:  "1.3.6.1.2.1.2.2",
+		Columns: map[string]knownColumn{
+			"1":  {Name: "ifIndex", Skip: true},
+			"2":  {Name: "ifDescr"},
+			"3":  {Name: "ifType", Skip: true},
+			"4":  {Name: "ifMtu", Skip: true},
+			"5":  {Name: "ifSpeed"},
+			"6":  {Name: "ifPhysAddress"},
+			"7":  {Name: "ifAdminStatus"},
+			"8":  {Name: "ifOperStatus"},
+			"9":  {Name: "ifLastChange", Skip: true},
+			"10": {Name: "ifInOctets"},
+			"11": {Name: "ifInUcastPkts"},
+			"12": {Name: "ifInNUcastPkts"},
+			"13": {Name: "ifInDiscards"},
+			"14": {Name: "ifInErrors"},
+			"15": {Name: "ifInUnknownProtos"},
+			"16": {Name: "ifOutOctets"},
+			"17": {Name: "ifOutUcastPkts"},
+			"18": {Name: "ifOutNUcastPkts"},
+			"19": {Name: "ifOutDiscards"},
+			"20": {Name: "ifOutErrors"},
+			"21": {Name: "ifOutQLen"},
+		},
+		Tags: interfaceTags,
+	},
+	{
+		MIB:  "IF-MIB",
+		Name: "ifXTable",
+		OID:  "1.3.6.1.2.1.31.1.1",
+		Columns: map[string]knownColumn{
+			"1":  {Name: "ifName"},
+			"2":  {Name: "ifInMulticastPkts"},
+			"3":  {Name: "ifInBroadcastPkts"},
+			"4":  {Name: "ifOutMulticastPkts"},
+			"5":  {Name: "ifOutBroadcastPkts"},
+			"6":  {Name: "ifHCInOctets"},
+			"7":  {Name: "ifHCInUcastPkts"},
+			"8":  {Name: "ifHCInMulticastPkts"},
+			"9":  {Name: "ifHCInBroadcastPkts"},
+			"10": {Name: "ifHCOutOctets"},
+			"11": {Name: "ifHCOutUcastPkts"},
+			"12": {Name: "ifHCOutMulticastPkts"},
+			"13": {Name: "ifHCOutBroadcastPkts"},
+			"14": {Name: "ifLinkUpDownTrapEnable", Skip: true},
+			"15": {Name: "ifHighSpeed"},
+			"16": {Name: "ifPromiscuousMode", Skip: true},
+			"17": {Name: "ifConnectorPresent", Skip: true},
+			"18": {Name: "ifAlias"},
+			"19": {Name: "ifCounterDiscontinuityTime", Skip: true},
+		},
+		Tags: interfaceTags,
+	},
+	{
+		MIB:  "ENTITY-SENSOR-MIB",
+		Name: "entPhySensorTable",
+		OID:  "1.3.6.1.2.1.99.1.1",
+		Columns: map[string]knownColumn{
+			"1": {Name: "entPhySensorType", Skip: true},
+			"2": {Name: "entPhySensorScale", Skip: true},
+			"3": {Name: "entPhySensorPrecision", Skip: true},
+			"4": {Name: "entPhySensorValue"},
+			"5": {Name: "entPhySensorOperStatus"},
+			"6": {Name: "entPhySensorUnitsDisplay"},
+			"7": {Name: "entPhySensorValueTimeStamp", Skip: true},
+			"8": {Name: "entPhySensorValueUpdateRate", Skip: true},
+		},
+		Tags: []knownTag{
+			{Tag: "sensor_name", Candidates: entityTagsColumns, Index: 1},
+			{Tag: "sensor_type", Candidates: []symbol{entPhySensorType}},
+		},
+	},
+	{
+		MIB:  "HOST-RESOURCES-MIB",
+		Name: "hrStorageTable",
+		OID:  "1.3.6.1.2.1.25.2.3",
+		Columns: map[string]knownColumn{
+			"1": {Name: "hrStorageIndex", Skip: true},
+			"2": {Name: "hrStorageType"},
+			"3": {Name: "hrStorageDescr"},
+			"4": {Name: "hrStorageAllocationUnits"},
+			"5": {Name: "hrStorageSize"},
+			"6": {Name: "hrStorageUsed"},
+			"7": {Name: "hrStorageAllocationFailures"},
+		},
+		Tags: []knownTag{{Tag: "storage_desc", Candidates: []symbol{hrStorageDescr}, Index: 1}},
+	},
+	{
+		MIB:  "HOST-RESOURCES-MIB",
+		Name: "hrProcessorTable",
+		OID:  "1.3.6.1.2.1.25.3.3",
+		Columns: map[string]knownColumn{
+			"1": {Name: "hrProcessorFrwID"},
+			"2": {Name: "hrProcessorLoad"},
+		},
+		Tags: []knownTag{{Tag: "processorid", Index: 1}},
+	},
+}
+
+// knownScalars are the standard MIB scalars the generator recognizes
+var knownScalars = []knownScalar{
+	{MIB: "IF-MIB", Name: "ifNumber", OID: "1.3.6.1.2.1.2.1.0"},
+	{MIB: "HOST-RESOURCES-MIB", Name: "hrSystemUptime", OID: "1.3.6.1.2.1.25.1.1.0"},
+	{MIB: "HOST-RESOURCES-MIB", Name: "hrSystemNumUsers", OID: "1.3.6.1.2.1.25.1.5.0"},
+	{MIB: "HOST-RESOURCES-MIB", Name: "hrSystemProcesses", OID: "1.3.6.1.2.1.25.1.6.0"},
+	{MIB: "HOST-RESOURCES-MIB", Name: "hrSystemMaxProcesses", OID: "1.3.6.1.2.1.25.1.7.0"},
+	{MIB: "HOST-RESOURCES-MIB", Name: "hrMemorySize", OID: "1.3.6.1.2.1.25.2.2.0"},
+}
@@ -0,0 +1,103 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package profilegen
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	reportWidth = 100
+
+	wColSource  = 18
+	wColTable   = 40
+	wColRows    = 6
+	wColMetrics = 8
+	wColTags    = 24
+
+	wColSubtree = 60
+	wColCount   = 8
+
+	// maxUncoveredSubtrees is the number of uncovered subtrees listed by the report
+	maxUncoveredSubtrees = 25
+)
+
+// FormatCoverageReport formats the coverage of the walk by a generated profile
+func FormatCoverageReport(coverage Coverage) string {
+	var b strings.Builder
+	rule := strings.Repeat("=", reportWidth)
+	dash := strings.Repeat("-", reportWidth)
+
+	b.WriteString(rule + "\n")
+	b.WriteString(center("SNMP Profile Generation", reportWidth) + "\n")
+	b.WriteString(dash + "\n")
+	fmt.Fprintf(&b, "  Total OIDs: %d  |  Covered: %d (%s)  |  Uncovered: %d\n",
+		coverage.TotalOIDs, coverage.CoveredOIDs(), percent(coverage.CoveredOIDs(), coverage.TotalOIDs), coverage.TotalOIDs-coverage.CoveredOIDs())
+	fmt.Fprintf(&b, "  Metrics: %d  |  Tags: %d  |  Base profile: %d\n", coverage.MetricOIDs, coverage.TagOIDs, coverage.BaseOIDs)
+	b.WriteString(rule + "\n\n")
+
+	b.WriteString("Generated Metrics\n")
+	b.WriteString(dash + "\n")
+	fmt.Fprintf(&b, "%-*s %-*s %*s %*s  %-*s\n",
+		wColSource, "SOURCE",
+		wColTable, "TABLE / SCALAR",
+		wColRows, "ROWS",
+		wColMetrics, "METRICS",
+		wColTags, "TAGS",
+	)
+	for _, table := range coverage.Tables {
+		source := table.MIB
+		if table.Vendor {
+			source = "vendor (inferred)"
+		}
+		fmt.Fprintf(&b, "%-*s %-*s %*d %*d  %-*s\n",
+			wColSource, truncate(source, wColSource),
+			wColTable, truncate(table.Name, wColTable),
+			wColRows, table.Rows,
+			wColMetrics, len(table.Metrics),
+			wColTags, truncate(strings.Join(table.Tags, ", "), wColTags),
+		)
+	}
+
+	b.WriteString("\nUncovered Subtrees\n")
+	b.WriteString(dash + "\n")
+	fmt.Fprintf(&b, "%-*s %*s\n", wColSubtree, "OID", wColCount, "OIDS")
+	for i, subtree := range coverage.Uncovered {
+		if i == maxUncoveredSubtrees {
+			fmt.Fprintf(&b, "... and %d more subtrees\n", len(coverage.Uncovered)-maxUncoveredSubtrees)
+			break
+		}
+		fmt.Fprintf(&b, "%-*s %*d\n", wColSubtree, truncate(subtree.OID, wColSubtree), wColCount, subtree.Count)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+func percent(part, total int) string {
+	if total == 0 {
+		return "0.0%"
+	}
+	return fmt.Sprintf("%.1f%%", float64(part)*100/float64(total))
+}
+
+func center(s string, w int) string {
+	if len(s) >= w {
+		return s[:w]
+	}
+	pad := (w - len(s)) / 2
+	return strings.Repeat(" ", pad) + s + strings.Repeat(" ", w-pad-len(s))
+}
+
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	if max <= 3 {
+		return s[:max]
+	}
+	return s[:max-3] + "..."
+}
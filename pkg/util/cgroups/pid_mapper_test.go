@@ -177,6 +177,10 @@ var (
 	cgroupV2KubePodsWithSubcgroup = `0::/kubepods/burstable/pod15513b48-e7a5-48fc-b9e3-92f713f36504/a51a9f7d073f848e7fc59e56e8f11524f330a2175a4ed26327da2dfe0d28015f/sensor.falcon`
 
 	dindCgroupV2WithSubcgroup = `0::/docker/88ea268ece65a02d68b169fd74bcbcb427eb7f28900db0e3b906fb2eeb7341df/kubelet/kubepods/burstable/poda5ea884f-9e60-4912-bd62-fef9a31db47a/a51a9f7d073f848e7fc59e56e8f11524f330a2175a4ed26327da2dfe0d28015e/sensor.falcon`
+
+	lxcCgroupV2 = `0::/lxc.payload.web1/system.slice/ssh.service`
+
+	dockerInLXCCgroupV2 = `0::/lxc.payload.edge_web1/system.slice/docker-a51a9f7d073f848e7fc59e56e8f11524f330a2175a4ed26327da2dfe0d28015e.scope`
 )
 
 func TestProcPidMapperCgroupV2(t *testing.T) {
@@ -214,6 +218,7 @@ func TestIdentiferFromCgroupReferences(t *testing.T) {
 		name        string
 		fileContent string
 		controller  string
+		filter      ReaderFilter
 		expectedID  string
 	}{
 		{
@@ -266,6 +271,26 @@ func TestIdentiferFromCgroupReferences(t *testing.T) {
 			controller:  "",
 			expectedID:  "a51a9f7d073f848e7fc59e56e8f11524f330a2175a4ed26327da2dfe0d28015e",
 		},
+		{
+			name:        "cgroupv2 lxc container is not a container by default",
+			fileContent: lxcCgroupV2,
+			controller:  "",
+			expectedID:  "",
+		},
+		{
+			name:        "cgroupv2 lxc container",
+			fileContent: lxcCgroupV2,
+			controller:  "",
+			filter:      LXCContainerFilter,
+			expectedID:  "lxc.payload.web1",
+		},
+		{
+			name:        "cgroupv2 docker in lxc container picks innermost",
+			fileContent: dockerInLXCCgroupV2,
+			controller:  "",
+			filter:      LXCContainerFilter,
+			expectedID:  "a51a9f7d073f848e7fc59e56e8f11524f330a2175a4ed26327da2dfe0d28015e",
+		},
 	}
 
 	for _, test := range tests {
@@ -276,7 +301,11 @@ func TestIdentiferFromCgroupReferences(t *testing.T) {
 			require.NoErrorf(t, os.MkdirAll(procPIDPath, 0o750), "impossible to create temp directory '%s'", procPath)
 			require.NoError(t, os.WriteFile(filepath.Join(procPIDPath, "cgroup"), []byte(test.fileContent), 0o640))
 
-			id, err := IdentiferFromCgroupReferences(procPath, "123", test.controller, ContainerFilter)
+			filter := test.filter
+			if filter == nil {
+				filter = ContainerFilter
+			}
+			id, err := IdentiferFromCgroupReferences(procPath, "123", test.controller, filter)
 			require.NoError(t, err)
 			assert.Equal(t, test.expectedID, id)
 		})
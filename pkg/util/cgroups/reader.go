@@ -23,6 +23,12 @@ const (
 	// ([0-9a-f]{32}-\d+) is container id used by AWS ECS
 	// ([0-9a-f]{8}(-[0-9a-f]{4}){4}$) is container id used by Garden
 	ContainerRegexpStr = "([0-9a-f]{64})|([0-9a-f]{32}-\\d+)|([0-9a-f]{8}(-[0-9a-f]{4}){4}$)"
+
+	// LXCPayloadPrefix is the prefix of the cgroup folder of LXC, LXD and Incus containers. It is followed
+	// by the container name, itself prefixed by `<project>_` for Incus/LXD projects other than `default`.
+	// As these containers don't have an ID, the cgroup folder name is used as their container ID by
+	// LXCContainerFilter.
+	LXCPayloadPrefix = "lxc.payload."
 )
 
 // Reader is the main interface to scrape data from cgroups
@@ -99,6 +105,27 @@ func ContainerFilter(path, name string) (string, error) {
 	return "", nil
 }
 
+// LXCContainerFilter is a ContainerFilter that also matches the cgroup folders of LXC, LXD and Incus
+// containers, using the folder name as container ID. Containers nested in them, like Docker containers,
+// are still identified by their own ID. It must only be used when the Incus collector reports these
+// containers, as plain LXC hosts (e.g. Proxmox) would otherwise see their processes attributed to
+// unknown containers.
+func LXCContainerFilter(path, name string) (string, error) {
+	if id, _ := ContainerFilter(path, name); id != "" {
+		return id, nil
+	}
+	if _, shouldExclude := matchContainerID(name); shouldExclude {
+		return "", nil
+	}
+
+	for _, part := range slices.Backward(append(strings.Split(path, "/"), name)) {
+		if strings.HasPrefix(part, LXCPayloadPrefix) && len(part) > len(LXCPayloadPrefix) {
+			return part, nil
+		}
+	}
+	return "", nil
+}
+
 // ReaderOption allows to customize reader behavior (Builder-style)
 type ReaderOption func(*Reader)
 
@@ -30,6 +30,8 @@ const (
 	RuntimeNameCRIO                Runtime = "cri-o"
 	RuntimeNameGarden              Runtime = "garden"
 	RuntimeNamePodman              Runtime = "podman"
+	RuntimeNameIncus               Runtime = "incus"
+	RuntimeNameLXD                 Runtime = "lxd"
 	RuntimeNameECSFargate          Runtime = "ecsfargate"
 	RuntimeNameECSManagedInstances Runtime = "ecsmanagedinstances"
 	RuntimeNameCRINonstandard      Runtime = "cri-nonstandard"
@@ -59,6 +61,8 @@ var (
 		RuntimeNameCRIO,
 		RuntimeNameGarden,
 		RuntimeNamePodman,
+		RuntimeNameIncus,
+		RuntimeNameLXD,
 		RuntimeNameECSFargate,
 		RuntimeNameECSManagedInstances,
 		RuntimeNameCRINonstandard,
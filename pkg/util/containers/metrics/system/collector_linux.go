@@ -48,6 +48,8 @@ type systemCollector struct {
 	procPath            string
 	baseController      string
 	hostCgroupNamespace bool
+	// containerFilter identifies the containers from cgroup paths when not using the improved cgroup parser
+	containerFilter cgroups.ReaderFilter
 }
 
 func newSystemCollector(cache *provider.Cache, wlm option.Option[workloadmeta.Component]) (provider.CollectorMetadata, error) {
@@ -61,17 +63,23 @@ func newSystemCollector(cache *provider.Cache, wlm option.Option[workloadmeta.Co
 		hostPrefix = "/host"
 	}
 
+	// LXC containers are only identified when the Incus collector reports them
+	containerFilter := cgroups.ContainerFilter
+	if env.IsFeaturePresent(env.Incus) {
+		containerFilter = cgroups.LXCContainerFilter
+	}
+
 	if useTrie := pkgconfigsetup.Datadog().GetBool("use_improved_cgroup_parser"); useTrie {
 		var w workloadmeta.Component
 		unwrapped, ok := wlm.Get()
 		if ok {
 			w = unwrapped
 		}
-		filter := newContainerFilter(w)
+		filter := newContainerFilter(w, containerFilter)
 		go filter.start()
 		cf = filter.ContainerFilter
 	} else {
-		cf = cgroups.ContainerFilter
+		cf = containerFilter
 	}
 	reader, err := cgroups.NewReader(
 		cgroups.WithCgroupV1BaseController(cgroupV1BaseController),
@@ -97,9 +105,10 @@ func newSystemCollector(cache *provider.Cache, wlm option.Option[workloadmeta.Co
 		return collectorMetadata, provider.ErrPermaFail
 	}
 	systemCollector := &systemCollector{
-		reader:     reader,
-		selfReader: selfReader,
-		procPath:   procPath,
+		reader:          reader,
+		selfReader:      selfReader,
+		procPath:        procPath,
+		containerFilter: containerFilter,
 	}
 
 	// Set base controller for cgroupV1 (remains empty for cgroupV2)
@@ -141,7 +150,7 @@ func newSystemCollector(cache *provider.Cache, wlm option.Option[workloadmeta.Co
 		} else if isAgentSidecar {
 			// When side car with sharedPIDNamespace, we can get the same data.
 			// As we don't know if we are sharedPIDNamespace, adding as low priority.
-			systemCollector.pidMapper = cgroups.NewStandalonePIDMapper(systemCollector.procPath, systemCollector.baseController, cgroups.ContainerFilter)
+			systemCollector.pidMapper = cgroups.NewStandalonePIDMapper(systemCollector.procPath, systemCollector.baseController, containerFilter)
 
 			collectors.Network = provider.MakeRef[provider.ContainerNetworkStatsGetter](systemCollector, collectorLowPriority)
 			collectors.OpenFilesCount = provider.MakeRef[provider.ContainerOpenFilesCountGetter](systemCollector, collectorLowPriority)
@@ -224,7 +233,7 @@ func (c *systemCollector) GetPIDs(_, containerID string, cacheValidity time.Dura
 }
 
 func (c *systemCollector) GetContainerIDForPID(pid int, _ time.Duration) (string, error) {
-	containerID, err := cgroups.IdentiferFromCgroupReferences(c.procPath, strconv.Itoa(pid), c.baseController, cgroups.ContainerFilter)
+	containerID, err := cgroups.IdentiferFromCgroupReferences(c.procPath, strconv.Itoa(pid), c.baseController, c.containerFilter)
 	return containerID, err
 }
 
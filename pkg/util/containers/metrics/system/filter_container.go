@@ -22,16 +22,19 @@ import (
 // the metadata retrieved from workloadmeta.
 type containerFilter struct {
 	wlm workloadmeta.Component
+	// cgroupFilter is the regex based filter tried first
+	cgroupFilter cgroups.ReaderFilter
 
 	mutex sync.RWMutex
 	trie  *trie.SuffixTrie[string]
 }
 
 // newContainerFilter returns a new container filter
-func newContainerFilter(wlm workloadmeta.Component) *containerFilter {
+func newContainerFilter(wlm workloadmeta.Component, cgroupFilter cgroups.ReaderFilter) *containerFilter {
 	cf := &containerFilter{
-		trie: trie.NewSuffixTrie[string](),
-		wlm:  wlm,
+		trie:         trie.NewSuffixTrie[string](),
+		wlm:          wlm,
+		cgroupFilter: cgroupFilter,
 	}
 	return cf
 }
@@ -65,7 +68,7 @@ func (cf *containerFilter) handleEvent(ev workloadmeta.Event) {
 		if cont.CgroupPath != "" {
 			// As a memory optimization, we only store the container id in the trie
 			// if the cgroup path is not already matched by the cgroup filter.
-			if res, _ := cgroups.ContainerFilter("", cont.CgroupPath); res == "" {
+			if res, _ := cf.cgroupFilter("", cont.CgroupPath); res == "" {
 				cid := cont.ID
 				cf.trie.Insert(cont.CgroupPath, &cid)
 			}
@@ -79,7 +82,7 @@ func (cf *containerFilter) handleEvent(ev workloadmeta.Event) {
 
 // ContainerFilter returns a filter that will match cgroup folders containing a container id
 func (cf *containerFilter) ContainerFilter(fullPath, name string) (string, error) {
-	if res, _ := cgroups.ContainerFilter(fullPath, name); res != "" {
+	if res, _ := cf.cgroupFilter(fullPath, name); res != "" {
 		return res, nil
 	}
 	cf.mutex.RLock()
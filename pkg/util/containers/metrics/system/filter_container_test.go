@@ -18,6 +18,7 @@ import (
 	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
 	workloadmetafxmock "github.com/DataDog/datadog-agent/comp/core/workloadmeta/fx-mock"
 	workloadmetamock "github.com/DataDog/datadog-agent/comp/core/workloadmeta/mock"
+	"github.com/DataDog/datadog-agent/pkg/util/cgroups"
 	"github.com/DataDog/datadog-agent/pkg/util/fxutil"
 
 	"github.com/stretchr/testify/assert"
@@ -48,7 +49,7 @@ func TestHandleSetEvent(t *testing.T) {
 		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
-			cf := newContainerFilter(nil)
+			cf := newContainerFilter(nil, cgroups.ContainerFilter)
 			cont := &workloadmeta.Container{
 				EntityID: workloadmeta.EntityID{
 					Kind: workloadmeta.KindContainer,
@@ -69,7 +70,7 @@ func TestHandleSetEvent(t *testing.T) {
 }
 
 func TestHandleUnsetEvent(t *testing.T) {
-	cf := newContainerFilter(nil)
+	cf := newContainerFilter(nil, cgroups.ContainerFilter)
 	cont := &workloadmeta.Container{
 		EntityID: workloadmeta.EntityID{
 			Kind: workloadmeta.KindContainer,
@@ -97,7 +98,7 @@ func TestListenWorkloadmeta(t *testing.T) {
 		fx.Supply(context.Background()),
 		workloadmetafxmock.MockModule(workloadmeta.NewParams()),
 	))
-	cf := newContainerFilter(wlm)
+	cf := newContainerFilter(wlm, cgroups.ContainerFilter)
 	go cf.start()
 	cont := &workloadmeta.Container{
 		EntityID: workloadmeta.EntityID{
@@ -0,0 +1,144 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package incus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"time"
+)
+
+const (
+	defaultIncusSocket    = "/var/lib/incus/unix.socket"
+	defaultLXDSnapSocket  = "/var/snap/lxd/common/lxd/unix.socket"
+	defaultLXDSocket      = "/var/lib/lxd/unix.socket"
+	defaultHostPrefix     = "/host"
+	defaultRequestTimeout = 10 * time.Second
+	// the API returns the whole state of every instance, keep room for large hosts
+	maxResponseSize = 64 * 1024 * 1024
+)
+
+// Client is a client for the Incus/LXD REST API, reached on the local unix socket
+type Client struct {
+	SocketPath string
+	httpClient *http.Client
+}
+
+// NewClient returns a new client using the given unix socket
+func NewClient(socketPath string) *Client {
+	return &Client{
+		SocketPath: socketPath,
+		httpClient: &http.Client{
+			Timeout: defaultRequestTimeout,
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var d net.Dialer
+					return d.DialContext(ctx, "unix", socketPath)
+				},
+			},
+		},
+	}
+}
+
+// DefaultSocketPaths returns the paths where the Incus and LXD sockets are
+// usually found, first on the host then under hostPrefix when it is set
+func DefaultSocketPaths(hostPrefix string) []string {
+	prefixes := []string{""}
+	if hostPrefix != "" {
+		prefixes = append(prefixes, hostPrefix)
+	}
+
+	var paths []string
+	for _, prefix := range prefixes {
+		for _, socketPath := range []string{defaultIncusSocket, defaultLXDSnapSocket, defaultLXDSocket} {
+			paths = append(paths, path.Join(prefix, socketPath))
+		}
+	}
+	return paths
+}
+
+// FindSocket returns the first existing socket among the configured path, or
+// the default ones when it is empty
+func FindSocket(configuredPath string, containerized bool) (string, error) {
+	candidates := []string{configuredPath}
+	if configuredPath == "" {
+		hostPrefix := ""
+		if containerized {
+			hostPrefix = defaultHostPrefix
+		}
+		candidates = DefaultSocketPaths(hostPrefix)
+	}
+
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && info.Mode()&os.ModeSocket != 0 {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("no Incus or LXD socket found in %v", candidates)
+}
+
+// GetServer returns the information about the server
+func (c *Client) GetServer(ctx context.Context) (*Server, error) {
+	var server Server
+	if err := c.get(ctx, "/1.0", &server); err != nil {
+		return nil, err
+	}
+	return &server, nil
+}
+
+// GetInstances returns the instances of all the projects, along with their state
+func (c *Client) GetInstances(ctx context.Context) ([]Instance, error) {
+	var instances []Instance
+	if err := c.get(ctx, "/1.0/instances?recursion=2&all-projects=true", &instances); err != nil {
+		return nil, err
+	}
+	return instances, nil
+}
+
+func (c *Client) get(ctx context.Context, endpoint string, metadata interface{}) error {
+	// the host is ignored when dialing the unix socket
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://unix"+endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to query %s on %s: %w", endpoint, c.SocketPath, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return fmt.Errorf("unable to read the response of %s: %w", endpoint, err)
+	}
+
+	var r response
+	if err := json.Unmarshal(body, &r); err != nil {
+		return fmt.Errorf("unable to parse the response of %s: %w", endpoint, err)
+	}
+	if r.Type == "error" || resp.StatusCode != http.StatusOK {
+		if r.Error == "" {
+			r.Error = resp.Status
+		}
+		return fmt.Errorf("%s returned an error: %s", endpoint, r.Error)
+	}
+	if len(r.Metadata) == 0 {
+		return errors.New(endpoint + " returned no metadata")
+	}
+
+	if err := json.Unmarshal(r.Metadata, metadata); err != nil {
+		return fmt.Errorf("unable to parse the metadata of %s: %w", endpoint, err)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package incus
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testInstances = `{
+  "type": "sync",
+  "status": "Success",
+  "status_code": 200,
+  "metadata": [
+    {
+      "name": "web1",
+      "project": "default",
+      "type": "container",
+      "status": "Running",
+      "created_at": "2024-05-01T10:00:00Z",
+      "last_used_at": "2024-05-02T10:00:00Z",
+      "config": {"image.os": "Ubuntu", "image.release": "noble", "user.team": "edge"},
+      "expanded_config": {
+        "image.os": "Ubuntu",
+        "image.release": "noble",
+        "user.team": "edge",
+        "user.com.datadoghq.tags.env": "prod",
+        "environment.DD_SERVICE": "web"
+      },
+      "state": {
+        "status": "Running",
+        "pid": 4242,
+        "network": {
+          "eth0": {"addresses": [{"family": "inet", "address": "10.0.3.15", "netmask": "24", "scope": "global"}]}
+        }
+      }
+    },
+    {
+      "name": "db",
+      "project": "edge",
+      "type": "virtual-machine",
+      "status": "Stopped"
+    }
+  ]
+}`
+
+func newTestServer(t *testing.T, handler http.HandlerFunc) *Client {
+	socketPath := filepath.Join(t.TempDir(), "unix.socket")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(handler)
+	server.Listener = listener
+	server.Start()
+	t.Cleanup(server.Close)
+
+	return NewClient(socketPath)
+}
+
+func TestGetInstances(t *testing.T) {
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/1.0/instances", r.URL.Path)
+		assert.Equal(t, "2", r.URL.Query().Get("recursion"))
+		assert.Equal(t, "true", r.URL.Query().Get("all-projects"))
+		_, _ = w.Write([]byte(testInstances))
+	})
+
+	instances, err := client.GetInstances(context.Background())
+	require.NoError(t, err)
+	require.Len(t, instances, 2)
+
+	web := instances[0]
+	assert.Equal(t, "web1", web.Name)
+	assert.Equal(t, InstanceTypeContainer, web.Type)
+	assert.Equal(t, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), web.CreatedAt)
+	require.NotNil(t, web.State)
+	assert.Equal(t, 4242, web.State.PID)
+	assert.Equal(t, "10.0.3.15", web.State.Network["eth0"].Addresses[0].Address)
+	assert.Equal(t, "lxc.payload.web1", web.ContainerID())
+	assert.Equal(t, map[string]string{"team": "edge", "com.datadoghq.tags.env": "prod"}, web.UserConfig())
+	assert.Equal(t, map[string]string{"DD_SERVICE": "web"}, web.Environment())
+
+	db := instances[1]
+	assert.Nil(t, db.State)
+	assert.Equal(t, "lxc.payload.edge_db", db.ContainerID())
+}
+
+func TestGetServer(t *testing.T) {
+	client := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"type": "sync", "metadata": {"environment": {"server": "lxd", "server_version": "5.21.1"}}}`))
+	})
+
+	server, err := client.GetServer(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "lxd", server.Environment.Server)
+	assert.Equal(t, "5.21.1", server.Environment.ServerVersion)
+}
+
+func TestGetError(t *testing.T) {
+	client := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"type": "error", "error": "not authorized", "error_code": 403}`))
+	})
+
+	_, err := client.GetInstances(context.Background())
+	assert.ErrorContains(t, err, "not authorized")
+}
+
+func TestFindSocket(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "unix.socket")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	found, err := FindSocket(socketPath, false)
+	require.NoError(t, err)
+	assert.Equal(t, socketPath, found)
+
+	_, err = FindSocket(filepath.Join(t.TempDir(), "missing.socket"), false)
+	assert.Error(t, err)
+
+	assert.Equal(t, []string{
+		"/var/lib/incus/unix.socket",
+		"/var/snap/lxd/common/lxd/unix.socket",
+		"/var/lib/lxd/unix.socket",
+		"/host/var/lib/incus/unix.socket",
+		"/host/var/snap/lxd/common/lxd/unix.socket",
+		"/host/var/lib/lxd/unix.socket",
+	}, DefaultSocketPaths("/host"))
+}
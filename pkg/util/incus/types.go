@@ -0,0 +1,141 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package incus
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// The types in this file only hold the subset of the Incus API we need. The
+// LXD API is the same for these fields.
+// See https://linuxcontainers.org/incus/docs/main/rest-api/
+
+const (
+	// InstanceTypeContainer is the type of system containers
+	InstanceTypeContainer = "container"
+	// InstanceTypeVirtualMachine is the type of virtual machines
+	InstanceTypeVirtualMachine = "virtual-machine"
+
+	// DefaultProject is the project instances belong to when projects aren't used
+	DefaultProject = "default"
+
+	// UserConfigPrefix is the prefix of the free-form instance configuration keys
+	UserConfigPrefix = "user."
+	// EnvironmentConfigPrefix is the prefix of the instance configuration keys setting environment variables
+	EnvironmentConfigPrefix = "environment."
+
+	// lxcPayloadPrefix mirrors cgroups.LXCPayloadPrefix, the prefix of the cgroup of a container
+	lxcPayloadPrefix = "lxc.payload."
+)
+
+// Instance statuses
+const (
+	StatusRunning  = "Running"
+	StatusStopped  = "Stopped"
+	StatusStarting = "Starting"
+	StatusStopping = "Stopping"
+	StatusFrozen   = "Frozen"
+	StatusFreezing = "Freezing"
+	StatusError    = "Error"
+)
+
+// response is the envelope of every API response
+type response struct {
+	Type      string          `json:"type"`
+	Status    string          `json:"status"`
+	Error     string          `json:"error"`
+	ErrorCode int             `json:"error_code"`
+	Metadata  json.RawMessage `json:"metadata"`
+}
+
+// Server holds the information about the Incus or LXD server
+type Server struct {
+	Environment ServerEnvironment `json:"environment"`
+}
+
+// ServerEnvironment describes the server software
+type ServerEnvironment struct {
+	// Server is the server implementation, `incus` or `lxd`
+	Server        string `json:"server"`
+	ServerVersion string `json:"server_version"`
+	Driver        string `json:"driver"`
+}
+
+// Instance is an Incus/LXD instance, along with its state
+type Instance struct {
+	Name           string            `json:"name"`
+	Project        string            `json:"project"`
+	Type           string            `json:"type"`
+	Status         string            `json:"status"`
+	Description    string            `json:"description"`
+	Location       string            `json:"location"`
+	CreatedAt      time.Time         `json:"created_at"`
+	LastUsedAt     time.Time         `json:"last_used_at"`
+	Config         map[string]string `json:"config"`
+	ExpandedConfig map[string]string `json:"expanded_config"`
+	State          *InstanceState    `json:"state"`
+}
+
+// InstanceState is the runtime state of an instance
+type InstanceState struct {
+	Status  string                          `json:"status"`
+	PID     int                             `json:"pid"`
+	Network map[string]InstanceStateNetwork `json:"network"`
+}
+
+// InstanceStateNetwork is the state of a network interface of an instance
+type InstanceStateNetwork struct {
+	Addresses []InstanceStateNetworkAddress `json:"addresses"`
+	HostName  string                        `json:"host_name"`
+	Type      string                        `json:"type"`
+}
+
+// InstanceStateNetworkAddress is an address of a network interface
+type InstanceStateNetworkAddress struct {
+	Family  string `json:"family"`
+	Address string `json:"address"`
+	Netmask string `json:"netmask"`
+	Scope   string `json:"scope"`
+}
+
+// ContainerID returns the identifier of a container, which is the name of its
+// cgroup as no other identifier is shared between Incus and the host.
+// Instances of projects other than `default` are prefixed by their project.
+func (i *Instance) ContainerID() string {
+	if i.Project == "" || i.Project == DefaultProject {
+		return lxcPayloadPrefix + i.Name
+	}
+	return lxcPayloadPrefix + i.Project + "_" + i.Name
+}
+
+// UserConfig returns the `user.*` configuration keys of the instance, including
+// the ones inherited from its profiles, without their prefix
+func (i *Instance) UserConfig() map[string]string {
+	return i.configWithPrefix(UserConfigPrefix)
+}
+
+// Environment returns the environment variables set in the configuration of
+// the instance, including the ones inherited from its profiles
+func (i *Instance) Environment() map[string]string {
+	return i.configWithPrefix(EnvironmentConfigPrefix)
+}
+
+func (i *Instance) configWithPrefix(prefix string) map[string]string {
+	config := i.ExpandedConfig
+	if config == nil {
+		config = i.Config
+	}
+
+	res := make(map[string]string)
+	for key, value := range config {
+		if name, found := strings.CutPrefix(key, prefix); found && name != "" {
+			res[name] = value
+		}
+	}
+	return res
+}
@@ -0,0 +1,263 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+// Package nomad provides a minimal client for the HashiCorp Nomad HTTP API,
+// used by the autodiscovery Nomad config provider and listener.
+package nomad
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	pkgconfigmodel "github.com/DataDog/datadog-agent/pkg/config/model"
+)
+
+const (
+	tokenHeader = "X-Nomad-Token"
+	indexHeader = "X-Nomad-Index"
+
+	// AllNamespaces is the wildcard namespace accepted by the Nomad API
+	AllNamespaces = "*"
+)
+
+// ErrNotClientMode is returned by LocalNodeID when the Nomad agent only runs as
+// a server, and therefore has no node
+var ErrNotClientMode = errors.New("nomad agent is not running in client mode")
+
+// Config holds the settings needed to connect to the Nomad HTTP API
+type Config struct {
+	URL       string
+	Token     string
+	Namespace string
+	CAFile    string
+	CertFile  string
+	KeyFile   string
+	Timeout   time.Duration
+}
+
+// ConfigFromAgentConfig builds a Config from the `nomad` section of the agent configuration
+func ConfigFromAgentConfig(cfg pkgconfigmodel.Reader) Config {
+	return Config{
+		URL:       cfg.GetString("nomad.url"),
+		Token:     cfg.GetString("nomad.token"),
+		Namespace: cfg.GetString("nomad.namespace"),
+		CAFile:    cfg.GetString("nomad.ca_file"),
+		CertFile:  cfg.GetString("nomad.cert_file"),
+		KeyFile:   cfg.GetString("nomad.key_file"),
+		Timeout:   time.Duration(cfg.GetInt("nomad.timeout")) * time.Second,
+	}
+}
+
+// Client queries the Nomad HTTP API
+type Client struct {
+	baseURL    *url.URL
+	token      string
+	namespace  string
+	httpClient *http.Client
+}
+
+// NewClient returns a new Client for the given configuration
+func NewClient(config Config) (*Client, error) {
+	if config.URL == "" {
+		return nil, errors.New("nomad url is empty")
+	}
+	baseURL, err := url.Parse(config.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nomad url %q: %w", config.URL, err)
+	}
+
+	tlsConfig, err := buildTLSConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := config.Namespace
+	if namespace == "" {
+		namespace = AllNamespaces
+	}
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &Client{
+		baseURL:   baseURL,
+		token:     config.Token,
+		namespace: namespace,
+		httpClient: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: tlsConfig,
+			},
+		},
+	}, nil
+}
+
+func buildTLSConfig(config Config) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if config.CAFile != "" {
+		caCert, err := os.ReadFile(config.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read nomad ca_file %s: %w", config.CAFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("cannot parse any certificate from nomad ca_file %s", config.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if config.CertFile != "" && config.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load nomad client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// ListServices returns the services registered in the configured namespace(s)
+// along with the Nomad index of the service registration table, which changes
+// whenever a registration is added, updated or removed.
+func (c *Client) ListServices(ctx context.Context) ([]ServiceListStub, uint64, error) {
+	var stubs []ServiceListStub
+	index, err := c.get(ctx, "/v1/services", c.namespace, &stubs)
+	return stubs, index, err
+}
+
+// GetServiceRegistrations returns every registration of a service
+func (c *Client) GetServiceRegistrations(ctx context.Context, namespace, serviceName string) ([]ServiceRegistration, error) {
+	var registrations []ServiceRegistration
+	_, err := c.get(ctx, "/v1/service/"+url.PathEscape(serviceName), namespace, &registrations)
+	return registrations, err
+}
+
+// ListServiceRegistrations returns the registrations of every service, along
+// with the index returned by ListServices.
+func (c *Client) ListServiceRegistrations(ctx context.Context) ([]ServiceRegistration, uint64, error) {
+	stubs, index, err := c.ListServices(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var registrations []ServiceRegistration
+	for _, stub := range stubs {
+		for _, svc := range stub.Services {
+			regs, err := c.GetServiceRegistrations(ctx, stub.Namespace, svc.ServiceName)
+			if err != nil {
+				return nil, 0, err
+			}
+			registrations = append(registrations, regs...)
+		}
+	}
+	return registrations, index, nil
+}
+
+// ListNodeServiceRegistrations returns the registrations of the services
+// exposed by the allocations running on the given client node, along with the
+// index of the node allocations. Unlike ListServiceRegistrations, it only
+// queries the allocations of that node instead of every service of the cluster.
+func (c *Client) ListNodeServiceRegistrations(ctx context.Context, nodeID string) ([]ServiceRegistration, uint64, error) {
+	var allocs []Allocation
+	index, err := c.get(ctx, "/v1/node/"+url.PathEscape(nodeID)+"/allocations", "", &allocs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var registrations []ServiceRegistration
+	for _, alloc := range allocs {
+		if alloc.IsTerminal() {
+			continue
+		}
+		if c.namespace != AllNamespaces && alloc.Namespace != c.namespace {
+			continue
+		}
+		var regs []ServiceRegistration
+		if _, err := c.get(ctx, "/v1/allocation/"+url.PathEscape(alloc.ID)+"/services", alloc.Namespace, &regs); err != nil {
+			return nil, 0, err
+		}
+		registrations = append(registrations, regs...)
+	}
+	return registrations, index, nil
+}
+
+// GetJob returns the specification of a job
+func (c *Client) GetJob(ctx context.Context, namespace, jobID string) (*Job, error) {
+	job := &Job{}
+	if _, err := c.get(ctx, "/v1/job/"+url.PathEscape(jobID), namespace, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// agentSelf is the subset of the /v1/agent/self response used to identify the local node
+type agentSelf struct {
+	Stats struct {
+		Client struct {
+			NodeID string `json:"node_id"`
+		} `json:"client"`
+	} `json:"stats"`
+}
+
+// LocalNodeID returns the ID of the Nomad client node the agent API is served by.
+// It fails when the agent is a server-only agent.
+func (c *Client) LocalNodeID(ctx context.Context) (string, error) {
+	var self agentSelf
+	if _, err := c.get(ctx, "/v1/agent/self", "", &self); err != nil {
+		return "", err
+	}
+	if self.Stats.Client.NodeID == "" {
+		return "", ErrNotClientMode
+	}
+	return self.Stats.Client.NodeID, nil
+}
+
+func (c *Client) get(ctx context.Context, path, namespace string, out interface{}) (uint64, error) {
+	u := c.baseURL.JoinPath(path)
+	if namespace != "" {
+		q := u.Query()
+		q.Set("namespace", namespace)
+		u.RawQuery = q.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+	if c.token != "" {
+		req.Header.Set(tokenHeader, c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("nomad request %s failed: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return 0, fmt.Errorf("nomad request %s returned status %d: %s", path, resp.StatusCode, string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return 0, fmt.Errorf("cannot decode nomad response for %s: %w", path, err)
+	}
+
+	index, _ := strconv.ParseUint(resp.Header.Get(indexHeader), 10, 64)
+	return index, nil
+}
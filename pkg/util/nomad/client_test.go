@@ -0,0 +1,144 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package nomad
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/services", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "*", r.URL.Query().Get("namespace"))
+		assert.Equal(t, "secret", r.Header.Get(tokenHeader))
+		w.Header().Set(indexHeader, "42")
+		w.Write([]byte(`[{"Namespace":"default","Services":[{"ServiceName":"redis","Tags":["cache"]}]}]`))
+	})
+	mux.HandleFunc("/v1/service/redis", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "default", r.URL.Query().Get("namespace"))
+		w.Write([]byte(`[
+			{"ID":"_nomad-task-1","ServiceName":"redis","Namespace":"default","NodeID":"node-1","Datacenter":"dc1","JobID":"cache","AllocID":"alloc-1","Address":"10.0.0.1","Port":6379},
+			{"ID":"_nomad-task-2","ServiceName":"redis","Namespace":"default","NodeID":"node-2","Datacenter":"dc1","JobID":"cache","AllocID":"alloc-2","Address":"10.0.0.2","Port":6380}
+		]`))
+	})
+	mux.HandleFunc("/v1/job/cache", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(`{
+			"ID":"cache","Namespace":"default","Meta":{"team":"storage"},
+			"TaskGroups":[{"Name":"cache","Meta":{"team":"db"},"Tasks":[{"Name":"redis","Meta":{"tier":"1"},"Services":[{"Name":"redis","Meta":{"tier":"0"}}]}]}]
+		}`))
+	})
+	mux.HandleFunc("/v1/agent/self", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(`{"stats":{"client":{"node_id":"node-1"}}}`))
+	})
+	mux.HandleFunc("/v1/node/node-1/allocations", func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.URL.Query().Get("namespace"))
+		w.Header().Set(indexHeader, "7")
+		w.Write([]byte(`[
+			{"ID":"alloc-1","Namespace":"default","ClientStatus":"running"},
+			{"ID":"alloc-0","Namespace":"default","ClientStatus":"complete"},
+			{"ID":"alloc-3","Namespace":"other","ClientStatus":"running"}
+		]`))
+	})
+	mux.HandleFunc("/v1/allocation/alloc-1/services", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "default", r.URL.Query().Get("namespace"))
+		w.Write([]byte(`[
+			{"ID":"_nomad-task-1","ServiceName":"redis","Namespace":"default","NodeID":"node-1","Datacenter":"dc1","JobID":"cache","AllocID":"alloc-1","Address":"10.0.0.1","Port":6379}
+		]`))
+	})
+	mux.HandleFunc("/v1/allocation/alloc-3/services", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(`[
+			{"ID":"_nomad-task-3","ServiceName":"web","Namespace":"other","NodeID":"node-1","Datacenter":"dc1","JobID":"web","AllocID":"alloc-3","Address":"10.0.0.1","Port":8080}
+		]`))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestClient(t *testing.T) {
+	srv := newTestServer(t)
+	client, err := NewClient(Config{URL: srv.URL, Token: "secret"})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	registrations, index, err := client.ListServiceRegistrations(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), index)
+	require.Len(t, registrations, 2)
+	assert.Equal(t, "nomad://default/cache/redis", registrations[0].ADIdentifier())
+	assert.Equal(t, "nomad://default/cache/redis/alloc-2/_nomad-task-2", registrations[1].EntityID())
+	assert.Equal(t, 6380, registrations[1].Port)
+
+	job, err := client.GetJob(ctx, "default", "cache")
+	require.NoError(t, err)
+	meta, found := job.ServiceMeta("redis")
+	require.True(t, found)
+	assert.Equal(t, map[string]string{"team": "db", "tier": "0"}, meta)
+	_, found = job.ServiceMeta("unknown")
+	assert.False(t, found)
+
+	nodeID, err := client.LocalNodeID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "node-1", nodeID)
+
+	_, err = client.GetJob(ctx, "default", "missing")
+	assert.Error(t, err)
+}
+
+func TestLocalNodeIDServerOnly(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/agent/self", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(`{"stats":{"nomad":{"server":"true"}}}`))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	client, err := NewClient(Config{URL: srv.URL})
+	require.NoError(t, err)
+	_, err = client.LocalNodeID(context.Background())
+	assert.ErrorIs(t, err, ErrNotClientMode)
+}
+
+func TestListNodeServiceRegistrations(t *testing.T) {
+	srv := newTestServer(t)
+	ctx := context.Background()
+
+	client, err := NewClient(Config{URL: srv.URL, Token: "secret"})
+	require.NoError(t, err)
+	registrations, index, err := client.ListNodeServiceRegistrations(ctx, "node-1")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), index)
+	require.Len(t, registrations, 2)
+	assert.Equal(t, "nomad://default/cache/redis/alloc-1/_nomad-task-1", registrations[0].EntityID())
+	assert.Equal(t, "nomad://other/web/web/alloc-3/_nomad-task-3", registrations[1].EntityID())
+
+	// allocations outside of the configured namespace are skipped
+	client, err = NewClient(Config{URL: srv.URL, Token: "secret", Namespace: "default"})
+	require.NoError(t, err)
+	registrations, _, err = client.ListNodeServiceRegistrations(ctx, "node-1")
+	require.NoError(t, err)
+	require.Len(t, registrations, 1)
+	assert.Equal(t, "alloc-1", registrations[0].AllocID)
+}
+
+func TestNewClientInvalidConfig(t *testing.T) {
+	_, err := NewClient(Config{})
+	assert.Error(t, err)
+
+	_, err = NewClient(Config{URL: "http://127.0.0.1:4646", CAFile: "/does/not/exist"})
+	assert.Error(t, err)
+}
+
+func TestIsNomadADIdentifier(t *testing.T) {
+	assert.True(t, IsNomadADIdentifier(ADIdentifier("default", "job", "svc")))
+	assert.False(t, IsNomadADIdentifier("docker://abcdef"))
+}
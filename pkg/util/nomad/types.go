@@ -0,0 +1,146 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package nomad
+
+import (
+	"fmt"
+	"strings"
+)
+
+// adIdentifierPrefix is the scheme used by Nomad AD identifiers
+const adIdentifierPrefix = "nomad://"
+
+// ServiceListStub is a single namespace entry returned by the /v1/services endpoint
+type ServiceListStub struct {
+	Namespace string        `json:"Namespace"`
+	Services  []ServiceStub `json:"Services"`
+}
+
+// ServiceStub is the summary of a service registered with the Nomad native service discovery
+type ServiceStub struct {
+	ServiceName string   `json:"ServiceName"`
+	Tags        []string `json:"Tags"`
+}
+
+// ServiceRegistration is a single instance of a service, i.e. one allocation
+// exposing a service on a given address and port.
+type ServiceRegistration struct {
+	ID          string   `json:"ID"`
+	ServiceName string   `json:"ServiceName"`
+	Namespace   string   `json:"Namespace"`
+	NodeID      string   `json:"NodeID"`
+	Datacenter  string   `json:"Datacenter"`
+	JobID       string   `json:"JobID"`
+	AllocID     string   `json:"AllocID"`
+	Tags        []string `json:"Tags"`
+	Address     string   `json:"Address"`
+	Port        int      `json:"Port"`
+	ModifyIndex uint64   `json:"ModifyIndex"`
+}
+
+// Allocation is the subset of a Nomad allocation used to list the services
+// running on a given client node
+type Allocation struct {
+	ID           string `json:"ID"`
+	Namespace    string `json:"Namespace"`
+	ClientStatus string `json:"ClientStatus"`
+}
+
+// IsTerminal returns whether the allocation is no longer running on its node
+func (a *Allocation) IsTerminal() bool {
+	switch a.ClientStatus {
+	case "complete", "failed", "lost":
+		return true
+	}
+	return false
+}
+
+// Job is the subset of a Nomad job specification used by autodiscovery
+type Job struct {
+	ID          string            `json:"ID"`
+	Namespace   string            `json:"Namespace"`
+	Meta        map[string]string `json:"Meta"`
+	TaskGroups  []TaskGroup       `json:"TaskGroups"`
+	ModifyIndex uint64            `json:"ModifyIndex"`
+}
+
+// TaskGroup is the subset of a Nomad task group used by autodiscovery
+type TaskGroup struct {
+	Name     string            `json:"Name"`
+	Meta     map[string]string `json:"Meta"`
+	Services []Service         `json:"Services"`
+	Tasks    []Task            `json:"Tasks"`
+}
+
+// Task is the subset of a Nomad task used by autodiscovery
+type Task struct {
+	Name     string            `json:"Name"`
+	Meta     map[string]string `json:"Meta"`
+	Services []Service         `json:"Services"`
+}
+
+// Service is a service block of a job specification
+type Service struct {
+	Name     string            `json:"Name"`
+	Provider string            `json:"Provider"`
+	Tags     []string          `json:"Tags"`
+	Meta     map[string]string `json:"Meta"`
+}
+
+// ServiceMeta returns the meta annotations applying to the given service of
+// the job. Job, group and task meta are merged in this order, so the most
+// specific level wins. The boolean is false when the job doesn't declare
+// the service.
+func (j *Job) ServiceMeta(serviceName string) (map[string]string, bool) {
+	for _, tg := range j.TaskGroups {
+		meta := mergeMeta(j.Meta, tg.Meta)
+		for _, svc := range tg.Services {
+			if svc.Name == serviceName {
+				return mergeMeta(meta, svc.Meta), true
+			}
+		}
+		for _, task := range tg.Tasks {
+			for _, svc := range task.Services {
+				if svc.Name == serviceName {
+					return mergeMeta(meta, task.Meta, svc.Meta), true
+				}
+			}
+		}
+	}
+	return nil, false
+}
+
+func mergeMeta(metas ...map[string]string) map[string]string {
+	merged := make(map[string]string)
+	for _, meta := range metas {
+		for k, v := range meta {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// ADIdentifier returns the AD identifier shared by every registration of a
+// service in a given job: nomad://<namespace>/<job>/<service>
+func ADIdentifier(namespace, jobID, serviceName string) string {
+	return fmt.Sprintf("%s%s/%s/%s", adIdentifierPrefix, namespace, jobID, serviceName)
+}
+
+// ADIdentifier returns the AD identifier of the registration, see ADIdentifier
+func (r *ServiceRegistration) ADIdentifier() string {
+	return ADIdentifier(r.Namespace, r.JobID, r.ServiceName)
+}
+
+// EntityID returns a unique identifier for the registration, scoped to its
+// allocation: nomad://<namespace>/<job>/<service>/<alloc id>/<registration id>
+func (r *ServiceRegistration) EntityID() string {
+	return r.ADIdentifier() + "/" + r.AllocID + "/" + r.ID
+}
+
+// IsNomadADIdentifier returns whether the given identifier has been built by ADIdentifier
+func IsNomadADIdentifier(id string) bool {
+	return strings.HasPrefix(id, adIdentifierPrefix)
+}
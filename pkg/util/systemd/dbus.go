@@ -14,13 +14,41 @@
 package systemd
 
 import (
+	"context"
 	"os"
 	"strconv"
 
 	"github.com/coreos/go-systemd/v22/dbus"
 	godbus "github.com/godbus/dbus/v5"
+
+	"github.com/DataDog/datadog-agent/pkg/config/env"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
 )
 
+// DefaultPrivateSocket is the path of the private socket of systemd
+const DefaultPrivateSocket = "/run/systemd/private"
+
+// Connect returns a connection to systemd, using the given private socket when
+// it is set. Otherwise, it uses the private socket of the host when the Agent
+// is containerized, and the system bus falling back to the private socket
+// when it isn't.
+func Connect(privateSocket string) (*dbus.Conn, error) {
+	if privateSocket != "" {
+		return NewSystemdConnection(privateSocket)
+	}
+
+	if env.IsContainerized() {
+		return NewSystemdConnection("/host" + DefaultPrivateSocket)
+	}
+
+	conn, err := dbus.NewSystemConnectionContext(context.Background())
+	if err != nil {
+		log.Debugf("Error getting new connection using system bus socket: %v", err)
+		return NewSystemdConnection(DefaultPrivateSocket)
+	}
+	return conn, nil
+}
+
 // NewSystemdConnection establishes a private, direct connection to systemd.
 // This can be used for communicating with systemd without a dbus daemon.
 // Callers should call Close() when done with the connection.